@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -16,11 +17,14 @@ import (
 	"go.mondoo.com/cnquery/upstream"
 	"go.mondoo.com/cnspec/internal/bundle"
 	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/cnspec/policy/permissions"
 	"go.mondoo.com/ranger-rpc"
 )
 
 func init() {
 	// policy init
+	policyInitCmd.Flags().String("platform", "", "Generate a policy skeleton for a platform instead of the example: "+strings.Join(policy.ScaffoldPlatforms(), ", "))
+	policyInitCmd.Flags().String("name", "My Policy", "Set the name of the generated policy skeleton")
 	policyBundlesCmd.AddCommand(policyInitCmd)
 
 	// validate
@@ -35,6 +39,11 @@ func init() {
 	policyPublishCmd.Flags().String("policy-version", "", "Override the version of each policy in the bundle.")
 	policyBundlesCmd.AddCommand(policyPublishCmd)
 
+	// permissions
+	policyPermissionsCmd.Flags().String("provider", "aws", "Set the cloud provider: aws, azure, gcp")
+	policyPermissionsCmd.Flags().String("role-name", "cnspec-scan", "Set the name of the generated role")
+	policyBundlesCmd.AddCommand(policyPermissionsCmd)
+
 	rootCmd.AddCommand(policyBundlesCmd)
 }
 
@@ -61,7 +70,20 @@ var policyInitCmd = &cobra.Command{
 			log.Fatal().Msgf("Policy '%s' already exists", name)
 		}
 
-		err = os.WriteFile(name, embedPolicyTemplate, 0o640)
+		data := embedPolicyTemplate
+		if platform, _ := cmd.Flags().GetString("platform"); platform != "" {
+			policyName, _ := cmd.Flags().GetString("name")
+			bundle, err := policy.ScaffoldBundle(policy.ScaffoldOptions{Name: policyName, Platform: platform})
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not generate policy")
+			}
+			data, err = bundle.ToYAML()
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not generate policy")
+			}
+		}
+
+		err = os.WriteFile(name, data, 0o640)
 		if err != nil {
 			log.Fatal().Err(err).Msgf("Could not write '%s'", name)
 		}
@@ -225,3 +247,39 @@ var policyPublishCmd = &cobra.Command{
 		log.Info().Msg("successfully added policies")
 	},
 }
+
+var policyPermissionsCmd = &cobra.Command{
+	Use:   "permissions [path]",
+	Short: "Generate a least-privilege cloud role for the queries of a policy bundle.",
+	Args:  cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlag("provider", cmd.Flags().Lookup("provider"))
+		viper.BindPFlag("role-name", cmd.Flags().Lookup("role-name"))
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		policyBundle, err := policy.BundleFromPaths(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not load policy bundle")
+		}
+
+		analysis, err := permissions.Analyze(context.Background(), policyBundle)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not analyze policy bundle")
+		}
+
+		provider := permissions.Provider(viper.GetString("provider"))
+		for mrn, msg := range analysis.Errors {
+			log.Warn().Str("query", mrn).Str("error", msg).Msg("could not analyze query")
+		}
+		if unknown := analysis.Unknown[provider]; len(unknown) != 0 {
+			log.Warn().Strs("resources", unknown).Msg("permissions of these resources are unknown, the role may be incomplete")
+		}
+
+		doc, err := analysis.Document(provider, viper.GetString("role-name"))
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not generate role")
+		}
+		os.Stdout.Write(doc)
+		fmt.Println()
+	},
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.mondoo.com/cnquery/logger"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
+	"go.mondoo.com/cnspec/policy/report/dashboard"
+)
+
+func init() {
+	dashboardCmd.Flags().String("address", "127.0.0.1", "address to listen on")
+	dashboardCmd.Flags().Uint("port", 8080, "port to listen on")
+	dashboardCmd.Flags().String("datalake", "", "path to the SQLite database with the results of previous scans")
+	rootCmd.AddCommand(dashboardCmd)
+}
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Serve a read-only web dashboard of the scans in a datalake",
+	Long: `Serve a read-only web dashboard of all assets and their reports that were
+scanned into a datalake, e.g. via 'cnspec scan --datalake scans.db'.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlag("port", cmd.Flags().Lookup("port"))
+		viper.BindPFlag("address", cmd.Flags().Lookup("address"))
+		viper.BindPFlag("datalake", cmd.Flags().Lookup("datalake"))
+
+		logger.StandardZerologLogger()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		path := viper.GetString("datalake")
+		if path == "" {
+			log.Fatal().Msg("the dashboard requires a datalake, please provide --datalake")
+		}
+		db, err := sqlite.Open(path)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not open datalake")
+		}
+		defer db.Close()
+
+		bind, err := getHttpBind(viper.GetString("address"), viper.GetInt("port"))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create HTTP bind")
+		}
+		uri, err := url.Parse(bind)
+		if err != nil {
+			log.Fatal().Err(err).Str("binding", bind).Msg("failed to parse binding")
+		}
+
+		log.Info().Str("url", bind).Msg("serve dashboard")
+		if err := bindHTTP(dashboard.NewHandler(db), uri); err != nil {
+			log.Fatal().Err(err).Msg("failed to bind http server")
+		}
+	},
+}
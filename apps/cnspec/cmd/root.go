@@ -10,6 +10,7 @@ import (
 
 	"github.com/muesli/termenv"
 	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 	"github.com/spf13/viper"
@@ -20,6 +21,7 @@ import (
 	"go.mondoo.com/cnquery/cli/theme/colors"
 	"go.mondoo.com/cnquery/logger"
 	"go.mondoo.com/cnspec"
+	"go.mondoo.com/cnspec/policy"
 	"go.mondoo.com/ranger-rpc"
 	"go.mondoo.com/ranger-rpc/plugins/scope"
 )
@@ -70,6 +72,7 @@ var rootCmd = &cobra.Command{
 	Long: landing() + "\n\n" + rootCmdDesc,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		initLogger(cmd)
+		initHashAlgorithm()
 	},
 }
 
@@ -97,6 +100,7 @@ func init() {
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
 	viper.BindEnv("features")
+	viper.BindEnv("hash_algorithm", "CNSPEC_HASH_ALGORITHM")
 
 	config.Init(rootCmd)
 }
@@ -117,6 +121,18 @@ func initLogger(cmd *cobra.Command) {
 	logger.Set(level)
 }
 
+// initHashAlgorithm selects the algorithm of all policy checksums from the
+// config, e.g. `hash_algorithm: sha256` for FIPS environments
+func initHashAlgorithm() {
+	alg := viper.GetString("hash_algorithm")
+	if alg == "" {
+		return
+	}
+	if err := policy.SetHashAlgorithm(policy.HashAlgorithm(alg)); err != nil {
+		log.Fatal().Err(err).Msg("invalid hash algorithm")
+	}
+}
+
 var reMdName = regexp.MustCompile(`/([^/]+)\.md$`)
 
 func GenerateMarkdown(dir string) error {
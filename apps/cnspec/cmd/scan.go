@@ -3,9 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -28,6 +30,9 @@ import (
 	"go.mondoo.com/cnquery/upstream"
 	cnspec_config "go.mondoo.com/cnspec/apps/cnspec/cmd/config"
 	"go.mondoo.com/cnspec/cli/reporter"
+	"go.mondoo.com/cnspec/internal/archive"
+	"go.mondoo.com/cnspec/internal/inventory"
+	"go.mondoo.com/cnspec/internal/recordings"
 	"go.mondoo.com/cnspec/policy"
 	"go.mondoo.com/cnspec/policy/scan"
 	"go.mondoo.com/ranger-rpc"
@@ -91,6 +96,10 @@ This scan uses an existing Ansible inventory:
 
     $ ansible-inventory -i hosts.ini --list | cnspec scan --inventory-ansible
 
+This scan uses all machines and cloud accounts that Terraform manages:
+
+    $ cnspec scan --inventory-file terraform.tfstate --inventory-terraform
+
 To learn more, read https://mondoo.com/docs/.
 	`,
 	Docs: builder.CommandsDocs{
@@ -272,11 +281,12 @@ This example connects to Microsoft 365 using the PKCS #12 formatted certificate:
 		cmd.Flags().String("inventory-file", "", "Set the path to the inventory file.")
 		cmd.Flags().Bool("inventory-ansible", false, "Set the inventory format to Ansible.")
 		cmd.Flags().Bool("inventory-domainlist", false, "Set the inventory format to domain list.")
+		cmd.Flags().Bool("inventory-terraform", false, "Set the inventory format to a Terraform state file or the JSON of a plan.")
 
 		// policies & incognito mode
 		cmd.Flags().Bool("incognito", false, "Run in incognito mode. Do not report scan results to the Mondoo platform.")
 		cmd.Flags().StringSlice("policy", nil, "Lists policies to execute. This requires incognito mode. You can pass multiple policies using --policy POLICY")
-		cmd.Flags().StringSliceP("policy-bundle", "f", nil, "Path to local policy bundle file.")
+		cmd.Flags().StringSliceP("policy-bundle", "f", nil, "Path to local policy bundle file, OCI reference, e.g. oci://ghcr.io/org/policies:v1, or git reference, e.g. git+https://github.com/org/policies.git@v1#policies.")
 		// flag completion command
 		cmd.RegisterFlagCompletionFunc("policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			return getPoliciesForCompletion(), cobra.ShellCompDirectiveDefault
@@ -300,8 +310,25 @@ This example connects to Microsoft 365 using the PKCS #12 formatted certificate:
 		cmd.Flags().Bool("insecure", false, "Disable TLS/SSL checks or SSH hostkey config.")
 		cmd.Flags().Bool("sudo", false, "Elevate privileges with sudo.")
 		cmd.Flags().Int("score-threshold", 0, "If any score falls below the threshold, exit 1.")
+		cmd.Flags().String("severity-bands", "", "Map scores to severity bands by their upper bounds for critical, high, medium and low, e.g. 10,30,60,100.")
 		cmd.Flags().Bool("record", false, "Record all backend calls.")
 		cmd.Flags().MarkHidden("record")
+		cmd.Flags().String("record-store", "", "Keep recordings in this directory or S3 location (s3://bucket/prefix).")
+		cmd.Flags().MarkHidden("record-store")
+		cmd.Flags().String("resolver-snapshot-dir", "", "Write the intermediate state of failed policy resolutions to this directory.")
+		cmd.Flags().MarkHidden("resolver-snapshot-dir")
+		cmd.Flags().String("archive", "", "Archive compressed reports of the scan run in this S3 or GCS location (s3://bucket/prefix or gs://bucket/prefix).")
+		cmd.Flags().String("archive-layout", archive.DefaultLayout, "Key layout of report archives. Supports {year}, {month}, {day}, {date}, {run}, {batch} and {asset}.")
+		cmd.Flags().Int("archive-batch-size", archive.DefaultBatchSize, "Archive the reports of up to this many assets per object.")
+		cmd.Flags().Bool("audit", false, "Record all commands that are run on assets and the checks that ran them in the report.")
+		cmd.Flags().Int("query-concurrency", 1, "Execute up to this many independent queries of an asset in parallel.")
+		cmd.Flags().Int("max-concurrency", 1, "Scan up to this many assets in parallel.")
+		cmd.Flags().Bool("memoize-results", false, "Reuse results of deterministic queries across assets with identical platforms.")
+		cmd.Flags().String("datalake", "", "Persist policies, scores and data in a SQLite database at this path.")
+		cmd.Flags().Bool("resume", false, "Skip assets that were completely scanned before into the datalake and whose policies haven't changed.")
+		cmd.Flags().Bool("incremental", false, "Only execute the queries that changed since the last complete scan of an asset into the datalake.")
+		cmd.Flags().Duration("incremental-max-age", 0, "Execute all queries again once the last complete scan is older than this, e.g. 24h.")
+		cmd.Flags().Int("reachability-checks", 0, "Resolve and probe network assets before connecting to them, with at most this many probes per second.")
 
 		// v6 should make detect-cicd and category flag public, default for "detect-cicd" should switch to true
 		cmd.Flags().Bool("detect-cicd", true, "Try to detect CI/CD environments and, if successful, set the asset category to 'cicd'.")
@@ -319,6 +346,7 @@ This example connects to Microsoft 365 using the PKCS #12 formatted certificate:
 		viper.BindPFlag("inventory-file", cmd.Flags().Lookup("inventory-file"))
 		viper.BindPFlag("inventory-ansible", cmd.Flags().Lookup("inventory-ansible"))
 		viper.BindPFlag("inventory-domainlist", cmd.Flags().Lookup("inventory-domainlist"))
+		viper.BindPFlag("inventory-terraform", cmd.Flags().Lookup("inventory-terraform"))
 		viper.BindPFlag("policy-bundle", cmd.Flags().Lookup("policy-bundle"))
 		viper.BindPFlag("id-detector", cmd.Flags().Lookup("id-detector"))
 		viper.BindPFlag("detect-cicd", cmd.Flags().Lookup("detect-cicd"))
@@ -331,6 +359,21 @@ This example connects to Microsoft 365 using the PKCS #12 formatted certificate:
 		viper.BindPFlag("sudo.active", cmd.Flags().Lookup("sudo"))
 
 		viper.BindPFlag("score-threshold", cmd.Flags().Lookup("score-threshold"))
+		viper.BindPFlag("severity-bands", cmd.Flags().Lookup("severity-bands"))
+		viper.BindPFlag("memoize-results", cmd.Flags().Lookup("memoize-results"))
+		viper.BindPFlag("audit", cmd.Flags().Lookup("audit"))
+		viper.BindPFlag("query-concurrency", cmd.Flags().Lookup("query-concurrency"))
+		viper.BindPFlag("max-concurrency", cmd.Flags().Lookup("max-concurrency"))
+		viper.BindPFlag("datalake", cmd.Flags().Lookup("datalake"))
+		viper.BindPFlag("resume", cmd.Flags().Lookup("resume"))
+		viper.BindPFlag("incremental", cmd.Flags().Lookup("incremental"))
+		viper.BindPFlag("incremental-max-age", cmd.Flags().Lookup("incremental-max-age"))
+		viper.BindPFlag("reachability-checks", cmd.Flags().Lookup("reachability-checks"))
+		viper.BindPFlag("record-store", cmd.Flags().Lookup("record-store"))
+		viper.BindPFlag("resolver-snapshot-dir", cmd.Flags().Lookup("resolver-snapshot-dir"))
+		viper.BindPFlag("archive", cmd.Flags().Lookup("archive"))
+		viper.BindPFlag("archive-layout", cmd.Flags().Lookup("archive-layout"))
+		viper.BindPFlag("archive-batch-size", cmd.Flags().Lookup("archive-batch-size"))
 
 		viper.BindPFlag("output", cmd.Flags().Lookup("output"))
 		// the logic is that noPager takes precedence over pager if both are sent
@@ -358,6 +401,13 @@ This example connects to Microsoft 365 using the PKCS #12 formatted certificate:
 			log.Fatal().Err(err).Msg("failed to resolve policies")
 		}
 
+		// open the archive before scanning, so that misconfigurations
+		// don't surface only after a long scan
+		archiver, err := conf.openArchive()
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to open report archive")
+		}
+
 		report, err := RunScan(conf)
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to run scan")
@@ -365,6 +415,7 @@ This example connects to Microsoft 365 using the PKCS #12 formatted certificate:
 
 		logger.DebugDumpJSON("report", report)
 		printReports(report, conf, cmd)
+		archiveReports(archiver, report)
 
 		// if we had asset errors, we return a non-zero exit code
 		// asset errors are only connection issues
@@ -403,10 +454,37 @@ type scanConfig struct {
 	PolicyNames []string
 	Props       map[string]string
 	Bundle      *policy.Bundle
+	// Sources are the locations of all policies and queries in PolicyPaths
+	Sources policy.SourceMap
 
 	IsIncognito    bool
 	ScoreThreshold int
-	DoRecord       bool
+	// SeverityBands override the bands of the policies (optional)
+	SeverityBands *policy.SeverityBands
+	DoRecord      bool
+	// RecordStore is the location of the recordings store (optional)
+	RecordStore    string
+	MemoizeResults bool
+	// Audit records all commands that are run on assets
+	Audit        bool
+	DataLakePath string
+	Resume       bool
+	// Incremental only executes queries that changed since the last
+	// complete scan, until it is older than IncrementalMaxAge
+	Incremental       bool
+	IncrementalMaxAge time.Duration
+	// ReachabilityChecks is the max number of probes per second, 0 disables them
+	ReachabilityChecks int
+	// QueryConcurrency is the number of queries of an asset that run in parallel
+	QueryConcurrency int
+	// MaxConcurrency is the number of assets that are scanned in parallel
+	MaxConcurrency int
+	// ResolverSnapshotDir keeps snapshots of failed resolutions (optional)
+	ResolverSnapshotDir string
+	// Archive is the S3 or GCS location for report archives (optional)
+	Archive          string
+	ArchiveLayout    string
+	ArchiveBatchSize int
 
 	UpstreamConfig *resources.UpstreamConfig
 }
@@ -429,13 +507,28 @@ func getCobraScanConfig(cmd *cobra.Command, args []string, provider providers.Pr
 	}
 
 	conf := scanConfig{
-		Features:       opts.GetFeatures(),
-		IsIncognito:    viper.GetBool("incognito"),
-		DoRecord:       viper.GetBool("record"),
-		PolicyPaths:    viper.GetStringSlice("policy-bundle"),
-		PolicyNames:    viper.GetStringSlice("policies"),
-		ScoreThreshold: viper.GetInt("score-threshold"),
-		Props:          props,
+		Features:           opts.GetFeatures(),
+		IsIncognito:        viper.GetBool("incognito"),
+		DoRecord:           viper.GetBool("record"),
+		RecordStore:        viper.GetString("record-store"),
+		PolicyPaths:        viper.GetStringSlice("policy-bundle"),
+		PolicyNames:        viper.GetStringSlice("policies"),
+		ScoreThreshold:     viper.GetInt("score-threshold"),
+		MemoizeResults:     viper.GetBool("memoize-results"),
+		Audit:              viper.GetBool("audit"),
+		DataLakePath:       viper.GetString("datalake"),
+		Resume:             viper.GetBool("resume"),
+		Incremental:        viper.GetBool("incremental"),
+		IncrementalMaxAge:  viper.GetDuration("incremental-max-age"),
+		ReachabilityChecks: viper.GetInt("reachability-checks"),
+		QueryConcurrency:   viper.GetInt("query-concurrency"),
+		MaxConcurrency:     viper.GetInt("max-concurrency"),
+		Props:              props,
+
+		ResolverSnapshotDir: viper.GetString("resolver-snapshot-dir"),
+		Archive:             viper.GetString("archive"),
+		ArchiveLayout:       viper.GetString("archive-layout"),
+		ArchiveBatchSize:    viper.GetInt("archive-batch-size"),
 	}
 
 	// if users want to get more information on available output options,
@@ -452,6 +545,13 @@ func getCobraScanConfig(cmd *cobra.Command, args []string, provider providers.Pr
 	}
 	conf.Output = output
 
+	if bands := viper.GetString("severity-bands"); bands != "" {
+		conf.SeverityBands, err = policy.ParseSeverityBands(bands)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid severity bands")
+		}
+	}
+
 	// check if the user used --password without a value
 	askPass, err := cmd.Flags().GetBool("ask-pass")
 	if err == nil && askPass {
@@ -463,8 +563,12 @@ func getCobraScanConfig(cmd *cobra.Command, args []string, provider providers.Pr
 	}
 
 	// determine the scan config from pipe or args
-	flagAsset := builder.ParseTargetAsset(cmd, args, provider, assetType)
-	conf.Inventory, err = inventoryloader.ParseOrUse(flagAsset, viper.GetBool("insecure"))
+	if viper.GetBool("inventory-terraform") {
+		conf.Inventory, err = loadTerraformInventory(viper.GetString("inventory-file"), viper.GetBool("insecure"))
+	} else {
+		flagAsset := builder.ParseTargetAsset(cmd, args, provider, assetType)
+		conf.Inventory, err = inventoryloader.ParseOrUse(flagAsset, viper.GetBool("insecure"))
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not load configuration")
 	}
@@ -524,23 +628,40 @@ func getCobraScanConfig(cmd *cobra.Command, args []string, provider providers.Pr
 	return &conf, nil
 }
 
+// loadTerraformInventory synthesizes the inventory from a Terraform state
+// or plan file, or from the JSON that is piped in, e.g. from
+// `terraform show -json`
+func loadTerraformInventory(path string, insecure bool) (*v1.Inventory, error) {
+	opts := inventory.TerraformOptions{Insecure: insecure}
+	if path != "" {
+		return inventory.TerraformInventoryFromFile(path, opts)
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read terraform json from stdin")
+	}
+	return inventory.TerraformInventory(data, opts)
+}
+
 func (c *scanConfig) loadPolicies() error {
 	if c.IsIncognito {
 		if len(c.PolicyPaths) == 0 {
 			return nil
 		}
 
-		bundle, err := policy.BundleFromPaths(c.PolicyPaths...)
+		bundle, sources, err := policy.BundleFromPathsOrOCI(context.Background(), policy.OCIOptions{}, c.PolicyPaths...)
 		if err != nil {
 			return err
 		}
 
-		_, err = bundle.Compile(context.Background(), nil)
+		_, err = bundle.Compile(policy.WithSourceMap(context.Background(), sources), nil)
 		if err != nil {
 			return errors.Wrap(err, "failed to compile bundle")
 		}
 
 		c.Bundle = bundle
+		c.Sources = sources
 		return nil
 	}
 
@@ -555,6 +676,59 @@ func RunScan(config *scanConfig, opts ...scan.ScannerOption) (*policy.ReportColl
 		scannerOpts = append(scannerOpts, scan.WithUpstream(config.UpstreamConfig.ApiEndpoint, config.UpstreamConfig.SpaceMrn), scan.WithPlugins(config.UpstreamConfig.Plugins))
 	}
 
+	if config.MemoizeResults {
+		scannerOpts = append(scannerOpts, scan.WithResultMemoization())
+	}
+
+	if config.QueryConcurrency > 1 {
+		scannerOpts = append(scannerOpts, scan.WithQueryConcurrency(config.QueryConcurrency))
+	}
+
+	if config.MaxConcurrency > 1 {
+		scannerOpts = append(scannerOpts, scan.WithMaxConcurrency(config.MaxConcurrency))
+	}
+
+	if config.Audit {
+		scannerOpts = append(scannerOpts, scan.WithAuditTrail())
+	}
+
+	if config.DataLakePath != "" {
+		scannerOpts = append(scannerOpts, scan.WithDataLake(config.DataLakePath))
+	}
+
+	if config.Resume {
+		if config.DataLakePath == "" {
+			return nil, errors.New("resuming a scan requires a datalake, please provide --datalake")
+		}
+		scannerOpts = append(scannerOpts, scan.WithResume())
+	}
+
+	if config.Incremental {
+		if config.DataLakePath == "" {
+			return nil, errors.New("incremental scans require a datalake, please provide --datalake")
+		}
+		scannerOpts = append(scannerOpts, scan.WithIncrementalRescan(config.IncrementalMaxAge))
+	}
+
+	if config.ReachabilityChecks > 0 {
+		scannerOpts = append(scannerOpts, scan.WithReachabilityChecks(config.ReachabilityChecks, 5*time.Second))
+	}
+
+	if config.RecordStore != "" {
+		if !config.DoRecord {
+			return nil, errors.New("storing recordings requires recording, please provide --record")
+		}
+		store, err := recordings.Open(context.Background(), config.RecordStore)
+		if err != nil {
+			return nil, err
+		}
+		scannerOpts = append(scannerOpts, scan.WithRecordingStore(store))
+	}
+
+	if config.ResolverSnapshotDir != "" {
+		scannerOpts = append(scannerOpts, scan.WithResolverSnapshots(config.ResolverSnapshotDir))
+	}
+
 	// show warning to the user of the policy filter container a bundle file name
 	for i := range config.PolicyNames {
 		entry := config.PolicyNames[i]
@@ -565,6 +739,7 @@ func RunScan(config *scanConfig, opts ...scan.ScannerOption) (*policy.ReportColl
 
 	scanner := scan.NewLocalScanner(scannerOpts...)
 	ctx := cnquery.SetFeatures(context.Background(), config.Features)
+	ctx = policy.WithSourceMap(ctx, config.Sources)
 
 	if config.IsIncognito {
 		res, err := scanner.RunIncognito(
@@ -599,6 +774,33 @@ func RunScan(config *scanConfig, opts ...scan.ScannerOption) (*policy.ReportColl
 	return res.GetFull(), nil
 }
 
+// openArchive returns the archiver for the reports of this scan, nil if
+// reports aren't archived
+func (c *scanConfig) openArchive() (*archive.Archiver, error) {
+	if c.Archive == "" {
+		return nil, nil
+	}
+	bucket, err := archive.Open(context.Background(), c.Archive)
+	if err != nil {
+		return nil, err
+	}
+	return archive.New(bucket, archive.Options{
+		Layout:    c.ArchiveLayout,
+		BatchSize: c.ArchiveBatchSize,
+	})
+}
+
+func archiveReports(archiver *archive.Archiver, report *policy.ReportCollection) {
+	if archiver == nil {
+		return
+	}
+	keys, err := archiver.Archive(context.Background(), archive.Run{}, report)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to archive reports")
+	}
+	log.Info().Int("archives", len(keys)).Msg("archived reports")
+}
+
 func printReports(report *policy.ReportCollection, conf *scanConfig, cmd *cobra.Command) {
 	// print the output using the specified output format
 	r, err := reporter.New(conf.Output)
@@ -610,6 +812,10 @@ func printReports(report *policy.ReportCollection, conf *scanConfig, cmd *cobra.
 	r.Pager, _ = cmd.Flags().GetString("pager")
 	r.IsIncognito = conf.IsIncognito
 
+	if conf.SeverityBands != nil && report.Bundle != nil {
+		report.Bundle.SetSeverityBands(conf.SeverityBands)
+	}
+
 	if err = r.Print(report, os.Stdout); err != nil {
 		log.Fatal().Err(err).Msg("failed to print")
 	}
@@ -20,7 +20,10 @@ import (
 	"go.mondoo.com/cnquery/logger"
 	"go.mondoo.com/cnquery/resources"
 	"go.mondoo.com/cnquery/upstream"
+	"go.mondoo.com/cnspec"
 	cnspec_config "go.mondoo.com/cnspec/apps/cnspec/cmd/config"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
+	"go.mondoo.com/cnspec/policy"
 	"go.mondoo.com/cnspec/policy/scan"
 	"go.mondoo.com/ranger-rpc"
 )
@@ -28,6 +31,7 @@ import (
 func init() {
 	serveApiCmd.Flags().String("address", "127.0.0.1", "address to listen on")
 	serveApiCmd.Flags().Uint("port", 8080, "port to listen on")
+	serveApiCmd.Flags().String("datalake", "", "path to a SQLite database that persists scheduled scans and results, including those that couldn't be sent upstream yet")
 	rootCmd.AddCommand(serveApiCmd)
 }
 
@@ -38,6 +42,7 @@ var serveApiCmd = &cobra.Command{
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlag("port", cmd.Flags().Lookup("port"))
 		viper.BindPFlag("address", cmd.Flags().Lookup("address"))
+		viper.BindPFlag("datalake", cmd.Flags().Lookup("datalake"))
 
 		logger.StandardZerologLogger()
 
@@ -77,10 +82,24 @@ var serveApiCmd = &cobra.Command{
 			Plugins:     plugins,
 		}
 
-		scanner := scan.NewLocalScanner(scan.WithUpstream(upstreamConfig.ApiEndpoint, upstreamConfig.SpaceMrn), scan.WithPlugins(plugins), scan.DisableProgressBar())
+		scannerOpts := []scan.ScannerOption{scan.WithUpstream(upstreamConfig.ApiEndpoint, upstreamConfig.SpaceMrn), scan.WithPlugins(plugins), scan.DisableProgressBar()}
+		if path := viper.GetString("datalake"); path != "" {
+			// results are kept while upstream is unreachable and sent once it is back
+			scannerOpts = append(scannerOpts, scan.WithDataLake(path), scan.WithOfflineUploads(policy.UploadSyncOptions{}))
+		}
+		scanner := scan.NewLocalScanner(scannerOpts...)
+		defer scanner.CloseOfflineUploads()
 		if err := scanner.EnableQueue(); err != nil {
 			log.Fatal().Err(err).Msg("could not enable scan queue")
 		}
+		if viper.GetString("datalake") != "" {
+			// assets are re-resolved after upgrades and bundle updates, so that
+			// their next scan doesn't wait for it
+			if err := scanner.StartReResolver(context.Background(), cnspec.Version, opts.GetFeatures()); err != nil {
+				log.Error().Err(err).Msg("could not start re-resolving policies in the background")
+			}
+			defer scanner.StopReResolver()
+		}
 
 		addressOpt := viper.GetString("address")
 		portOpt := viper.GetInt("port")
@@ -99,6 +118,16 @@ var serveApiCmd = &cobra.Command{
 		log.Info().Str("url", "/Scan/").Msg("enable Scanner API")
 		mux.Handle("/Scan/", server)
 
+		if path := viper.GetString("datalake"); path != "" {
+			db, err := sqlite.Open(path)
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not open datalake")
+			}
+			defer db.Close()
+			log.Info().Str("url", "/Reports/Stream").Msg("enable report streaming")
+			mux.Handle("/Reports/Stream", policy.NewReportStreamHandler(db))
+		}
+
 		if err := bindHTTP(mux, uri); err != nil {
 			log.Fatal().Err(err).Msg("failed to bind http server")
 		}
@@ -0,0 +1,51 @@
+package reporter
+
+import (
+	"sort"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// ComplianceSummary is the coverage of compliance frameworks by the scanned
+// assets, see policy.ComplianceTagPrefix
+type ComplianceSummary struct {
+	// Assets are sorted by their MRN
+	Assets []*AssetCompliance `json:"assets"`
+}
+
+// AssetCompliance is the compliance of one asset
+type AssetCompliance struct {
+	Mrn  string `json:"mrn"`
+	Name string `json:"name,omitempty"`
+	// Frameworks are sorted by name
+	Frameworks []*policy.FrameworkScore `json:"frameworks"`
+	// Controls are sorted by framework and control
+	Controls []*policy.ControlScore `json:"controls"`
+}
+
+// ReportCollectionToCompliance aggregates the scores of all assets per
+// control and framework, using the mappings of the collection's bundle
+func ReportCollectionToCompliance(data *policy.ReportCollection) *ComplianceSummary {
+	res := &ComplianceSummary{Assets: []*AssetCompliance{}}
+	if data == nil {
+		return res
+	}
+
+	mapping := data.Bundle.ComplianceMapping()
+	for mrn, report := range data.Reports {
+		controls := report.ControlScores(mapping)
+		asset := &AssetCompliance{
+			Mrn:        mrn,
+			Frameworks: policy.FrameworkScores(controls),
+			Controls:   controls,
+		}
+		if a, ok := data.Assets[mrn]; ok && a != nil {
+			asset.Name = a.Name
+		}
+		res.Assets = append(res.Assets, asset)
+	}
+	sort.Slice(res.Assets, func(i, j int) bool {
+		return res.Assets[i].Mrn < res.Assets[j].Mrn
+	})
+	return res
+}
@@ -0,0 +1,35 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestReporterCompliance(t *testing.T) {
+	data := testReportCollectionV1()
+	data.Bundle.Queries[0].Tags = map[string]string{policy.ComplianceTagPrefix + "cis": "1.1"}
+	data.Bundle.Queries[1].Tags = map[string]string{policy.ComplianceTagPrefix + "cis": "1.2"}
+
+	r, err := New("compliance")
+	require.NoError(t, err)
+	buf := bytes.Buffer{}
+	require.NoError(t, r.Print(data, &buf))
+
+	var summary ComplianceSummary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &summary))
+	require.Len(t, summary.Assets, 1)
+	asset := summary.Assets[0]
+	assert.Equal(t, "debian", asset.Name)
+	require.Len(t, asset.Frameworks, 1)
+	assert.Equal(t, "cis", asset.Frameworks[0].Framework)
+	assert.Equal(t, 2, asset.Frameworks[0].Controls)
+	assert.Equal(t, 1, asset.Frameworks[0].Passed)
+	assert.Equal(t, 1, asset.Frameworks[0].Errors)
+	require.Len(t, asset.Controls, 2)
+	assert.Equal(t, "1.1", asset.Controls[0].Control)
+}
@@ -16,18 +16,16 @@ func printScore(score *policy.Score, mrn string, out shared.OutputHelper, prefix
 		return false
 	}
 
-	status := score.TypeLabel()
-	if score.Type == policy.ScoreType_Result {
-		if score.Value == 100 {
-			status = "pass"
-		} else {
-			status = "fail"
-		}
+	status := scoreStatus(score)
+
+	var informational string
+	if score.Informational {
+		informational = ",\"informational\":true"
 	}
 
 	out.WriteString(prefix + llx.PrettyPrintString(mrn) +
 		":{\"score\":" + strconv.FormatUint(uint64(score.Value), 10) + "," +
-		"\"status\":\"" + status + "\"}")
+		"\"status\":\"" + status + "\"" + informational + "}")
 	return true
 }
 
@@ -0,0 +1,392 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	cr "go.mondoo.com/cnquery/cli/reporter"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/shared"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// JSONSchemaV1 is the schema version of JSONReportV1
+const JSONSchemaV1 = "v1"
+
+// JSONReportV1 is the versioned JSON report. Unlike the internal report
+// protos, its structure is stable: fields of the v1 schema are never removed,
+// renamed, or changed in type. New fields may be added, so parsers should
+// ignore fields they don't know. Breaking changes require a new schema version.
+type JSONReportV1 struct {
+	// Schema is always set to JSONSchemaV1
+	Schema string `json:"schema"`
+	// Assets are sorted by their MRN
+	Assets []*JSONAssetV1 `json:"assets"`
+	// Errors of assets that could not be scanned, indexed by asset MRN
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// JSONAssetV1 is the report of one asset
+type JSONAssetV1 struct {
+	Mrn      string `json:"mrn"`
+	Name     string `json:"name"`
+	Platform string `json:"platform,omitempty"`
+	// Cloud is the cloud context of the asset, if it runs in a cloud
+	Cloud *JSONCloudV1 `json:"cloud,omitempty"`
+	// Discovery links the asset to the inventory entry it was discovered from
+	Discovery *JSONDiscoveryV1 `json:"discovery,omitempty"`
+	// Weighting is applied to the scores of the asset by its criticality
+	Weighting *JSONWeightingV1 `json:"weighting,omitempty"`
+	// Audit lists all commands that the scan ran on the asset, if audited
+	Audit []*JSONAuditEntryV1 `json:"audit,omitempty"`
+	// Score is the overall score of the asset
+	Score *JSONScoreV1 `json:"score,omitempty"`
+	// Checks are sorted by their MRN
+	Checks []*JSONCheckV1 `json:"checks"`
+	// Data contains the results of data queries, indexed by query MRN
+	Data map[string]json.RawMessage `json:"data,omitempty"`
+}
+
+// JSONCheckV1 is the result of one check
+type JSONCheckV1 struct {
+	Mrn    string       `json:"mrn"`
+	Title  string       `json:"title,omitempty"`
+	CodeID string       `json:"code_id"`
+	Score  *JSONScoreV1 `json:"score"`
+	// Impacts explain the effective impact of the check in each policy
+	// that contains it
+	Impacts []*JSONImpactProvenanceV1 `json:"impacts,omitempty"`
+}
+
+// JSONCloudV1 is the cloud context of an asset
+type JSONCloudV1 struct {
+	// Provider is the cloud provider, e.g. aws, gcp or azure
+	Provider  string            `json:"provider"`
+	AccountID string            `json:"account_id,omitempty"`
+	Region    string            `json:"region,omitempty"`
+	ImageID   string            `json:"image_id,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// JSONDiscoveryV1 links an asset to the inventory entry it was discovered
+// from
+type JSONDiscoveryV1 struct {
+	// CorrelationID is shared by all assets that were discovered from the
+	// same inventory entry
+	CorrelationID string `json:"correlation_id"`
+	// Root is the name of the inventory entry
+	Root string `json:"root"`
+	// Credential identifies the credential of the inventory entry, never the
+	// secret itself
+	Credential string `json:"credential,omitempty"`
+}
+
+// JSONWeightingV1 is the weighting of the scores of an asset by its
+// criticality
+type JSONWeightingV1 struct {
+	// Criticality is one of: low, medium, high, critical
+	Criticality string `json:"criticality"`
+	// Factor scales the impact of all checks of the asset
+	Factor float64 `json:"factor"`
+}
+
+// JSONAuditEntryV1 is a call that the scan issued on an asset
+type JSONAuditEntryV1 struct {
+	// Kind is the kind of call, e.g. command
+	Kind string `json:"kind"`
+	Call string `json:"call"`
+	// CodeID is the query that issued the call, if any
+	CodeID    string    `json:"code_id,omitempty"`
+	CheckMrns []string  `json:"check_mrns,omitempty"`
+	Time      time.Time `json:"time"`
+	// Duration of the call in nanoseconds
+	Duration   int64  `json:"duration"`
+	ExitStatus int    `json:"exit_status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// JSONImpactV1 is the impact of a check or policy
+type JSONImpactV1 struct {
+	// Value is between 0 and 100
+	Value  int32 `json:"value,omitempty"`
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// JSONImpactProvenanceV1 explains the effective impact of a check in one of
+// the policies that contain it
+type JSONImpactProvenanceV1 struct {
+	// Policy is the policy that added the check
+	Policy   string `json:"policy"`
+	ID       string `json:"id"`
+	IsPolicy bool   `json:"is_policy,omitempty"`
+	// Impact is the effective impact that is used for scoring
+	Impact *JSONImpactV1 `json:"impact,omitempty"`
+	// Declared is the impact that the check declares
+	Declared *JSONImpactV1 `json:"declared,omitempty"`
+	// ModifiedBy is the policy whose modification of the impact took effect
+	ModifiedBy string `json:"modified_by,omitempty"`
+	Priority   int    `json:"priority,omitempty"`
+	// Overridden lists the policies whose modifications were discarded
+	Overridden []string `json:"overridden,omitempty"`
+	// Rule is one of: priority, depth, mrn, activation
+	Rule          string `json:"rule,omitempty"`
+	Informational bool   `json:"informational,omitempty"`
+	// Criticality is the factor by which the asset's criticality scaled the
+	// impact
+	Criticality float64 `json:"criticality,omitempty"`
+}
+
+// JSONScoreV1 is a score
+type JSONScoreV1 struct {
+	// Status is one of: pass, fail, error, skip, unknown, unscored
+	Status string `json:"status"`
+	// Value is between 0 and 100
+	Value  uint32 `json:"value"`
+	Weight uint32 `json:"weight"`
+	// Completion of the score in percent
+	Completion uint32 `json:"completion"`
+	Message    string `json:"message,omitempty"`
+	// Band is the severity band of results, one of: critical, high,
+	// medium, low, pass
+	Band string `json:"band,omitempty"`
+	// Informational scores are reported, but don't count towards the score
+	// of the asset
+	Informational bool `json:"informational,omitempty"`
+}
+
+// scoreStatus returns pass or fail for results and the type for all others
+func scoreStatus(score *policy.Score) string {
+	if score.Type != policy.ScoreType_Result {
+		return score.TypeLabel()
+	}
+	if score.Value == 100 {
+		return "pass"
+	}
+	return "fail"
+}
+
+// ConvertScoreV1 converts a score into the v1 schema with the default
+// severity bands
+func ConvertScoreV1(score *policy.Score) *JSONScoreV1 {
+	return convertScoreV1(score, &policy.DefaultSeverityBands)
+}
+
+func convertScoreV1(score *policy.Score, bands *policy.SeverityBands) *JSONScoreV1 {
+	if score == nil {
+		return nil
+	}
+	return &JSONScoreV1{
+		Status:        scoreStatus(score),
+		Value:         score.Value,
+		Weight:        score.Weight,
+		Completion:    score.ScoreCompletion,
+		Message:       score.MessageLine(),
+		Band:          string(bands.Band(score)),
+		Informational: score.Informational,
+	}
+}
+
+// ConvertReportV1 converts the report of one asset into the v1 schema.
+// Checks and data queries are looked up in the resolved policy and bundle.
+func ConvertReportV1(report *policy.Report, asset *policy.Asset, resolved *policy.ResolvedPolicy, bundle *policy.Bundle) (*JSONAssetV1, error) {
+	if report == nil {
+		return nil, errors.New("cannot convert empty report")
+	}
+	if resolved == nil || resolved.ExecutionJob == nil || resolved.CollectorJob == nil {
+		return nil, errors.New("cannot find resolved policy for report of " + report.EntityMrn)
+	}
+
+	bands := bundle.SeverityBands()
+	res := &JSONAssetV1{
+		Mrn:    report.EntityMrn,
+		Score:  convertScoreV1(report.Score, bands),
+		Checks: []*JSONCheckV1{},
+	}
+	if asset != nil {
+		res.Mrn = asset.Mrn
+		res.Name = asset.Name
+		res.Platform = asset.PlatformName
+		res.Cloud = convertCloudV1(asset.Cloud)
+		res.Discovery = convertDiscoveryV1(asset.Discovery)
+		res.Weighting = convertWeightingV1(asset.Weighting)
+	}
+	res.Audit = convertAuditV1(report.AuditTrail)
+
+	queries := map[string]*explorer.Mquery{}
+	if bundle != nil {
+		queries = bundle.ToMap().QueryMap()
+	}
+	for codeID := range resolved.CollectorJob.ReportingQueries {
+		score, ok := report.Scores[codeID]
+		if !ok {
+			continue
+		}
+		check := &JSONCheckV1{
+			CodeID: codeID,
+			Score:  convertScoreV1(score, bands),
+		}
+		if provenance, ok := report.ImpactProvenance[codeID]; ok {
+			check.Impacts = convertImpactProvenanceV1(provenance.List)
+		}
+		if query, ok := queries[codeID]; ok {
+			check.Mrn = query.Mrn
+			check.Title = query.Title
+		}
+		res.Checks = append(res.Checks, check)
+	}
+	sort.Slice(res.Checks, func(i, j int) bool {
+		if res.Checks[i].Mrn == res.Checks[j].Mrn {
+			return res.Checks[i].CodeID < res.Checks[j].CodeID
+		}
+		return res.Checks[i].Mrn < res.Checks[j].Mrn
+	})
+
+	results := report.RawResults()
+	var err error
+	resolved.WithDataQueries(func(id string, query *policy.ExecutionQuery) {
+		q, ok := queries[id]
+		if !ok || err != nil || query.Code == nil {
+			return
+		}
+
+		buf := bytes.Buffer{}
+		if err = cr.BundleResultsToJSON(query.Code, results, &shared.IOWriter{Writer: &buf}); err != nil {
+			return
+		}
+		if res.Data == nil {
+			res.Data = map[string]json.RawMessage{}
+		}
+		res.Data[q.Mrn] = json.RawMessage(buf.Bytes())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func convertCloudV1(cloud *policy.CloudContext) *JSONCloudV1 {
+	if cloud == nil {
+		return nil
+	}
+	return &JSONCloudV1{
+		Provider:  cloud.Provider,
+		AccountID: cloud.AccountId,
+		Region:    cloud.Region,
+		ImageID:   cloud.ImageId,
+		Tags:      cloud.Tags,
+	}
+}
+
+func convertDiscoveryV1(lineage *policy.DiscoveryLineage) *JSONDiscoveryV1 {
+	if lineage == nil {
+		return nil
+	}
+	return &JSONDiscoveryV1{
+		CorrelationID: lineage.CorrelationId,
+		Root:          lineage.Root,
+		Credential:    lineage.Credential,
+	}
+}
+
+func convertWeightingV1(weighting *policy.CriticalityWeighting) *JSONWeightingV1 {
+	if weighting == nil {
+		return nil
+	}
+	return &JSONWeightingV1{
+		Criticality: weighting.Criticality,
+		Factor:      weighting.Factor,
+	}
+}
+
+func convertAuditV1(trail []*policy.AuditEntry) []*JSONAuditEntryV1 {
+	if len(trail) == 0 {
+		return nil
+	}
+	res := make([]*JSONAuditEntryV1, len(trail))
+	for i, entry := range trail {
+		res[i] = &JSONAuditEntryV1{
+			Kind:       entry.Kind,
+			Call:       entry.Call,
+			CodeID:     entry.CodeId,
+			CheckMrns:  entry.CheckMrns,
+			Time:       time.Unix(0, entry.Time),
+			Duration:   entry.Duration,
+			ExitStatus: int(entry.ExitStatus),
+			Error:      entry.Error,
+		}
+	}
+	return res
+}
+
+func convertImpactV1(impact *explorer.Impact) *JSONImpactV1 {
+	if impact == nil {
+		return nil
+	}
+	return &JSONImpactV1{
+		Value:  impact.Value,
+		Weight: impact.Weight,
+	}
+}
+
+func convertImpactProvenanceV1(provenance []*policy.ImpactProvenance) []*JSONImpactProvenanceV1 {
+	if len(provenance) == 0 {
+		return nil
+	}
+	res := make([]*JSONImpactProvenanceV1, len(provenance))
+	for i, p := range provenance {
+		res[i] = &JSONImpactProvenanceV1{
+			Policy:        p.Policy,
+			ID:            p.Id,
+			IsPolicy:      p.IsPolicy,
+			Impact:        convertImpactV1(p.Impact),
+			Declared:      convertImpactV1(p.Declared),
+			ModifiedBy:    p.ModifiedBy,
+			Priority:      int(p.Priority),
+			Overridden:    p.Overridden,
+			Rule:          p.Rule,
+			Informational: p.Informational,
+			Criticality:   p.Criticality,
+		}
+	}
+	return res
+}
+
+// ReportCollectionToJSONV1 converts all reports of a collection into the v1 schema
+func ReportCollectionToJSONV1(data *policy.ReportCollection) (*JSONReportV1, error) {
+	res := &JSONReportV1{
+		Schema: JSONSchemaV1,
+		Assets: []*JSONAssetV1{},
+	}
+	if data == nil {
+		return res, nil
+	}
+
+	for mrn, report := range data.Reports {
+		resolved, ok := data.ResolvedPolicies[mrn]
+		if !ok {
+			return nil, errors.New("cannot find resolved pack for " + mrn + " in report")
+		}
+
+		asset, err := ConvertReportV1(report, data.Assets[mrn], resolved, data.Bundle)
+		if err != nil {
+			return nil, err
+		}
+		res.Assets = append(res.Assets, asset)
+	}
+	sort.Slice(res.Assets, func(i, j int) bool {
+		return res.Assets[i].Mrn < res.Assets[j].Mrn
+	})
+
+	if len(data.Errors) != 0 {
+		res.Errors = make(map[string]string, len(data.Errors))
+		for k, v := range data.Errors {
+			res.Errors[k] = v
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,154 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func testReportCollectionV1() *policy.ReportCollection {
+	assetMrn := "//assets.api.mondoo.app/assets/abc"
+	return &policy.ReportCollection{
+		Assets: map[string]*policy.Asset{
+			assetMrn: {Mrn: assetMrn, Name: "debian", PlatformName: "Debian GNU/Linux 11 (bullseye)"},
+		},
+		Bundle: &policy.Bundle{
+			Queries: []*explorer.Mquery{
+				{Mrn: "//local.cnspec.io/queries/check-a", Title: "Check A", CodeId: "codeA"},
+				{Mrn: "//local.cnspec.io/queries/check-b", Title: "Check B", CodeId: "codeB"},
+			},
+		},
+		Reports: map[string]*policy.Report{
+			assetMrn: {
+				EntityMrn: assetMrn,
+				Score:     &policy.Score{Type: policy.ScoreType_Result, Value: 50, Weight: 2, ScoreCompletion: 100},
+				Scores: map[string]*policy.Score{
+					"codeA": {Type: policy.ScoreType_Result, Value: 100, Weight: 1, ScoreCompletion: 100},
+					"codeB": {Type: policy.ScoreType_Error, Weight: 1, ScoreCompletion: 100, Message: "failed to\nexecute"},
+				},
+			},
+		},
+		ResolvedPolicies: map[string]*policy.ResolvedPolicy{
+			assetMrn: {
+				ExecutionJob: &policy.ExecutionJob{},
+				CollectorJob: &policy.CollectorJob{
+					ReportingQueries: map[string]*policy.StringArray{
+						"codeA": {},
+						"codeB": {},
+					},
+				},
+			},
+		},
+		Errors: map[string]string{
+			"//assets.api.mondoo.app/assets/xyz": "failed to connect",
+		},
+	}
+}
+
+func TestReportCollectionToJSONV1(t *testing.T) {
+	report, err := ReportCollectionToJSONV1(testReportCollectionV1())
+	require.NoError(t, err)
+
+	raw, err := json.MarshalIndent(report, "", "  ")
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("./testdata/report-v1.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(raw))
+}
+
+// The v1 schema must stay compatible: every field in the stored report must
+// still be known, otherwise existing parsers break.
+func TestJSONReportV1Compatibility(t *testing.T) {
+	raw, err := os.ReadFile("./testdata/report-v1.json")
+	require.NoError(t, err)
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	report := &JSONReportV1{}
+	require.NoError(t, decoder.Decode(report))
+	assert.Equal(t, JSONSchemaV1, report.Schema)
+	require.Len(t, report.Assets, 1)
+	require.Len(t, report.Assets[0].Checks, 2)
+	assert.Equal(t, "pass", report.Assets[0].Checks[0].Score.Status)
+	assert.Equal(t, "error", report.Assets[0].Checks[1].Score.Status)
+}
+
+func TestReporterJSONV1(t *testing.T) {
+	r, err := New("json-v1")
+	require.NoError(t, err)
+
+	buf := bytes.Buffer{}
+	require.NoError(t, r.Print(testReportCollectionV1(), &buf))
+	assert.Contains(t, buf.String(), `"schema":"v1"`)
+}
+
+func TestConvertReportV1_ImpactProvenance(t *testing.T) {
+	data := testReportCollectionV1()
+	data.Reports["//assets.api.mondoo.app/assets/abc"].ImpactProvenance = map[string]*policy.ImpactProvenances{
+		"codeA": {List: []*policy.ImpactProvenance{{
+			Policy:     "//local.cnspec.io/policies/child",
+			Id:         "//local.cnspec.io/queries/check-a",
+			Impact:     &explorer.Impact{Value: 20},
+			Declared:   &explorer.Impact{Value: 80},
+			ModifiedBy: "//local.cnspec.io/policies/parent",
+		}}},
+	}
+	report, err := ReportCollectionToJSONV1(data)
+	require.NoError(t, err)
+
+	require.Len(t, report.Assets[0].Checks, 2)
+	assert.Equal(t, []*JSONImpactProvenanceV1{{
+		Policy:     "//local.cnspec.io/policies/child",
+		ID:         "//local.cnspec.io/queries/check-a",
+		Impact:     &JSONImpactV1{Value: 20},
+		Declared:   &JSONImpactV1{Value: 80},
+		ModifiedBy: "//local.cnspec.io/policies/parent",
+	}}, report.Assets[0].Checks[0].Impacts)
+	assert.Empty(t, report.Assets[0].Checks[1].Impacts)
+
+	raw, err := json.Marshal(report.Assets[0].Checks[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"modified_by":"//local.cnspec.io/policies/parent"`)
+}
+
+func TestConvertScoreV1_Informational(t *testing.T) {
+	score := ConvertScoreV1(&policy.Score{Type: policy.ScoreType_Result, Value: 0, Weight: 1, Informational: true})
+	assert.True(t, score.Informational)
+
+	raw, err := json.Marshal(ConvertScoreV1(&policy.Score{Type: policy.ScoreType_Result, Value: 100, Weight: 1}))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "informational")
+}
+
+func TestConvertReportV1_AssetContext(t *testing.T) {
+	assetMrn := "//assets.api.mondoo.app/assets/abc"
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := testReportCollectionV1()
+	data.Assets[assetMrn].Cloud = &policy.CloudContext{Provider: "aws", AccountId: "123", Region: "us-east-1"}
+	data.Assets[assetMrn].Discovery = &policy.DiscoveryLineage{CorrelationId: "abc", Root: "aws"}
+	data.Assets[assetMrn].Weighting = policy.CriticalityHigh.Weighting()
+	data.Reports[assetMrn].AuditTrail = []*policy.AuditEntry{
+		{Kind: string(policy.AuditCommand), Call: "uname -a", CodeId: "codeA", Time: start.UnixNano(), Duration: int64(time.Second)},
+	}
+	report, err := ReportCollectionToJSONV1(data)
+	require.NoError(t, err)
+
+	asset := report.Assets[0]
+	assert.Equal(t, &JSONCloudV1{Provider: "aws", AccountID: "123", Region: "us-east-1"}, asset.Cloud)
+	assert.Equal(t, &JSONDiscoveryV1{CorrelationID: "abc", Root: "aws"}, asset.Discovery)
+	assert.Equal(t, "high", asset.Weighting.Criticality)
+	require.Len(t, asset.Audit, 1)
+	assert.Equal(t, "command", asset.Audit[0].Kind)
+	assert.Equal(t, "codeA", asset.Audit[0].CodeID)
+	assert.True(t, start.Equal(asset.Audit[0].Time))
+	assert.Equal(t, int64(time.Second), asset.Audit[0].Duration)
+}
@@ -95,6 +95,8 @@ func assetPolicyTests(r *policy.ReportCollection, assetMrn string, assetObj *pol
 		return ts
 	}
 
+	bands := r.Bundle.SeverityBands()
+
 	// jUnit is not able to handle meta information of policies and also does not support
 	// data query results.
 	for id, score := range report.Scores {
@@ -117,6 +119,8 @@ func assetPolicyTests(r *policy.ReportCollection, assetMrn string, assetObj *pol
 		}
 
 		if score != nil {
+			testCase.Status = string(bands.Band(score))
+
 			if score.Type == policy.ScoreType_Skip {
 				testCase.Skipped = &junit.Result{
 					Message: "skipped",
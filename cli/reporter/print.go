@@ -21,6 +21,9 @@ const (
 	JSON
 	JUnit
 	CSV
+	JSONv1
+	SARIF
+	Compliance
 )
 
 // Formats that are supported by the reporter
@@ -35,6 +38,10 @@ var Formats = map[string]Format{
 	"json":    JSON,
 	"junit":   JUnit,
 	"csv":     CSV,
+	"json-v1": JSONv1,
+	"sarif":   SARIF,
+
+	"compliance": Compliance,
 }
 
 func AllFormats() string {
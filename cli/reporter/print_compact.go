@@ -399,6 +399,11 @@ func (r *defaultReporter) printScore(title string, score *policy.Score, query *e
 			score.Value = floor
 		}
 	}
+	// informational checks are recorded, but they neither pass nor fail
+	if score.Informational {
+		return termenv.String(". Info:  ").Foreground(r.Colors.Disabled).String() + title + NewLineCharacter
+	}
+
 	rating := score.Rating()
 	color := cnspecComponents.DefaultRatingColors.Color(rating)
 
@@ -449,7 +454,7 @@ func (r *defaultReporter) printControl(score *policy.Score, query *explorer.Mque
 		r.out.Write([]byte(r.printScore(title, score, query)))
 
 		// additional information about the failed query
-		if !r.isCompact && score.Value != 100 {
+		if !r.isCompact && score.Value != 100 && !score.Informational {
 			queryString := strings.ReplaceAll(stringx.Indent(4, query.Query), "\n", NewLineCharacter)
 			r.out.Write([]byte("  Query:" + NewLineCharacter + queryString))
 			r.out.Write([]byte(NewLineCharacter))
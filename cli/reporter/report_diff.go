@@ -0,0 +1,94 @@
+package reporter
+
+import (
+	"encoding/json"
+
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/shared"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// JSONReportDiffV1 is the difference between two reports of an asset in the
+// v1 schema, see policy.CompareReports
+type JSONReportDiffV1 struct {
+	// Schema is always set to JSONSchemaV1
+	Schema string `json:"schema"`
+	Mrn    string `json:"mrn"`
+	// Regression is true if any check newly fails or the score dropped
+	Regression bool `json:"regression"`
+	// Score is the change of the overall score, if it changed
+	Score        *JSONScoreDeltaV1   `json:"score,omitempty"`
+	NewlyFailing []*JSONScoreDeltaV1 `json:"newly_failing"`
+	NewlyPassing []*JSONScoreDeltaV1 `json:"newly_passing"`
+	Changed      []*JSONScoreDeltaV1 `json:"changed"`
+	// Data lists the checksums of all datapoints that changed
+	Data []policy.DataChange `json:"data"`
+}
+
+// JSONScoreDeltaV1 is the change of one score
+type JSONScoreDeltaV1 struct {
+	// Mrn is the MRN of the check, if it is found in the bundle
+	Mrn   string `json:"mrn,omitempty"`
+	Title string `json:"title,omitempty"`
+	// ID is the code ID of checks and the MRN of policies and assets
+	ID  string       `json:"id"`
+	Old *JSONScoreV1 `json:"old,omitempty"`
+	New *JSONScoreV1 `json:"new,omitempty"`
+	// Delta is the change of the score value
+	Delta int `json:"delta"`
+}
+
+// ConvertReportDiffV1 converts a report diff into the v1 schema. Checks are
+// looked up in the bundle, which may be nil.
+func ConvertReportDiffV1(diff *policy.ReportDiff, bundle *policy.Bundle) *JSONReportDiffV1 {
+	bands := bundle.SeverityBands()
+	queries := map[string]*explorer.Mquery{}
+	if bundle != nil {
+		queries = bundle.ToMap().QueryMap()
+	}
+
+	convert := func(deltas []*policy.ScoreDelta) []*JSONScoreDeltaV1 {
+		res := make([]*JSONScoreDeltaV1, len(deltas))
+		for i := range deltas {
+			delta := deltas[i]
+			res[i] = &JSONScoreDeltaV1{
+				ID:    delta.ID,
+				Old:   convertScoreV1(delta.Old, bands),
+				New:   convertScoreV1(delta.New, bands),
+				Delta: delta.Delta,
+			}
+			if query, ok := queries[delta.ID]; ok {
+				res[i].Mrn = query.Mrn
+				res[i].Title = query.Title
+			}
+		}
+		return res
+	}
+
+	res := &JSONReportDiffV1{
+		Schema:       JSONSchemaV1,
+		Mrn:          diff.EntityMrn,
+		Regression:   diff.HasRegressions(),
+		NewlyFailing: convert(diff.NewlyFailing),
+		NewlyPassing: convert(diff.NewlyPassing),
+		Changed:      convert(diff.Changed),
+		Data:         diff.Data,
+	}
+	if diff.Score != nil {
+		res.Score = convert([]*policy.ScoreDelta{diff.Score})[0]
+	}
+	if res.Data == nil {
+		res.Data = []policy.DataChange{}
+	}
+	return res
+}
+
+// ReportDiffToJSON writes a report diff in the v1 schema
+func ReportDiffToJSON(diff *policy.ReportDiff, bundle *policy.Bundle, out shared.OutputHelper) error {
+	data, err := json.Marshal(ConvertReportDiffV1(diff, bundle))
+	if err != nil {
+		return err
+	}
+	out.WriteString(string(data))
+	return nil
+}
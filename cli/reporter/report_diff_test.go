@@ -0,0 +1,46 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/shared"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestReportDiffToJSON(t *testing.T) {
+	data := testReportCollectionV1()
+	var assetMrn string
+	for mrn := range data.Reports {
+		assetMrn = mrn
+	}
+	old := data.Reports[assetMrn]
+	new := &policy.Report{
+		EntityMrn: assetMrn,
+		Score:     &policy.Score{Type: policy.ScoreType_Result, Value: 0, Weight: 2, ScoreCompletion: 100},
+		Scores: map[string]*policy.Score{
+			"codeA": {Type: policy.ScoreType_Result, Value: 0, Weight: 1, ScoreCompletion: 100},
+			"codeB": {Type: policy.ScoreType_Result, Value: 100, Weight: 1, ScoreCompletion: 100},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	require.NoError(t, ReportDiffToJSON(policy.CompareReports(old, new), data.Bundle, &shared.IOWriter{Writer: &buf}))
+
+	var diff JSONReportDiffV1
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &diff))
+	assert.Equal(t, JSONSchemaV1, diff.Schema)
+	assert.True(t, diff.Regression)
+	assert.Equal(t, -50, diff.Score.Delta)
+	require.Len(t, diff.NewlyFailing, 1)
+	assert.Equal(t, "//local.cnspec.io/queries/check-a", diff.NewlyFailing[0].Mrn)
+	assert.Equal(t, "pass", diff.NewlyFailing[0].Old.Status)
+	assert.Equal(t, "fail", diff.NewlyFailing[0].New.Status)
+	require.Len(t, diff.NewlyPassing, 1)
+	assert.Equal(t, "Check B", diff.NewlyPassing[0].Title)
+	assert.Empty(t, diff.Changed)
+	assert.Empty(t, diff.Data)
+}
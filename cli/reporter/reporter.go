@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"strings"
@@ -99,6 +100,16 @@ func (r *Reporter) Print(data *policy.ReportCollection, out io.Writer) error {
 	case JSON:
 		writer := shared.IOWriter{Writer: out}
 		return ReportCollectionToJSON(data, &writer)
+	case JSONv1:
+		report, err := ReportCollectionToJSONV1(data)
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(out).Encode(report)
+	case SARIF:
+		return ReportCollectionToSarifWriter(data, out)
+	case Compliance:
+		return json.NewEncoder(out).Encode(ReportCollectionToCompliance(data))
 	case JUnit:
 		writer := shared.IOWriter{Writer: out}
 		return ReportCollectionToJunit(data, &writer)
@@ -0,0 +1,242 @@
+package reporter
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnspec/policy"
+)
+
+const (
+	sarifError   = "error"
+	sarifWarning = "warning"
+	sarifNote    = "note"
+	sarifNone    = "none"
+)
+
+// sarifLevel maps the impact of a check to a SARIF level
+func sarifLevel(impact *explorer.Impact) string {
+	if impact == nil {
+		return sarifWarning
+	}
+	switch {
+	case impact.Value >= 70:
+		return sarifError
+	case impact.Value >= 40:
+		return sarifWarning
+	case impact.Value > 0:
+		return sarifNote
+	default:
+		return sarifNone
+	}
+}
+
+// sarifSecuritySeverity is the CVSS-like severity (0.0 - 10.0) that GitHub
+// code scanning uses to rank results
+func sarifSecuritySeverity(impact *explorer.Impact) string {
+	if impact == nil {
+		return "5.0"
+	}
+	return strconv.FormatFloat(float64(impact.Value)/10, 'f', 1, 64)
+}
+
+// sarifHelpURI picks the most actionable reference of a check: runbooks
+// before vendor docs before anything else
+func sarifHelpURI(refs []policy.CheckRef) string {
+	for _, kind := range []policy.RefKind{policy.RefRunbook, policy.RefKB} {
+		for i := range refs {
+			if refs[i].Kind == kind {
+				return refs[i].Url
+			}
+		}
+	}
+	return refs[0].Url
+}
+
+func remediationText(query *explorer.Mquery) string {
+	if query.Docs == nil || query.Docs.Remediation == nil {
+		return ""
+	}
+
+	items := query.Docs.Remediation.Items
+	res := make([]string, 0, len(items))
+	for i := range items {
+		if desc := strings.TrimSpace(items[i].Desc); desc != "" {
+			res = append(res, desc)
+		}
+	}
+	return strings.Join(res, "\n\n")
+}
+
+// AddSarifResults adds the failed checks of one asset report to the run.
+// Every check becomes a rule, failed and errored scores become results.
+func AddSarifResults(run *sarif.Run, report *policy.Report, asset *policy.Asset, resolved *policy.ResolvedPolicy, bundle *policy.Bundle) error {
+	if report == nil {
+		return errors.New("cannot convert empty report")
+	}
+	if resolved == nil || resolved.CollectorJob == nil {
+		return errors.New("cannot find resolved policy for report of " + report.EntityMrn)
+	}
+
+	queries := map[string]*explorer.Mquery{}
+	if bundle != nil {
+		queries = bundle.ToMap().QueryMap()
+	}
+
+	assetName := report.EntityMrn
+	if asset != nil && asset.Name != "" {
+		assetName = asset.Name
+	}
+	location := sarif.NewLocation().WithPhysicalLocation(
+		sarif.NewPhysicalLocation().WithArtifactLocation(sarif.NewSimpleArtifactLocation(assetName)),
+	)
+
+	bands := bundle.SeverityBands()
+	assetProperties := sarifAssetProperties(asset)
+
+	codeIDs := make([]string, 0, len(resolved.CollectorJob.ReportingQueries))
+	for codeID := range resolved.CollectorJob.ReportingQueries {
+		codeIDs = append(codeIDs, codeID)
+	}
+	sort.Strings(codeIDs)
+
+	for _, codeID := range codeIDs {
+		query, ok := queries[codeID]
+		if !ok {
+			continue
+		}
+
+		rule := run.AddRule(query.Mrn).
+			WithName(query.Title).
+			WithDescription(query.Title).
+			WithDefaultConfiguration(sarif.NewReportingConfiguration().WithLevel(sarifLevel(query.Impact))).
+			WithProperties(sarif.Properties{
+				"security-severity": sarifSecuritySeverity(query.Impact),
+			})
+		if query.Docs != nil && query.Docs.Desc != "" {
+			rule.WithFullDescription(sarif.NewMultiformatMessageString(query.Docs.Desc))
+		}
+		remediation := remediationText(query)
+		if remediation != "" {
+			rule.WithHelp(sarif.NewMultiformatMessageString(remediation))
+		}
+		if refs := policy.DefaultRefClassifier.CheckRefs(query); len(refs) != 0 {
+			rule.WithHelpURI(sarifHelpURI(refs))
+			rule.Properties["references"] = refs
+			var tags []string
+			for i := range refs {
+				if refs[i].Kind == policy.RefCVE && refs[i].ID != "" {
+					tags = append(tags, refs[i].ID)
+				}
+			}
+			if len(tags) != 0 {
+				rule.Properties["tags"] = tags
+			}
+		}
+
+		score, ok := report.Scores[codeID]
+		if !ok {
+			continue
+		}
+
+		var msg string
+		level := sarifLevel(query.Impact)
+		switch {
+		case score.Type == policy.ScoreType_Error:
+			msg = query.Title + ": " + score.MessageLine()
+			level = sarifError
+		case score.Type == policy.ScoreType_Result && score.Value != 100:
+			msg = query.Title + " failed on " + assetName
+		default:
+			continue
+		}
+		if remediation != "" {
+			msg += "\n\nRemediation:\n" + remediation
+		}
+
+		result := sarif.NewRuleResult(query.Mrn).
+			WithMessage(sarif.NewTextMessage(msg)).
+			WithLevel(level).
+			WithLocations([]*sarif.Location{location})
+		properties := sarif.Properties{}
+		for k, v := range assetProperties {
+			properties[k] = v
+		}
+		if band := bands.Band(score); band != "" {
+			properties["severity-band"] = string(band)
+		}
+		if len(properties) != 0 {
+			result.Properties = properties
+		}
+		run.AddResult(result)
+	}
+
+	return nil
+}
+
+// sarifAssetProperties returns the cloud context and criticality of an asset
+// as properties of its results, so that they can be filtered by them
+func sarifAssetProperties(asset *policy.Asset) sarif.Properties {
+	res := sarif.Properties{}
+	if asset == nil {
+		return res
+	}
+	if asset.Cloud != nil {
+		if asset.Cloud.Provider != "" {
+			res["cloud-provider"] = asset.Cloud.Provider
+		}
+		if asset.Cloud.AccountId != "" {
+			res["cloud-account"] = asset.Cloud.AccountId
+		}
+		if asset.Cloud.Region != "" {
+			res["cloud-region"] = asset.Cloud.Region
+		}
+	}
+	if asset.Weighting != nil && asset.Weighting.Criticality != "" {
+		res["criticality"] = asset.Weighting.Criticality
+	}
+	return res
+}
+
+// ReportCollectionToSarif converts all reports of a collection into one
+// SARIF 2.1.0 report, e.g. for GitHub code scanning
+func ReportCollectionToSarif(data *policy.ReportCollection) (*sarif.Report, error) {
+	report, err := sarif.New(sarif.Version210)
+	if err != nil {
+		return nil, err
+	}
+
+	run := sarif.NewRunWithInformationURI("cnspec", "https://cnspec.io")
+
+	if data != nil {
+		mrns := make([]string, 0, len(data.Reports))
+		for mrn := range data.Reports {
+			mrns = append(mrns, mrn)
+		}
+		sort.Strings(mrns)
+
+		for _, mrn := range mrns {
+			err := AddSarifResults(run, data.Reports[mrn], data.Assets[mrn], data.ResolvedPolicies[mrn], data.Bundle)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	report.AddRun(run)
+	return report, nil
+}
+
+// ReportCollectionToSarifWriter writes all reports of a collection as SARIF
+func ReportCollectionToSarifWriter(data *policy.ReportCollection, out io.Writer) error {
+	report, err := ReportCollectionToSarif(data)
+	if err != nil {
+		return err
+	}
+	return report.Write(out)
+}
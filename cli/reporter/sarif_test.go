@@ -0,0 +1,60 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestReportCollectionToSarif(t *testing.T) {
+	data := testReportCollectionV1()
+	data.Bundle.Queries[0].Impact = &explorer.Impact{Value: 80}
+	data.Bundle.Queries[0].Refs = []*explorer.MqueryRef{
+		{Title: "CVE-2021-44228", Url: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+		{Title: "Vendor advisory", Url: "https://access.redhat.com/security/cve/cve-2021-44228"},
+	}
+	data.Bundle.Queries[1].Docs = &explorer.MqueryDocs{
+		Remediation: &explorer.Remediation{Items: []*explorer.TypedDoc{{Id: "default", Desc: "Fix it."}}},
+	}
+	assetMrn := "//assets.api.mondoo.app/assets/abc"
+	data.Reports[assetMrn].Scores["codeA"].Value = 0
+	data.Assets[assetMrn].Cloud = &policy.CloudContext{Provider: "aws", AccountId: "123", Region: "us-east-1"}
+	data.Assets[assetMrn].Weighting = policy.CriticalityHigh.Weighting()
+
+	report, err := ReportCollectionToSarif(data)
+	require.NoError(t, err)
+	require.Len(t, report.Runs, 1)
+	run := report.Runs[0]
+
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "//local.cnspec.io/queries/check-a", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "https://access.redhat.com/security/cve/cve-2021-44228", *run.Tool.Driver.Rules[0].HelpURI)
+	assert.Equal(t, []string{"CVE-2021-44228"}, run.Tool.Driver.Rules[0].Properties["tags"])
+
+	require.Len(t, run.Results, 2)
+	assert.Equal(t, "error", *run.Results[0].Level)
+	assert.Equal(t, "Check A failed on debian", *run.Results[0].Message.Text)
+	assert.Equal(t, "aws", run.Results[0].Properties["cloud-provider"])
+	assert.Equal(t, "123", run.Results[0].Properties["cloud-account"])
+	assert.Equal(t, "us-east-1", run.Results[0].Properties["cloud-region"])
+	assert.Equal(t, "high", run.Results[0].Properties["criticality"])
+	assert.Equal(t, "error", *run.Results[1].Level)
+	assert.Contains(t, *run.Results[1].Message.Text, "Remediation:\nFix it.")
+}
+
+func TestReporterSarif(t *testing.T) {
+	r, err := New("sarif")
+	require.NoError(t, err)
+
+	buf := bytes.Buffer{}
+	require.NoError(t, r.Print(testReportCollectionV1(), &buf))
+
+	res := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Equal(t, "2.1.0", res["version"])
+}
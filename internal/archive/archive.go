@@ -0,0 +1,280 @@
+// Package archive exports compressed reports of scan runs to S3 or GCS
+// buckets for long-term compliance archival.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"go.mondoo.com/cnspec/cli/reporter"
+	"go.mondoo.com/cnspec/policy"
+)
+
+const (
+	// DefaultLayout partitions archives by the date of the scan run
+	DefaultLayout = "{year}/{month}/{day}/{run}/{batch}.json.gz"
+	// DefaultBatchSize is the number of assets per archive
+	DefaultBatchSize = 100
+	// ContentType of all archives
+	ContentType = "application/gzip"
+)
+
+// Bucket stores archives
+type Bucket interface {
+	// Put writes an object. Existing objects are overwritten.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+// Open creates a bucket from its location, which is either an S3 url like
+// s3://bucket/prefix or a GCS url like gs://bucket/prefix
+func Open(ctx context.Context, location string) (Bucket, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.New("invalid archive location '" + location + "': " + err.Error())
+	}
+
+	prefix := strings.TrimPrefix(u.Path, "/")
+	switch u.Scheme {
+	case "s3":
+		return NewS3Bucket(ctx, u.Host, prefix)
+	case "gs":
+		return NewGCSBucket(ctx, u.Host, prefix)
+	default:
+		return nil, errors.New("unsupported archive location '" + location + "', expected s3://bucket/prefix or gs://bucket/prefix")
+	}
+}
+
+// joinPrefix returns the prefix with a trailing slash, if it isn't empty
+func joinPrefix(prefix string) string {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// Options configure how reports are archived
+type Options struct {
+	// Layout of the object keys, defaults to DefaultLayout. It supports the
+	// placeholders {year}, {month}, {day} and {date} of the scan run (in
+	// UTC), {run} for the ID of the scan run, {batch} for the number of the
+	// batch within the run, and {asset} for the asset ID. Layouts with
+	// {asset} archive every asset on its own.
+	Layout string
+	// BatchSize is the max number of assets per archive, defaults to
+	// DefaultBatchSize. Fewer and larger objects keep lifecycle rules and
+	// storage class transitions cheap.
+	BatchSize int
+}
+
+var (
+	reLayoutPlaceholder = regexp.MustCompile(`\{[^}]*\}`)
+	reUnsafeKeyChars    = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+var layoutPlaceholders = map[string]struct{}{
+	"{year}":  {},
+	"{month}": {},
+	"{day}":   {},
+	"{date}":  {},
+	"{run}":   {},
+	"{batch}": {},
+	"{asset}": {},
+}
+
+// validateLayout makes sure that every archive of every run gets its own key
+func validateLayout(layout string) error {
+	for _, placeholder := range reLayoutPlaceholder.FindAllString(layout, -1) {
+		if _, ok := layoutPlaceholders[placeholder]; !ok {
+			return errors.New("unknown placeholder " + placeholder + " in archive layout '" + layout + "'")
+		}
+	}
+	if !strings.Contains(layout, "{run}") {
+		return errors.New("archive layout '" + layout + "' must contain {run}, otherwise scan runs overwrite each other")
+	}
+	if !strings.Contains(layout, "{batch}") && !strings.Contains(layout, "{asset}") {
+		return errors.New("archive layout '" + layout + "' must contain {batch} or {asset}, otherwise batches overwrite each other")
+	}
+	if strings.HasPrefix(layout, "/") {
+		return errors.New("archive layout '" + layout + "' must not start with /")
+	}
+	return nil
+}
+
+// Archiver writes the reports of scan runs to a bucket
+type Archiver struct {
+	bucket    Bucket
+	layout    string
+	batchSize int
+}
+
+// New creates an archiver for the bucket
+func New(bucket Bucket, opts Options) (*Archiver, error) {
+	if bucket == nil {
+		return nil, errors.New("cannot archive reports without a bucket")
+	}
+
+	res := &Archiver{
+		bucket:    bucket,
+		layout:    opts.Layout,
+		batchSize: opts.BatchSize,
+	}
+	if res.layout == "" {
+		res.layout = DefaultLayout
+	}
+	if err := validateLayout(res.layout); err != nil {
+		return nil, err
+	}
+	if res.batchSize < 0 {
+		return nil, errors.New("invalid archive batch size " + strconv.Itoa(res.batchSize))
+	}
+	if res.batchSize == 0 {
+		res.batchSize = DefaultBatchSize
+	}
+	if strings.Contains(res.layout, "{asset}") {
+		res.batchSize = 1
+	}
+	return res, nil
+}
+
+// Run is one scan run whose reports are archived
+type Run struct {
+	// ID of the run, a new one is generated if empty
+	ID string
+	// Time of the run, defaults to now
+	Time time.Time
+}
+
+// key renders the layout for one batch of a run
+func (a *Archiver) key(run Run, batch int, assetMrn string) string {
+	t := run.Time.UTC()
+	return strings.NewReplacer(
+		"{year}", t.Format("2006"),
+		"{month}", t.Format("01"),
+		"{day}", t.Format("02"),
+		"{date}", t.Format("2006-01-02"),
+		"{run}", reUnsafeKeyChars.ReplaceAllString(run.ID, "_"),
+		"{batch}", batchName(batch),
+		"{asset}", assetName(assetMrn),
+	).Replace(a.layout)
+}
+
+func batchName(batch int) string {
+	res := strconv.Itoa(batch)
+	if len(res) < 4 {
+		res = strings.Repeat("0", 4-len(res)) + res
+	}
+	return res
+}
+
+// assetName returns the last segment of the asset MRN, which is its ID
+func assetName(mrn string) string {
+	return reUnsafeKeyChars.ReplaceAllString(path.Base(mrn), "_")
+}
+
+// assetMrns returns all scanned and failed assets of the collection, sorted
+func assetMrns(data *policy.ReportCollection) []string {
+	seen := map[string]struct{}{}
+	for mrn := range data.Reports {
+		seen[mrn] = struct{}{}
+	}
+	for mrn := range data.Errors {
+		seen[mrn] = struct{}{}
+	}
+
+	res := make([]string, 0, len(seen))
+	for mrn := range seen {
+		res = append(res, mrn)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// batchCollection returns the part of the collection for the given assets
+func batchCollection(data *policy.ReportCollection, mrns []string) *policy.ReportCollection {
+	res := &policy.ReportCollection{
+		Assets:           map[string]*policy.Asset{},
+		Bundle:           data.Bundle,
+		Reports:          map[string]*policy.Report{},
+		Errors:           map[string]string{},
+		ResolvedPolicies: map[string]*policy.ResolvedPolicy{},
+	}
+	for _, mrn := range mrns {
+		if asset, ok := data.Assets[mrn]; ok {
+			res.Assets[mrn] = asset
+		}
+		if report, ok := data.Reports[mrn]; ok {
+			res.Reports[mrn] = report
+		}
+		if msg, ok := data.Errors[mrn]; ok {
+			res.Errors[mrn] = msg
+		}
+		if resolved, ok := data.ResolvedPolicies[mrn]; ok {
+			res.ResolvedPolicies[mrn] = resolved
+		}
+	}
+	return res
+}
+
+// encode writes the collection as gzip compressed JSON report
+func encode(data *policy.ReportCollection) ([]byte, error) {
+	report, err := reporter.ReportCollectionToJSONV1(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Archive writes the reports of a scan run in batches of assets, sorted by
+// asset MRN. It returns the keys of all written archives.
+func (a *Archiver) Archive(ctx context.Context, run Run, data *policy.ReportCollection) ([]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+	if run.ID == "" {
+		run.ID = ksuid.New().String()
+	}
+	if run.Time.IsZero() {
+		run.Time = time.Now()
+	}
+
+	mrns := assetMrns(data)
+	var keys []string
+	for start := 0; start < len(mrns); start += a.batchSize {
+		end := start + a.batchSize
+		if end > len(mrns) {
+			end = len(mrns)
+		}
+		batch := mrns[start:end]
+
+		raw, err := encode(batchCollection(data, batch))
+		if err != nil {
+			return keys, errors.New("failed to encode report archive: " + err.Error())
+		}
+		key := a.key(run, len(keys)+1, batch[0])
+		if err := a.bucket.Put(ctx, key, raw, ContentType); err != nil {
+			return keys, errors.New("failed to upload report archive '" + key + "': " + err.Error())
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
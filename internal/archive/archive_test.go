@@ -0,0 +1,106 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/cli/reporter"
+	"go.mondoo.com/cnspec/policy"
+)
+
+type memBucket map[string][]byte
+
+func (b memBucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	b[key] = data
+	return nil
+}
+
+func (b memBucket) report(t *testing.T, key string) *reporter.JSONReportV1 {
+	r, err := gzip.NewReader(bytes.NewReader(b[key]))
+	require.NoError(t, err)
+	res := &reporter.JSONReportV1{}
+	require.NoError(t, json.NewDecoder(r).Decode(res))
+	return res
+}
+
+func testCollection() *policy.ReportCollection {
+	res := &policy.ReportCollection{
+		Assets:           map[string]*policy.Asset{},
+		Reports:          map[string]*policy.Report{},
+		ResolvedPolicies: map[string]*policy.ResolvedPolicy{},
+		Errors:           map[string]string{"//assets/c": "connection failed"},
+	}
+	for _, mrn := range []string{"//assets/a", "//assets/b", "//assets/d"} {
+		res.Assets[mrn] = &policy.Asset{Mrn: mrn, Name: mrn}
+		res.Reports[mrn] = &policy.Report{EntityMrn: mrn, Score: &policy.Score{Value: 100, Type: policy.ScoreType_Result}}
+		res.ResolvedPolicies[mrn] = &policy.ResolvedPolicy{
+			ExecutionJob: &policy.ExecutionJob{},
+			CollectorJob: &policy.CollectorJob{},
+		}
+	}
+	return res
+}
+
+func TestArchiver(t *testing.T) {
+	ctx := context.Background()
+	run := Run{ID: "run1", Time: time.Date(2023, 2, 7, 23, 30, 0, 0, time.UTC)}
+
+	t.Run("batches", func(t *testing.T) {
+		bucket := memBucket{}
+		archiver, err := New(bucket, Options{BatchSize: 3})
+		require.NoError(t, err)
+
+		keys, err := archiver.Archive(ctx, run, testCollection())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2023/02/07/run1/0001.json.gz", "2023/02/07/run1/0002.json.gz"}, keys)
+
+		first := bucket.report(t, keys[0])
+		require.Len(t, first.Assets, 2)
+		assert.Equal(t, "//assets/a", first.Assets[0].Mrn)
+		assert.Equal(t, "//assets/b", first.Assets[1].Mrn)
+		assert.Equal(t, map[string]string{"//assets/c": "connection failed"}, first.Errors)
+
+		second := bucket.report(t, keys[1])
+		require.Len(t, second.Assets, 1)
+		assert.Equal(t, "//assets/d", second.Assets[0].Mrn)
+		assert.Empty(t, second.Errors)
+	})
+
+	t.Run("per asset", func(t *testing.T) {
+		bucket := memBucket{}
+		archiver, err := New(bucket, Options{Layout: "reports/{date}/{asset}-{run}.json.gz", BatchSize: 10})
+		require.NoError(t, err)
+
+		keys, err := archiver.Archive(ctx, run, testCollection())
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"reports/2023-02-07/a-run1.json.gz",
+			"reports/2023-02-07/b-run1.json.gz",
+			"reports/2023-02-07/c-run1.json.gz",
+			"reports/2023-02-07/d-run1.json.gz",
+		}, keys)
+	})
+
+	t.Run("invalid layouts", func(t *testing.T) {
+		for _, layout := range []string{
+			"{year}/{batch}.json.gz",
+			"{run}/all.json.gz",
+			"{run}/{batch}-{hour}.json.gz",
+			"/{run}/{batch}.json.gz",
+		} {
+			_, err := New(memBucket{}, Options{Layout: layout})
+			assert.Error(t, err, layout)
+		}
+	})
+
+	t.Run("unsupported location", func(t *testing.T) {
+		_, err := Open(ctx, "https://example.com/archive")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,45 @@
+package archive
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/storage"
+)
+
+// GCSBucket writes archives to a Google Cloud Storage bucket
+type GCSBucket struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// NewGCSBucket writes archives to the given bucket, with all objects below
+// the prefix. It uses the application default credentials.
+func NewGCSBucket(ctx context.Context, bucket string, prefix string) (*GCSBucket, error) {
+	if bucket == "" {
+		return nil, errors.New("cannot archive reports in GCS without a bucket")
+	}
+
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create GCS client: " + err.Error())
+	}
+
+	return &GCSBucket{
+		bucket: client.Bucket(bucket),
+		prefix: joinPrefix(prefix),
+	}, nil
+}
+
+// Put writes an object to the bucket
+func (b *GCSBucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	w := b.bucket.Object(b.prefix + key).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+var _ Bucket = (*GCSBucket)(nil)
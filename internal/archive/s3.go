@@ -0,0 +1,55 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3API is the subset of the S3 client that the bucket uses
+type s3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3Bucket writes archives to an S3 bucket
+type S3Bucket struct {
+	client s3API
+	bucket string
+	prefix string
+}
+
+// NewS3Bucket writes archives to the given bucket, with all objects below
+// the prefix. It uses the default AWS configuration of the environment.
+func NewS3Bucket(ctx context.Context, bucket string, prefix string) (*S3Bucket, error) {
+	if bucket == "" {
+		return nil, errors.New("cannot archive reports in S3 without a bucket")
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, errors.New("failed to load AWS configuration: " + err.Error())
+	}
+
+	return &S3Bucket{
+		client: s3.NewFromConfig(cfg),
+		bucket: bucket,
+		prefix: joinPrefix(prefix),
+	}, nil
+}
+
+// Put writes an object to the bucket
+func (b *S3Bucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(b.prefix + key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+var _ Bucket = (*S3Bucket)(nil)
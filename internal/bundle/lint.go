@@ -167,9 +167,10 @@ func Lint(files ...string) (*Results, error) {
 
 	// Note: we only run compile on the aggregated level to ensure the bundle in combination is valid
 	// Invalid yaml files are already caught by the individual linting, therefore we do not need extra error handling here
-	policyBundle, err := policy.BundleFromPaths(files...)
+	policyBundle, sources, err := policy.BundleFromPathsWithSources(files...)
 	if err == nil {
-		_, err = policyBundle.Compile(context.Background(), nil)
+		// the source map adds file and line to all compile errors
+		_, err = policyBundle.Compile(policy.WithSourceMap(context.Background(), sources), nil)
 		if err != nil {
 			locs := []Location{}
 
@@ -0,0 +1,277 @@
+// Package federated provides a DataLake that reads from multiple backends,
+// e.g. a local inmemory data lake for hot data and a remote one for history.
+package federated
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// MergeRule decides how results of multiple backends are combined
+type MergeRule string
+
+const (
+	// MergeFirst uses the result of the first backend that has one
+	MergeFirst MergeRule = "first"
+	// MergeFill uses the result of the first backend that has one and fills
+	// in scores and data that it is missing from the other backends, in order
+	MergeFill MergeRule = "fill"
+	// MergeNewest combines the results of all backends like MergeFill, but
+	// picks the most recently modified score if backends disagree
+	MergeNewest MergeRule = "newest"
+)
+
+// Db federates reads across data lakes. All writes and all calls that are
+// not federated go to the primary data lake only. Backends are ordered by
+// precedence, starting with the primary.
+type Db struct {
+	policy.DataLake
+	backends []policy.DataLake
+	merge    MergeRule
+}
+
+// New creates a federated data lake. The primary receives all writes, the
+// secondaries are only used for reads, in the given order.
+func New(merge MergeRule, primary policy.DataLake, secondaries ...policy.DataLake) (*Db, error) {
+	if primary == nil {
+		return nil, errors.New("a primary data lake is required for federation")
+	}
+	switch merge {
+	case MergeFirst, MergeFill, MergeNewest:
+	case "":
+		merge = MergeFill
+	default:
+		return nil, errors.New("unknown merge rule '" + string(merge) + "'")
+	}
+
+	backends := make([]policy.DataLake, 0, len(secondaries)+1)
+	backends = append(backends, primary)
+	for i := range secondaries {
+		if secondaries[i] != nil {
+			backends = append(backends, secondaries[i])
+		}
+	}
+
+	return &Db{
+		DataLake: primary,
+		backends: backends,
+		merge:    merge,
+	}, nil
+}
+
+// GetScore retrieves one score for an asset from the first backend that has
+// it, or the most recently modified one with MergeNewest
+func (db *Db) GetScore(ctx context.Context, assetMrn string, scoreID string) (policy.Score, error) {
+	var res policy.Score
+	var firstErr error
+	found := false
+	for _, backend := range db.backends {
+		score, err := backend.GetScore(ctx, assetMrn, scoreID)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !found || (db.merge == MergeNewest && score.ValueModifiedTime > res.ValueModifiedTime) {
+			res = score
+		}
+		found = true
+		if db.merge != MergeNewest {
+			break
+		}
+	}
+
+	if !found {
+		return policy.Score{}, firstErr
+	}
+	return res, nil
+}
+
+// GetReport retrieves all scores and data for a given asset and combines
+// the reports of all backends according to the merge rule
+func (db *Db) GetReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, error) {
+	res, _, err := db.getReport(ctx, assetMrn, qrID, false)
+	return res, err
+}
+
+// getReport combines the reports of all backends. Partial reports are
+// taken from backends that support them, together with the completeness of
+// the first one.
+func (db *Db) getReport(ctx context.Context, assetMrn string, qrID string, partial bool) (*policy.Report, *policy.ReportCompleteness, error) {
+	var res *policy.Report
+	var completeness *policy.ReportCompleteness
+	var firstErr error
+	merged := false
+	for _, backend := range db.backends {
+		var report *policy.Report
+		var err error
+		if reader, ok := backend.(policy.PartialResultsReader); ok && partial {
+			var c *policy.ReportCompleteness
+			report, c, err = reader.GetPartialReport(ctx, assetMrn, qrID)
+			if err == nil && completeness == nil {
+				completeness = c
+			}
+		} else {
+			report, err = backend.GetReport(ctx, assetMrn, qrID)
+		}
+		if err != nil {
+			log.Debug().Err(err).Str("asset", assetMrn).Msg("federated> could not get report from backend")
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		// backends return empty reports for assets they don't know
+		if report == nil || report.Score == nil {
+			continue
+		}
+
+		if res == nil {
+			if db.merge == MergeFirst {
+				return report, completeness, nil
+			}
+			// backends may hand out the reports they keep, merging must not
+			// change them
+			res = proto.Clone(report).(*policy.Report)
+			continue
+		}
+		db.mergeReport(res, report)
+		merged = true
+	}
+
+	if res != nil {
+		if merged {
+			db.computeStats(ctx, res)
+		}
+		return res, completeness, nil
+	}
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+	return &policy.Report{
+		EntityMrn:  assetMrn,
+		ScoringMrn: qrID,
+	}, completeness, nil
+}
+
+// computeStats updates the stats of a merged report for the scores it got
+// from all backends. The stats stay as they are if no backend has the
+// resolved policy of the asset.
+func (db *Db) computeStats(ctx context.Context, report *policy.Report) {
+	for _, backend := range db.backends {
+		resolvedPolicy, err := backend.GetResolvedPolicy(ctx, report.EntityMrn)
+		if err != nil || resolvedPolicy.GetCollectorJob() == nil {
+			continue
+		}
+		report.ComputeStats(resolvedPolicy)
+		return
+	}
+	log.Debug().Str("asset", report.EntityMrn).Msg("federated> no resolved policy to compute stats of merged report")
+}
+
+// mergeReport adds the scores and data of a report with lower precedence
+func (db *Db) mergeReport(dst *policy.Report, src *policy.Report) {
+	if db.merge == MergeNewest && src.Score.ValueModifiedTime > dst.Score.ValueModifiedTime {
+		dst.Score = src.Score
+	}
+
+	if dst.Scores == nil {
+		dst.Scores = map[string]*policy.Score{}
+	}
+	for id, score := range src.Scores {
+		cur, ok := dst.Scores[id]
+		if !ok || (db.merge == MergeNewest && score.ValueModifiedTime > cur.ValueModifiedTime) {
+			dst.Scores[id] = score
+		}
+	}
+
+	if dst.Data == nil {
+		dst.Data = map[string]*llx.Result{}
+	}
+	for id, datum := range src.Data {
+		if _, ok := dst.Data[id]; !ok {
+			dst.Data[id] = datum
+		}
+	}
+
+	if dst.ResolvedPolicyVersion == "" {
+		dst.ResolvedPolicyVersion = src.ResolvedPolicyVersion
+	}
+}
+
+// GetScoreHistory combines the score history of all backends. Changes that
+// were recorded by multiple backends are only returned once.
+func (db *Db) GetScoreHistory(ctx context.Context, assetMrn string, qrID string, window time.Duration) ([]policy.ScoreHistoryEntry, error) {
+	var res []policy.ScoreHistoryEntry
+	var firstErr error
+	seen := map[policy.ScoreHistoryEntry]struct{}{}
+	succeeded := false
+	for _, backend := range db.backends {
+		entries, err := backend.GetScoreHistory(ctx, assetMrn, qrID, window)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		succeeded = true
+
+		for _, entry := range entries {
+			key := entry
+			key.Recorded = entry.Recorded.UTC()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			res = append(res, entry)
+		}
+		if db.merge == MergeFirst && len(entries) != 0 {
+			break
+		}
+	}
+	if !succeeded {
+		return nil, firstErr
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Recorded.Before(res[j].Recorded)
+	})
+
+	// every backend may return its own last change before the window,
+	// only the most recent one of them is kept
+	if window > 0 {
+		start := time.Now().Add(-window)
+		baseline := 0
+		for baseline+1 < len(res) && res[baseline+1].Recorded.Before(start) {
+			baseline++
+		}
+		res = res[baseline:]
+	}
+
+	return res, nil
+}
+
+// GetReportByID returns the stored report from the first backend that has
+// it. Reports with the same ID have the same content, so no merging is
+// needed.
+func (db *Db) GetReportByID(ctx context.Context, id string) (*policy.Report, error) {
+	var firstErr error
+	for _, backend := range db.backends {
+		report, err := backend.GetReportByID(ctx, id)
+		if err == nil {
+			return report, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
@@ -0,0 +1,177 @@
+package federated
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/internal/datalakes/inmemory"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+const testAssetMrn = "//policy.api.mondoo.app/assets/asset1"
+const testSpaceMrn = "//captain.api.mondoo.app/spaces/space1"
+
+// testBackend hands out the report it keeps, like caching data lakes do,
+// and supports none of the optional interfaces
+type testBackend struct {
+	policy.DataLake
+	report   *policy.Report
+	resolved *policy.ResolvedPolicy
+}
+
+func (b *testBackend) GetReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, error) {
+	return b.report, nil
+}
+
+func (b *testBackend) GetResolvedPolicy(ctx context.Context, assetMrn string) (*policy.ResolvedPolicy, error) {
+	return b.resolved, nil
+}
+
+func testReport(scores ...*policy.Score) *policy.Report {
+	res := &policy.Report{
+		EntityMrn:  testAssetMrn,
+		ScoringMrn: testAssetMrn,
+		Score:      &policy.Score{QrId: testAssetMrn, Value: 50, Type: policy.ScoreType_Result},
+		Scores:     map[string]*policy.Score{},
+	}
+	for _, score := range scores {
+		res.Scores[score.QrId] = score
+	}
+	return res
+}
+
+func TestGetReport(t *testing.T) {
+	ctx := context.Background()
+	resolved := &policy.ResolvedPolicy{
+		CollectorJob: &policy.CollectorJob{
+			ReportingQueries: map[string]*policy.StringArray{"ssh": {}, "tls": {}},
+		},
+	}
+	primaryReport := testReport(&policy.Score{QrId: "ssh", Value: 0, Type: policy.ScoreType_Result})
+	primaryReport.ComputeStats(resolved)
+	original := proto.Clone(primaryReport).(*policy.Report)
+	primary := &testBackend{report: primaryReport, resolved: resolved}
+	secondary := &testBackend{
+		report:   testReport(&policy.Score{QrId: "tls", Value: 100, Type: policy.ScoreType_Result}),
+		resolved: resolved,
+	}
+
+	t.Run("merged reports are new reports with their own stats", func(t *testing.T) {
+		db, err := New(MergeFill, primary, secondary)
+		require.NoError(t, err)
+
+		res, err := db.GetReport(ctx, testAssetMrn, testAssetMrn)
+		require.NoError(t, err)
+		assert.Len(t, res.Scores, 2)
+		assert.Equal(t, uint32(2), res.Stats.Total)
+		assert.Equal(t, uint32(1), res.Stats.Failed.Total)
+		assert.Equal(t, uint32(1), res.Stats.Passed.Total)
+
+		assert.True(t, proto.Equal(original, primaryReport), "the primary's report must not change")
+		assert.Len(t, secondary.report.Scores, 1)
+	})
+
+	t.Run("first report is returned as it is", func(t *testing.T) {
+		db, err := New(MergeFirst, primary, secondary)
+		require.NoError(t, err)
+
+		res, err := db.GetReport(ctx, testAssetMrn, testAssetMrn)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(original, res))
+	})
+}
+
+// setupAsset stores an asset that reports the given scores, of which the
+// data lake only has those in stored
+func setupAsset(t *testing.T, db *inmemory.Db, reported []string, stored []string) {
+	ctx := context.Background()
+	jobs := map[string]*policy.ReportingJob{"root": {Uuid: "root", QrId: "root"}}
+	for _, qrID := range reported {
+		jobs[qrID] = &policy.ReportingJob{Uuid: qrID, QrId: qrID}
+	}
+	require.NoError(t, db.EnsureAsset(ctx, testAssetMrn))
+	require.NoError(t, db.SetAssetResolvedPolicy(ctx, testAssetMrn, &policy.ResolvedPolicy{
+		CollectorJob: &policy.CollectorJob{ReportingJobs: jobs},
+	}, policy.V2Code))
+
+	scores := []*policy.Score{{QrId: testAssetMrn, Value: 50, Type: policy.ScoreType_Result}}
+	for _, qrID := range stored {
+		scores = append(scores, &policy.Score{QrId: qrID, Value: 100, Type: policy.ScoreType_Result})
+	}
+	_, err := db.UpdateScores(ctx, testAssetMrn, scores)
+	require.NoError(t, err)
+}
+
+func TestPartialResults(t *testing.T) {
+	ctx := context.Background()
+	primary, _, err := inmemory.NewServices(nil)
+	require.NoError(t, err)
+	secondary, _, err := inmemory.NewServices(nil)
+	require.NoError(t, err)
+	setupAsset(t, primary, []string{"ssh", "tls"}, []string{"ssh"})
+	setupAsset(t, secondary, []string{"ssh", "tls"}, []string{"tls"})
+
+	db, err := New(MergeFill, primary, secondary)
+	require.NoError(t, err)
+	var reader policy.PartialResultsReader = db
+
+	scores, missing, err := reader.GetScoresPartial(ctx, testAssetMrn, []string{"ssh", "tls", "unknown"})
+	require.NoError(t, err)
+	assert.Len(t, scores, 2)
+	assert.Equal(t, []string{"unknown"}, missing)
+
+	report, completeness, err := reader.GetPartialReport(ctx, testAssetMrn, testAssetMrn)
+	require.NoError(t, err)
+	assert.Len(t, report.Scores, 3)
+	require.NotNil(t, completeness)
+	assert.Equal(t, 3, completeness.ScoresTotal)
+	assert.True(t, completeness.Complete())
+
+	// without federation the primary misses a score
+	_, completeness, err = primary.GetPartialReport(ctx, testAssetMrn, testAssetMrn)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tls"}, completeness.MissingScores)
+}
+
+func TestOptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("primary supports them", func(t *testing.T) {
+		primary, _, err := inmemory.NewServices(nil)
+		require.NoError(t, err)
+		db, err := New(MergeFill, primary)
+		require.NoError(t, err)
+
+		var datalake policy.DataLake = db
+		queue, ok := datalake.(policy.UploadQueue)
+		require.True(t, ok)
+		require.NoError(t, queue.EnqueueUpload(ctx, testSpaceMrn, &policy.StoreResultsReq{AssetMrn: testAssetMrn}))
+		pending, err := primary.PendingUploads(ctx, testSpaceMrn, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, testAssetMrn, pending[0].Req.AssetMrn)
+
+		_, ok = datalake.(checkpointStore)
+		assert.True(t, ok)
+	})
+
+	t.Run("primary doesn't support them", func(t *testing.T) {
+		db, err := New(MergeFill, &testBackend{})
+		require.NoError(t, err)
+
+		assert.Error(t, db.EnqueueUpload(ctx, testSpaceMrn, &policy.StoreResultsReq{AssetMrn: testAssetMrn}))
+		pending, err := db.PendingUploads(ctx, testSpaceMrn, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+		assert.Error(t, db.StreamReports(ctx, policy.ReportStreamOptions{}, func(*policy.Report) error { return nil }))
+
+		// assets were never scanned without checkpoints
+		require.NoError(t, db.SetScanCheckpoint(ctx, testAssetMrn, "checksum"))
+		checksum, err := db.GetScanCheckpoint(ctx, testAssetMrn)
+		require.NoError(t, err)
+		assert.Empty(t, checksum)
+	})
+}
@@ -0,0 +1,200 @@
+package federated
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// Optional interfaces of data lakes are found via type assertions, which
+// don't see through the embedded primary. They are implemented here and use
+// the backends that support them.
+
+var (
+	_ policy.ReportStreamer       = (*Db)(nil)
+	_ policy.UploadQueue          = (*Db)(nil)
+	_ policy.PartialResultsReader = (*Db)(nil)
+)
+
+// checkpointStore mirrors the scan checkpoints of the local scanner, see
+// scan.WithResume
+type checkpointStore interface {
+	GetScanCheckpoint(ctx context.Context, assetMrn string) (string, error)
+	GetScanCheckpointTime(ctx context.Context, assetMrn string) (time.Time, error)
+	SetScanCheckpoint(ctx context.Context, assetMrn string, graphExecutionChecksum string) error
+}
+
+var _ checkpointStore = (*Db)(nil)
+
+func errNotSupported(what string) error {
+	return errors.New("the primary data lake does not support " + what)
+}
+
+// StreamReports streams the reports of the primary data lake, which has
+// the reports of all scans that were run with it
+func (db *Db) StreamReports(ctx context.Context, opts policy.ReportStreamOptions, f func(report *policy.Report) error) error {
+	streamer, ok := db.DataLake.(policy.ReportStreamer)
+	if !ok {
+		return errNotSupported("streaming reports")
+	}
+	return streamer.StreamReports(ctx, opts, f)
+}
+
+// EnqueueUpload adds results to the offline queue of the primary data lake
+func (db *Db) EnqueueUpload(ctx context.Context, spaceMrn string, req *policy.StoreResultsReq) error {
+	queue, ok := db.DataLake.(policy.UploadQueue)
+	if !ok {
+		return errNotSupported("queueing uploads")
+	}
+	return queue.EnqueueUpload(ctx, spaceMrn, req)
+}
+
+// PendingUploads returns the queued uploads of the primary data lake
+func (db *Db) PendingUploads(ctx context.Context, spaceMrn string, afterID int64, limit int) ([]*policy.QueuedUpload, error) {
+	queue, ok := db.DataLake.(policy.UploadQueue)
+	if !ok {
+		return nil, nil
+	}
+	return queue.PendingUploads(ctx, spaceMrn, afterID, limit)
+}
+
+// CompleteUpload removes an upload from the queue of the primary data lake
+func (db *Db) CompleteUpload(ctx context.Context, id int64) error {
+	queue, ok := db.DataLake.(policy.UploadQueue)
+	if !ok {
+		return errNotSupported("queueing uploads")
+	}
+	return queue.CompleteUpload(ctx, id)
+}
+
+// FailUpload counts a rejected attempt of an upload in the primary data lake
+func (db *Db) FailUpload(ctx context.Context, id int64, reason string) error {
+	queue, ok := db.DataLake.(policy.UploadQueue)
+	if !ok {
+		return errNotSupported("queueing uploads")
+	}
+	return queue.FailUpload(ctx, id, reason)
+}
+
+// GetScanCheckpoint returns the checkpoint of the last complete scan of an
+// asset. Scans are only recorded in the primary data lake, without support
+// for checkpoints no asset was scanned before.
+func (db *Db) GetScanCheckpoint(ctx context.Context, assetMrn string) (string, error) {
+	checkpoints, ok := db.DataLake.(checkpointStore)
+	if !ok {
+		return "", nil
+	}
+	return checkpoints.GetScanCheckpoint(ctx, assetMrn)
+}
+
+// GetScanCheckpointTime returns when an asset was last scanned completely
+func (db *Db) GetScanCheckpointTime(ctx context.Context, assetMrn string) (time.Time, error) {
+	checkpoints, ok := db.DataLake.(checkpointStore)
+	if !ok {
+		return time.Time{}, nil
+	}
+	return checkpoints.GetScanCheckpointTime(ctx, assetMrn)
+}
+
+// SetScanCheckpoint records a complete scan of an asset in the primary data
+// lake, if it supports checkpoints
+func (db *Db) SetScanCheckpoint(ctx context.Context, assetMrn string, graphExecutionChecksum string) error {
+	checkpoints, ok := db.DataLake.(checkpointStore)
+	if !ok {
+		return nil
+	}
+	return checkpoints.SetScanCheckpoint(ctx, assetMrn, graphExecutionChecksum)
+}
+
+// GetScoresPartial fills in the scores that are missing in one backend from
+// the following ones
+func (db *Db) GetScoresPartial(ctx context.Context, assetMrn string, qrIDs []string) (map[string]*policy.Score, []string, error) {
+	res := make(map[string]*policy.Score, len(qrIDs))
+	missing := qrIDs
+	for _, backend := range db.backends {
+		if len(missing) == 0 {
+			break
+		}
+		reader, ok := backend.(policy.PartialResultsReader)
+		if !ok {
+			continue
+		}
+		scores, stillMissing, err := reader.GetScoresPartial(ctx, assetMrn, missing)
+		if err != nil {
+			return nil, nil, err
+		}
+		for id, score := range scores {
+			res[id] = score
+		}
+		missing = stillMissing
+	}
+
+	missing = append([]string(nil), missing...)
+	sort.Strings(missing)
+	return res, missing, nil
+}
+
+// GetDataPartial fills in the datapoints that are missing in one backend
+// from the following ones
+func (db *Db) GetDataPartial(ctx context.Context, assetMrn string, fields map[string]types.Type) (map[string]*llx.Result, []string, error) {
+	res := make(map[string]*llx.Result, len(fields))
+	missing := fields
+	for _, backend := range db.backends {
+		if len(missing) == 0 {
+			break
+		}
+		reader, ok := backend.(policy.PartialResultsReader)
+		if !ok {
+			continue
+		}
+		data, stillMissing, err := reader.GetDataPartial(ctx, assetMrn, missing)
+		if err != nil {
+			return nil, nil, err
+		}
+		for checksum, datum := range data {
+			res[checksum] = datum
+		}
+		next := make(map[string]types.Type, len(stillMissing))
+		for _, checksum := range stillMissing {
+			next[checksum] = fields[checksum]
+		}
+		missing = next
+	}
+
+	ids := make([]string, 0, len(missing))
+	for checksum := range missing {
+		ids = append(ids, checksum)
+	}
+	sort.Strings(ids)
+	return res, ids, nil
+}
+
+// GetPartialReport merges the partial reports of all backends according to
+// the merge rule. Scores and data that any backend has are not missing.
+func (db *Db) GetPartialReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, *policy.ReportCompleteness, error) {
+	report, completeness, err := db.getReport(ctx, assetMrn, qrID, true)
+	if err != nil || completeness == nil {
+		return report, completeness, err
+	}
+
+	res := &policy.ReportCompleteness{
+		ScoresTotal: completeness.ScoresTotal,
+		DataTotal:   completeness.DataTotal,
+	}
+	for _, id := range completeness.MissingScores {
+		if _, ok := report.Scores[id]; !ok {
+			res.MissingScores = append(res.MissingScores, id)
+		}
+	}
+	for _, checksum := range completeness.MissingData {
+		if _, ok := report.Data[checksum]; !ok {
+			res.MissingData = append(res.MissingData, checksum)
+		}
+	}
+	return report, res, nil
+}
@@ -20,6 +20,11 @@ func (db *Db) EnsureAsset(ctx context.Context, mrn string) error {
 	return err
 }
 
+// ListAssetMrns returns the MRNs of all assets, sorted
+func (db *Db) ListAssetMrns(ctx context.Context) ([]string, error) {
+	return db.activity.all(), nil
+}
+
 func (db *Db) ensureAsset(ctx context.Context, mrn string) (wrapAsset, wrapPolicy, error) {
 	assetw, created, err := db.ensureAssetObject(ctx, mrn)
 	if err != nil {
@@ -62,6 +67,7 @@ func (db *Db) ensureAssetPolicy(ctx context.Context, mrn string) (wrapPolicy, er
 
 func (db *Db) ensureAssetObject(ctx context.Context, mrn string) (wrapAsset, bool, error) {
 	log.Debug().Str("mrn", mrn).Msg("assets> ensure asset")
+	db.touchAsset(mrn)
 
 	x, ok := db.cache.Get(dbIDAsset + mrn)
 	if ok {
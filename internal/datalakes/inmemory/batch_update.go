@@ -0,0 +1,197 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// assetLocks serializes the writes of scores and data per asset, so that a
+// batch update and its rollback don't interleave with other writes and
+// readers don't see half-applied batches
+type assetLocks struct {
+	mu    sync.Mutex
+	locks map[string]*assetLock
+}
+
+type assetLock struct {
+	sync.RWMutex
+	refs int
+}
+
+func (l *assetLocks) acquire(assetMrn string) *assetLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locks == nil {
+		l.locks = map[string]*assetLock{}
+	}
+	lock, ok := l.locks[assetMrn]
+	if !ok {
+		lock = &assetLock{}
+		l.locks[assetMrn] = lock
+	}
+	lock.refs++
+	return lock
+}
+
+func (l *assetLocks) release(assetMrn string, lock *assetLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, assetMrn)
+	}
+}
+
+// lock the asset for writing and return the unlock func
+func (l *assetLocks) lock(assetMrn string) func() {
+	lock := l.acquire(assetMrn)
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		l.release(assetMrn, lock)
+	}
+}
+
+// rlock the asset for reading and return the unlock func
+func (l *assetLocks) rlock(assetMrn string) func() {
+	lock := l.acquire(assetMrn)
+	lock.RLock()
+	return func() {
+		lock.RUnlock()
+		l.release(assetMrn, lock)
+	}
+}
+
+// batchSnapshot keeps the values of all keys that a batch update changes, so
+// that they can be restored if it fails
+type batchSnapshot struct {
+	cache  kvStore
+	blobs  *blobStore
+	values map[string]interface{}
+	exists map[string]bool
+	// scores are updated in place, so their fields are kept instead
+	scores map[*scoreEntry]scoreFields
+}
+
+func (s *batchSnapshot) save(key string) {
+	if _, ok := s.exists[key]; ok {
+		return
+	}
+	s.values[key], s.exists[key] = s.cache.Get(key)
+}
+
+// saveScore keeps the fields of a score, or that it didn't exist
+func (s *batchSnapshot) saveScore(key string) {
+	if _, ok := s.exists[key]; ok {
+		return
+	}
+	x, ok := s.cache.Get(key)
+	if !ok {
+		s.exists[key] = false
+		return
+	}
+	entry := x.(*scoreEntry)
+	entry.mu.Lock()
+	s.scores[entry] = entry.fields
+	entry.mu.Unlock()
+}
+
+func (s *batchSnapshot) restore() {
+	for entry, fields := range s.scores {
+		entry.mu.Lock()
+		entry.fields = fields
+		entry.mu.Unlock()
+	}
+	for key, ok := range s.exists {
+		if strings.HasPrefix(key, dbIDData) {
+			// restore the blob references of datapoints, too
+			var value *llx.Result
+			if ok && s.values[key] != nil {
+				value = s.values[key].(*llx.Result)
+			}
+			s.blobs.share(key, value)
+		}
+		if ok {
+			s.cache.Set(key, s.values[key], 1)
+		} else {
+			s.cache.Del(key)
+		}
+	}
+}
+
+// ApplyBatch validates all scores and data of the batch and stores them
+// together, see policy.BatchUpdater. If storing any entry fails, all
+// previous values are restored. The asset is locked for the whole batch.
+func (db *Db) ApplyBatch(ctx context.Context, batch *policy.BatchUpdate) (*policy.BatchUpdateResult, error) {
+	db.touchAsset(batch.AssetMrn)
+	defer db.assetLocks.lock(batch.AssetMrn)()
+
+	collectorJob, err := db.GetCollectorJob(ctx, batch.AssetMrn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find collectorJob to store data: %w", err)
+	}
+
+	res, data := policy.ValidateBatch(batch, collectorJob, db.coercion)
+	if !res.Applied {
+		return res, nil
+	}
+
+	assetMrn := batch.AssetMrn
+	snapshot := &batchSnapshot{
+		cache:  db.cache,
+		blobs:  db.blobs,
+		values: map[string]interface{}{},
+		exists: map[string]bool{},
+		scores: map[*scoreEntry]scoreFields{},
+	}
+	snapshot.save(dbIDDataWarnings + assetMrn)
+	snapshot.save(dbIDDataCollected + assetMrn)
+
+	failed, err := func() (*policy.BatchEntryResult, error) {
+		now := db.nowProvider().Unix()
+		keys := db.scoreKeys.asset(assetMrn)
+		for i := range batch.Scores {
+			score := batch.Scores[i]
+			entry := res.Entry(policy.BatchEntryScore, score.QrId)
+			key := keys.key(score.QrId)
+			snapshot.saveScore(key)
+			ok, err := db.updateScore(ctx, assetMrn, key, score, now)
+			if err != nil {
+				return entry, err
+			}
+			entry.Updated = ok
+		}
+
+		collected := make(map[string]types.Type, len(data))
+		for checksum, val := range data {
+			entry := res.Entry(policy.BatchEntryData, checksum)
+			snapshot.save(dbIDData + assetMrn + "\x00" + checksum)
+			if err := db.setDatum(ctx, assetMrn, checksum, val); err != nil {
+				return entry, err
+			}
+			if err := db.setDataWarning(assetMrn, checksum, entry.Warning); err != nil {
+				return entry, err
+			}
+			entry.Updated = true
+			collected[checksum] = entry.Type
+		}
+		return nil, db.setDataCollected(assetMrn, collected)
+	}()
+	if err != nil {
+		snapshot.restore()
+		if failed == nil {
+			return nil, err
+		}
+		res.RolledBack(failed, err)
+	}
+
+	return res, nil
+}
+
+var _ policy.BatchUpdater = (*Db)(nil)
@@ -0,0 +1,186 @@
+package inmemory
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// failingStore fails to set all keys that contain fail
+type failingStore struct {
+	kvStore
+	fail string
+}
+
+func (s *failingStore) Set(key interface{}, value interface{}, cost int64) bool {
+	if strings.Contains(key.(string), s.fail) {
+		return false
+	}
+	return s.kvStore.Set(key, value, cost)
+}
+
+func setupBatchAsset(t *testing.T, db *Db, assetMrn string) {
+	ctx := context.Background()
+	require.NoError(t, db.EnsureAsset(ctx, assetMrn))
+	require.NoError(t, db.SetAssetResolvedPolicy(ctx, assetMrn, &policy.ResolvedPolicy{
+		GraphExecutionChecksum: "checksum",
+		CollectorJob: &policy.CollectorJob{
+			Datapoints: map[string]*policy.DataQueryInfo{
+				"hostname": {Type: string(types.String)},
+				"kernel":   {Type: string(types.String)},
+			},
+		},
+	}, policy.V2Code))
+}
+
+func stringResult(codeID string, value string) *llx.Result {
+	return (&llx.RawResult{Data: llx.StringData(value), CodeID: codeID}).Result()
+}
+
+func TestApplyBatch(t *testing.T) {
+	ctx := context.Background()
+	assetMrn := "//policy.api.mondoo.app/assets/web-01"
+
+	t.Run("all entries are stored", func(t *testing.T) {
+		db, _, err := NewServices(nil)
+		require.NoError(t, err)
+		setupBatchAsset(t, db, assetMrn)
+
+		res, err := db.ApplyBatch(ctx, &policy.BatchUpdate{
+			AssetMrn: assetMrn,
+			Scores:   []*policy.Score{{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result}},
+			Data:     map[string]*llx.Result{"hostname": stringResult("hostname", "web-01")},
+		})
+		require.NoError(t, err)
+		require.NoError(t, res.Err())
+		assert.True(t, res.Entry(policy.BatchEntryScore, "ssh").Updated)
+		assert.True(t, res.Entry(policy.BatchEntryData, "hostname").Updated)
+
+		score, err := db.GetScore(ctx, assetMrn, "ssh")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), score.Value)
+		data, err := db.GetData(ctx, assetMrn, map[string]types.Type{"hostname": types.String})
+		require.NoError(t, err)
+		assert.Equal(t, "web-01", data["hostname"].RawResultV2().Data.Value)
+	})
+
+	t.Run("invalid batches are not stored", func(t *testing.T) {
+		db, _, err := NewServices(nil)
+		require.NoError(t, err)
+		setupBatchAsset(t, db, assetMrn)
+
+		res, err := db.ApplyBatch(ctx, &policy.BatchUpdate{
+			AssetMrn: assetMrn,
+			Scores:   []*policy.Score{{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result}},
+			Data:     map[string]*llx.Result{"unknown": stringResult("unknown", "x")},
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Applied)
+		_, err = db.GetScore(ctx, assetMrn, "ssh")
+		assert.Error(t, err)
+	})
+
+	t.Run("failed batches are rolled back", func(t *testing.T) {
+		db, _, err := NewServices(nil)
+		require.NoError(t, err)
+		setupBatchAsset(t, db, assetMrn)
+		_, err = db.ApplyBatch(ctx, &policy.BatchUpdate{
+			AssetMrn: assetMrn,
+			Scores:   []*policy.Score{{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result}},
+			Data:     map[string]*llx.Result{"hostname": stringResult("hostname", "web-01")},
+		})
+		require.NoError(t, err)
+
+		db.cache = &failingStore{kvStore: db.cache, fail: "kernel"}
+		res, err := db.ApplyBatch(ctx, &policy.BatchUpdate{
+			AssetMrn: assetMrn,
+			Scores:   []*policy.Score{{QrId: "ssh", Value: 0, Type: policy.ScoreType_Result}, {QrId: "tls", Value: 50, Type: policy.ScoreType_Result}},
+			Data: map[string]*llx.Result{
+				"hostname": stringResult("hostname", "web-02"),
+				"kernel":   stringResult("kernel", "6.1"),
+			},
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Applied)
+		assert.NotEmpty(t, res.Entry(policy.BatchEntryData, "kernel").Error)
+		for _, entry := range res.Entries {
+			assert.False(t, entry.Updated)
+		}
+
+		// the previous values are back and new entries are gone, datapoints
+		// without values are kept empty
+		score, err := db.GetScore(ctx, assetMrn, "ssh")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), score.Value)
+		_, err = db.GetScore(ctx, assetMrn, "tls")
+		assert.Error(t, err)
+		data, err := db.GetData(ctx, assetMrn, map[string]types.Type{"hostname": types.String, "kernel": types.String})
+		require.NoError(t, err)
+		assert.Equal(t, "web-01", data["hostname"].RawResultV2().Data.Value)
+		assert.Nil(t, data["kernel"])
+	})
+
+	t.Run("concurrent batches of an asset", func(t *testing.T) {
+		db, _, err := NewServices(nil)
+		require.NoError(t, err)
+		setupBatchAsset(t, db, assetMrn)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := db.ApplyBatch(ctx, &policy.BatchUpdate{
+					AssetMrn: assetMrn,
+					Scores:   []*policy.Score{{QrId: "ssh", Value: uint32(i), Type: policy.ScoreType_Result}},
+					Data:     map[string]*llx.Result{"hostname": stringResult("hostname", "web-"+strconv.Itoa(i))},
+				})
+				assert.NoError(t, err)
+			}(i)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				db.GetScoresPartial(ctx, assetMrn, []string{"ssh"})
+				db.GetDataPartial(ctx, assetMrn, map[string]types.Type{"hostname": types.String})
+			}()
+		}
+		wg.Wait()
+		assert.Empty(t, db.assetLocks.locks)
+	})
+}
+
+func TestStoreResultsBatch(t *testing.T) {
+	ctx := context.Background()
+	db, services, err := NewServices(nil)
+	require.NoError(t, err)
+	assetMrn := "//policy.api.mondoo.app/assets/web-01"
+	setupBatchAsset(t, db, assetMrn)
+
+	// scores and data of one request are stored together or not at all
+	_, err = services.StoreResults(ctx, &policy.StoreResultsReq{
+		AssetMrn: assetMrn,
+		Scores:   []*policy.Score{{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result}},
+		Data:     map[string]*llx.Result{"unknown": stringResult("unknown", "x")},
+	})
+	assert.ErrorContains(t, err, "batch update was not applied")
+	_, err = db.GetScore(ctx, assetMrn, "ssh")
+	assert.Error(t, err)
+
+	_, err = services.StoreResults(ctx, &policy.StoreResultsReq{
+		AssetMrn: assetMrn,
+		Scores:   []*policy.Score{{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result}},
+		Data:     map[string]*llx.Result{"hostname": stringResult("hostname", "web-01")},
+	})
+	require.NoError(t, err)
+	score, err := db.GetScore(ctx, assetMrn, "ssh")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(100), score.Value)
+}
@@ -0,0 +1,103 @@
+package inmemory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"go.mondoo.com/cnquery/llx"
+	"google.golang.org/protobuf/proto"
+)
+
+// BlobThreshold is the size in bytes from which datapoints are shared
+// content-addressed. Identical large values, like file contents or
+// certificates, are then kept only once across all assets.
+const BlobThreshold = 4 * 1024
+
+type blob struct {
+	value *llx.Result
+	refs  int
+}
+
+// blobStore keeps large datapoint values once and counts which datapoints
+// reference them. It is safe for concurrent use.
+type blobStore struct {
+	mu    sync.Mutex
+	blobs map[string]*blob  // hash => blob
+	refs  map[string]string // datapoint key => hash
+}
+
+func newBlobStore() *blobStore {
+	return &blobStore{
+		blobs: map[string]*blob{},
+		refs:  map[string]string{},
+	}
+}
+
+// share returns the value that is stored for the datapoint key. Large values
+// are replaced by an identical one that is already stored, if it exists.
+// The blob that the key referenced before is released.
+func (s *blobStore) share(key string, value *llx.Result) (*llx.Result, error) {
+	hash := ""
+	if value != nil {
+		data, err := proto.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) >= BlobThreshold {
+			sum := sha256.Sum256(data)
+			hash = hex.EncodeToString(sum[:])
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldHash := s.refs[key]
+	if hash == "" {
+		s.release(key)
+		return value, nil
+	}
+	if hash == oldHash {
+		return s.blobs[hash].value, nil
+	}
+
+	b, ok := s.blobs[hash]
+	if !ok {
+		b = &blob{value: value}
+		s.blobs[hash] = b
+	}
+	b.refs++
+	s.release(key)
+	s.refs[key] = hash
+	return b.value, nil
+}
+
+// releasePrefix releases the blobs of all datapoint keys with the prefix
+func (s *blobStore) releasePrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.refs {
+		if strings.HasPrefix(key, prefix) {
+			s.release(key)
+		}
+	}
+}
+
+// release removes the reference of the datapoint key and deletes its blob
+// once it is not referenced anymore. The lock must be held.
+func (s *blobStore) release(key string) {
+	hash, ok := s.refs[key]
+	if !ok {
+		return
+	}
+	delete(s.refs, key)
+
+	b := s.blobs[hash]
+	b.refs--
+	if b.refs <= 0 {
+		delete(s.blobs, hash)
+	}
+}
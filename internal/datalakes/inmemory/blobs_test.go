@@ -0,0 +1,85 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+func TestBlobStore(t *testing.T) {
+	large := func(s string) *llx.Result {
+		return llx.StringData(strings.Repeat(s, BlobThreshold)).Result()
+	}
+	s := newBlobStore()
+
+	t.Run("identical values share one blob", func(t *testing.T) {
+		a, err := s.share("1", large("a"))
+		require.NoError(t, err)
+		b, err := s.share("2", large("a"))
+		require.NoError(t, err)
+		assert.Same(t, a, b)
+		require.Len(t, s.blobs, 1)
+		for _, blob := range s.blobs {
+			assert.Equal(t, 2, blob.refs)
+		}
+
+		// storing the same value again keeps the reference
+		_, err = s.share("1", large("a"))
+		require.NoError(t, err)
+		for _, blob := range s.blobs {
+			assert.Equal(t, 2, blob.refs)
+		}
+	})
+
+	t.Run("small values are not shared", func(t *testing.T) {
+		small := llx.StringData("small").Result()
+		res, err := s.share("3", small)
+		require.NoError(t, err)
+		assert.Same(t, small, res)
+		assert.Len(t, s.blobs, 1)
+	})
+
+	t.Run("overwriting a value releases its blob", func(t *testing.T) {
+		_, err := s.share("1", large("b"))
+		require.NoError(t, err)
+		require.Len(t, s.blobs, 2)
+		for _, blob := range s.blobs {
+			assert.Equal(t, 1, blob.refs)
+		}
+	})
+
+	t.Run("the last release deletes the blob", func(t *testing.T) {
+		_, err := s.share("2", nil)
+		require.NoError(t, err)
+		assert.Len(t, s.blobs, 1)
+
+		s.releasePrefix("1")
+		assert.Empty(t, s.blobs)
+		assert.Empty(t, s.refs)
+	})
+}
+
+func TestPurgeAssetReleasesBlobs(t *testing.T) {
+	db, _, err := NewServices(nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	value := llx.StringData(strings.Repeat("a", BlobThreshold)).Result()
+	require.NoError(t, db.setDatum(ctx, "//assets/1", "checksum", value))
+	require.NoError(t, db.setDatum(ctx, "//assets/2", "checksum", value))
+	require.Len(t, db.blobs.blobs, 1)
+
+	require.NoError(t, db.PurgeAsset(ctx, "//assets/1"))
+	assert.Len(t, db.blobs.blobs, 1)
+	data, _, err := db.GetDataPartial(ctx, "//assets/2", map[string]types.Type{"checksum": types.String})
+	require.NoError(t, err)
+	assert.Same(t, value, data["checksum"])
+
+	require.NoError(t, db.PurgeAsset(ctx, "//assets/2"))
+	assert.Empty(t, db.blobs.blobs)
+}
@@ -0,0 +1,51 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// GetDataCollected returns when all datapoints of an asset were last
+// collected, by checksum
+func (db *Db) GetDataCollected(ctx context.Context, assetMrn string) (map[string]time.Time, error) {
+	x, ok := db.cache.Get(dbIDDataCollected + assetMrn)
+	if !ok {
+		return map[string]time.Time{}, nil
+	}
+
+	collected := x.(map[string]int64)
+	res := make(map[string]time.Time, len(collected))
+	for k, v := range collected {
+		res[k] = time.Unix(v, 0)
+	}
+	return res, nil
+}
+
+// setDataCollected records that the given datapoints were collected now
+func (db *Db) setDataCollected(assetMrn string, checksums map[string]types.Type) error {
+	if len(checksums) == 0 {
+		return nil
+	}
+
+	res := map[string]int64{}
+	if x, ok := db.cache.Get(dbIDDataCollected + assetMrn); ok {
+		for k, v := range x.(map[string]int64) {
+			res[k] = v
+		}
+	}
+	now := db.nowProvider().Unix()
+	for checksum := range checksums {
+		res[checksum] = now
+	}
+
+	if ok := db.cache.Set(dbIDDataCollected+assetMrn, res, 1); !ok {
+		return errors.New("failed to save collection time of data for asset '" + assetMrn + "'")
+	}
+	return nil
+}
+
+var _ policy.DataFreshnessStore = (*Db)(nil)
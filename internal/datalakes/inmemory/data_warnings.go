@@ -0,0 +1,60 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetCoercion configures which type mismatches of stored data are coerced
+// into the expected type of their datapoint, see policy.CoerceResult
+func (db *Db) SetCoercion(opts policy.CoercionOptions) {
+	db.coercion = opts
+}
+
+// GetDataWarnings returns the warnings of all datapoints of an asset, by checksum
+func (db *Db) GetDataWarnings(ctx context.Context, assetMrn string) (map[string]string, error) {
+	x, ok := db.cache.Get(dbIDDataWarnings + assetMrn)
+	if !ok {
+		return map[string]string{}, nil
+	}
+
+	warnings := x.(map[string]string)
+	res := make(map[string]string, len(warnings))
+	for k, v := range warnings {
+		res[k] = v
+	}
+	return res, nil
+}
+
+// setDataWarning sets or, if the warning is empty, clears the warning of a datapoint
+func (db *Db) setDataWarning(assetMrn string, checksum string, warning string) error {
+	x, ok := db.cache.Get(dbIDDataWarnings + assetMrn)
+	if !ok && warning == "" {
+		return nil
+	}
+
+	res := map[string]string{}
+	if ok {
+		existing := x.(map[string]string)
+		if existing[checksum] == warning {
+			return nil
+		}
+		for k, v := range existing {
+			res[k] = v
+		}
+	}
+	if warning == "" {
+		delete(res, checksum)
+	} else {
+		res[checksum] = warning
+	}
+
+	if ok := db.cache.Set(dbIDDataWarnings+assetMrn, res, 1); !ok {
+		return errors.New("failed to save data warning for asset '" + assetMrn + "' and checksum '" + checksum + "'")
+	}
+	return nil
+}
+
+var _ policy.DataWarningStore = (*Db)(nil)
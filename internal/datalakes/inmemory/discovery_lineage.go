@@ -0,0 +1,96 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// SetDiscoveryLineage stores the lineage of an asset
+func (db *Db) SetDiscoveryLineage(ctx context.Context, assetMrn string, lineage *policy.DiscoveryLineage) error {
+	if lineage == nil {
+		db.purgeDiscoveryLineage(assetMrn)
+		return nil
+	}
+
+	db.lineageLock.Lock()
+	defer db.lineageLock.Unlock()
+
+	db.removeLineageAsset(assetMrn)
+	l := proto.Clone(lineage).(*policy.DiscoveryLineage)
+	if ok := db.cache.Set(dbIDLineage+assetMrn, l, 1); !ok {
+		return errors.New("failed to save discovery lineage of asset '" + assetMrn + "'")
+	}
+
+	// assets are looked up by their correlation ID
+	var mrns []string
+	if x, ok := db.cache.Get(dbIDLineageAssets + l.CorrelationId); ok {
+		mrns = x.([]string)
+	}
+	mrns = append(mrns[:len(mrns):len(mrns)], assetMrn)
+	db.cache.Set(dbIDLineageAssets+l.CorrelationId, mrns, 1)
+	return nil
+}
+
+// GetDiscoveryLineage returns the lineage of an asset, nil if it is unknown
+func (db *Db) GetDiscoveryLineage(ctx context.Context, assetMrn string) (*policy.DiscoveryLineage, error) {
+	x, ok := db.cache.Get(dbIDLineage + assetMrn)
+	if !ok {
+		return nil, nil
+	}
+	return proto.Clone(x.(*policy.DiscoveryLineage)).(*policy.DiscoveryLineage), nil
+}
+
+// ListDiscoveredAssets returns the MRNs of all assets with the given
+// correlation ID, sorted
+func (db *Db) ListDiscoveredAssets(ctx context.Context, correlationID string) ([]string, error) {
+	x, ok := db.cache.Get(dbIDLineageAssets + correlationID)
+	if !ok {
+		return []string{}, nil
+	}
+	mrns := x.([]string)
+	res := make([]string, len(mrns))
+	copy(res, mrns)
+	sort.Strings(res)
+	return res, nil
+}
+
+// removeLineageAsset removes the asset from the index of its previous
+// correlation ID. The caller must hold the lineage lock.
+func (db *Db) removeLineageAsset(assetMrn string) {
+	x, ok := db.cache.Get(dbIDLineage + assetMrn)
+	if !ok {
+		return
+	}
+	correlationID := x.(*policy.DiscoveryLineage).CorrelationId
+
+	y, ok := db.cache.Get(dbIDLineageAssets + correlationID)
+	if !ok {
+		return
+	}
+	mrns := y.([]string)
+	res := make([]string, 0, len(mrns))
+	for _, mrn := range mrns {
+		if mrn != assetMrn {
+			res = append(res, mrn)
+		}
+	}
+	if len(res) == 0 {
+		db.cache.Del(dbIDLineageAssets + correlationID)
+		return
+	}
+	db.cache.Set(dbIDLineageAssets+correlationID, res, 1)
+}
+
+// purgeDiscoveryLineage removes the lineage of an asset
+func (db *Db) purgeDiscoveryLineage(assetMrn string) {
+	db.lineageLock.Lock()
+	defer db.lineageLock.Unlock()
+	db.removeLineageAsset(assetMrn)
+	db.cache.Del(dbIDLineage + assetMrn)
+}
+
+var _ policy.DiscoveryLineageStore = (*Db)(nil)
@@ -0,0 +1,63 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetException stores an exception, it replaces any exception for the
+// same check on the same entity
+func (db *Db) SetException(ctx context.Context, exception *policy.Exception) error {
+	existing, err := db.ListExceptions(ctx, exception.EntityMrn)
+	if err != nil {
+		return err
+	}
+
+	res := make([]*policy.Exception, 0, len(existing)+1)
+	for i := range existing {
+		if existing[i].CheckMrn != exception.CheckMrn {
+			res = append(res, existing[i])
+		}
+	}
+	res = append(res, exception)
+
+	ok := db.cache.Set(dbIDExceptions+exception.EntityMrn, res, 1)
+	if !ok {
+		return errors.New("failed to save exception for check '" + exception.CheckMrn + "' on '" + exception.EntityMrn + "'")
+	}
+	return nil
+}
+
+// DeleteException removes the exception for a check on an entity
+func (db *Db) DeleteException(ctx context.Context, entityMrn string, checkMrn string) error {
+	existing, err := db.ListExceptions(ctx, entityMrn)
+	if err != nil {
+		return err
+	}
+
+	res := make([]*policy.Exception, 0, len(existing))
+	for i := range existing {
+		if existing[i].CheckMrn != checkMrn {
+			res = append(res, existing[i])
+		}
+	}
+
+	ok := db.cache.Set(dbIDExceptions+entityMrn, res, 1)
+	if !ok {
+		return errors.New("failed to delete exception for check '" + checkMrn + "' on '" + entityMrn + "'")
+	}
+	return nil
+}
+
+// ListExceptions returns all exceptions of an entity, including expired ones
+func (db *Db) ListExceptions(ctx context.Context, entityMrn string) ([]*policy.Exception, error) {
+	x, ok := db.cache.Get(dbIDExceptions + entityMrn)
+	if !ok {
+		return nil, nil
+	}
+	return x.([]*policy.Exception), nil
+}
+
+var _ policy.ExceptionStore = (*Db)(nil)
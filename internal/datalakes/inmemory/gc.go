@@ -0,0 +1,202 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// GCOptions configures the garbage collection of assets
+type GCOptions struct {
+	// AssetTTL is the time after which assets that had no activity are purged.
+	// Activity is anything that creates an asset, resolves its policy or
+	// stores its results.
+	AssetTTL time.Duration
+	// Interval is the time between two collections, it defaults to a tenth
+	// of the AssetTTL
+	Interval time.Duration
+}
+
+// assetActivity tracks when assets were last active
+type assetActivity struct {
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+}
+
+func newAssetActivity() *assetActivity {
+	return &assetActivity{
+		lastSeen: map[string]time.Time{},
+	}
+}
+
+func (a *assetActivity) touch(assetMrn string, now time.Time) {
+	a.mu.Lock()
+	a.lastSeen[assetMrn] = now
+	a.mu.Unlock()
+}
+
+func (a *assetActivity) remove(assetMrn string) {
+	a.mu.Lock()
+	delete(a.lastSeen, assetMrn)
+	a.mu.Unlock()
+}
+
+// inactiveSince returns all assets that had no activity since the given time
+func (a *assetActivity) inactiveSince(t time.Time) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	res := []string{}
+	for mrn, lastSeen := range a.lastSeen {
+		if lastSeen.Before(t) {
+			res = append(res, mrn)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// all returns all tracked assets
+func (a *assetActivity) all() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	res := make([]string, 0, len(a.lastSeen))
+	for mrn := range a.lastSeen {
+		res = append(res, mrn)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (db *Db) touchAsset(assetMrn string) {
+	db.activity.touch(assetMrn, db.nowProvider())
+}
+
+// PurgeAsset removes an asset with its policy, resolved policy, scores,
+// score history, datapoints, data warnings, exceptions, stored reports and
+// discovery lineage. The policy conflicts and impact provenance of its
+// resolved policy are removed once no other asset uses the resolved policy.
+// Resolved policies that are cached by their checksums are left to expire in
+// the resolved policy cache.
+func (db *Db) PurgeAsset(ctx context.Context, assetMrn string) error {
+	if assetMrn == "" {
+		return errors.New("cannot purge asset without MRN")
+	}
+
+	if _, ok := db.cache.Get(dbIDPolicy + assetMrn); ok {
+		if err := db.DeletePolicy(ctx, assetMrn); err != nil {
+			return errors.New("failed to purge policy of asset '" + assetMrn + "': " + err.Error())
+		}
+	}
+	db.cache.Del(dbIDBundle + assetMrn)
+
+	var resolvedPolicy *policy.ResolvedPolicy
+	if x, ok := db.cache.Get(dbIDAsset + assetMrn); ok {
+		resolvedPolicy = x.(wrapAsset).ResolvedPolicy
+	}
+
+	scores := db.purgeScores(assetMrn)
+	data := db.cache.DelPrefix(dbIDData + assetMrn + "\x00")
+	db.blobs.releasePrefix(dbIDData + assetMrn + "\x00")
+	db.cache.DelPrefix(dbIDScoreHistory + assetMrn + "\x00")
+	db.cache.Del(dbIDExceptions + assetMrn)
+	db.cache.Del(dbIDDataWarnings + assetMrn)
+	db.cache.Del(dbIDDataCollected + assetMrn)
+	db.purgeReports(assetMrn)
+	db.purgeDiscoveryLineage(assetMrn)
+	db.cache.Del(dbIDAsset + assetMrn)
+	db.activity.remove(assetMrn)
+	db.purgeResolutionDetails(resolvedPolicy)
+
+	log.Debug().
+		Str("asset", assetMrn).
+		Int("scores", scores).
+		Int("datapoints", data).
+		Msg("inmemory> purged asset")
+	return nil
+}
+
+// purgeResolutionDetails removes the policy conflicts and impact provenance
+// of a resolved policy, unless another asset still uses it
+func (db *Db) purgeResolutionDetails(resolvedPolicy *policy.ResolvedPolicy) {
+	if resolvedPolicy == nil {
+		return
+	}
+	for _, mrn := range db.activity.all() {
+		x, ok := db.cache.Get(dbIDAsset + mrn)
+		if !ok {
+			continue
+		}
+		other := x.(wrapAsset).ResolvedPolicy
+		if other != nil &&
+			other.GraphExecutionChecksum == resolvedPolicy.GraphExecutionChecksum &&
+			other.FiltersChecksum == resolvedPolicy.FiltersChecksum {
+			return
+		}
+	}
+
+	key := resolvedPolicy.GraphExecutionChecksum + "\x00" + resolvedPolicy.FiltersChecksum
+	db.cache.Del(dbIDConflicts + key)
+	db.cache.Del(dbIDImpacts + key)
+}
+
+// CollectGarbage purges all assets that had no activity within the TTL and
+// returns their MRNs
+func (db *Db) CollectGarbage(ctx context.Context, ttl time.Duration) ([]string, error) {
+	if ttl <= 0 {
+		return nil, errors.New("cannot collect garbage without a TTL")
+	}
+
+	assets := db.activity.inactiveSince(db.nowProvider().Add(-ttl))
+	for i := range assets {
+		if err := db.PurgeAsset(ctx, assets[i]); err != nil {
+			return assets[:i], err
+		}
+	}
+	return assets, nil
+}
+
+// StartGC periodically collects garbage until the context is done. Started
+// on the shared space, it also collects the garbage of all owner spaces.
+func (db *Db) StartGC(ctx context.Context, opts GCOptions) error {
+	if opts.AssetTTL <= 0 {
+		return errors.New("cannot start garbage collection without an asset TTL")
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = opts.AssetTTL / 10
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// the shared space collects the garbage of all owner spaces
+				spaces := []*Db{db}
+				if db.ownerMrn == "" {
+					spaces = append(spaces, db.owners.all()...)
+				}
+				for _, space := range spaces {
+					purged, err := space.CollectGarbage(ctx, opts.AssetTTL)
+					if err != nil {
+						log.Error().Err(err).Str("owner", space.ownerMrn).Msg("inmemory> failed to collect garbage")
+					} else if len(purged) != 0 {
+						log.Debug().Str("owner", space.ownerMrn).Int("assets", len(purged)).Msg("inmemory> collected garbage")
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,176 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// setupTestAsset stores a policy, resolved policy, scores, score history,
+// data, a report and an exception for the asset
+func setupTestAsset(t *testing.T, db *Db, assetMrn string) {
+	ctx := context.Background()
+	require.NoError(t, db.EnsureAsset(ctx, assetMrn))
+	require.NoError(t, db.SetAssetResolvedPolicy(ctx, assetMrn, &policy.ResolvedPolicy{
+		GraphExecutionChecksum: "checksum",
+		CollectorJob: &policy.CollectorJob{
+			Datapoints: map[string]*policy.DataQueryInfo{
+				"hostname": {Type: string(types.String)},
+			},
+			ReportingJobs: map[string]*policy.ReportingJob{
+				"root": {Uuid: "root", QrId: "root"},
+			},
+		},
+	}, policy.V2Code))
+
+	scores := []*policy.Score{{QrId: assetMrn, Value: 80, Type: policy.ScoreType_Result, ScoreCompletion: 100}}
+	_, err := db.UpdateScores(ctx, assetMrn, scores)
+	require.NoError(t, err)
+	require.NoError(t, db.AppendScoreHistory(ctx, assetMrn, scores))
+	_, err = db.UpdateData(ctx, assetMrn, map[string]*llx.Result{
+		"hostname": (&llx.RawResult{Data: llx.StringData("web-01"), CodeID: "hostname"}).Result(),
+	})
+	require.NoError(t, err)
+	_, err = db.StoreReport(ctx, "report-"+assetMrn, &policy.Report{EntityMrn: assetMrn, ScoringMrn: assetMrn})
+	require.NoError(t, err)
+	require.NoError(t, db.SetException(ctx, &policy.Exception{
+		CheckMrn:      "//local.cnspec.io/run/local-execution/queries/ssh-check",
+		EntityMrn:     assetMrn,
+		Justification: "bastion host",
+	}))
+}
+
+// keysOf returns all keys of the datalake that refer to the asset
+func keysOf(db *Db, assetMrn string) []string {
+	kiss := db.cache.(*kissDb)
+	kiss.mu.Lock()
+	defer kiss.mu.Unlock()
+
+	res := []string{}
+	for k, v := range kiss.data {
+		if strings.Contains(k, assetMrn) {
+			res = append(res, k)
+			continue
+		}
+		if report, ok := v.(*policy.Report); ok && report.EntityMrn == assetMrn {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+func TestPurgeAsset(t *testing.T) {
+	ctx := context.Background()
+	db, _, err := NewServices(nil)
+	require.NoError(t, err)
+
+	purged := "//policy.api.mondoo.app/assets/purged"
+	kept := "//policy.api.mondoo.app/assets/kept"
+	setupTestAsset(t, db, purged)
+	setupTestAsset(t, db, kept)
+	require.NotEmpty(t, keysOf(db, purged))
+
+	require.NoError(t, db.PurgeAsset(ctx, purged))
+	assert.Empty(t, keysOf(db, purged))
+
+	_, err = db.GetScore(ctx, purged, purged)
+	assert.Error(t, err)
+	_, err = db.GetReportByID(ctx, "report-"+purged)
+	assert.Error(t, err)
+	exceptions, err := db.ListExceptions(ctx, purged)
+	require.NoError(t, err)
+	assert.Empty(t, exceptions)
+
+	// other assets are not affected
+	score, err := db.GetScore(ctx, kept, kept)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(80), score.Value)
+	_, err = db.GetReportByID(ctx, "report-"+kept)
+	assert.NoError(t, err)
+	exceptions, err = db.ListExceptions(ctx, kept)
+	require.NoError(t, err)
+	assert.Len(t, exceptions, 1)
+
+	assert.Error(t, db.PurgeAsset(ctx, ""))
+}
+
+func TestPurgeAsset_ResolutionDetails(t *testing.T) {
+	ctx := context.Background()
+	db, _, err := NewServices(nil)
+	require.NoError(t, err)
+
+	first := "//policy.api.mondoo.app/assets/first"
+	second := "//policy.api.mondoo.app/assets/second"
+	setupTestAsset(t, db, first)
+	setupTestAsset(t, db, second)
+
+	// both assets share the resolved policy of setupTestAsset
+	resolvedPolicy := &policy.ResolvedPolicy{GraphExecutionChecksum: "checksum"}
+	require.NoError(t, db.SetResolutionConflicts(ctx, resolvedPolicy, []*policy.PolicyConflict{{}}))
+	require.NoError(t, db.SetImpactProvenance(ctx, resolvedPolicy, map[string][]*policy.ImpactProvenance{
+		"check": {{Policy: "//policy"}},
+	}))
+
+	require.NoError(t, db.PurgeAsset(ctx, first))
+	conflicts, err := db.GetResolutionConflicts(ctx, resolvedPolicy)
+	require.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	impacts, err := db.GetImpactProvenance(ctx, resolvedPolicy)
+	require.NoError(t, err)
+	assert.Len(t, impacts, 1)
+
+	require.NoError(t, db.PurgeAsset(ctx, second))
+	conflicts, err = db.GetResolutionConflicts(ctx, resolvedPolicy)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	impacts, err = db.GetImpactProvenance(ctx, resolvedPolicy)
+	require.NoError(t, err)
+	assert.Empty(t, impacts)
+}
+
+func TestCollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	db, _, err := NewServices(nil)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	db.SetNowProvider(func() time.Time { return now })
+
+	stale := "//policy.api.mondoo.app/assets/stale"
+	active := "//policy.api.mondoo.app/assets/active"
+	setupTestAsset(t, db, stale)
+
+	now = now.Add(2 * time.Hour)
+	setupTestAsset(t, db, active)
+
+	now = now.Add(30 * time.Minute)
+	purged, err := db.CollectGarbage(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{stale}, purged)
+	assert.Empty(t, keysOf(db, stale))
+	assert.NotEmpty(t, keysOf(db, active))
+
+	// activity keeps assets alive
+	now = now.Add(45 * time.Minute)
+	_, err = db.UpdateScores(ctx, active, []*policy.Score{{QrId: active, Value: 90, Type: policy.ScoreType_Result}})
+	require.NoError(t, err)
+	now = now.Add(45 * time.Minute)
+	purged, err = db.CollectGarbage(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+
+	now = now.Add(2 * time.Hour)
+	purged, err = db.CollectGarbage(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{active}, purged)
+
+	_, err = db.CollectGarbage(ctx, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetImpactProvenance stores the impact provenance that was recorded while resolving a policy
+func (db *Db) SetImpactProvenance(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy, provenance map[string][]*policy.ImpactProvenance) error {
+	key := dbIDImpacts + resolvedPolicy.GraphExecutionChecksum + "\x00" + resolvedPolicy.FiltersChecksum
+	if len(provenance) == 0 {
+		db.cache.Del(key)
+		return nil
+	}
+
+	ok := db.cache.Set(key, provenance, 1)
+	if !ok {
+		return errors.New("failed to save impact provenance for resolved policy '" + resolvedPolicy.GraphExecutionChecksum + "'")
+	}
+	return nil
+}
+
+// GetImpactProvenance returns the impact provenance that was recorded while resolving a policy
+func (db *Db) GetImpactProvenance(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy) (map[string][]*policy.ImpactProvenance, error) {
+	x, ok := db.cache.Get(dbIDImpacts + resolvedPolicy.GraphExecutionChecksum + "\x00" + resolvedPolicy.FiltersChecksum)
+	if !ok {
+		return map[string][]*policy.ImpactProvenance{}, nil
+	}
+	return x.(map[string][]*policy.ImpactProvenance), nil
+}
+
+var _ policy.ImpactProvenanceStore = (*Db)(nil)
@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,19 @@ type Db struct {
 	uuid                string                // used for all object identifiers to prevent clashes (eg in-memory pubsub)
 	nowProvider         func() time.Time
 	resolvedPolicyCache *ResolvedPolicyCache
+	activity            *assetActivity
+	coercion            policy.CoercionOptions
+	resolvedPolicyTTL   policy.ResolvedPolicyTTL
+	reportsLock         sync.Mutex
+	usageLock           sync.Mutex
+	lineageLock         sync.Mutex
+	uploadsLock         sync.Mutex
+	uploadSeq           int64          // ID of the last queued upload
+	scoreKeys           *scoreKeyIndex // precomputed cache keys of scores
+	ownerMrn            string         // owner of this space, see OwnerSpace
+	owners              *ownerSpaces   // spaces of all owners that share the cache
+	blobs               *blobStore     // large datapoints that are shared across assets
+	assetLocks          assetLocks     // serializes writes of scores and data per asset
 }
 
 // NewServices creates a new set of policy services
@@ -30,6 +44,11 @@ func NewServices(resolvedPolicyCache *ResolvedPolicyCache) (*Db, *policy.LocalSe
 		uuid:                uuid.New().String(),
 		nowProvider:         time.Now,
 		resolvedPolicyCache: resolvedPolicyCache,
+		activity:            newAssetActivity(),
+		coercion:            policy.DefaultCoercion,
+		owners:              newOwnerSpaces(cache),
+		scoreKeys:           newScoreKeyIndex(),
+		blobs:               newBlobStore(),
 	}
 
 	services := policy.NewLocalServices(db, db.uuid)
@@ -60,6 +79,20 @@ const (
 	dbIDData           = "d\x00"
 	dbIDAsset          = "a\x00"
 	dbIDResolvedPolicy = "rp\x00"
+	dbIDConflicts      = "rc\x00"
+	dbIDImpacts        = "ri\x00"
+	dbIDExceptions     = "ex\x00"
+	dbIDScoreHistory   = "sh\x00"
+	dbIDDataWarnings   = "dw\x00"
+	dbIDDataCollected  = "dc\x00"
+	dbIDReport         = "r\x00"
+	dbIDAssetReports   = "ar\x00"
+	dbIDQuota          = "sq\x00"
+	dbIDUsage          = "su\x00"
+	dbIDLineage        = "dl\x00"
+	dbIDLineageAssets  = "dla\x00"
+	dbIDOwner          = "o\x00"
+	dbIDUploads        = "uq\x00"
 )
 
 func (db *Db) SetNowProvider(f func() time.Time) {
@@ -1,12 +1,18 @@
 package inmemory
 
-import "sync"
+import (
+	"strings"
+	"sync"
+)
 
 // kvStore is an general-purpose abstraction for key-value stores
 type kvStore interface {
 	Get(key interface{}) (interface{}, bool)
 	Set(key interface{}, value interface{}, cost int64) bool
 	Del(key interface{})
+	// DelPrefix removes all keys with the given prefix and returns how many
+	// were removed
+	DelPrefix(prefix string) int
 }
 
 // kissDb for ristretto-like behavior; works synchronously
@@ -57,3 +63,17 @@ func (c *kissDb) Del(key interface{}) {
 	delete(c.data, k)
 	c.mu.Unlock()
 }
+
+func (c *kissDb) DelPrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int
+	for k := range c.data {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.data, k)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,129 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// ownerStore scopes all keys of a kvStore to one owner
+type ownerStore struct {
+	store  kvStore
+	prefix string
+}
+
+func (s *ownerStore) key(key interface{}) string {
+	k, ok := key.(string)
+	if !ok {
+		panic("cannot map key to string for owner store")
+	}
+	return s.prefix + k
+}
+
+func (s *ownerStore) Get(key interface{}) (interface{}, bool) {
+	return s.store.Get(s.key(key))
+}
+
+func (s *ownerStore) Set(key interface{}, value interface{}, cost int64) bool {
+	return s.store.Set(s.key(key), value, cost)
+}
+
+func (s *ownerStore) Del(key interface{}) {
+	s.store.Del(s.key(key))
+}
+
+func (s *ownerStore) DelPrefix(prefix string) int {
+	return s.store.DelPrefix(s.prefix + prefix)
+}
+
+// ownerSpaces are the spaces of all owners that share one store
+type ownerSpaces struct {
+	mu     sync.Mutex
+	store  kvStore
+	spaces map[string]*Db
+}
+
+func newOwnerSpaces(store kvStore) *ownerSpaces {
+	return &ownerSpaces{
+		store:  store,
+		spaces: map[string]*Db{},
+	}
+}
+
+// all returns the spaces of all owners, sorted by owner
+func (o *ownerSpaces) all() []*Db {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	res := make([]*Db, 0, len(o.spaces))
+	for _, space := range o.spaces {
+		res = append(res, space)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ownerMrn < res[j].ownerMrn
+	})
+	return res
+}
+
+// OwnerMrn returns the owner of this space, empty for the shared space
+func (db *Db) OwnerMrn() string {
+	return db.ownerMrn
+}
+
+// OwnerSpace returns the space of the owner with services that operate on
+// it. The space is created if it doesn't exist yet and inherits the
+// settings of this database. All spaces share the resolved policy cache,
+// whose entries are addressed by their content.
+func (db *Db) OwnerSpace(ownerMrn string) (*Db, *policy.LocalServices, error) {
+	if ownerMrn == "" {
+		return nil, nil, errors.New("cannot create a space without owner MRN")
+	}
+
+	db.owners.mu.Lock()
+	defer db.owners.mu.Unlock()
+
+	if space, ok := db.owners.spaces[ownerMrn]; ok {
+		return space, space.services, nil
+	}
+
+	space := &Db{
+		cache:               &ownerStore{store: db.owners.store, prefix: dbIDOwner + ownerMrn + "\x00"},
+		uuid:                uuid.New().String(),
+		nowProvider:         db.nowProvider,
+		resolvedPolicyCache: db.resolvedPolicyCache,
+		activity:            newAssetActivity(),
+		coercion:            db.coercion,
+		resolvedPolicyTTL:   db.resolvedPolicyTTL,
+		ownerMrn:            ownerMrn,
+		owners:              db.owners,
+		scoreKeys:           newScoreKeyIndex(),
+	}
+	space.services = policy.NewLocalServices(space, space.uuid)
+	db.owners.spaces[ownerMrn] = space
+	return space, space.services, nil
+}
+
+// ForOwner returns the space of the owner, see OwnerSpace
+func (db *Db) ForOwner(ownerMrn string) (policy.DataLake, *policy.LocalServices, error) {
+	space, services, err := db.OwnerSpace(ownerMrn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return space, services, nil
+}
+
+// ListOwners returns all owners that have a space, sorted
+func (db *Db) ListOwners(ctx context.Context) ([]string, error) {
+	spaces := db.owners.all()
+	res := make([]string, len(spaces))
+	for i := range spaces {
+		res[i] = spaces[i].ownerMrn
+	}
+	return res, nil
+}
+
+var _ policy.OwnerScopedDataLake = (*Db)(nil)
@@ -0,0 +1,140 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// ownerKeys returns all keys of the asset in the space of the owner
+func ownerKeys(root *Db, ownerMrn string, assetMrn string) []string {
+	res := []string{}
+	for _, k := range keysOf(root, assetMrn) {
+		if strings.HasPrefix(k, dbIDOwner+ownerMrn+"\x00") {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+func TestOwnerSpaces(t *testing.T) {
+	ctx := context.Background()
+	root, _, err := NewServices(nil)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	root.SetNowProvider(func() time.Time { return now })
+
+	ownerA := "//captain.api.mondoo.app/spaces/a"
+	ownerB := "//captain.api.mondoo.app/spaces/b"
+	a, _, err := root.OwnerSpace(ownerA)
+	require.NoError(t, err)
+	b, _, err := root.OwnerSpace(ownerB)
+	require.NoError(t, err)
+
+	same, _, err := root.OwnerSpace(ownerA)
+	require.NoError(t, err)
+	assert.Same(t, a, same)
+	_, _, err = root.OwnerSpace("")
+	assert.Error(t, err)
+
+	owners, err := root.ListOwners(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ownerA, ownerB}, owners)
+	assert.Equal(t, ownerA, a.OwnerMrn())
+	assert.Empty(t, root.OwnerMrn())
+
+	// both owners use the same MRNs
+	assetMrn := "//policy.api.mondoo.app/assets/web-01"
+	policyMrn := "//policy.api.mondoo.app/policies/ssh"
+	for _, space := range []*Db{a, b} {
+		setupTestAsset(t, space, assetMrn)
+		require.NoError(t, space.SetPolicy(ctx, &policy.Policy{
+			Mrn:                    policyMrn,
+			Name:                   "SSH policy of " + space.OwnerMrn(),
+			OwnerMrn:               space.OwnerMrn(),
+			LocalContentChecksum:   "content",
+			LocalExecutionChecksum: "execution",
+		}, nil))
+	}
+	_, err = b.UpdateScores(ctx, assetMrn, []*policy.Score{{QrId: assetMrn, Value: 20, Type: policy.ScoreType_Result, ScoreCompletion: 100}})
+	require.NoError(t, err)
+
+	t.Run("owners don't see each other's data", func(t *testing.T) {
+		score, err := a.GetScore(ctx, assetMrn, assetMrn)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(80), score.Value)
+		score, err = b.GetScore(ctx, assetMrn, assetMrn)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(20), score.Value)
+
+		p, err := a.GetRawPolicy(ctx, policyMrn)
+		require.NoError(t, err)
+		assert.Equal(t, "SSH policy of "+ownerA, p.Name)
+		p, err = b.GetRawPolicy(ctx, policyMrn)
+		require.NoError(t, err)
+		assert.Equal(t, "SSH policy of "+ownerB, p.Name)
+
+		// the shared space has none of it
+		_, err = root.GetScore(ctx, assetMrn, assetMrn)
+		assert.Error(t, err)
+		_, err = root.GetRawPolicy(ctx, policyMrn)
+		assert.Error(t, err)
+		_, err = root.GetReportByID(ctx, "report-"+assetMrn)
+		assert.Error(t, err)
+	})
+
+	t.Run("owners only list their own data", func(t *testing.T) {
+		policies, err := a.ListPolicies(ctx, "", "")
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, ownerA, policies[0].OwnerMrn)
+
+		policies, err = a.ListPolicies(ctx, ownerB, "")
+		require.NoError(t, err)
+		assert.Empty(t, policies)
+
+		assets, err := a.ListAssetMrns(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{assetMrn}, assets)
+		assets, err = root.ListAssetMrns(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, assets)
+	})
+
+	t.Run("owners only purge their own data", func(t *testing.T) {
+		require.NotEmpty(t, ownerKeys(root, ownerA, assetMrn))
+		require.NoError(t, root.PurgeAsset(ctx, assetMrn))
+		assert.NotEmpty(t, ownerKeys(root, ownerA, assetMrn))
+		assert.NotEmpty(t, ownerKeys(root, ownerB, assetMrn))
+
+		require.NoError(t, a.PurgeAsset(ctx, assetMrn))
+		assert.Empty(t, ownerKeys(root, ownerA, assetMrn))
+		_, err := a.GetScore(ctx, assetMrn, assetMrn)
+		assert.Error(t, err)
+
+		bKeys := ownerKeys(root, ownerB, assetMrn)
+		assert.NotEmpty(t, bKeys)
+		score, err := b.GetScore(ctx, assetMrn, assetMrn)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(20), score.Value)
+		_, err = b.GetReportByID(ctx, "report-"+assetMrn)
+		assert.NoError(t, err)
+		exceptions, err := b.ListExceptions(ctx, assetMrn)
+		require.NoError(t, err)
+		assert.Len(t, exceptions, 1)
+
+		// collecting the garbage of one owner leaves the other alone
+		setupTestAsset(t, a, assetMrn)
+		now = now.Add(2 * time.Hour)
+		purged, err := a.CollectGarbage(ctx, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, []string{assetMrn}, purged)
+		assert.ElementsMatch(t, bKeys, ownerKeys(root, ownerB, assetMrn))
+	})
+}
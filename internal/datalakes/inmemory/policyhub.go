@@ -86,7 +86,7 @@ func (db *Db) SetProperty(ctx context.Context, mrn string, prop *explorer.Proper
 func (db *Db) GetRawPolicy(ctx context.Context, mrn string) (*policy.Policy, error) {
 	q, ok := db.cache.Get(dbIDPolicy + mrn)
 	if !ok {
-		return nil, errors.New("policy '" + mrn + "' not found")
+		return nil, policy.NewPolicyNotFoundError(mrn)
 	}
 	return (q.(wrapPolicy)).Policy, nil
 }
@@ -235,7 +235,7 @@ func (db *Db) invalidatePolicyAndBundleAncestors(ctx context.Context, wrap *wrap
 	for parentMrn := range wrap.parents {
 		x, ok := db.cache.Get(dbIDPolicy + parentMrn)
 		if !ok {
-			return errors.New("policy '" + mrn + "' not found")
+			return policy.NewPolicyNotFoundError(mrn)
 		}
 		parent := x.(wrapPolicy)
 
@@ -248,8 +248,13 @@ func (db *Db) invalidatePolicyAndBundleAncestors(ctx context.Context, wrap *wrap
 }
 
 // ListPolicies all policies for a given owner
-// Note: Owner MRN is required
+// Note: Owner MRN is required, except in owner spaces where it defaults to
+// the owner of the space
 func (db *Db) ListPolicies(ctx context.Context, ownerMrn string, name string) ([]*policy.Policy, error) {
+	if ownerMrn == "" {
+		ownerMrn = db.ownerMrn
+	}
+
 	mrns, err := db.listPolicies()
 	if err != nil {
 		return nil, err
@@ -383,7 +388,7 @@ func (db *Db) GetValidatedBundle(ctx context.Context, mrn string) (*policy.Bundl
 func (db *Db) GetValidatedPolicy(ctx context.Context, mrn string) (*policy.Policy, error) {
 	q, ok := db.cache.Get(dbIDPolicy + mrn)
 	if !ok {
-		return nil, errors.New("policy '" + mrn + "' not found")
+		return nil, policy.NewPolicyNotFoundError(mrn)
 	}
 
 	p := q.(wrapPolicy)
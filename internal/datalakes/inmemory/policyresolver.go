@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/rs/zerolog/log"
@@ -51,7 +52,7 @@ func (db *Db) MutatePolicy(ctx context.Context, mutation *policy.PolicyMutationD
 
 			x, ok := db.cache.Get(dbIDPolicy + policyMrn)
 			if !ok {
-				return nil, errors.New("cannot find child policy '" + policyMrn + "' when trying to assign it")
+				return nil, policy.NewPolicyNotFoundError(policyMrn)
 			}
 			childw := x.(wrapPolicy)
 
@@ -69,7 +70,7 @@ func (db *Db) MutatePolicy(ctx context.Context, mutation *policy.PolicyMutationD
 		case policy.PolicyDelta_DELETE:
 			x, ok := db.cache.Get(dbIDPolicy + policyMrn)
 			if !ok {
-				return nil, errors.New("cannot find child policy '" + policyMrn + "' when trying to assign it")
+				return nil, policy.NewPolicyNotFoundError(policyMrn)
 			}
 			childw := x.(wrapPolicy)
 
@@ -239,6 +240,19 @@ func (db *Db) refreshDependentAssetFilters(ctx context.Context, startPolicy wrap
 
 // GetReport retrieves all scores and data for a given asset
 func (db *Db) GetReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, error) {
+	res, _, err := db.getReport(ctx, assetMrn, qrID, false)
+	return res, err
+}
+
+// GetPartialReport returns the report with all scores and data that were
+// found and how complete it is
+func (db *Db) GetPartialReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, *policy.ReportCompleteness, error) {
+	return db.getReport(ctx, assetMrn, qrID, true)
+}
+
+// getReport collects the report of an asset. Partial reports skip missing
+// scores and data instead of failing.
+func (db *Db) getReport(ctx context.Context, assetMrn string, qrID string, partial bool) (*policy.Report, *policy.ReportCompleteness, error) {
 	emptyReport := &policy.Report{
 		EntityMrn:  assetMrn,
 		ScoringMrn: qrID,
@@ -246,12 +260,12 @@ func (db *Db) GetReport(ctx context.Context, assetMrn string, qrID string) (*pol
 
 	score, err := db.GetScore(ctx, assetMrn, qrID)
 	if err != nil {
-		return emptyReport, nil
+		return emptyReport, nil, nil
 	}
 
 	x, ok := db.cache.Get(dbIDAsset + assetMrn)
 	if !ok {
-		return nil, errors.New("cannot find asset '" + assetMrn + "'")
+		return nil, nil, policy.NewAssetNotFoundError(assetMrn)
 	}
 
 	assetw := x.(wrapAsset)
@@ -274,13 +288,16 @@ func (db *Db) GetReport(ctx context.Context, assetMrn string, qrID string) (*pol
 		i++
 	}
 
-	scores, err := db.GetScores(ctx, assetMrn, scoreQrIDs)
+	scores, missingScores, err := db.GetScoresPartial(ctx, assetMrn, scoreQrIDs)
+	if err == nil && !partial && len(missingScores) != 0 {
+		err = policy.NewScoreNotFoundError(assetMrn, missingScores[0])
+	}
 	if err != nil {
 		log.Error().
 			Err(err).
 			Str("entity", assetMrn).
 			Msg("resolver.db> could not fetch scores for asset")
-		return nil, err
+		return nil, nil, err
 	}
 
 	datapoints := resolvedPolicy.CollectorJob.Datapoints
@@ -289,13 +306,16 @@ func (db *Db) GetReport(ctx context.Context, assetMrn string, qrID string) (*pol
 		fields[field] = types.Type(info.Type)
 	}
 
-	data, err := db.GetData(ctx, assetMrn, fields)
+	data, missingData, err := db.GetDataPartial(ctx, assetMrn, fields)
+	if err == nil && !partial && len(missingData) != 0 {
+		err = errors.New("failed to get data for asset '" + assetMrn + "' and checksum '" + missingData[0] + "'")
+	}
 	if err != nil {
 		log.Error().
 			Err(err).
 			Str("entity", assetMrn).
 			Msg("resolver.db> could not fetch data for asset")
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := policy.Report{
@@ -307,45 +327,89 @@ func (db *Db) GetReport(ctx context.Context, assetMrn string, qrID string) (*pol
 		ResolvedPolicyVersion: resolvedPolicyVersion,
 	}
 
-	return &res, nil
+	completeness := &policy.ReportCompleteness{
+		ScoresTotal:   len(scoreQrIDs),
+		DataTotal:     len(fields),
+		MissingScores: missingScores,
+		MissingData:   missingData,
+	}
+	return &res, completeness, nil
 }
 
 // GetScore retrieves one score for an asset
 func (db *Db) GetScore(ctx context.Context, assetMrn, scoreID string) (policy.Score, error) {
-	x, ok := db.cache.Get(dbIDScore + assetMrn + "\x00" + scoreID)
+	defer db.assetLocks.rlock(assetMrn)()
+	entry, ok := db.getScoreEntry(db.scoreKeys.key(assetMrn, scoreID))
 	if !ok {
-		return policy.Score{}, errors.New("cannot find score")
+		return policy.Score{}, policy.NewScoreNotFoundError(assetMrn, scoreID)
 	}
-	return x.(policy.Score), nil
+	var res policy.Score
+	entry.load(&res)
+	return res, nil
 }
 
 // GetScores retrieves a map of score for an asset
 func (db *Db) GetScores(ctx context.Context, assetMrn string, qrIDs []string) (map[string]*policy.Score, error) {
+	res, missing, err := db.GetScoresPartial(ctx, assetMrn, qrIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) != 0 {
+		return nil, policy.NewScoreNotFoundError(assetMrn, missing[0])
+	}
+	return res, nil
+}
+
+// GetScoresPartial retrieves the scores of an asset that exist and the
+// sorted IDs of those that are missing
+func (db *Db) GetScoresPartial(ctx context.Context, assetMrn string, qrIDs []string) (map[string]*policy.Score, []string, error) {
+	defer db.assetLocks.rlock(assetMrn)()
 	res := make(map[string]*policy.Score, len(qrIDs))
+	keys := db.scoreKeys.asset(assetMrn)
+	var missing []string
 
 	for i := range qrIDs {
 		qrID := qrIDs[i]
 
-		x, ok := db.cache.Get(dbIDScore + assetMrn + "\x00" + qrID)
+		entry, ok := db.getScoreEntry(keys.key(qrID))
 		if !ok {
-			return nil, errors.New("score for asset '" + assetMrn + "' with ID '" + qrID + "' not found")
+			missing = append(missing, qrID)
+			continue
 		}
 
-		score := x.(policy.Score)
-		res[qrID] = &score
+		score := &policy.Score{}
+		entry.load(score)
+		res[qrID] = score
 	}
 
-	return res, nil
+	sort.Strings(missing)
+	return res, missing, nil
 }
 
 // GetData retrieves a map of requested data fields for an asset
 func (db *Db) GetData(ctx context.Context, assetMrn string, fields map[string]types.Type) (map[string]*llx.Result, error) {
+	res, missing, err := db.GetDataPartial(ctx, assetMrn, fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) != 0 {
+		return nil, errors.New("failed to get data for asset '" + assetMrn + "' and checksum '" + missing[0] + "'")
+	}
+	return res, nil
+}
+
+// GetDataPartial retrieves the requested data fields of an asset that exist
+// and the sorted checksums of those that are missing
+func (db *Db) GetDataPartial(ctx context.Context, assetMrn string, fields map[string]types.Type) (map[string]*llx.Result, []string, error) {
+	defer db.assetLocks.rlock(assetMrn)()
 	res := make(map[string]*llx.Result, len(fields))
+	var missing []string
 
 	for checksum := range fields {
 		x, ok := db.cache.Get(dbIDData + assetMrn + "\x00" + checksum)
 		if !ok {
-			return nil, errors.New("failed to get data for asset '" + assetMrn + "' and checksum '" + checksum + "'")
+			missing = append(missing, checksum)
+			continue
 		}
 
 		if x == nil {
@@ -355,20 +419,21 @@ func (db *Db) GetData(ctx context.Context, assetMrn string, fields map[string]ty
 		}
 	}
 
-	return res, nil
+	sort.Strings(missing)
+	return res, missing, nil
 }
 
 // GetResolvedPolicy returns the resolved policy for a given asset
 func (db *Db) GetResolvedPolicy(ctx context.Context, assetMrn string) (*policy.ResolvedPolicy, error) {
 	x, ok := db.cache.Get(dbIDAsset + assetMrn)
 	if !ok {
-		return nil, errors.New("cannot find asset '" + assetMrn + "'")
+		return nil, policy.NewAssetNotFoundError(assetMrn)
 	}
 
 	assetw := x.(wrapAsset)
 
 	if assetw.ResolvedPolicy == nil {
-		return nil, errors.New("cannot find resolved policy for asset '" + assetMrn + "'")
+		return nil, policy.NewInvalidStateError(assetMrn, "cannot find resolved policy for asset '"+assetMrn+"'")
 	}
 
 	return assetw.ResolvedPolicy, nil
@@ -378,7 +443,7 @@ func (db *Db) GetResolvedPolicy(ctx context.Context, assetMrn string) (*policy.R
 func (db *Db) CachedResolvedPolicy(ctx context.Context, policyMrn string, assetFilterChecksum string, version policy.ResolvedPolicyVersion) (*policy.ResolvedPolicy, error) {
 	policyObj, err := db.GetValidatedPolicy(ctx, policyMrn)
 	if err != nil {
-		return nil, errors.New("cannot find policy for resolver: '" + policyMrn + "'")
+		return nil, err
 	}
 
 	res, ok := db.resolvedPolicyCache.Get(dbIDResolvedPolicy + policyObj.GraphExecutionChecksum + "\x00" + assetFilterChecksum)
@@ -403,9 +468,12 @@ func (db *Db) ResolveQuery(ctx context.Context, mrn string) (*explorer.Mquery, e
 // SetResolvedPolicy to the data store; cached indicates if it was cached from
 // upstream, thus preventing any attempts of resolving it in the client
 func (db *Db) SetResolvedPolicy(ctx context.Context, mrn string, resolvedPolicy *policy.ResolvedPolicy, version policy.ResolvedPolicyVersion, cached bool) error {
-	ok := db.resolvedPolicyCache.Set(dbIDResolvedPolicy+resolvedPolicy.GraphExecutionChecksum+"\x00"+resolvedPolicy.FiltersChecksum, resolvedPolicy)
+	// the parent policy is still updated if the cache is full, so that the
+	// caller may continue without the cached resolved policy
+	var err error
+	ok := db.resolvedPolicyCache.SetWithTTL(dbIDResolvedPolicy+resolvedPolicy.GraphExecutionChecksum+"\x00"+resolvedPolicy.FiltersChecksum, resolvedPolicy, db.resolvedPolicyTTL.Next())
 	if !ok {
-		return errors.New("failed to save resolved policy '" + mrn + "'")
+		err = fmt.Errorf("failed to save resolved policy '%s': %w", mrn, policy.ErrResolvedPolicyCacheFull)
 	}
 
 	if cached {
@@ -424,17 +492,42 @@ func (db *Db) SetResolvedPolicy(ctx context.Context, mrn string, resolvedPolicy
 		}
 	}
 
+	return err
+}
+
+// SetResolutionConflicts stores the policy conflicts that were detected while resolving a policy
+func (db *Db) SetResolutionConflicts(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy, conflicts []*policy.PolicyConflict) error {
+	key := dbIDConflicts + resolvedPolicy.GraphExecutionChecksum + "\x00" + resolvedPolicy.FiltersChecksum
+	if len(conflicts) == 0 {
+		db.cache.Del(key)
+		return nil
+	}
+
+	ok := db.cache.Set(key, conflicts, 1)
+	if !ok {
+		return errors.New("failed to save policy conflicts for resolved policy '" + resolvedPolicy.GraphExecutionChecksum + "'")
+	}
 	return nil
 }
 
+// GetResolutionConflicts returns the policy conflicts that were detected while resolving a policy
+func (db *Db) GetResolutionConflicts(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy) ([]*policy.PolicyConflict, error) {
+	x, ok := db.cache.Get(dbIDConflicts + resolvedPolicy.GraphExecutionChecksum + "\x00" + resolvedPolicy.FiltersChecksum)
+	if !ok {
+		return nil, nil
+	}
+	return x.([]*policy.PolicyConflict), nil
+}
+
 // SetAssetResolvedPolicy sets and initialized all fields for an asset's resolved policy
 func (db *Db) SetAssetResolvedPolicy(ctx context.Context, assetMrn string, resolvedPolicy *policy.ResolvedPolicy, version policy.ResolvedPolicyVersion) error {
 	x, ok := db.cache.Get(dbIDAsset + assetMrn)
 	if !ok {
-		return errors.New("cannot find asset '" + assetMrn + "'")
+		return policy.NewAssetNotFoundError(assetMrn)
 	}
 
 	assetw := x.(wrapAsset)
+	db.touchAsset(assetMrn)
 
 	if assetw.ResolvedPolicy != nil && assetw.ResolvedPolicy.GraphExecutionChecksum == resolvedPolicy.GraphExecutionChecksum && assetw.resolvedPolicyVersion == string(version) {
 		log.Debug().
@@ -501,9 +594,15 @@ func (db *Db) initDataValue(ctx context.Context, assetMrn string, checksum strin
 }
 
 func (db *Db) initEmptyScore(ctx context.Context, assetMrn string, qrid string) error {
-	id := dbIDScore + assetMrn + "\x00" + qrid
+	id := db.scoreKeys.key(assetMrn, qrid)
+	if entry, ok := db.getScoreEntry(id); ok {
+		entry.mu.Lock()
+		entry.fields = scoreFields{}
+		entry.mu.Unlock()
+		return nil
+	}
 
-	ok := db.cache.Set(id, policy.Score{}, 1)
+	ok := db.cache.Set(id, &scoreEntry{}, 1)
 	if !ok {
 		return errors.New("failed to initialize score for asset '" + assetMrn + "' with qrID '" + qrid + "'")
 	}
@@ -514,40 +613,46 @@ func (db *Db) initEmptyScore(ctx context.Context, assetMrn string, qrid string)
 func (db *Db) GetCollectorJob(ctx context.Context, assetMrn string) (*policy.CollectorJob, error) {
 	x, ok := db.cache.Get(dbIDAsset + assetMrn)
 	if !ok {
-		return nil, errors.New("cannot find asset '" + assetMrn + "'")
+		return nil, policy.NewAssetNotFoundError(assetMrn)
 	}
 
 	assetw := x.(wrapAsset)
 
 	if assetw.ResolvedPolicy == nil {
-		return nil, errors.New("cannot find resolved policy for asset '" + assetMrn + "'")
+		return nil, policy.NewInvalidStateError(assetMrn, "cannot find resolved policy for asset '"+assetMrn+"'")
 	}
 	if assetw.ResolvedPolicy.CollectorJob == nil {
-		return nil, errors.New("cannot find collectorJob for asset '" + assetMrn + "'")
+		return nil, policy.NewInvalidStateError(assetMrn, "cannot find collectorJob for asset '"+assetMrn+"'")
 	}
 
 	return assetw.ResolvedPolicy.CollectorJob, nil
 }
 
-var errTypesDontMatch = errors.New("types don't match")
-
 // UpdateData sets the list of data value for a given asset and returns a list of updated IDs
 func (db *Db) UpdateData(ctx context.Context, assetMrn string, data map[string]*llx.Result) (map[string]types.Type, error) {
+	db.touchAsset(assetMrn)
+	defer db.assetLocks.lock(assetMrn)()
+
 	collectorJob, err := db.GetCollectorJob(ctx, assetMrn)
 	if err != nil {
-		return nil, errors.New("cannot find collectorJob to store data: " + err.Error())
+		return nil, fmt.Errorf("cannot find collectorJob to store data: %w", err)
 	}
 
 	res := make(map[string]types.Type, len(data))
 	var errList error
+	defer func() {
+		if err := db.setDataCollected(assetMrn, res); err != nil {
+			log.Warn().Err(err).Str("asset", assetMrn).Msg("resolver.db> failed to record when data was collected")
+		}
+	}()
 	for dpChecksum, val := range data {
 		info, ok := collectorJob.Datapoints[dpChecksum]
 		if !ok {
 			return nil, errors.New("cannot find this datapoint to store values: " + dpChecksum)
 		}
 
-		if val.Data != nil && !val.Data.IsNil() && val.Data.Type != "" &&
-			val.Data.Type != info.Type && types.Type(info.Type) != types.Unset {
+		coerced, warning, err := policy.CoerceResult(val, types.Type(info.Type), db.coercion)
+		if err != nil {
 			log.Warn().
 				Str("checksum", dpChecksum).
 				Str("asset", assetMrn).
@@ -556,17 +661,25 @@ func (db *Db) UpdateData(ctx context.Context, assetMrn string, data map[string]*
 				Str("received", types.Type(val.Data.Type).Label()).
 				Msg("resolver.db> failed to store data, types don't match")
 
-			errList = multierror.Append(errList, fmt.Errorf("failed to store data for %q, %w: expected %s, got %s",
-				dpChecksum, errTypesDontMatch, types.Type(info.Type).Label(), types.Type(val.Data.Type).Label()))
-
+			errList = multierror.Append(errList, fmt.Errorf("failed to store data for %q, %w", dpChecksum, err))
 			continue
 		}
+		if warning != "" {
+			log.Debug().
+				Str("checksum", dpChecksum).
+				Str("asset", assetMrn).
+				Msg("resolver.db> " + warning)
+		}
 
-		err := db.setDatum(ctx, assetMrn, dpChecksum, val)
+		err = db.setDatum(ctx, assetMrn, dpChecksum, coerced)
 		if err != nil {
 			errList = multierror.Append(errList, err)
 			continue
 		}
+		if err := db.setDataWarning(assetMrn, dpChecksum, warning); err != nil {
+			errList = multierror.Append(errList, err)
+			continue
+		}
 
 		// TODO: we don't know which data was updated and which wasn't yet, so
 		// we currently always notify...
@@ -582,6 +695,10 @@ func (db *Db) UpdateData(ctx context.Context, assetMrn string, data map[string]*
 
 func (db *Db) setDatum(ctx context.Context, assetMrn string, checksum string, value *llx.Result) error {
 	id := dbIDData + assetMrn + "\x00" + checksum
+	value, err := db.blobs.share(id, value)
+	if err != nil {
+		return err
+	}
 	ok := db.cache.Set(id, value, 1)
 	if !ok {
 		return errors.New("failed to save asset data for asset '" + assetMrn + "' and checksum '" + checksum + "'")
@@ -591,12 +708,16 @@ func (db *Db) setDatum(ctx context.Context, assetMrn string, checksum string, va
 
 // UpdateScores sets the given scores and returns true if any were updated
 func (db *Db) UpdateScores(ctx context.Context, assetMrn string, scores []*policy.Score) (map[string]struct{}, error) {
+	db.touchAsset(assetMrn)
+	defer db.assetLocks.lock(assetMrn)()
+
 	updated := map[string]struct{}{}
 	now := db.nowProvider().Unix()
+	keys := db.scoreKeys.asset(assetMrn)
 
 	for i := range scores {
 		score := scores[i]
-		ok, err := db.updateScore(ctx, assetMrn, score, now)
+		ok, err := db.updateScore(ctx, assetMrn, keys.key(score.QrId), score, now)
 		if err != nil {
 			return nil, err
 		}
@@ -609,56 +730,64 @@ func (db *Db) UpdateScores(ctx context.Context, assetMrn string, scores []*polic
 	return updated, nil
 }
 
-// set one score and return true if it was updated
-func (db *Db) updateScore(ctx context.Context, assetMrn string, score *policy.Score, now int64) (bool, error) {
-	org, err := db.GetScore(ctx, assetMrn, score.QrId)
-	if err == nil &&
-		org.Value == score.Value &&
-		org.Type == score.Type &&
-		org.DataCompletion == score.DataCompletion &&
-		org.DataTotal == score.DataTotal &&
-		org.ScoreCompletion == score.ScoreCompletion &&
-		org.Weight == score.Weight {
-		return false, nil
+// set one score under its cache key and return true if it was updated.
+// Existing scores are updated in place.
+func (db *Db) updateScore(ctx context.Context, assetMrn string, key string, score *policy.Score, now int64) (bool, error) {
+	entry, ok := db.getScoreEntry(key)
+	if !ok {
+		setScoreTimes(nil, score, now)
+		entry = &scoreEntry{}
+		entry.fields.set(score)
+		if ok := db.cache.Set(key, entry, 1); !ok {
+			return false, errors.New("failed to set score for asset '" + assetMrn + "' with ID '" + score.QrId + "'")
+		}
+	} else {
+		entry.mu.Lock()
+		if entry.fields.equals(score) {
+			entry.mu.Unlock()
+			return false, nil
+		}
+		setScoreTimes(&entry.fields, score, now)
+		entry.fields.set(score)
+		entry.mu.Unlock()
 	}
 
+	log.Debug().
+		Str("asset", assetMrn).
+		Str("query", score.QrId).
+		Str("type", score.TypeLabel()).
+		Int("value", int(score.Value)).
+		Int("score-completion", int(score.ScoreCompletion)).
+		Int("data-completion", int(score.DataCompletion)).
+		Int("data-total", int(score.DataTotal)).
+		Str("error_msg", score.Message).
+		Msg("resolver.db> update score")
+	return true, nil
+}
+
+// setScoreTimes sets when the value of the score was modified and when it
+// started failing, based on the stored score (nil if there is none)
+func setScoreTimes(org *scoreFields, score *policy.Score, now int64) {
 	// if this is the first time saving the score
-	if err != nil || (org.ScoreCompletion == 0 && score.Type == policy.ScoreType_Result) {
+	if org == nil || (org.scoreCompletion == 0 && score.Type == policy.ScoreType_Result) {
 		score.ValueModifiedTime = now
 		if score.Value == 100 || score.ScoreCompletion < 100 {
 			score.FailureTime = 0
 		} else {
 			score.FailureTime = now
 		}
-	} else if (org.Value != score.Value || org.ScoreCompletion == 0) && score.Type == policy.ScoreType_Result {
+	} else if (org.value != score.Value || org.scoreCompletion == 0) && score.Type == policy.ScoreType_Result {
 		score.ValueModifiedTime = now
 		// we are failing from 100 => something else
-		if org.Value == 100 {
+		if org.value == 100 {
 			score.FailureTime = now
 		} else {
-			score.FailureTime = org.FailureTime
+			score.FailureTime = org.failureTime
 		}
 	} else {
-		score.ValueModifiedTime = org.ValueModifiedTime
-		score.FailureTime = org.FailureTime
-	}
-
-	ok := db.cache.Set(dbIDScore+assetMrn+"\x00"+score.QrId, *score, 1)
-	if !ok {
-		return false, errors.New("failed to set score for asset '" + assetMrn + "' with ID '" + score.QrId + "'")
+		score.ValueModifiedTime = org.valueModifiedTime
+		score.FailureTime = org.failureTime
 	}
-
-	log.Debug().
-		Str("asset", assetMrn).
-		Str("query", score.QrId).
-		Str("type", score.TypeLabel()).
-		Int("value", int(score.Value)).
-		Int("score-completion", int(score.ScoreCompletion)).
-		Int("data-completion", int(score.DataCompletion)).
-		Int("data-total", int(score.DataTotal)).
-		Str("error_msg", score.Message).
-		Msg("resolver.db> update score")
-	return true, nil
 }
 
 // SetProps will override properties for a given entity (asset, space, org)
@@ -702,3 +831,5 @@ func (db *Db) SetProps(ctx context.Context, req *explorer.PropsReq) error {
 
 	return nil
 }
+
+var _ policy.PartialResultsReader = (*Db)(nil)
@@ -0,0 +1,74 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetQuota sets the quota of a space, nil removes it
+func (db *Db) SetQuota(ctx context.Context, spaceMrn string, quota *policy.Quota) error {
+	if quota == nil {
+		db.cache.Del(dbIDQuota + spaceMrn)
+		return nil
+	}
+
+	q := *quota
+	if ok := db.cache.Set(dbIDQuota+spaceMrn, q, 1); !ok {
+		return errors.New("failed to save quota for space '" + spaceMrn + "'")
+	}
+	return nil
+}
+
+// GetQuota returns the quota of a space, nil if it has none
+func (db *Db) GetQuota(ctx context.Context, spaceMrn string) (*policy.Quota, error) {
+	x, ok := db.cache.Get(dbIDQuota + spaceMrn)
+	if !ok {
+		return nil, nil
+	}
+	q := x.(policy.Quota)
+	return &q, nil
+}
+
+func usageKey(spaceMrn string, day time.Time) string {
+	return dbIDUsage + spaceMrn + "\x00" + strconv.FormatInt(day.Unix(), 10)
+}
+
+// AddUsage adds to the usage of a space on the given day and returns the
+// total usage of that day
+func (db *Db) AddUsage(ctx context.Context, spaceMrn string, day time.Time, usage policy.SpaceUsage) (*policy.SpaceUsage, error) {
+	db.usageLock.Lock()
+	defer db.usageLock.Unlock()
+
+	key := usageKey(spaceMrn, day)
+	var res policy.SpaceUsage
+	if x, ok := db.cache.Get(key); ok {
+		res = x.(policy.SpaceUsage)
+	}
+	res.Scans += usage.Scans
+	res.Datapoints += usage.Datapoints
+	res.UploadBytes += usage.UploadBytes
+
+	if ok := db.cache.Set(key, res, 1); !ok {
+		return nil, errors.New("failed to save usage of space '" + spaceMrn + "'")
+	}
+	return &res, nil
+}
+
+// GetUsage returns the usage of a space on the given day
+func (db *Db) GetUsage(ctx context.Context, spaceMrn string, day time.Time) (*policy.SpaceUsage, error) {
+	db.usageLock.Lock()
+	defer db.usageLock.Unlock()
+
+	x, ok := db.cache.Get(usageKey(spaceMrn, day))
+	if !ok {
+		return &policy.SpaceUsage{}, nil
+	}
+	res := x.(policy.SpaceUsage)
+	return &res, nil
+}
+
+var _ policy.UsageStore = (*Db)(nil)
@@ -0,0 +1,63 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// StoreReport stores a copy of the report under its content ID. It returns
+// false if the ID was already stored.
+func (db *Db) StoreReport(ctx context.Context, id string, report *policy.Report) (bool, error) {
+	if id == "" {
+		return false, errors.New("cannot store report without ID")
+	}
+
+	db.reportsLock.Lock()
+	defer db.reportsLock.Unlock()
+
+	if _, ok := db.cache.Get(dbIDReport + id); ok {
+		return false, nil
+	}
+
+	ok := db.cache.Set(dbIDReport+id, proto.Clone(report).(*policy.Report), 1)
+	if !ok {
+		return false, errors.New("failed to store report '" + id + "'")
+	}
+
+	// reports are looked up by ID, the index per asset is only needed to
+	// purge them with their asset
+	var ids []string
+	if x, ok := db.cache.Get(dbIDAssetReports + report.EntityMrn); ok {
+		ids = x.([]string)
+	}
+	ids = append(ids[:len(ids):len(ids)], id)
+	db.cache.Set(dbIDAssetReports+report.EntityMrn, ids, 1)
+	return true, nil
+}
+
+// GetReportByID returns a report that was stored with StoreReport
+func (db *Db) GetReportByID(ctx context.Context, id string) (*policy.Report, error) {
+	x, ok := db.cache.Get(dbIDReport + id)
+	if !ok {
+		return nil, errors.New("report '" + id + "' not found")
+	}
+	return proto.Clone(x.(*policy.Report)).(*policy.Report), nil
+}
+
+// purgeReports removes all stored reports of an asset
+func (db *Db) purgeReports(assetMrn string) {
+	db.reportsLock.Lock()
+	defer db.reportsLock.Unlock()
+
+	x, ok := db.cache.Get(dbIDAssetReports + assetMrn)
+	if !ok {
+		return
+	}
+	for _, id := range x.([]string) {
+		db.cache.Del(dbIDReport + id)
+	}
+	db.cache.Del(dbIDAssetReports + assetMrn)
+}
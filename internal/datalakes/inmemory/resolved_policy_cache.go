@@ -10,15 +10,62 @@ import (
 
 const ResolvedPolicyCacheTTL = 1 * time.Hour
 
+// EvictionPolicy decides which entries are removed first when the cache is full
+type EvictionPolicy string
+
+const (
+	// EvictLRU removes the least recently used entries first
+	EvictLRU EvictionPolicy = "lru"
+	// EvictLFU removes the least frequently used entries first. Entries that
+	// were used equally often are removed in LRU order.
+	EvictLFU EvictionPolicy = "lfu"
+	// EvictSegmentedLRU removes entries in LRU order, but entries that were
+	// used at least FrequentHits times are only removed once no other entries
+	// are left. Frequently used filter sets stay cached, even if many assets
+	// with rare filter sets are scanned in between.
+	EvictSegmentedLRU EvictionPolicy = "slru"
+)
+
+// DefaultFrequentHits is the number of cache hits from which entries are
+// protected by EvictSegmentedLRU
+const DefaultFrequentHits = 2
+
+// ResolvedPolicyCacheOptions configures a ResolvedPolicyCache
+type ResolvedPolicyCacheOptions struct {
+	// SizeLimit is the maximum size of all entries in bytes, 0 is unlimited
+	SizeLimit int64
+	// TTL is the time after which entries expire, defaults to ResolvedPolicyCacheTTL
+	TTL time.Duration
+	// Eviction defaults to EvictLRU
+	Eviction EvictionPolicy
+	// FrequentHits is used by EvictSegmentedLRU, defaults to DefaultFrequentHits
+	FrequentHits uint64
+}
+
+// ResolvedPolicyCacheStats are the counters of a ResolvedPolicyCache
+type ResolvedPolicyCacheStats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	// Rejections counts entries that were too large to be cached
+	Rejections uint64
+	Entries    int
+	Size       int64
+	SizeLimit  int64
+}
+
 type cachedResolvedPolicy struct {
 	createdOn      time.Time
 	lastAccessedOn time.Time
+	ttl            time.Duration
+	hits           uint64
 	resolvedPolicy *policy.ResolvedPolicy
 	size           int64
 }
 
 func (c *cachedResolvedPolicy) isExpired(now time.Time) bool {
-	return now.Sub(c.createdOn) > ResolvedPolicyCacheTTL
+	return now.Sub(c.createdOn) > c.ttl
 }
 
 type ResolvedPolicyCache struct {
@@ -26,18 +73,45 @@ type ResolvedPolicyCache struct {
 	data        map[string]*cachedResolvedPolicy
 	totalSize   int64
 	sizeLimit   int64
+	ttl         time.Duration
+	eviction    EvictionPolicy
+	frequent    uint64
+	stats       ResolvedPolicyCacheStats
 	nowProvider func() time.Time
 }
 
 // NewResolvedPolicyCache creates a new ResolvedPolicyCache with the given size limit. If the size
 // limit is 0, the cache is unlimited.
 func NewResolvedPolicyCache(sizeLimit int64) *ResolvedPolicyCache {
-	if sizeLimit < 0 {
+	return NewResolvedPolicyCacheWithOptions(ResolvedPolicyCacheOptions{SizeLimit: sizeLimit})
+}
+
+// NewResolvedPolicyCacheWithOptions creates a new ResolvedPolicyCache with
+// the given size limit, TTL and eviction policy
+func NewResolvedPolicyCacheWithOptions(opts ResolvedPolicyCacheOptions) *ResolvedPolicyCache {
+	if opts.SizeLimit < 0 {
 		panic("sizeLimit must be >= 0")
 	}
+	if opts.TTL <= 0 {
+		opts.TTL = ResolvedPolicyCacheTTL
+	}
+	switch opts.Eviction {
+	case EvictLRU, EvictLFU, EvictSegmentedLRU:
+	case "":
+		opts.Eviction = EvictLRU
+	default:
+		panic("unknown eviction policy '" + string(opts.Eviction) + "'")
+	}
+	if opts.FrequentHits == 0 {
+		opts.FrequentHits = DefaultFrequentHits
+	}
+
 	return &ResolvedPolicyCache{
 		data:        make(map[string]*cachedResolvedPolicy),
-		sizeLimit:   sizeLimit,
+		sizeLimit:   opts.SizeLimit,
+		ttl:         opts.TTL,
+		eviction:    opts.Eviction,
+		frequent:    opts.FrequentHits,
 		nowProvider: time.Now,
 	}
 }
@@ -47,25 +121,40 @@ func (c *ResolvedPolicyCache) Get(key string) (*policy.ResolvedPolicy, bool) {
 	res, ok := c.data[key]
 	defer c.mu.Unlock()
 	if !ok {
+		c.stats.Misses++
 		return nil, ok
 	}
 
 	// If the entry is older than TTL delete it and return nothing.
-	if c.nowProvider().Sub(res.createdOn) > ResolvedPolicyCacheTTL {
+	if res.isExpired(c.nowProvider()) {
 		delete(c.data, key)
 		c.totalSize -= res.size
+		c.stats.Expirations++
+		c.stats.Misses++
 		return nil, false
 	}
 
 	res.lastAccessedOn = c.nowProvider()
+	res.hits++
+	c.stats.Hits++
 
 	return res.resolvedPolicy, ok
 }
 
 func (c *ResolvedPolicyCache) Set(key string, resolvedPolicy *policy.ResolvedPolicy) bool {
+	return c.SetWithTTL(key, resolvedPolicy, 0)
+}
+
+// SetWithTTL adds an entry that expires after the given TTL instead of the
+// TTL of the cache. A TTL of 0 uses the TTL of the cache.
+func (c *ResolvedPolicyCache) SetWithTTL(key string, resolvedPolicy *policy.ResolvedPolicy, ttl time.Duration) bool {
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
 	cacheEntry := cachedResolvedPolicy{
 		createdOn:      c.nowProvider(),
 		lastAccessedOn: c.nowProvider(),
+		ttl:            ttl,
 		resolvedPolicy: resolvedPolicy,
 		size:           int64(proto.Size(resolvedPolicy)),
 	}
@@ -76,6 +165,7 @@ func (c *ResolvedPolicyCache) Set(key string, resolvedPolicy *policy.ResolvedPol
 
 	// If there is still no space, then return false.
 	if c.hasSpace(cacheEntry.size) {
+		c.stats.Rejections++
 		return false
 	}
 
@@ -89,46 +179,75 @@ func (c *ResolvedPolicyCache) Set(key string, resolvedPolicy *policy.ResolvedPol
 	return true
 }
 
-// freeSpace deletes expired entries and entries starting from the oldest one until the cache has sufficient space
-// to accommodate a new entry with the given size. The function doesn't solve thread safety so the caller needs to
-// handle that.
+// Stats returns the current counters of the cache
+func (c *ResolvedPolicyCache) Stats() ResolvedPolicyCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := c.stats
+	res.Entries = len(c.data)
+	res.Size = c.totalSize
+	res.SizeLimit = c.sizeLimit
+	return res
+}
+
+// freeSpace deletes expired entries and then entries in the order of the eviction policy until the
+// cache has sufficient space to accommodate a new entry with the given size. The function doesn't
+// solve thread safety so the caller needs to handle that.
 func (c *ResolvedPolicyCache) freeSpace(size int64) {
 	if len(c.data) == 0 {
 		return
 	}
 
 	for c.hasSpace(size) {
-		// Delete the oldest entry in the cache to make space for the new one
-		var oldestEntry *cachedResolvedPolicy
-		oldestKey := ""
-		oldestTimestamp := c.nowProvider().Add(1 * time.Minute)
+		// Delete the first entry in eviction order to make space for the new one
+		var evictEntry *cachedResolvedPolicy
+		evictKey := ""
+		now := c.nowProvider()
 		for k, v := range c.data {
 			// If the entry is older than TTL delete it.
-			if c.nowProvider().Sub(v.createdOn) > ResolvedPolicyCacheTTL {
+			if v.isExpired(now) {
 				delete(c.data, k)
 				c.totalSize -= v.size
+				c.stats.Expirations++
 				continue
 			}
 
-			if v.lastAccessedOn.Before(oldestTimestamp) {
-				oldestTimestamp = v.lastAccessedOn
-				oldestEntry = v
-				oldestKey = k
+			if evictEntry == nil || c.evictsBefore(v, evictEntry) {
+				evictEntry = v
+				evictKey = k
 			}
 		}
 
 		// Since the loop above also delete expired entries, check whether we have sufficient
-		// space now before deleting the oldest entry.
+		// space now before deleting the next entry.
 		if c.hasSpace(size) {
-			if oldestKey == "" { // If the oldest key is not set, there is nothing to delete.
+			if evictKey == "" { // If the key is not set, there is nothing to delete.
 				return
 			}
 
 			// Delete the entry and update the total size
-			delete(c.data, oldestKey)
-			c.totalSize -= oldestEntry.size
+			delete(c.data, evictKey)
+			c.totalSize -= evictEntry.size
+			c.stats.Evictions++
+		}
+	}
+}
+
+// evictsBefore returns true if entry a has to be evicted before entry b
+func (c *ResolvedPolicyCache) evictsBefore(a *cachedResolvedPolicy, b *cachedResolvedPolicy) bool {
+	switch c.eviction {
+	case EvictLFU:
+		if a.hits != b.hits {
+			return a.hits < b.hits
+		}
+	case EvictSegmentedLRU:
+		aFrequent, bFrequent := a.hits >= c.frequent, b.hits >= c.frequent
+		if aFrequent != bFrequent {
+			return !aFrequent
 		}
 	}
+	return a.lastAccessedOn.Before(b.lastAccessedOn)
 }
 
 func (c *ResolvedPolicyCache) hasSpace(size int64) bool {
@@ -0,0 +1,134 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// testResolvedPolicy returns resolved policies that all have the same size
+func testResolvedPolicy(id string) *policy.ResolvedPolicy {
+	return &policy.ResolvedPolicy{GraphExecutionChecksum: "checksum-" + id}
+}
+
+// newTestCache creates a cache that holds two resolved policies and whose
+// time is controlled by the returned function
+func newTestCache(opts ResolvedPolicyCacheOptions) (*ResolvedPolicyCache, func(time.Duration)) {
+	opts.SizeLimit = 2 * int64(proto.Size(testResolvedPolicy("a")))
+	c := NewResolvedPolicyCacheWithOptions(opts)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.nowProvider = func() time.Time { return now }
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func cachedKeys(c *ResolvedPolicyCache, keys ...string) []string {
+	res := []string{}
+	for _, key := range keys {
+		c.mu.Lock()
+		_, ok := c.data[key]
+		c.mu.Unlock()
+		if ok {
+			res = append(res, key)
+		}
+	}
+	return res
+}
+
+func TestResolvedPolicyCacheEviction(t *testing.T) {
+	t.Run("lru", func(t *testing.T) {
+		c, advance := newTestCache(ResolvedPolicyCacheOptions{Eviction: EvictLRU})
+		require.True(t, c.Set("a", testResolvedPolicy("a")))
+		advance(time.Second)
+		require.True(t, c.Set("b", testResolvedPolicy("b")))
+		advance(time.Second)
+		_, ok := c.Get("a")
+		require.True(t, ok)
+		advance(time.Second)
+
+		require.True(t, c.Set("c", testResolvedPolicy("c")))
+		assert.Equal(t, []string{"a", "c"}, cachedKeys(c, "a", "b", "c"))
+		assert.Equal(t, uint64(1), c.Stats().Evictions)
+	})
+
+	t.Run("lfu", func(t *testing.T) {
+		c, advance := newTestCache(ResolvedPolicyCacheOptions{Eviction: EvictLFU})
+		require.True(t, c.Set("a", testResolvedPolicy("a")))
+		require.True(t, c.Set("b", testResolvedPolicy("b")))
+		advance(time.Second)
+		c.Get("b")
+		c.Get("b")
+		advance(time.Second)
+		// a was used last, but less often
+		c.Get("a")
+		advance(time.Second)
+
+		require.True(t, c.Set("c", testResolvedPolicy("c")))
+		assert.Equal(t, []string{"b", "c"}, cachedKeys(c, "a", "b", "c"))
+	})
+
+	t.Run("slru", func(t *testing.T) {
+		c, advance := newTestCache(ResolvedPolicyCacheOptions{Eviction: EvictSegmentedLRU})
+		require.True(t, c.Set("frequent", testResolvedPolicy("a")))
+		advance(time.Second)
+		c.Get("frequent")
+		c.Get("frequent")
+		advance(time.Second)
+		require.True(t, c.Set("rare", testResolvedPolicy("b")))
+		advance(time.Second)
+		c.Get("rare")
+		advance(time.Second)
+
+		// frequent entries are protected, even if they weren't used recently
+		require.True(t, c.Set("new", testResolvedPolicy("c")))
+		assert.Equal(t, []string{"frequent", "new"}, cachedKeys(c, "frequent", "rare", "new"))
+
+		// once only frequent entries are left, they are removed in LRU order
+		advance(time.Second)
+		c.Get("new")
+		c.Get("new")
+		advance(time.Second)
+		require.True(t, c.Set("next", testResolvedPolicy("d")))
+		assert.Equal(t, []string{"new", "next"}, cachedKeys(c, "frequent", "new", "next"))
+	})
+
+	t.Run("oversized entries are rejected", func(t *testing.T) {
+		c, _ := newTestCache(ResolvedPolicyCacheOptions{})
+		large := &policy.ResolvedPolicy{GraphExecutionChecksum: string(make([]byte, 1024))}
+		assert.False(t, c.Set("large", large))
+		assert.Equal(t, uint64(1), c.Stats().Rejections)
+	})
+}
+
+func TestResolvedPolicyCacheTTL(t *testing.T) {
+	c, advance := newTestCache(ResolvedPolicyCacheOptions{TTL: time.Hour})
+	require.True(t, c.Set("a", testResolvedPolicy("a")))
+	require.True(t, c.SetWithTTL("b", testResolvedPolicy("b"), 3*time.Hour))
+
+	advance(30 * time.Minute)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	advance(time.Hour)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	_, ok = c.Get("b")
+	assert.True(t, ok)
+
+	advance(2 * time.Hour)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(2), stats.Expirations)
+	assert.Equal(t, 0, stats.Entries)
+	assert.Equal(t, int64(0), stats.Size)
+
+	// the default TTL applies without options
+	c = NewResolvedPolicyCacheWithOptions(ResolvedPolicyCacheOptions{})
+	assert.Equal(t, ResolvedPolicyCacheTTL, c.ttl)
+	assert.Equal(t, EvictLRU, c.eviction)
+}
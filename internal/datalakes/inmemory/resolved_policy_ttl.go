@@ -0,0 +1,10 @@
+package inmemory
+
+import "go.mondoo.com/cnspec/policy"
+
+// SetResolvedPolicyTTL configures after how long cached resolved policies
+// expire and are resolved again. It overrides the TTL of the resolved
+// policy cache for all resolved policies this datalake caches.
+func (db *Db) SetResolvedPolicyTTL(ttl policy.ResolvedPolicyTTL) {
+	db.resolvedPolicyTTL = ttl
+}
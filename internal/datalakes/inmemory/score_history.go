@@ -0,0 +1,72 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// AppendScoreHistory adds all scores whose value or outcome changed to the
+// score history of the asset
+func (db *Db) AppendScoreHistory(ctx context.Context, assetMrn string, scores []*policy.Score) error {
+	var executionChecksum string
+	if x, ok := db.cache.Get(dbIDAsset + assetMrn); ok {
+		if asset := x.(wrapAsset); asset.ResolvedPolicy != nil {
+			executionChecksum = asset.ResolvedPolicy.GraphExecutionChecksum
+		}
+	}
+	now := db.nowProvider()
+
+	for i := range scores {
+		entry, ok := policy.NewScoreHistoryEntry(scores[i], executionChecksum, now)
+		if !ok {
+			continue
+		}
+
+		key := dbIDScoreHistory + assetMrn + "\x00" + entry.QrId
+		var history []policy.ScoreHistoryEntry
+		if x, ok := db.cache.Get(key); ok {
+			history = x.([]policy.ScoreHistoryEntry)
+		}
+		if len(history) != 0 && !entry.Changes(history[len(history)-1]) {
+			continue
+		}
+
+		// copy to not modify the slice that readers may hold
+		res := make([]policy.ScoreHistoryEntry, len(history), len(history)+1)
+		copy(res, history)
+		res = append(res, entry)
+
+		if ok := db.cache.Set(key, res, 1); !ok {
+			return errors.New("failed to record score history for asset '" + assetMrn + "'")
+		}
+	}
+	return nil
+}
+
+// GetScoreHistory returns the changes of one score of an asset within the
+// window, ordered by time. It starts with the last change before the window,
+// so that the value at the start of the window is known. A window of 0
+// returns the entire history.
+func (db *Db) GetScoreHistory(ctx context.Context, assetMrn string, qrID string, window time.Duration) ([]policy.ScoreHistoryEntry, error) {
+	x, ok := db.cache.Get(dbIDScoreHistory + assetMrn + "\x00" + qrID)
+	if !ok {
+		return []policy.ScoreHistoryEntry{}, nil
+	}
+	history := x.([]policy.ScoreHistoryEntry)
+	if window <= 0 {
+		return history, nil
+	}
+
+	since := db.nowProvider().Add(-window)
+	start := len(history) - 1
+	for start > 0 && !history[start].Recorded.Before(since) {
+		start--
+	}
+	if start < 0 {
+		start = 0
+	}
+	return history[start:], nil
+}
@@ -0,0 +1,182 @@
+package inmemory
+
+import (
+	"sync"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// scoreFields are the values of a stored score. They are kept apart from
+// policy.Score, whose message state must not be copied.
+type scoreFields struct {
+	qrID              string
+	typ               uint32
+	value             uint32
+	weight            uint32
+	scoreCompletion   uint32
+	dataTotal         uint32
+	dataCompletion    uint32
+	message           string
+	valueModifiedTime int64
+	failureTime       int64
+}
+
+func (f *scoreFields) set(score *policy.Score) {
+	f.qrID = score.QrId
+	f.typ = score.Type
+	f.value = score.Value
+	f.weight = score.Weight
+	f.scoreCompletion = score.ScoreCompletion
+	f.dataTotal = score.DataTotal
+	f.dataCompletion = score.DataCompletion
+	f.message = score.Message
+	f.valueModifiedTime = score.ValueModifiedTime
+	f.failureTime = score.FailureTime
+}
+
+func (f *scoreFields) get(score *policy.Score) {
+	score.QrId = f.qrID
+	score.Type = f.typ
+	score.Value = f.value
+	score.Weight = f.weight
+	score.ScoreCompletion = f.scoreCompletion
+	score.DataTotal = f.dataTotal
+	score.DataCompletion = f.dataCompletion
+	score.Message = f.message
+	score.ValueModifiedTime = f.valueModifiedTime
+	score.FailureTime = f.failureTime
+}
+
+// equals returns true if the score has the same results, regardless of
+// its timestamps
+func (f *scoreFields) equals(score *policy.Score) bool {
+	return f.value == score.Value &&
+		f.typ == score.Type &&
+		f.dataCompletion == score.DataCompletion &&
+		f.dataTotal == score.DataTotal &&
+		f.scoreCompletion == score.ScoreCompletion &&
+		f.weight == score.Weight
+}
+
+// scoreEntry is a score in the cache. Entries are updated in place, so
+// that the millions of score updates of large scans don't allocate. Readers
+// may still hold an entry after its asset was purged, so entries are never
+// reused.
+type scoreEntry struct {
+	mu     sync.Mutex
+	fields scoreFields
+}
+
+func (e *scoreEntry) load(score *policy.Score) {
+	e.mu.Lock()
+	e.fields.get(score)
+	e.mu.Unlock()
+}
+
+// assetScoreKeys are the cache keys of all scores of an asset, so that they
+// are only concatenated once
+type assetScoreKeys struct {
+	prefix string
+	mu     sync.RWMutex
+	keys   map[string]string
+}
+
+func (k *assetScoreKeys) key(qrID string) string {
+	k.mu.RLock()
+	key, ok := k.keys[qrID]
+	k.mu.RUnlock()
+	if ok {
+		return key
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if key, ok = k.keys[qrID]; !ok {
+		key = k.prefix + qrID
+		k.keys[qrID] = key
+	}
+	return key
+}
+
+func (k *assetScoreKeys) all() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	res := make([]string, 0, len(k.keys))
+	for _, key := range k.keys {
+		res = append(res, key)
+	}
+	return res
+}
+
+// scoreKeyIndex keeps the score keys of all assets
+type scoreKeyIndex struct {
+	mu     sync.RWMutex
+	assets map[string]*assetScoreKeys
+}
+
+func newScoreKeyIndex() *scoreKeyIndex {
+	return &scoreKeyIndex{
+		assets: map[string]*assetScoreKeys{},
+	}
+}
+
+func (i *scoreKeyIndex) asset(assetMrn string) *assetScoreKeys {
+	i.mu.RLock()
+	res, ok := i.assets[assetMrn]
+	i.mu.RUnlock()
+	if ok {
+		return res
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if res, ok = i.assets[assetMrn]; !ok {
+		res = &assetScoreKeys{
+			prefix: dbIDScore + assetMrn + "\x00",
+			keys:   map[string]string{},
+		}
+		i.assets[assetMrn] = res
+	}
+	return res
+}
+
+// key returns the cache key of a score
+func (i *scoreKeyIndex) key(assetMrn string, qrID string) string {
+	return i.asset(assetMrn).key(qrID)
+}
+
+// remove drops the keys of an asset and returns them
+func (i *scoreKeyIndex) remove(assetMrn string) []string {
+	i.mu.Lock()
+	keys, ok := i.assets[assetMrn]
+	delete(i.assets, assetMrn)
+	i.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return keys.all()
+}
+
+// getScoreEntry returns the stored entry of a score
+func (db *Db) getScoreEntry(key string) (*scoreEntry, bool) {
+	x, ok := db.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return x.(*scoreEntry), true
+}
+
+// purgeScores removes all scores of an asset and returns how many scores
+// were removed
+func (db *Db) purgeScores(assetMrn string) int {
+	var n int
+	for _, key := range db.scoreKeys.remove(assetMrn) {
+		if _, ok := db.getScoreEntry(key); ok {
+			db.cache.Del(key)
+			n++
+		}
+	}
+	// scores whose keys weren't indexed
+	return n + db.cache.DelPrefix(dbIDScore+assetMrn+"\x00")
+}
@@ -0,0 +1,130 @@
+package inmemory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func testScores(n int, value uint32) []*policy.Score {
+	res := make([]*policy.Score, n)
+	for i := range res {
+		res[i] = &policy.Score{
+			QrId:            "//local.cnspec.io/run/local-execution/queries/check-" + strconv.Itoa(i),
+			Value:           value,
+			Type:            policy.ScoreType_Result,
+			ScoreCompletion: 100,
+		}
+	}
+	return res
+}
+
+// run with -benchmem, updating existing scores must not allocate per score
+func BenchmarkUpdateScores(b *testing.B) {
+	ctx := context.Background()
+	db, _, err := NewServices(nil)
+	require.NoError(b, err)
+	assetMrn := "//policy.api.mondoo.app/assets/web-01"
+	require.NoError(b, db.EnsureAsset(ctx, assetMrn))
+
+	runs := [2][]*policy.Score{testScores(1000, 0), testScores(1000, 100)}
+	_, err = db.UpdateScores(ctx, assetMrn, runs[1])
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := db.UpdateScores(ctx, assetMrn, runs[i%2]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// run with -race, readers may hold entries of scores that are purged
+func TestPurgeScoresDuringUpdate(t *testing.T) {
+	ctx := context.Background()
+	db, _, err := NewServices(nil)
+	require.NoError(t, err)
+
+	purged := "//policy.api.mondoo.app/assets/purged"
+	kept := "//policy.api.mondoo.app/assets/kept"
+	scores := map[string][]*policy.Score{
+		purged: testScores(20, 10),
+		kept:   testScores(20, 90),
+	}
+	for assetMrn := range scores {
+		require.NoError(t, db.EnsureAsset(ctx, assetMrn))
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	errs := make(chan string, 100)
+	report := func(msg string) {
+		select {
+		case errs <- msg:
+		default:
+		}
+	}
+
+	for assetMrn, assetScores := range scores {
+		wg.Add(2)
+		// writers keep storing the same values
+		go func(assetMrn string, assetScores []*policy.Score) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if _, err := db.UpdateScores(ctx, assetMrn, testScores(len(assetScores), assetScores[0].Value)); err != nil {
+					report(err.Error())
+				}
+			}
+		}(assetMrn, assetScores)
+
+		// readers must only ever see the values of their asset
+		go func(assetMrn string, assetScores []*policy.Score) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				for _, want := range assetScores {
+					got, err := db.GetScore(ctx, assetMrn, want.QrId)
+					if err != nil {
+						continue
+					}
+					if got.QrId != want.QrId || got.Value != want.Value {
+						report("read " + got.QrId + "=" + strconv.Itoa(int(got.Value)) + " for " + assetMrn + " " + want.QrId)
+					}
+				}
+			}
+		}(assetMrn, assetScores)
+	}
+
+	for i := 0; i < 200; i++ {
+		require.NoError(t, db.PurgeAsset(ctx, purged))
+		require.NoError(t, db.EnsureAsset(ctx, purged))
+	}
+	close(stop)
+	wg.Wait()
+	close(errs)
+
+	for msg := range errs {
+		t.Error(msg)
+	}
+
+	// the scores of the other asset are complete
+	res, missing, err := db.GetScoresPartial(ctx, kept, []string{scores[kept][0].QrId, scores[kept][19].QrId})
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+	assert.Len(t, res, 2)
+}
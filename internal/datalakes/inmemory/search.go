@@ -0,0 +1,112 @@
+package inmemory
+
+import (
+	"context"
+
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ policy.DataLakeSearcher = (*Db)(nil)
+
+// ListAssets returns the assets that match the filter, sorted by MRN
+func (db *Db) ListAssets(ctx context.Context, filter *policy.AssetFilter) (*policy.AssetList, error) {
+	matches := []*policy.AssetInfo{}
+	for _, mrn := range db.activity.all() {
+		x, ok := db.cache.Get(dbIDAsset + mrn)
+		if !ok {
+			continue
+		}
+		assetw := x.(wrapAsset)
+		info := &policy.AssetInfo{
+			Mrn:                   mrn,
+			ResolvedPolicyVersion: assetw.resolvedPolicyVersion,
+		}
+		if filter.Matches(info, assetw.ResolvedPolicy != nil) {
+			matches = append(matches, info)
+		}
+	}
+
+	var page policy.Pagination
+	if filter != nil {
+		page = filter.Pagination
+	}
+	start, end := page.Bounds(len(matches))
+	res := &policy.AssetList{Assets: matches[start:end], Total: len(matches)}
+	for _, info := range res.Assets {
+		if score, err := db.GetScore(ctx, info.Mrn, info.Mrn); err == nil {
+			info.Score = &score
+		}
+	}
+	return res, nil
+}
+
+// ListReports returns the reports of an asset that were stored with
+// StoreReport, newest first
+func (db *Db) ListReports(ctx context.Context, assetMrn string, page policy.Pagination) (*policy.ReportList, error) {
+	db.reportsLock.Lock()
+	var ids []string
+	if x, ok := db.cache.Get(dbIDAssetReports + assetMrn); ok {
+		ids = x.([]string)
+	}
+	reports := make([]*policy.StoredReport, 0, len(ids))
+	for _, id := range ids {
+		if x, ok := db.cache.Get(dbIDReport + id); ok {
+			reports = append(reports, &policy.StoredReport{ID: id, Report: x.(*policy.Report)})
+		}
+	}
+	db.reportsLock.Unlock()
+
+	policy.SortStoredReports(reports)
+	start, end := page.Bounds(len(reports))
+	res := &policy.ReportList{Reports: reports[start:end], Total: len(reports)}
+	for _, cur := range res.Reports {
+		cur.Report = proto.Clone(cur.Report).(*policy.Report)
+	}
+	return res, nil
+}
+
+// SearchScores returns the scores that match the query. Scores are found
+// via the resolved policies of assets.
+func (db *Db) SearchScores(ctx context.Context, query *policy.ScoreQuery) (*policy.ScoreList, error) {
+	if query == nil {
+		query = &policy.ScoreQuery{}
+	}
+
+	assetMrns := []string{query.AssetMrn}
+	if query.AssetMrn == "" {
+		assetMrns = db.activity.all()
+	}
+
+	candidates := []*policy.ScoreResult{}
+	for _, assetMrn := range assetMrns {
+		x, ok := db.cache.Get(dbIDAsset + assetMrn)
+		if !ok {
+			continue
+		}
+		resolvedPolicy := x.(wrapAsset).ResolvedPolicy
+		if resolvedPolicy == nil || resolvedPolicy.CollectorJob == nil {
+			continue
+		}
+
+		seen := map[string]struct{}{}
+		for _, job := range resolvedPolicy.CollectorJob.ReportingJobs {
+			qrID := job.QrId
+			if qrID == "root" {
+				qrID = assetMrn
+			}
+			if _, ok := seen[qrID]; ok {
+				continue
+			}
+			seen[qrID] = struct{}{}
+
+			score, err := db.GetScore(ctx, assetMrn, qrID)
+			if err != nil {
+				continue
+			}
+			candidates = append(candidates, &policy.ScoreResult{AssetMrn: assetMrn, Score: &score})
+		}
+	}
+
+	return query.Apply(candidates)
+}
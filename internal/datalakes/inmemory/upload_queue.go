@@ -0,0 +1,100 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// uploads returns the queued uploads, the caller must hold the uploads lock
+func (db *Db) uploads() []*policy.QueuedUpload {
+	x, ok := db.cache.Get(dbIDUploads)
+	if !ok {
+		return nil
+	}
+	return x.([]*policy.QueuedUpload)
+}
+
+// EnqueueUpload adds results of the space to the end of the upload queue
+func (db *Db) EnqueueUpload(ctx context.Context, spaceMrn string, req *policy.StoreResultsReq) error {
+	db.uploadsLock.Lock()
+	defer db.uploadsLock.Unlock()
+
+	db.uploadSeq++
+	upload := &policy.QueuedUpload{
+		ID:       db.uploadSeq,
+		SpaceMrn: spaceMrn,
+		Req:      proto.Clone(req).(*policy.StoreResultsReq),
+		Queued:   db.nowProvider(),
+	}
+
+	uploads := db.uploads()
+	uploads = append(uploads[:len(uploads):len(uploads)], upload)
+	if ok := db.cache.Set(dbIDUploads, uploads, 1); !ok {
+		return errors.New("failed to queue upload for asset '" + req.AssetMrn + "'")
+	}
+	return nil
+}
+
+// PendingUploads returns up to limit uploads of the space whose ID is
+// greater than afterID, oldest first
+func (db *Db) PendingUploads(ctx context.Context, spaceMrn string, afterID int64, limit int) ([]*policy.QueuedUpload, error) {
+	db.uploadsLock.Lock()
+	defer db.uploadsLock.Unlock()
+
+	res := []*policy.QueuedUpload{}
+	for _, upload := range db.uploads() {
+		if upload.SpaceMrn != spaceMrn || upload.ID <= afterID {
+			continue
+		}
+		if limit > 0 && len(res) == limit {
+			break
+		}
+		cur := *upload
+		cur.Req = proto.Clone(upload.Req).(*policy.StoreResultsReq)
+		res = append(res, &cur)
+	}
+	return res, nil
+}
+
+// CompleteUpload removes an upload from the queue
+func (db *Db) CompleteUpload(ctx context.Context, id int64) error {
+	db.uploadsLock.Lock()
+	defer db.uploadsLock.Unlock()
+
+	uploads := db.uploads()
+	res := make([]*policy.QueuedUpload, 0, len(uploads))
+	for _, upload := range uploads {
+		if upload.ID != id {
+			res = append(res, upload)
+		}
+	}
+	db.cache.Set(dbIDUploads, res, 1)
+	return nil
+}
+
+// FailUpload counts a rejected attempt of an upload
+func (db *Db) FailUpload(ctx context.Context, id int64, reason string) error {
+	db.uploadsLock.Lock()
+	defer db.uploadsLock.Unlock()
+
+	uploads := db.uploads()
+	res := make([]*policy.QueuedUpload, len(uploads))
+	for i, upload := range uploads {
+		res[i] = upload
+		if upload.ID == id {
+			cur := *upload
+			cur.Attempts++
+			cur.LastError = reason
+			res[i] = &cur
+			db.cache.Set(dbIDUploads, res, 1)
+			return nil
+		}
+	}
+	return errors.New("queued upload " + strconv.FormatInt(id, 10) + " not found")
+}
+
+var _ policy.UploadQueue = (*Db)(nil)
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// EnsureAsset makes sure an asset exists
+func (db *Db) EnsureAsset(ctx context.Context, mrn string) error {
+	_, err := db.ensureAsset(ctx, mrn)
+	return err
+}
+
+// ListAssetMrns returns the MRNs of all assets, sorted
+func (db *Db) ListAssetMrns(ctx context.Context) ([]string, error) {
+	rows, err := db.db.QueryContext(ctx, "SELECT mrn FROM assets ORDER BY mrn")
+	if err != nil {
+		return nil, errors.New("failed to list assets: " + err.Error())
+	}
+	defer rows.Close()
+
+	res := []string{}
+	for rows.Next() {
+		var mrn string
+		if err := rows.Scan(&mrn); err != nil {
+			return nil, err
+		}
+		res = append(res, mrn)
+	}
+	return res, rows.Err()
+}
+
+func (db *Db) ensureAsset(ctx context.Context, mrn string) (*policy.Policy, error) {
+	created, err := db.ensureAssetObject(ctx, mrn)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		policyObj, _, err := db.getPolicy(ctx, mrn)
+		if err != nil {
+			return nil, err
+		}
+		if policyObj != nil {
+			return policyObj, nil
+		}
+
+		log.Warn().Str("asset", mrn).Msg("assets> asset did not have a policy set, this should not happen, fixing")
+	}
+
+	return db.ensureAssetPolicy(ctx, mrn)
+}
+
+func (db *Db) ensureAssetPolicy(ctx context.Context, mrn string) (*policy.Policy, error) {
+	policyObj := db.services.CreatePolicyObject(mrn, "")
+	policyObj, filters, err := db.services.PreparePolicy(ctx, policyObj, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.setPolicy(ctx, policyObj, filters); err != nil {
+		return nil, err
+	}
+
+	return policyObj, nil
+}
+
+func (db *Db) ensureAssetObject(ctx context.Context, mrn string) (bool, error) {
+	log.Debug().Str("mrn", mrn).Msg("assets> ensure asset")
+
+	res, err := db.db.ExecContext(ctx, "INSERT OR IGNORE INTO assets (mrn) VALUES (?)", mrn)
+	if err != nil {
+		return false, errors.New("failed to create asset '" + mrn + "': " + err.Error())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n != 0, nil
+}
+
+// getAsset returns the resolved policy of an asset and its version
+func getAsset(ctx context.Context, q queryer, mrn string) (*policy.ResolvedPolicy, string, error) {
+	var data []byte
+	var version string
+	err := q.QueryRowContext(ctx, "SELECT resolved_policy, resolved_policy_version FROM assets WHERE mrn = ?", mrn).Scan(&data, &version)
+	if err == sql.ErrNoRows {
+		return nil, "", policy.NewAssetNotFoundError(mrn)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if data == nil {
+		return nil, version, nil
+	}
+
+	res := &policy.ResolvedPolicy{}
+	if err = proto.Unmarshal(data, res); err != nil {
+		return nil, "", err
+	}
+	return res, version, nil
+}
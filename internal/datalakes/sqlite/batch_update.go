@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// ApplyBatch validates all scores and data of the batch and stores them in
+// one transaction, see policy.BatchUpdater
+func (db *Db) ApplyBatch(ctx context.Context, batch *policy.BatchUpdate) (*policy.BatchUpdateResult, error) {
+	collectorJob, err := db.GetCollectorJob(ctx, batch.AssetMrn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find collectorJob to store data: %w", err)
+	}
+
+	res, data := policy.ValidateBatch(batch, collectorJob, db.coercion)
+	if !res.Applied {
+		return res, nil
+	}
+
+	now := db.nowProvider().Unix()
+	var failed *policy.BatchEntryResult
+	err = db.withTx(ctx, func(tx *sql.Tx) error {
+		for i := range batch.Scores {
+			score := batch.Scores[i]
+			entry := res.Entry(policy.BatchEntryScore, score.QrId)
+			ok, err := updateScore(ctx, tx, batch.AssetMrn, score, now)
+			if err != nil {
+				failed = entry
+				return err
+			}
+			entry.Updated = ok
+		}
+
+		for checksum, val := range data {
+			entry := res.Entry(policy.BatchEntryData, checksum)
+			if err := setDatum(ctx, tx, batch.AssetMrn, checksum, val, entry.Warning, now); err != nil {
+				failed = entry
+				return err
+			}
+			entry.Updated = true
+		}
+		return nil
+	})
+	if err != nil {
+		// the transaction was rolled back, so nothing was stored
+		if failed == nil {
+			return nil, err
+		}
+		res.RolledBack(failed, err)
+	}
+
+	return res, nil
+}
+
+var _ policy.BatchUpdater = (*Db)(nil)
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func stringResult(codeID string, value string) *llx.Result {
+	return (&llx.RawResult{Data: llx.StringData(value), CodeID: codeID}).Result()
+}
+
+func TestApplyBatch(t *testing.T) {
+	ctx := context.Background()
+	assetMrn := "//policy.api.mondoo.app/assets/web-01"
+
+	db, _ := openTestDb(t)
+	require.NoError(t, db.EnsureAsset(ctx, assetMrn))
+	require.NoError(t, db.SetAssetResolvedPolicy(ctx, assetMrn, &policy.ResolvedPolicy{
+		GraphExecutionChecksum: "checksum",
+		CollectorJob: &policy.CollectorJob{
+			Datapoints: map[string]*policy.DataQueryInfo{
+				"hostname": {Type: string(types.String)},
+				"kernel":   {Type: string(types.String)},
+			},
+		},
+	}, policy.V2Code))
+
+	res, err := db.ApplyBatch(ctx, &policy.BatchUpdate{
+		AssetMrn: assetMrn,
+		Scores:   []*policy.Score{{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result}},
+		Data:     map[string]*llx.Result{"hostname": stringResult("hostname", "web-01")},
+	})
+	require.NoError(t, err)
+	require.NoError(t, res.Err())
+	assert.True(t, res.Entry(policy.BatchEntryScore, "ssh").Updated)
+	assert.True(t, res.Entry(policy.BatchEntryData, "hostname").Updated)
+
+	t.Run("invalid batches are not stored", func(t *testing.T) {
+		res, err := db.ApplyBatch(ctx, &policy.BatchUpdate{
+			AssetMrn: assetMrn,
+			Scores:   []*policy.Score{{QrId: "tls", Value: 100, Type: policy.ScoreType_Result}},
+			Data:     map[string]*llx.Result{"unknown": stringResult("unknown", "x")},
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Applied)
+		_, err = db.GetScore(ctx, assetMrn, "tls")
+		assert.Error(t, err)
+	})
+
+	t.Run("failed batches are rolled back", func(t *testing.T) {
+		_, err := db.db.Exec(`CREATE TRIGGER fail_kernel BEFORE INSERT ON data WHEN NEW.checksum = 'kernel'
+			BEGIN SELECT RAISE(ABORT, 'disk is full'); END`)
+		require.NoError(t, err)
+		defer db.db.Exec("DROP TRIGGER fail_kernel")
+
+		res, err := db.ApplyBatch(ctx, &policy.BatchUpdate{
+			AssetMrn: assetMrn,
+			Scores:   []*policy.Score{{QrId: "ssh", Value: 0, Type: policy.ScoreType_Result}, {QrId: "tls", Value: 50, Type: policy.ScoreType_Result}},
+			Data: map[string]*llx.Result{
+				"hostname": stringResult("hostname", "web-02"),
+				"kernel":   stringResult("kernel", "6.1"),
+			},
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Applied)
+		assert.Contains(t, res.Entry(policy.BatchEntryData, "kernel").Error, "disk is full")
+		for _, entry := range res.Entries {
+			assert.False(t, entry.Updated)
+		}
+
+		// the previous values are back and new entries are gone, datapoints
+		// without values are kept empty
+		score, err := db.GetScore(ctx, assetMrn, "ssh")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), score.Value)
+		_, err = db.GetScore(ctx, assetMrn, "tls")
+		assert.Error(t, err)
+		data, err := db.GetData(ctx, assetMrn, map[string]types.Type{"hostname": types.String, "kernel": types.String})
+		require.NoError(t, err)
+		assert.Equal(t, "web-01", data["hostname"].RawResultV2().Data.Value)
+		assert.Nil(t, data["kernel"])
+	})
+}
@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// BlobThreshold is the size in bytes from which datapoints are stored
+// content-addressed. Identical large values, like file contents or
+// certificates, are then stored only once across all assets.
+const BlobThreshold = 4 * 1024
+
+func blobHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// retainBlob stores the blob or adds a reference to it, if it already exists
+func retainBlob(ctx context.Context, q queryer, hash string, data []byte) error {
+	_, err := q.ExecContext(ctx, "INSERT INTO blobs (hash, data, refs) VALUES (?, ?, 1) ON CONFLICT (hash) DO UPDATE SET refs = refs + 1", hash, data)
+	if err != nil {
+		return errors.New("failed to store blob '" + hash + "': " + err.Error())
+	}
+	return nil
+}
+
+// releaseBlob removes a reference to the blob and deletes it once it is
+// not referenced anymore
+func releaseBlob(ctx context.Context, q queryer, hash string) error {
+	if _, err := q.ExecContext(ctx, "UPDATE blobs SET refs = refs - 1 WHERE hash = ?", hash); err != nil {
+		return errors.New("failed to release blob '" + hash + "': " + err.Error())
+	}
+	if _, err := q.ExecContext(ctx, "DELETE FROM blobs WHERE hash = ? AND refs <= 0", hash); err != nil {
+		return errors.New("failed to delete blob '" + hash + "': " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func largeResult(t *testing.T, s string) (*llx.Result, string) {
+	res := llx.StringData(strings.Repeat(s, BlobThreshold)).Result()
+	data, err := proto.Marshal(res)
+	require.NoError(t, err)
+	return res, blobHash(data)
+}
+
+// blobRefs returns the references of a blob, or false if it doesn't exist
+func blobRefs(t *testing.T, db *Db, hash string) (int, bool) {
+	var refs int
+	err := db.db.QueryRow("SELECT refs FROM blobs WHERE hash = ?", hash).Scan(&refs)
+	if err == sql.ErrNoRows {
+		return 0, false
+	}
+	require.NoError(t, err)
+	return refs, true
+}
+
+func TestBlobs(t *testing.T) {
+	ctx := context.Background()
+	db, _ := openTestDb(t)
+	value, hash := largeResult(t, "a")
+
+	t.Run("identical values share one blob", func(t *testing.T) {
+		require.NoError(t, setDatum(ctx, db.db, "//assets/1", "checksum", value, "", 1))
+		require.NoError(t, setDatum(ctx, db.db, "//assets/2", "checksum", value, "", 1))
+
+		refs, ok := blobRefs(t, db, hash)
+		require.True(t, ok)
+		assert.Equal(t, 2, refs)
+
+		// storing the same value again keeps the reference
+		require.NoError(t, setDatum(ctx, db.db, "//assets/1", "checksum", value, "", 2))
+		refs, _ = blobRefs(t, db, hash)
+		assert.Equal(t, 2, refs)
+
+		for _, assetMrn := range []string{"//assets/1", "//assets/2"} {
+			data, err := db.GetData(ctx, assetMrn, map[string]types.Type{"checksum": types.String})
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(value, data["checksum"]))
+		}
+	})
+
+	t.Run("overwriting a value releases its blob", func(t *testing.T) {
+		other, otherHash := largeResult(t, "b")
+		require.NoError(t, setDatum(ctx, db.db, "//assets/1", "checksum", other, "", 3))
+
+		refs, _ := blobRefs(t, db, hash)
+		assert.Equal(t, 1, refs)
+		refs, _ = blobRefs(t, db, otherHash)
+		assert.Equal(t, 1, refs)
+	})
+
+	t.Run("the last release deletes the blob", func(t *testing.T) {
+		small := llx.StringData("small").Result()
+		require.NoError(t, setDatum(ctx, db.db, "//assets/2", "checksum", small, "", 4))
+
+		_, ok := blobRefs(t, db, hash)
+		assert.False(t, ok)
+
+		data, err := db.GetData(ctx, "//assets/2", map[string]types.Type{"checksum": types.String})
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(small, data["checksum"]))
+	})
+}
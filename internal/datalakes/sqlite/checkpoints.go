@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// SetScanCheckpoint records that the asset was completely scanned with the
+// resolved policy that has the given execution checksum
+func (db *Db) SetScanCheckpoint(ctx context.Context, assetMrn string, graphExecutionChecksum string) error {
+	_, err := db.db.ExecContext(ctx, "INSERT OR REPLACE INTO scan_checkpoints (asset_mrn, graph_execution_checksum, completed) VALUES (?, ?, ?)",
+		assetMrn, graphExecutionChecksum, db.nowProvider().Unix())
+	if err != nil {
+		return errors.New("failed to set scan checkpoint for asset '" + assetMrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// GetScanCheckpoint returns the execution checksum of the resolved policy
+// with which the asset was last completely scanned. It is empty if the asset
+// was never completely scanned.
+func (db *Db) GetScanCheckpoint(ctx context.Context, assetMrn string) (string, error) {
+	var checksum string
+	err := db.db.QueryRowContext(ctx, "SELECT graph_execution_checksum FROM scan_checkpoints WHERE asset_mrn = ?", assetMrn).Scan(&checksum)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return checksum, nil
+}
+
+// GetScanCheckpointTime returns when the asset was last completely scanned.
+// It is zero if the asset was never completely scanned.
+func (db *Db) GetScanCheckpointTime(ctx context.Context, assetMrn string) (time.Time, error) {
+	var completed int64
+	err := db.db.QueryRowContext(ctx, "SELECT completed FROM scan_checkpoints WHERE asset_mrn = ?", assetMrn).Scan(&completed)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(completed, 0), nil
+}
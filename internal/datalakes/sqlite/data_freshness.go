@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// GetDataCollected returns when all datapoints of an asset were last
+// collected, by checksum
+func (db *Db) GetDataCollected(ctx context.Context, assetMrn string) (map[string]time.Time, error) {
+	rows, err := db.db.QueryContext(ctx, "SELECT checksum, collected FROM data WHERE asset_mrn = ? AND collected IS NOT NULL", assetMrn)
+	if err != nil {
+		return nil, errors.New("failed to get collection times of data for asset '" + assetMrn + "': " + err.Error())
+	}
+	defer rows.Close()
+
+	res := map[string]time.Time{}
+	for rows.Next() {
+		var checksum string
+		var collected int64
+		if err := rows.Scan(&checksum, &collected); err != nil {
+			return nil, err
+		}
+		res[checksum] = time.Unix(collected, 0)
+	}
+	return res, rows.Err()
+}
+
+var _ policy.DataFreshnessStore = (*Db)(nil)
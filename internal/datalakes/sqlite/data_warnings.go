@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetCoercion configures which type mismatches of stored data are coerced
+// into the expected type of their datapoint, see policy.CoerceResult
+func (db *Db) SetCoercion(opts policy.CoercionOptions) {
+	db.coercion = opts
+}
+
+// GetDataWarnings returns the warnings of all datapoints of an asset, by checksum
+func (db *Db) GetDataWarnings(ctx context.Context, assetMrn string) (map[string]string, error) {
+	rows, err := db.db.QueryContext(ctx, "SELECT checksum, warning FROM data WHERE asset_mrn = ? AND warning IS NOT NULL", assetMrn)
+	if err != nil {
+		return nil, errors.New("failed to get data warnings for asset '" + assetMrn + "': " + err.Error())
+	}
+	defer rows.Close()
+
+	res := map[string]string{}
+	for rows.Next() {
+		var checksum, warning string
+		if err := rows.Scan(&checksum, &warning); err != nil {
+			return nil, err
+		}
+		res[checksum] = warning
+	}
+	return res, rows.Err()
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+var _ policy.DataWarningStore = (*Db)(nil)
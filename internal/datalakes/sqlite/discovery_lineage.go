@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetDiscoveryLineage stores the lineage of an asset
+func (db *Db) SetDiscoveryLineage(ctx context.Context, assetMrn string, lineage *policy.DiscoveryLineage) error {
+	if lineage == nil {
+		_, err := db.db.ExecContext(ctx, "DELETE FROM discovery_lineage WHERE asset_mrn = ?", assetMrn)
+		return err
+	}
+
+	_, err := db.db.ExecContext(ctx, "INSERT OR REPLACE INTO discovery_lineage (asset_mrn, correlation_id, root, credential) VALUES (?, ?, ?, ?)",
+		assetMrn, lineage.CorrelationId, lineage.Root, lineage.Credential)
+	if err != nil {
+		return errors.New("failed to save discovery lineage of asset '" + assetMrn + "'")
+	}
+	return nil
+}
+
+// GetDiscoveryLineage returns the lineage of an asset, nil if it is unknown
+func (db *Db) GetDiscoveryLineage(ctx context.Context, assetMrn string) (*policy.DiscoveryLineage, error) {
+	res := &policy.DiscoveryLineage{}
+	err := db.db.QueryRowContext(ctx, "SELECT correlation_id, root, credential FROM discovery_lineage WHERE asset_mrn = ?", assetMrn).
+		Scan(&res.CorrelationId, &res.Root, &res.Credential)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListDiscoveredAssets returns the MRNs of all assets with the given
+// correlation ID, sorted
+func (db *Db) ListDiscoveredAssets(ctx context.Context, correlationID string) ([]string, error) {
+	rows, err := db.db.QueryContext(ctx, "SELECT asset_mrn FROM discovery_lineage WHERE correlation_id = ? ORDER BY asset_mrn", correlationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []string{}
+	for rows.Next() {
+		var mrn string
+		if err := rows.Scan(&mrn); err != nil {
+			return nil, err
+		}
+		res = append(res, mrn)
+	}
+	return res, rows.Err()
+}
+
+var _ policy.DiscoveryLineageStore = (*Db)(nil)
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetException stores an exception, it replaces any exception for the
+// same check on the same entity
+func (db *Db) SetException(ctx context.Context, exception *policy.Exception) error {
+	var expires, reviewed int64
+	if !exception.Expires.IsZero() {
+		expires = exception.Expires.Unix()
+	}
+	if !exception.Reviewed.IsZero() {
+		reviewed = exception.Reviewed.Unix()
+	}
+	state := exception.State
+	if state == "" {
+		state = policy.ExceptionApproved
+	}
+
+	_, err := db.db.ExecContext(ctx, "INSERT OR REPLACE INTO exceptions (entity_mrn, check_mrn, justification, created, expires, state, reviewer, review_comment, reviewed) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		exception.EntityMrn, exception.CheckMrn, exception.Justification, exception.Created.Unix(), expires, string(state), exception.Reviewer, exception.ReviewComment, reviewed)
+	if err != nil {
+		return errors.New("failed to save exception for check '" + exception.CheckMrn + "' on '" + exception.EntityMrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// DeleteException removes the exception for a check on an entity
+func (db *Db) DeleteException(ctx context.Context, entityMrn string, checkMrn string) error {
+	_, err := db.db.ExecContext(ctx, "DELETE FROM exceptions WHERE entity_mrn = ? AND check_mrn = ?", entityMrn, checkMrn)
+	if err != nil {
+		return errors.New("failed to delete exception for check '" + checkMrn + "' on '" + entityMrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// ListExceptions returns all exceptions of an entity, including expired ones
+func (db *Db) ListExceptions(ctx context.Context, entityMrn string) ([]*policy.Exception, error) {
+	rows, err := db.db.QueryContext(ctx, "SELECT check_mrn, justification, created, expires, state, reviewer, review_comment, reviewed FROM exceptions WHERE entity_mrn = ? ORDER BY check_mrn", entityMrn)
+	if err != nil {
+		return nil, errors.New("failed to list exceptions of '" + entityMrn + "': " + err.Error())
+	}
+	defer rows.Close()
+
+	var res []*policy.Exception
+	for rows.Next() {
+		var created, expires, reviewed int64
+		var state string
+		exception := &policy.Exception{EntityMrn: entityMrn}
+		if err := rows.Scan(&exception.CheckMrn, &exception.Justification, &created, &expires,
+			&state, &exception.Reviewer, &exception.ReviewComment, &reviewed); err != nil {
+			return nil, err
+		}
+		exception.State = policy.ExceptionState(state)
+		exception.Created = time.Unix(created, 0)
+		if expires != 0 {
+			exception.Expires = time.Unix(expires, 0)
+		}
+		if reviewed != 0 {
+			exception.Reviewed = time.Unix(reviewed, 0)
+		}
+		res = append(res, exception)
+	}
+	return res, rows.Err()
+}
+
+var _ policy.ExceptionStore = (*Db)(nil)
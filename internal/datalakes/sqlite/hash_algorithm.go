@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// hashAlgorithmSetting keeps the algorithm of all checksums in the
+// datalake, see policy.SetHashAlgorithm
+const hashAlgorithmSetting = "hash_algorithm"
+
+// checkHashAlgorithm records the hash algorithm of new datalakes and refuses
+// datalakes that were built with another one. Their checksums, which key
+// resolved policies, checkpoints and data, would never match and every
+// lookup would miss without an error. The caller has to hold the write lock
+// of the database.
+func checkHashAlgorithm(ctx context.Context, conn *sql.Conn) error {
+	alg := string(policy.GetHashAlgorithm())
+
+	var stored string
+	err := conn.QueryRowContext(ctx, "SELECT value FROM settings WHERE key = ?", hashAlgorithmSetting).Scan(&stored)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := conn.ExecContext(ctx, "INSERT INTO settings (key, value) VALUES (?, ?)", hashAlgorithmSetting, alg); err != nil {
+			return errors.New("failed to store hash algorithm of sqlite datalake: " + err.Error())
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.New("failed to get hash algorithm of sqlite datalake: " + err.Error())
+	}
+
+	if stored != alg {
+		return errors.New("sqlite datalake was created with hash algorithm " + stored + ", but " + alg +
+			" is configured. Set CNSPEC_HASH_ALGORITHM=" + stored + " or use a new datalake")
+	}
+	return nil
+}
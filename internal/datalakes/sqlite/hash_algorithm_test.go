@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestHashAlgorithm(t *testing.T) {
+	defer policy.SetHashAlgorithm(policy.GetHashAlgorithm())
+
+	require.NoError(t, policy.SetHashAlgorithm(policy.HashFNV1a))
+	db, path := openTestDb(t)
+	require.NoError(t, db.Close())
+
+	// reopening with the same algorithm works
+	db, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// checksums of another algorithm would never match
+	require.NoError(t, policy.SetHashAlgorithm(policy.HashSHA256))
+	_, err = Open(path)
+	assert.ErrorContains(t, err, "created with hash algorithm fnv1a, but sha256 is configured")
+
+	// new datalakes use the configured algorithm
+	db, _ = openTestDb(t)
+	var stored string
+	require.NoError(t, db.db.QueryRow("SELECT value FROM settings WHERE key = ?", hashAlgorithmSetting).Scan(&stored))
+	assert.Equal(t, "sha256", stored)
+}
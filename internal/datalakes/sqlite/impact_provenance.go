@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetImpactProvenance stores the impact provenance that was recorded while resolving a policy
+func (db *Db) SetImpactProvenance(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy, provenance map[string][]*policy.ImpactProvenance) error {
+	id := resolvedPolicy.GraphExecutionChecksum + "\x00" + resolvedPolicy.FiltersChecksum
+	if len(provenance) == 0 {
+		_, err := db.db.ExecContext(ctx, "DELETE FROM impact_provenance WHERE id = ?", id)
+		return err
+	}
+
+	data, err := json.Marshal(provenance)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO impact_provenance (id, data) VALUES (?, ?)", id, data)
+	if err != nil {
+		return errors.New("failed to save impact provenance for resolved policy '" + resolvedPolicy.GraphExecutionChecksum + "'")
+	}
+	return nil
+}
+
+// GetImpactProvenance returns the impact provenance that was recorded while resolving a policy
+func (db *Db) GetImpactProvenance(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy) (map[string][]*policy.ImpactProvenance, error) {
+	var data []byte
+	err := db.db.QueryRowContext(ctx, "SELECT data FROM impact_provenance WHERE id = ?",
+		resolvedPolicy.GraphExecutionChecksum+"\x00"+resolvedPolicy.FiltersChecksum).Scan(&data)
+	if err == sql.ErrNoRows {
+		return map[string][]*policy.ImpactProvenance{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string][]*policy.ImpactProvenance{}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+var _ policy.ImpactProvenanceStore = (*Db)(nil)
@@ -0,0 +1,211 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// migrations are applied in order. The schema version of the database is
+// tracked via `PRAGMA user_version`, which is the index of the next migration.
+// Never change an existing migration, always add a new one.
+var migrations = []string{
+	// 1: initial schema
+	`
+	CREATE TABLE queries (
+		mrn  TEXT PRIMARY KEY,
+		data BLOB NOT NULL
+	);
+	CREATE TABLE properties (
+		mrn  TEXT PRIMARY KEY,
+		data BLOB NOT NULL
+	);
+	CREATE TABLE policies (
+		mrn         TEXT PRIMARY KEY,
+		owner_mrn   TEXT NOT NULL,
+		data        BLOB NOT NULL,
+		invalidated INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX policies_owner ON policies (owner_mrn);
+	CREATE TABLE policy_children (
+		parent_mrn TEXT NOT NULL,
+		child_mrn  TEXT NOT NULL,
+		PRIMARY KEY (parent_mrn, child_mrn)
+	);
+	CREATE INDEX policy_children_child ON policy_children (child_mrn);
+	CREATE TABLE bundles (
+		mrn                    TEXT PRIMARY KEY,
+		data                   BLOB NOT NULL,
+		graph_content_checksum TEXT NOT NULL,
+		invalidated            INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE TABLE assets (
+		mrn                     TEXT PRIMARY KEY,
+		resolved_policy         BLOB,
+		resolved_policy_version TEXT NOT NULL DEFAULT ''
+	);
+	CREATE TABLE resolved_policies (
+		id      TEXT PRIMARY KEY,
+		data    BLOB NOT NULL,
+		created INTEGER NOT NULL,
+		expires INTEGER
+	);
+	CREATE TABLE resolution_conflicts (
+		id   TEXT PRIMARY KEY,
+		data BLOB NOT NULL
+	);
+	CREATE TABLE impact_provenance (
+		id   TEXT PRIMARY KEY,
+		data BLOB NOT NULL
+	);
+	CREATE TABLE scores (
+		asset_mrn TEXT NOT NULL,
+		qr_id     TEXT NOT NULL,
+		data      BLOB NOT NULL,
+		PRIMARY KEY (asset_mrn, qr_id)
+	);
+	CREATE TABLE score_history (
+		id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+		asset_mrn          TEXT NOT NULL,
+		qr_id              TEXT NOT NULL,
+		outcome            TEXT NOT NULL,
+		value              INTEGER NOT NULL,
+		execution_checksum TEXT NOT NULL,
+		recorded           INTEGER NOT NULL
+	);
+	CREATE INDEX score_history_asset ON score_history (asset_mrn, id);
+	CREATE INDEX score_history_score ON score_history (asset_mrn, qr_id, id);
+	CREATE TABLE blobs (
+		hash TEXT PRIMARY KEY,
+		data BLOB NOT NULL,
+		refs INTEGER NOT NULL
+	);
+	CREATE TABLE data (
+		asset_mrn TEXT NOT NULL,
+		checksum  TEXT NOT NULL,
+		data      BLOB,
+		blob_hash TEXT REFERENCES blobs (hash),
+		warning   TEXT,
+		collected INTEGER,
+		PRIMARY KEY (asset_mrn, checksum)
+	);
+	CREATE TABLE reports (
+		id        TEXT PRIMARY KEY,
+		asset_mrn TEXT NOT NULL,
+		data      BLOB NOT NULL,
+		created   INTEGER NOT NULL
+	);
+	CREATE INDEX reports_asset ON reports (asset_mrn);
+	CREATE TABLE exceptions (
+		entity_mrn     TEXT NOT NULL,
+		check_mrn      TEXT NOT NULL,
+		justification  TEXT NOT NULL,
+		created        INTEGER NOT NULL,
+		expires        INTEGER NOT NULL,
+		state          TEXT NOT NULL DEFAULT 'approved',
+		reviewer       TEXT NOT NULL DEFAULT '',
+		review_comment TEXT NOT NULL DEFAULT '',
+		reviewed       INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (entity_mrn, check_mrn)
+	);
+	CREATE TABLE discovery_lineage (
+		asset_mrn      TEXT PRIMARY KEY,
+		correlation_id TEXT NOT NULL,
+		root           TEXT NOT NULL,
+		credential     TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX discovery_lineage_correlation ON discovery_lineage (correlation_id);
+	CREATE TABLE scan_jobs (
+		id       INTEGER PRIMARY KEY AUTOINCREMENT,
+		checksum TEXT NOT NULL,
+		data     BLOB NOT NULL,
+		state    TEXT NOT NULL,
+		created  INTEGER NOT NULL
+	);
+	CREATE UNIQUE INDEX scan_jobs_pending ON scan_jobs (checksum) WHERE state = 'pending';
+	CREATE TABLE scan_checkpoints (
+		asset_mrn                TEXT PRIMARY KEY,
+		graph_execution_checksum TEXT NOT NULL,
+		completed                INTEGER NOT NULL
+	);
+	CREATE TABLE upload_queue (
+		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+		space_mrn  TEXT NOT NULL,
+		asset_mrn  TEXT NOT NULL,
+		data       BLOB NOT NULL,
+		queued     INTEGER NOT NULL,
+		attempts   INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX upload_queue_space ON upload_queue (space_mrn, id);
+	CREATE TABLE space_quotas (
+		space_mrn TEXT PRIMARY KEY,
+		data      BLOB NOT NULL
+	);
+	CREATE TABLE space_usage (
+		space_mrn    TEXT NOT NULL,
+		day          INTEGER NOT NULL,
+		scans        INTEGER NOT NULL DEFAULT 0,
+		datapoints   INTEGER NOT NULL DEFAULT 0,
+		upload_bytes INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (space_mrn, day)
+	);
+	CREATE TABLE settings (
+		key   TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+	`,
+}
+
+// migrate brings the database schema up to date and checks that the
+// datalake was built with the configured hash algorithm
+func migrate(ctx context.Context, db *sql.DB) error {
+	// concurrent scanners may open the same datalake, so the version is read
+	// and updated while holding the write lock
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return errors.New("failed to lock sqlite datalake for migrations: " + err.Error())
+	}
+	if err := migrateLocked(ctx, conn); err != nil {
+		conn.ExecContext(ctx, "ROLLBACK")
+		return err
+	}
+	if err := checkHashAlgorithm(ctx, conn); err != nil {
+		conn.ExecContext(ctx, "ROLLBACK")
+		return err
+	}
+	_, err = conn.ExecContext(ctx, "COMMIT")
+	return err
+}
+
+// migrateLocked applies all missing migrations, the caller has to hold the
+// write lock of the database
+func migrateLocked(ctx context.Context, conn *sql.Conn) error {
+	var version int
+	if err := conn.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return errors.New("failed to get sqlite datalake schema version: " + err.Error())
+	}
+
+	if version > len(migrations) {
+		return errors.New("sqlite datalake schema version " + strconv.Itoa(version) + " is newer than this version of cnspec supports")
+	}
+	if version == len(migrations) {
+		return nil
+	}
+
+	for i := version; i < len(migrations); i++ {
+		if _, err := conn.ExecContext(ctx, migrations[i]); err != nil {
+			return errors.New("failed to migrate sqlite datalake to version " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+	}
+
+	// pragmas don't support placeholders
+	_, err := conn.ExecContext(ctx, "PRAGMA user_version = "+strconv.Itoa(len(migrations)))
+	return err
+}
@@ -0,0 +1,435 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// QueryExists checks if the given MRN exists
+func (db *Db) QueryExists(ctx context.Context, mrn string) (bool, error) {
+	return db.exists(ctx, "SELECT 1 FROM queries WHERE mrn = ?", mrn)
+}
+
+// PolicyExists checks if the given MRN exists
+func (db *Db) PolicyExists(ctx context.Context, mrn string) (bool, error) {
+	return db.exists(ctx, "SELECT 1 FROM policies WHERE mrn = ?", mrn)
+}
+
+func (db *Db) exists(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	var x int
+	err := db.db.QueryRowContext(ctx, query, args...).Scan(&x)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetQuery retrieves a given query
+func (db *Db) GetQuery(ctx context.Context, mrn string) (*explorer.Mquery, error) {
+	res := &explorer.Mquery{}
+	ok, err := getProto(ctx, db.db, res, "SELECT data FROM queries WHERE mrn = ?", mrn)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("query '" + mrn + "' not found")
+	}
+	return res, nil
+}
+
+// SetQuery stores a given query
+// Note: the query must be defined, it cannot be nil
+func (db *Db) SetQuery(ctx context.Context, mrn string, mquery *explorer.Mquery) error {
+	data, err := proto.Marshal(mquery)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO queries (mrn, data) VALUES (?, ?)", mrn, data)
+	if err != nil {
+		return errors.New("failed to save query '" + mrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// GetProperty retrieves a given property
+func (db *Db) GetProperty(ctx context.Context, mrn string) (*explorer.Property, error) {
+	res := &explorer.Property{}
+	ok, err := getProto(ctx, db.db, res, "SELECT data FROM properties WHERE mrn = ?", mrn)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("property '" + mrn + "' not found")
+	}
+	return res, nil
+}
+
+// SetProperty stores a given property
+// Note: the property must be defined, it cannot be nil
+func (db *Db) SetProperty(ctx context.Context, mrn string, prop *explorer.Property) error {
+	data, err := proto.Marshal(prop)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO properties (mrn, data) VALUES (?, ?)", mrn, data)
+	if err != nil {
+		return errors.New("failed to save property '" + mrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// getPolicy returns the stored policy and whether it was invalidated
+func (db *Db) getPolicy(ctx context.Context, mrn string) (*policy.Policy, bool, error) {
+	var data []byte
+	var invalidated bool
+	err := db.db.QueryRowContext(ctx, "SELECT data, invalidated FROM policies WHERE mrn = ?", mrn).Scan(&data, &invalidated)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	res := &policy.Policy{}
+	if err = proto.Unmarshal(data, res); err != nil {
+		return nil, false, err
+	}
+	return res, invalidated, nil
+}
+
+// savePolicy stores the policy and resets its invalidation
+func (db *Db) savePolicy(ctx context.Context, policyObj *policy.Policy) error {
+	data, err := proto.Marshal(policyObj)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO policies (mrn, owner_mrn, data, invalidated) VALUES (?, ?, ?, 0)",
+		policyObj.Mrn, policyObj.OwnerMrn, data)
+	if err != nil {
+		return errors.New("failed to save policy '" + policyObj.Mrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// updatePolicy stores the policy without changing its invalidation
+func (db *Db) updatePolicy(ctx context.Context, policyObj *policy.Policy) error {
+	data, err := proto.Marshal(policyObj)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "UPDATE policies SET owner_mrn = ?, data = ? WHERE mrn = ?",
+		policyObj.OwnerMrn, data, policyObj.Mrn)
+	if err != nil {
+		return errors.New("failed to update policy '" + policyObj.Mrn + "': " + err.Error())
+	}
+	return nil
+}
+
+func (db *Db) policyParents(ctx context.Context, mrn string) ([]string, error) {
+	return listStrings(ctx, db.db, "SELECT parent_mrn FROM policy_children WHERE child_mrn = ?", mrn)
+}
+
+func (db *Db) addPolicyChild(ctx context.Context, parentMrn string, childMrn string) error {
+	_, err := db.db.ExecContext(ctx, "INSERT OR IGNORE INTO policy_children (parent_mrn, child_mrn) VALUES (?, ?)", parentMrn, childMrn)
+	if err != nil {
+		return errors.New("failed to update child-parent relationship for policy '" + childMrn + "': " + err.Error())
+	}
+	return nil
+}
+
+func (db *Db) removePolicyChild(ctx context.Context, parentMrn string, childMrn string) error {
+	_, err := db.db.ExecContext(ctx, "DELETE FROM policy_children WHERE parent_mrn = ? AND child_mrn = ?", parentMrn, childMrn)
+	if err != nil {
+		return errors.New("failed to update child-parent relationship for policy '" + childMrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// GetRawPolicy retrieves the policy without fixing any invalidations (fast)
+func (db *Db) GetRawPolicy(ctx context.Context, mrn string) (*policy.Policy, error) {
+	res, _, err := db.getPolicy(ctx, mrn)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, policy.NewPolicyNotFoundError(mrn)
+	}
+	return res, nil
+}
+
+// GetPolicyFilters retrieves the list of asset filters for a policy (fast)
+func (db *Db) GetPolicyFilters(ctx context.Context, mrn string) ([]*explorer.Mquery, error) {
+	r, err := db.GetRawPolicy(ctx, mrn)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Filters == nil || len(r.Filters.Items) == 0 {
+		return nil, nil
+	}
+
+	res := make([]*explorer.Mquery, len(r.Filters.Items))
+	var i int
+	for _, v := range r.Filters.Items {
+		res[i] = v
+		i++
+	}
+
+	return res, nil
+}
+
+// SetPolicy stores a given policy in the data lake
+func (db *Db) SetPolicy(ctx context.Context, policyObj *policy.Policy, filters []*explorer.Mquery) error {
+	return db.setPolicy(ctx, policyObj, filters)
+}
+
+func (db *Db) setPolicy(ctx context.Context, policyObj *policy.Policy, filters []*explorer.Mquery) error {
+	existing, _, err := db.getPolicy(ctx, policyObj.Mrn)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if existing.LocalContentChecksum == policyObj.LocalContentChecksum &&
+			existing.LocalExecutionChecksum == policyObj.LocalExecutionChecksum {
+			if existing.GraphContentChecksum != policyObj.GraphContentChecksum ||
+				existing.GraphExecutionChecksum != policyObj.GraphExecutionChecksum {
+				return db.checkAndInvalidatePolicyBundle(ctx, existing)
+			}
+			return nil
+		}
+
+		// fall through, re-create the policy
+	}
+
+	policyObj.Filters = &explorer.Filters{
+		Items: make(map[string]*explorer.Mquery, len(filters)),
+	}
+	for i := range filters {
+		filter := filters[i]
+		policyObj.Filters.Items[filter.CodeId] = filter
+		if err = db.SetQuery(ctx, filter.Mrn, filter); err != nil {
+			return err
+		}
+	}
+
+	// relationships are re-created, parents of this policy stay as they are
+	if _, err = db.db.ExecContext(ctx, "DELETE FROM policy_children WHERE parent_mrn = ?", policyObj.Mrn); err != nil {
+		return err
+	}
+	children := policyObj.DependentPolicyMrns()
+	for childMrn := range children {
+		ok, err := db.PolicyExists(ctx, childMrn)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("failed to get child policy '" + childMrn + "'")
+		}
+		if err = db.addPolicyChild(ctx, policyObj.Mrn, childMrn); err != nil {
+			return err
+		}
+	}
+
+	if err = db.savePolicy(ctx, policyObj); err != nil {
+		return err
+	}
+
+	return db.checkAndInvalidatePolicyBundle(ctx, policyObj)
+}
+
+func (db *Db) checkAndInvalidatePolicyBundle(ctx context.Context, policyObj *policy.Policy) error {
+	var graphContentChecksum string
+	err := db.db.QueryRowContext(ctx, "SELECT graph_content_checksum FROM bundles WHERE mrn = ?", policyObj.Mrn).Scan(&graphContentChecksum)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if err == nil && graphContentChecksum == policyObj.GraphContentChecksum {
+		log.Trace().Str("policy", policyObj.Mrn).Msg("marketplace> policy cache is up-to-date")
+		return nil
+	}
+
+	return db.invalidatePolicyAndBundleAncestors(ctx, policyObj.Mrn, map[string]struct{}{})
+}
+
+func (db *Db) invalidatePolicyAndBundleAncestors(ctx context.Context, mrn string, visited map[string]struct{}) error {
+	if _, ok := visited[mrn]; ok {
+		return nil
+	}
+	visited[mrn] = struct{}{}
+	log.Debug().Str("policy", mrn).Msg("invalidate policy cache")
+
+	res, err := db.db.ExecContext(ctx, "UPDATE policies SET invalidated = 1 WHERE mrn = ?", mrn)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return policy.NewPolicyNotFoundError(mrn)
+	}
+
+	if _, err = db.db.ExecContext(ctx, "UPDATE bundles SET invalidated = 1 WHERE mrn = ?", mrn); err != nil {
+		return err
+	}
+
+	// update all dependencies
+	parents, err := db.policyParents(ctx, mrn)
+	if err != nil {
+		return err
+	}
+	for i := range parents {
+		if err := db.invalidatePolicyAndBundleAncestors(ctx, parents[i], visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListPolicies all policies for a given owner
+// Note: Owner MRN is required
+func (db *Db) ListPolicies(ctx context.Context, ownerMrn string, name string) ([]*policy.Policy, error) {
+	rows, err := db.db.QueryContext(ctx, "SELECT data FROM policies WHERE owner_mrn = ?", ownerMrn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []*policy.Policy{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		policyObj := &policy.Policy{}
+		if err := proto.Unmarshal(data, policyObj); err != nil {
+			return nil, err
+		}
+		res = append(res, policyObj)
+	}
+
+	return res, rows.Err()
+}
+
+// DeletePolicy removes a given policy
+// Note: the MRN has to be valid
+func (db *Db) DeletePolicy(ctx context.Context, mrn string) error {
+	ok, err := db.PolicyExists(ctx, mrn)
+	if err != nil || !ok {
+		return err
+	}
+
+	parents, err := db.policyParents(ctx, mrn)
+	if err != nil {
+		return err
+	}
+	if len(parents) != 0 {
+		return errors.New("cannot remove policy '" + mrn + "' it has " + strconv.Itoa(len(parents)) + " other policies attached")
+	}
+
+	return db.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM policy_children WHERE parent_mrn = ?", mrn); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM bundles WHERE mrn = ?", mrn); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM policies WHERE mrn = ?", mrn)
+		return err
+	})
+}
+
+// GetValidatedBundle retrieves and if necessary updates the policy bundle
+// Note: the checksum and graphchecksum of the policy must be computed to the right number
+func (db *Db) GetValidatedBundle(ctx context.Context, mrn string) (*policy.Bundle, error) {
+	policyv, err := db.GetValidatedPolicy(ctx, mrn)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	var graphContentChecksum string
+	var invalidated bool
+	err = db.db.QueryRowContext(ctx, "SELECT data, graph_content_checksum, invalidated FROM bundles WHERE mrn = ?", mrn).
+		Scan(&data, &graphContentChecksum, &invalidated)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if err == nil && !invalidated && graphContentChecksum == policyv.GraphContentChecksum {
+		res := &policy.Bundle{}
+		if err = proto.Unmarshal(data, res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	bundle, err := db.services.ComputeBundle(ctx, policyv)
+	if err != nil {
+		return nil, errors.New("failed to compute policy bundle: " + err.Error())
+	}
+
+	data, err = proto.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO bundles (mrn, data, graph_content_checksum, invalidated) VALUES (?, ?, ?, 0)",
+		mrn, data, policyv.GraphContentChecksum)
+	if err != nil {
+		return nil, errors.New("failed to save policy bundle '" + policyv.Mrn + "': " + err.Error())
+	}
+
+	return bundle, nil
+}
+
+// GetValidatedPolicy retrieves and if necessary updates the policy
+func (db *Db) GetValidatedPolicy(ctx context.Context, mrn string) (*policy.Policy, error) {
+	p, invalidated, err := db.getPolicy(ctx, mrn)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, policy.NewPolicyNotFoundError(mrn)
+	}
+
+	if invalidated {
+		if err = db.fixInvalidatedPolicy(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (db *Db) fixInvalidatedPolicy(ctx context.Context, policyObj *policy.Policy) error {
+	policyObj.InvalidateGraphChecksums()
+	policyObj.UpdateChecksums(ctx,
+		func(ctx context.Context, mrn string) (*policy.Policy, error) { return db.GetValidatedPolicy(ctx, mrn) },
+		func(ctx context.Context, mrn string) (*explorer.Mquery, error) { return db.GetQuery(ctx, mrn) },
+		nil)
+
+	return db.savePolicy(ctx, policyObj)
+}
+
+// sortedPolicyRefs turns the policy references into a list with a stable order
+func sortedPolicyRefs(refs map[string]*policy.PolicyRef) []*policy.PolicyRef {
+	res := make([]*policy.PolicyRef, 0, len(refs))
+	for _, v := range refs {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Mrn < res[j].Mrn
+	})
+	return res
+}
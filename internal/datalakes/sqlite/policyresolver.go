@@ -0,0 +1,887 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/ranger-rpc/codes"
+	"go.mondoo.com/ranger-rpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// MutatePolicy modifies a policy. If it does not find the policy, and if the
+// caller chooses to, it will treat the MRN as an asset and create it + its policy
+func (db *Db) MutatePolicy(ctx context.Context, mutation *policy.PolicyMutationDelta, createIfMissing bool) (*policy.Policy, error) {
+	targetMRN := mutation.PolicyMrn
+
+	policyObj, err := db.ensurePolicy(ctx, targetMRN, createIfMissing)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policyObj.Groups) == 0 {
+		log.Error().Str("policy", targetMRN).Msg("resolver.db> failed to modify policy, it has no specs")
+		return nil, errors.New("cannot modify policy, it has no specs (invalid state)")
+	}
+
+	group := policyObj.Groups[0]
+	changed := false
+
+	// prepare a map for easier processing
+	policies := map[string]*policy.PolicyRef{}
+	for i := range group.Policies {
+		cur := group.Policies[i]
+		policies[cur.Mrn] = cur
+	}
+
+	for policyMrn, delta := range mutation.PolicyDeltas {
+		switch delta.Action {
+		case policy.PolicyDelta_ADD:
+			if _, ok := policies[policyMrn]; ok {
+				continue
+			}
+
+			// FIXME: upstream policies
+
+			ok, err := db.PolicyExists(ctx, policyMrn)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, policy.NewPolicyNotFoundError(policyMrn)
+			}
+
+			policies[policyMrn] = &policy.PolicyRef{
+				Mrn: policyMrn,
+			}
+			if err = db.addPolicyChild(ctx, targetMRN, policyMrn); err != nil {
+				return nil, err
+			}
+
+			changed = true
+
+		case policy.PolicyDelta_DELETE:
+			ok, err := db.PolicyExists(ctx, policyMrn)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, policy.NewPolicyNotFoundError(policyMrn)
+			}
+
+			delete(policies, policyMrn)
+			if err = db.removePolicyChild(ctx, targetMRN, policyMrn); err != nil {
+				return nil, err
+			}
+
+			changed = true
+
+		default:
+			return nil, status.Error(codes.InvalidArgument, "unsupported change  is required")
+		}
+	}
+
+	if !changed {
+		return policyObj, nil
+	}
+
+	// since the map was only used for faster create/delete, we now have to translate it back
+	group.Policies = sortedPolicyRefs(policies)
+
+	err = db.refreshAssetFilters(ctx, policyObj)
+	if err != nil {
+		return nil, err
+	}
+
+	policyObj.InvalidateExecutionChecksums()
+	err = policyObj.UpdateChecksums(ctx,
+		func(ctx context.Context, mrn string) (*policy.Policy, error) { return db.GetValidatedPolicy(ctx, mrn) },
+		func(ctx context.Context, mrn string) (*explorer.Mquery, error) { return db.GetQuery(ctx, mrn) },
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.updatePolicy(ctx, policyObj); err != nil {
+		return nil, err
+	}
+
+	err = db.checkAndInvalidatePolicyBundle(ctx, policyObj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.refreshDependentAssetFilters(ctx, targetMRN)
+	if err != nil {
+		return nil, err
+	}
+
+	return policyObj, nil
+}
+
+func (db *Db) ensurePolicy(ctx context.Context, mrn string, createIfMissing bool) (*policy.Policy, error) {
+	policyObj, _, err := db.getPolicy(ctx, mrn)
+	if err != nil {
+		return nil, err
+	}
+	if policyObj != nil {
+		return policyObj, nil
+	}
+
+	if !createIfMissing {
+		return nil, errors.New("failed to modify policy '" + mrn + "', could not find it")
+	}
+
+	return db.ensureAsset(ctx, mrn)
+}
+
+func (db *Db) refreshAssetFilters(ctx context.Context, policyObj *policy.Policy) error {
+	filters, err := policyObj.ComputeAssetFilters(ctx,
+		func(ctx context.Context, mrn string) (*policy.Policy, error) { return db.GetRawPolicy(ctx, mrn) },
+		false,
+	)
+	if err != nil {
+		return errors.New("failed to compute asset filters: " + err.Error())
+	}
+
+	policyObj.Filters = &explorer.Filters{
+		Items: map[string]*explorer.Mquery{},
+	}
+	for i := range filters {
+		filter := filters[i]
+		policyObj.Filters.Items[filter.CodeId] = filter
+	}
+
+	depMrns := policyObj.DependentPolicyMrns()
+	for mrn := range depMrns {
+		dep, err := db.GetRawPolicy(ctx, mrn)
+		if err != nil {
+			return errors.New("failed to get dependent policy '" + mrn + "': " + err.Error())
+		}
+
+		if dep.Filters == nil {
+			continue
+		}
+
+		for k, v := range dep.Filters.Items {
+			policyObj.Filters.Items[k] = v
+		}
+	}
+
+	if err = db.updatePolicy(ctx, policyObj); err != nil {
+		return errors.New("failed to update policy asset filters for '" + policyObj.Mrn + "'")
+	}
+
+	return nil
+}
+
+func (db *Db) refreshDependentAssetFilters(ctx context.Context, startMrn string) error {
+	needsUpdate := map[string]struct{}{}
+
+	parents, err := db.policyParents(ctx, startMrn)
+	if err != nil {
+		return err
+	}
+	for i := range parents {
+		needsUpdate[parents[i]] = struct{}{}
+	}
+
+	for len(needsUpdate) > 0 {
+		for k := range needsUpdate {
+			policyObj, err := db.GetRawPolicy(ctx, k)
+			if err != nil {
+				return errors.New("failed to get parent policy '" + k + "'")
+			}
+
+			err = db.refreshAssetFilters(ctx, policyObj)
+			if err != nil {
+				return err
+			}
+
+			policyObj.InvalidateGraphChecksums()
+			err = policyObj.UpdateChecksums(ctx,
+				func(ctx context.Context, mrn string) (*policy.Policy, error) { return db.GetValidatedPolicy(ctx, mrn) },
+				func(ctx context.Context, mrn string) (*explorer.Mquery, error) { return db.GetQuery(ctx, mrn) },
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err = db.updatePolicy(ctx, policyObj); err != nil {
+				return err
+			}
+			err = db.checkAndInvalidatePolicyBundle(ctx, policyObj)
+			if err != nil {
+				return err
+			}
+
+			parents, err := db.policyParents(ctx, k)
+			if err != nil {
+				return err
+			}
+			for i := range parents {
+				needsUpdate[parents[i]] = struct{}{}
+			}
+
+			delete(needsUpdate, k)
+		}
+	}
+
+	return nil
+}
+
+// GetReport retrieves all scores and data for a given asset
+func (db *Db) GetReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, error) {
+	res, _, err := db.getReport(ctx, assetMrn, qrID, true, false)
+	return res, err
+}
+
+// GetPartialReport returns the report with all scores and data that were
+// found and how complete it is
+func (db *Db) GetPartialReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, *policy.ReportCompleteness, error) {
+	return db.getReport(ctx, assetMrn, qrID, true, true)
+}
+
+// getReport collects the report of an asset. Partial reports skip missing
+// scores and data instead of failing.
+func (db *Db) getReport(ctx context.Context, assetMrn string, qrID string, withData bool, partial bool) (*policy.Report, *policy.ReportCompleteness, error) {
+	emptyReport := &policy.Report{
+		EntityMrn:  assetMrn,
+		ScoringMrn: qrID,
+	}
+
+	score, err := db.GetScore(ctx, assetMrn, qrID)
+	if err != nil {
+		return emptyReport, nil, nil
+	}
+
+	resolvedPolicy, resolvedPolicyVersion, err := getAsset(ctx, db.db, assetMrn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resolvedPolicy == nil {
+		return nil, nil, policy.NewInvalidStateError(assetMrn, "cannot find resolved policy for asset '"+assetMrn+"'")
+	}
+
+	includedScores := map[string]struct{}{}
+	for _, job := range resolvedPolicy.CollectorJob.ReportingJobs {
+		qrid := job.QrId
+		if qrid == "root" {
+			qrid = assetMrn
+		}
+
+		includedScores[qrid] = struct{}{}
+	}
+	scoreQrIDs := make([]string, len(includedScores))
+	i := 0
+	for k := range includedScores {
+		scoreQrIDs[i] = k
+		i++
+	}
+
+	scores, missingScores, err := db.GetScoresPartial(ctx, assetMrn, scoreQrIDs)
+	if err == nil && !partial && len(missingScores) != 0 {
+		err = policy.NewScoreNotFoundError(assetMrn, missingScores[0])
+	}
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("entity", assetMrn).
+			Msg("resolver.db> could not fetch scores for asset")
+		return nil, nil, err
+	}
+
+	res := policy.Report{
+		EntityMrn:             assetMrn,
+		ScoringMrn:            qrID,
+		Score:                 &score,
+		Scores:                scores,
+		ResolvedPolicyVersion: resolvedPolicyVersion,
+	}
+	completeness := &policy.ReportCompleteness{
+		ScoresTotal:   len(scoreQrIDs),
+		MissingScores: missingScores,
+	}
+
+	if withData {
+		datapoints := resolvedPolicy.CollectorJob.Datapoints
+		fields := make(map[string]types.Type, len(datapoints))
+		for field, info := range datapoints {
+			fields[field] = types.Type(info.Type)
+		}
+
+		var missingData []string
+		res.Data, missingData, err = db.GetDataPartial(ctx, assetMrn, fields)
+		if err == nil && !partial && len(missingData) != 0 {
+			err = errors.New("failed to get data for asset '" + assetMrn + "' and checksum '" + missingData[0] + "'")
+		}
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("entity", assetMrn).
+				Msg("resolver.db> could not fetch data for asset")
+			return nil, nil, err
+		}
+		completeness.DataTotal = len(fields)
+		completeness.MissingData = missingData
+	}
+
+	return &res, completeness, nil
+}
+
+// GetScore retrieves one score for an asset
+func (db *Db) GetScore(ctx context.Context, assetMrn, scoreID string) (policy.Score, error) {
+	return getScore(ctx, db.db, assetMrn, scoreID)
+}
+
+func getScore(ctx context.Context, q queryer, assetMrn, scoreID string) (policy.Score, error) {
+	res := policy.Score{}
+	ok, err := getProto(ctx, q, &res, "SELECT data FROM scores WHERE asset_mrn = ? AND qr_id = ?", assetMrn, scoreID)
+	if err != nil {
+		return policy.Score{}, err
+	}
+	if !ok {
+		return policy.Score{}, policy.NewScoreNotFoundError(assetMrn, scoreID)
+	}
+	return res, nil
+}
+
+// GetScores retrieves a map of score for an asset
+func (db *Db) GetScores(ctx context.Context, assetMrn string, qrIDs []string) (map[string]*policy.Score, error) {
+	res, missing, err := db.GetScoresPartial(ctx, assetMrn, qrIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) != 0 {
+		return nil, policy.NewScoreNotFoundError(assetMrn, missing[0])
+	}
+	return res, nil
+}
+
+// GetScoresPartial retrieves the scores of an asset that exist and the
+// sorted IDs of those that are missing
+func (db *Db) GetScoresPartial(ctx context.Context, assetMrn string, qrIDs []string) (map[string]*policy.Score, []string, error) {
+	res := make(map[string]*policy.Score, len(qrIDs))
+	var missing []string
+
+	for i := range qrIDs {
+		qrID := qrIDs[i]
+
+		score := &policy.Score{}
+		ok, err := getProto(ctx, db.db, score, "SELECT data FROM scores WHERE asset_mrn = ? AND qr_id = ?", assetMrn, qrID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			missing = append(missing, qrID)
+			continue
+		}
+		res[qrID] = score
+	}
+
+	sort.Strings(missing)
+	return res, missing, nil
+}
+
+// GetData retrieves a map of requested data fields for an asset
+func (db *Db) GetData(ctx context.Context, assetMrn string, fields map[string]types.Type) (map[string]*llx.Result, error) {
+	res, missing, err := db.GetDataPartial(ctx, assetMrn, fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) != 0 {
+		return nil, errors.New("failed to get data for asset '" + assetMrn + "' and checksum '" + missing[0] + "'")
+	}
+	return res, nil
+}
+
+// GetDataPartial retrieves the requested data fields of an asset that exist
+// and the sorted checksums of those that are missing
+func (db *Db) GetDataPartial(ctx context.Context, assetMrn string, fields map[string]types.Type) (map[string]*llx.Result, []string, error) {
+	res := make(map[string]*llx.Result, len(fields))
+	var missing []string
+
+	for checksum := range fields {
+		var data []byte
+		err := db.db.QueryRowContext(ctx, "SELECT COALESCE(data.data, blobs.data) FROM data LEFT JOIN blobs ON data.blob_hash = blobs.hash WHERE data.asset_mrn = ? AND data.checksum = ?", assetMrn, checksum).Scan(&data)
+		if err == sql.ErrNoRows {
+			missing = append(missing, checksum)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if data == nil {
+			res[checksum] = nil
+			continue
+		}
+
+		result := &llx.Result{}
+		if err = proto.Unmarshal(data, result); err != nil {
+			return nil, nil, err
+		}
+		res[checksum] = result
+	}
+
+	sort.Strings(missing)
+	return res, missing, nil
+}
+
+// GetResolvedPolicy returns the resolved policy for a given asset
+func (db *Db) GetResolvedPolicy(ctx context.Context, assetMrn string) (*policy.ResolvedPolicy, error) {
+	resolvedPolicy, _, err := getAsset(ctx, db.db, assetMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolvedPolicy == nil {
+		return nil, policy.NewInvalidStateError(assetMrn, "cannot find resolved policy for asset '"+assetMrn+"'")
+	}
+
+	return resolvedPolicy, nil
+}
+
+// CachedResolvedPolicy returns the resolved policy if it exists
+func (db *Db) CachedResolvedPolicy(ctx context.Context, policyMrn string, assetFilterChecksum string, version policy.ResolvedPolicyVersion) (*policy.ResolvedPolicy, error) {
+	policyObj, err := db.GetValidatedPolicy(ctx, policyMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	id := policyObj.GraphExecutionChecksum + "\x00" + assetFilterChecksum
+	var data []byte
+	var created int64
+	var expires sql.NullInt64
+	err = db.db.QueryRowContext(ctx, "SELECT data, created, expires FROM resolved_policies WHERE id = ?", id).Scan(&data, &created, &expires)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !expires.Valid {
+		expires.Int64 = created + int64(ResolvedPolicyCacheTTL.Seconds())
+	}
+
+	// If the entry is expired delete it and return nothing.
+	if db.nowProvider().Unix() > expires.Int64 {
+		if _, err = db.db.ExecContext(ctx, "DELETE FROM resolved_policies WHERE id = ?", id); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	res := &policy.ResolvedPolicy{}
+	if err = proto.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ResolveQuery looks up a given query and caches it for later access (optional)
+func (db *Db) ResolveQuery(ctx context.Context, mrn string) (*explorer.Mquery, error) {
+	res, err := db.GetQuery(ctx, mrn)
+	if err != nil {
+		return nil, errors.New("failed to get query '" + mrn + "'")
+	}
+	return res, nil
+}
+
+// SetResolvedPolicy to the data store; cached indicates if it was cached from
+// upstream, thus preventing any attempts of resolving it in the client
+func (db *Db) SetResolvedPolicy(ctx context.Context, mrn string, resolvedPolicy *policy.ResolvedPolicy, version policy.ResolvedPolicyVersion, cached bool) error {
+	data, err := proto.Marshal(resolvedPolicy)
+	if err != nil {
+		return err
+	}
+
+	now := db.nowProvider()
+	var expires sql.NullInt64
+	if ttl := db.resolvedPolicyTTL.Next(); ttl > 0 {
+		expires.Int64, expires.Valid = now.Add(ttl).Unix(), true
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO resolved_policies (id, data, created, expires) VALUES (?, ?, ?, ?)",
+		resolvedPolicy.GraphExecutionChecksum+"\x00"+resolvedPolicy.FiltersChecksum, data, now.Unix(), expires)
+	if err != nil {
+		return errors.New("failed to save resolved policy '" + mrn + "': " + err.Error())
+	}
+
+	if cached {
+		policyObj, _, err := db.getPolicy(ctx, mrn)
+		if err != nil {
+			return err
+		}
+		if policyObj == nil {
+			return errors.New("failed to save resolved policy as cached entry in this client, cannot find its parent policy locally: '" + mrn + "'")
+		}
+
+		policyObj.GraphExecutionChecksum = resolvedPolicy.GraphExecutionChecksum
+		if err = db.savePolicy(ctx, policyObj); err != nil {
+			return errors.New("failed to save resolved policy as cached entry in this client, failed to update parent policy locally: '" + mrn + "'")
+		}
+	}
+
+	return nil
+}
+
+// SetResolutionConflicts stores the policy conflicts that were detected while resolving a policy
+func (db *Db) SetResolutionConflicts(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy, conflicts []*policy.PolicyConflict) error {
+	id := resolvedPolicy.GraphExecutionChecksum + "\x00" + resolvedPolicy.FiltersChecksum
+	if len(conflicts) == 0 {
+		_, err := db.db.ExecContext(ctx, "DELETE FROM resolution_conflicts WHERE id = ?", id)
+		return err
+	}
+
+	data, err := json.Marshal(conflicts)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO resolution_conflicts (id, data) VALUES (?, ?)", id, data)
+	if err != nil {
+		return errors.New("failed to save policy conflicts for resolved policy '" + resolvedPolicy.GraphExecutionChecksum + "'")
+	}
+	return nil
+}
+
+// GetResolutionConflicts returns the policy conflicts that were detected while resolving a policy
+func (db *Db) GetResolutionConflicts(ctx context.Context, resolvedPolicy *policy.ResolvedPolicy) ([]*policy.PolicyConflict, error) {
+	var data []byte
+	err := db.db.QueryRowContext(ctx, "SELECT data FROM resolution_conflicts WHERE id = ?",
+		resolvedPolicy.GraphExecutionChecksum+"\x00"+resolvedPolicy.FiltersChecksum).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*policy.PolicyConflict
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetAssetResolvedPolicy sets and initialized all fields for an asset's resolved policy
+func (db *Db) SetAssetResolvedPolicy(ctx context.Context, assetMrn string, resolvedPolicy *policy.ResolvedPolicy, version policy.ResolvedPolicyVersion) error {
+	existing, existingVersion, err := getAsset(ctx, db.db, assetMrn)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.GraphExecutionChecksum == resolvedPolicy.GraphExecutionChecksum && existingVersion == string(version) {
+		log.Debug().
+			Str("asset", assetMrn).
+			Msg("resolverj.db> asset resolved policy is already cached (and unchanged)")
+		return nil
+	}
+
+	data, err := proto.Marshal(resolvedPolicy)
+	if err != nil {
+		return err
+	}
+
+	return db.withTx(ctx, func(tx *sql.Tx) error {
+		collectorJob := resolvedPolicy.CollectorJob
+		for checksum := range collectorJob.Datapoints {
+			_, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO data (asset_mrn, checksum, data) VALUES (?, ?, NULL)", assetMrn, checksum)
+			if err != nil {
+				log.Error().
+					Err(err).
+					Str("asset", assetMrn).
+					Str("query checksum", checksum).
+					Msg("resolver.db> failed to set asset resolved policy, failed to initialize data value")
+				return errors.New("failed to create asset scoring job (failed to init data)")
+			}
+		}
+
+		emptyScore, err := proto.Marshal(&policy.Score{})
+		if err != nil {
+			return err
+		}
+
+		for _, job := range collectorJob.ReportingJobs {
+			qrid := job.QrId
+			if qrid == "root" {
+				qrid = assetMrn
+			}
+
+			_, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO scores (asset_mrn, qr_id, data) VALUES (?, ?, ?)", assetMrn, qrid, emptyScore)
+			if err != nil {
+				log.Error().
+					Err(err).
+					Str("asset", assetMrn).
+					Str("score qrID", qrid).
+					Msg("resolver.db> failed to set asset resolved policy, failed to initialize score")
+				return errors.New("failed to create asset scoring job (failed to init score)")
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE assets SET resolved_policy = ?, resolved_policy_version = ? WHERE mrn = ?",
+			data, string(version), assetMrn)
+		if err != nil {
+			return errors.New("failed to save resolved policy for asset '" + assetMrn + "'")
+		}
+		return nil
+	})
+}
+
+// GetCollectorJob returns the collector job for a given asset
+func (db *Db) GetCollectorJob(ctx context.Context, assetMrn string) (*policy.CollectorJob, error) {
+	resolvedPolicy, _, err := getAsset(ctx, db.db, assetMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolvedPolicy == nil {
+		return nil, policy.NewInvalidStateError(assetMrn, "cannot find resolved policy for asset '"+assetMrn+"'")
+	}
+	if resolvedPolicy.CollectorJob == nil {
+		return nil, policy.NewInvalidStateError(assetMrn, "cannot find collectorJob for asset '"+assetMrn+"'")
+	}
+
+	return resolvedPolicy.CollectorJob, nil
+}
+
+// UpdateData sets the list of data value for a given asset and returns a list of updated IDs
+func (db *Db) UpdateData(ctx context.Context, assetMrn string, data map[string]*llx.Result) (map[string]types.Type, error) {
+	collectorJob, err := db.GetCollectorJob(ctx, assetMrn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find collectorJob to store data: %w", err)
+	}
+
+	res := make(map[string]types.Type, len(data))
+	var errList error
+	now := db.nowProvider().Unix()
+	err = db.withTx(ctx, func(tx *sql.Tx) error {
+		for dpChecksum, val := range data {
+			info, ok := collectorJob.Datapoints[dpChecksum]
+			if !ok {
+				return errors.New("cannot find this datapoint to store values: " + dpChecksum)
+			}
+
+			coerced, warning, err := policy.CoerceResult(val, types.Type(info.Type), db.coercion)
+			if err != nil {
+				log.Warn().
+					Str("checksum", dpChecksum).
+					Str("asset", assetMrn).
+					Interface("data", val.Data).
+					Str("expected", types.Type(info.Type).Label()).
+					Str("received", types.Type(val.Data.Type).Label()).
+					Msg("resolver.db> failed to store data, types don't match")
+
+				errList = multierror.Append(errList, fmt.Errorf("failed to store data for %q, %w", dpChecksum, err))
+				continue
+			}
+			if warning != "" {
+				log.Debug().
+					Str("checksum", dpChecksum).
+					Str("asset", assetMrn).
+					Msg("resolver.db> " + warning)
+			}
+
+			err = setDatum(ctx, tx, assetMrn, dpChecksum, coerced, warning, now)
+			if err != nil {
+				errList = multierror.Append(errList, err)
+				continue
+			}
+
+			// TODO: we don't know which data was updated and which wasn't yet, so
+			// we currently always notify...
+			res[dpChecksum] = types.Type(info.Type)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if errList != nil {
+		return nil, errList
+	}
+
+	return res, nil
+}
+
+// setDatum stores the value of a datapoint with an optional warning, e.g.
+// about a coercion of its type, and when it was collected (unix seconds)
+func setDatum(ctx context.Context, q queryer, assetMrn string, checksum string, value *llx.Result, warning string, collected int64) error {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	// the previous value may have been the last reference to a blob
+	var oldHash sql.NullString
+	err = q.QueryRowContext(ctx, "SELECT blob_hash FROM data WHERE asset_mrn = ? AND checksum = ?", assetMrn, checksum).Scan(&oldHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	var hash sql.NullString
+	if len(data) >= BlobThreshold {
+		hash.String, hash.Valid = blobHash(data), true
+		if hash == oldHash {
+			// unchanged, keep the existing reference
+			_, err = q.ExecContext(ctx, "UPDATE data SET warning = ?, collected = ? WHERE asset_mrn = ? AND checksum = ?", nullString(warning), collected, assetMrn, checksum)
+			return err
+		}
+		if err = retainBlob(ctx, q, hash.String, data); err != nil {
+			return err
+		}
+		data = nil
+	}
+
+	_, err = q.ExecContext(ctx, "INSERT OR REPLACE INTO data (asset_mrn, checksum, data, blob_hash, warning, collected) VALUES (?, ?, ?, ?, ?, ?)", assetMrn, checksum, data, hash, nullString(warning), collected)
+	if err != nil {
+		return errors.New("failed to save asset data for asset '" + assetMrn + "' and checksum '" + checksum + "'")
+	}
+
+	if oldHash.Valid {
+		return releaseBlob(ctx, q, oldHash.String)
+	}
+	return nil
+}
+
+// UpdateScores sets the given scores and returns true if any were updated
+func (db *Db) UpdateScores(ctx context.Context, assetMrn string, scores []*policy.Score) (map[string]struct{}, error) {
+	updated := map[string]struct{}{}
+	now := db.nowProvider().Unix()
+
+	err := db.withTx(ctx, func(tx *sql.Tx) error {
+		for i := range scores {
+			score := scores[i]
+			ok, err := updateScore(ctx, tx, assetMrn, score, now)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				updated[score.QrId] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// set one score and return true if it was updated
+func updateScore(ctx context.Context, q queryer, assetMrn string, score *policy.Score, now int64) (bool, error) {
+	org, err := getScore(ctx, q, assetMrn, score.QrId)
+	if err == nil &&
+		org.Value == score.Value &&
+		org.Type == score.Type &&
+		org.DataCompletion == score.DataCompletion &&
+		org.DataTotal == score.DataTotal &&
+		org.ScoreCompletion == score.ScoreCompletion &&
+		org.Weight == score.Weight {
+		return false, nil
+	}
+
+	// if this is the first time saving the score
+	if err != nil || (org.ScoreCompletion == 0 && score.Type == policy.ScoreType_Result) {
+		score.ValueModifiedTime = now
+		if score.Value == 100 || score.ScoreCompletion < 100 {
+			score.FailureTime = 0
+		} else {
+			score.FailureTime = now
+		}
+	} else if (org.Value != score.Value || org.ScoreCompletion == 0) && score.Type == policy.ScoreType_Result {
+		score.ValueModifiedTime = now
+		// we are failing from 100 => something else
+		if org.Value == 100 {
+			score.FailureTime = now
+		} else {
+			score.FailureTime = org.FailureTime
+		}
+	} else {
+		score.ValueModifiedTime = org.ValueModifiedTime
+		score.FailureTime = org.FailureTime
+	}
+
+	data, err := proto.Marshal(score)
+	if err != nil {
+		return false, err
+	}
+	_, err = q.ExecContext(ctx, "INSERT OR REPLACE INTO scores (asset_mrn, qr_id, data) VALUES (?, ?, ?)", assetMrn, score.QrId, data)
+	if err != nil {
+		return false, errors.New("failed to set score for asset '" + assetMrn + "' with ID '" + score.QrId + "'")
+	}
+
+	log.Debug().
+		Str("asset", assetMrn).
+		Str("query", score.QrId).
+		Str("type", score.TypeLabel()).
+		Int("value", int(score.Value)).
+		Int("score-completion", int(score.ScoreCompletion)).
+		Int("data-completion", int(score.DataCompletion)).
+		Int("data-total", int(score.DataTotal)).
+		Str("error_msg", score.Message).
+		Msg("resolver.db> update score")
+	return true, nil
+}
+
+// SetProps will override properties for a given entity (asset, space, org)
+func (db *Db) SetProps(ctx context.Context, req *explorer.PropsReq) error {
+	policyObj, err := db.ensurePolicy(ctx, req.EntityMrn, false)
+	if err != nil {
+		return err
+	}
+
+	propsIdx := make(map[string]*explorer.Property, len(policyObj.Props))
+	for i := range policyObj.Props {
+		cur := policyObj.Props[i]
+		if cur.Mrn != "" {
+			propsIdx[cur.Mrn] = cur
+		}
+		if cur.Uid != "" {
+			propsIdx[cur.Uid] = cur
+		}
+	}
+
+	for i := range req.Props {
+		cur := req.Props[i]
+		id := cur.Mrn
+		if id == "" {
+			id = cur.Uid
+		}
+		if id == "" {
+			return errors.New("cannot set property without MRN: " + cur.Mql)
+		}
+
+		if x, ok := propsIdx[id]; ok {
+			x.Mql = cur.Mql
+			continue
+		}
+
+		policyObj.Props = append(policyObj.Props, cur)
+		propsIdx[id] = cur
+	}
+
+	// unlike in memory, the altered policy has to be stored again
+	return db.updatePolicy(ctx, policyObj)
+}
+
+// compile-time check that the sqlite Db is a full DataLake
+var _ policy.DataLake = (*Db)(nil)
+
+var _ policy.PartialResultsReader = (*Db)(nil)
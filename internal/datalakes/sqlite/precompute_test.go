@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// cacheLookups returns the number of resolved policy cache lookups with the
+// given result
+func cacheLookups(t *testing.T, reg *prometheus.Registry, result string) float64 {
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "cnspec_resolver_cache_lookups_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "result" && label.GetValue() == result {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestPrecomputeAssetResolve(t *testing.T) {
+	ctx := context.Background()
+	_, path := openTestDb(t)
+
+	bundle, err := policy.BundleFromPaths("../../../examples/example.mql.yaml")
+	require.NoError(t, err)
+	policyMrns := bundle.PolicyMRNs()
+
+	// prepare assigns the bundle to the asset like an asset scan does
+	prepare := func(services *policy.LocalServices, assetMrn string) []*explorer.Mquery {
+		_, err := services.SetBundle(ctx, proto.Clone(bundle).(*policy.Bundle))
+		require.NoError(t, err)
+		_, err = services.Assign(ctx, &policy.PolicyAssignment{AssetMrn: assetMrn, PolicyMrns: policyMrns})
+		require.NoError(t, err)
+		filters, err := services.GetPolicyFilters(ctx, &policy.Mrn{Mrn: assetMrn})
+		require.NoError(t, err)
+		require.NotEmpty(t, filters.Items)
+		return filters.Items
+	}
+
+	// the inventory is precomputed for the asset that was scanned before
+	precomputed := "//policy.api.mondoo.app/assets/web-01"
+	require.NoError(t, WithDb(path, func(db *Db, services *policy.LocalServices) error {
+		filters := prepare(services, precomputed)
+		return services.PrecomputeAssetResolve(ctx, []policy.PrecomputeAsset{{Mrn: precomputed, Filters: filters}}, 1)
+	}))
+
+	// another asset with the same policies and filters is scanned later
+	// with its own connection and finds the precomputed resolved policy
+	reg := prometheus.NewRegistry()
+	metrics, err := policy.NewResolverMetrics(reg)
+	require.NoError(t, err)
+	require.NoError(t, WithDb(path, func(db *Db, services *policy.LocalServices) error {
+		services.Metrics = metrics
+		later := "//policy.api.mondoo.app/assets/web-02"
+		filters := prepare(services, later)
+		rp, err := services.ResolveAndUpdateJobs(ctx, &policy.UpdateAssetJobsReq{AssetMrn: later, AssetFilters: filters})
+		require.NoError(t, err)
+		assert.NotNil(t, rp.ExecutionJob)
+		return nil
+	}))
+	assert.Equal(t, 1.0, cacheLookups(t, reg, "hit"))
+	assert.Equal(t, 0.0, cacheLookups(t, reg, "miss"))
+
+	t.Run("assets with another criticality aren't precomputed", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+		metrics, err := policy.NewResolverMetrics(reg)
+		require.NoError(t, err)
+		require.NoError(t, WithDb(path, func(db *Db, services *policy.LocalServices) error {
+			services.Metrics = metrics
+			critical := "//policy.api.mondoo.app/assets/db-01"
+			filters := prepare(services, critical)
+			_, err := services.ResolveAndUpdateJobs(policy.WithAssetCriticality(ctx, policy.CriticalityCritical),
+				&policy.UpdateAssetJobsReq{AssetMrn: critical, AssetFilters: filters})
+			return err
+		}))
+		assert.Equal(t, 0.0, cacheLookups(t, reg, "hit"))
+		assert.Equal(t, 1.0, cacheLookups(t, reg, "miss"))
+	})
+}
@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// SetQuota sets the quota of a space, nil removes it
+func (db *Db) SetQuota(ctx context.Context, spaceMrn string, quota *policy.Quota) error {
+	if quota == nil {
+		_, err := db.db.ExecContext(ctx, "DELETE FROM space_quotas WHERE space_mrn = ?", spaceMrn)
+		return err
+	}
+
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT OR REPLACE INTO space_quotas (space_mrn, data) VALUES (?, ?)", spaceMrn, data)
+	if err != nil {
+		return errors.New("failed to save quota for space '" + spaceMrn + "'")
+	}
+	return nil
+}
+
+// GetQuota returns the quota of a space, nil if it has none
+func (db *Db) GetQuota(ctx context.Context, spaceMrn string) (*policy.Quota, error) {
+	var data []byte
+	err := db.db.QueryRowContext(ctx, "SELECT data FROM space_quotas WHERE space_mrn = ?", spaceMrn).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res policy.Quota
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AddUsage adds to the usage of a space on the given day and returns the
+// total usage of that day
+func (db *Db) AddUsage(ctx context.Context, spaceMrn string, day time.Time, usage policy.SpaceUsage) (*policy.SpaceUsage, error) {
+	var res policy.SpaceUsage
+	err := db.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO space_usage (space_mrn, day, scans, datapoints, upload_bytes) VALUES (?, ?, ?, ?, ?) "+
+			"ON CONFLICT (space_mrn, day) DO UPDATE SET scans = scans + excluded.scans, "+
+			"datapoints = datapoints + excluded.datapoints, upload_bytes = upload_bytes + excluded.upload_bytes",
+			spaceMrn, day.Unix(), usage.Scans, usage.Datapoints, usage.UploadBytes)
+		if err != nil {
+			return errors.New("failed to save usage of space '" + spaceMrn + "': " + err.Error())
+		}
+
+		return tx.QueryRowContext(ctx, "SELECT scans, datapoints, upload_bytes FROM space_usage WHERE space_mrn = ? AND day = ?",
+			spaceMrn, day.Unix()).Scan(&res.Scans, &res.Datapoints, &res.UploadBytes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetUsage returns the usage of a space on the given day
+func (db *Db) GetUsage(ctx context.Context, spaceMrn string, day time.Time) (*policy.SpaceUsage, error) {
+	res := &policy.SpaceUsage{}
+	err := db.db.QueryRowContext(ctx, "SELECT scans, datapoints, upload_bytes FROM space_usage WHERE space_mrn = ? AND day = ?",
+		spaceMrn, day.Unix()).Scan(&res.Scans, &res.Datapoints, &res.UploadBytes)
+	if err == sql.ErrNoRows {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+var _ policy.UsageStore = (*Db)(nil)
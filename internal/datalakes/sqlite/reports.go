@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// reportStreamPageSize is the number of assets loaded per query while
+// streaming reports
+const reportStreamPageSize = 100
+
+var _ policy.ReportStreamer = (*Db)(nil)
+
+// StreamReports calls the function with the report of every scored asset,
+// ordered by MRN. Assets are loaded page by page, so the database is not
+// locked while the function runs.
+func (db *Db) StreamReports(ctx context.Context, opts policy.ReportStreamOptions, f func(report *policy.Report) error) error {
+	after := opts.After
+	for {
+		mrns, err := listStrings(ctx, db.db,
+			"SELECT mrn FROM assets WHERE mrn > ? AND resolved_policy IS NOT NULL ORDER BY mrn LIMIT ?",
+			after, reportStreamPageSize)
+		if err != nil {
+			return errors.New("failed to list assets for reports: " + err.Error())
+		}
+
+		for _, mrn := range mrns {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			report, _, err := db.getReport(ctx, mrn, mrn, opts.IncludeData, false)
+			if err != nil {
+				return err
+			}
+			// assets that were never scored have no report
+			if report.Score == nil {
+				continue
+			}
+			if err := f(report); err != nil {
+				return err
+			}
+		}
+
+		if len(mrns) < reportStreamPageSize {
+			return nil
+		}
+		after = mrns[len(mrns)-1]
+	}
+}
+
+// StoreReport stores the report under its content ID. It returns false if
+// the ID was already stored.
+func (db *Db) StoreReport(ctx context.Context, id string, report *policy.Report) (bool, error) {
+	if id == "" {
+		return false, errors.New("cannot store report without ID")
+	}
+
+	data, err := proto.Marshal(report)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := db.db.ExecContext(ctx, "INSERT OR IGNORE INTO reports (id, asset_mrn, data, created) VALUES (?, ?, ?, ?)",
+		id, report.EntityMrn, data, db.nowProvider().Unix())
+	if err != nil {
+		return false, errors.New("failed to store report '" + id + "': " + err.Error())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n != 0, nil
+}
+
+// GetReportByID returns a report that was stored with StoreReport
+func (db *Db) GetReportByID(ctx context.Context, id string) (*policy.Report, error) {
+	var data []byte
+	err := db.db.QueryRowContext(ctx, "SELECT data FROM reports WHERE id = ?", id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("report '" + id + "' not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &policy.Report{}
+	if err := proto.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
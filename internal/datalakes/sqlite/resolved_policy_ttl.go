@@ -0,0 +1,10 @@
+package sqlite
+
+import "go.mondoo.com/cnspec/policy"
+
+// SetResolvedPolicyTTL configures after how long cached resolved policies
+// expire and are resolved again. Without it they expire after
+// ResolvedPolicyCacheTTL.
+func (db *Db) SetResolvedPolicyTTL(ttl policy.ResolvedPolicyTTL) {
+	db.resolvedPolicyTTL = ttl
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// states of jobs in the scan queue
+const (
+	scanJobPending = "pending"
+	scanJobRunning = "running"
+)
+
+// EnqueueScanJob adds a scan job to the persistent queue. Jobs are identified
+// by their checksum: if an identical job is still pending, the new one is
+// dropped and false is returned.
+func (db *Db) EnqueueScanJob(ctx context.Context, checksum string, data []byte) (bool, error) {
+	res, err := db.db.ExecContext(ctx, "INSERT OR IGNORE INTO scan_jobs (checksum, data, state, created) VALUES (?, ?, ?, ?)",
+		checksum, data, scanJobPending, db.nowProvider().Unix())
+	if err != nil {
+		return false, errors.New("failed to enqueue scan job: " + err.Error())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n != 0, nil
+}
+
+// NextScanJob marks the oldest pending scan job as running and returns it.
+// It returns false if no job is pending.
+func (db *Db) NextScanJob(ctx context.Context) (int64, []byte, bool, error) {
+	var id int64
+	var data []byte
+	err := db.withTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, "SELECT id, data FROM scan_jobs WHERE state = ? ORDER BY id LIMIT 1", scanJobPending).Scan(&id, &data)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE scan_jobs SET state = ? WHERE id = ?", scanJobRunning, id)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return 0, nil, false, nil
+	}
+	if err != nil {
+		return 0, nil, false, errors.New("failed to get next scan job: " + err.Error())
+	}
+	return id, data, true, nil
+}
+
+// CompleteScanJob removes a scan job from the queue once it is done
+func (db *Db) CompleteScanJob(ctx context.Context, id int64) error {
+	_, err := db.db.ExecContext(ctx, "DELETE FROM scan_jobs WHERE id = ?", id)
+	return err
+}
+
+// ResumeScanJobs puts all jobs that were running back into the queue. This
+// is called on startup, when no job can be running anymore. Jobs that are
+// now identical to a pending job are removed. It returns the number of
+// resumed jobs.
+func (db *Db) ResumeScanJobs(ctx context.Context) (int, error) {
+	ids, err := listStrings(ctx, db.db, "SELECT id FROM scan_jobs WHERE state = ? ORDER BY id", scanJobRunning)
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+	err = db.withTx(ctx, func(tx *sql.Tx) error {
+		for i := range ids {
+			res, err := tx.ExecContext(ctx, "UPDATE OR IGNORE scan_jobs SET state = ? WHERE id = ?", scanJobPending, ids[i])
+			if err != nil {
+				return err
+			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				// a duplicate is already pending
+				if _, err = tx.ExecContext(ctx, "DELETE FROM scan_jobs WHERE id = ?", ids[i]); err != nil {
+					return err
+				}
+				continue
+			}
+			resumed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.New("failed to resume scan jobs: " + err.Error())
+	}
+	return resumed, nil
+}
@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("jobs run in order", func(t *testing.T) {
+		db, _ := openTestDb(t)
+		for _, checksum := range []string{"a", "b"} {
+			added, err := db.EnqueueScanJob(ctx, checksum, []byte(checksum))
+			require.NoError(t, err)
+			assert.True(t, added)
+		}
+
+		id, data, ok, err := db.NextScanJob(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte("a"), data)
+		require.NoError(t, db.CompleteScanJob(ctx, id))
+
+		id, data, ok, err = db.NextScanJob(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte("b"), data)
+		require.NoError(t, db.CompleteScanJob(ctx, id))
+
+		_, _, ok, err = db.NextScanJob(ctx)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("identical pending jobs are dropped", func(t *testing.T) {
+		db, _ := openTestDb(t)
+		added, err := db.EnqueueScanJob(ctx, "a", []byte("a"))
+		require.NoError(t, err)
+		assert.True(t, added)
+		added, err = db.EnqueueScanJob(ctx, "a", []byte("a"))
+		require.NoError(t, err)
+		assert.False(t, added)
+
+		// once the job runs, it may be scheduled again
+		_, _, ok, err := db.NextScanJob(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		added, err = db.EnqueueScanJob(ctx, "a", []byte("a"))
+		require.NoError(t, err)
+		assert.True(t, added)
+	})
+
+	t.Run("running jobs are resumed after a restart", func(t *testing.T) {
+		db, path := openTestDb(t)
+		for _, checksum := range []string{"a", "b"} {
+			_, err := db.EnqueueScanJob(ctx, checksum, []byte(checksum))
+			require.NoError(t, err)
+		}
+		_, _, ok, err := db.NextScanJob(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, _, ok, err = db.NextScanJob(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		// b is scheduled again while it runs
+		_, err = db.EnqueueScanJob(ctx, "b", []byte("b"))
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		db, err = Open(path)
+		require.NoError(t, err)
+		defer db.Close()
+		resumed, err := db.ResumeScanJobs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, resumed)
+
+		checksums := []string{}
+		for {
+			id, data, ok, err := db.NextScanJob(ctx)
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			checksums = append(checksums, string(data))
+			require.NoError(t, db.CompleteScanJob(ctx, id))
+		}
+		assert.ElementsMatch(t, []string{"a", "b"}, checksums)
+	})
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.mondoo.com/cnspec/policy"
+)
+
+// AppendScoreHistory adds all scores whose value or outcome changed to the
+// score history of the asset. Scores are recorded with the execution
+// checksum of the asset's resolved policy.
+func (db *Db) AppendScoreHistory(ctx context.Context, assetMrn string, scores []*policy.Score) error {
+	var executionChecksum string
+	if resolvedPolicy, _, err := getAsset(ctx, db.db, assetMrn); err == nil && resolvedPolicy != nil {
+		executionChecksum = resolvedPolicy.GraphExecutionChecksum
+	}
+	now := db.nowProvider()
+
+	return db.withTx(ctx, func(tx *sql.Tx) error {
+		for i := range scores {
+			if err := recordScoreHistory(ctx, tx, assetMrn, scores[i], now, executionChecksum); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// recordScoreHistory adds the score to the history if it changed since it
+// was last recorded. Incomplete results are not recorded.
+func recordScoreHistory(ctx context.Context, q queryer, assetMrn string, score *policy.Score, now time.Time, executionChecksum string) error {
+	entry, ok := policy.NewScoreHistoryEntry(score, executionChecksum, now)
+	if !ok {
+		return nil
+	}
+
+	var last policy.ScoreHistoryEntry
+	err := q.QueryRowContext(ctx, "SELECT outcome, value, execution_checksum FROM score_history WHERE asset_mrn = ? AND qr_id = ? ORDER BY id DESC LIMIT 1",
+		assetMrn, score.QrId).Scan(&last.Outcome, &last.Value, &last.ExecutionChecksum)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil && !entry.Changes(last) {
+		return nil
+	}
+
+	_, err = q.ExecContext(ctx, "INSERT INTO score_history (asset_mrn, qr_id, outcome, value, execution_checksum, recorded) VALUES (?, ?, ?, ?, ?, ?)",
+		assetMrn, entry.QrId, entry.Outcome, entry.Value, entry.ExecutionChecksum, now.Unix())
+	if err != nil {
+		return errors.New("failed to record score history for asset '" + assetMrn + "': " + err.Error())
+	}
+	return nil
+}
+
+func scanScoreHistory(rows *sql.Rows) ([]policy.ScoreHistoryEntry, error) {
+	defer rows.Close()
+
+	res := []policy.ScoreHistoryEntry{}
+	for rows.Next() {
+		var entry policy.ScoreHistoryEntry
+		var recorded int64
+		if err := rows.Scan(&entry.QrId, &entry.Outcome, &entry.Value, &entry.ExecutionChecksum, &recorded); err != nil {
+			return nil, err
+		}
+		entry.Recorded = time.Unix(recorded, 0)
+		res = append(res, entry)
+	}
+	return res, rows.Err()
+}
+
+// GetScoreHistory returns the changes of one score of an asset within the
+// window, ordered by time. It starts with the last change before the window,
+// so that the value at the start of the window is known. A window of 0
+// returns the entire history.
+func (db *Db) GetScoreHistory(ctx context.Context, assetMrn string, qrID string, window time.Duration) ([]policy.ScoreHistoryEntry, error) {
+	var since int64
+	if window > 0 {
+		since = db.nowProvider().Add(-window).Unix()
+	}
+
+	rows, err := db.db.QueryContext(ctx, `SELECT qr_id, outcome, value, execution_checksum, recorded FROM score_history
+		WHERE asset_mrn = ? AND qr_id = ? AND id >= COALESCE(
+			(SELECT MAX(id) FROM score_history WHERE asset_mrn = ? AND qr_id = ? AND recorded < ?), 0)
+		ORDER BY id`,
+		assetMrn, qrID, assetMrn, qrID, since)
+	if err != nil {
+		return nil, errors.New("failed to get score history for asset '" + assetMrn + "': " + err.Error())
+	}
+	return scanScoreHistory(rows)
+}
+
+// getAssetScoreHistory returns the changes of all scores of an asset since
+// the given time, ordered by time
+func (db *Db) getAssetScoreHistory(ctx context.Context, assetMrn string, since time.Time) ([]policy.ScoreHistoryEntry, error) {
+	rows, err := db.db.QueryContext(ctx, "SELECT qr_id, outcome, value, execution_checksum, recorded FROM score_history WHERE asset_mrn = ? AND recorded >= ? ORDER BY id",
+		assetMrn, since.Unix())
+	if err != nil {
+		return nil, errors.New("failed to get score history for asset '" + assetMrn + "': " + err.Error())
+	}
+	return scanScoreHistory(rows)
+}
+
+// FlakyChecks analyzes the score history of all assets and returns the
+// checks that flip frequently, see policy.DetectFlakyChecks
+func (db *Db) FlakyChecks(ctx context.Context, opts policy.FlakinessOptions) ([]policy.FlakyCheck, error) {
+	assets, err := listStrings(ctx, db.db, "SELECT DISTINCT asset_mrn FROM score_history WHERE recorded >= ? ORDER BY asset_mrn", opts.Since.Unix())
+	if err != nil {
+		return nil, errors.New("failed to list assets with score history: " + err.Error())
+	}
+
+	res := []policy.FlakyCheck{}
+	for _, assetMrn := range assets {
+		history, err := db.getAssetScoreHistory(ctx, assetMrn, opts.Since)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, policy.DetectFlakyChecks(assetMrn, history, opts)...)
+	}
+	return res, nil
+}
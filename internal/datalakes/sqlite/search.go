@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ policy.DataLakeSearcher = (*Db)(nil)
+
+// sqlLimit converts a pagination limit to SQL, where -1 returns all rows
+func sqlLimit(page policy.Pagination) (int, int) {
+	limit := page.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	offset := page.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// ListAssets returns the assets that match the filter, sorted by MRN
+func (db *Db) ListAssets(ctx context.Context, filter *policy.AssetFilter) (*policy.AssetList, error) {
+	if filter == nil {
+		filter = &policy.AssetFilter{}
+	}
+
+	where := "substr(mrn, 1, ?) = ?"
+	args := []interface{}{len(filter.MrnPrefix), filter.MrnPrefix}
+	if filter.ScoredOnly {
+		where += " AND resolved_policy IS NOT NULL"
+	}
+
+	res := &policy.AssetList{Assets: []*policy.AssetInfo{}}
+	if err := db.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM assets WHERE "+where, args...).Scan(&res.Total); err != nil {
+		return nil, errors.New("failed to count assets: " + err.Error())
+	}
+
+	limit, offset := sqlLimit(filter.Pagination)
+	rows, err := db.db.QueryContext(ctx, "SELECT mrn, resolved_policy_version FROM assets WHERE "+where+" ORDER BY mrn LIMIT ? OFFSET ?",
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, errors.New("failed to list assets: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		info := &policy.AssetInfo{}
+		if err := rows.Scan(&info.Mrn, &info.ResolvedPolicyVersion); err != nil {
+			return nil, err
+		}
+		res.Assets = append(res.Assets, info)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, info := range res.Assets {
+		if score, err := getScore(ctx, db.db, info.Mrn, info.Mrn); err == nil {
+			info.Score = &score
+		}
+	}
+	return res, nil
+}
+
+// ListReports returns the reports of an asset that were stored with
+// StoreReport, newest first
+func (db *Db) ListReports(ctx context.Context, assetMrn string, page policy.Pagination) (*policy.ReportList, error) {
+	res := &policy.ReportList{Reports: []*policy.StoredReport{}}
+	if err := db.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM reports WHERE asset_mrn = ?", assetMrn).Scan(&res.Total); err != nil {
+		return nil, errors.New("failed to count reports: " + err.Error())
+	}
+
+	limit, offset := sqlLimit(page)
+	rows, err := db.db.QueryContext(ctx, "SELECT id, data FROM reports WHERE asset_mrn = ? ORDER BY created DESC, id LIMIT ? OFFSET ?",
+		assetMrn, limit, offset)
+	if err != nil {
+		return nil, errors.New("failed to list reports: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+		report := &policy.Report{}
+		if err := proto.Unmarshal(data, report); err != nil {
+			return nil, err
+		}
+		res.Reports = append(res.Reports, &policy.StoredReport{ID: id, Report: report})
+	}
+	return res, rows.Err()
+}
+
+// SearchScores returns the scores that match the query
+func (db *Db) SearchScores(ctx context.Context, query *policy.ScoreQuery) (*policy.ScoreList, error) {
+	if query == nil {
+		query = &policy.ScoreQuery{}
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	sqlQuery := "SELECT asset_mrn, data FROM scores"
+	args := []interface{}{}
+	if query.AssetMrn != "" {
+		sqlQuery += " WHERE asset_mrn = ?"
+		args = append(args, query.AssetMrn)
+	}
+
+	rows, err := db.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, errors.New("failed to search scores: " + err.Error())
+	}
+	defer rows.Close()
+
+	candidates := []*policy.ScoreResult{}
+	for rows.Next() {
+		var assetMrn string
+		var data []byte
+		if err := rows.Scan(&assetMrn, &data); err != nil {
+			return nil, err
+		}
+		score := &policy.Score{}
+		if err := proto.Unmarshal(data, score); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &policy.ScoreResult{AssetMrn: assetMrn, Score: score})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return query.Apply(candidates)
+}
@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+
+	// registers the pure-go sqlite driver
+	_ "modernc.org/sqlite"
+)
+
+// ResolvedPolicyCacheTTL is the time after which cached resolved policies are
+// resolved again
+const ResolvedPolicyCacheTTL = 1 * time.Hour
+
+// Db is a SQLite-based DataLake. In contrast to the in-memory datalake it
+// persists all policies, resolved policies, scores and data across runs.
+type Db struct {
+	db          *sql.DB
+	services    *policy.LocalServices // bidirectional connection between db + services
+	uuid        string                // used for all object identifiers to prevent clashes (eg in-memory pubsub)
+	nowProvider func() time.Time
+	coercion    policy.CoercionOptions
+	// ttl of resolved policies, ResolvedPolicyCacheTTL if unset
+	resolvedPolicyTTL policy.ResolvedPolicyTTL
+}
+
+// Open opens the SQLite database at the given path and migrates it to the
+// latest schema. Use ":memory:" for a database that is not persisted.
+func Open(path string) (*Db, error) {
+	if path == "" {
+		return nil, errors.New("cannot open sqlite datalake, no path provided")
+	}
+
+	sqlDb, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, errors.New("failed to open sqlite datalake '" + path + "': " + err.Error())
+	}
+	// SQLite only supports one writer at a time; a single connection also
+	// keeps in-memory databases consistent
+	sqlDb.SetMaxOpenConns(1)
+
+	if err = migrate(context.Background(), sqlDb); err != nil {
+		sqlDb.Close()
+		return nil, err
+	}
+
+	return &Db{
+		db:          sqlDb,
+		uuid:        uuid.New().String(),
+		nowProvider: time.Now,
+		coercion:    policy.DefaultCoercion,
+	}, nil
+}
+
+// NewServices opens the database and creates a new set of policy services for it
+func NewServices(path string) (*Db, *policy.LocalServices, error) {
+	db, err := Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	services := policy.NewLocalServices(db, db.uuid)
+	db.services = services // close the connection between db and services
+
+	return db, services, nil
+}
+
+// WithDb creates a new set of policy services and closes everything out once the function is done
+func WithDb(path string, f func(*Db, *policy.LocalServices) error) error {
+	db, ls, err := NewServices(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return f(db, ls)
+}
+
+// Close the underlying database
+func (db *Db) Close() error {
+	return db.db.Close()
+}
+
+func (db *Db) SetNowProvider(f func() time.Time) {
+	db.nowProvider = f
+}
+
+// queryer is implemented by both the database and its transactions
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// withTx runs the function in a transaction, which is rolled back on errors
+func (db *Db) withTx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// getProto reads one proto message. It returns false if no row was found.
+func getProto(ctx context.Context, q queryer, msg proto.Message, query string, args ...interface{}) (bool, error) {
+	var data []byte
+	err := q.QueryRowContext(ctx, query, args...).Scan(&data)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, proto.Unmarshal(data, msg)
+}
+
+// listStrings reads the first column of all rows
+func listStrings(ctx context.Context, q queryer, query string, args ...interface{}) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []string{}
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
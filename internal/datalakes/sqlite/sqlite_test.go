@@ -0,0 +1,215 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+func openTestDb(t *testing.T) (*Db, string) {
+	path := filepath.Join(t.TempDir(), "datalake.db")
+	db, err := Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, path
+}
+
+func schemaVersion(t *testing.T, db *Db) int {
+	var version int
+	require.NoError(t, db.db.QueryRow("PRAGMA user_version").Scan(&version))
+	return version
+}
+
+func TestMigrations(t *testing.T) {
+	t.Run("new databases use the latest schema", func(t *testing.T) {
+		db, path := openTestDb(t)
+		assert.Equal(t, len(migrations), schemaVersion(t, db))
+		require.NoError(t, db.Close())
+
+		// reopening doesn't migrate again
+		db, err := Open(path)
+		require.NoError(t, err)
+		defer db.Close()
+		assert.Equal(t, len(migrations), schemaVersion(t, db))
+	})
+
+	t.Run("the initial schema has all tables", func(t *testing.T) {
+		db, _ := openTestDb(t)
+		rows, err := db.db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+		require.NoError(t, err)
+		defer rows.Close()
+		var tables []string
+		for rows.Next() {
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			tables = append(tables, name)
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, []string{
+			"assets", "blobs", "bundles", "data", "discovery_lineage", "exceptions", "impact_provenance",
+			"policies", "policy_children", "properties", "queries", "reports", "resolution_conflicts",
+			"resolved_policies", "scan_checkpoints", "scan_jobs", "score_history", "scores", "settings",
+			"space_quotas", "space_usage", "upload_queue",
+		}, tables)
+
+		// queued uploads belong to a space from the start
+		var index string
+		require.NoError(t, db.db.QueryRow("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'upload_queue'").Scan(&index))
+		assert.Equal(t, "upload_queue_space", index)
+	})
+
+	t.Run("concurrent opens migrate once", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "datalake.db")
+
+		var wg sync.WaitGroup
+		errs := make([]error, 8)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				db, err := Open(path)
+				if err == nil {
+					db.Close()
+				}
+				errs[i] = err
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range errs {
+			assert.NoError(t, errs[i])
+		}
+		db, err := Open(path)
+		require.NoError(t, err)
+		defer db.Close()
+		assert.Equal(t, len(migrations), schemaVersion(t, db))
+	})
+
+	t.Run("newer schemas are rejected", func(t *testing.T) {
+		db, path := openTestDb(t)
+		_, err := db.db.Exec("PRAGMA user_version = " + strconv.Itoa(len(migrations)+1))
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		_, err = Open(path)
+		assert.ErrorContains(t, err, "is newer than this version of cnspec supports")
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	assetMrn := "//policy.api.mondoo.app/assets/asset1"
+
+	t.Run("policies", func(t *testing.T) {
+		db, path := openTestDb(t)
+		p := &policy.Policy{
+			Mrn:                    "//local.cnspec.io/policies/ssh",
+			Name:                   "SSH policy",
+			Version:                "1.0.0",
+			LocalContentChecksum:   "content",
+			LocalExecutionChecksum: "execution",
+		}
+		require.NoError(t, db.SetPolicy(ctx, p, nil))
+		require.NoError(t, db.Close())
+
+		db, err := Open(path)
+		require.NoError(t, err)
+		defer db.Close()
+		res, err := db.GetRawPolicy(ctx, p.Mrn)
+		require.NoError(t, err)
+		assert.Equal(t, "SSH policy", res.Name)
+		assert.Equal(t, "1.0.0", res.Version)
+		assert.Equal(t, "execution", res.LocalExecutionChecksum)
+
+		_, err = db.GetRawPolicy(ctx, "//local.cnspec.io/policies/missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("scores", func(t *testing.T) {
+		db, _ := openTestDb(t)
+		require.NoError(t, db.EnsureAsset(ctx, assetMrn))
+
+		updated, err := db.UpdateScores(ctx, assetMrn, []*policy.Score{
+			{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result, ScoreCompletion: 100},
+			{QrId: "tls", Value: 0, Type: policy.ScoreType_Result, ScoreCompletion: 100},
+		})
+		require.NoError(t, err)
+		assert.Len(t, updated, 2)
+
+		score, err := db.GetScore(ctx, assetMrn, "ssh")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), score.Value)
+
+		// unchanged scores aren't updated
+		updated, err = db.UpdateScores(ctx, assetMrn, []*policy.Score{
+			{QrId: "ssh", Value: 100, Type: policy.ScoreType_Result, ScoreCompletion: 100},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, updated)
+
+		_, err = db.GetScore(ctx, assetMrn, "missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("data", func(t *testing.T) {
+		db, _ := openTestDb(t)
+		require.NoError(t, db.EnsureAsset(ctx, assetMrn))
+		require.NoError(t, db.SetAssetResolvedPolicy(ctx, assetMrn, &policy.ResolvedPolicy{
+			GraphExecutionChecksum: "checksum",
+			CollectorJob: &policy.CollectorJob{
+				Datapoints: map[string]*policy.DataQueryInfo{
+					"hostname": {Type: string(types.String)},
+				},
+			},
+		}, policy.V2Code))
+
+		updated, err := db.UpdateData(ctx, assetMrn, map[string]*llx.Result{
+			"hostname": (&llx.RawResult{Data: llx.StringData("web-01"), CodeID: "hostname"}).Result(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]types.Type{"hostname": types.String}, updated)
+
+		res, err := db.GetData(ctx, assetMrn, map[string]types.Type{"hostname": types.String})
+		require.NoError(t, err)
+		require.Contains(t, res, "hostname")
+		assert.Equal(t, "web-01", res["hostname"].RawResultV2().Data.Value)
+
+		// data that isn't part of the collector job is rejected
+		_, err = db.UpdateData(ctx, assetMrn, map[string]*llx.Result{
+			"unknown": (&llx.RawResult{Data: llx.StringData("x"), CodeID: "unknown"}).Result(),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("reports", func(t *testing.T) {
+		db, _ := openTestDb(t)
+		report := &policy.Report{
+			EntityMrn:  assetMrn,
+			ScoringMrn: assetMrn,
+			Score:      &policy.Score{QrId: assetMrn, Value: 80, Type: policy.ScoreType_Result},
+		}
+
+		stored, err := db.StoreReport(ctx, "report1", report)
+		require.NoError(t, err)
+		assert.True(t, stored)
+		stored, err = db.StoreReport(ctx, "report1", report)
+		require.NoError(t, err)
+		assert.False(t, stored)
+
+		res, err := db.GetReportByID(ctx, "report1")
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(report, res))
+
+		_, err = db.GetReportByID(ctx, "missing")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// EnqueueUpload adds results of the space to the end of the upload queue
+func (db *Db) EnqueueUpload(ctx context.Context, spaceMrn string, req *policy.StoreResultsReq) error {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, "INSERT INTO upload_queue (space_mrn, asset_mrn, data, queued) VALUES (?, ?, ?, ?)",
+		spaceMrn, req.AssetMrn, data, db.nowProvider().Unix())
+	if err != nil {
+		return errors.New("failed to queue upload for asset '" + req.AssetMrn + "': " + err.Error())
+	}
+	return nil
+}
+
+// PendingUploads returns up to limit uploads of the space whose ID is
+// greater than afterID, oldest first
+func (db *Db) PendingUploads(ctx context.Context, spaceMrn string, afterID int64, limit int) ([]*policy.QueuedUpload, error) {
+	query := "SELECT id, data, queued, attempts, last_error FROM upload_queue WHERE space_mrn = ? AND id > ? ORDER BY id"
+	if limit > 0 {
+		query += " LIMIT " + strconv.Itoa(limit)
+	}
+
+	rows, err := db.db.QueryContext(ctx, query, spaceMrn, afterID)
+	if err != nil {
+		return nil, errors.New("failed to get queued uploads: " + err.Error())
+	}
+	defer rows.Close()
+
+	res := []*policy.QueuedUpload{}
+	for rows.Next() {
+		var data []byte
+		var queued int64
+		upload := &policy.QueuedUpload{SpaceMrn: spaceMrn, Req: &policy.StoreResultsReq{}}
+		if err := rows.Scan(&upload.ID, &data, &queued, &upload.Attempts, &upload.LastError); err != nil {
+			return nil, err
+		}
+		if err := proto.Unmarshal(data, upload.Req); err != nil {
+			return nil, errors.New("failed to read queued upload " + strconv.FormatInt(upload.ID, 10) + ": " + err.Error())
+		}
+		upload.Queued = time.Unix(queued, 0)
+		res = append(res, upload)
+	}
+	return res, rows.Err()
+}
+
+// CompleteUpload removes an upload from the queue
+func (db *Db) CompleteUpload(ctx context.Context, id int64) error {
+	_, err := db.db.ExecContext(ctx, "DELETE FROM upload_queue WHERE id = ?", id)
+	return err
+}
+
+// FailUpload counts a rejected attempt of an upload
+func (db *Db) FailUpload(ctx context.Context, id int64, reason string) error {
+	res, err := db.db.ExecContext(ctx, "UPDATE upload_queue SET attempts = attempts + 1, last_error = ? WHERE id = ?", reason, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("queued upload " + strconv.FormatInt(id, 10) + " not found")
+	}
+	return nil
+}
+
+var _ policy.UploadQueue = (*Db)(nil)
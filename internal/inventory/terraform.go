@@ -0,0 +1,412 @@
+package inventory
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/motor/asset"
+	v1 "go.mondoo.com/cnquery/motor/inventory/v1"
+	"go.mondoo.com/cnquery/motor/providers"
+)
+
+// Labels that are added to all assets synthesized from Terraform
+const (
+	TerraformAddressLabel  = "terraform.io/address"
+	TerraformTypeLabel     = "terraform.io/resource-type"
+	TerraformProviderLabel = "terraform.io/provider"
+)
+
+// TerraformOptions configure how assets are synthesized from Terraform
+type TerraformOptions struct {
+	// Path of the state or plan file, which is added as an asset itself to
+	// scan the Terraform configuration. It is skipped if empty.
+	Path string
+	// Insecure disables TLS and SSH host key checks of all connections
+	Insecure bool
+	// SkipCloudAccounts doesn't add the cloud accounts, projects and
+	// subscriptions that resources are deployed into
+	SkipCloudAccounts bool
+}
+
+// tfResource is a managed resource of a state or plan, independent of the
+// file format
+type tfResource struct {
+	Address  string
+	Type     string
+	Provider string
+	Values   map[string]interface{}
+}
+
+// tfInstanceType describes how to connect to instances of a resource type
+type tfInstanceType struct {
+	Backend providers.ProviderType
+	// Hosts are the attributes of the host to connect to, by preference
+	Hosts []string
+	// Names are the attributes of the asset name, by preference
+	Names []string
+}
+
+// tfInstanceTypes are the resource types of machines that are scanned over
+// the network
+var tfInstanceTypes = map[string]tfInstanceType{
+	"aws_instance": {
+		Backend: providers.ProviderType_SSH,
+		Hosts:   []string{"public_ip", "public_dns", "private_ip"},
+		Names:   []string{"tags.Name", "id"},
+	},
+	"google_compute_instance": {
+		Backend: providers.ProviderType_SSH,
+		Hosts:   []string{"network_interface.0.access_config.0.nat_ip", "network_interface.0.network_ip"},
+		Names:   []string{"name"},
+	},
+	"azurerm_linux_virtual_machine": {
+		Backend: providers.ProviderType_SSH,
+		Hosts:   []string{"public_ip_address", "private_ip_address"},
+		Names:   []string{"name"},
+	},
+	"azurerm_windows_virtual_machine": {
+		Backend: providers.ProviderType_WINRM,
+		Hosts:   []string{"public_ip_address", "private_ip_address"},
+		Names:   []string{"name"},
+	},
+	"digitalocean_droplet": {
+		Backend: providers.ProviderType_SSH,
+		Hosts:   []string{"ipv4_address", "ipv4_address_private"},
+		Names:   []string{"name"},
+	},
+}
+
+// TerraformInventoryFromFile loads an inventory from a Terraform state file
+// or the JSON of a plan or state, see TerraformInventory
+func TerraformInventoryFromFile(path string, opts TerraformOptions) (*v1.Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read terraform file")
+	}
+	if opts.Path == "" {
+		opts.Path = path
+	}
+	return TerraformInventory(data, opts)
+}
+
+// TerraformInventory synthesizes an inventory from a Terraform state file
+// (`terraform.tfstate`) or the JSON of a plan or state (`terraform show
+// -json`). It contains all machines that Terraform manages and, unless
+// skipped, the cloud accounts they are deployed into. Credentials are not
+// part of the inventory, connections use the defaults of their providers,
+// e.g. the SSH agent or the AWS profile of the environment.
+func TerraformInventory(data []byte, opts TerraformOptions) (*v1.Inventory, error) {
+	resources, isPlan, err := parseTerraformResources(data)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &v1.Inventory{
+		Metadata: &v1.ObjectMeta{Name: "terraform"},
+	}
+
+	if opts.Path != "" {
+		assetType := "state"
+		if isPlan {
+			assetType = "plan"
+		}
+		inv.AddAssets(&asset.Asset{
+			Name: "Terraform " + assetType + " " + opts.Path,
+			Connections: []*providers.Config{{
+				Backend: providers.ProviderType_TERRAFORM,
+				Options: map[string]string{
+					"path":       opts.Path,
+					"asset-type": assetType,
+				},
+			}},
+		})
+	}
+
+	for i := range resources {
+		if a := terraformInstanceAsset(resources[i], opts); a != nil {
+			inv.AddAssets(a)
+		}
+	}
+
+	if !opts.SkipCloudAccounts {
+		inv.AddAssets(terraformCloudAssets(resources)...)
+	}
+
+	return inv, nil
+}
+
+// terraformInstanceAsset returns the asset of a machine, nil if the resource
+// isn't one or it has no host yet
+func terraformInstanceAsset(r tfResource, opts TerraformOptions) *asset.Asset {
+	instanceType, ok := tfInstanceTypes[r.Type]
+	if !ok {
+		return nil
+	}
+	host := firstAttribute(r.Values, instanceType.Hosts)
+	if host == "" {
+		return nil
+	}
+	name := firstAttribute(r.Values, instanceType.Names)
+	if name == "" {
+		name = r.Address
+	}
+
+	return &asset.Asset{
+		Name: name,
+		Connections: []*providers.Config{{
+			Backend:  instanceType.Backend,
+			Host:     host,
+			Insecure: opts.Insecure,
+		}},
+		Labels: map[string]string{
+			TerraformAddressLabel:  r.Address,
+			TerraformTypeLabel:     r.Type,
+			TerraformProviderLabel: r.Provider,
+		},
+	}
+}
+
+// terraformCloudAssets returns one asset per AWS account and region, GCP
+// project and Azure subscription that resources are deployed into
+func terraformCloudAssets(resources []tfResource) []*asset.Asset {
+	assets := map[string]*asset.Asset{}
+	for _, r := range resources {
+		var key string
+		var conn *providers.Config
+		var name string
+
+		switch {
+		case strings.HasPrefix(r.Type, "aws_"):
+			account, region := awsARNScope(attribute(r.Values, "arn"))
+			if account == "" || region == "" {
+				continue
+			}
+			key = "aws/" + account + "/" + region
+			name = "AWS account " + account + " (" + region + ")"
+			conn = &providers.Config{
+				Backend: providers.ProviderType_AWS,
+				Options: map[string]string{"region": region},
+			}
+		case strings.HasPrefix(r.Type, "google_"):
+			project := attribute(r.Values, "project")
+			if project == "" {
+				continue
+			}
+			key = "gcp/" + project
+			name = "GCP project " + project
+			conn = &providers.Config{
+				Backend: providers.ProviderType_GCP,
+				Options: map[string]string{"project-id": project},
+			}
+		case strings.HasPrefix(r.Type, "azurerm_"):
+			subscription := azureSubscription(attribute(r.Values, "id"))
+			if subscription == "" {
+				continue
+			}
+			key = "azure/" + subscription
+			name = "Azure subscription " + subscription
+			conn = &providers.Config{
+				Backend: providers.ProviderType_AZURE,
+				Options: map[string]string{"subscription-id": subscription},
+			}
+		default:
+			continue
+		}
+
+		if _, ok := assets[key]; ok {
+			continue
+		}
+		assets[key] = &asset.Asset{
+			Name:        name,
+			Connections: []*providers.Config{conn},
+			Labels: map[string]string{
+				TerraformProviderLabel: r.Provider,
+			},
+		}
+	}
+
+	keys := make([]string, 0, len(assets))
+	for key := range assets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res := make([]*asset.Asset, len(keys))
+	for i := range keys {
+		res[i] = assets[keys[i]]
+	}
+	return res
+}
+
+// awsARNScope returns the account and region of an ARN, e.g.
+// arn:aws:ec2:us-east-1:123456789012:instance/i-1234
+func awsARNScope(arn string) (string, string) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return "", ""
+	}
+	return parts[4], parts[3]
+}
+
+// azureSubscription returns the subscription of an Azure resource ID, e.g.
+// /subscriptions/0000/resourceGroups/rg/providers/...
+func azureSubscription(id string) string {
+	parts := strings.Split(strings.TrimPrefix(id, "/"), "/")
+	if len(parts) < 2 || !strings.EqualFold(parts[0], "subscriptions") {
+		return ""
+	}
+	return parts[1]
+}
+
+// attribute returns a string attribute by its path, e.g. tags.Name or
+// network_interface.0.network_ip. Missing attributes return "".
+func attribute(values map[string]interface{}, path string) string {
+	var cur interface{} = values
+	for _, key := range strings.Split(path, ".") {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			cur = v[key]
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return ""
+			}
+			cur = v[idx]
+		default:
+			return ""
+		}
+	}
+	s, _ := cur.(string)
+	return s
+}
+
+func firstAttribute(values map[string]interface{}, paths []string) string {
+	for _, path := range paths {
+		if v := attribute(values, path); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// tfStateV4 is the format of terraform.tfstate files
+type tfStateV4 struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Provider  string `json:"provider"`
+		Instances []struct {
+			IndexKey   interface{}            `json:"index_key"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// tfModule is a module of the JSON output of plans and states
+type tfModule struct {
+	Resources []struct {
+		Address      string                 `json:"address"`
+		Mode         string                 `json:"mode"`
+		Type         string                 `json:"type"`
+		ProviderName string                 `json:"provider_name"`
+		Values       map[string]interface{} `json:"values"`
+	} `json:"resources"`
+	ChildModules []*tfModule `json:"child_modules"`
+}
+
+// tfJSONOutput is the output of `terraform show -json`
+type tfJSONOutput struct {
+	FormatVersion string `json:"format_version"`
+	Values        *struct {
+		RootModule *tfModule `json:"root_module"`
+	} `json:"values"`
+	PlannedValues *struct {
+		RootModule *tfModule `json:"root_module"`
+	} `json:"planned_values"`
+}
+
+// parseTerraformResources returns the managed resources of a state file or
+// the JSON of a plan or state, and if it is a plan
+func parseTerraformResources(data []byte) ([]tfResource, bool, error) {
+	var output tfJSONOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse terraform file")
+	}
+
+	if output.FormatVersion != "" {
+		var res []tfResource
+		if output.PlannedValues != nil && output.PlannedValues.RootModule != nil {
+			collectModuleResources(output.PlannedValues.RootModule, &res)
+			return res, true, nil
+		}
+		if output.Values != nil && output.Values.RootModule != nil {
+			collectModuleResources(output.Values.RootModule, &res)
+		}
+		return res, false, nil
+	}
+
+	var state tfStateV4
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse terraform state")
+	}
+	if state.Version != 4 {
+		return nil, false, errors.New("unsupported terraform state version " + strconv.Itoa(state.Version) + ", only version 4 and the output of `terraform show -json` are supported")
+	}
+
+	var res []tfResource
+	for _, r := range state.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+		address := r.Type + "." + r.Name
+		if r.Module != "" {
+			address = r.Module + "." + address
+		}
+		for _, instance := range r.Instances {
+			res = append(res, tfResource{
+				Address:  address + indexSuffix(instance.IndexKey),
+				Type:     r.Type,
+				Provider: r.Provider,
+				Values:   instance.Attributes,
+			})
+		}
+	}
+	return res, false, nil
+}
+
+func collectModuleResources(module *tfModule, res *[]tfResource) {
+	for _, r := range module.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+		*res = append(*res, tfResource{
+			Address:  r.Address,
+			Type:     r.Type,
+			Provider: r.ProviderName,
+			Values:   r.Values,
+		})
+	}
+	for _, child := range module.ChildModules {
+		collectModuleResources(child, res)
+	}
+}
+
+// indexSuffix formats the index of count and for_each resources like
+// Terraform addresses, e.g. [0] or ["key"]
+func indexSuffix(key interface{}) string {
+	switch v := key.(type) {
+	case float64:
+		return "[" + strconv.FormatFloat(v, 'f', -1, 64) + "]"
+	case string:
+		return "[" + strconv.Quote(v) + "]"
+	default:
+		return ""
+	}
+}
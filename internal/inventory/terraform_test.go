@@ -0,0 +1,64 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/motor/providers"
+)
+
+func TestTerraformInventoryFromState(t *testing.T) {
+	inv, err := TerraformInventoryFromFile("testdata/terraform.tfstate", TerraformOptions{Insecure: true})
+	require.NoError(t, err)
+
+	assets := inv.Spec.Assets
+	require.Len(t, assets, 6)
+
+	state := assets[0]
+	assert.Equal(t, providers.ProviderType_TERRAFORM, state.Connections[0].Backend)
+	assert.Equal(t, "state", state.Connections[0].Options["asset-type"])
+	assert.Equal(t, "testdata/terraform.tfstate", state.Connections[0].Options["path"])
+
+	web0 := assets[1]
+	assert.Equal(t, "web-0", web0.Name)
+	assert.Equal(t, "203.0.113.10", web0.Connections[0].Host)
+	assert.True(t, web0.Connections[0].Insecure)
+	assert.Equal(t, "module.web.aws_instance.server[0]", web0.Labels[TerraformAddressLabel])
+
+	// instances without public IPs are reached by their private IP
+	web1 := assets[2]
+	assert.Equal(t, "i-0a1c", web1.Name)
+	assert.Equal(t, "10.0.0.11", web1.Connections[0].Host)
+
+	app := assets[3]
+	assert.Equal(t, providers.ProviderType_WINRM, app.Connections[0].Backend)
+	assert.Equal(t, `azurerm_windows_virtual_machine.app["blue"]`, app.Labels[TerraformAddressLabel])
+
+	// buckets have no region in their ARN, so only the account of the
+	// instances is added
+	assert.Equal(t, providers.ProviderType_AWS, assets[4].Connections[0].Backend)
+	assert.Equal(t, "us-east-1", assets[4].Connections[0].Options["region"])
+	assert.Equal(t, providers.ProviderType_AZURE, assets[5].Connections[0].Backend)
+	assert.Equal(t, "0000-1111", assets[5].Connections[0].Options["subscription-id"])
+}
+
+func TestTerraformInventoryFromPlan(t *testing.T) {
+	inv, err := TerraformInventoryFromFile("testdata/plan.json", TerraformOptions{SkipCloudAccounts: true})
+	require.NoError(t, err)
+
+	assets := inv.Spec.Assets
+	require.Len(t, assets, 2)
+	assert.Equal(t, "plan", assets[0].Connections[0].Options["asset-type"])
+	assert.Equal(t, "vm", assets[1].Name)
+	assert.Equal(t, "198.51.100.7", assets[1].Connections[0].Host)
+	assert.Equal(t, "module.vm.google_compute_instance.vm", assets[1].Labels[TerraformAddressLabel])
+}
+
+func TestTerraformInventoryErrors(t *testing.T) {
+	_, err := TerraformInventory([]byte(`{"version": 3}`), TerraformOptions{})
+	assert.EqualError(t, err, "unsupported terraform state version 3, only version 4 and the output of `terraform show -json` are supported")
+
+	_, err = TerraformInventory([]byte(`not json`), TerraformOptions{})
+	assert.Error(t, err)
+}
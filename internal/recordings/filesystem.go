@@ -0,0 +1,163 @@
+package recordings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	recordingExt = ".toml"
+	metadataExt  = ".json"
+)
+
+// FilesystemStore keeps recordings in a local directory. Every recording is
+// stored next to a JSON file with its metadata, which serves as the index.
+type FilesystemStore struct {
+	dir string
+}
+
+// NewFilesystemStore creates a store in the given directory
+func NewFilesystemStore(dir string) (*FilesystemStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.New("failed to create recordings directory '" + dir + "': " + err.Error())
+	}
+	return &FilesystemStore{dir: dir}, nil
+}
+
+func (s *FilesystemStore) path(id string, ext string) string {
+	return filepath.Join(s.dir, id+ext)
+}
+
+// Put stores a recording. If meta.ID is empty, a new ID is assigned.
+func (s *FilesystemStore) Put(ctx context.Context, meta *Metadata, data io.Reader) error {
+	initMetadata(meta)
+	if err := validID(meta.ID); err != nil {
+		return err
+	}
+
+	// write the recording first, so the index never points to missing data
+	tmp, err := os.CreateTemp(s.dir, ".recording-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, data)
+	if err != nil {
+		tmp.Close()
+		return errors.New("failed to write recording '" + meta.ID + "': " + err.Error())
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), s.path(meta.ID, recordingExt)); err != nil {
+		return err
+	}
+
+	meta.Size = n
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(meta.ID, metadataExt), raw, 0o600)
+}
+
+func (s *FilesystemStore) metadata(id string) (*Metadata, error) {
+	raw, err := os.ReadFile(s.path(id, metadataExt))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Metadata{}
+	if err = json.Unmarshal(raw, res); err != nil {
+		return nil, errors.New("invalid metadata for recording '" + id + "': " + err.Error())
+	}
+	return res, nil
+}
+
+// Get returns the metadata and content of a recording
+func (s *FilesystemStore) Get(ctx context.Context, id string) (*Metadata, io.ReadCloser, error) {
+	if err := validID(id); err != nil {
+		return nil, nil, err
+	}
+
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(s.path(id, recordingExt))
+	if os.IsNotExist(err) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return meta, f, nil
+}
+
+// List returns the metadata of all matching recordings, ordered by time
+func (s *FilesystemStore) List(ctx context.Context, filter Filter) ([]*Metadata, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*Metadata{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, metadataExt) {
+			continue
+		}
+
+		meta, err := s.metadata(strings.TrimSuffix(name, metadataExt))
+		if err != nil {
+			return nil, err
+		}
+		if filter.Matches(meta) {
+			res = append(res, meta)
+		}
+	}
+
+	sortByTime(res)
+	return res, nil
+}
+
+// Delete removes a recording
+func (s *FilesystemStore) Delete(ctx context.Context, id string) error {
+	if err := validID(id); err != nil {
+		return err
+	}
+
+	// remove the index entry first, so a failure never leaves it dangling
+	if err := os.Remove(s.path(id, metadataExt)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if err := os.Remove(s.path(id, recordingExt)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func sortByTime(list []*Metadata) {
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Recorded.Equal(list[j].Recorded) {
+			return list[i].Recorded.Before(list[j].Recorded)
+		}
+		return list[i].ID < list[j].ID
+	})
+}
+
+var _ Store = (*FilesystemStore)(nil)
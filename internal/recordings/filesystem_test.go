@@ -0,0 +1,82 @@
+package recordings
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+	first := &Metadata{AssetMrn: "//assets/a", BundleChecksum: "x", Recorded: start}
+	require.NoError(t, store.Put(ctx, first, strings.NewReader("first")))
+	second := &Metadata{AssetMrn: "//assets/b", BundleChecksum: "x", Recorded: start.Add(time.Hour)}
+	require.NoError(t, store.Put(ctx, second, strings.NewReader("second recording")))
+	assert.NotEmpty(t, first.ID)
+	assert.Equal(t, int64(16), second.Size)
+
+	meta, data, err := store.Get(ctx, second.ID)
+	require.NoError(t, err)
+	raw, err := io.ReadAll(data)
+	data.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "second recording", string(raw))
+	assert.Equal(t, "//assets/b", meta.AssetMrn)
+
+	list, err := store.List(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, first.ID, list[0].ID)
+	assert.Equal(t, second.ID, list[1].ID)
+
+	list, err = store.List(ctx, Filter{AssetMrn: "//assets/a"})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, first.ID, list[0].ID)
+
+	list, err = store.List(ctx, Filter{BundleChecksum: "x", Since: start.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, second.ID, list[0].ID)
+
+	t.Run("fetch for replay", func(t *testing.T) {
+		path, err := Fetch(ctx, store, first.ID, t.TempDir())
+		require.NoError(t, err)
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(raw))
+	})
+
+	t.Run("fetch with metadata for replay", func(t *testing.T) {
+		meta, path, err := FetchWithMetadata(ctx, store, second.ID, t.TempDir())
+		require.NoError(t, err)
+		assert.Equal(t, "//assets/b", meta.AssetMrn)
+		assert.Equal(t, "x", meta.BundleChecksum)
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "second recording", string(raw))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, first.ID))
+		_, _, err := store.Get(ctx, first.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, store.Delete(ctx, first.ID), ErrNotFound)
+	})
+
+	t.Run("invalid ids", func(t *testing.T) {
+		_, _, err := store.Get(ctx, "../x")
+		assert.Error(t, err)
+		assert.Error(t, store.Put(ctx, &Metadata{ID: "a/b"}, strings.NewReader("")))
+	})
+}
@@ -0,0 +1,129 @@
+// Package recordings stores recorded scan sessions, so that they can be
+// replayed for debugging, e.g. via `cnspec scan mock <recording>`.
+package recordings
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// ErrNotFound is returned if a recording doesn't exist in a store
+var ErrNotFound = errors.New("recording not found")
+
+// Metadata describes a recorded scan session and is used to find it again
+type Metadata struct {
+	ID          string    `json:"id"`
+	AssetMrn    string    `json:"asset_mrn,omitempty"`
+	AssetName   string    `json:"asset_name,omitempty"`
+	PlatformIds []string  `json:"platform_ids,omitempty"`
+	Recorded    time.Time `json:"recorded"`
+	// BundleChecksum is the source hash of the policy bundle that was used
+	// for the scan, see policy.Bundle.SourceHash
+	BundleChecksum string `json:"bundle_checksum,omitempty"`
+	Size           int64  `json:"size"`
+}
+
+// Filter selects recordings by their metadata. Empty fields match everything.
+type Filter struct {
+	AssetMrn       string
+	BundleChecksum string
+	Since          time.Time
+	Until          time.Time
+}
+
+// Matches returns true if the recording is selected by the filter
+func (f Filter) Matches(meta *Metadata) bool {
+	if f.AssetMrn != "" && f.AssetMrn != meta.AssetMrn {
+		return false
+	}
+	if f.BundleChecksum != "" && f.BundleChecksum != meta.BundleChecksum {
+		return false
+	}
+	if !f.Since.IsZero() && meta.Recorded.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !meta.Recorded.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Store persists recordings with their metadata
+type Store interface {
+	// Put stores a recording. If meta.ID is empty, a new ID is assigned.
+	Put(ctx context.Context, meta *Metadata, data io.Reader) error
+	// Get returns the metadata and content of a recording. The caller has to
+	// close the content. Returns ErrNotFound for unknown IDs.
+	Get(ctx context.Context, id string) (*Metadata, io.ReadCloser, error)
+	// List returns the metadata of all matching recordings, ordered by time
+	List(ctx context.Context, filter Filter) ([]*Metadata, error)
+	// Delete removes a recording
+	Delete(ctx context.Context, id string) error
+}
+
+// Open creates a store from its location, which is either a local directory
+// or an S3 url like s3://bucket/prefix
+func Open(ctx context.Context, location string) (Store, error) {
+	u, err := url.Parse(location)
+	if err == nil && u.Scheme == "s3" {
+		return NewS3Store(ctx, u.Host, strings.TrimPrefix(u.Path, "/"))
+	}
+	if err == nil && u.Scheme == "file" {
+		location = u.Path
+	}
+	return NewFilesystemStore(location)
+}
+
+// Fetch copies a recording into the given directory, so it can be replayed,
+// and returns the path of the file
+func Fetch(ctx context.Context, store Store, id string, dir string) (string, error) {
+	_, path, err := FetchWithMetadata(ctx, store, id, dir)
+	return path, err
+}
+
+// FetchWithMetadata copies a recording into the given directory like Fetch
+// and also returns its metadata
+func FetchWithMetadata(ctx context.Context, store Store, id string, dir string) (*Metadata, string, error) {
+	meta, data, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	defer data.Close()
+
+	path := filepath.Join(dir, "recording-"+id+".toml")
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = io.Copy(f, data); err != nil {
+		f.Close()
+		return nil, "", err
+	}
+	return meta, path, f.Close()
+}
+
+// initMetadata assigns an ID and recording time if they are missing
+func initMetadata(meta *Metadata) {
+	if meta.ID == "" {
+		meta.ID = ksuid.New().String()
+	}
+	if meta.Recorded.IsZero() {
+		meta.Recorded = time.Now()
+	}
+}
+
+// validID prevents IDs from escaping the store, e.g. via path separators
+func validID(id string) error {
+	if id == "" || strings.ContainsAny(id, "/\\") || id == "." || id == ".." {
+		return errors.New("invalid recording id '" + id + "'")
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package recordings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// s3API is the subset of the S3 client that the store uses
+type s3API interface {
+	s3.ListObjectsV2APIClient
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// S3Store keeps recordings in an S3 bucket. Like the FilesystemStore, every
+// recording has a JSON object with its metadata next to it.
+type S3Store struct {
+	client s3API
+	bucket string
+	prefix string
+}
+
+// NewS3Store creates a store in the given bucket, with all objects below the
+// prefix. It uses the default AWS configuration of the environment.
+func NewS3Store(ctx context.Context, bucket string, prefix string) (*S3Store, error) {
+	if bucket == "" {
+		return nil, errors.New("cannot store recordings in S3 without a bucket")
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, errors.New("failed to load AWS configuration: " + err.Error())
+	}
+
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &S3Store{
+		client: s3.NewFromConfig(cfg),
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+func (s *S3Store) key(id string, ext string) string {
+	return s.prefix + id + ext
+}
+
+func (s *S3Store) put(ctx context.Context, key string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (s *S3Store) get(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NoSuchKey
+		if errors.As(err, &notFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// Put stores a recording. If meta.ID is empty, a new ID is assigned.
+func (s *S3Store) Put(ctx context.Context, meta *Metadata, data io.Reader) error {
+	initMetadata(meta)
+	if err := validID(meta.ID); err != nil {
+		return err
+	}
+
+	// uploads need to be seekable for signing
+	raw, err := io.ReadAll(data)
+	if err != nil {
+		return errors.New("failed to read recording '" + meta.ID + "': " + err.Error())
+	}
+	if err = s.put(ctx, s.key(meta.ID, recordingExt), raw); err != nil {
+		return errors.New("failed to upload recording '" + meta.ID + "': " + err.Error())
+	}
+
+	meta.Size = int64(len(raw))
+	rawMeta, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err = s.put(ctx, s.key(meta.ID, metadataExt), rawMeta); err != nil {
+		return errors.New("failed to upload metadata of recording '" + meta.ID + "': " + err.Error())
+	}
+	return nil
+}
+
+func (s *S3Store) metadata(ctx context.Context, id string) (*Metadata, error) {
+	body, err := s.get(ctx, s.key(id, metadataExt))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	res := &Metadata{}
+	if err = json.NewDecoder(body).Decode(res); err != nil {
+		return nil, errors.New("invalid metadata for recording '" + id + "': " + err.Error())
+	}
+	return res, nil
+}
+
+// Get returns the metadata and content of a recording
+func (s *S3Store) Get(ctx context.Context, id string) (*Metadata, io.ReadCloser, error) {
+	if err := validID(id); err != nil {
+		return nil, nil, err
+	}
+
+	meta, err := s.metadata(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.get(ctx, s.key(id, recordingExt))
+	if err != nil {
+		return nil, nil, err
+	}
+	return meta, body, nil
+}
+
+// List returns the metadata of all matching recordings, ordered by time
+func (s *S3Store) List(ctx context.Context, filter Filter) ([]*Metadata, error) {
+	res := []*Metadata{}
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, errors.New("failed to list recordings: " + err.Error())
+		}
+
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if !strings.HasSuffix(key, metadataExt) {
+				continue
+			}
+			id := strings.TrimSuffix(strings.TrimPrefix(key, s.prefix), metadataExt)
+			if strings.Contains(id, "/") {
+				// belongs to a nested prefix
+				continue
+			}
+
+			meta, err := s.metadata(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if filter.Matches(meta) {
+				res = append(res, meta)
+			}
+		}
+	}
+
+	sortByTime(res)
+	return res, nil
+}
+
+// Delete removes a recording
+func (s *S3Store) Delete(ctx context.Context, id string) error {
+	if err := validID(id); err != nil {
+		return err
+	}
+
+	for _, ext := range []string{metadataExt, recordingExt} {
+		_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(s.key(id, ext)),
+		})
+		if err != nil {
+			return errors.New("failed to delete recording '" + id + "': " + err.Error())
+		}
+	}
+	return nil
+}
+
+var _ Store = (*S3Store)(nil)
@@ -0,0 +1,195 @@
+package policy
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/ranger-rpc/codes"
+	"go.mondoo.com/ranger-rpc/status"
+)
+
+// BatchAssignment assigns policies to an asset, see AssignBatch
+type BatchAssignment struct {
+	AssetMrn   string
+	PolicyMrns []string
+	// AssetFilters describe the asset's platform. If they are set, every
+	// policy has to match them and is previewed for the asset. Otherwise
+	// policies are only checked for existence.
+	AssetFilters []*explorer.Mquery
+}
+
+// PolicyAssignmentPreview lists how many queries of a policy run on an asset
+type PolicyAssignmentPreview struct {
+	PolicyMrn   string
+	Checks      int
+	DataQueries int
+}
+
+// AssetAssignmentPreview lists how many queries run on an asset for all
+// policies that were assigned to it. Queries that are part of multiple
+// policies are only counted once.
+type AssetAssignmentPreview struct {
+	AssetMrn    string
+	Policies    []*PolicyAssignmentPreview
+	Checks      int
+	DataQueries int
+	// Previewed is false if the asset filters weren't known, in which
+	// case no queries are counted
+	Previewed bool
+}
+
+// AssignmentPreview is the result of AssignBatch, sorted by asset MRN
+type AssignmentPreview struct {
+	Assets []*AssetAssignmentPreview
+}
+
+// mergeAssignments combines all assignments of the same asset, so that
+// they can be applied in one mutation
+func mergeAssignments(assignments []*BatchAssignment) ([]*BatchAssignment, error) {
+	byAsset := map[string]*BatchAssignment{}
+	var res []*BatchAssignment
+	for i := range assignments {
+		assignment := assignments[i]
+		if assignment == nil {
+			continue
+		}
+		if assignment.AssetMrn == "" {
+			return nil, status.Error(codes.InvalidArgument, "an asset mrn is required")
+		}
+		if len(assignment.PolicyMrns) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "a policy mrn is required for asset "+assignment.AssetMrn)
+		}
+
+		merged, ok := byAsset[assignment.AssetMrn]
+		if !ok {
+			merged = &BatchAssignment{AssetMrn: assignment.AssetMrn}
+			byAsset[assignment.AssetMrn] = merged
+			res = append(res, merged)
+		}
+		for _, mrn := range assignment.PolicyMrns {
+			merged.PolicyMrns = appendUnique(merged.PolicyMrns, mrn)
+		}
+		if len(assignment.AssetFilters) != 0 {
+			if len(merged.AssetFilters) != 0 && !sameAssetFilters(merged.AssetFilters, assignment.AssetFilters) {
+				return nil, status.Error(codes.InvalidArgument, "conflicting asset filters for asset "+assignment.AssetMrn)
+			}
+			merged.AssetFilters = assignment.AssetFilters
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].AssetMrn < res[j].AssetMrn
+	})
+	return res, nil
+}
+
+func sameAssetFilters(a []*explorer.Mquery, b []*explorer.Mquery) bool {
+	as, err := ChecksumAssetFilters(a)
+	if err != nil {
+		return false
+	}
+	bs, err := ChecksumAssetFilters(b)
+	if err != nil {
+		return false
+	}
+	return as == bs
+}
+
+// previewPolicy counts the active and unique queries of a resolve plan.
+// All counted query MRNs are added to checks and data.
+func previewPolicy(plan *ResolvePlan, checks map[string]struct{}, data map[string]struct{}) *PolicyAssignmentPreview {
+	res := &PolicyAssignmentPreview{PolicyMrn: plan.PolicyMrn}
+	for _, query := range plan.Queries {
+		if !query.Active || query.DuplicateOf != "" {
+			continue
+		}
+		if query.IsData {
+			res.DataQueries++
+			data[query.Mrn] = struct{}{}
+		} else {
+			res.Checks++
+			checks[query.Mrn] = struct{}{}
+		}
+	}
+	return res
+}
+
+// AssignBatch assigns policies to multiple assets at once. All assignments
+// are validated before any of them is applied: every policy has to exist
+// and, if the asset filters are known, match the asset. The policies of
+// each asset are then assigned in one mutation, so that an asset either
+// gets all of its policies or none. It returns a preview of the queries
+// that will run on every asset.
+func (s *LocalServices) AssignBatch(ctx context.Context, assignments []*BatchAssignment) (*AssignmentPreview, error) {
+	if s.useUpstream() {
+		return nil, errors.New("cannot assign a batch of policies, policies are assigned upstream")
+	}
+
+	merged, err := mergeAssignments(assignments)
+	if err != nil {
+		return nil, err
+	}
+	if len(merged) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no policies were assigned")
+	}
+
+	// phase 1: validate all assignments without changing anything
+	res := &AssignmentPreview{}
+	for _, assignment := range merged {
+		preview := &AssetAssignmentPreview{
+			AssetMrn:  assignment.AssetMrn,
+			Previewed: len(assignment.AssetFilters) != 0,
+		}
+		checks := map[string]struct{}{}
+		data := map[string]struct{}{}
+
+		for _, policyMrn := range assignment.PolicyMrns {
+			// NOTE: by calling GetPolicy policies from upstream are cached
+			if _, err := s.GetPolicy(ctx, &Mrn{Mrn: policyMrn}); err != nil {
+				return nil, errors.Wrap(err, "cannot assign policy "+policyMrn+" to asset "+assignment.AssetMrn)
+			}
+			if !preview.Previewed {
+				continue
+			}
+
+			plan, err := s.ExplainResolve(ctx, &ResolveReq{
+				PolicyMrn:    policyMrn,
+				AssetFilters: assignment.AssetFilters,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot assign policy "+policyMrn+" to asset "+assignment.AssetMrn)
+			}
+			preview.Policies = append(preview.Policies, previewPolicy(plan, checks, data))
+		}
+
+		preview.Checks = len(checks)
+		preview.DataQueries = len(data)
+		res.Assets = append(res.Assets, preview)
+	}
+
+	// phase 2: apply all policies of an asset at once
+	for _, assignment := range merged {
+		deltas := make(map[string]*PolicyDelta, len(assignment.PolicyMrns))
+		for _, policyMrn := range assignment.PolicyMrns {
+			deltas[policyMrn] = &PolicyDelta{
+				PolicyMrn: policyMrn,
+				Action:    PolicyDelta_ADD,
+			}
+		}
+
+		if err := s.DataLake.EnsureAsset(ctx, assignment.AssetMrn); err != nil {
+			return nil, err
+		}
+		_, err := s.DataLake.MutatePolicy(ctx, &PolicyMutationDelta{
+			PolicyMrn:    assignment.AssetMrn,
+			PolicyDeltas: deltas,
+		}, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to assign policies to asset "+assignment.AssetMrn)
+		}
+	}
+
+	return res, nil
+}
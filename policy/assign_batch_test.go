@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestMergeAssignments(t *testing.T) {
+	linux := []*explorer.Mquery{{Mql: "asset.family.contains('linux')", CodeId: "linux"}}
+	merged, err := mergeAssignments([]*BatchAssignment{
+		{AssetMrn: "//asset/b", PolicyMrns: []string{"//policy/1"}},
+		{AssetMrn: "//asset/a", PolicyMrns: []string{"//policy/1", "//policy/2"}},
+		nil,
+		{AssetMrn: "//asset/b", PolicyMrns: []string{"//policy/2", "//policy/1"}, AssetFilters: linux},
+	})
+	require.NoError(t, err)
+	require.Len(t, merged, 2)
+	assert.Equal(t, &BatchAssignment{AssetMrn: "//asset/a", PolicyMrns: []string{"//policy/1", "//policy/2"}}, merged[0])
+	assert.Equal(t, &BatchAssignment{AssetMrn: "//asset/b", PolicyMrns: []string{"//policy/1", "//policy/2"}, AssetFilters: linux}, merged[1])
+
+	_, err = mergeAssignments([]*BatchAssignment{{AssetMrn: "//asset/a"}})
+	assert.Error(t, err)
+	_, err = mergeAssignments([]*BatchAssignment{{PolicyMrns: []string{"//policy/1"}}})
+	assert.Error(t, err)
+}
+
+func TestPreviewPolicy(t *testing.T) {
+	checks, data := map[string]struct{}{}, map[string]struct{}{}
+	preview := previewPolicy(&ResolvePlan{PolicyMrn: "//policy/1", Queries: []*PlannedQuery{
+		{Mrn: "//check/a", Active: true},
+		{Mrn: "//check/b", Active: true, DuplicateOf: "//check/a"},
+		{Mrn: "//check/c", Reason: "deactivated by //asset"},
+		{Mrn: "//query/d", Active: true, IsData: true},
+	}}, checks, data)
+
+	assert.Equal(t, &PolicyAssignmentPreview{PolicyMrn: "//policy/1", Checks: 1, DataQueries: 1}, preview)
+	assert.Equal(t, map[string]struct{}{"//check/a": {}}, checks)
+	assert.Equal(t, map[string]struct{}{"//query/d": {}}, data)
+}
@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// AuditKind is the kind of call that the scanner issued on an asset
+type AuditKind string
+
+// AuditCommand is a command that was run on the asset
+const AuditCommand AuditKind = "command"
+
+// AuditTrail records all calls that the scanner issues on an asset, so that
+// they can be audited later. Calls are attributed to the query that is
+// executing while they are issued, which requires queries to be executed
+// one after the other. It is safe for concurrent use.
+type AuditTrail struct {
+	mu      sync.Mutex
+	codeID  string
+	entries []*AuditEntry
+}
+
+// NewAuditTrail creates an empty audit trail
+func NewAuditTrail() *AuditTrail {
+	return &AuditTrail{}
+}
+
+// QueryStarted attributes all following calls to the query
+func (t *AuditTrail) QueryStarted(codeID string) {
+	t.mu.Lock()
+	t.codeID = codeID
+	t.mu.Unlock()
+}
+
+// QueryFinished stops attributing calls to the query
+func (t *AuditTrail) QueryFinished(codeID string) {
+	t.mu.Lock()
+	if t.codeID == codeID {
+		t.codeID = ""
+	}
+	t.mu.Unlock()
+}
+
+// Record adds a call that was issued at the given time and just finished
+func (t *AuditTrail) Record(kind AuditKind, call string, start time.Time, exitStatus int, err error) {
+	entry := &AuditEntry{
+		Kind:       string(kind),
+		Call:       call,
+		Time:       start.UnixNano(),
+		Duration:   int64(time.Since(start)),
+		ExitStatus: int32(exitStatus),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	t.mu.Lock()
+	entry.CodeId = t.codeID
+	t.entries = append(t.entries, entry)
+	t.mu.Unlock()
+}
+
+// Entries returns all recorded calls in the order they were issued. The
+// bundle is optional, it links the calls to the MRNs of their checks.
+func (t *AuditTrail) Entries(bundle *Bundle) []*AuditEntry {
+	mrns := map[string][]string{}
+	if bundle != nil {
+		for _, query := range bundle.Queries {
+			if query.CodeId != "" {
+				mrns[query.CodeId] = appendUnique(mrns[query.CodeId], query.Mrn)
+			}
+		}
+		for _, policy := range bundle.Policies {
+			for _, group := range policy.Groups {
+				for _, check := range group.Checks {
+					if check.CodeId != "" && check.Mrn != "" {
+						mrns[check.CodeId] = appendUnique(mrns[check.CodeId], check.Mrn)
+					}
+				}
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := make([]*AuditEntry, len(t.entries))
+	for i := range t.entries {
+		entry := proto.Clone(t.entries[i]).(*AuditEntry)
+		if checkMrns, ok := mrns[entry.CodeId]; ok {
+			entry.CheckMrns = append([]string{}, checkMrns...)
+			sort.Strings(entry.CheckMrns)
+		}
+		res[i] = entry
+	}
+	return res
+}
@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestAuditTrail(t *testing.T) {
+	trail := NewAuditTrail()
+	trail.Record(AuditCommand, "uname -s", time.Now(), 0, nil)
+
+	trail.QueryStarted("code-1")
+	trail.Record(AuditCommand, "cat /etc/passwd", time.Now(), 0, nil)
+	trail.Record(AuditCommand, "sshd -T", time.Now(), 1, errors.New("permission denied"))
+	trail.QueryFinished("code-1")
+
+	// finishing another query doesn't end the attribution
+	trail.QueryStarted("code-2")
+	trail.QueryFinished("code-1")
+	trail.Record(AuditCommand, "ls /", time.Now(), 0, nil)
+
+	bundle := &Bundle{
+		Queries: []*explorer.Mquery{{Mrn: "//query/passwd", CodeId: "code-1"}},
+		Policies: []*Policy{{Groups: []*PolicyGroup{{
+			Checks: []*explorer.Mquery{{Mrn: "//check/passwd", CodeId: "code-1"}},
+		}}}},
+	}
+
+	entries := trail.Entries(bundle)
+	require.Len(t, entries, 4)
+	assert.Equal(t, "uname -s", entries[0].Call)
+	assert.Empty(t, entries[0].CodeId)
+	assert.Empty(t, entries[0].CheckMrns)
+
+	assert.Equal(t, "code-1", entries[1].CodeId)
+	assert.Equal(t, []string{"//check/passwd", "//query/passwd"}, entries[1].CheckMrns)
+	assert.Equal(t, string(AuditCommand), entries[2].Kind)
+	assert.Equal(t, int32(1), entries[2].ExitStatus)
+	assert.Equal(t, "permission denied", entries[2].Error)
+
+	assert.Equal(t, "code-2", entries[3].CodeId)
+	assert.Empty(t, entries[3].CheckMrns)
+
+	// entries without a bundle aren't linked to checks
+	assert.Empty(t, trail.Entries(nil)[1].CheckMrns)
+}
@@ -0,0 +1,194 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+// BatchEntryKind is the kind of entry in a batch update
+type BatchEntryKind string
+
+const (
+	BatchEntryScore BatchEntryKind = "score"
+	BatchEntryData  BatchEntryKind = "data"
+)
+
+// BatchUpdate contains scores and data of an asset that are stored together,
+// see BatchUpdater
+type BatchUpdate struct {
+	AssetMrn string
+	Scores   []*Score
+	// Data by datapoint checksum
+	Data map[string]*llx.Result
+}
+
+// BatchEntryResult is the outcome of one entry of a batch update
+type BatchEntryResult struct {
+	Kind BatchEntryKind
+	// ID is the QrId of scores and the checksum of data
+	ID string
+	// Updated is true if the stored entry changed. Data is always updated.
+	Updated bool
+	// Type is the expected type of data
+	Type types.Type
+	// Warning is set if the data was stored with a warning, e.g. about a
+	// coercion of its type
+	Warning string
+	// Error is set if the entry is invalid or couldn't be stored
+	Error string
+}
+
+// BatchUpdateResult is the outcome of a batch update. Entries are sorted by
+// kind and ID.
+type BatchUpdateResult struct {
+	// Applied is true if all entries were stored. Otherwise none of them
+	// were stored and the entries with an Error explain why.
+	Applied bool
+	Entries []*BatchEntryResult
+
+	index map[BatchEntryKind]map[string]*BatchEntryResult
+}
+
+// BatchUpdater is implemented by datalakes that can store all scores and data
+// of an asset atomically, instead of one by one like UpdateScores and
+// UpdateData
+type BatchUpdater interface {
+	// ApplyBatch validates all entries of the batch and only stores them if
+	// all are valid. Either all entries are stored or none. Invalid entries
+	// are reported in the result; the error is only set if the batch
+	// couldn't be processed at all.
+	ApplyBatch(ctx context.Context, batch *BatchUpdate) (*BatchUpdateResult, error)
+}
+
+// Failed returns all entries with an error
+func (r *BatchUpdateResult) Failed() []*BatchEntryResult {
+	var res []*BatchEntryResult
+	for i := range r.Entries {
+		if r.Entries[i].Error != "" {
+			res = append(res, r.Entries[i])
+		}
+	}
+	return res
+}
+
+// Err returns an error that summarizes the failed entries, if the batch
+// wasn't applied
+func (r *BatchUpdateResult) Err() error {
+	if r.Applied {
+		return nil
+	}
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return errors.New("batch update was not applied")
+	}
+	return errors.New("batch update was not applied, " + strconv.Itoa(len(failed)) + " entries failed, e.g. " +
+		string(failed[0].Kind) + " " + failed[0].ID + ": " + failed[0].Error)
+}
+
+// Entry returns the result of one entry, or nil if it isn't part of the
+// batch. Entries are indexed on the first call, so none may be added after.
+func (r *BatchUpdateResult) Entry(kind BatchEntryKind, id string) *BatchEntryResult {
+	if r.index == nil {
+		r.index = map[BatchEntryKind]map[string]*BatchEntryResult{}
+		for i := range r.Entries {
+			entry := r.Entries[i]
+			if r.index[entry.Kind] == nil {
+				r.index[entry.Kind] = map[string]*BatchEntryResult{}
+			}
+			r.index[entry.Kind][entry.ID] = entry
+		}
+	}
+	return r.index[kind][id]
+}
+
+// RolledBack marks the batch as not applied after it failed to be stored,
+// with the error on the entry that failed
+func (r *BatchUpdateResult) RolledBack(failed *BatchEntryResult, err error) {
+	r.Applied = false
+	for i := range r.Entries {
+		r.Entries[i].Updated = false
+	}
+	if failed != nil {
+		failed.Error = err.Error()
+	}
+}
+
+// SortEntries orders the entries by kind and ID
+func (r *BatchUpdateResult) SortEntries() {
+	sort.Slice(r.Entries, func(i, j int) bool {
+		if r.Entries[i].Kind != r.Entries[j].Kind {
+			return r.Entries[i].Kind > r.Entries[j].Kind
+		}
+		return r.Entries[i].ID < r.Entries[j].ID
+	})
+}
+
+// ValidateBatch checks all entries of the batch against the asset's collector
+// job: scores need an ID and a value of at most 100, data must belong to a
+// datapoint of the job and match (or be coercible into) its type. It
+// returns the result of all entries, which is marked as applied if all are
+// valid, and the data that should be stored, after coercion.
+func ValidateBatch(batch *BatchUpdate, collectorJob *CollectorJob, coercion CoercionOptions) (*BatchUpdateResult, map[string]*llx.Result) {
+	res := &BatchUpdateResult{Applied: true}
+	fail := func(entry *BatchEntryResult, msg string) {
+		entry.Error = msg
+		res.Applied = false
+	}
+
+	seen := make(map[string]struct{}, len(batch.Scores))
+	for i := range batch.Scores {
+		score := batch.Scores[i]
+		entry := &BatchEntryResult{Kind: BatchEntryScore}
+		res.Entries = append(res.Entries, entry)
+		if score == nil {
+			fail(entry, "score is empty")
+			continue
+		}
+		entry.ID = score.QrId
+
+		if score.QrId == "" {
+			fail(entry, "score has no ID")
+		} else if _, ok := seen[score.QrId]; ok {
+			fail(entry, "score is part of the batch more than once")
+		} else if score.Value > 100 {
+			fail(entry, "score value "+strconv.Itoa(int(score.Value))+" is larger than 100")
+		}
+		seen[score.QrId] = struct{}{}
+	}
+
+	data := make(map[string]*llx.Result, len(batch.Data))
+	for checksum, val := range batch.Data {
+		entry := &BatchEntryResult{Kind: BatchEntryData, ID: checksum}
+		res.Entries = append(res.Entries, entry)
+
+		var info *DataQueryInfo
+		if collectorJob != nil {
+			info = collectorJob.Datapoints[checksum]
+		}
+		if info == nil {
+			fail(entry, "cannot find this datapoint to store values")
+			continue
+		}
+		entry.Type = types.Type(info.Type)
+
+		if val == nil {
+			fail(entry, "data is empty")
+			continue
+		}
+		coerced, warning, err := CoerceResult(val, entry.Type, coercion)
+		if err != nil {
+			fail(entry, err.Error())
+			continue
+		}
+		entry.Warning = warning
+		data[checksum] = coerced
+	}
+
+	res.SortEntries()
+	return res, data
+}
@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+func TestValidateBatch(t *testing.T) {
+	collectorJob := &CollectorJob{
+		Datapoints: map[string]*DataQueryInfo{
+			"dp-int":    {Type: string(types.Int)},
+			"dp-string": {Type: string(types.String)},
+		},
+	}
+
+	t.Run("valid batch", func(t *testing.T) {
+		batch := &BatchUpdate{
+			AssetMrn: "//asset",
+			Scores:   []*Score{{QrId: "q2", Value: 100}, {QrId: "q1", Value: 0}},
+			Data: map[string]*llx.Result{
+				"dp-string": (&llx.RawResult{Data: llx.StringData("a"), CodeID: "dp-string"}).Result(),
+			},
+		}
+		res, data := ValidateBatch(batch, collectorJob, DefaultCoercion)
+		require.True(t, res.Applied)
+		assert.NoError(t, res.Err())
+		assert.Empty(t, res.Failed())
+		require.Len(t, res.Entries, 3)
+		assert.Equal(t, BatchEntryScore, res.Entries[0].Kind)
+		assert.Equal(t, "q1", res.Entries[0].ID)
+		assert.Equal(t, "q2", res.Entries[1].ID)
+		assert.Equal(t, BatchEntryData, res.Entries[2].Kind)
+		assert.Equal(t, types.String, res.Entry(BatchEntryData, "dp-string").Type)
+		assert.Contains(t, data, "dp-string")
+	})
+
+	t.Run("coerced data", func(t *testing.T) {
+		batch := &BatchUpdate{
+			Data: map[string]*llx.Result{
+				"dp-int": (&llx.RawResult{Data: llx.FloatData(4), CodeID: "dp-int"}).Result(),
+			},
+		}
+		res, data := ValidateBatch(batch, collectorJob, DefaultCoercion)
+		require.True(t, res.Applied)
+		assert.NotEmpty(t, res.Entry(BatchEntryData, "dp-int").Warning)
+		assert.Equal(t, int64(4), data["dp-int"].Data.RawData().Value)
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		batch := &BatchUpdate{
+			Scores: []*Score{
+				{QrId: "q1", Value: 101},
+				{QrId: "q2"},
+				{QrId: "q2"},
+				{Value: 50},
+			},
+			Data: map[string]*llx.Result{
+				"dp-int":     (&llx.RawResult{Data: llx.StringData("a"), CodeID: "dp-int"}).Result(),
+				"dp-unknown": (&llx.RawResult{Data: llx.IntData(1), CodeID: "dp-unknown"}).Result(),
+				"dp-string":  nil,
+			},
+		}
+		res, data := ValidateBatch(batch, collectorJob, DefaultCoercion)
+		require.False(t, res.Applied)
+		assert.Empty(t, data)
+		assert.Len(t, res.Failed(), 6)
+		assert.Contains(t, res.Entry(BatchEntryScore, "q1").Error, "larger than 100")
+		assert.Equal(t, "score has no ID", res.Entry(BatchEntryScore, "").Error)
+		assert.Equal(t, "cannot find this datapoint to store values", res.Entry(BatchEntryData, "dp-unknown").Error)
+		assert.Equal(t, "data is empty", res.Entry(BatchEntryData, "dp-string").Error)
+		assert.NotEmpty(t, res.Entry(BatchEntryData, "dp-int").Error)
+		assert.Nil(t, res.Entry(BatchEntryData, "dp-missing"))
+
+		err := res.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "6 entries failed")
+	})
+}
+
+func TestBatchUpdateResult_RolledBack(t *testing.T) {
+	res := &BatchUpdateResult{
+		Applied: true,
+		Entries: []*BatchEntryResult{
+			{Kind: BatchEntryScore, ID: "q1", Updated: true},
+			{Kind: BatchEntryData, ID: "dp", Updated: true},
+		},
+	}
+	res.RolledBack(res.Entry(BatchEntryData, "dp"), assert.AnError)
+	assert.False(t, res.Applied)
+	assert.False(t, res.Entries[0].Updated)
+	assert.Equal(t, assert.AnError.Error(), res.Entries[1].Error)
+	assert.ErrorContains(t, res.Err(), "1 entries failed")
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
-	"go.mondoo.com/cnquery/checksums"
 	"go.mondoo.com/cnquery/explorer"
 	"go.mondoo.com/cnquery/llx"
 	"go.mondoo.com/cnquery/logger"
@@ -22,27 +21,37 @@ const (
 	MRN_RESOURCE_QUERY  = "queries"
 	MRN_RESOURCE_POLICY = "policies"
 	MRN_RESOURCE_ASSET  = "assets"
+	MRN_RESOURCE_SPACE  = "spaces"
 )
 
 // BundleFromPaths loads a single policy bundle file or a bundle that
 // was split into multiple files into a single PolicyBundle struct
 func BundleFromPaths(paths ...string) (*Bundle, error) {
+	bundle, _, err := BundleFromPathsWithSources(paths...)
+	return bundle, err
+}
+
+// BundleFromPathsWithSources works like BundleFromPaths, but also returns
+// where all policies, queries and properties are defined. Attach the source
+// map to the context via WithSourceMap to get source locations in errors.
+func BundleFromPathsWithSources(paths ...string) (*Bundle, SourceMap, error) {
 	// load all the source files
 	resolvedFilenames, err := WalkPolicyBundleFiles(paths...)
 	if err != nil {
 		log.Error().Err(err).Msg("could not resolve bundle files")
-		return nil, err
+		return nil, nil, err
 	}
 
 	// aggregate all files into a single policy bundle
-	aggregatedBundle, err := aggregateFilesToBundle(resolvedFilenames)
+	sources := SourceMap{}
+	aggregatedBundle, err := aggregateFilesToBundle(resolvedFilenames, sources)
 	if err != nil {
 		log.Debug().Err(err).Msg("could merge bundle files")
-		return nil, err
+		return nil, nil, err
 	}
 
 	logger.DebugDumpYAML("resolved_mql_bundle.mql", aggregatedBundle)
-	return aggregatedBundle, nil
+	return aggregatedBundle, sources, nil
 }
 
 // WalkPolicyBundleFiles iterates over all provided filenames and
@@ -85,14 +94,14 @@ func WalkPolicyBundleFiles(filenames ...string) ([]string, error) {
 
 // aggregateFilesToBundle iterates over all provided files and loads its content.
 // It assumes that all provided files are checked upfront and are not a directory
-func aggregateFilesToBundle(paths []string) (*Bundle, error) {
+func aggregateFilesToBundle(paths []string, sources SourceMap) (*Bundle, error) {
 	// iterate over all files, load them and merge them
 	mergedBundle := &Bundle{}
 
 	for i := range paths {
 		path := paths[i]
 		log.Debug().Str("path", path).Msg("loading policy bundle file")
-		bundle, err := bundleFromSingleFile(path)
+		bundle, err := bundleFromSingleFile(path, sources)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not load file: "+path)
 		}
@@ -104,13 +113,23 @@ func aggregateFilesToBundle(paths []string) (*Bundle, error) {
 }
 
 // bundleFromSingleFile loads a policy bundle from a single file
-func bundleFromSingleFile(path string) (*Bundle, error) {
+func bundleFromSingleFile(path string, sources SourceMap) (*Bundle, error) {
 	bundleData, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return BundleFromYAML(bundleData)
+	bundle, err := BundleFromYAML(bundleData)
+	if err != nil {
+		return nil, err
+	}
+
+	// source locations are only informational, we don't fail if they are missing
+	if err := sourcesFromYAML(path, bundleData, sources); err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("could not collect source locations")
+	}
+
+	return bundle, nil
 }
 
 // aggregateBundles combines two PolicyBundle and merges the data additive into one
@@ -187,14 +206,9 @@ func BundleFromYAML(data []byte) (*Bundle, error) {
 	// be an old bundle. So let's try to parse it as an old bundle.
 	var altRes DeprecatedV7_Bundle
 	altErr := yaml.Unmarshal(data, &altRes)
-	if altErr == nil && len(altRes.Policies) != 0 {
-		// we still want to do a sanity check that this is a valid v7 policy
-		for i := range altRes.Policies {
-			cur := altRes.Policies[i]
-			if cur.Specs != nil {
-				return altRes.ToV8(), nil
-			}
-		}
+	// we still want to do a sanity check that this is a valid v7 policy
+	if altErr == nil && isDeprecatedV7Bundle(&altRes) {
+		return altRes.ToV8(), nil
 	}
 
 	// This is the final fallthrough, where we either have an error or
@@ -213,7 +227,7 @@ func (p *Bundle) SourceHash() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	c := checksums.New
+	c := NewChecksum()
 	c = c.Add(string(raw))
 	return c.String(), nil
 }
@@ -452,8 +466,12 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 	var err error
 	var warnings []error
 
+	// source locations of the bundle, if it was loaded from files
+	sources := SourceMapFromContext(ctx)
+
 	uid2mrn := map[string]string{}
 	bundles := map[string]*llx.CodeBundle{}
+	checks := map[string]struct{}{}
 
 	// Index properties
 	lookupProp := map[string]explorer.PropertyRef{}
@@ -478,11 +496,12 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 		// ensure the correct mrn is set
 		uid := query.Uid
 		if err = query.RefreshMRN(ownerMrn); err != nil {
-			return nil, err
+			return nil, sources.Wrap(err, uid, query.Mrn)
 		}
 		if uid != "" {
 			uid2mrn[uid] = query.Mrn
 		}
+		sources.alias(uid, query.Mrn)
 		lookupQuery[query.Mrn] = query
 
 		// ensure MRNs for properties
@@ -496,7 +515,7 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 		bundle, err := query.RefreshChecksumAndType(lookupProp)
 		if err != nil {
 			log.Error().Err(err).Msg("could not compile the query")
-			warnings = append(warnings, errors.Wrap(err, "failed to validate query '"+query.Mrn+"'"))
+			warnings = append(warnings, sources.Wrap(errors.Wrap(err, "failed to validate query '"+query.Mrn+"'"), query.Mrn))
 		}
 
 		bundles[query.Mrn] = bundle
@@ -514,12 +533,13 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 
 		err := policy.RefreshMRN(ownerMrn)
 		if err != nil {
-			return nil, errors.New("failed to refresh policy " + policy.Mrn + ": " + err.Error())
+			return nil, sources.Wrap(errors.New("failed to refresh policy "+policy.Mrn+": "+err.Error()), policyUID, policy.Mrn)
 		}
 
 		if policyUID != "" {
 			uid2mrn[policyUID] = policy.Mrn
 		}
+		sources.alias(policyUID, policy.Mrn)
 
 		// Properties
 		for i := range policy.Props {
@@ -528,6 +548,16 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 			}
 		}
 
+		// Scoring presets are resolved into the scoring of the policy and
+		// its checks
+		policyPreset, err := scoringPresetFromTags(policy.Tags)
+		if err != nil {
+			return nil, sources.Wrap(errors.Wrap(err, "failed to compile policy "+policy.Mrn), policy.Mrn)
+		}
+		if policyPreset != nil {
+			policyPreset.ApplyToPolicy(policy)
+		}
+
 		// Filters: prep a data structure in case it doesn't exist yet and add
 		// any filters that child groups may carry with them
 		if policy.Filters == nil || policy.Filters.Items == nil {
@@ -555,9 +585,11 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 				query.Sanitize()
 
 				// ensure the correct mrn is set
+				uid := query.Uid
 				if err = query.RefreshMRN(ownerMrn); err != nil {
-					return nil, err
+					return nil, sources.Wrap(err, uid, query.Mrn)
 				}
+				sources.alias(uid, query.Mrn)
 
 				for k := range query.Props {
 					if err = p.compileProp(query.Props[k], ownerMrn, lookupProp, uid2mrn, bundles); err != nil {
@@ -576,7 +608,7 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 				_, err := query.RefreshChecksumAndType(lookupProp)
 				if err != nil {
 					log.Error().Err(err).Msg("could not compile the query")
-					warnings = append(warnings, errors.Wrap(err, "failed to validate query '"+query.Mrn+"'"))
+					warnings = append(warnings, sources.Wrap(errors.Wrap(err, "failed to validate query '"+query.Mrn+"'"), query.Mrn))
 				}
 
 				lookupQuery[query.Mrn] = query
@@ -592,9 +624,12 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 				check.Sanitize()
 
 				// ensure the correct mrn is set
+				uid := check.Uid
 				if err = check.RefreshMRN(ownerMrn); err != nil {
-					return nil, err
+					return nil, sources.Wrap(err, uid, check.Mrn)
 				}
+				sources.alias(uid, check.Mrn)
+				checks[check.Mrn] = struct{}{}
 
 				for k := range check.Props {
 					if err = p.compileProp(check.Props[k], ownerMrn, lookupProp, uid2mrn, bundles); err != nil {
@@ -605,15 +640,22 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 				existing, ok := lookupQuery[check.Mrn]
 				if ok {
 					check.Merge(existing)
+					if err = applyScoringPreset(policyPreset, check, existing); err != nil {
+						return nil, sources.Wrap(err, check.Mrn)
+					}
 					check.RefreshChecksumAndType(lookupProp)
 					continue
 				}
 
+				if err = applyScoringPreset(policyPreset, check, nil); err != nil {
+					return nil, sources.Wrap(err, check.Mrn)
+				}
+
 				// recalculate the checksums
 				_, err := check.RefreshChecksumAndType(lookupProp)
 				if err != nil {
 					log.Error().Err(err).Msg("could not compile the query")
-					warnings = append(warnings, errors.Wrap(err, "failed to validate query '"+check.Mrn+"'"))
+					warnings = append(warnings, sources.Wrap(errors.Wrap(err, "failed to validate query '"+check.Mrn+"'"), check.Mrn))
 				}
 
 				lookupQuery[check.Mrn] = check
@@ -624,6 +666,13 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 		}
 	}
 
+	p.warnNondeterministicQueries(ctx, checks)
+
+	// properties only learn their MRNs while being compiled
+	for uid, id := range uid2mrn {
+		sources.alias(uid, id)
+	}
+
 	// cannot be done before all policies and queries have their MRNs set
 	bundleMap := p.ToMap()
 	bundleMap.Library = library
@@ -638,15 +687,24 @@ func (p *Bundle) Compile(ctx context.Context, library Library) (*PolicyBundleMap
 
 		err := translateGroupUIDs(ownerMrn, policy, uid2mrn)
 		if err != nil {
-			return nil, errors.New("failed to validate policy: " + err.Error())
+			return nil, sources.Wrap(errors.New("failed to validate policy: "+err.Error()), policy.Mrn)
 		}
 
 		err = bundleMap.ValidatePolicy(ctx, policy)
 		if err != nil {
-			return nil, errors.New("failed to validate policy: " + err.Error())
+			return nil, sources.Wrap(errors.New("failed to validate policy: "+err.Error()), policy.Mrn)
 		}
 	}
 
+	// dependencies only have MRNs now, so cycles can be reported with their full
+	// path before they fail deep inside resolution
+	if err := bundleMap.DetectCycles(); err != nil {
+		if cycle, ok := err.(*DependencyCycleError); ok {
+			return nil, sources.Wrap(err, cycle.Path[0])
+		}
+		return nil, err
+	}
+
 	if len(warnings) != 0 {
 		var msg strings.Builder
 		for i := range warnings {
@@ -0,0 +1,225 @@
+package policy
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+	"google.golang.org/protobuf/proto"
+)
+
+// BundleBuilder constructs policy bundles in Go, e.g. for tools that
+// generate policies from external data sources. Checks and queries are
+// attached to the policy that was added last. All problems are collected
+// and reported by Build, so calls can be chained:
+//
+//	bundle, err := policy.NewBundleBuilder().
+//		AddPolicy("ssh-policy", "SSH Policy").
+//		AddCheck(&explorer.Mquery{Title: "Disable root login", Mql: "sshd.config.params['PermitRootLogin'] == 'no'"}).
+//		Build(ctx)
+type BundleBuilder struct {
+	bundle  *Bundle
+	current *Policy
+	uids    map[string]struct{}
+	errs    []error
+}
+
+// NewBundleBuilder creates an empty bundle builder
+func NewBundleBuilder() *BundleBuilder {
+	return &BundleBuilder{
+		bundle: &Bundle{},
+		uids:   map[string]struct{}{},
+	}
+}
+
+// OwnerMrn sets the owner of the bundle, which is used to generate the MRNs
+// of all policies and queries. Local bundles don't need an owner.
+func (b *BundleBuilder) OwnerMrn(mrn string) *BundleBuilder {
+	b.bundle.OwnerMrn = mrn
+	return b
+}
+
+// AddPolicy adds a new policy with one group to the bundle. All following
+// checks, queries and policy references are added to this policy. If uid is
+// empty, it is generated from the name.
+func (b *BundleBuilder) AddPolicy(uid string, name string) *BundleBuilder {
+	if name == "" {
+		b.errs = append(b.errs, errors.New("policy '"+uid+"' has no name"))
+	}
+
+	uid = b.claimUID(uid, name, "policy")
+	b.current = &Policy{
+		Uid:     uid,
+		Name:    name,
+		Version: "1.0.0",
+		Groups:  []*PolicyGroup{{}},
+	}
+	b.bundle.Policies = append(b.bundle.Policies, b.current)
+	return b
+}
+
+// Version sets the version of the current policy
+func (b *BundleBuilder) Version(version string) *BundleBuilder {
+	if b.current == nil {
+		b.errs = append(b.errs, errors.New("cannot set version '"+version+"', no policy was added yet"))
+		return b
+	}
+	b.current.Version = version
+	return b
+}
+
+// Filters sets the asset filters of the current policy's group
+func (b *BundleBuilder) Filters(mql ...string) *BundleBuilder {
+	if b.current == nil {
+		b.errs = append(b.errs, errors.New("cannot add filters, no policy was added yet"))
+		return b
+	}
+
+	group := b.current.Groups[0]
+	if group.Filters == nil {
+		group.Filters = &explorer.Filters{Items: map[string]*explorer.Mquery{}}
+	}
+	for i := range mql {
+		group.Filters.Items[mql[i]] = &explorer.Mquery{Mql: mql[i]}
+	}
+	return b
+}
+
+// AddCheck adds a scored check to the bundle and references it from the
+// current policy. If the check has no UID, it is generated from its title.
+func (b *BundleBuilder) AddCheck(check *explorer.Mquery) *BundleBuilder {
+	ref := b.addQuery(check, "check")
+	if ref != nil {
+		b.current.Groups[0].Checks = append(b.current.Groups[0].Checks, ref)
+	}
+	return b
+}
+
+// AddQuery adds a data query to the bundle and references it from the
+// current policy. If the query has no UID, it is generated from its title.
+func (b *BundleBuilder) AddQuery(query *explorer.Mquery) *BundleBuilder {
+	ref := b.addQuery(query, "query")
+	if ref != nil {
+		b.current.Groups[0].Queries = append(b.current.Groups[0].Queries, ref)
+	}
+	return b
+}
+
+// AddPolicyRef references another policy from the current policy. This can
+// either be the UID of a policy in this bundle or the MRN of any policy.
+func (b *BundleBuilder) AddPolicyRef(uidOrMrn string) *BundleBuilder {
+	if b.current == nil {
+		b.errs = append(b.errs, errors.New("cannot add reference to policy '"+uidOrMrn+"', no policy was added yet"))
+		return b
+	}
+
+	ref := &PolicyRef{}
+	if strings.HasPrefix(uidOrMrn, "//") {
+		ref.Mrn = uidOrMrn
+	} else {
+		ref.Uid = uidOrMrn
+	}
+	b.current.Groups[0].Policies = append(b.current.Groups[0].Policies, ref)
+	return b
+}
+
+func (b *BundleBuilder) addQuery(query *explorer.Mquery, kind string) *explorer.Mquery {
+	if query == nil {
+		b.errs = append(b.errs, errors.New("cannot add empty "+kind))
+		return nil
+	}
+	if b.current == nil {
+		b.errs = append(b.errs, errors.New("cannot add "+kind+" '"+query.Title+"', no policy was added yet"))
+		return nil
+	}
+	if query.Mrn != "" {
+		// queries from other bundles are only referenced
+		return &explorer.Mquery{Mrn: query.Mrn, Impact: query.Impact}
+	}
+	if strings.TrimSpace(query.Mql) == "" {
+		b.errs = append(b.errs, errors.New(kind+" '"+query.Title+"' has no MQL"))
+	}
+
+	query = proto.Clone(query).(*explorer.Mquery)
+	query.Uid = b.claimUID(query.Uid, query.Title, kind)
+	b.bundle.Queries = append(b.bundle.Queries, query)
+
+	return &explorer.Mquery{Uid: query.Uid}
+}
+
+var nonUIDChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// claimUID makes sure a UID is unique in the bundle. Empty UIDs are
+// generated from the title, with a numeric suffix if needed.
+func (b *BundleBuilder) claimUID(uid string, title string, kind string) string {
+	if uid != "" {
+		if _, ok := b.uids[uid]; ok {
+			b.errs = append(b.errs, errors.New("duplicate UID '"+uid+"' for "+kind))
+		}
+		b.uids[uid] = struct{}{}
+		return uid
+	}
+
+	base := strings.Trim(nonUIDChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if base == "" {
+		base = kind
+	}
+
+	uid = base
+	for i := 2; ; i++ {
+		if _, ok := b.uids[uid]; !ok {
+			break
+		}
+		uid = base + "-" + strconv.Itoa(i)
+	}
+	b.uids[uid] = struct{}{}
+	return uid
+}
+
+// Build validates and compiles the bundle. The returned bundle has MRNs for
+// all policies and queries and is ready for use with the policy services.
+func (b *BundleBuilder) Build(ctx context.Context) (*Bundle, error) {
+	// references to policies within this bundle must exist
+	for i := range b.bundle.Policies {
+		groups := b.bundle.Policies[i].Groups
+		for j := range groups {
+			for k := range groups[j].Policies {
+				ref := groups[j].Policies[k]
+				if ref.Uid == "" {
+					continue
+				}
+				if !b.hasPolicy(ref.Uid) {
+					b.errs = append(b.errs, errors.New("policy '"+b.bundle.Policies[i].Uid+"' references unknown policy '"+ref.Uid+"'"))
+				}
+			}
+		}
+	}
+
+	if len(b.errs) != 0 {
+		var msg strings.Builder
+		for i := range b.errs {
+			msg.WriteString(b.errs[i].Error())
+			msg.WriteString("\n")
+		}
+		return nil, errors.New("failed to build bundle: " + strings.TrimSuffix(msg.String(), "\n"))
+	}
+
+	bundle := proto.Clone(b.bundle).(*Bundle)
+	if _, err := bundle.Compile(ctx, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to build bundle")
+	}
+
+	return bundle, nil
+}
+
+func (b *BundleBuilder) hasPolicy(uid string) bool {
+	for i := range b.bundle.Policies {
+		if b.bundle.Policies[i].Uid == uid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestBundleBuilder(t *testing.T) {
+	t.Run("builds and compiles a bundle", func(t *testing.T) {
+		bundle, err := NewBundleBuilder().
+			AddPolicy("base", "Base Policy").
+			AddCheck(&explorer.Mquery{Title: "Always true", Mql: "true == true", Impact: &explorer.Impact{Value: 70}}).
+			AddCheck(&explorer.Mquery{Title: "Always true", Mql: "1 == 1"}).
+			AddPolicy("", "Parent Policy").
+			Version("2.1.0").
+			AddQuery(&explorer.Mquery{Uid: "answer", Mql: "42"}).
+			AddPolicyRef("base").
+			Build(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, bundle.Policies, 2)
+		assert.Equal(t, "//local.cnspec.io/run/local-execution/policies/base", bundle.Policies[0].Mrn)
+		assert.Equal(t, "//local.cnspec.io/run/local-execution/policies/parent-policy", bundle.Policies[1].Mrn)
+		assert.Equal(t, "2.1.0", bundle.Policies[1].Version)
+
+		checks := bundle.Policies[0].Groups[0].Checks
+		require.Len(t, checks, 2)
+		assert.Equal(t, "//local.cnspec.io/run/local-execution/queries/always-true", checks[0].Mrn)
+		assert.Equal(t, "//local.cnspec.io/run/local-execution/queries/always-true-2", checks[1].Mrn)
+		assert.NotEmpty(t, checks[0].CodeId)
+
+		refs := bundle.Policies[1].Groups[0].Policies
+		require.Len(t, refs, 1)
+		assert.Equal(t, bundle.Policies[0].Mrn, refs[0].Mrn)
+	})
+
+	t.Run("collects all errors", func(t *testing.T) {
+		_, err := NewBundleBuilder().
+			AddCheck(&explorer.Mquery{Title: "orphan", Mql: "true"}).
+			AddPolicy("p", "Policy").
+			AddCheck(&explorer.Mquery{Uid: "p", Title: "no mql"}).
+			AddPolicyRef("missing").
+			Build(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot add check 'orphan', no policy was added yet")
+		assert.Contains(t, err.Error(), "check 'no mql' has no MQL")
+		assert.Contains(t, err.Error(), "duplicate UID 'p' for check")
+		assert.Contains(t, err.Error(), "policy 'p' references unknown policy 'missing'")
+	})
+
+	t.Run("reports invalid MQL", func(t *testing.T) {
+		_, err := NewBundleBuilder().
+			AddPolicy("p", "Policy").
+			AddCheck(&explorer.Mquery{Title: "broken", Mql: "this is not mql ]"}).
+			Build(context.Background())
+		assert.Error(t, err)
+	})
+}
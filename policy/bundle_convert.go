@@ -0,0 +1,173 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// ConversionIssue is a setting of a deprecated v7 bundle that can't be
+// converted automatically and has to be migrated by hand
+type ConversionIssue struct {
+	// Policy is the UID or MRN of the policy
+	Policy string
+	// Item is the UID or MRN of the query, policy reference or property
+	Item    string
+	Message string
+}
+
+func (i ConversionIssue) String() string {
+	res := i.Message
+	if i.Item != "" {
+		res = i.Item + ": " + res
+	}
+	if i.Policy != "" {
+		res = i.Policy + ": " + res
+	}
+	return res
+}
+
+// BundleConversion is the result of converting a bundle to the current
+// format
+type BundleConversion struct {
+	Bundle *Bundle
+	// Converted is true if the bundle used the deprecated v7 format
+	Converted bool
+	// Issues lists everything that was dropped during the conversion
+	Issues []ConversionIssue
+}
+
+// isDeprecatedV7Bundle returns true if any policy of the bundle uses specs,
+// which only exist in v7
+func isDeprecatedV7Bundle(bundle *DeprecatedV7_Bundle) bool {
+	for i := range bundle.Policies {
+		if bundle.Policies[i].Specs != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertBundle upgrades a deprecated v7 bundle (specs with scoring and data
+// queries) to the current format with groups of checks and queries. Bundles
+// that already use the current format are returned as they are.
+func ConvertBundle(data []byte) (*BundleConversion, error) {
+	var v7 DeprecatedV7_Bundle
+	if err := yaml.Unmarshal(data, &v7); err != nil || !isDeprecatedV7Bundle(&v7) {
+		bundle, err := BundleFromYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		return &BundleConversion{Bundle: bundle}, nil
+	}
+
+	FixZeroValuesInPolicyBundle(&v7)
+	return &BundleConversion{
+		Bundle:    v7.ToV8(),
+		Converted: true,
+		Issues:    deprecatedV7Issues(&v7),
+	}, nil
+}
+
+// deprecatedV7Issues collects all settings that ToV8 drops
+func deprecatedV7Issues(bundle *DeprecatedV7_Bundle) []ConversionIssue {
+	var res []ConversionIssue
+	for _, policy := range bundle.Policies {
+		id := policy.Uid
+		if id == "" {
+			id = policy.Mrn
+		}
+
+		if policy.IsPublic {
+			res = append(res, ConversionIssue{Policy: id, Message: "is_public is not supported anymore"})
+		}
+		for _, key := range sortedKeys(policy.Props) {
+			if policy.Props[key] != "" {
+				res = append(res, ConversionIssue{Policy: id, Item: key, Message: "property target '" + policy.Props[key] + "' is dropped, set the property on the policy instead"})
+			}
+		}
+
+		for i, spec := range policy.Specs {
+			for _, ref := range sortedKeys(spec.Policies) {
+				s := spec.Policies[ref]
+				if s == nil {
+					continue
+				}
+				if s.ScoringSystem != ScoringSystem_SCORING_UNSPECIFIED || s.Weight != 0 || s.WeightIsPercentage || s.Severity != nil {
+					res = append(res, ConversionIssue{Policy: id, Item: ref, Message: "scoring of policy references in spec " + strconv.Itoa(i) + " is dropped, only its action is converted"})
+				}
+			}
+			for _, ref := range sortedKeys(spec.ScoringQueries) {
+				s := spec.ScoringQueries[ref]
+				if s == nil {
+					continue
+				}
+				if s.WeightIsPercentage {
+					res = append(res, ConversionIssue{Policy: id, Item: ref, Message: "weights in percent are not supported, the weight is used as it is"})
+				}
+				if s.Severity != nil {
+					res = append(res, ConversionIssue{Policy: id, Item: ref, Message: "severity override in spec " + strconv.Itoa(i) + " is dropped, set the impact of the check instead"})
+				}
+			}
+		}
+	}
+	return res
+}
+
+// BundleFileConversion is the conversion of one bundle file
+type BundleFileConversion struct {
+	// Path of the bundle file, relative to the converted directory
+	Path string
+	*BundleConversion
+}
+
+// ConvertBundleDir converts all bundle files (*.mql.yaml) in a directory and
+// its subdirectories. Converted bundles are written to outDir with the same
+// relative path, or replace the original files if outDir is empty. Bundles
+// that already use the current format are not written.
+func ConvertBundleDir(dir string, outDir string) ([]*BundleFileConversion, error) {
+	files, err := WalkPolicyBundleFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if outDir == "" {
+		outDir = dir
+	}
+
+	res := make([]*BundleFileConversion, 0, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read bundle "+file)
+		}
+		conversion, err := ConvertBundle(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert bundle "+file)
+		}
+		res = append(res, &BundleFileConversion{Path: rel, BundleConversion: conversion})
+		if !conversion.Converted {
+			continue
+		}
+
+		out, err := conversion.Bundle.ToYAML()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode converted bundle "+file)
+		}
+		target := filepath.Join(outDir, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, out, 0o644); err != nil {
+			return nil, errors.Wrap(err, "failed to write converted bundle "+target)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertBundle(t *testing.T) {
+	data, err := os.ReadFile("../examples/example.deprecated_v7.mql.yaml")
+	require.NoError(t, err)
+
+	conversion, err := ConvertBundle(data)
+	require.NoError(t, err)
+	assert.True(t, conversion.Converted)
+	assert.Empty(t, conversion.Issues)
+
+	bundle := conversion.Bundle
+	require.Len(t, bundle.Policies, 1)
+	require.Len(t, bundle.Policies[0].Groups, 1)
+	group := bundle.Policies[0].Groups[0]
+	assert.Len(t, group.Checks, 3)
+	assert.Len(t, group.Queries, 1)
+	assert.Equal(t, "sshd-d-1", group.Queries[0].Uid)
+	require.NotNil(t, group.Filters)
+	assert.Len(t, group.Filters.Items, 1)
+	assert.Len(t, bundle.Queries, 4)
+
+	// converted bundles are current and stay as they are
+	raw, err := bundle.ToYAML()
+	require.NoError(t, err)
+	again, err := ConvertBundle(raw)
+	require.NoError(t, err)
+	assert.False(t, again.Converted)
+	assert.Len(t, again.Bundle.Policies[0].Groups, 1)
+}
+
+func TestConvertBundle_Issues(t *testing.T) {
+	conversion, err := ConvertBundle([]byte(`
+policies:
+  - uid: legacy
+    is_public: true
+    props:
+      home: "//props/home"
+      user: ""
+    specs:
+      - policies:
+          child:
+            action: 1
+            weight: 2
+        scoring_queries:
+          check-1:
+            action: 2
+            weight: 50
+            weight_is_percentage: true
+            severity: 80
+          check-2:
+queries:
+  - uid: check-1
+    query: "true"
+  - uid: check-2
+    query: "true"
+`))
+	require.NoError(t, err)
+	assert.True(t, conversion.Converted)
+
+	var issues []string
+	for _, issue := range conversion.Issues {
+		issues = append(issues, issue.String())
+	}
+	assert.Equal(t, []string{
+		"legacy: is_public is not supported anymore",
+		"legacy: home: property target '//props/home' is dropped, set the property on the policy instead",
+		"legacy: child: scoring of policy references in spec 0 is dropped, only its action is converted",
+		"legacy: check-1: weights in percent are not supported, the weight is used as it is",
+		"legacy: check-1: severity override in spec 0 is dropped, set the impact of the check instead",
+	}, issues)
+}
+
+func TestConvertBundleDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"example.deprecated_v7.mql.yaml", "example.mql.yaml"} {
+		data, err := os.ReadFile(filepath.Join("../examples", name))
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", name), data, 0o644))
+	}
+
+	out := t.TempDir()
+	conversions, err := ConvertBundleDir(dir, out)
+	require.NoError(t, err)
+	require.Len(t, conversions, 2)
+
+	converted := map[string]bool{}
+	for _, c := range conversions {
+		converted[c.Path] = c.Converted
+	}
+	assert.Equal(t, map[string]bool{
+		filepath.Join("nested", "example.deprecated_v7.mql.yaml"): true,
+		filepath.Join("nested", "example.mql.yaml"):               false,
+	}, converted)
+
+	bundle, err := BundleFromPaths(filepath.Join(out, "nested", "example.deprecated_v7.mql.yaml"))
+	require.NoError(t, err)
+	assert.Len(t, bundle.Policies[0].Groups, 1)
+	_, err = os.Stat(filepath.Join(out, "nested", "example.mql.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,234 @@
+package policy
+
+import (
+	"sort"
+
+	"go.mondoo.com/cnquery/explorer"
+	"google.golang.org/protobuf/proto"
+)
+
+// ChangeKind describes how an element changed between two bundles
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// BundleChange is one policy, query or property that differs between two bundles
+type BundleChange struct {
+	Kind ChangeKind
+	// ID is the MRN of the element, or its UID if the bundle isn't compiled
+	ID string
+	// ExecutionChanged is true if the change affects execution checksums,
+	// i.e. assets have to be scanned again to get new results. Added and
+	// removed elements always change execution.
+	ExecutionChanged bool
+	// ContentChanged is true if the change affects content checksums, e.g.
+	// for changes of titles or docs. Execution changes always change content.
+	ContentChanged bool
+}
+
+// BundleDiff lists all differences between two bundles, sorted by ID
+type BundleDiff struct {
+	Policies []BundleChange
+	Checks   []BundleChange
+	Queries  []BundleChange
+	Props    []BundleChange
+}
+
+// IsEmpty returns true if both bundles are the same
+func (d *BundleDiff) IsEmpty() bool {
+	return len(d.Policies) == 0 && len(d.Checks) == 0 && len(d.Queries) == 0 && len(d.Props) == 0
+}
+
+// AffectsExecution returns true if any change requires assets to be scanned again
+func (d *BundleDiff) AffectsExecution() bool {
+	for _, changes := range [][]BundleChange{d.Policies, d.Checks, d.Queries, d.Props} {
+		for i := range changes {
+			if changes[i].ExecutionChanged {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DiffBundles computes the differences between two bundles, e.g. to review
+// a policy update before rolling it out. Bundles should be compiled, so
+// that changes can be split into execution and content changes by their
+// checksums. For bundles that aren't compiled, every change is treated as
+// an execution change if its MQL changed.
+func DiffBundles(old *Bundle, new *Bundle) *BundleDiff {
+	if old == nil {
+		old = &Bundle{}
+	}
+	if new == nil {
+		new = &Bundle{}
+	}
+
+	res := &BundleDiff{}
+
+	oldPolicies, newPolicies := map[string]*Policy{}, map[string]*Policy{}
+	for _, p := range old.Policies {
+		oldPolicies[elementID(p.Mrn, p.Uid)] = p
+	}
+	for _, p := range new.Policies {
+		newPolicies[elementID(p.Mrn, p.Uid)] = p
+	}
+	for _, id := range unionKeys(oldPolicies, newPolicies) {
+		if change, ok := diffPolicy(id, oldPolicies[id], newPolicies[id]); ok {
+			res.Policies = append(res.Policies, change)
+		}
+	}
+
+	oldQueries, newQueries := map[string]*explorer.Mquery{}, map[string]*explorer.Mquery{}
+	for _, q := range old.Queries {
+		oldQueries[elementID(q.Mrn, q.Uid)] = q
+	}
+	for _, q := range new.Queries {
+		newQueries[elementID(q.Mrn, q.Uid)] = q
+	}
+	checks := bundleCheckIDs(old)
+	for id := range bundleCheckIDs(new) {
+		checks[id] = struct{}{}
+	}
+	for _, id := range unionKeys(oldQueries, newQueries) {
+		change, ok := diffQuery(id, oldQueries[id], newQueries[id])
+		if !ok {
+			continue
+		}
+		if _, isCheck := checks[id]; isCheck {
+			res.Checks = append(res.Checks, change)
+		} else {
+			res.Queries = append(res.Queries, change)
+		}
+	}
+
+	oldProps, newProps := bundleProps(old), bundleProps(new)
+	for _, id := range unionKeys(oldProps, newProps) {
+		if change, ok := diffProp(id, oldProps[id], newProps[id]); ok {
+			res.Props = append(res.Props, change)
+		}
+	}
+
+	return res
+}
+
+func elementID(mrn string, uid string) string {
+	if mrn != "" {
+		return mrn
+	}
+	return uid
+}
+
+func unionKeys[T any](a map[string]T, b map[string]T) []string {
+	res := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		res = append(res, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			res = append(res, k)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// bundleCheckIDs returns the IDs of all queries that are used as checks
+func bundleCheckIDs(bundle *Bundle) map[string]struct{} {
+	res := map[string]struct{}{}
+	for _, p := range bundle.Policies {
+		for _, group := range p.Groups {
+			for _, check := range group.Checks {
+				res[elementID(check.Mrn, check.Uid)] = struct{}{}
+			}
+		}
+	}
+	return res
+}
+
+// bundleProps collects the properties of the bundle and all its policies
+func bundleProps(bundle *Bundle) map[string]*explorer.Property {
+	res := map[string]*explorer.Property{}
+	for _, prop := range bundle.Props {
+		res[elementID(prop.Mrn, prop.Uid)] = prop
+	}
+	for _, p := range bundle.Policies {
+		for _, prop := range p.Props {
+			res[elementID(prop.Mrn, prop.Uid)] = prop
+		}
+	}
+	return res
+}
+
+// checksumChanged compares checksums if both are set and falls back to the
+// given comparison otherwise
+func checksumChanged(a string, b string, fallback func() bool) bool {
+	if a != "" && b != "" {
+		return a != b
+	}
+	return fallback()
+}
+
+func addedOrRemoved(id string, isOld bool, isNew bool) (BundleChange, bool) {
+	switch {
+	case isOld && !isNew:
+		return BundleChange{Kind: ChangeRemoved, ID: id, ExecutionChanged: true, ContentChanged: true}, true
+	case !isOld && isNew:
+		return BundleChange{Kind: ChangeAdded, ID: id, ExecutionChanged: true, ContentChanged: true}, true
+	default:
+		return BundleChange{}, false
+	}
+}
+
+func changed(id string, execution bool, content bool) (BundleChange, bool) {
+	if !execution && !content {
+		return BundleChange{}, false
+	}
+	return BundleChange{Kind: ChangeChanged, ID: id, ExecutionChanged: execution, ContentChanged: content || execution}, true
+}
+
+func diffPolicy(id string, old *Policy, new *Policy) (BundleChange, bool) {
+	if old == nil || new == nil {
+		return addedOrRemoved(id, old != nil, new != nil)
+	}
+
+	execution := checksumChanged(old.GraphExecutionChecksum, new.GraphExecutionChecksum, func() bool {
+		return !proto.Equal(&Policy{Groups: old.Groups, Props: old.Props}, &Policy{Groups: new.Groups, Props: new.Props})
+	})
+	content := checksumChanged(old.GraphContentChecksum, new.GraphContentChecksum, func() bool {
+		return !proto.Equal(old, new)
+	})
+	return changed(id, execution, content)
+}
+
+func diffQuery(id string, old *explorer.Mquery, new *explorer.Mquery) (BundleChange, bool) {
+	if old == nil || new == nil {
+		return addedOrRemoved(id, old != nil, new != nil)
+	}
+
+	execution := checksumChanged(old.CodeId, new.CodeId, func() bool {
+		return old.Mql != new.Mql
+	})
+	content := checksumChanged(old.Checksum, new.Checksum, func() bool {
+		return !proto.Equal(old, new)
+	})
+	return changed(id, execution, content)
+}
+
+func diffProp(id string, old *explorer.Property, new *explorer.Property) (BundleChange, bool) {
+	if old == nil || new == nil {
+		return addedOrRemoved(id, old != nil, new != nil)
+	}
+
+	execution := checksumChanged(old.CodeId, new.CodeId, func() bool {
+		return old.Mql != new.Mql
+	})
+	content := checksumChanged(old.Checksum, new.Checksum, func() bool {
+		return !proto.Equal(old, new)
+	})
+	return changed(id, execution, content)
+}
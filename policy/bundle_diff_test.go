@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestDiffBundles(t *testing.T) {
+	old := &Bundle{
+		Policies: []*Policy{{
+			Uid:  "policy",
+			Name: "Policy",
+			Groups: []*PolicyGroup{{
+				Checks: []*explorer.Mquery{{Uid: "check-1"}, {Uid: "check-2"}},
+			}},
+			Props: []*explorer.Property{{Uid: "prop", Mql: "1"}},
+		}, {
+			Uid: "removed-policy",
+		}},
+		Queries: []*explorer.Mquery{
+			{Uid: "check-1", Mql: "true", Title: "Check 1"},
+			{Uid: "check-2", Mql: "true", Title: "Check 2"},
+			{Uid: "query", Mql: "asset.name"},
+		},
+	}
+	new := &Bundle{
+		Policies: []*Policy{{
+			Uid:  "policy",
+			Name: "Policy",
+			Groups: []*PolicyGroup{{
+				Checks: []*explorer.Mquery{{Uid: "check-1"}, {Uid: "check-2"}},
+			}},
+			Props: []*explorer.Property{{Uid: "prop", Mql: "2"}},
+		}},
+		Queries: []*explorer.Mquery{
+			{Uid: "check-1", Mql: "false", Title: "Check 1"},
+			{Uid: "check-2", Mql: "true", Title: "Check 2 renamed"},
+			{Uid: "query", Mql: "asset.name"},
+			{Uid: "new-query", Mql: "asset.platform"},
+		},
+	}
+
+	diff := DiffBundles(old, new)
+	assert.False(t, diff.IsEmpty())
+	assert.True(t, diff.AffectsExecution())
+
+	assert.Equal(t, []BundleChange{
+		{Kind: ChangeChanged, ID: "policy", ExecutionChanged: true, ContentChanged: true},
+		{Kind: ChangeRemoved, ID: "removed-policy", ExecutionChanged: true, ContentChanged: true},
+	}, diff.Policies)
+	assert.Equal(t, []BundleChange{
+		{Kind: ChangeChanged, ID: "check-1", ExecutionChanged: true, ContentChanged: true},
+		{Kind: ChangeChanged, ID: "check-2", ExecutionChanged: false, ContentChanged: true},
+	}, diff.Checks)
+	assert.Equal(t, []BundleChange{
+		{Kind: ChangeAdded, ID: "new-query", ExecutionChanged: true, ContentChanged: true},
+	}, diff.Queries)
+	assert.Equal(t, []BundleChange{
+		{Kind: ChangeChanged, ID: "prop", ExecutionChanged: true, ContentChanged: true},
+	}, diff.Props)
+
+	t.Run("same bundle", func(t *testing.T) {
+		diff := DiffBundles(old, old)
+		assert.True(t, diff.IsEmpty())
+		assert.False(t, diff.AffectsExecution())
+	})
+
+	t.Run("compiled checksums", func(t *testing.T) {
+		old := &Bundle{Queries: []*explorer.Mquery{{Mrn: "//query", Mql: "true", CodeId: "code", Checksum: "a"}}}
+		new := &Bundle{Queries: []*explorer.Mquery{{Mrn: "//query", Mql: "true  ", CodeId: "code", Checksum: "b"}}}
+		diff := DiffBundles(old, new)
+		require.Len(t, diff.Queries, 1)
+		assert.Equal(t, BundleChange{Kind: ChangeChanged, ID: "//query", ContentChanged: true}, diff.Queries[0])
+		assert.False(t, diff.AffectsExecution())
+	})
+}
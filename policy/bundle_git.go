@@ -0,0 +1,207 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	neturl "net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// GitScheme prefixes references to policies in git repositories, e.g.
+// git+https://github.com/org/policies.git@v1#policies, see
+// ParseGitReference
+const GitScheme = "git+"
+
+// GitOptions configures how bundles are fetched from git repositories
+type GitOptions struct {
+	// CacheDir keeps a clone per repository, so that later fetches only
+	// transfer what changed. Defaults to a directory in the user's cache dir.
+	CacheDir string
+	// SSHKey is the path to a private key for ssh remotes. Without it, git
+	// uses the ssh agent and the user's ssh config.
+	SSHKey string
+	// Username and Password are used for https remotes, e.g. with an access
+	// token as password. Without them, git uses its credential helpers.
+	Username string
+	Password string
+}
+
+// BundleFromGit fetches a git repository and loads all *.mql.yaml policies
+// below subdir. The ref is a branch, tag or commit and defaults to the
+// default branch of the remote. Only the requested ref is fetched, without
+// history. Authentication is handled by the git binary, see GitOptions.
+func BundleFromGit(ctx context.Context, url string, ref string, subdir string, opts GitOptions) (*Bundle, error) {
+	if url == "" {
+		return nil, errors.New("git url is required")
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+	// both are passed to git as arguments and must not be read as options
+	if strings.HasPrefix(url, "-") {
+		return nil, errors.New("invalid git url '" + url + "'")
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, errors.New("invalid git ref '" + ref + "'")
+	}
+
+	cacheDir := opts.CacheDir
+	if cacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find cache dir for git repositories")
+		}
+		cacheDir = filepath.Join(dir, "cnspec", "git")
+	}
+
+	sum := sha256.Sum256([]byte(url))
+	repoDir := filepath.Join(cacheDir, hex.EncodeToString(sum[:8]))
+
+	root := filepath.Clean(repoDir)
+	path := filepath.Join(root, subdir)
+	if path != root && !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return nil, errors.New("subdir '" + subdir + "' is outside of the repository")
+	}
+
+	// the clone is checked out and read as a whole, so concurrent fetches of
+	// the same repository must not interleave
+	lock := gitRepoLock(repoDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	git := gitRunner{dir: repoDir, env: opts.env()}
+	if _, err := os.Stat(filepath.Join(repoDir, ".git")); err != nil {
+		if err := os.MkdirAll(repoDir, 0o700); err != nil {
+			return nil, errors.Wrap(err, "failed to create cache dir for git repository")
+		}
+		if err := git.run(ctx, "init", "-q"); err != nil {
+			return nil, err
+		}
+		if err := git.run(ctx, "remote", "add", "--", "origin", url); err != nil {
+			return nil, err
+		}
+	} else if err := git.run(ctx, "remote", "set-url", "--", "origin", url); err != nil {
+		return nil, err
+	}
+
+	log.Debug().Str("url", url).Str("ref", ref).Msg("fetch policies from git repository")
+	if err := git.run(ctx, "fetch", "-q", "--depth", "1", "--no-tags", "--", "origin", ref); err != nil {
+		return nil, err
+	}
+	if err := git.run(ctx, "checkout", "-q", "--force", "--detach", "FETCH_HEAD"); err != nil {
+		return nil, err
+	}
+
+	bundle, err := BundleFromPaths(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load policies from git repository '"+url+"'")
+	}
+	return bundle, nil
+}
+
+// IsGitReference returns true if the path refers to policies in a git
+// repository, see ParseGitReference
+func IsGitReference(path string) bool {
+	return strings.HasPrefix(path, GitScheme)
+}
+
+// ParseGitReference splits a git reference of the form
+// git+<url>[@<ref>][#<subdir>] into the arguments of BundleFromGit, e.g.
+// git+ssh://git@github.com/org/policies.git@v1#policies
+func ParseGitReference(reference string) (url string, ref string, subdir string, err error) {
+	u, err := neturl.Parse(strings.TrimPrefix(reference, GitScheme))
+	if err != nil {
+		return "", "", "", errors.Wrap(err, "invalid git reference '"+reference+"'")
+	}
+	if u.Scheme == "" || (u.Host == "" && u.Scheme != "file") {
+		return "", "", "", errors.New("invalid git reference '" + reference + "', expected e.g. git+https://github.com/org/policies.git@v1")
+	}
+
+	subdir = u.Fragment
+	u.Fragment = ""
+	u.RawFragment = ""
+	// the user of ssh urls is before the host, so the ref is only looked
+	// for in the path
+	if idx := strings.LastIndex(u.Path, "@"); idx != -1 {
+		ref = u.Path[idx+1:]
+		u.Path = u.Path[:idx]
+		u.RawPath = ""
+	}
+	return u.String(), ref, subdir, nil
+}
+
+// BundleFromGitReference loads policies from a git reference, see
+// ParseGitReference and BundleFromGit
+func BundleFromGitReference(ctx context.Context, reference string, opts GitOptions) (*Bundle, error) {
+	url, ref, subdir, err := ParseGitReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	return BundleFromGit(ctx, url, ref, subdir, opts)
+}
+
+var gitRepoLocks sync.Map // repo dir => *sync.Mutex
+
+func gitRepoLock(repoDir string) *sync.Mutex {
+	lock, _ := gitRepoLocks.LoadOrStore(repoDir, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// shellQuote quotes s as a single word for sh, which git uses to run
+// GIT_SSH_COMMAND
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// env passes credentials to git via environment variables, so that they
+// don't show up in the process list
+func (o GitOptions) env() []string {
+	res := []string{"GIT_TERMINAL_PROMPT=0"}
+	if o.SSHKey != "" {
+		res = append(res, "GIT_SSH_COMMAND=ssh -i "+shellQuote(o.SSHKey)+" -o IdentitiesOnly=yes")
+	}
+	if o.Password != "" {
+		username := o.Username
+		if username == "" {
+			username = "git"
+		}
+		auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + o.Password))
+		res = append(res,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+auth,
+		)
+	}
+	return res
+}
+
+type gitRunner struct {
+	dir string
+	env []string
+}
+
+func (g gitRunner) run(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.dir
+	cmd.Env = append(os.Environ(), g.env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return errors.New("git " + args[0] + " failed: " + msg)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	remote := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = remote
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	git("init", "-q")
+	require.NoError(t, os.MkdirAll(filepath.Join(remote, "policies"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(remote, "policies", "oci.mql.yaml"), []byte(ociTestBundle), 0o644))
+	git("add", "-A")
+	git("commit", "-q", "-m", "add policy")
+	git("tag", "v1")
+
+	ctx := context.Background()
+	opts := GitOptions{CacheDir: t.TempDir()}
+
+	bundle, err := BundleFromGit(ctx, "file://"+remote, "v1", "policies", opts)
+	require.NoError(t, err)
+	require.Len(t, bundle.Policies, 1)
+	assert.Equal(t, "oci-policy", bundle.Policies[0].Uid)
+
+	t.Run("fetches updates into the cached clone", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(remote, "policies", "oci.mql.yaml")))
+		git("commit", "-q", "-a", "-m", "remove policy")
+
+		_, err := BundleFromGit(ctx, "file://"+remote, "", "policies", opts)
+		assert.Error(t, err)
+
+		bundle, err := BundleFromGit(ctx, "file://"+remote, "v1", "", opts)
+		require.NoError(t, err)
+		assert.Len(t, bundle.Policies, 1)
+	})
+
+	t.Run("subdir must be in the repository", func(t *testing.T) {
+		_, err := BundleFromGit(ctx, "file://"+remote, "v1", "../..", opts)
+		assert.Error(t, err)
+	})
+	t.Run("refs and urls must not be options", func(t *testing.T) {
+		_, err := BundleFromGit(ctx, "file://"+remote, "--upload-pack=touch /tmp/pwned", "", opts)
+		assert.ErrorContains(t, err, "invalid git ref")
+		_, err = BundleFromGit(ctx, "--upload-pack=touch /tmp/pwned", "v1", "", opts)
+		assert.ErrorContains(t, err, "invalid git url")
+	})
+
+	t.Run("concurrent fetches of the same repository", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make([]error, 8)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = BundleFromGit(ctx, "file://"+remote, "v1", "policies", opts)
+			}(i)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("git references in policy paths", func(t *testing.T) {
+		bundle, _, err := BundleFromPathsOrOCI(ctx, OCIOptions{}, "git+file://"+remote+"@v1#policies")
+		require.NoError(t, err)
+		require.Len(t, bundle.Policies, 1)
+		assert.Equal(t, "oci-policy", bundle.Policies[0].Uid)
+	})
+}
+
+func TestParseGitReference(t *testing.T) {
+	tests := []struct {
+		reference string
+		url       string
+		ref       string
+		subdir    string
+	}{
+		{"git+https://github.com/org/policies.git", "https://github.com/org/policies.git", "", ""},
+		{"git+https://github.com/org/policies.git@v1", "https://github.com/org/policies.git", "v1", ""},
+		{"git+https://github.com/org/policies.git@main#linux/ssh", "https://github.com/org/policies.git", "main", "linux/ssh"},
+		{"git+ssh://git@github.com/org/policies.git#policies", "ssh://git@github.com/org/policies.git", "", "policies"},
+		{"git+ssh://git@github.com/org/policies.git@v1", "ssh://git@github.com/org/policies.git", "v1", ""},
+		{"git+file:///srv/policies@v1", "file:///srv/policies", "v1", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			assert.True(t, IsGitReference(test.reference))
+			url, ref, subdir, err := ParseGitReference(test.reference)
+			require.NoError(t, err)
+			assert.Equal(t, test.url, url)
+			assert.Equal(t, test.ref, ref)
+			assert.Equal(t, test.subdir, subdir)
+		})
+	}
+
+	for _, reference := range []string{"git+github.com/org/policies", "git+https:///policies"} {
+		_, _, _, err := ParseGitReference(reference)
+		assert.Error(t, err, reference)
+	}
+	assert.False(t, IsGitReference("oci://ghcr.io/org/policies:v1"))
+}
+
+func TestGitOptionsSSHKey(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not installed")
+	}
+
+	key := "/home/o'brien/keys/id $(whoami)"
+	env := GitOptions{SSHKey: key}.env()
+	require.Len(t, env, 2)
+	cmd := strings.TrimPrefix(env[1], "GIT_SSH_COMMAND=")
+
+	// git runs the command with sh, which must see the path as one word
+	out, err := exec.Command("sh", "-c", "printf '%s\\n' "+strings.TrimPrefix(cmd, "ssh ")).Output()
+	require.NoError(t, err)
+	assert.Equal(t, "-i\n"+key+"\n-o\nIdentitiesOnly=yes\n", string(out))
+}
@@ -0,0 +1,170 @@
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DefaultHTTPBundleRetries = 3
+	DefaultHTTPBundleBackoff = 1 * time.Second
+)
+
+// HTTPBundleSource loads a bundle from an HTTP(S) URL. It remembers the
+// ETag and Last-Modified of the last response and sends conditional
+// requests, so that refreshing an unchanged bundle neither transfers nor
+// parses it again. It is safe for concurrent use.
+type HTTPBundleSource struct {
+	URL string
+	// Checksum pins the SHA256 of the bundle file, in hex. It is taken from
+	// URLs with a #sha256=<hex> fragment.
+	Checksum string
+	// Client defaults to http.DefaultClient
+	Client *http.Client
+	// Retries is the number of retries after network errors and server
+	// errors, defaults to DefaultHTTPBundleRetries
+	Retries int
+	// Backoff is the wait before the first retry, it doubles with every
+	// retry. Defaults to DefaultHTTPBundleBackoff.
+	Backoff time.Duration
+
+	lock         sync.Mutex
+	etag         string
+	lastModified string
+	// fetched is set once a bundle was returned, later requests are
+	// conditional
+	fetched bool
+}
+
+// NewHTTPBundleSource creates a source for the bundle at the given URL. A
+// #sha256=<hex> fragment in the URL pins the checksum of the bundle.
+func NewHTTPBundleSource(url string) *HTTPBundleSource {
+	res := &HTTPBundleSource{
+		URL: url,
+		Client: &http.Client{
+			CheckRedirect: func(r *http.Request, via []*http.Request) error {
+				r.URL.Opaque = r.URL.Path
+				return nil
+			},
+		},
+		Retries: DefaultHTTPBundleRetries,
+		Backoff: DefaultHTTPBundleBackoff,
+	}
+	if idx := strings.LastIndex(url, "#sha256="); idx != -1 {
+		res.URL = url[:idx]
+		res.Checksum = strings.ToLower(url[idx+len("#sha256="):])
+	}
+	return res
+}
+
+// Fetch returns the bundle and whether it changed since the last fetch. An
+// unchanged bundle is not parsed again and nil is returned, so callers keep
+// the bundle of the earlier fetch. Returned bundles belong to the caller,
+// which may modify them, e.g. to compile them.
+func (s *HTTPBundleSource) Fetch(ctx context.Context) (*Bundle, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = DefaultHTTPBundleBackoff
+	}
+
+	var bundle *Bundle
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		bundle, retry, err = s.fetch(ctx)
+		if err == nil || !retry || attempt >= s.Retries {
+			break
+		}
+
+		log.Debug().Err(err).Str("url", s.URL).Int("attempt", attempt+1).Msg("retry fetching policy bundle")
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, false, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if bundle == nil {
+		return nil, false, nil
+	}
+
+	s.fetched = true
+	return bundle, true, nil
+}
+
+// fetch sends one conditional request and returns the bundle if it
+// changed, and for errors whether the request may be retried
+func (s *HTTPBundleSource) fetch(ctx context.Context) (*Bundle, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to set up request to fetch bundle")
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; cnquery/1.0; +http://www.mondoo.com)")
+	if s.fetched {
+		if s.etag != "" {
+			req.Header.Set("If-None-Match", s.etag)
+		}
+		if s.lastModified != "" {
+			req.Header.Set("If-Modified-Since", s.lastModified)
+		}
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && s.fetched:
+		return nil, false, nil
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return nil, true, errors.New("failed to fetch policy bundle from " + s.URL + ": " + resp.Status)
+	default:
+		return nil, false, errors.New("failed to fetch policy bundle from " + s.URL + ": " + resp.Status)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if s.Checksum != "" {
+		sum := sha256.Sum256(raw)
+		if checksum := hex.EncodeToString(sum[:]); checksum != s.Checksum {
+			return nil, false, errors.New("checksum of policy bundle from " + s.URL + " doesn't match, expected " + s.Checksum + ", got " + checksum)
+		}
+	}
+
+	bundle, err := BundleFromYAML(raw)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to load policy bundle from "+s.URL)
+	}
+
+	s.etag = resp.Header.Get("ETag")
+	s.lastModified = resp.Header.Get("Last-Modified")
+	log.Debug().Str("url", s.URL).Int("size", len(raw)).Msg("fetched policy bundle")
+	return bundle, false, nil
+}
@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPBundleSource(t *testing.T) {
+	var requests, failures int32
+	etag := `"v1"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failures) > 0 {
+			atomic.AddInt32(&failures, -1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(ociTestBundle))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	source := NewHTTPBundleSource(server.URL)
+	source.Backoff = time.Millisecond
+
+	bundle, changed, err := source.Fetch(ctx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.Len(t, bundle.Policies, 1)
+
+	t.Run("unchanged bundles are not fetched again", func(t *testing.T) {
+		bundle, changed, err := source.Fetch(ctx)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Nil(t, bundle)
+	})
+
+	t.Run("server errors are retried", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		atomic.StoreInt32(&failures, 2)
+		_, _, err := source.Fetch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+		atomic.StoreInt32(&failures, 10)
+		_, _, err = source.Fetch(ctx)
+		assert.Error(t, err)
+		atomic.StoreInt32(&failures, 0)
+	})
+
+	t.Run("checksums are verified", func(t *testing.T) {
+		sum := sha256.Sum256([]byte(ociTestBundle))
+		source := NewHTTPBundleSource(server.URL + "#sha256=" + hex.EncodeToString(sum[:]))
+		assert.Equal(t, server.URL, source.URL)
+		_, _, err := source.Fetch(ctx)
+		require.NoError(t, err)
+
+		source = NewHTTPBundleSource(server.URL + "#sha256=0000")
+		_, _, err = source.Fetch(ctx)
+		assert.Error(t, err)
+	})
+}
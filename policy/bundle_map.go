@@ -124,6 +124,10 @@ func (p *PolicyBundleMap) ToList() *Bundle {
 // PoliciesSortedByDependency sorts policies by their dependencies
 // note: the MRN field must be set and dependencies in groups must be specified by MRN
 func (p *PolicyBundleMap) PoliciesSortedByDependency() ([]*Policy, error) {
+	if err := p.DetectCycles(); err != nil {
+		return nil, err
+	}
+
 	indexer := map[string]struct{}{}
 	var res []*Policy
 
@@ -194,6 +198,10 @@ func (p *PolicyBundleMap) ValidatePolicy(ctx context.Context, policy *Policy) er
 		}
 	}
 
+	if _, err := PolicyPriority(policy); err != nil {
+		return err
+	}
+
 	// semver checks are a bit optional
 	if policy.Version != "" {
 		_, err := version.NewSemver(policy.Version)
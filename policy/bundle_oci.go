@@ -0,0 +1,261 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// OCIScheme prefixes references to policy bundles in OCI registries,
+	// e.g. oci://ghcr.io/org/policies:v1
+	OCIScheme = "oci://"
+	// OCIBundleMediaType is the media type of image layers that contain a
+	// policy bundle in YAML
+	OCIBundleMediaType = "application/vnd.mondoo.cnspec.bundle.v1+yaml"
+
+	ociTitleAnnotation = "org.opencontainers.image.title"
+)
+
+// OCIOptions configures how bundles are fetched from OCI registries
+type OCIOptions struct {
+	// CacheDir keeps fetched bundles by the digest of their manifest.
+	// Defaults to a directory in the user's cache dir.
+	CacheDir string
+	// NoCache disables the cache
+	NoCache bool
+	// Keychain provides credentials for registries. Defaults to the docker
+	// config and its credential helpers.
+	Keychain authn.Keychain
+	// Insecure allows registries that use plain HTTP
+	Insecure bool
+}
+
+// IsOCIReference returns true if the path refers to a bundle in an OCI
+// registry, see BundleFromOCI
+func IsOCIReference(path string) bool {
+	return strings.HasPrefix(path, OCIScheme)
+}
+
+// BundleFromOCI pulls a policy bundle from an OCI registry. The reference is
+// either a tag or a digest, with or without the oci:// prefix. Bundles are
+// all image layers with the OCIBundleMediaType, or whose title annotation
+// ends in .mql.yaml (e.g. as pushed by oras). The digests of the manifest
+// and of every layer are verified, and bundles are cached by the digest of
+// their manifest, so that pinned references work offline.
+func BundleFromOCI(ctx context.Context, reference string, opts OCIOptions) (*Bundle, error) {
+	var nameOpts []name.Option
+	if opts.Insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	ref, err := name.ParseReference(strings.TrimPrefix(reference, OCIScheme), nameOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid OCI reference '"+reference+"'")
+	}
+
+	keychain := opts.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	remoteOpts := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}
+
+	cacheDir := opts.CacheDir
+	if cacheDir == "" && !opts.NoCache {
+		if dir, err := os.UserCacheDir(); err == nil {
+			cacheDir = filepath.Join(dir, "cnspec", "bundles")
+		}
+	}
+
+	// only pinned references can skip the registry, tags may have moved
+	var digest string
+	if d, ok := ref.(name.Digest); ok {
+		digest = d.DigestStr()
+	} else {
+		desc, err := remote.Head(ref, remoteOpts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to look up OCI reference '"+reference+"'")
+		}
+		digest = desc.Digest.String()
+	}
+
+	if !opts.NoCache && cacheDir != "" {
+		if bundle, ok := cachedOCIBundle(cacheDir, digest); ok {
+			log.Debug().Str("reference", reference).Str("digest", digest).Msg("using cached policy bundle")
+			return bundle, nil
+		}
+	}
+
+	img, err := remote.Image(ref, remoteOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch OCI reference '"+reference+"'")
+	}
+	imgDigest, err := img.Digest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute digest of '"+reference+"'")
+	}
+	if imgDigest.String() != digest {
+		return nil, errors.New("digest of '" + reference + "' doesn't match, expected " + digest + ", got " + imgDigest.String())
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest of '"+reference+"'")
+	}
+
+	res := &Bundle{}
+	found := false
+	for _, desc := range manifest.Layers {
+		if !isOCIBundleLayer(desc) {
+			continue
+		}
+		data, err := fetchOCILayer(img, desc)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch policy bundle from '"+reference+"'")
+		}
+		bundle, err := BundleFromYAML(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load policy bundle from '"+reference+"'")
+		}
+		res = aggregateBundles(res, bundle)
+		found = true
+	}
+	if !found {
+		return nil, errors.New("'" + reference + "' doesn't contain a policy bundle, expected a layer with media type " + OCIBundleMediaType)
+	}
+
+	if !opts.NoCache && cacheDir != "" {
+		if err := cacheOCIBundle(cacheDir, digest, res); err != nil {
+			log.Warn().Err(err).Str("reference", reference).Msg("could not cache policy bundle")
+		}
+	}
+
+	return res, nil
+}
+
+// BundleFromPathsOrOCI loads bundles from local paths like
+// BundleFromPathsWithSources, from all paths that are OCI references via
+// BundleFromOCI and from all git references via BundleFromGitReference.
+// Git uses its own credential helpers and ssh config. Source maps are only
+// available for local files.
+func BundleFromPathsOrOCI(ctx context.Context, opts OCIOptions, paths ...string) (*Bundle, SourceMap, error) {
+	var local []string
+	var refs []string
+	var gitRefs []string
+	for _, path := range paths {
+		if IsOCIReference(path) {
+			refs = append(refs, path)
+		} else if IsGitReference(path) {
+			gitRefs = append(gitRefs, path)
+		} else {
+			local = append(local, path)
+		}
+	}
+
+	res := &Bundle{}
+	sources := SourceMap{}
+	if len(local) != 0 {
+		var err error
+		res, sources, err = BundleFromPathsWithSources(local...)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, ref := range refs {
+		bundle, err := BundleFromOCI(ctx, ref, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		res = aggregateBundles(res, bundle)
+	}
+
+	for _, ref := range gitRefs {
+		bundle, err := BundleFromGitReference(ctx, ref, GitOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		res = aggregateBundles(res, bundle)
+	}
+
+	return res, sources, nil
+}
+
+func isOCIBundleLayer(desc v1.Descriptor) bool {
+	if string(desc.MediaType) == OCIBundleMediaType {
+		return true
+	}
+	title := desc.Annotations[ociTitleAnnotation]
+	return strings.HasSuffix(title, ".mql.yaml") || strings.HasSuffix(title, ".mql.yml")
+}
+
+// fetchOCILayer reads a layer and verifies its digest
+func fetchOCILayer(img v1.Image, desc v1.Descriptor) ([]byte, error) {
+	layer, err := img.LayerByDigest(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, _, err := v1.SHA256(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if digest != desc.Digest {
+		return nil, errors.New("digest of layer doesn't match, expected " + desc.Digest.String() + ", got " + digest.String())
+	}
+	return data, nil
+}
+
+func ociCachePath(cacheDir string, digest string) string {
+	return filepath.Join(cacheDir, strings.ReplaceAll(digest, ":", "-")+".mql.yaml")
+}
+
+func cachedOCIBundle(cacheDir string, digest string) (*Bundle, bool) {
+	data, err := os.ReadFile(ociCachePath(cacheDir, digest))
+	if err != nil {
+		return nil, false
+	}
+	bundle, err := BundleFromYAML(data)
+	if err != nil {
+		log.Debug().Err(err).Str("digest", digest).Msg("ignoring invalid cached policy bundle")
+		return nil, false
+	}
+	return bundle, true
+}
+
+func cacheOCIBundle(cacheDir string, digest string, bundle *Bundle) error {
+	data, err := bundle.ToYAML()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return err
+	}
+
+	// write atomically, other processes may read the cache at the same time
+	path := ociCachePath(cacheDir, digest)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
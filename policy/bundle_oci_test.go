@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ociTestBundle = `
+policies:
+  - uid: oci-policy
+    name: OCI Policy
+    version: "1.0.0"
+    groups:
+      - filters: asset.family.contains('unix')
+        checks:
+          - uid: oci-check
+queries:
+  - uid: oci-check
+    title: Always true
+    mql: true == true
+`
+
+func TestBundleFromOCI(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	img, err := mutate.AppendLayers(
+		mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		static.NewLayer([]byte(ociTestBundle), OCIBundleMediaType),
+	)
+	require.NoError(t, err)
+	ref, err := name.ParseReference(host+"/policies:v1", name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	opts := OCIOptions{CacheDir: t.TempDir(), Insecure: true, Keychain: authn.NewMultiKeychain()}
+
+	bundle, err := BundleFromOCI(ctx, "oci://"+host+"/policies:v1", opts)
+	require.NoError(t, err)
+	require.Len(t, bundle.Policies, 1)
+	assert.Equal(t, "oci-policy", bundle.Policies[0].Uid)
+	require.Len(t, bundle.Queries, 1)
+
+	_, err = os.Stat(ociCachePath(opts.CacheDir, digest.String()))
+	require.NoError(t, err)
+
+	t.Run("pinned references are served from the cache", func(t *testing.T) {
+		server.Close()
+		bundle, err := BundleFromOCI(ctx, "oci://"+host+"/policies@"+digest.String(), opts)
+		require.NoError(t, err)
+		assert.Equal(t, "oci-policy", bundle.Policies[0].Uid)
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		_, err := BundleFromOCI(ctx, "oci://UPPER/case:v1", opts)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+// Capabilities describe what an asset's connection can provide. They are
+// negotiated before a policy is resolved, so that checks which need missing
+// capabilities are skipped instead of failing during execution.
+type Capabilities struct {
+	// Missing lists the resources that the connection can't provide,
+	// including their children, e.g. `command` for container images or
+	// `aws` for connections to other clouds
+	Missing []string
+}
+
+// CapabilityMissingError is the result of every datapoint of a query that
+// needs capabilities which the asset's connection doesn't have
+type CapabilityMissingError struct {
+	Resources []string
+}
+
+func (e *CapabilityMissingError) Error() string {
+	return "capability missing: the connection doesn't support " + strings.Join(e.Resources, ", ")
+}
+
+// missingResource returns the missing capability that covers the resource
+func (c *Capabilities) missingResource(resource string) (string, bool) {
+	for _, missing := range c.Missing {
+		if resource == missing || strings.HasPrefix(resource, missing+".") {
+			return missing, true
+		}
+	}
+	return "", false
+}
+
+// Check returns a CapabilityMissingError if the code uses any resource
+// that the connection can't provide
+func (c *Capabilities) Check(code *llx.CodeBundle) error {
+	if c == nil || len(c.Missing) == 0 || code == nil || code.CodeV2 == nil {
+		return nil
+	}
+
+	set := map[string]struct{}{}
+	for _, resource := range CodeResources(code.CodeV2) {
+		if missing, ok := c.missingResource(resource); ok {
+			set[missing] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	res := make([]string, 0, len(set))
+	for missing := range set {
+		res = append(res, missing)
+	}
+	sort.Strings(res)
+	return &CapabilityMissingError{Resources: res}
+}
+
+// CodeResources returns all resources that the code creates or whose fields
+// it accesses, e.g. `users` and `user` for `users.list { name }`
+func CodeResources(code *llx.CodeV2) []string {
+	var res []string
+	for _, block := range code.Blocks {
+		for _, chunk := range block.Chunks {
+			// resources are created by functions without a binding
+			if chunk.Call == llx.Chunk_FUNCTION && (chunk.Function == nil || chunk.Function.Binding == 0) {
+				res = append(res, chunk.Id)
+				continue
+			}
+			if chunk.Function == nil {
+				continue
+			}
+
+			typ := types.Type(chunk.Function.Type)
+			for typ.IsArray() || typ.IsMap() {
+				typ = typ.Child()
+			}
+			if typ.IsResource() {
+				res = append(res, typ.ResourceName())
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+func TestCapabilities_Check(t *testing.T) {
+	code := &llx.CodeBundle{CodeV2: &llx.CodeV2{
+		Blocks: []*llx.Block{{
+			Chunks: []*llx.Chunk{
+				{Call: llx.Chunk_FUNCTION, Id: "aws.ec2"},
+				{Call: llx.Chunk_FUNCTION, Id: "instances", Function: &llx.Function{
+					Binding: 1,
+					Type:    string(types.Array(types.Resource("aws.ec2.instance"))),
+				}},
+			},
+		}},
+	}}
+	assert.Equal(t, []string{"aws.ec2", "aws.ec2.instance"}, CodeResources(code.CodeV2))
+
+	var none *Capabilities
+	assert.NoError(t, none.Check(code))
+	assert.NoError(t, (&Capabilities{Missing: []string{"aws.ec2x", "command"}}).Check(code))
+
+	err := (&Capabilities{Missing: []string{"gcp", "aws", "aws.ec2"}}).Check(code)
+	var missing *CapabilityMissingError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{"aws"}, missing.Resources)
+	assert.Equal(t, "capability missing: the connection doesn't support aws", err.Error())
+}
@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/checksums"
+)
+
+// HashAlgorithm is the algorithm of all checksums of policies, resolved
+// policies and their cache keys
+type HashAlgorithm string
+
+const (
+	// HashFNV1a is fast and the default
+	HashFNV1a HashAlgorithm = "fnv1a"
+	// HashSHA256 is FIPS 140 approved
+	HashSHA256 HashAlgorithm = "sha256"
+)
+
+var hashAlgorithm atomic.Value
+
+func init() {
+	hashAlgorithm.Store(defaultHashAlgorithm)
+}
+
+// SetHashAlgorithm selects the algorithm of all checksums. It must be set
+// before any policy is loaded and be the same across a deployment, since
+// checksums of other algorithms never match, e.g. in caches.
+func SetHashAlgorithm(alg HashAlgorithm) error {
+	switch alg {
+	case HashFNV1a, HashSHA256:
+		hashAlgorithm.Store(alg)
+		return nil
+	case "":
+		hashAlgorithm.Store(defaultHashAlgorithm)
+		return nil
+	default:
+		return errors.New("unknown hash algorithm '" + string(alg) + "', supported are: fnv1a, sha256")
+	}
+}
+
+// GetHashAlgorithm returns the algorithm of all checksums
+func GetHashAlgorithm() HashAlgorithm {
+	return hashAlgorithm.Load().(HashAlgorithm)
+}
+
+// Checksum is built with the selected hash algorithm, see SetHashAlgorithm.
+// Like checksums.Fast it's a value, i.e. every Add returns a new checksum.
+// SHA-256 checksums are a hash chain of all added values.
+type Checksum struct {
+	alg  HashAlgorithm
+	fast checksums.Fast
+	sha  [sha256.Size]byte
+}
+
+// NewChecksum starts an empty checksum
+func NewChecksum() Checksum {
+	return Checksum{alg: GetHashAlgorithm(), fast: checksums.New}
+}
+
+func (c Checksum) addBytes(b []byte) Checksum {
+	h := sha256.New()
+	h.Write(c.sha[:])
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(b)))
+	h.Write(size[:])
+	h.Write(b)
+	h.Sum(c.sha[:0])
+	return c
+}
+
+// Add a string to the checksum
+func (c Checksum) Add(s string) Checksum {
+	if c.alg == HashSHA256 {
+		return c.addBytes([]byte(s))
+	}
+	c.fast = c.fast.Add(s)
+	return c
+}
+
+// AddUint adds a number to the checksum
+func (c Checksum) AddUint(u uint64) Checksum {
+	if c.alg == HashSHA256 {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], u)
+		return c.addBytes(b[:])
+	}
+	c.fast = c.fast.AddUint(u)
+	return c
+}
+
+// AddChecksum adds another checksum to the checksum
+func (c Checksum) AddChecksum(other Checksum) Checksum {
+	if c.alg == HashSHA256 {
+		return c.addBytes(other.sha[:])
+	}
+	c.fast = c.fast.AddUint(uint64(other.fast))
+	return c
+}
+
+func (c Checksum) String() string {
+	if c.alg == HashSHA256 {
+		return base64.StdEncoding.EncodeToString(c.sha[:])
+	}
+	return c.fast.String()
+}
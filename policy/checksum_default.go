@@ -0,0 +1,5 @@
+//go:build !fips
+
+package policy
+
+const defaultHashAlgorithm = HashFNV1a
@@ -0,0 +1,6 @@
+//go:build fips
+
+package policy
+
+// builds for FIPS environments use approved algorithms by default
+const defaultHashAlgorithm = HashSHA256
@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/checksums"
+)
+
+func TestChecksum_HashAlgorithms(t *testing.T) {
+	defer SetHashAlgorithm(defaultHashAlgorithm)
+
+	require.NoError(t, SetHashAlgorithm(HashFNV1a))
+	fnv := NewChecksum().Add("a").AddUint(1).String()
+	assert.Equal(t, checksums.New.Add("a").AddUint(1).String(), fnv)
+
+	require.NoError(t, SetHashAlgorithm(HashSHA256))
+	sha := NewChecksum().Add("a").AddUint(1).String()
+	assert.Len(t, sha, 44)
+	assert.Equal(t, sha, NewChecksum().Add("a").AddUint(1).String())
+	// values are length-prefixed
+	assert.NotEqual(t, NewChecksum().Add("ab").Add("c").String(), NewChecksum().Add("a").Add("bc").String())
+	assert.Equal(t, NewChecksum().Add("a").Add("b").String(), checksumStrings("a", "b"))
+
+	base := NewChecksum().Add("a")
+	assert.NotEqual(t, base.Add("b").String(), base.Add("c").String())
+
+	assert.Error(t, SetHashAlgorithm("md5"))
+	assert.Equal(t, HashSHA256, GetHashAlgorithm())
+}
@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"strings"
+
+	"go.mondoo.com/cnquery/motor/asset"
+)
+
+// Labels that discovery sets on cloud assets
+const (
+	CloudAccountLabel = "mondoo.com/account"
+	CloudRegionLabel  = "mondoo.com/region"
+	CloudImageLabel   = "mondoo.com/image-id"
+)
+
+const platformIDRuntimePrefix = "//platformid.api.mondoo.app/runtime/"
+
+// CloudContextFromAsset collects the cloud context of an asset from its
+// platform IDs and labels. It returns nil for assets that are not in a cloud.
+func CloudContextFromAsset(a *asset.Asset) *CloudContext {
+	if a == nil {
+		return nil
+	}
+
+	res := &CloudContext{}
+	for i := range a.PlatformIds {
+		if parsePlatformID(a.PlatformIds[i], res) {
+			break
+		}
+	}
+
+	for k, v := range a.Labels {
+		switch k {
+		case CloudAccountLabel:
+			res.AccountId = v
+		case CloudRegionLabel:
+			res.Region = v
+		case CloudImageLabel:
+			res.ImageId = v
+		default:
+			// namespaced labels are set by cnspec itself, all others are
+			// tags of the cloud resource
+			if strings.Contains(k, "/") {
+				continue
+			}
+			if res.Tags == nil {
+				res.Tags = map[string]string{}
+			}
+			res.Tags[k] = v
+		}
+	}
+
+	if res.Provider == "" {
+		return nil
+	}
+	return res
+}
+
+// parsePlatformID fills in provider, account and region from cloud platform
+// IDs, e.g. //platformid.api.mondoo.app/runtime/aws/ec2/v1/accounts/1234/regions/us-east-1/instances/i-1234
+func parsePlatformID(id string, res *CloudContext) bool {
+	if !strings.HasPrefix(id, platformIDRuntimePrefix) {
+		return false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(id, platformIDRuntimePrefix), "/")
+	if len(parts) == 0 {
+		return false
+	}
+
+	var accountKey string
+	var regionKeys []string
+	switch parts[0] {
+	case "aws":
+		accountKey, regionKeys = "accounts", []string{"regions"}
+	case "gcp":
+		accountKey, regionKeys = "projects", []string{"regions", "zones"}
+	case "azure":
+		accountKey, regionKeys = "subscriptions", []string{"locations"}
+	default:
+		return false
+	}
+
+	res.Provider = parts[0]
+	for i := 1; i+1 < len(parts); i++ {
+		key, value := parts[i], parts[i+1]
+		if key == accountKey && res.AccountId == "" {
+			res.AccountId = value
+		}
+		for _, regionKey := range regionKeys {
+			if key == regionKey && res.Region == "" {
+				res.Region = value
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/motor/asset"
+)
+
+func TestCloudContextFromAsset(t *testing.T) {
+	t.Run("aws instance", func(t *testing.T) {
+		res := CloudContextFromAsset(&asset.Asset{
+			PlatformIds: []string{
+				"//platformid.api.mondoo.app/machineid/abc",
+				"//platformid.api.mondoo.app/runtime/aws/ec2/v1/accounts/185972265011/regions/us-east-1/instances/i-0a1b2c",
+			},
+			Labels: map[string]string{
+				CloudImageLabel:       "ami-1234",
+				"mondoo.com/instance": "i-0a1b2c",
+				"team":                "platform",
+			},
+		})
+		require.NotNil(t, res)
+		assert.Equal(t, &CloudContext{
+			Provider:  "aws",
+			AccountId: "185972265011",
+			Region:    "us-east-1",
+			ImageId:   "ami-1234",
+			Tags:      map[string]string{"team": "platform"},
+		}, res)
+	})
+
+	t.Run("gcp instance", func(t *testing.T) {
+		res := CloudContextFromAsset(&asset.Asset{
+			PlatformIds: []string{"//platformid.api.mondoo.app/runtime/gcp/compute/v1/projects/my-project/zones/us-central1-a/instances/123"},
+		})
+		require.NotNil(t, res)
+		assert.Equal(t, "gcp", res.Provider)
+		assert.Equal(t, "my-project", res.AccountId)
+		assert.Equal(t, "us-central1-a", res.Region)
+	})
+
+	t.Run("labels override platform ids", func(t *testing.T) {
+		res := CloudContextFromAsset(&asset.Asset{
+			PlatformIds: []string{"//platformid.api.mondoo.app/runtime/aws/ec2/v1/accounts/1/regions/us-east-1/instances/i-1"},
+			Labels:      map[string]string{CloudRegionLabel: "eu-west-1"},
+		})
+		require.NotNil(t, res)
+		assert.Equal(t, "eu-west-1", res.Region)
+	})
+
+	t.Run("not a cloud asset", func(t *testing.T) {
+		assert.Nil(t, CloudContextFromAsset(&asset.Asset{
+			PlatformIds: []string{"//platformid.api.mondoo.app/hostname/localhost"},
+			Labels:      map[string]string{"team": "platform"},
+		}))
+		assert.Nil(t, CloudContextFromAsset(nil))
+	})
+}
@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+// ErrTypesDontMatch is returned for data whose type doesn't match the
+// expected type of its datapoint and that can't be coerced
+var ErrTypesDontMatch = errors.New("types don't match")
+
+// CoercionOptions configures which type mismatches of stored data are
+// coerced into the expected type instead of being rejected. This reduces
+// lost data when provider schemas shift slightly between versions.
+type CoercionOptions struct {
+	// IntFloat converts ints to floats, and floats without a fractional part
+	// to ints
+	IntFloat bool
+	// StringTime parses RFC 3339 strings into times
+	StringTime bool
+	// ValueToArray wraps single values into arrays of their type
+	ValueToArray bool
+}
+
+// DefaultCoercion enables all coercions
+var DefaultCoercion = CoercionOptions{
+	IntFloat:     true,
+	StringTime:   true,
+	ValueToArray: true,
+}
+
+// DataWarningStore is implemented by datalakes that record warnings on
+// datapoints, e.g. when their data was coerced into the expected type
+type DataWarningStore interface {
+	// GetDataWarnings returns the warnings of all datapoints of an asset,
+	// by checksum
+	GetDataWarnings(ctx context.Context, assetMrn string) (map[string]string, error)
+}
+
+// CoerceResult converts the data of a result into the expected type. It
+// returns the result unchanged if the types already match, otherwise it
+// returns the coerced result with a warning that describes the coercion.
+// Data that can't be coerced fails with ErrTypesDontMatch.
+func CoerceResult(res *llx.Result, expected types.Type, opts CoercionOptions) (*llx.Result, string, error) {
+	if res == nil || res.Data == nil || res.Data.IsNil() || res.Data.Type == "" ||
+		expected == types.Unset || types.Type(res.Data.Type) == expected {
+		return res, "", nil
+	}
+
+	received := types.Type(res.Data.Type)
+	mismatch := errors.Wrapf(ErrTypesDontMatch, "expected %s, got %s", expected.Label(), received.Label())
+
+	raw := res.Data.RawData()
+	if raw.Error != nil {
+		return nil, "", mismatch
+	}
+
+	data, ok := coerceRawData(raw, expected, opts)
+	if !ok {
+		return nil, "", mismatch
+	}
+
+	coerced := (&llx.RawResult{Data: data, CodeID: res.CodeId}).Result()
+	coerced.Error = res.Error
+	return coerced, "coerced " + received.Label() + " to " + expected.Label(), nil
+}
+
+func coerceRawData(raw *llx.RawData, expected types.Type, opts CoercionOptions) (*llx.RawData, bool) {
+	switch {
+	case opts.IntFloat && raw.Type == types.Int && expected == types.Float:
+		v, ok := raw.Value.(int64)
+		if !ok {
+			return nil, false
+		}
+		return llx.FloatData(float64(v)), true
+
+	case opts.IntFloat && raw.Type == types.Float && expected == types.Int:
+		v, ok := raw.Value.(float64)
+		if !ok || v != math.Trunc(v) || v > math.MaxInt64 || v < math.MinInt64 {
+			return nil, false
+		}
+		return llx.IntData(int64(v)), true
+
+	case opts.StringTime && raw.Type == types.String && expected == types.Time:
+		v, ok := raw.Value.(string)
+		if !ok {
+			return nil, false
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, false
+		}
+		return llx.TimeData(t), true
+
+	case opts.ValueToArray && expected.IsArray() && !raw.Type.IsArray():
+		child := expected.Child()
+		elem := raw
+		if raw.Type != child {
+			var ok bool
+			if elem, ok = coerceRawData(raw, child, opts); !ok {
+				return nil, false
+			}
+		}
+		return llx.ArrayData([]interface{}{elem.Value}, child), true
+
+	default:
+		return nil, false
+	}
+}
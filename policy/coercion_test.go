@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+func coerce(t *testing.T, data *llx.RawData, expected types.Type, opts CoercionOptions) (*llx.RawData, string, error) {
+	res, warning, err := CoerceResult((&llx.RawResult{Data: data, CodeID: "id"}).Result(), expected, opts)
+	if err != nil {
+		return nil, warning, err
+	}
+	require.Equal(t, "id", res.CodeId)
+	return res.Data.RawData(), warning, nil
+}
+
+func TestCoerceResult(t *testing.T) {
+	t.Run("matching types are unchanged", func(t *testing.T) {
+		res := (&llx.RawResult{Data: llx.IntData(1), CodeID: "id"}).Result()
+		coerced, warning, err := CoerceResult(res, types.Int, DefaultCoercion)
+		require.NoError(t, err)
+		assert.Same(t, res, coerced)
+		assert.Empty(t, warning)
+	})
+
+	t.Run("int and float", func(t *testing.T) {
+		data, warning, err := coerce(t, llx.IntData(3), types.Float, DefaultCoercion)
+		require.NoError(t, err)
+		assert.Equal(t, 3.0, data.Value)
+		assert.Equal(t, "coerced int to float", warning)
+
+		data, _, err = coerce(t, llx.FloatData(4), types.Int, DefaultCoercion)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), data.Value)
+
+		_, _, err = coerce(t, llx.FloatData(4.5), types.Int, DefaultCoercion)
+		assert.ErrorIs(t, err, ErrTypesDontMatch)
+	})
+
+	t.Run("string to time", func(t *testing.T) {
+		data, _, err := coerce(t, llx.StringData("2023-01-02T03:04:05Z"), types.Time, DefaultCoercion)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), *data.Value.(*time.Time))
+
+		_, _, err = coerce(t, llx.StringData("yesterday"), types.Time, DefaultCoercion)
+		assert.ErrorIs(t, err, ErrTypesDontMatch)
+	})
+
+	t.Run("value to array", func(t *testing.T) {
+		data, _, err := coerce(t, llx.StringData("a"), types.Array(types.String), DefaultCoercion)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"a"}, data.Value)
+
+		data, _, err = coerce(t, llx.IntData(1), types.Array(types.Float), DefaultCoercion)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{1.0}, data.Value)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, _, err := coerce(t, llx.IntData(3), types.Float, CoercionOptions{})
+		assert.ErrorIs(t, err, ErrTypesDontMatch)
+		_, _, err = coerce(t, llx.BoolData(true), types.String, DefaultCoercion)
+		assert.ErrorIs(t, err, ErrTypesDontMatch)
+	})
+}
@@ -0,0 +1,294 @@
+package policy
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// PlatformCompatibility is a platform or platform family that a policy targets
+type PlatformCompatibility struct {
+	// Platform is the platform or family as used in filters, e.g. `ubuntu`
+	Platform string
+	// Family is true if all platforms of a family are targeted, e.g. `linux`
+	Family bool
+	// AnyVersion is true if at least one filter doesn't restrict the version
+	AnyVersion bool
+	// Versions are the exact versions that are targeted, e.g. `8` and `9`
+	Versions []string
+	// MinVersions are the versions from which on all versions are targeted
+	MinVersions []string
+}
+
+// PolicyCompatibility lists all platforms that a policy targets
+type PolicyCompatibility struct {
+	Mrn  string
+	Name string
+	// Platforms are sorted by their name, families first
+	Platforms []*PlatformCompatibility
+	// Unrecognized are filters that don't target a known platform field.
+	// They are listed as-is, so that catalogs can show them.
+	Unrecognized []string
+}
+
+// CompatibilityMatrix lists the targeted platforms for every policy of a bundle
+type CompatibilityMatrix struct {
+	// Policies are sorted by their MRN
+	Policies []*PolicyCompatibility
+}
+
+var platformNames = map[string]string{
+	"alpine":        "Alpine",
+	"amazonlinux":   "Amazon Linux",
+	"arch":          "Arch Linux",
+	"aws":           "AWS",
+	"azure":         "Azure",
+	"centos":        "CentOS",
+	"debian":        "Debian",
+	"fedora":        "Fedora",
+	"gcp-project":   "GCP Project",
+	"k8s-pod":       "Kubernetes Pod",
+	"linux":         "Linux",
+	"macos":         "macOS",
+	"oraclelinux":   "Oracle Linux",
+	"redhat":        "RHEL",
+	"rhel":          "RHEL",
+	"sles":          "SLES",
+	"suse":          "SUSE",
+	"ubuntu":        "Ubuntu",
+	"unix":          "Unix",
+	"windows":       "Windows",
+	"windowsserver": "Windows Server",
+}
+
+// Name returns the display name of the platform, e.g. `RHEL` for `redhat`
+func (c *PlatformCompatibility) Name() string {
+	if name, ok := platformNames[c.Platform]; ok {
+		return name
+	}
+	return c.Platform
+}
+
+// String renders the platform with its versions, e.g. `RHEL 8/9` or
+// `Ubuntu 20.04+`
+func (c *PlatformCompatibility) String() string {
+	if c.AnyVersion || (len(c.Versions) == 0 && len(c.MinVersions) == 0) {
+		return c.Name()
+	}
+
+	versions := append([]string{}, c.Versions...)
+	for _, v := range c.MinVersions {
+		versions = append(versions, v+"+")
+	}
+	return c.Name() + " " + strings.Join(versions, "/")
+}
+
+// String renders all targeted platforms, e.g.
+// `Ubuntu 20.04+, RHEL 8/9, Windows Server 2019+`. Policies without
+// filters work on any platform.
+func (c *PolicyCompatibility) String() string {
+	if len(c.Platforms) == 0 && len(c.Unrecognized) == 0 {
+		return "any platform"
+	}
+
+	res := make([]string, 0, len(c.Platforms)+len(c.Unrecognized))
+	for i := range c.Platforms {
+		res = append(res, c.Platforms[i].String())
+	}
+	res = append(res, c.Unrecognized...)
+	return strings.Join(res, ", ")
+}
+
+var (
+	platformFilterRe = regexp.MustCompile(`^(?:asset\.platform|platform\.name)\s*==\s*["']([^"']+)["']$`)
+	familyFilterRe   = regexp.MustCompile(`^(?:asset|platform)\.family\.contains\(\s*["']([^"']+)["']\s*\)$`)
+	versionFilterRe  = regexp.MustCompile(`^(?:asset\.version|platform\.release|platform\.version)\s*(==|>=)\s*["']?([^"'\s]+)["']?$`)
+)
+
+// filterTerm removes whitespace and enclosing parentheses of a term
+func filterTerm(term string) string {
+	term = strings.TrimSpace(term)
+	for strings.HasPrefix(term, "(") && strings.HasSuffix(term, ")") {
+		term = strings.TrimSpace(term[1 : len(term)-1])
+	}
+	return term
+}
+
+// parseCompatibility adds the platforms of one filter. Filters are split
+// into alternatives by `||` and into conditions by `&&`, so that e.g.
+// `platform.name == "rhel" && platform.release == "9"` targets RHEL 9.
+// It returns false if any alternative doesn't target a known platform.
+func parseCompatibility(mql string, platforms map[string]*PlatformCompatibility) bool {
+	type alternative struct {
+		platform    string
+		family      bool
+		versions    []string
+		minVersions []string
+	}
+
+	var alternatives []alternative
+	for _, alt := range strings.Split(mql, "||") {
+		var cur alternative
+		for _, term := range strings.Split(alt, "&&") {
+			term = filterTerm(term)
+			if m := platformFilterRe.FindStringSubmatch(term); m != nil {
+				cur.platform = m[1]
+			} else if m := familyFilterRe.FindStringSubmatch(term); m != nil {
+				cur.platform, cur.family = m[1], true
+			} else if m := versionFilterRe.FindStringSubmatch(term); m != nil {
+				if m[1] == "==" {
+					cur.versions = append(cur.versions, m[2])
+				} else {
+					cur.minVersions = append(cur.minVersions, m[2])
+				}
+			}
+		}
+		if cur.platform == "" {
+			return false
+		}
+		alternatives = append(alternatives, cur)
+	}
+
+	for _, alt := range alternatives {
+		key := alt.platform
+		if alt.family {
+			key = "family:" + key
+		}
+		platform, ok := platforms[key]
+		if !ok {
+			platform = &PlatformCompatibility{Platform: alt.platform, Family: alt.family}
+			platforms[key] = platform
+		}
+		if len(alt.versions) == 0 && len(alt.minVersions) == 0 {
+			platform.AnyVersion = true
+		}
+		for _, v := range alt.versions {
+			platform.Versions = appendUnique(platform.Versions, v)
+		}
+		for _, v := range alt.minVersions {
+			platform.MinVersions = appendUnique(platform.MinVersions, v)
+		}
+	}
+	return true
+}
+
+// versionLess compares versions by their numeric components, e.g. so that
+// `9` is sorted before `10`
+func versionLess(a string, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aerr := strconv.Atoi(as[i])
+		bn, berr := strconv.Atoi(bs[i])
+		if aerr != nil || berr != nil {
+			if as[i] != bs[i] {
+				return as[i] < bs[i]
+			}
+			continue
+		}
+		if an != bn {
+			return an < bn
+		}
+	}
+	return len(as) < len(bs)
+}
+
+func policyFilters(policy *Policy) []*explorer.Mquery {
+	var res []*explorer.Mquery
+	if policy.Filters != nil {
+		for _, filter := range policy.Filters.Items {
+			res = append(res, filter)
+		}
+	}
+	for _, group := range policy.Groups {
+		if group.Filters == nil {
+			continue
+		}
+		for _, filter := range group.Filters.Items {
+			res = append(res, filter)
+		}
+	}
+	return res
+}
+
+// CompatibilityMatrix lists which platforms and versions every policy of the
+// bundle targets, as derived from their filters. Policies without filters
+// of their own target the platforms of the policies they reference.
+func (p *Bundle) CompatibilityMatrix() *CompatibilityMatrix {
+	res := &CompatibilityMatrix{}
+	if p == nil {
+		return res
+	}
+
+	policies := make(map[string]*Policy, len(p.Policies))
+	for _, policy := range p.Policies {
+		policies[elementID(policy.Mrn, policy.Uid)] = policy
+	}
+
+	var collect func(policy *Policy, platforms map[string]*PlatformCompatibility, unrecognized map[string]struct{}, visited map[string]struct{})
+	collect = func(policy *Policy, platforms map[string]*PlatformCompatibility, unrecognized map[string]struct{}, visited map[string]struct{}) {
+		id := elementID(policy.Mrn, policy.Uid)
+		if _, ok := visited[id]; ok {
+			return
+		}
+		visited[id] = struct{}{}
+
+		filters := policyFilters(policy)
+		for _, filter := range filters {
+			if !parseCompatibility(filter.Mql, platforms) {
+				unrecognized[strings.TrimSpace(filter.Mql)] = struct{}{}
+			}
+		}
+		if len(filters) != 0 {
+			return
+		}
+
+		for _, group := range policy.Groups {
+			for _, ref := range group.Policies {
+				if child, ok := policies[elementID(ref.Mrn, ref.Uid)]; ok {
+					collect(child, platforms, unrecognized, visited)
+				}
+			}
+		}
+	}
+
+	for _, policy := range p.Policies {
+		platforms := map[string]*PlatformCompatibility{}
+		unrecognized := map[string]struct{}{}
+		collect(policy, platforms, unrecognized, map[string]struct{}{})
+
+		entry := &PolicyCompatibility{
+			Mrn:  policy.Mrn,
+			Name: policy.Name,
+		}
+		for _, platform := range platforms {
+			sort.Slice(platform.Versions, func(i, j int) bool {
+				return versionLess(platform.Versions[i], platform.Versions[j])
+			})
+			sort.Slice(platform.MinVersions, func(i, j int) bool {
+				return versionLess(platform.MinVersions[i], platform.MinVersions[j])
+			})
+			entry.Platforms = append(entry.Platforms, platform)
+		}
+		sort.Slice(entry.Platforms, func(i, j int) bool {
+			a, b := entry.Platforms[i], entry.Platforms[j]
+			if a.Family != b.Family {
+				return a.Family
+			}
+			return a.Name() < b.Name()
+		})
+		for mql := range unrecognized {
+			entry.Unrecognized = append(entry.Unrecognized, mql)
+		}
+		sort.Strings(entry.Unrecognized)
+
+		res.Policies = append(res.Policies, entry)
+	}
+	sort.Slice(res.Policies, func(i, j int) bool {
+		return res.Policies[i].Mrn < res.Policies[j].Mrn
+	})
+
+	return res
+}
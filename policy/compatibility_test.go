@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func compatFilters(mqls ...string) *explorer.Filters {
+	res := &explorer.Filters{Items: map[string]*explorer.Mquery{}}
+	for _, mql := range mqls {
+		res.Items[mql] = &explorer.Mquery{Mql: mql}
+	}
+	return res
+}
+
+func TestBundle_CompatibilityMatrix(t *testing.T) {
+	bundle := &Bundle{Policies: []*Policy{
+		{Mrn: "//policy/all", Groups: []*PolicyGroup{{
+			Policies: []*PolicyRef{{Mrn: "//policy/linux"}, {Mrn: "//policy/windows"}},
+		}}},
+		{Mrn: "//policy/linux", Name: "Linux", Groups: []*PolicyGroup{
+			{Filters: compatFilters(
+				"platform.name == 'ubuntu' && platform.release >= '20.04'",
+				"(asset.platform == \"redhat\" && asset.version == \"9\") || (asset.platform == \"redhat\" && asset.version == \"8\")",
+			)},
+			{Filters: compatFilters("asset.family.contains('linux')")},
+		}},
+		{Mrn: "//policy/windows", Filters: compatFilters("asset.platform == 'windowsserver' && asset.version >= '2019'")},
+		{Mrn: "//policy/custom", Filters: compatFilters("asset.platform == 'debian'", "asset.name == 'web'")},
+		{Mrn: "//policy/any"},
+	}}
+
+	matrix := bundle.CompatibilityMatrix()
+	require.Len(t, matrix.Policies, 5)
+	byMrn := map[string]*PolicyCompatibility{}
+	for _, p := range matrix.Policies {
+		byMrn[p.Mrn] = p
+	}
+
+	linux := byMrn["//policy/linux"]
+	assert.Equal(t, "Linux", linux.Name)
+	assert.Equal(t, []*PlatformCompatibility{
+		{Platform: "linux", Family: true, AnyVersion: true},
+		{Platform: "redhat", Versions: []string{"8", "9"}},
+		{Platform: "ubuntu", MinVersions: []string{"20.04"}},
+	}, linux.Platforms)
+	assert.Equal(t, "Linux, RHEL 8/9, Ubuntu 20.04+", linux.String())
+
+	assert.Equal(t, "Windows Server 2019+", byMrn["//policy/windows"].String())
+	assert.Equal(t, "Linux, RHEL 8/9, Ubuntu 20.04+, Windows Server 2019+", byMrn["//policy/all"].String())
+	assert.Equal(t, []string{"asset.name == 'web'"}, byMrn["//policy/custom"].Unrecognized)
+	assert.Equal(t, "Debian, asset.name == 'web'", byMrn["//policy/custom"].String())
+	assert.Equal(t, "any platform", byMrn["//policy/any"].String())
+
+	var none *Bundle
+	assert.Empty(t, none.CompatibilityMatrix().Policies)
+}
+
+func TestVersionLess(t *testing.T) {
+	assert.True(t, versionLess("9", "10"))
+	assert.True(t, versionLess("20.04", "22.04"))
+	assert.True(t, versionLess("8", "8.1"))
+	assert.False(t, versionLess("10", "9"))
+}
@@ -0,0 +1,241 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+)
+
+// ComplianceTagPrefix maps policies and checks to the controls of compliance
+// frameworks. The rest of the tag names the framework and its value lists
+// the controls, e.g. `compliance.mondoo.com/cis-ubuntu-22.04: 1.1.1, 1.1.2`
+// or `compliance.mondoo.com/nist-800-53: AC-2`.
+const ComplianceTagPrefix = "compliance.mondoo.com/"
+
+// ControlRef identifies a control of a compliance framework
+type ControlRef struct {
+	Framework string `json:"framework"`
+	Control   string `json:"control"`
+}
+
+// ComplianceControls returns the controls that the tags map to, sorted by
+// framework and control
+func ComplianceControls(tags map[string]string) []ControlRef {
+	var res []ControlRef
+	for k, v := range tags {
+		if !strings.HasPrefix(k, ComplianceTagPrefix) {
+			continue
+		}
+		framework := strings.TrimSpace(strings.TrimPrefix(k, ComplianceTagPrefix))
+		if framework == "" {
+			continue
+		}
+		for _, control := range strings.Split(v, ",") {
+			control = strings.TrimSpace(control)
+			if control != "" {
+				res = append(res, ControlRef{Framework: framework, Control: control})
+			}
+		}
+	}
+	sortControlRefs(res)
+	return res
+}
+
+func sortControlRefs(refs []ControlRef) {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Framework == refs[j].Framework {
+			return refs[i].Control < refs[j].Control
+		}
+		return refs[i].Framework < refs[j].Framework
+	})
+}
+
+// ComplianceMapping maps the controls of frameworks to the IDs of the scores
+// that cover them, i.e. the code IDs of checks and the MRNs of policies
+type ComplianceMapping map[ControlRef][]string
+
+// add maps the control to the score ID
+func (m ComplianceMapping) add(ref ControlRef, id string) {
+	for _, existing := range m[ref] {
+		if existing == id {
+			return
+		}
+	}
+	m[ref] = append(m[ref], id)
+}
+
+// Controls returns all mapped controls, sorted by framework and control
+func (m ComplianceMapping) Controls() []ControlRef {
+	res := make([]ControlRef, 0, len(m))
+	for ref := range m {
+		res = append(res, ref)
+	}
+	sortControlRefs(res)
+	return res
+}
+
+// ComplianceMapping collects the controls that the policies and checks of
+// the bundle are mapped to. The bundle must be compiled, so that checks
+// have code IDs.
+func (p *Bundle) ComplianceMapping() ComplianceMapping {
+	res := ComplianceMapping{}
+	if p == nil {
+		return res
+	}
+
+	for i := range p.Policies {
+		policy := p.Policies[i]
+		for _, ref := range ComplianceControls(policy.Tags) {
+			res.add(ref, policy.Mrn)
+		}
+	}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		if query.CodeId == "" {
+			continue
+		}
+		for _, ref := range ComplianceControls(query.Tags) {
+			res.add(ref, query.CodeId)
+		}
+	}
+	return res
+}
+
+// ControlScore is the aggregated score of a control on one asset
+type ControlScore struct {
+	ControlRef
+	// Score is the worst score of all checks and policies that are mapped
+	// to the control, nil if none of them was scored
+	Score *Score `json:"score,omitempty"`
+	// IDs of the mapped checks and policies
+	IDs     []string `json:"ids"`
+	Passed  int      `json:"passed"`
+	Failed  int      `json:"failed"`
+	Errors  int      `json:"errors"`
+	Skipped int      `json:"skipped"`
+	// Unknown counts mapped checks and policies without result
+	Unknown int `json:"unknown"`
+}
+
+// Outcome returns the outcome of the control, see ScoreOutcome
+func (c *ControlScore) Outcome() string {
+	switch {
+	case c.Errors > 0:
+		return OutcomeError
+	case c.Failed > 0:
+		return OutcomeFail
+	case c.Passed > 0:
+		return OutcomePass
+	case c.Skipped > 0:
+		return OutcomeSkip
+	default:
+		return OutcomeUnknown
+	}
+}
+
+// FrameworkScore summarizes the controls of a framework on one asset
+type FrameworkScore struct {
+	Framework string `json:"framework"`
+	// Controls is the number of mapped controls
+	Controls int `json:"controls"`
+	// Covered is the number of controls with a result
+	Covered int `json:"covered"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Errors  int `json:"errors"`
+	// Score is the average score of all covered controls
+	Score *Score `json:"score,omitempty"`
+}
+
+// Coverage returns the percentage of controls with a result
+func (f *FrameworkScore) Coverage() uint32 {
+	if f.Controls == 0 {
+		return 0
+	}
+	return uint32(100 * f.Covered / f.Controls)
+}
+
+// ControlScores aggregates the scores of the report per control. Every
+// control gets the worst score of its checks and policies, so a control
+// only passes if all of them pass.
+func (r *Report) ControlScores(mapping ComplianceMapping) []*ControlScore {
+	controls := mapping.Controls()
+	res := make([]*ControlScore, len(controls))
+	for i, ref := range controls {
+		ids := mapping[ref]
+		control := &ControlScore{
+			ControlRef: ref,
+			IDs:        ids,
+		}
+
+		calculator := &worstScoreCalculator{}
+		calculator.Init()
+		for _, id := range ids {
+			score := r.Scores[id]
+			switch ScoreOutcome(score) {
+			case OutcomePass:
+				control.Passed++
+			case OutcomeFail:
+				control.Failed++
+			case OutcomeError:
+				control.Errors++
+			case OutcomeSkip:
+				control.Skipped++
+				continue
+			default:
+				control.Unknown++
+				continue
+			}
+			calculator.Add(score)
+		}
+		if control.Passed+control.Failed+control.Errors > 0 {
+			control.Score = calculator.Calculate()
+		}
+		res[i] = control
+	}
+	return res
+}
+
+// FrameworkScores summarizes control scores per framework, sorted by
+// framework
+func FrameworkScores(controls []*ControlScore) []*FrameworkScore {
+	byFramework := map[string]*FrameworkScore{}
+	calculators := map[string]ScoreCalculator{}
+	var frameworks []string
+
+	for _, control := range controls {
+		framework, ok := byFramework[control.Framework]
+		if !ok {
+			framework = &FrameworkScore{Framework: control.Framework}
+			byFramework[control.Framework] = framework
+			calculator := &averageScoreCalculator{}
+			calculator.Init()
+			calculators[control.Framework] = calculator
+			frameworks = append(frameworks, control.Framework)
+		}
+
+		framework.Controls++
+		switch control.Outcome() {
+		case OutcomePass:
+			framework.Passed++
+		case OutcomeFail:
+			framework.Failed++
+		case OutcomeError:
+			framework.Errors++
+		}
+		if control.Score != nil {
+			framework.Covered++
+			calculators[control.Framework].Add(control.Score)
+		}
+	}
+
+	sort.Strings(frameworks)
+	res := make([]*FrameworkScore, len(frameworks))
+	for i, name := range frameworks {
+		framework := byFramework[name]
+		if framework.Covered > 0 {
+			framework.Score = calculators[name].Calculate()
+		}
+		res[i] = framework
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestComplianceControls(t *testing.T) {
+	controls := ComplianceControls(map[string]string{
+		ComplianceTagPrefix + "nist-800-53": "AC-2",
+		ComplianceTagPrefix + "cis":         " 1.1.2, 1.1.1 ,",
+		ComplianceTagPrefix:                 "ignored",
+		"other":                             "ignored",
+	})
+	assert.Equal(t, []ControlRef{
+		{Framework: "cis", Control: "1.1.1"},
+		{Framework: "cis", Control: "1.1.2"},
+		{Framework: "nist-800-53", Control: "AC-2"},
+	}, controls)
+}
+
+func TestReport_ControlScores(t *testing.T) {
+	bundle := &Bundle{
+		Policies: []*Policy{{
+			Mrn:  "//policy",
+			Tags: map[string]string{ComplianceTagPrefix + "soc2": "CC6.1"},
+		}},
+		Queries: []*explorer.Mquery{
+			{CodeId: "pass", Tags: map[string]string{ComplianceTagPrefix + "cis": "1.1, 1.2"}},
+			{CodeId: "fail", Tags: map[string]string{ComplianceTagPrefix + "cis": "1.2"}},
+			{CodeId: "missing", Tags: map[string]string{ComplianceTagPrefix + "cis": "1.3"}},
+		},
+	}
+	report := &Report{
+		Scores: map[string]*Score{
+			"pass":     {Type: ScoreType_Result, Value: 100, Weight: 1, ScoreCompletion: 100},
+			"fail":     {Type: ScoreType_Result, Value: 40, Weight: 1, ScoreCompletion: 100},
+			"//policy": {Type: ScoreType_Result, Value: 70, Weight: 1, ScoreCompletion: 100},
+		},
+	}
+
+	controls := report.ControlScores(bundle.ComplianceMapping())
+	require.Len(t, controls, 4)
+
+	assert.Equal(t, ControlRef{Framework: "cis", Control: "1.1"}, controls[0].ControlRef)
+	assert.Equal(t, OutcomePass, controls[0].Outcome())
+
+	// controls only pass if all mapped checks pass
+	assert.Equal(t, []string{"pass", "fail"}, controls[1].IDs)
+	assert.Equal(t, OutcomeFail, controls[1].Outcome())
+	assert.Equal(t, uint32(40), controls[1].Score.Value)
+
+	assert.Equal(t, OutcomeUnknown, controls[2].Outcome())
+	assert.Nil(t, controls[2].Score)
+	assert.Equal(t, 1, controls[2].Unknown)
+
+	assert.Equal(t, []string{"//policy"}, controls[3].IDs)
+
+	frameworks := FrameworkScores(controls)
+	require.Len(t, frameworks, 2)
+	cis := frameworks[0]
+	assert.Equal(t, "cis", cis.Framework)
+	assert.Equal(t, 3, cis.Controls)
+	assert.Equal(t, 2, cis.Covered)
+	assert.Equal(t, uint32(66), cis.Coverage())
+	assert.Equal(t, 1, cis.Passed)
+	assert.Equal(t, 1, cis.Failed)
+	assert.Equal(t, uint32(70), cis.Score.Value)
+	assert.Equal(t, "soc2", frameworks[1].Framework)
+}
@@ -0,0 +1,204 @@
+package policy
+
+import (
+	"sort"
+
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// ConflictKind describes how assigned policies disagree about a check or policy
+type ConflictKind string
+
+const (
+	// ConflictImpact is detected when multiple policies modify the impact of
+	// the same check or policy in different ways
+	ConflictImpact ConflictKind = "impact"
+	// ConflictDeactivation is detected when a check or policy is deactivated by
+	// one policy but activated by another
+	ConflictDeactivation ConflictKind = "deactivation"
+)
+
+// PolicyConflict describes a check or policy that multiple policies tried to
+// change in different ways during one resolution.
+//
+// Conflicts are resolved with these precedence rules:
+//  1. Impact modifications from the policy with the highest priority win,
+//     see PriorityTag. If two policies have the same priority, the one
+//     closest to the asset (i.e. the one with the fewest ancestors) wins. If
+//     they are equally close, the policy with the lexicographically lowest
+//     MRN wins.
+//  2. Deactivations only apply to the policy tree they are declared in. If a
+//     check or policy is activated anywhere else, it stays active.
+//
+// The outcome doesn't depend on the order in which policies are resolved.
+type PolicyConflict struct {
+	Kind ConflictKind
+	// ID is the MRN of the check or policy in conflict
+	ID       string
+	IsPolicy bool
+	// Applied lists the policies whose change took effect
+	Applied []string
+	// Overridden lists the policies whose change was discarded
+	Overridden []string
+	// Impact is the effective impact for impact conflicts
+	Impact *explorer.Impact
+	// Rule is the precedence rule that decided the conflict
+	Rule ConflictRule
+}
+
+// impactOverride tracks which policy set the impact of a child job
+type impactOverride struct {
+	policyMrn string
+	priority  int
+	depth     int
+	impact    *explorer.Impact
+}
+
+// precedes returns true if this override takes precedence over the other
+func (o *impactOverride) precedes(other *impactOverride) bool {
+	if o.priority != other.priority {
+		return o.priority > other.priority
+	}
+	if o.depth != other.depth {
+		return o.depth < other.depth
+	}
+	return o.policyMrn < other.policyMrn
+}
+
+// rule returns the precedence rule that decides between two overrides
+func (o *impactOverride) rule(other *impactOverride) ConflictRule {
+	if o.priority != other.priority {
+		return ConflictRulePriority
+	}
+	if o.depth != other.depth {
+		return ConflictRuleDepth
+	}
+	return ConflictRuleMrn
+}
+
+func impactsEqual(a *explorer.Impact, b *explorer.Impact) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value && a.Weight == b.Weight && a.Scoring == b.Scoring
+}
+
+func appendUnique(list []string, s string) []string {
+	for i := range list {
+		if list[i] == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func removeString(list []string, s string) []string {
+	res := list[:0]
+	for i := range list {
+		if list[i] != s {
+			res = append(res, list[i])
+		}
+	}
+	return res
+}
+
+func (r *resolverCache) addConflict(kind ConflictKind, id string, isPolicy bool) *PolicyConflict {
+	key := string(kind) + "\x00" + id
+	if c, ok := r.conflicts[key]; ok {
+		return c
+	}
+	c := &PolicyConflict{
+		Kind:     kind,
+		ID:       id,
+		IsPolicy: isPolicy,
+	}
+	r.conflicts[key] = c
+	return c
+}
+
+// setChildImpact sets the impact of a child job in its parent job, unless a
+// modification with higher precedence was applied to it before. Conflicting
+// modifications from different policies are recorded.
+func (p *policyResolverCache) setChildImpact(parentJob *ReportingJob, childJob *ReportingJob, id string, isPolicy bool, impact *explorer.Impact) {
+	cur := &impactOverride{
+		policyMrn: p.policyMrn,
+		priority:  p.global.policyPriority(p.policyMrn),
+		depth:     len(p.parentPolicies),
+		impact:    impact,
+	}
+
+	key := parentJob.Uuid + "\x00" + childJob.Uuid
+	prev, ok := p.global.impactOverrides[key]
+	if !ok || prev.policyMrn == cur.policyMrn {
+		p.global.impactOverrides[key] = cur
+		parentJob.ChildJobs[childJob.Uuid] = impact
+		p.global.traceImpactModification(key, cur, nil)
+		return
+	}
+
+	winner, loser := prev, cur
+	if cur.precedes(prev) {
+		winner, loser = cur, prev
+		p.global.impactOverrides[key] = cur
+		parentJob.ChildJobs[childJob.Uuid] = impact
+	}
+
+	if impactsEqual(prev.impact, cur.impact) {
+		p.global.traceImpactModification(key, winner, nil)
+		return
+	}
+	p.global.traceImpactModification(key, winner, loser)
+
+	conflict := p.global.addConflict(ConflictImpact, id, isPolicy)
+	for i := range conflict.Applied {
+		if conflict.Applied[i] != winner.policyMrn {
+			conflict.Overridden = appendUnique(conflict.Overridden, conflict.Applied[i])
+		}
+	}
+	conflict.Applied = []string{winner.policyMrn}
+	conflict.Overridden = removeString(appendUnique(conflict.Overridden, loser.policyMrn), winner.policyMrn)
+	conflict.Impact = winner.impact
+	conflict.Rule = winner.rule(loser)
+}
+
+// detectDeactivationConflicts finds all checks and policies that were
+// deactivated in one part of the policy tree but are still active via another
+func (r *resolverCache) detectDeactivationConflicts() {
+	active := make(map[string]struct{}, len(r.reportingJobsByChecksum))
+	for _, rj := range r.reportingJobsByChecksum {
+		active[rj.QrId] = struct{}{}
+	}
+
+	for id, deactivated := range r.deactivatedBy {
+		if _, ok := active[id]; !ok {
+			continue
+		}
+
+		_, isPolicy := r.bundleMap.Policies[id]
+		conflict := r.addConflict(ConflictDeactivation, id, isPolicy)
+		conflict.Rule = ConflictRuleActivation
+		for i := range r.activatedBy[id] {
+			conflict.Applied = appendUnique(conflict.Applied, r.activatedBy[id][i])
+		}
+		for i := range deactivated {
+			conflict.Overridden = appendUnique(conflict.Overridden, deactivated[i])
+		}
+	}
+}
+
+// conflictList returns all detected conflicts, sorted by kind and ID
+func (r *resolverCache) conflictList() []*PolicyConflict {
+	res := make([]*PolicyConflict, 0, len(r.conflicts))
+	for _, c := range r.conflicts {
+		sort.Strings(c.Applied)
+		sort.Strings(c.Overridden)
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Kind != res[j].Kind {
+			return res[i].Kind < res[j].Kind
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
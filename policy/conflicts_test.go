@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func testConflictCache() (*resolverCache, *ReportingJob, *ReportingJob) {
+	parent := &ReportingJob{Uuid: "parent", ChildJobs: map[string]*explorer.Impact{}}
+	child := &ReportingJob{Uuid: "child", QrId: "//check"}
+	global := &resolverCache{
+		reportingJobsByChecksum: map[string]*ReportingJob{},
+		impactOverrides:         map[string]*impactOverride{},
+		activatedBy:             map[string][]string{},
+		deactivatedBy:           map[string][]string{},
+		conflicts:               map[string]*PolicyConflict{},
+	}
+	return global, parent, child
+}
+
+func TestSetChildImpact(t *testing.T) {
+	t.Run("closest policy wins", func(t *testing.T) {
+		global, parent, child := testConflictCache()
+
+		deep := &policyResolverCache{
+			policyMrn:      "//deep",
+			parentPolicies: map[string]struct{}{"//asset": {}, "//a": {}, "//deep": {}},
+			global:         global,
+		}
+		near := &policyResolverCache{
+			policyMrn:      "//close",
+			parentPolicies: map[string]struct{}{"//asset": {}, "//close": {}},
+			global:         global,
+		}
+
+		near.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 20})
+		deep.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 80})
+
+		assert.Equal(t, int32(20), parent.ChildJobs["child"].Value)
+		conflicts := global.conflictList()
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, ConflictImpact, conflicts[0].Kind)
+		assert.Equal(t, []string{"//close"}, conflicts[0].Applied)
+		assert.Equal(t, []string{"//deep"}, conflicts[0].Overridden)
+	})
+
+	t.Run("ties are resolved by mrn", func(t *testing.T) {
+		global, parent, child := testConflictCache()
+
+		b := &policyResolverCache{policyMrn: "//b", parentPolicies: map[string]struct{}{"//b": {}}, global: global}
+		a := &policyResolverCache{policyMrn: "//a", parentPolicies: map[string]struct{}{"//a": {}}, global: global}
+
+		b.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 20})
+		a.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 80})
+
+		assert.Equal(t, int32(80), parent.ChildJobs["child"].Value)
+		conflicts := global.conflictList()
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, []string{"//a"}, conflicts[0].Applied)
+		assert.Equal(t, []string{"//b"}, conflicts[0].Overridden)
+	})
+
+	t.Run("equal impacts are no conflict", func(t *testing.T) {
+		global, parent, child := testConflictCache()
+
+		b := &policyResolverCache{policyMrn: "//b", parentPolicies: map[string]struct{}{"//b": {}}, global: global}
+		a := &policyResolverCache{policyMrn: "//a", parentPolicies: map[string]struct{}{"//a": {}}, global: global}
+
+		b.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 20})
+		a.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 20})
+
+		assert.Empty(t, global.conflictList())
+	})
+}
+
+func TestDetectDeactivationConflicts(t *testing.T) {
+	global, _, child := testConflictCache()
+	global.bundleMap = NewPolicyBundleMap("")
+	global.reportingJobsByChecksum["checksum"] = child
+	global.activatedBy["//check"] = []string{"//a"}
+	global.deactivatedBy["//check"] = []string{"//b"}
+	global.deactivatedBy["//other"] = []string{"//b"}
+
+	global.detectDeactivationConflicts()
+	conflicts := global.conflictList()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, ConflictDeactivation, conflicts[0].Kind)
+	assert.Equal(t, "//check", conflicts[0].ID)
+	assert.Equal(t, []string{"//a"}, conflicts[0].Applied)
+	assert.Equal(t, []string{"//b"}, conflicts[0].Overridden)
+}
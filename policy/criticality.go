@@ -0,0 +1,122 @@
+package policy
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor/asset"
+	"google.golang.org/protobuf/proto"
+)
+
+// AssetCriticalityLabel is the inventory label that sets the criticality of
+// an asset, e.g. `mondoo.com/criticality: high`
+const AssetCriticalityLabel = "mondoo.com/criticality"
+
+// AssetCriticality is the business criticality of an asset. Failed checks
+// score worse on critical assets, since their impact is scaled up.
+type AssetCriticality string
+
+const (
+	CriticalityLow      AssetCriticality = "low"
+	CriticalityMedium   AssetCriticality = "medium"
+	CriticalityHigh     AssetCriticality = "high"
+	CriticalityCritical AssetCriticality = "critical"
+)
+
+// DefaultAssetCriticality applies to assets without criticality
+const DefaultAssetCriticality = CriticalityMedium
+
+var criticalityFactors = map[AssetCriticality]float64{
+	CriticalityLow:      0.5,
+	CriticalityMedium:   1,
+	CriticalityHigh:     1.5,
+	CriticalityCritical: 2,
+}
+
+// ParseAssetCriticality parses the name of a criticality
+func ParseAssetCriticality(s string) (AssetCriticality, error) {
+	res := AssetCriticality(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := criticalityFactors[res]; !ok {
+		return "", errors.New("unknown asset criticality '" + s + "', supported are: low, medium, high, critical")
+	}
+	return res, nil
+}
+
+// Factor is the factor by which check impacts are scaled
+func (c AssetCriticality) Factor() float64 {
+	if f, ok := criticalityFactors[c]; ok {
+		return f
+	}
+	return 1
+}
+
+// AssetCriticalityFromAsset returns the criticality of an asset from its
+// labels. Assets without a valid criticality label have the default.
+func AssetCriticalityFromAsset(a *asset.Asset) AssetCriticality {
+	if a == nil {
+		return DefaultAssetCriticality
+	}
+	label, ok := a.Labels[AssetCriticalityLabel]
+	if !ok {
+		return DefaultAssetCriticality
+	}
+	res, err := ParseAssetCriticality(label)
+	if err != nil {
+		log.Warn().Err(err).Str("asset", a.Name).Msg("ignoring criticality of asset")
+		return DefaultAssetCriticality
+	}
+	return res
+}
+
+// Weighting returns the weighting of the criticality
+func (c AssetCriticality) Weighting() *CriticalityWeighting {
+	return &CriticalityWeighting{Criticality: string(c), Factor: c.Factor()}
+}
+
+type assetCriticalityKey struct{}
+
+// WithAssetCriticality sets the criticality of the asset that is resolved
+// with the context. The resolver scales the impact of all checks by it.
+func WithAssetCriticality(ctx context.Context, criticality AssetCriticality) context.Context {
+	return context.WithValue(ctx, assetCriticalityKey{}, criticality)
+}
+
+func assetCriticalityFromContext(ctx context.Context) AssetCriticality {
+	res, ok := ctx.Value(assetCriticalityKey{}).(AssetCriticality)
+	if !ok {
+		return DefaultAssetCriticality
+	}
+	return res
+}
+
+// criticalityChecksum is part of the cache key of resolved policies, since
+// the same policy is weighted differently for other criticalities
+func criticalityChecksum(criticality AssetCriticality) string {
+	return checksumStrings("criticality", strconv.FormatFloat(criticality.Factor(), 'f', -1, 64))
+}
+
+// weightByCriticality scales the impact of all children of all reporting
+// jobs, capped at 100
+func weightByCriticality(collectorJob *CollectorJob, criticality AssetCriticality) {
+	factor := criticality.Factor()
+	if factor == 1 {
+		return
+	}
+
+	for _, rj := range collectorJob.ReportingJobs {
+		for uuid, impact := range rj.ChildJobs {
+			if impact == nil || impact.Value <= 0 {
+				continue
+			}
+			// impacts are shared with the policies
+			impact = proto.Clone(impact).(*explorer.Impact)
+			impact.Value = int32(math.Min(100, math.Round(float64(impact.Value)*factor)))
+			rj.ChildJobs[uuid] = impact
+		}
+	}
+}
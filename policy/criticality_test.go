@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor/asset"
+)
+
+func TestAssetCriticalityFromAsset(t *testing.T) {
+	assert.Equal(t, CriticalityMedium, AssetCriticalityFromAsset(nil))
+	assert.Equal(t, CriticalityCritical, AssetCriticalityFromAsset(&asset.Asset{
+		Labels: map[string]string{AssetCriticalityLabel: " Critical"},
+	}))
+	assert.Equal(t, CriticalityMedium, AssetCriticalityFromAsset(&asset.Asset{
+		Labels: map[string]string{AssetCriticalityLabel: "urgent"},
+	}))
+
+	_, err := ParseAssetCriticality("urgent")
+	assert.Error(t, err)
+}
+
+func TestWeightByCriticality(t *testing.T) {
+	shared := &explorer.Impact{Value: 60, Weight: 2}
+	collectorJob := &CollectorJob{
+		ReportingJobs: map[string]*ReportingJob{
+			"policy": {ChildJobs: map[string]*explorer.Impact{
+				"check1": shared,
+				"check2": {Value: 30},
+				"query":  nil,
+			}},
+		},
+	}
+
+	weightByCriticality(collectorJob, CriticalityHigh)
+	children := collectorJob.ReportingJobs["policy"].ChildJobs
+	require.NotNil(t, children["check1"])
+	assert.Equal(t, int32(90), children["check1"].Value)
+	assert.Equal(t, int32(2), children["check1"].Weight)
+	assert.Equal(t, int32(45), children["check2"].Value)
+	assert.Nil(t, children["query"])
+	// the policy's impact is unchanged
+	assert.Equal(t, int32(60), shared.Value)
+
+	weightByCriticality(collectorJob, CriticalityCritical)
+	assert.Equal(t, int32(100), children["check1"].Value)
+}
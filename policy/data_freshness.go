@@ -0,0 +1,169 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DataTTLTag is the query tag that sets how long the data of a query stays
+// fresh after it was collected, e.g. `1h` or `30m`
+const DataTTLTag = "cnspec/ttl"
+
+// DataFreshnessStore is implemented by datalakes that record when the data
+// of a datapoint was collected
+type DataFreshnessStore interface {
+	// GetDataCollected returns when all datapoints of an asset were last
+	// collected, by checksum
+	GetDataCollected(ctx context.Context, assetMrn string) (map[string]time.Time, error)
+}
+
+// DataFreshness describes how fresh the data of a datapoint is
+type DataFreshness struct {
+	// Collected is when the data was last collected
+	Collected time.Time
+	// TTL is how long the data stays fresh, 0 if it never goes stale
+	TTL time.Duration
+	// Stale is true if the data is older than its TTL
+	Stale bool
+}
+
+// ParseDataTTL reads the TTL of a query's data from its tags. It returns
+// 0 if no TTL is configured.
+func ParseDataTTL(tags map[string]string) (time.Duration, error) {
+	v, ok := tags[DataTTLTag]
+	if !ok {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(strings.TrimSpace(v))
+	if err != nil || ttl <= 0 {
+		return 0, errors.New("invalid ttl '" + v + "', expected a positive duration, e.g. '1h'")
+	}
+	return ttl, nil
+}
+
+// DataTTLByCodeID collects the TTLs of all queries in this bundle, indexed by
+// their code ID. The bundle must be compiled.
+func (p *Bundle) DataTTLByCodeID() (map[string]time.Duration, error) {
+	res := map[string]time.Duration{}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		ttl, err := ParseDataTTL(query.Tags)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse ttl for query "+query.Mrn)
+		}
+		if ttl != 0 && query.CodeId != "" {
+			res[query.CodeId] = ttl
+		}
+	}
+	return res, nil
+}
+
+// DatapointTTLs maps the TTLs of queries (by code ID) to the checksums of all
+// datapoints that they collect in the resolved policy. If multiple queries
+// collect the same datapoint, the shortest TTL applies.
+func DatapointTTLs(resolvedPolicy *ResolvedPolicy, ttls map[string]time.Duration) map[string]time.Duration {
+	res := map[string]time.Duration{}
+	if resolvedPolicy == nil || resolvedPolicy.ExecutionJob == nil {
+		return res
+	}
+
+	for codeID, ttl := range ttls {
+		query, ok := resolvedPolicy.ExecutionJob.Queries[codeID]
+		if !ok {
+			continue
+		}
+		for _, checksum := range query.Datapoints {
+			if cur, ok := res[checksum]; !ok || ttl < cur {
+				res[checksum] = ttl
+			}
+		}
+	}
+	return res
+}
+
+// DatapointFreshness combines when datapoints were collected with their TTLs
+func DatapointFreshness(collected map[string]time.Time, ttls map[string]time.Duration, now time.Time) map[string]*DataFreshness {
+	res := make(map[string]*DataFreshness, len(collected))
+	for checksum, at := range collected {
+		ttl := ttls[checksum]
+		res[checksum] = &DataFreshness{
+			Collected: at,
+			TTL:       ttl,
+			Stale:     ttl != 0 && now.Sub(at) > ttl,
+		}
+	}
+	return res
+}
+
+// StaleDatapoints returns the sorted checksums of all stale datapoints
+func StaleDatapoints(freshness map[string]*DataFreshness) []string {
+	var res []string
+	for checksum, f := range freshness {
+		if f.Stale {
+			res = append(res, checksum)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// GetDataFreshness returns how fresh all datapoints of an asset are, by
+// checksum. TTLs are taken from the queries of the asset's bundle. It is
+// empty if the datalake doesn't record when data was collected.
+func (s *LocalServices) GetDataFreshness(ctx context.Context, assetMrn string) (map[string]*DataFreshness, error) {
+	store, ok := s.DataLake.(DataFreshnessStore)
+	if !ok {
+		return map[string]*DataFreshness{}, nil
+	}
+
+	collected, err := store.GetDataCollected(ctx, assetMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.DataLake.GetValidatedBundle(ctx, assetMrn)
+	if err != nil {
+		return nil, err
+	}
+	ttls, err := bundle.DataTTLByCodeID()
+	if err != nil {
+		return nil, err
+	}
+
+	var datapointTTLs map[string]time.Duration
+	if len(ttls) != 0 {
+		resolvedPolicy, err := s.DataLake.GetResolvedPolicy(ctx, assetMrn)
+		if err != nil {
+			return nil, err
+		}
+		datapointTTLs = DatapointTTLs(resolvedPolicy, ttls)
+	}
+
+	return DatapointFreshness(collected, datapointTTLs, time.Now()), nil
+}
+
+// GetReportWithFreshness retrieves the report of an asset like GetReport,
+// together with the freshness of its data. Stale data is still part of the
+// report, callers decide how to present it or whether to collect it again.
+func (s *LocalServices) GetReportWithFreshness(ctx context.Context, req *EntityScoreReq) (*Report, map[string]*DataFreshness, error) {
+	report, err := s.GetReport(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	freshness, err := s.GetDataFreshness(ctx, req.EntityMrn)
+	if err != nil {
+		return nil, nil, err
+	}
+	for checksum := range freshness {
+		if _, ok := report.Data[checksum]; !ok {
+			delete(freshness, checksum)
+		}
+	}
+	return report, freshness, nil
+}
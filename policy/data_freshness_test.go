@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestParseDataTTL(t *testing.T) {
+	ttl, err := ParseDataTTL(map[string]string{DataTTLTag: " 1h30m"})
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, ttl)
+
+	ttl, err = ParseDataTTL(map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	_, err = ParseDataTTL(map[string]string{DataTTLTag: "soon"})
+	assert.Error(t, err)
+	_, err = ParseDataTTL(map[string]string{DataTTLTag: "-1h"})
+	assert.Error(t, err)
+}
+
+func TestDatapointFreshness(t *testing.T) {
+	bundle := &Bundle{Queries: []*explorer.Mquery{
+		{Mrn: "//query/packages", CodeId: "packages", Tags: map[string]string{DataTTLTag: "1h"}},
+		{Mrn: "//query/users", CodeId: "users", Tags: map[string]string{DataTTLTag: "24h"}},
+		{Mrn: "//query/os", CodeId: "os"},
+	}}
+	ttls, err := bundle.DataTTLByCodeID()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"packages": time.Hour, "users": 24 * time.Hour}, ttls)
+
+	rp := &ResolvedPolicy{ExecutionJob: &ExecutionJob{Queries: map[string]*ExecutionQuery{
+		"packages": {Datapoints: []string{"dp-packages", "dp-shared"}},
+		"users":    {Datapoints: []string{"dp-users", "dp-shared"}},
+		"os":       {Datapoints: []string{"dp-os"}},
+	}}}
+	datapointTTLs := DatapointTTLs(rp, ttls)
+	assert.Equal(t, map[string]time.Duration{
+		"dp-packages": time.Hour, "dp-shared": time.Hour, "dp-users": 24 * time.Hour,
+	}, datapointTTLs)
+
+	now := time.Unix(1700000000, 0)
+	freshness := DatapointFreshness(map[string]time.Time{
+		"dp-packages": now.Add(-2 * time.Hour),
+		"dp-shared":   now.Add(-30 * time.Minute),
+		"dp-users":    now.Add(-2 * time.Hour),
+		"dp-os":       now.Add(-720 * time.Hour),
+	}, datapointTTLs, now)
+	assert.Equal(t, &DataFreshness{Collected: now.Add(-2 * time.Hour), TTL: time.Hour, Stale: true}, freshness["dp-packages"])
+	assert.Equal(t, []string{"dp-packages"}, StaleDatapoints(freshness))
+}
@@ -2,12 +2,19 @@ package policy
 
 import (
 	"context"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.mondoo.com/cnquery/explorer"
 	"go.mondoo.com/cnquery/llx"
 	"go.mondoo.com/cnquery/types"
 )
 
+// ErrResolvedPolicyCacheFull is returned by SetResolvedPolicy if the
+// resolved policy couldn't be cached because the cache is full. Everything
+// else was stored, so callers may continue without the cached entry.
+var ErrResolvedPolicyCacheFull = errors.New("resolved policy cache is full")
+
 // DataLake provides additional database calls, that are not accessible to
 // external users. We use them with specialized tools only. This limits the
 // potential exposure to underlying data and reduces the surface for breaking
@@ -67,16 +74,33 @@ type DataLake interface {
 	// SetResolvedPolicy to the data store; cached indicates if it was cached from
 	// upstream, thus preventing any attempts of resolving it in the client
 	SetResolvedPolicy(ctx context.Context, mrn string, resolvedPolicy *ResolvedPolicy, version ResolvedPolicyVersion, cached bool) error
+	// SetResolutionConflicts stores the policy conflicts that were detected while resolving a policy
+	SetResolutionConflicts(ctx context.Context, resolvedPolicy *ResolvedPolicy, conflicts []*PolicyConflict) error
+	// GetResolutionConflicts returns the policy conflicts that were detected while resolving a policy
+	GetResolutionConflicts(ctx context.Context, resolvedPolicy *ResolvedPolicy) ([]*PolicyConflict, error)
 
 	// GetScore retrieves one score for an asset
 	GetScore(ctx context.Context, assetMrn string, scoreID string) (Score, error)
 	// UpdateScores sets the given scores and returns true if any were updated
 	UpdateScores(ctx context.Context, assetMrn string, scores []*Score) (map[string]struct{}, error)
+	// AppendScoreHistory adds the scores whose value or outcome changed to the
+	// score history of the asset
+	AppendScoreHistory(ctx context.Context, assetMrn string, scores []*Score) error
+	// GetScoreHistory returns the changes of one score within the window,
+	// ordered by time. The first entry is the last change before the window,
+	// if there is one. A window of 0 returns the entire history.
+	GetScoreHistory(ctx context.Context, assetMrn string, qrID string, window time.Duration) ([]ScoreHistoryEntry, error)
 	// UpdateData sets the list of data value for a given asset and returns a list of updated IDs
 	UpdateData(ctx context.Context, assetMrn string, data map[string]*llx.Result) (map[string]types.Type, error)
 
 	// GetReport retrieves all scores and data for a given asset
 	GetReport(ctx context.Context, assetMrn string, qrID string) (*Report, error)
+	// StoreReport stores a copy of the report under its content ID (see
+	// Report.ContentID). It returns false if the ID was already stored, in
+	// which case the stored report is kept.
+	StoreReport(ctx context.Context, id string, report *Report) (bool, error)
+	// GetReportByID returns a report that was stored with StoreReport
+	GetReportByID(ctx context.Context, id string) (*Report, error)
 
 	// EnsureAsset makes sure an asset with mrn exists
 	EnsureAsset(ctx context.Context, mrn string) error
@@ -0,0 +1,225 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DataLakeSearcher is implemented by datalakes that can enumerate the assets,
+// reports and scores they store, e.g. for a UI or CLI that browses a local
+// service
+type DataLakeSearcher interface {
+	// ListAssets returns the assets that match the filter, sorted by MRN
+	ListAssets(ctx context.Context, filter *AssetFilter) (*AssetList, error)
+	// ListReports returns the reports of an asset that were stored with
+	// StoreReport, newest first
+	ListReports(ctx context.Context, assetMrn string, page Pagination) (*ReportList, error)
+	// SearchScores returns the scores that match the query
+	SearchScores(ctx context.Context, query *ScoreQuery) (*ScoreList, error)
+}
+
+// Pagination selects one page of a list
+type Pagination struct {
+	// Offset is the number of items that are skipped
+	Offset int
+	// Limit is the maximum number of items, 0 returns all items
+	Limit int
+}
+
+// Bounds returns the range of the page in a list with n items
+func (p Pagination) Bounds(n int) (int, int) {
+	start := p.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > n {
+		start = n
+	}
+	end := n
+	if p.Limit > 0 && start+p.Limit < n {
+		end = start + p.Limit
+	}
+	return start, end
+}
+
+// AssetFilter selects assets in ListAssets
+type AssetFilter struct {
+	// MrnPrefix only selects assets whose MRN starts with it
+	MrnPrefix string
+	// ScoredOnly only selects assets with a resolved policy
+	ScoredOnly bool
+	Pagination
+}
+
+// AssetInfo describes an asset in the datalake
+type AssetInfo struct {
+	Mrn                   string
+	ResolvedPolicyVersion string
+	// Score is the overall score of the asset, nil if it wasn't scored
+	Score *Score
+}
+
+// AssetList is one page of assets
+type AssetList struct {
+	Assets []*AssetInfo
+	// Total is the number of assets that match the filter on all pages
+	Total int
+}
+
+// Matches returns true if the filter selects the asset
+func (f *AssetFilter) Matches(asset *AssetInfo, scored bool) bool {
+	if f == nil {
+		return true
+	}
+	if f.MrnPrefix != "" && !strings.HasPrefix(asset.Mrn, f.MrnPrefix) {
+		return false
+	}
+	return scored || !f.ScoredOnly
+}
+
+// StoredReport is a report that was stored with StoreReport
+type StoredReport struct {
+	ID     string
+	Report *Report
+}
+
+// ReportList is one page of stored reports
+type ReportList struct {
+	Reports []*StoredReport
+	// Total is the number of stored reports of the asset
+	Total int
+}
+
+// SortStoredReports sorts reports by their creation time, newest first, and
+// then by ID
+func SortStoredReports(reports []*StoredReport) {
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Report.Created != reports[j].Report.Created {
+			return reports[i].Report.Created > reports[j].Report.Created
+		}
+		return reports[i].ID < reports[j].ID
+	})
+}
+
+// ScoreSortField is the field by which SearchScores sorts its results
+type ScoreSortField string
+
+const (
+	// SortScoresByAsset sorts scores by asset MRN and then by their ID
+	SortScoresByAsset ScoreSortField = "asset"
+	// SortScoresByID sorts scores by their ID and then by asset MRN
+	SortScoresByID ScoreSortField = "id"
+	// SortScoresByValue sorts scores by their value, then like SortScoresByAsset
+	SortScoresByValue ScoreSortField = "value"
+)
+
+// ScoreQuery selects scores in SearchScores
+type ScoreQuery struct {
+	// AssetMrn only selects scores of this asset, all assets if empty
+	AssetMrn string
+	// QrIDs only selects scores with these IDs, all scores if empty
+	QrIDs []string
+	// MinValue and MaxValue select scores by their value; a MaxValue of 0
+	// selects all values above MinValue
+	MinValue uint32
+	MaxValue uint32
+	// SortBy defaults to SortScoresByAsset
+	SortBy     ScoreSortField
+	Descending bool
+	Pagination
+}
+
+// ScoreResult is a score of an asset found by SearchScores
+type ScoreResult struct {
+	AssetMrn string
+	Score    *Score
+}
+
+// ScoreList is one page of scores
+type ScoreList struct {
+	Scores []*ScoreResult
+	// Total is the number of scores that match the query on all pages
+	Total int
+}
+
+// Validate returns an error if the query can't be run
+func (q *ScoreQuery) Validate() error {
+	if q.MaxValue != 0 && q.MaxValue < q.MinValue {
+		return errors.New("max value of scores must not be lower than their min value")
+	}
+	switch q.SortBy {
+	case "", SortScoresByAsset, SortScoresByID, SortScoresByValue:
+		return nil
+	default:
+		return errors.New("cannot sort scores by unknown field '" + string(q.SortBy) + "'")
+	}
+}
+
+// Apply filters the candidates by the query, sorts them and returns the
+// selected page. Candidates must already be limited to the asset of the
+// query, if it has one.
+func (q *ScoreQuery) Apply(candidates []*ScoreResult) (*ScoreList, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var ids map[string]struct{}
+	if len(q.QrIDs) != 0 {
+		ids = make(map[string]struct{}, len(q.QrIDs))
+		for _, id := range q.QrIDs {
+			ids[id] = struct{}{}
+		}
+	}
+
+	res := []*ScoreResult{}
+	for _, cur := range candidates {
+		if cur.Score == nil {
+			continue
+		}
+		if ids != nil {
+			if _, ok := ids[cur.Score.QrId]; !ok {
+				continue
+			}
+		}
+		if cur.Score.Value < q.MinValue || (q.MaxValue != 0 && cur.Score.Value > q.MaxValue) {
+			continue
+		}
+		res = append(res, cur)
+	}
+
+	byAsset := func(a, b *ScoreResult) bool {
+		if a.AssetMrn != b.AssetMrn {
+			return a.AssetMrn < b.AssetMrn
+		}
+		return a.Score.QrId < b.Score.QrId
+	}
+	less := byAsset
+	switch q.SortBy {
+	case SortScoresByID:
+		less = func(a, b *ScoreResult) bool {
+			if a.Score.QrId != b.Score.QrId {
+				return a.Score.QrId < b.Score.QrId
+			}
+			return a.AssetMrn < b.AssetMrn
+		}
+	case SortScoresByValue:
+		less = func(a, b *ScoreResult) bool {
+			if a.Score.Value != b.Score.Value {
+				return a.Score.Value < b.Score.Value
+			}
+			return byAsset(a, b)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if q.Descending {
+			return less(res[j], res[i])
+		}
+		return less(res[i], res[j])
+	})
+
+	start, end := q.Bounds(len(res))
+	return &ScoreList{Scores: res[start:end], Total: len(res)}, nil
+}
@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationBounds(t *testing.T) {
+	start, end := Pagination{}.Bounds(5)
+	assert.Equal(t, []int{0, 5}, []int{start, end})
+
+	start, end = Pagination{Offset: 2, Limit: 2}.Bounds(5)
+	assert.Equal(t, []int{2, 4}, []int{start, end})
+
+	start, end = Pagination{Offset: 4, Limit: 2}.Bounds(5)
+	assert.Equal(t, []int{4, 5}, []int{start, end})
+
+	start, end = Pagination{Offset: 10}.Bounds(5)
+	assert.Equal(t, []int{5, 5}, []int{start, end})
+}
+
+func TestScoreQueryApply(t *testing.T) {
+	candidates := []*ScoreResult{
+		{AssetMrn: "//asset/b", Score: &Score{QrId: "check-1", Value: 40}},
+		{AssetMrn: "//asset/a", Score: &Score{QrId: "check-2", Value: 100}},
+		{AssetMrn: "//asset/a", Score: &Score{QrId: "check-1", Value: 0}},
+		{AssetMrn: "//asset/b", Score: &Score{QrId: "check-2", Value: 80}},
+	}
+	ids := func(list *ScoreList) []string {
+		res := make([]string, len(list.Scores))
+		for i, cur := range list.Scores {
+			res[i] = cur.AssetMrn + " " + cur.Score.QrId
+		}
+		return res
+	}
+
+	t.Run("sorted by asset by default", func(t *testing.T) {
+		res, err := (&ScoreQuery{}).Apply(candidates)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.Total)
+		assert.Equal(t, []string{"//asset/a check-1", "//asset/a check-2", "//asset/b check-1", "//asset/b check-2"}, ids(res))
+	})
+
+	t.Run("filtered by value and paginated", func(t *testing.T) {
+		res, err := (&ScoreQuery{
+			MinValue:   1,
+			MaxValue:   99,
+			SortBy:     SortScoresByValue,
+			Descending: true,
+			Pagination: Pagination{Limit: 1},
+		}).Apply(candidates)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Total)
+		assert.Equal(t, []string{"//asset/b check-2"}, ids(res))
+	})
+
+	t.Run("filtered by ID", func(t *testing.T) {
+		res, err := (&ScoreQuery{QrIDs: []string{"check-1"}, SortBy: SortScoresByID}).Apply(candidates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"//asset/a check-1", "//asset/b check-1"}, ids(res))
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		_, err := (&ScoreQuery{MinValue: 50, MaxValue: 10}).Apply(candidates)
+		assert.Error(t, err)
+		_, err = (&ScoreQuery{SortBy: "grade"}).Apply(candidates)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,209 @@
+package policy
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const redactedPlaceholder = "[REDACTED]"
+
+// DebugChecksums are the checksums of a policy in the asset's bundle
+type DebugChecksums struct {
+	LocalContentChecksum   string `json:"local_content_checksum"`
+	GraphContentChecksum   string `json:"graph_content_checksum"`
+	LocalExecutionChecksum string `json:"local_execution_checksum"`
+	GraphExecutionChecksum string `json:"graph_execution_checksum"`
+}
+
+// DebugArchive collects the internal state of an asset's policy resolution
+// and scoring, which is needed to investigate support cases. Call Redact
+// to remove sensitive information before writing it.
+type DebugArchive struct {
+	AssetMrn        string                    `json:"asset_mrn"`
+	Created         time.Time                 `json:"created"`
+	ResolvedPolicy  *ResolvedPolicy           `json:"resolved_policy"`
+	Report          *Report                   `json:"report"`
+	BundleChecksums map[string]DebugChecksums `json:"bundle_checksums"`
+	Conflicts       []*PolicyConflict         `json:"conflicts"`
+	Errors          []string                  `json:"errors"`
+
+	redactions []string
+}
+
+// DebugBundle collects the asset's resolved policy, collector job, scores,
+// bundle checksums and errors into one archive. Parts that cannot be
+// retrieved are recorded as errors in the archive.
+func DebugBundle(ctx context.Context, assetMrn string, datalake DataLake) (*DebugArchive, error) {
+	if assetMrn == "" {
+		return nil, errors.New("asset mrn is required for the debug bundle")
+	}
+
+	res := &DebugArchive{
+		AssetMrn:        assetMrn,
+		Created:         time.Now(),
+		BundleChecksums: map[string]DebugChecksums{},
+	}
+
+	resolvedPolicy, err := datalake.GetResolvedPolicy(ctx, assetMrn)
+	if err != nil {
+		res.Errors = append(res.Errors, "failed to get resolved policy: "+err.Error())
+	} else {
+		res.ResolvedPolicy = proto.Clone(resolvedPolicy).(*ResolvedPolicy)
+
+		conflicts, err := datalake.GetResolutionConflicts(ctx, resolvedPolicy)
+		if err != nil {
+			res.Errors = append(res.Errors, "failed to get policy conflicts: "+err.Error())
+		}
+		res.Conflicts = conflicts
+	}
+
+	report, err := datalake.GetReport(ctx, assetMrn, assetMrn)
+	if err != nil {
+		res.Errors = append(res.Errors, "failed to get report: "+err.Error())
+	} else {
+		res.Report = proto.Clone(report).(*Report)
+		res.Errors = append(res.Errors, reportErrors(report)...)
+	}
+
+	bundle, err := datalake.GetValidatedBundle(ctx, assetMrn)
+	if err != nil {
+		res.Errors = append(res.Errors, "failed to get bundle: "+err.Error())
+	} else {
+		for i := range bundle.Policies {
+			p := bundle.Policies[i]
+			res.BundleChecksums[p.Mrn] = DebugChecksums{
+				LocalContentChecksum:   p.LocalContentChecksum,
+				GraphContentChecksum:   p.GraphContentChecksum,
+				LocalExecutionChecksum: p.LocalExecutionChecksum,
+				GraphExecutionChecksum: p.GraphExecutionChecksum,
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// reportErrors collects all errors from scores and data in a report
+func reportErrors(report *Report) []string {
+	res := []string{}
+	for id, score := range report.Scores {
+		if score != nil && score.Type == ScoreType_Error {
+			res = append(res, "score "+id+": "+score.MessageLine())
+		}
+	}
+	for id, data := range report.Data {
+		if data != nil && data.Error != "" {
+			res = append(res, "data "+id+": "+data.Error)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// DebugRedaction configures which information is removed from a debug archive
+type DebugRedaction struct {
+	// Data removes all collected data values
+	Data bool
+	// Messages removes all score and error messages
+	Messages bool
+	// Strings are replaced everywhere in the archive, e.g. asset names or IPs
+	Strings []string
+}
+
+// Redact removes sensitive information from the archive
+func (a *DebugArchive) Redact(r DebugRedaction) {
+	if a.Report != nil {
+		if r.Data {
+			for id := range a.Report.Data {
+				a.Report.Data[id] = nil
+			}
+		}
+		if r.Messages {
+			for _, score := range a.Report.Scores {
+				if score != nil && score.Message != "" {
+					score.Message = redactedPlaceholder
+				}
+			}
+		}
+	}
+
+	if r.Messages {
+		for i := range a.Errors {
+			if idx := strings.Index(a.Errors[i], ": "); idx != -1 {
+				a.Errors[i] = a.Errors[i][:idx+2] + redactedPlaceholder
+			}
+		}
+	}
+
+	for i := range r.Strings {
+		if r.Strings[i] != "" {
+			a.redactions = append(a.redactions, r.Strings[i])
+		}
+	}
+}
+
+// Write the archive as a zip file with one JSON file per section
+func (a *DebugArchive) Write(w io.Writer) error {
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{"manifest.json", map[string]interface{}{"asset_mrn": a.AssetMrn, "created": a.Created}},
+		{"resolved_policy.json", a.ResolvedPolicy},
+		{"collector_job.json", a.collectorJob()},
+		{"report.json", a.Report},
+		{"bundle_checksums.json", a.BundleChecksums},
+		{"conflicts.json", a.Conflicts},
+		{"errors.json", a.Errors},
+	}
+
+	zw := zip.NewWriter(w)
+	for i := range files {
+		data, err := json.MarshalIndent(files[i].content, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to write "+files[i].name)
+		}
+		data = a.applyRedactions(data)
+
+		fw, err := zw.Create(files[i].name)
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (a *DebugArchive) collectorJob() *CollectorJob {
+	if a.ResolvedPolicy == nil {
+		return nil
+	}
+	return a.ResolvedPolicy.CollectorJob
+}
+
+func (a *DebugArchive) applyRedactions(data []byte) []byte {
+	if len(a.redactions) == 0 {
+		return data
+	}
+
+	pairs := make([]string, 0, len(a.redactions)*2)
+	for i := range a.redactions {
+		// values are stored json-encoded, so we need to look for their encoded form
+		encoded, err := json.Marshal(a.redactions[i])
+		if err != nil {
+			continue
+		}
+		pairs = append(pairs, string(encoded[1:len(encoded)-1]), redactedPlaceholder)
+	}
+	return []byte(strings.NewReplacer(pairs...).Replace(string(data)))
+}
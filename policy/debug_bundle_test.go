@@ -0,0 +1,55 @@
+package policy
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+)
+
+func TestDebugArchive_Redact(t *testing.T) {
+	archive := &DebugArchive{
+		AssetMrn: "//assets/secret-host",
+		Report: &Report{
+			EntityMrn: "//assets/secret-host",
+			Scores: map[string]*Score{
+				"check": {QrId: "check", Type: ScoreType_Error, Message: "password=hunter2"},
+			},
+			Data: map[string]*llx.Result{
+				"data": {CodeId: "data", Error: "cannot read secret-host config"},
+			},
+		},
+	}
+	archive.Errors = reportErrors(archive.Report)
+	require.Equal(t, []string{
+		"data data: cannot read secret-host config",
+		"score check: password=hunter2",
+	}, archive.Errors)
+
+	archive.Redact(DebugRedaction{
+		Data:     true,
+		Messages: true,
+		Strings:  []string{"secret-host"},
+	})
+	assert.Nil(t, archive.Report.Data["data"])
+	assert.Equal(t, redactedPlaceholder, archive.Report.Scores["check"].Message)
+
+	buf := bytes.Buffer{}
+	require.NoError(t, archive.Write(&buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.NotEmpty(t, zr.File)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "secret-host", f.Name)
+		assert.NotContains(t, string(data), "hunter2", f.Name)
+	}
+}
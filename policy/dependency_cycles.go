@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"strings"
+)
+
+// DependencyCycleError is returned when policies of a bundle depend on each
+// other in a cycle, which can never be resolved
+type DependencyCycleError struct {
+	// Path are the MRNs of the policies in the cycle, starting and ending with
+	// the same policy
+	Path []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	return "policy dependency cycle: " + strings.Join(e.Path, " → ")
+}
+
+// DetectCycles returns a DependencyCycleError with the full path of the first
+// cycle in the dependencies of the bundle's policies. Policies are visited
+// by MRN, so the same bundle always reports the same cycle. Dependencies
+// that aren't part of the bundle are ignored.
+func (p *PolicyBundleMap) DetectCycles() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(p.Policies))
+	var path []string
+
+	var visit func(policyMrn string) error
+	visit = func(policyMrn string) error {
+		switch state[policyMrn] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == policyMrn {
+					cycle := append([]string{}, path[i:]...)
+					return &DependencyCycleError{Path: append(cycle, policyMrn)}
+				}
+			}
+		}
+
+		policy, ok := p.Policies[policyMrn]
+		if !ok || policy == nil {
+			return nil
+		}
+
+		state[policyMrn] = visiting
+		path = append(path, policyMrn)
+		for _, group := range policy.Groups {
+			if group == nil {
+				continue
+			}
+			for _, ref := range group.Policies {
+				if ref == nil || ref.Mrn == "" {
+					continue
+				}
+				if err := visit(ref.Mrn); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[policyMrn] = visited
+		return nil
+	}
+
+	for _, policyMrn := range sortedKeys(p.Policies) {
+		if err := visit(policyMrn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
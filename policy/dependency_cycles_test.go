@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cyclePolicy(policyMrn string, deps ...string) *Policy {
+	refs := make([]*PolicyRef, len(deps))
+	for i := range deps {
+		refs[i] = &PolicyRef{Mrn: deps[i]}
+	}
+	return &Policy{
+		Mrn:    policyMrn,
+		Groups: []*PolicyGroup{{Policies: refs}},
+	}
+}
+
+func TestDetectCycles(t *testing.T) {
+	t.Run("no cycle", func(t *testing.T) {
+		bundle := &Bundle{Policies: []*Policy{
+			cyclePolicy("//a", "//b", "//c"),
+			cyclePolicy("//b", "//c", "//external"),
+			cyclePolicy("//c"),
+		}}
+		pbm := bundle.ToMap()
+		require.NoError(t, pbm.DetectCycles())
+
+		policies, err := pbm.PoliciesSortedByDependency()
+		require.NoError(t, err)
+		assert.Len(t, policies, 3)
+	})
+
+	t.Run("cycle with full path", func(t *testing.T) {
+		bundle := &Bundle{Policies: []*Policy{
+			cyclePolicy("//a", "//b"),
+			cyclePolicy("//b", "//c"),
+			cyclePolicy("//c", "//a"),
+		}}
+		pbm := bundle.ToMap()
+		err := pbm.DetectCycles()
+		require.Error(t, err)
+		cycle, ok := err.(*DependencyCycleError)
+		require.True(t, ok)
+		assert.Equal(t, []string{"//a", "//b", "//c", "//a"}, cycle.Path)
+		assert.Equal(t, "policy dependency cycle: //a → //b → //c → //a", err.Error())
+
+		_, err = pbm.PoliciesSortedByDependency()
+		assert.Equal(t, cycle, err)
+	})
+
+	t.Run("self reference", func(t *testing.T) {
+		bundle := &Bundle{Policies: []*Policy{
+			cyclePolicy("//a", "//a"),
+		}}
+		err := bundle.ToMap().DetectCycles()
+		require.Error(t, err)
+		assert.Equal(t, "policy dependency cycle: //a → //a", err.Error())
+	})
+}
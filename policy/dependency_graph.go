@@ -0,0 +1,208 @@
+package policy
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// DependencyNodeKind is the kind of element in a DependencyGraph
+type DependencyNodeKind string
+
+const (
+	DependencyPolicy DependencyNodeKind = "policy"
+	DependencyGroup  DependencyNodeKind = "group"
+	DependencyCheck  DependencyNodeKind = "check"
+	DependencyQuery  DependencyNodeKind = "query"
+)
+
+// DependencyNode is a policy, group, check or query of a bundle
+type DependencyNode struct {
+	// ID is the MRN of the element, or its UID if the bundle isn't compiled.
+	// Groups are identified by their policy and index.
+	ID    string
+	Kind  DependencyNodeKind
+	Title string
+	// Checksum is the graph content checksum of policies and the checksum of
+	// checks and queries. It's empty if the bundle isn't compiled.
+	Checksum string
+	// External is true for policies and queries that are referenced but not
+	// defined in the bundle
+	External bool
+}
+
+// DependencyEdge points from an element to one it depends on
+type DependencyEdge struct {
+	From string
+	To   string
+}
+
+// DependencyGraph is the graph of policies, groups, checks and queries of a
+// bundle, see Bundle.DependencyGraph
+type DependencyGraph struct {
+	// Nodes are sorted by ID
+	Nodes []*DependencyNode
+	// Edges are sorted by their source and then by their target
+	Edges []DependencyEdge
+}
+
+// DependencyGraph returns the graph of all policies in the bundle with their
+// groups, and of the checks, queries and policies that the groups reference.
+// It shows what PoliciesSortedByDependency sorts by, so that authors can
+// spot unintended dependencies.
+func (p *Bundle) DependencyGraph() *DependencyGraph {
+	nodes := map[string]*DependencyNode{}
+	edges := map[DependencyEdge]struct{}{}
+
+	queries := make(map[string]*explorer.Mquery, len(p.Queries))
+	for _, query := range p.Queries {
+		queries[elementID(query.Mrn, query.Uid)] = query
+	}
+
+	addPolicy := func(id string, title string, checksum string, external bool) {
+		if cur, ok := nodes[id]; ok && !cur.External {
+			return
+		}
+		nodes[id] = &DependencyNode{ID: id, Kind: DependencyPolicy, Title: title, Checksum: checksum, External: external}
+	}
+	addQuery := func(from string, ref *explorer.Mquery, kind DependencyNodeKind) {
+		id := elementID(ref.Mrn, ref.Uid)
+		if id == "" {
+			return
+		}
+		edges[DependencyEdge{From: from, To: id}] = struct{}{}
+		if _, ok := nodes[id]; ok {
+			return
+		}
+
+		node := &DependencyNode{ID: id, Kind: kind, Title: ref.Title, Checksum: ref.Checksum}
+		if query, ok := queries[id]; ok {
+			node.Title = query.Title
+			node.Checksum = query.Checksum
+		} else if ref.Mql == "" {
+			node.External = true
+		}
+		nodes[id] = node
+	}
+
+	for _, policy := range p.Policies {
+		policyID := elementID(policy.Mrn, policy.Uid)
+		addPolicy(policyID, policy.Name, policy.GraphContentChecksum, false)
+
+		for i, group := range policy.Groups {
+			groupID := policyID + "#group" + strconv.Itoa(i)
+			nodes[groupID] = &DependencyNode{ID: groupID, Kind: DependencyGroup, Title: group.Title}
+			edges[DependencyEdge{From: policyID, To: groupID}] = struct{}{}
+
+			for _, ref := range group.Policies {
+				id := elementID(ref.Mrn, ref.Uid)
+				if id == "" {
+					continue
+				}
+				addPolicy(id, "", ref.GraphContentChecksum, true)
+				edges[DependencyEdge{From: groupID, To: id}] = struct{}{}
+			}
+			for _, check := range group.Checks {
+				addQuery(groupID, check, DependencyCheck)
+			}
+			for _, query := range group.Queries {
+				addQuery(groupID, query, DependencyQuery)
+			}
+		}
+	}
+
+	res := &DependencyGraph{
+		Nodes: make([]*DependencyNode, 0, len(nodes)),
+		Edges: make([]DependencyEdge, 0, len(edges)),
+	}
+	for _, id := range sortedKeys(nodes) {
+		res.Nodes = append(res.Nodes, nodes[id])
+	}
+	for edge := range edges {
+		res.Edges = append(res.Edges, edge)
+	}
+	sort.Slice(res.Edges, func(i, j int) bool {
+		if res.Edges[i].From != res.Edges[j].From {
+			return res.Edges[i].From < res.Edges[j].From
+		}
+		return res.Edges[i].To < res.Edges[j].To
+	})
+	return res
+}
+
+// label is the text of a node in rendered graphs
+func (n *DependencyNode) label() string {
+	res := string(n.Kind) + ": "
+	if n.Title != "" {
+		res += n.Title
+	} else {
+		res += n.ID
+	}
+	if n.Checksum != "" {
+		res += "\n" + n.Checksum
+	}
+	if n.External {
+		res += "\n(external)"
+	}
+	return res
+}
+
+var dotShapes = map[DependencyNodeKind]string{
+	DependencyPolicy: "box",
+	DependencyGroup:  "folder",
+	DependencyCheck:  "ellipse",
+	DependencyQuery:  "note",
+}
+
+// DOT renders the graph in the Graphviz DOT language
+func (g *DependencyGraph) DOT() string {
+	var res strings.Builder
+	res.WriteString("digraph dependencies {\n")
+	for _, node := range g.Nodes {
+		res.WriteString("  " + strconv.Quote(node.ID) + " [label=" + strconv.Quote(node.label()) + ", shape=" + dotShapes[node.Kind])
+		if node.External {
+			res.WriteString(", style=dashed")
+		}
+		res.WriteString("];\n")
+	}
+	for _, edge := range g.Edges {
+		res.WriteString("  " + strconv.Quote(edge.From) + " -> " + strconv.Quote(edge.To) + ";\n")
+	}
+	res.WriteString("}\n")
+	return res.String()
+}
+
+// mermaidLabel escapes a label for Mermaid, which doesn't support quotes
+// or newlines in labels
+func mermaidLabel(s string) string {
+	s = strings.ReplaceAll(s, "\"", "#quot;")
+	return strings.ReplaceAll(s, "\n", "<br/>")
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Nodes are numbered in
+// the order of the graph, since Mermaid IDs can't contain MRNs.
+func (g *DependencyGraph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+	var res strings.Builder
+	res.WriteString("flowchart TD\n")
+	for i, node := range g.Nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[node.ID] = id
+		open, closing := "[\"", "\"]"
+		switch node.Kind {
+		case DependencyGroup:
+			open, closing = "[/\"", "\"/]"
+		case DependencyCheck:
+			open, closing = "(\"", "\")"
+		case DependencyQuery:
+			open, closing = "[[\"", "\"]]"
+		}
+		res.WriteString("  " + id + open + mermaidLabel(node.label()) + closing + "\n")
+	}
+	for _, edge := range g.Edges {
+		res.WriteString("  " + ids[edge.From] + " --> " + ids[edge.To] + "\n")
+	}
+	return res.String()
+}
@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	bundle := &Bundle{
+		Policies: []*Policy{{
+			Uid:  "parent",
+			Name: "Parent",
+			Groups: []*PolicyGroup{{
+				Title:    "Base",
+				Policies: []*PolicyRef{{Uid: "child"}, {Uid: "vendor"}},
+				Checks:   []*explorer.Mquery{{Uid: "check"}},
+			}},
+		}, {
+			Uid:  "child",
+			Name: "Child",
+			Groups: []*PolicyGroup{{
+				Queries: []*explorer.Mquery{{Uid: "query"}},
+			}},
+		}},
+		Queries: []*explorer.Mquery{
+			{Uid: "check", Mql: "true", Title: "Check", Checksum: "c1"},
+			{Uid: "query", Mql: "asset.name", Title: "Query"},
+		},
+	}
+
+	graph := bundle.DependencyGraph()
+	ids := make([]string, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		ids[i] = node.ID
+	}
+	assert.Equal(t, []string{"check", "child", "child#group0", "parent", "parent#group0", "query", "vendor"}, ids)
+
+	require.Equal(t, DependencyCheck, graph.Nodes[0].Kind)
+	assert.Equal(t, "c1", graph.Nodes[0].Checksum)
+	assert.False(t, graph.Nodes[1].External)
+	assert.Equal(t, "Child", graph.Nodes[1].Title)
+	assert.True(t, graph.Nodes[6].External)
+
+	assert.Equal(t, []DependencyEdge{
+		{From: "child", To: "child#group0"},
+		{From: "child#group0", To: "query"},
+		{From: "parent", To: "parent#group0"},
+		{From: "parent#group0", To: "check"},
+		{From: "parent#group0", To: "child"},
+		{From: "parent#group0", To: "vendor"},
+	}, graph.Edges)
+
+	dot := graph.DOT()
+	assert.Contains(t, dot, `"parent#group0" -> "child";`)
+	assert.Contains(t, dot, `"vendor" [label="policy: vendor\n(external)", shape=box, style=dashed];`)
+
+	mermaid := graph.Mermaid()
+	assert.Contains(t, mermaid, "flowchart TD\n")
+	assert.Contains(t, mermaid, `n0("check: Check<br/>c1")`)
+	assert.Contains(t, mermaid, "n4 --> n1\n")
+}
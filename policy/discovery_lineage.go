@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"context"
+
+	"go.mondoo.com/cnquery/motor/asset"
+)
+
+// Labels that the scanner sets on all assets that discovery found for an
+// inventory entry, see DiscoveryLineage
+const (
+	DiscoveryCorrelationLabel = "mondoo.com/discovery-correlation-id"
+	DiscoveryRootLabel        = "mondoo.com/discovery-root"
+	DiscoveryCredentialLabel  = "mondoo.com/discovery-credential"
+)
+
+// Apply sets the labels of the lineage on the asset
+func (l *DiscoveryLineage) Apply(a *asset.Asset) {
+	if l == nil || a == nil {
+		return
+	}
+	if a.Labels == nil {
+		a.Labels = map[string]string{}
+	}
+	a.Labels[DiscoveryCorrelationLabel] = l.CorrelationId
+	a.Labels[DiscoveryRootLabel] = l.Root
+	if l.Credential != "" {
+		a.Labels[DiscoveryCredentialLabel] = l.Credential
+	}
+}
+
+// DiscoveryLineageFromAsset reads the lineage of an asset from its labels.
+// It returns nil if the asset wasn't discovered from an inventory entry.
+func DiscoveryLineageFromAsset(a *asset.Asset) *DiscoveryLineage {
+	if a == nil || a.Labels[DiscoveryCorrelationLabel] == "" {
+		return nil
+	}
+	return &DiscoveryLineage{
+		CorrelationId: a.Labels[DiscoveryCorrelationLabel],
+		Root:          a.Labels[DiscoveryRootLabel],
+		Credential:    a.Labels[DiscoveryCredentialLabel],
+	}
+}
+
+// DiscoveryLineageStore is implemented by datalakes that keep the discovery
+// lineage of scanned assets
+type DiscoveryLineageStore interface {
+	// SetDiscoveryLineage stores the lineage of an asset
+	SetDiscoveryLineage(ctx context.Context, assetMrn string, lineage *DiscoveryLineage) error
+	// GetDiscoveryLineage returns the lineage of an asset, nil if it is
+	// unknown
+	GetDiscoveryLineage(ctx context.Context, assetMrn string) (*DiscoveryLineage, error)
+	// ListDiscoveredAssets returns the MRNs of all assets with the given
+	// correlation ID, sorted
+	ListDiscoveredAssets(ctx context.Context, correlationID string) ([]string, error)
+}
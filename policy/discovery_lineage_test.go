@@ -0,0 +1,35 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnquery/motor/asset"
+)
+
+func TestDiscoveryLineage(t *testing.T) {
+	t.Run("roundtrip via labels", func(t *testing.T) {
+		lineage := &DiscoveryLineage{
+			CorrelationId: "abc",
+			Root:          "aws-prod",
+			Credential:    "secret-1",
+		}
+		a := &asset.Asset{Name: "i-0a1b2c"}
+		lineage.Apply(a)
+		assert.Equal(t, "abc", a.Labels[DiscoveryCorrelationLabel])
+		assert.Equal(t, lineage, DiscoveryLineageFromAsset(a))
+	})
+
+	t.Run("without credential", func(t *testing.T) {
+		a := &asset.Asset{Labels: map[string]string{"team": "platform"}}
+		(&DiscoveryLineage{CorrelationId: "abc", Root: "localhost"}).Apply(a)
+		_, ok := a.Labels[DiscoveryCredentialLabel]
+		assert.False(t, ok)
+		assert.Equal(t, "platform", a.Labels["team"])
+	})
+
+	t.Run("assets without lineage", func(t *testing.T) {
+		assert.Nil(t, DiscoveryLineageFromAsset(nil))
+		assert.Nil(t, DiscoveryLineageFromAsset(&asset.Asset{Labels: map[string]string{"team": "platform"}}))
+	})
+}
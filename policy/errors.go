@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error kinds of the policy services. The errors that services and datalakes
+// return for them are ServiceErrors, use errors.Is to check for a kind.
+var (
+	ErrAssetNotFound  = errors.New("asset not found")
+	ErrPolicyNotFound = errors.New("policy not found")
+	ErrScoreNotFound  = errors.New("score not found")
+	// ErrInvalidState is the kind of errors for objects that exist but can't
+	// be used yet, e.g. assets whose policies weren't resolved
+	ErrInvalidState = errors.New("invalid state")
+)
+
+// errorReasons identify the error kinds in the details of statuses that are
+// sent to clients, see ServiceErrorFromStatus
+var errorReasons = map[error]string{
+	ErrAssetNotFound:  "asset-not-found",
+	ErrPolicyNotFound: "policy-not-found",
+	ErrScoreNotFound:  "score-not-found",
+	ErrInvalidState:   "invalid-state",
+}
+
+// ServiceError is an error about one asset, policy or score. It carries the
+// MRN of the object and is sent to clients with the gRPC status code of its
+// kind, see ErrorCode, and its kind and MRN as details.
+type ServiceError struct {
+	// Kind is one of the error kinds, e.g. ErrAssetNotFound
+	Kind error
+	// Mrn is the MRN of the asset or policy
+	Mrn string
+	// ID further identifies the object, e.g. the QrId of a score
+	ID  string
+	Msg string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Msg
+}
+
+func (e *ServiceError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Code returns the gRPC status code of the error's kind
+func (e *ServiceError) Code() codes.Code {
+	switch e.Kind {
+	case ErrAssetNotFound, ErrPolicyNotFound, ErrScoreNotFound:
+		return codes.NotFound
+	case ErrInvalidState:
+		return codes.FailedPrecondition
+	default:
+		return codes.Unknown
+	}
+}
+
+// GRPCStatus is used by ranger and gRPC to send the error to clients
+func (e *ServiceError) GRPCStatus() *status.Status {
+	st := status.New(e.Code(), e.Msg)
+	reason, ok := errorReasons[e.Kind]
+	if !ok {
+		return st
+	}
+
+	metadata := map[string]string{"mrn": e.Mrn}
+	if e.ID != "" {
+		metadata["id"] = e.ID
+	}
+	std, err := st.WithDetails(&errdetails.ErrorInfo{
+		Domain:   POLICY_SERVICE_NAME,
+		Reason:   reason,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st
+	}
+	return std
+}
+
+// ServiceErrorFromStatus restores the ServiceError of a status that was
+// received from the policy services, so that clients can check its kind
+// with errors.Is. Other errors are returned unchanged.
+func ServiceErrorFromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != POLICY_SERVICE_NAME {
+			continue
+		}
+		for kind, reason := range errorReasons {
+			if info.Reason == reason {
+				return &ServiceError{
+					Kind: kind,
+					Mrn:  info.Metadata["mrn"],
+					ID:   info.Metadata["id"],
+					Msg:  st.Message(),
+				}
+			}
+		}
+	}
+	return err
+}
+
+// NewAssetNotFoundError is returned for assets that don't exist
+func NewAssetNotFoundError(assetMrn string) error {
+	return &ServiceError{
+		Kind: ErrAssetNotFound,
+		Mrn:  assetMrn,
+		Msg:  "cannot find asset '" + assetMrn + "'",
+	}
+}
+
+// NewPolicyNotFoundError is returned for policies that don't exist
+func NewPolicyNotFoundError(policyMrn string) error {
+	return &ServiceError{
+		Kind: ErrPolicyNotFound,
+		Mrn:  policyMrn,
+		Msg:  "policy '" + policyMrn + "' not found",
+	}
+}
+
+// NewScoreNotFoundError is returned for scores that weren't stored for an asset
+func NewScoreNotFoundError(assetMrn string, qrID string) error {
+	return &ServiceError{
+		Kind: ErrScoreNotFound,
+		Mrn:  assetMrn,
+		ID:   qrID,
+		Msg:  "score for asset '" + assetMrn + "' with ID '" + qrID + "' not found",
+	}
+}
+
+// NewInvalidStateError is returned for objects that can't be used yet, with
+// a message that explains why
+func NewInvalidStateError(mrn string, msg string) error {
+	return &ServiceError{
+		Kind: ErrInvalidState,
+		Mrn:  mrn,
+		Msg:  msg,
+	}
+}
+
+// ErrorCode returns the gRPC status code of an error, also if it wraps a
+// ServiceError
+func ErrorCode(err error) codes.Code {
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Code()
+	}
+	return status.Code(err)
+}
@@ -0,0 +1,45 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServiceError(t *testing.T) {
+	err := NewScoreNotFoundError("//asset", "qr-id")
+	assert.ErrorIs(t, err, ErrScoreNotFound)
+	assert.NotErrorIs(t, err, ErrAssetNotFound)
+	assert.Equal(t, "score for asset '//asset' with ID 'qr-id' not found", err.Error())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	wrapped := fmt.Errorf("failed to get report: %w", err)
+	assert.ErrorIs(t, wrapped, ErrScoreNotFound)
+	assert.Equal(t, codes.NotFound, ErrorCode(wrapped))
+
+	assert.Equal(t, codes.FailedPrecondition, ErrorCode(NewInvalidStateError("//asset", "not resolved")))
+	assert.Equal(t, codes.InvalidArgument, ErrorCode(status.Error(codes.InvalidArgument, "invalid")))
+	assert.Equal(t, codes.Unknown, ErrorCode(errors.New("other")))
+}
+
+func TestServiceErrorFromStatus(t *testing.T) {
+	// errors are sent to clients as statuses
+	sent := NewPolicyNotFoundError("//policy")
+	received := status.ErrorProto(status.Convert(sent).Proto())
+
+	err := ServiceErrorFromStatus(received)
+	require.ErrorIs(t, err, ErrPolicyNotFound)
+	var serviceErr *ServiceError
+	require.True(t, errors.As(err, &serviceErr))
+	assert.Equal(t, "//policy", serviceErr.Mrn)
+	assert.Equal(t, sent.Error(), serviceErr.Error())
+
+	other := status.Error(codes.Internal, "internal")
+	assert.Same(t, other, ServiceErrorFromStatus(other))
+	assert.NoError(t, ServiceErrorFromStatus(nil))
+}
@@ -0,0 +1,147 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ExceptionState is the state of an exception in its approval workflow.
+// Exceptions are requested, then approved or rejected. Approved exceptions
+// are active until they expire.
+type ExceptionState string
+
+const (
+	ExceptionRequested ExceptionState = "requested"
+	ExceptionApproved  ExceptionState = "approved"
+	ExceptionRejected  ExceptionState = "rejected"
+	ExceptionActive    ExceptionState = "active"
+	ExceptionExpired   ExceptionState = "expired"
+)
+
+// ExceptionReview is the decision on a requested exception
+type ExceptionReview struct {
+	// State is either ExceptionApproved or ExceptionRejected
+	State    ExceptionState
+	Reviewer string
+	Comment  string
+}
+
+// ExceptionApprover reviews requested exceptions, e.g. by checking them
+// against the rules of an organization or by opening a ticket for them
+type ExceptionApprover interface {
+	// ReviewException decides on a requested exception. It returns nil if
+	// the decision is pending, e.g. until someone reviewed the ticket. The
+	// exception then stays requested until it is reviewed with
+	// LocalServices.ReviewException.
+	ReviewException(ctx context.Context, exception *Exception) (*ExceptionReview, error)
+}
+
+// ExceptionApprovers approve an exception once all of them approved it.
+// The first rejection rejects it.
+type ExceptionApprovers []ExceptionApprover
+
+// ReviewException asks all approvers to review the exception
+func (a ExceptionApprovers) ReviewException(ctx context.Context, exception *Exception) (*ExceptionReview, error) {
+	var reviewers, comments []string
+	for i := range a {
+		review, err := a[i].ReviewException(ctx, exception)
+		if err != nil || review == nil {
+			return nil, err
+		}
+		if review.State == ExceptionRejected {
+			return review, nil
+		}
+		if review.Reviewer != "" {
+			reviewers = append(reviewers, review.Reviewer)
+		}
+		if review.Comment != "" {
+			comments = append(comments, review.Comment)
+		}
+	}
+	return &ExceptionReview{
+		State:    ExceptionApproved,
+		Reviewer: strings.Join(reviewers, ", "),
+		Comment:  strings.Join(comments, "; "),
+	}, nil
+}
+
+// applyReview records the decision on a requested exception
+func (e *Exception) applyReview(review *ExceptionReview, now time.Time) error {
+	if review.State != ExceptionApproved && review.State != ExceptionRejected {
+		return errors.New("invalid review of exception for check '" + e.CheckMrn + "': state must be approved or rejected, not '" + string(review.State) + "'")
+	}
+	if e.State != ExceptionRequested {
+		return errors.New("exception for check '" + e.CheckMrn + "' on '" + e.EntityMrn + "' was not requested, it is " + string(e.Status(now)))
+	}
+
+	e.State = review.State
+	e.Reviewer = review.Reviewer
+	e.ReviewComment = review.Comment
+	e.Reviewed = now
+	return nil
+}
+
+// findException returns the exception for a check on an entity
+func findException(ctx context.Context, store ExceptionStore, entityMrn string, checkMrn string) (*Exception, error) {
+	exceptions, err := store.ListExceptions(ctx, entityMrn)
+	if err != nil {
+		return nil, err
+	}
+	for i := range exceptions {
+		if exceptions[i].CheckMrn == checkMrn {
+			return exceptions[i], nil
+		}
+	}
+	return nil, errors.New("no exception for check '" + checkMrn + "' on '" + entityMrn + "'")
+}
+
+// ReviewException approves or rejects a requested exception, e.g. once
+// its review in a ticketing system is done. Approved exceptions waive
+// their check from the next resolution on.
+func (s *LocalServices) ReviewException(ctx context.Context, entityMrn string, checkMrn string, review *ExceptionReview) error {
+	if review == nil {
+		return errors.New("missing review for exception of check '" + checkMrn + "'")
+	}
+	store, err := s.exceptionStore()
+	if err != nil {
+		return err
+	}
+
+	exception, err := findException(ctx, store, entityMrn, checkMrn)
+	if err != nil {
+		return err
+	}
+	if err := exception.applyReview(review, time.Now()); err != nil {
+		return err
+	}
+	return store.SetException(ctx, exception)
+}
+
+// PendingExceptions returns all exceptions of an entity that wait for a
+// review, sorted by check MRN
+func (s *LocalServices) PendingExceptions(ctx context.Context, entityMrn string, now time.Time) ([]*Exception, error) {
+	store, err := s.exceptionStore()
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions, err := store.ListExceptions(ctx, entityMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*Exception
+	for i := range exceptions {
+		if exceptions[i].Status(now) == ExceptionRequested {
+			res = append(res, exceptions[i])
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CheckMrn < res[j].CheckMrn
+	})
+	return res, nil
+}
@@ -0,0 +1,210 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// Exception waives a check on an entity (asset, space) for a while. Waived
+// checks are still executed, but they are reported as skipped and don't
+// contribute to the score of their policies.
+type Exception struct {
+	CheckMrn      string
+	EntityMrn     string
+	Justification string
+	Created       time.Time
+	// Expires is optional. Once it has passed, the check is active again.
+	Expires time.Time
+	// State is the decision on the exception, see ExceptionApprover. Only
+	// approved exceptions waive checks. Exceptions without a state were
+	// created before approvals and count as approved.
+	State ExceptionState
+	// Reviewer, ReviewComment and Reviewed describe the decision (optional)
+	Reviewer      string
+	ReviewComment string
+	Reviewed      time.Time
+}
+
+// Status returns the state of the exception at the given time: requested,
+// rejected, active or expired
+func (e *Exception) Status(now time.Time) ExceptionState {
+	if e.State == ExceptionRejected {
+		return ExceptionRejected
+	}
+	if !e.Expires.IsZero() && !now.Before(e.Expires) {
+		return ExceptionExpired
+	}
+	if e.State == ExceptionRequested {
+		return ExceptionRequested
+	}
+	return ExceptionActive
+}
+
+// IsActive returns true if the exception applies at the given time, i.e.
+// it is approved and hasn't expired
+func (e *Exception) IsActive(now time.Time) bool {
+	return e.Status(now) == ExceptionActive
+}
+
+// Message describes the exception for scores of waived checks
+func (e *Exception) Message() string {
+	res := "excepted: " + e.Justification
+	if !e.Expires.IsZero() {
+		res += " (until " + e.Expires.UTC().Format(time.RFC3339) + ")"
+	}
+	return res
+}
+
+// ExceptionStore is implemented by DataLakes that can store exceptions
+type ExceptionStore interface {
+	// SetException stores an exception, it replaces any exception for the
+	// same check on the same entity
+	SetException(ctx context.Context, exception *Exception) error
+	// DeleteException removes the exception for a check on an entity
+	DeleteException(ctx context.Context, entityMrn string, checkMrn string) error
+	// ListExceptions returns all exceptions of an entity, including expired ones
+	ListExceptions(ctx context.Context, entityMrn string) ([]*Exception, error)
+}
+
+func (s *LocalServices) exceptionStore() (ExceptionStore, error) {
+	store, ok := s.DataLake.(ExceptionStore)
+	if !ok {
+		return nil, errors.New("the data lake does not support exceptions")
+	}
+	return store, nil
+}
+
+// AddException requests an exception for a check on an entity. Without
+// an ExceptionApprover, the exception is approved right away. Otherwise it
+// is reviewed by the approver and stays requested until it is approved.
+func (s *LocalServices) AddException(ctx context.Context, exception *Exception) error {
+	if exception == nil || exception.CheckMrn == "" || exception.EntityMrn == "" {
+		return errors.New("exceptions require a check and an entity")
+	}
+	if strings.TrimSpace(exception.Justification) == "" {
+		return errors.New("exception for check '" + exception.CheckMrn + "' requires a justification")
+	}
+	if exception.Created.IsZero() {
+		exception.Created = time.Now()
+	}
+	if !exception.Expires.IsZero() && !exception.Expires.After(exception.Created) {
+		return errors.New("exception for check '" + exception.CheckMrn + "' expires before it is created")
+	}
+
+	store, err := s.exceptionStore()
+	if err != nil {
+		return err
+	}
+
+	exception.Reviewer, exception.ReviewComment, exception.Reviewed = "", "", time.Time{}
+	if s.ExceptionApprover == nil {
+		exception.State = ExceptionApproved
+	} else {
+		exception.State = ExceptionRequested
+		review, err := s.ExceptionApprover.ReviewException(ctx, exception)
+		if err != nil {
+			return errors.Wrap(err, "failed to review exception for check '"+exception.CheckMrn+"'")
+		}
+		if review != nil {
+			if err := exception.applyReview(review, time.Now()); err != nil {
+				return err
+			}
+		}
+	}
+	return store.SetException(ctx, exception)
+}
+
+// RemoveException removes the exception for a check on an entity
+func (s *LocalServices) RemoveException(ctx context.Context, entityMrn string, checkMrn string) error {
+	store, err := s.exceptionStore()
+	if err != nil {
+		return err
+	}
+	return store.DeleteException(ctx, entityMrn, checkMrn)
+}
+
+// ActiveExceptions returns all exceptions that apply to an entity at the given
+// time, including the ones of its parents (see LocalServices.EntityParents).
+// They are sorted by check MRN. Data lakes without support for exceptions
+// have no active exceptions.
+func (s *LocalServices) ActiveExceptions(ctx context.Context, entityMrn string, now time.Time) ([]*Exception, error) {
+	store, ok := s.DataLake.(ExceptionStore)
+	if !ok {
+		return nil, nil
+	}
+
+	var mrns []string
+	if s.EntityParents != nil {
+		mrns = append(mrns, s.EntityParents(entityMrn)...)
+	}
+	mrns = append(mrns, entityMrn)
+
+	var res []*Exception
+	for _, mrn := range mrns {
+		exceptions, err := store.ListExceptions(ctx, mrn)
+		if err != nil {
+			return nil, err
+		}
+		for i := range exceptions {
+			if exceptions[i].IsActive(now) {
+				res = append(res, exceptions[i])
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CheckMrn < res[j].CheckMrn
+	})
+	return res, nil
+}
+
+// exceptionsChecksum identifies a set of active exceptions
+func exceptionsChecksum(exceptions []*Exception) string {
+	strs := make([]string, 0, len(exceptions)*2)
+	for i := range exceptions {
+		strs = append(strs, exceptions[i].EntityMrn, exceptions[i].CheckMrn)
+	}
+	return checksumStrings(strs...)
+}
+
+// isExcepted returns true if the check is waived for the resolved asset
+func (c *resolverCache) isExcepted(check *explorer.Mquery) bool {
+	_, ok := c.exceptions[check.Mrn]
+	return ok
+}
+
+// applyExceptions reports the scores of waived checks as skipped
+func (s *LocalServices) applyExceptions(ctx context.Context, assetMrn string, scores []*Score) error {
+	exceptions, err := s.ActiveExceptions(ctx, assetMrn, time.Now())
+	if err != nil || len(exceptions) == 0 {
+		return err
+	}
+
+	// scores of checks are reported by their code ID
+	byCodeID := make(map[string]*Exception, len(exceptions))
+	for i := range exceptions {
+		exception := exceptions[i]
+		check, err := s.DataLake.GetQuery(ctx, exception.CheckMrn)
+		if err != nil || check == nil {
+			continue
+		}
+		byCodeID[check.CodeId] = exception
+	}
+
+	for i := range scores {
+		score := scores[i]
+		exception, ok := byCodeID[score.QrId]
+		if !ok {
+			continue
+		}
+		score.Type = ScoreType_Skip
+		score.Value = 0
+		score.Message = exception.Message()
+	}
+	return nil
+}
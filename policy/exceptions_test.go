@@ -0,0 +1,171 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestException(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	permanent := &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "accepted risk"}
+	assert.True(t, permanent.IsActive(now))
+	assert.Equal(t, "excepted: accepted risk", permanent.Message())
+
+	expiring := &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "fix scheduled", Expires: now.Add(time.Hour)}
+	assert.True(t, expiring.IsActive(now))
+	assert.False(t, expiring.IsActive(now.Add(time.Hour)))
+	assert.Equal(t, "excepted: fix scheduled (until 2023-11-14T23:13:20Z)", expiring.Message())
+}
+
+func TestAddException_Invalid(t *testing.T) {
+	s := &LocalServices{}
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+
+	assert.Error(t, s.AddException(ctx, &Exception{EntityMrn: "//asset", Justification: "x"}))
+	assert.Error(t, s.AddException(ctx, &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: " "}))
+	assert.Error(t, s.AddException(ctx, &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "x", Created: now, Expires: now}))
+	// valid, but there is no data lake that can store it
+	assert.Error(t, s.AddException(ctx, &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "x"}))
+}
+
+func TestException_Status(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	exception := &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "x", Expires: now.Add(time.Hour)}
+
+	// exceptions without a state predate approvals
+	assert.Equal(t, ExceptionActive, exception.Status(now))
+
+	exception.State = ExceptionRequested
+	assert.Equal(t, ExceptionRequested, exception.Status(now))
+	assert.False(t, exception.IsActive(now))
+	assert.Equal(t, ExceptionExpired, exception.Status(now.Add(time.Hour)))
+
+	exception.State = ExceptionApproved
+	assert.Equal(t, ExceptionActive, exception.Status(now))
+	assert.True(t, exception.IsActive(now))
+	assert.Equal(t, ExceptionExpired, exception.Status(now.Add(time.Hour)))
+
+	exception.State = ExceptionRejected
+	assert.Equal(t, ExceptionRejected, exception.Status(now))
+	assert.Equal(t, ExceptionRejected, exception.Status(now.Add(time.Hour)))
+}
+
+type exceptionDataLake struct {
+	DataLake
+	exceptions map[string][]*Exception
+}
+
+func (db *exceptionDataLake) SetException(ctx context.Context, exception *Exception) error {
+	if err := db.DeleteException(ctx, exception.EntityMrn, exception.CheckMrn); err != nil {
+		return err
+	}
+	db.exceptions[exception.EntityMrn] = append(db.exceptions[exception.EntityMrn], exception)
+	return nil
+}
+
+func (db *exceptionDataLake) DeleteException(ctx context.Context, entityMrn string, checkMrn string) error {
+	var res []*Exception
+	for _, e := range db.exceptions[entityMrn] {
+		if e.CheckMrn != checkMrn {
+			res = append(res, e)
+		}
+	}
+	db.exceptions[entityMrn] = res
+	return nil
+}
+
+func (db *exceptionDataLake) ListExceptions(ctx context.Context, entityMrn string) ([]*Exception, error) {
+	return db.exceptions[entityMrn], nil
+}
+
+type testApprover struct {
+	review *ExceptionReview
+	calls  int
+}
+
+func (a *testApprover) ReviewException(ctx context.Context, exception *Exception) (*ExceptionReview, error) {
+	a.calls++
+	return a.review, nil
+}
+
+func TestExceptionApprovers(t *testing.T) {
+	ctx := context.Background()
+	exception := &Exception{CheckMrn: "//check", EntityMrn: "//asset", State: ExceptionRequested}
+
+	security := &testApprover{review: &ExceptionReview{State: ExceptionApproved, Reviewer: "security", Comment: "ok"}}
+	owner := &testApprover{review: &ExceptionReview{State: ExceptionApproved, Reviewer: "owner"}}
+	review, err := ExceptionApprovers{security, owner}.ReviewException(ctx, exception)
+	require.NoError(t, err)
+	assert.Equal(t, &ExceptionReview{State: ExceptionApproved, Reviewer: "security, owner", Comment: "ok"}, review)
+
+	owner.review = nil
+	review, err = ExceptionApprovers{security, owner}.ReviewException(ctx, exception)
+	require.NoError(t, err)
+	assert.Nil(t, review)
+
+	rejected := &ExceptionReview{State: ExceptionRejected, Reviewer: "security", Comment: "fix it"}
+	security.review = rejected
+	owner.calls = 0
+	review, err = ExceptionApprovers{security, owner}.ReviewException(ctx, exception)
+	require.NoError(t, err)
+	assert.Equal(t, rejected, review)
+	assert.Equal(t, 0, owner.calls)
+}
+
+func TestExceptionApproval(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	approver := &testApprover{}
+	s := &LocalServices{
+		DataLake:          &exceptionDataLake{exceptions: map[string][]*Exception{}},
+		ExceptionApprover: approver,
+	}
+
+	// pending exceptions don't waive their checks
+	require.NoError(t, s.AddException(ctx, &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "accepted risk"}))
+	assert.Equal(t, 1, approver.calls)
+	active, err := s.ActiveExceptions(ctx, "//asset", now)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	pending, err := s.PendingExceptions(ctx, "//asset", now)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, ExceptionRequested, pending[0].State)
+
+	assert.Error(t, s.ReviewException(ctx, "//asset", "//check", &ExceptionReview{State: ExceptionActive}))
+	assert.Error(t, s.ReviewException(ctx, "//asset", "//other", &ExceptionReview{State: ExceptionApproved}))
+
+	require.NoError(t, s.ReviewException(ctx, "//asset", "//check", &ExceptionReview{State: ExceptionApproved, Reviewer: "ciso"}))
+	active, err = s.ActiveExceptions(ctx, "//asset", now)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "ciso", active[0].Reviewer)
+	assert.False(t, active[0].Reviewed.IsZero())
+
+	// decisions are final, exceptions have to be requested again
+	assert.Error(t, s.ReviewException(ctx, "//asset", "//check", &ExceptionReview{State: ExceptionRejected}))
+
+	// approvers may decide right away
+	approver.review = &ExceptionReview{State: ExceptionRejected, Reviewer: "policy-bot"}
+	require.NoError(t, s.AddException(ctx, &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "again"}))
+	active, err = s.ActiveExceptions(ctx, "//asset", now)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	pending, err = s.PendingExceptions(ctx, "//asset", now)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// without approvers, exceptions are approved right away
+	s.ExceptionApprover = nil
+	require.NoError(t, s.AddException(ctx, &Exception{CheckMrn: "//check", EntityMrn: "//asset", Justification: "again"}))
+	active, err = s.ActiveExceptions(ctx, "//asset", now)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, ExceptionApproved, active[0].State)
+}
@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// datapointTracker records which datapoints arrived during execution
+type datapointTracker struct {
+	lock     sync.Mutex
+	received map[string]struct{}
+}
+
+func newDatapointTracker() *datapointTracker {
+	return &datapointTracker{received: map[string]struct{}{}}
+}
+
+func (c *datapointTracker) SinkData(results []*llx.RawResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, rr := range results {
+		c.received[rr.CodeID] = struct{}{}
+	}
+}
+
+// incompleteScores returns a score for every reporting job whose datapoints
+// didn't all arrive. These scores are marked as errors that list the missing
+// datapoints, so that they can't be mistaken for real results.
+func (c *datapointTracker) incompleteScores(resolvedPolicy *policy.ResolvedPolicy) []*policy.Score {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var res []*policy.Score
+	for _, rj := range resolvedPolicy.CollectorJob.ReportingJobs {
+		if len(rj.Datapoints) == 0 {
+			continue
+		}
+
+		var missing []string
+		for checksum := range rj.Datapoints {
+			if _, ok := c.received[checksum]; !ok {
+				missing = append(missing, checksum)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		sort.Strings(missing)
+
+		total := len(rj.Datapoints)
+		log.Warn().
+			Str("qrid", rj.QrId).
+			Strs("missing", missing).
+			Msg("executor> datapoints never arrived, score is incomplete")
+
+		res = append(res, &policy.Score{
+			QrId:            rj.QrId,
+			Type:            policy.ScoreType_Error,
+			DataTotal:       uint32(total),
+			DataCompletion:  uint32((total - len(missing)) * 100 / total),
+			ScoreCompletion: 0,
+			Message:         "incomplete results, " + strconv.Itoa(len(missing)) + " of " + strconv.Itoa(total) + " datapoints never arrived: " + strings.Join(missing, ", "),
+		})
+	}
+	return res
+}
@@ -0,0 +1,34 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestDatapointTrackerIncompleteScores(t *testing.T) {
+	resolvedPolicy := &policy.ResolvedPolicy{
+		CollectorJob: &policy.CollectorJob{
+			ReportingJobs: map[string]*policy.ReportingJob{
+				"complete":   {QrId: "complete", Datapoints: map[string]bool{"a": true}},
+				"incomplete": {QrId: "incomplete", Datapoints: map[string]bool{"a": true, "c": true, "b": true, "d": true}},
+				"parent":     {QrId: "parent"},
+			},
+		},
+	}
+
+	tracker := newDatapointTracker()
+	tracker.SinkData([]*llx.RawResult{{CodeID: "a"}})
+
+	scores := tracker.incompleteScores(resolvedPolicy)
+	require.Len(t, scores, 1)
+	score := scores[0]
+	assert.Equal(t, "incomplete", score.QrId)
+	assert.Equal(t, policy.ScoreType_Error, score.Type)
+	assert.Equal(t, uint32(4), score.DataTotal)
+	assert.Equal(t, uint32(25), score.DataCompletion)
+	assert.Equal(t, "incomplete results, 3 of 4 datapoints never arrived: b, c, d", score.Message)
+}
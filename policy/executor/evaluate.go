@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"context"
+	"errors"
+
+	"go.mondoo.com/cnquery"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/motor"
+	"go.mondoo.com/cnquery/mqlc"
+	"go.mondoo.com/cnquery/resources"
+	"go.mondoo.com/cnquery/resources/packs/all"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// CheckResult is the outcome of evaluating a single check
+type CheckResult struct {
+	// Score of the check with its impact applied
+	Score *policy.Score
+	// Evidence are the results of all datapoints of the check, by checksum
+	Evidence map[string]*llx.RawResult
+	// CodeID of the compiled check
+	CodeID string
+}
+
+// EvaluateCheck compiles, runs and scores a single check against an open
+// connection. It needs neither bundles nor a datalake, which makes it the
+// smallest entry point for embedding cnspec. The impact may be nil.
+func EvaluateCheck(ctx context.Context, mql string, impact *explorer.Impact, connection *motor.Motor) (*CheckResult, error) {
+	if mql == "" {
+		return nil, errors.New("cannot evaluate check without MQL")
+	}
+	if connection == nil {
+		return nil, errors.New("cannot evaluate check without a connection")
+	}
+
+	registry := all.Registry
+	schema := registry.Schema()
+	features := cnquery.GetFeatures(ctx)
+
+	codeBundle, err := mqlc.Compile(mql, nil, mqlc.NewConfig(schema, features))
+	if err != nil {
+		return nil, errors.New("failed to compile check: " + err.Error())
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	runtime := resources.NewRuntime(registry, connection)
+	score, evidence, err := ExecuteQuery(schema, runtime, codeBundle, nil, features)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckResult{
+		Score:    scoreWithImpact(score, impact),
+		Evidence: evidence,
+		CodeID:   codeBundle.CodeV2.Id,
+	}, nil
+}
+
+// scoreWithImpact applies the impact to the score the same way policies do:
+// failing checks can't score lower than their impact allows, and their
+// weight is taken from the impact
+func scoreWithImpact(score *policy.Score, impact *explorer.Impact) *policy.Score {
+	if score == nil || impact == nil {
+		return score
+	}
+
+	res := proto.Clone(score).(*policy.Score)
+	if res.Type != policy.ScoreType_Result {
+		return res
+	}
+
+	floor := 100 - uint32(impact.Value)
+	if floor > res.Value {
+		res.Value = floor
+	}
+	if impact.Weight != -1 {
+		res.Weight = uint32(impact.Weight)
+	}
+	return res
+}
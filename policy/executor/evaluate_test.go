@@ -0,0 +1,37 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor"
+	"go.mondoo.com/cnquery/motor/providers/mock"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestEvaluateCheck(t *testing.T) {
+	transport, err := mock.NewFromTomlFile("./testdata/arch.toml")
+	require.NoError(t, err)
+	connection, err := motor.New(transport)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	res, err := EvaluateCheck(ctx, "asset.platform == \"arch\"", nil, connection)
+	require.NoError(t, err)
+	assert.Equal(t, policy.ScoreType_Result, res.Score.Type)
+	assert.Equal(t, uint32(100), res.Score.Value)
+	assert.NotEmpty(t, res.Evidence)
+	assert.Equal(t, res.CodeID, res.Score.QrId)
+
+	res, err = EvaluateCheck(ctx, "asset.platform == \"ubuntu\"", &explorer.Impact{Value: 30, Weight: 5}, connection)
+	require.NoError(t, err)
+	assert.Equal(t, policy.ScoreType_Result, res.Score.Type)
+	assert.Equal(t, uint32(70), res.Score.Value)
+	assert.Equal(t, uint32(5), res.Score.Weight)
+
+	_, err = EvaluateCheck(ctx, "asset.platform ==", nil, connection)
+	assert.Error(t, err)
+}
@@ -17,19 +17,165 @@ type GraphExecutor interface {
 	Execute()
 }
 
+type executeConfig struct {
+	sampling      map[string]*policy.DataSampling
+	memo          *ResultMemo
+	fingerprint   string
+	deterministic map[string]struct{}
+	cache         *ResultMemo
+	cacheKeys     map[string]string
+	incremental   *incrementalScan
+	queryTimeout  time.Duration
+	capabilities  *policy.Capabilities
+	prerequisites *policy.Prerequisites
+	observer      QueryObserver
+	concurrency   int
+	dataAge       *policy.DataAge
+}
+
+// ExecuteOption configures the execution of a resolved policy
+type ExecuteOption func(*executeConfig)
+
+// WithDataSampling bounds the results of data queries, indexed by the code ID
+// of the query. See policy.Bundle.SamplingByCodeID.
+func WithDataSampling(sampling map[string]*policy.DataSampling) ExecuteOption {
+	return func(c *executeConfig) {
+		c.sampling = sampling
+	}
+}
+
+// WithResultMemo reuses the results of deterministic queries (indexed by
+// code ID, see policy.Bundle.DeterministicCodeIDs) across all assets with the
+// same platform fingerprint. New results are added to the memo.
+func WithResultMemo(memo *ResultMemo, fingerprint string, deterministic map[string]struct{}) ExecuteOption {
+	return func(c *executeConfig) {
+		c.memo = memo
+		c.fingerprint = fingerprint
+		c.deterministic = deterministic
+	}
+}
+
+// WithResultCache reuses the results of queries that opted into the result
+// cache across all assets with the same cache key. Keys are indexed by the
+// code ID of the query, see policy.ResultCacheKey. New results are added to
+// the cache.
+func WithResultCache(cache *ResultMemo, keys map[string]string) ExecuteOption {
+	return func(c *executeConfig) {
+		c.cache = cache
+		c.cacheKeys = keys
+	}
+}
+
+// WithCapabilities skips all queries that need capabilities which the
+// asset's connection doesn't have, instead of executing them. Their checks
+// are reported as skipped with a "capability missing" message.
+func WithCapabilities(capabilities *policy.Capabilities) ExecuteOption {
+	return func(c *executeConfig) {
+		c.capabilities = capabilities
+	}
+}
+
+// WithPrerequisites skips all checks whose prerequisites the asset doesn't
+// meet, see policy.PrerequisitesTag. Their checks are reported as skipped
+// with a "prerequisite missing" message that explains why.
+func WithPrerequisites(prerequisites *policy.Prerequisites) ExecuteOption {
+	return func(c *executeConfig) {
+		c.prerequisites = prerequisites
+	}
+}
+
+// QueryObserver is notified when the execution of a query starts and finishes
+type QueryObserver = internal.QueryObserver
+
+// WithQueryObserver notifies the observer of every query that is executed,
+// e.g. policy.AuditTrail to attribute commands to the checks that ran them
+func WithQueryObserver(o QueryObserver) ExecuteOption {
+	return func(c *executeConfig) {
+		c.observer = o
+	}
+}
+
+// WithQueryConcurrency executes up to n independent queries in parallel.
+// Queries that depend on properties only run once these are available.
+// Defaults to 1, i.e. queries run one after the other.
+func WithQueryConcurrency(n int) ExecuteOption {
+	return func(c *executeConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithDataAge reports checks as unscored if their data is older than their
+// max data age, see policy.MaxDataAgeTag. Data is as old as the recording
+// the asset is scanned with, if any. Results that are reused from a previous
+// scan are as old as their collection time in age.Collected.
+func WithDataAge(age *policy.DataAge) ExecuteOption {
+	return func(c *executeConfig) {
+		c.dataAge = age
+	}
+}
+
 func ExecuteResolvedPolicy(schema *resources.Schema, runtime *resources.Runtime, collectorSvc policy.PolicyResolver, assetMrn string,
-	resolvedPolicy *policy.ResolvedPolicy, features cnquery.Features, progressReporter progress.Progress,
+	resolvedPolicy *policy.ResolvedPolicy, features cnquery.Features, progressReporter progress.Progress, opts ...ExecuteOption,
 ) error {
-	collector := internal.NewBufferedCollector(internal.NewPolicyServiceCollector(assetMrn, collectorSvc))
+	conf := executeConfig{}
+	for i := range opts {
+		opts[i](&conf)
+	}
+
+	policyCollector := internal.NewPolicyServiceCollector(assetMrn, collectorSvc)
+	policyCollector.WithSampling(datapointSampling(resolvedPolicy, conf.sampling))
+	collector := internal.NewBufferedCollector(policyCollector)
 	defer collector.FlushAndStop()
 
 	builder := builderFromResolvedPolicy(resolvedPolicy)
 	builder.AddDatapointCollector(collector)
 	builder.AddScoreCollector(collector)
+	datapoints := newDatapointTracker()
+	builder.AddDatapointCollector(datapoints)
 	if progressReporter != nil {
 		builder.WithProgressReporter(progressReporter)
 	}
 
+	var memoized *memoCollector
+	memoizable := memoizableQueries(resolvedPolicy, &conf)
+	if len(memoizable) != 0 {
+		for queryID, query := range memoizable {
+			if results, ok := query.memo.load(query.partition, queryID); ok {
+				builder.AddPrecomputedResults(queryID, results)
+				delete(memoizable, queryID)
+			}
+		}
+		memoized = &memoCollector{results: map[string]*llx.RawResult{}}
+		builder.AddDatapointCollector(memoized)
+	}
+
+	var reused map[string]map[string]*llx.RawResult
+	if conf.incremental != nil {
+		reused = conf.incremental.reusableResults(resolvedPolicy)
+		for queryID, results := range reused {
+			builder.AddPrecomputedResults(queryID, results)
+			delete(memoizable, queryID)
+		}
+	}
+	if conf.dataAge != nil {
+		builder.WithDataAge(reusedDataAge(conf.dataAge, reused))
+	}
+	if conf.queryTimeout != 0 {
+		builder.WithQueryTimeout(conf.queryTimeout)
+	}
+	if conf.capabilities != nil {
+		builder.WithCapabilities(conf.capabilities)
+	}
+	if conf.prerequisites != nil {
+		builder.WithPrerequisites(conf.prerequisites)
+	}
+	if conf.observer != nil {
+		builder.WithQueryObserver(conf.observer)
+	}
+	if conf.concurrency > 1 {
+		builder.WithQueryConcurrency(conf.concurrency)
+	}
+
 	ge, err := builder.Build(schema, runtime, assetMrn)
 	if err != nil {
 		return err
@@ -37,7 +183,19 @@ func ExecuteResolvedPolicy(schema *resources.Schema, runtime *resources.Runtime,
 
 	ge.Debug()
 
-	return ge.Execute()
+	if err := ge.Execute(); err != nil {
+		return err
+	}
+
+	// jobs whose data never arrived must not look like real results
+	if incomplete := datapoints.incompleteScores(resolvedPolicy); len(incomplete) != 0 {
+		collector.SinkScore(incomplete)
+	}
+
+	if memoized != nil {
+		memoized.storeResults(memoizable)
+	}
+	return nil
 }
 
 func ExecuteFilterQueries(schema *resources.Schema, runtime *resources.Runtime, queries []*explorer.Mquery, timeout time.Duration) ([]*explorer.Mquery, []error) {
@@ -136,6 +294,42 @@ func ExecuteQuery(schema *resources.Schema, runtime *resources.Runtime, codeBund
 	return score, resultMap, nil
 }
 
+// reusedDataAge limits the collection times of the data age to the reused
+// results. All other data is collected during execution.
+func reusedDataAge(age *policy.DataAge, reused map[string]map[string]*llx.RawResult) *policy.DataAge {
+	res := &policy.DataAge{
+		MaxAges:   age.MaxAges,
+		Collected: map[string]time.Time{},
+		Recorded:  age.Recorded,
+		Now:       age.Now,
+	}
+	for _, results := range reused {
+		for checksum := range results {
+			res.Collected[checksum] = age.Collected[checksum]
+		}
+	}
+	return res
+}
+
+// datapointSampling maps the sampling of data queries to all their datapoints
+func datapointSampling(resolvedPolicy *policy.ResolvedPolicy, sampling map[string]*policy.DataSampling) map[string]*policy.DataSampling {
+	if len(sampling) == 0 {
+		return nil
+	}
+
+	res := map[string]*policy.DataSampling{}
+	for codeID, s := range sampling {
+		eq, ok := resolvedPolicy.ExecutionJob.Queries[codeID]
+		if !ok || eq.Code == nil {
+			continue
+		}
+		for _, checksum := range internal.CodepointChecksums(eq.Code) {
+			res[checksum] = s
+		}
+	}
+	return res
+}
+
 func builderFromResolvedPolicy(resolvedPolicy *policy.ResolvedPolicy) *internal.GraphBuilder {
 	b := internal.NewBuilder()
 
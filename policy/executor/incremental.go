@@ -0,0 +1,147 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mondoo.com/cnquery"
+	"go.mondoo.com/cnquery/cli/progress"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/resources"
+	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/cnspec/policy/executor/internal"
+)
+
+// incrementalScan reuses the results of all queries that don't use any of the
+// changed resources and, if the previous queries are known, didn't change
+type incrementalScan struct {
+	changedResources []string
+	previous         map[string]*llx.RawResult
+	// queries of the previous resolved policy by code ID (optional)
+	previousQueries map[string]*policy.ExecutionQuery
+}
+
+// WithIncrementalScan only executes the queries that use any of the changed
+// resources, e.g. `file` or `users`. Resources include their children, i.e.
+// `aws.ec2` also covers `aws.ec2.instance`. All other queries reuse their
+// previous results (indexed by datapoint checksum); queries without complete
+// previous results are executed as well. All scores are recalculated.
+func WithIncrementalScan(changedResources []string, previous map[string]*llx.RawResult) ExecuteOption {
+	return func(c *executeConfig) {
+		c.incremental = &incrementalScan{
+			changedResources: changedResources,
+			previous:         previous,
+		}
+	}
+}
+
+// WithChangedQueries only executes the queries that changed since the
+// previous resolved policy, e.g. after a slight policy update. Queries are
+// changed if they are new or their checksum changed, which includes their
+// properties. All other queries reuse their previous results (indexed by
+// datapoint checksum, see PreviousResults); queries without complete previous
+// results are executed as well. All scores are recalculated.
+func WithChangedQueries(previousPolicy *policy.ResolvedPolicy, previous map[string]*llx.RawResult) ExecuteOption {
+	return func(c *executeConfig) {
+		c.incremental = &incrementalScan{
+			previous:        previous,
+			previousQueries: map[string]*policy.ExecutionQuery{},
+		}
+		if previousPolicy != nil && previousPolicy.ExecutionJob != nil {
+			c.incremental.previousQueries = previousPolicy.ExecutionJob.Queries
+		}
+	}
+}
+
+// WithQueryTimeout limits the time that every query may take
+func WithQueryTimeout(timeout time.Duration) ExecuteOption {
+	return func(c *executeConfig) {
+		c.queryTimeout = timeout
+	}
+}
+
+// affected returns true if the resource is one of the changed resources or
+// one of their children
+func (s *incrementalScan) affected(resource string) bool {
+	for _, changed := range s.changedResources {
+		if resource == changed || strings.HasPrefix(resource, changed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// changed returns true if the query is new or changed since the previous
+// resolved policy. Without a previous resolved policy nothing changed.
+func (s *incrementalScan) changed(codeID string, eq *policy.ExecutionQuery) bool {
+	if s.previousQueries == nil {
+		return false
+	}
+	prev, ok := s.previousQueries[codeID]
+	return !ok || prev.Checksum != eq.Checksum
+}
+
+// reusableResults returns the previous results of all queries of the resolved
+// policy that are not affected by the changed resources, by query code ID
+func (s *incrementalScan) reusableResults(resolvedPolicy *policy.ResolvedPolicy) map[string]map[string]*llx.RawResult {
+	res := map[string]map[string]*llx.RawResult{}
+	for codeID, eq := range resolvedPolicy.ExecutionJob.Queries {
+		if eq.Code == nil || eq.Code.CodeV2 == nil || s.changed(codeID, eq) {
+			continue
+		}
+
+		isAffected := false
+		for _, resource := range policy.CodeResources(eq.Code.CodeV2) {
+			if s.affected(resource) {
+				isAffected = true
+				break
+			}
+		}
+		if isAffected {
+			continue
+		}
+
+		checksums := internal.CodepointChecksums(eq.Code)
+		results := make(map[string]*llx.RawResult, len(checksums))
+		for _, checksum := range checksums {
+			if rr, ok := s.previous[checksum]; ok {
+				results[checksum] = rr
+			}
+		}
+		if len(results) == len(checksums) {
+			res[codeID] = results
+		}
+	}
+	return res
+}
+
+// ExecuteIncremental re-evaluates the queries of the resolved policy that use
+// any of the changed resources, e.g. after file integrity events, and updates
+// all scores of the asset. Results of all other queries are taken from the
+// asset's last report. Every query is limited to the query timeout, if set.
+func ExecuteIncremental(ctx context.Context, schema *resources.Schema, runtime *resources.Runtime, collectorSvc policy.PolicyResolver, assetMrn string,
+	resolvedPolicy *policy.ResolvedPolicy, changedResources []string, queryTimeout time.Duration, features cnquery.Features, progressReporter progress.Progress,
+) error {
+	report, err := collectorSvc.GetReport(ctx, &policy.EntityScoreReq{EntityMrn: assetMrn, ScoreMrn: assetMrn})
+	if err != nil {
+		return err
+	}
+
+	return ExecuteResolvedPolicy(schema, runtime, collectorSvc, assetMrn, resolvedPolicy, features, progressReporter,
+		WithIncrementalScan(changedResources, PreviousResults(report)), WithQueryTimeout(queryTimeout))
+}
+
+// PreviousResults returns the results of the report by datapoint checksum,
+// so that they can be reused by incremental scans
+func PreviousResults(report *policy.Report) map[string]*llx.RawResult {
+	if report == nil {
+		return nil
+	}
+
+	res := make(map[string]*llx.RawResult, len(report.Data))
+	for checksum, result := range report.Data {
+		res[checksum] = result.RawResultV2()
+	}
+	return res
+}
@@ -0,0 +1,88 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// testCode creates code with one entrypoint that calls the resource
+func testCode(id string, resource string, field string) *llx.CodeBundle {
+	return &llx.CodeBundle{CodeV2: &llx.CodeV2{
+		Id: id,
+		Blocks: []*llx.Block{{
+			Chunks: []*llx.Chunk{
+				{Call: llx.Chunk_FUNCTION, Id: resource},
+				{Call: llx.Chunk_FUNCTION, Id: field, Function: &llx.Function{Binding: 1, Type: string(types.String)}},
+			},
+			Entrypoints: []uint64{2},
+		}},
+		Checksums: map[uint64]string{2: id + "-entrypoint"},
+	}}
+}
+
+func TestIncrementalScan_ReusableResults(t *testing.T) {
+	resolvedPolicy := &policy.ResolvedPolicy{
+		ExecutionJob: &policy.ExecutionJob{
+			Queries: map[string]*policy.ExecutionQuery{
+				"file":    {Code: testCode("file", "file", "content")},
+				"ec2":     {Code: testCode("ec2", "aws.ec2.instance", "state")},
+				"users":   {Code: testCode("users", "users", "list")},
+				"missing": {Code: testCode("missing", "os", "name")},
+			},
+		},
+	}
+	previous := map[string]*llx.RawResult{
+		"file-entrypoint":  {CodeID: "file-entrypoint"},
+		"ec2-entrypoint":   {CodeID: "ec2-entrypoint"},
+		"users-entrypoint": {CodeID: "users-entrypoint"},
+	}
+
+	s := &incrementalScan{changedResources: []string{"file", "aws.ec2"}, previous: previous}
+	res := s.reusableResults(resolvedPolicy)
+	require.Len(t, res, 1)
+	assert.Equal(t, previous["users-entrypoint"], res["users"]["users-entrypoint"])
+}
+
+func TestIncrementalScan_ChangedQueries(t *testing.T) {
+	resolvedPolicy := &policy.ResolvedPolicy{
+		ExecutionJob: &policy.ExecutionJob{
+			Queries: map[string]*policy.ExecutionQuery{
+				"same":    {Checksum: "same-1", Code: testCode("same", "os", "name")},
+				"props":   {Checksum: "props-2", Code: testCode("props", "users", "list")},
+				"new":     {Checksum: "new-1", Code: testCode("new", "file", "content")},
+				"missing": {Checksum: "missing-1", Code: testCode("missing", "os", "hostname")},
+			},
+		},
+	}
+	previousPolicy := &policy.ResolvedPolicy{
+		ExecutionJob: &policy.ExecutionJob{
+			Queries: map[string]*policy.ExecutionQuery{
+				"same":    {Checksum: "same-1"},
+				"props":   {Checksum: "props-1"},
+				"missing": {Checksum: "missing-1"},
+			},
+		},
+	}
+	previous := PreviousResults(&policy.Report{Data: map[string]*llx.Result{
+		"same-entrypoint":  {CodeId: "same-entrypoint", Data: llx.StringPrimitive("same")},
+		"props-entrypoint": {CodeId: "props-entrypoint", Data: llx.StringPrimitive("props")},
+		"new-entrypoint":   {CodeId: "new-entrypoint", Data: llx.StringPrimitive("new")},
+	}})
+	require.Len(t, previous, 3)
+
+	conf := executeConfig{}
+	WithChangedQueries(previousPolicy, previous)(&conf)
+	res := conf.incremental.reusableResults(resolvedPolicy)
+	require.Len(t, res, 1)
+	assert.Equal(t, previous["same-entrypoint"], res["same"]["same-entrypoint"])
+
+	// without a previous resolved policy every query changed
+	conf = executeConfig{}
+	WithChangedQueries(nil, previous)(&conf)
+	assert.Empty(t, conf.incremental.reusableResults(resolvedPolicy))
+}
@@ -51,6 +51,24 @@ type GraphBuilder struct {
 	// queryTimeout is the amount of time to wait for the underlying lumi
 	// runtime to send all the expected datapoints.
 	queryTimeout time.Duration
+	// precomputedResults is a map of query ID to the results of all its
+	// datapoints. These queries are not executed, their results are used
+	// instead
+	precomputedResults map[string]map[string]*llx.RawResult
+	// capabilities of the asset's connection. Queries that need missing
+	// capabilities are not executed
+	capabilities *policy.Capabilities
+	// prerequisites of checks and whether the asset meets them. Checks
+	// with unmet prerequisites are not executed
+	prerequisites *policy.Prerequisites
+	// queryObserver is notified when queries start and finish (optional)
+	queryObserver QueryObserver
+	// queryConcurrency is the number of queries that are executed in
+	// parallel
+	queryConcurrency int
+	// dataAge is how old the data of queries is. Checks whose data is too
+	// old are reported as unscored (optional)
+	dataAge *policy.DataAge
 }
 
 func NewBuilder() *GraphBuilder {
@@ -65,6 +83,7 @@ func NewBuilder() *GraphBuilder {
 		progressReporter:          progress.Noop{},
 		mondooVersion:             cnspec.GetCoreVersion(),
 		queryTimeout:              5 * time.Minute,
+		precomputedResults:        map[string]map[string]*llx.RawResult{},
 	}
 }
 
@@ -126,11 +145,45 @@ func (b *GraphBuilder) WithMondooVersion(mondooVersion string) {
 	b.mondooVersion = mondooVersion
 }
 
+// AddPrecomputedResults provides the results for all datapoints of a query.
+// The query will not be executed, instead these results are reported.
+func (b *GraphBuilder) AddPrecomputedResults(queryID string, results map[string]*llx.RawResult) {
+	b.precomputedResults[queryID] = results
+}
+
+// WithCapabilities sets the capabilities of the asset's connection
+func (b *GraphBuilder) WithCapabilities(capabilities *policy.Capabilities) {
+	b.capabilities = capabilities
+}
+
+// WithPrerequisites sets the prerequisites of checks and whether the asset
+// meets them
+func (b *GraphBuilder) WithPrerequisites(prerequisites *policy.Prerequisites) {
+	b.prerequisites = prerequisites
+}
+
 // WithMondooVersion sets the version of mondoo
 func (b *GraphBuilder) WithQueryTimeout(timeout time.Duration) {
 	b.queryTimeout = timeout
 }
 
+// WithQueryObserver sets the observer of query executions
+func (b *GraphBuilder) WithQueryObserver(o QueryObserver) {
+	b.queryObserver = o
+}
+
+// WithQueryConcurrency sets how many independent queries are executed in
+// parallel
+func (b *GraphBuilder) WithQueryConcurrency(n int) {
+	b.queryConcurrency = n
+}
+
+// WithDataAge sets how old the data of the queries is, see
+// policy.MaxDataAgeTag
+func (b *GraphBuilder) WithDataAge(age *policy.DataAge) {
+	b.dataAge = age
+}
+
 func (b *GraphBuilder) Build(schema *resources.Schema, runtime *resources.Runtime, assetMrn string) (*GraphExecutor, error) {
 	resultChan := make(chan *llx.RawResult, 128)
 
@@ -149,6 +202,8 @@ func (b *GraphBuilder) Build(schema *resources.Schema, runtime *resources.Runtim
 		resultChan: resultChan,
 		doneChan:   make(chan struct{}),
 	}
+	ge.executionManager.observer = b.queryObserver
+	ge.executionManager.concurrency = b.queryConcurrency
 
 	ge.nodes[DatapointCollectorID] = &Node{
 		id:       DatapointCollectorID,
@@ -180,13 +235,33 @@ func (b *GraphBuilder) Build(schema *resources.Schema, runtime *resources.Runtim
 	}
 
 	for queryID, q := range queries {
+		stale := b.dataAge.StaleMessage(queryID, CodepointChecksums(q.codeBundle))
+
+		if results, ok := b.precomputedResults[queryID]; ok {
+			ge.addPrecomputedQueryNodes(q, results, b.datapointType)
+			ge.addReportingQueryNode(queryID, q, stale)
+			continue
+		}
+
+		if err := b.prerequisites.Check(queryID); err != nil {
+			ge.addUnsupportedQueryNodes(q, err)
+			ge.addReportingQueryNode(queryID, q, stale)
+			continue
+		}
+
+		if err := b.capabilities.Check(q.codeBundle); err != nil {
+			ge.addUnsupportedQueryNodes(q, err)
+			ge.addReportingQueryNode(queryID, q, stale)
+			continue
+		}
+
 		canRun := checkVersion(q.codeBundle, mondooVersion)
 		if canRun {
 			ge.addExecutionQueryNode(queryID, q, q.resolvedProperties, b.datapointType)
 		} else {
 			unrunnableQueries = append(unrunnableQueries, q)
 		}
-		ge.addReportingQueryNode(queryID, q)
+		ge.addReportingQueryNode(queryID, q, stale)
 	}
 
 	scoresToCollect := make([]string, len(b.collectScoreQrIDs))
@@ -253,6 +328,30 @@ func (ge *GraphExecutor) handleUnrunnableQueries(unrunnableQueries []query) {
 	}
 }
 
+// addUnsupportedQueryNodes adds the datapoints of a query that can't be
+// executed, since the asset's connection lacks capabilities it needs or
+// the asset doesn't meet its prerequisites
+func (ge *GraphExecutor) addUnsupportedQueryNodes(q query, err error) {
+	for _, checksum := range CodepointChecksums(q.codeBundle) {
+		ge.addDatapointNode(checksum, nil, &llx.RawResult{
+			CodeID: checksum,
+			Data:   &llx.RawData{Error: err},
+		})
+	}
+}
+
+// addPrecomputedQueryNodes adds the datapoints of a query that doesn't need
+// to be executed, since its results are already known
+func (ge *GraphExecutor) addPrecomputedQueryNodes(q query, results map[string]*llx.RawResult, datapointTypeMap map[string]string) {
+	for _, checksum := range CodepointChecksums(q.codeBundle) {
+		var expectedType *string
+		if t, ok := datapointTypeMap[checksum]; ok {
+			expectedType = &t
+		}
+		ge.addDatapointNode(checksum, expectedType, results[checksum])
+	}
+}
+
 func (ge *GraphExecutor) addEdge(from NodeID, to NodeID) {
 	ge.edges[from] = insertSorted(ge.edges[from], to)
 }
@@ -344,15 +443,16 @@ func (ge *GraphExecutor) addExecutionQueryNode(queryID string, q query, resolved
 	ge.nodes[n.id] = n
 }
 
-func (ge *GraphExecutor) addReportingQueryNode(queryID string, q query) {
+func (ge *GraphExecutor) addReportingQueryNode(queryID string, q query, stale string) {
 	n, ok := ge.nodes[NodeID(queryID)]
 	if ok {
 		return
 	}
 
 	nodeData := &ReportingQueryNodeData{
-		results: map[string]*DataResult{},
-		queryID: queryID,
+		results:      map[string]*DataResult{},
+		queryID:      queryID,
+		staleMessage: stale,
 	}
 
 	n = &Node{
@@ -392,10 +492,11 @@ func (ge *GraphExecutor) addReportingJobNode(assetMrn string, reportingJobID str
 	}
 
 	nodeData := &ReportingJobNodeData{
-		queryID:     queryID,
-		isQuery:     isQuery,
-		childScores: map[string]*reportingJobResult{},
-		datapoints:  map[string]*reportingJobDatapoint{},
+		queryID:       queryID,
+		isQuery:       isQuery,
+		informational: rj.Informational,
+		childScores:   map[string]*reportingJobResult{},
+		datapoints:    map[string]*reportingJobDatapoint{},
 	}
 	n = &Node{
 		id:       NodeID(reportingJobID),
@@ -33,6 +33,12 @@ type Collector interface {
 	ScoreCollector
 }
 
+// ResultCollector receives the data and scores of one flush together, so
+// that they can be stored atomically
+type ResultCollector interface {
+	SinkResults([]*llx.RawResult, []*policy.Score)
+}
+
 type BufferedCollector struct {
 	results   map[string]*llx.RawResult
 	scores    map[string]*policy.Score
@@ -83,12 +89,18 @@ func (c *BufferedCollector) run() {
 			}
 			c.lock.Unlock()
 
-			if len(results) > 0 {
-				c.collector.SinkData(results)
-			}
+			if rc, ok := c.collector.(ResultCollector); ok {
+				if len(results) > 0 || len(scores) > 0 {
+					rc.SinkResults(results, scores)
+				}
+			} else {
+				if len(results) > 0 {
+					c.collector.SinkData(results)
+				}
 
-			if len(scores) > 0 {
-				c.collector.SinkScore(scores)
+				if len(scores) > 0 {
+					c.collector.SinkScore(scores)
+				}
 			}
 
 			results = results[:0]
@@ -136,6 +148,8 @@ func (c *BufferedCollector) SinkScore(scores []*policy.Score) {
 type PolicyServiceCollector struct {
 	assetMrn string
 	resolver policy.PolicyResolver
+	// sampling is indexed by datapoint checksum
+	sampling map[string]*policy.DataSampling
 }
 
 func NewPolicyServiceCollector(assetMrn string, resolver policy.PolicyResolver) *PolicyServiceCollector {
@@ -145,8 +159,19 @@ func NewPolicyServiceCollector(assetMrn string, resolver policy.PolicyResolver)
 	}
 }
 
+// WithSampling bounds the size of the given datapoints before they are stored
+func (c *PolicyServiceCollector) WithSampling(sampling map[string]*policy.DataSampling) {
+	c.sampling = sampling
+}
+
 func (c *PolicyServiceCollector) toResult(rr *llx.RawResult) *llx.Result {
-	v := rr.Result()
+	var v *llx.Result
+	if sampling, ok := c.sampling[rr.CodeID]; ok {
+		v = c.sampledResult(rr, sampling)
+	} else {
+		v = rr.Result()
+	}
+
 	if v.Data.Size() > MAX_DATAPOINT {
 		log.Warn().
 			Str("asset", c.assetMrn).
@@ -161,37 +186,68 @@ func (c *PolicyServiceCollector) toResult(rr *llx.RawResult) *llx.Result {
 	return v
 }
 
-func (c *PolicyServiceCollector) SinkData(results []*llx.RawResult) {
-	if len(results) == 0 {
-		return
+// sampledResult samples the given result and truncates lists until they
+// fit into the max size of the sampling
+func (c *PolicyServiceCollector) sampledResult(rr *llx.RawResult, sampling *policy.DataSampling) *llx.Result {
+	rr = sampling.Sample(rr)
+	v := rr.Result()
+	if sampling.MaxBytes == 0 || v.Data.Size() <= sampling.MaxBytes {
+		return v
 	}
-	resultsToSend := make(map[string]*llx.Result, len(results))
-	for _, rr := range results {
-		resultsToSend[rr.CodeID] = c.toResult(rr)
+
+	list, ok := rr.Data.Value.([]interface{})
+	for ok && len(list) > 0 && v.Data.Size() > sampling.MaxBytes {
+		rr = (&policy.DataSampling{Mode: policy.SampleFirst, Limit: len(list) / 2}).Sample(rr)
+		list = rr.Data.Value.([]interface{})
+		v = rr.Result()
 	}
-	log.Debug().Msg("Sending datapoints")
-	_, err := c.resolver.StoreResults(context.Background(), &policy.StoreResultsReq{
-		AssetMrn:       c.assetMrn,
-		Data:           resultsToSend,
-		IsPreprocessed: true,
-	})
-	if err != nil {
-		log.Error().Err(err).Msg("failed to send datapoints")
+
+	if v.Data.Size() > sampling.MaxBytes {
+		log.Warn().
+			Str("asset", c.assetMrn).
+			Str("id", rr.CodeID).
+			Int("max-size", sampling.MaxBytes).
+			Msg("executor.scoresheet> not storing datafield because it exceeds its max size")
+
+		return &llx.Result{
+			Error:  "datafield was removed because it exceeds its max size",
+			CodeId: v.CodeId,
+		}
 	}
+
+	return v
+}
+
+func (c *PolicyServiceCollector) SinkData(results []*llx.RawResult) {
+	c.SinkResults(results, nil)
 }
 
 func (c *PolicyServiceCollector) SinkScore(scores []*policy.Score) {
-	if len(scores) == 0 {
+	c.SinkResults(nil, scores)
+}
+
+// SinkResults sends data and scores in one request, which datalakes that
+// support batch updates store atomically, see policy.BatchUpdater
+func (c *PolicyServiceCollector) SinkResults(results []*llx.RawResult, scores []*policy.Score) {
+	if len(results) == 0 && len(scores) == 0 {
 		return
 	}
-	log.Debug().Msg("Sending scores")
+	var resultsToSend map[string]*llx.Result
+	if len(results) != 0 {
+		resultsToSend = make(map[string]*llx.Result, len(results))
+		for _, rr := range results {
+			resultsToSend[rr.CodeID] = c.toResult(rr)
+		}
+	}
+	log.Debug().Int("datapoints", len(results)).Int("scores", len(scores)).Msg("Sending results")
 	_, err := c.resolver.StoreResults(context.Background(), &policy.StoreResultsReq{
 		AssetMrn:       c.assetMrn,
+		Data:           resultsToSend,
 		Scores:         scores,
 		IsPreprocessed: true,
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("failed to send scores")
+		log.Error().Err(err).Msg("failed to send results")
 	}
 }
 
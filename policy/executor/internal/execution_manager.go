@@ -28,6 +28,19 @@ type executionManager struct {
 	// stopChan is a channel that is closed when a stop is requested
 	stopChan chan struct{}
 	wg       sync.WaitGroup
+	// observer is notified when queries start and finish (optional)
+	observer QueryObserver
+	// concurrency is the number of queries that are executed in parallel,
+	// defaults to 1
+	concurrency int
+}
+
+// QueryObserver is notified when the execution of a query starts and
+// finishes, e.g. to attribute calls on the asset to the query. Observers
+// that attribute calls need queries to be executed one after the other.
+type QueryObserver interface {
+	QueryStarted(codeID string)
+	QueryFinished(codeID string)
 }
 
 type runQueueItem struct {
@@ -49,57 +62,68 @@ func newExecutionManager(schema *resources.Schema, runtime *resources.Runtime, r
 	}
 }
 
+// Start executes queries from the run queue. Queries are only queued once
+// all properties they depend on are available, so all queued queries are
+// independent and up to concurrency of them run in parallel.
 func (em *executionManager) Start() {
-	em.wg.Add(1)
-	go func() {
-		defer em.wg.Done()
-		for {
-			// Prioritize stopChan
-			select {
-			case <-em.stopChan:
+	workers := em.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		em.wg.Add(1)
+		go em.worker()
+	}
+}
+
+func (em *executionManager) worker() {
+	defer em.wg.Done()
+	for {
+		// Prioritize stopChan
+		select {
+		case <-em.stopChan:
+			return
+		default:
+		}
+
+		select {
+		case item, ok := <-em.runQueue:
+			if !ok {
 				return
-			default:
 			}
-
-			select {
-			case item, ok := <-em.runQueue:
-				if !ok {
-					return
-				}
-				props := make(map[string]*llx.Primitive)
-				errMsg := ""
-				for k, r := range item.props {
-					if r.Error != "" {
-						// This case is tricky to handle. If we cannot run the query at
-						// all, its unclear what to report for the datapoint. If we
-						// report them in, then another query cant report them, at least
-						// with the way things are right now. If we don't report them,
-						// things will wait around for datapoint results that will never
-						// arrive.
-						errMsg = "property " + k + " errored: " + r.Error
-						break
-					}
-					props[k] = r.Data
+			props := make(map[string]*llx.Primitive)
+			errMsg := ""
+			for k, r := range item.props {
+				if r.Error != "" {
+					// This case is tricky to handle. If we cannot run the query at
+					// all, its unclear what to report for the datapoint. If we
+					// report them in, then another query cant report them, at least
+					// with the way things are right now. If we don't report them,
+					// things will wait around for datapoint results that will never
+					// arrive.
+					errMsg = "property " + k + " errored: " + r.Error
+					break
 				}
+				props[k] = r.Data
+			}
 
-				if err := em.executeCodeBundle(item.codeBundle, props, errMsg); err != nil {
-					// an error is returned if we cannot execute a query. This happens
-					// if the lumi runtime doesn't report back expected data, there is
-					// a problem with the lumi runtime, or the query is somehow invalid.
-					// We need to give up here because the underlying runtime is in a bad
-					// state and/or we will not be able to report certain datapoints and
-					// we cannot be confident about which ones
-					select {
-					case em.errChan <- err:
-					default:
-					}
-					return
+			if err := em.executeCodeBundle(item.codeBundle, props, errMsg); err != nil {
+				// an error is returned if we cannot execute a query. This happens
+				// if the lumi runtime doesn't report back expected data, there is
+				// a problem with the lumi runtime, or the query is somehow invalid.
+				// We need to give up here because the underlying runtime is in a bad
+				// state and/or we will not be able to report certain datapoints and
+				// we cannot be confident about which ones
+				select {
+				case em.errChan <- err:
+				default:
 				}
-			case <-em.stopChan:
 				return
 			}
+		case <-em.stopChan:
+			return
 		}
-	}()
+	}
 }
 
 func (em *executionManager) Err() chan error {
@@ -154,7 +178,13 @@ func (em *executionManager) executeCodeBundle(codeBundle *llx.CodeBundle, props
 
 	codeID := codeBundle.CodeV2.GetId()
 	log.Debug().Str("qrid", codeID).Msg("starting query execution")
+	if em.observer != nil {
+		em.observer.QueryStarted(codeID)
+	}
 	defer func() {
+		if em.observer != nil {
+			em.observer.QueryFinished(codeID)
+		}
 		log.Debug().Str("qrid", codeID).Msg("finished query execution")
 	}()
 	// TODO(jaym): sendResult may not be correct. We may need to fill in the
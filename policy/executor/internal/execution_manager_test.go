@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/motor"
+	"go.mondoo.com/cnquery/motor/providers/mock"
+	"go.mondoo.com/cnquery/mqlc"
+	"go.mondoo.com/cnquery/resources"
+	resource_pack "go.mondoo.com/cnquery/resources/packs/core"
+	"go.mondoo.com/cnquery/types"
+)
+
+func TestExecutionManager_Concurrency(t *testing.T) {
+	runQueue := make(chan runQueueItem, 3)
+	resultChan := make(chan *llx.RawResult, 3)
+	em := newExecutionManager(nil, nil, runQueue, resultChan, time.Second)
+	em.concurrency = 2
+	em.Start()
+
+	// queries whose properties errored report errors for all their
+	// datapoints without running
+	for _, id := range []string{"a", "b", "c"} {
+		runQueue <- runQueueItem{
+			codeBundle: &llx.CodeBundle{CodeV2: &llx.CodeV2{
+				Id:        id,
+				Blocks:    []*llx.Block{{Entrypoints: []uint64{1}}},
+				Checksums: map[uint64]string{1: id + "-checksum"},
+			}},
+			props: map[string]*llx.Result{"prop": {Error: "failed"}},
+		}
+	}
+
+	received := map[string]struct{}{}
+	for i := 0; i < 3; i++ {
+		select {
+		case res := <-resultChan:
+			require.Error(t, res.Data.Error)
+			received[res.CodeID] = struct{}{}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for results")
+		}
+	}
+	assert.Equal(t, map[string]struct{}{"a-checksum": {}, "b-checksum": {}, "c-checksum": {}}, received)
+
+	em.Stop()
+}
+
+func testRuntime(t *testing.T) (*resources.Schema, *resources.Runtime) {
+	transport, err := mock.NewFromTomlFile("../testdata/arch.toml")
+	require.NoError(t, err)
+	m, err := motor.New(transport)
+	require.NoError(t, err)
+	registry := resource_pack.Registry
+	return registry.Schema(), resources.NewRuntime(registry, m)
+}
+
+func compileTestQuery(t *testing.T, schema *resources.Schema, code string, props map[string]*llx.Primitive) *llx.CodeBundle {
+	codeBundle, err := mqlc.Compile(code, props, mqlc.NewConfig(schema, cnquery.DefaultFeatures))
+	require.NoError(t, err)
+	return codeBundle
+}
+
+// barrierObserver holds every query in QueryStarted until n queries were
+// started, which only happens if they run in parallel. Without n it only
+// records the order in which queries start.
+type barrierObserver struct {
+	n       int
+	timeout time.Duration
+	all     chan struct{}
+
+	lock     sync.Mutex
+	started  []string
+	timedOut bool
+}
+
+func newBarrierObserver(n int, timeout time.Duration) *barrierObserver {
+	return &barrierObserver{n: n, timeout: timeout, all: make(chan struct{})}
+}
+
+func (o *barrierObserver) QueryStarted(codeID string) {
+	o.lock.Lock()
+	o.started = append(o.started, codeID)
+	if len(o.started) == o.n {
+		close(o.all)
+	}
+	o.lock.Unlock()
+	if o.n <= 0 {
+		return
+	}
+
+	select {
+	case <-o.all:
+	case <-time.After(o.timeout):
+		o.lock.Lock()
+		o.timedOut = true
+		o.lock.Unlock()
+	}
+}
+
+func (o *barrierObserver) QueryFinished(codeID string) {}
+
+type testDatapointCollector struct {
+	lock    sync.Mutex
+	results []*llx.RawResult
+}
+
+func (c *testDatapointCollector) SinkData(results []*llx.RawResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.results = append(c.results, results...)
+}
+
+func TestExecutionManager_ParallelQueries(t *testing.T) {
+	schema, runtime := testRuntime(t)
+	n := 4
+
+	b := NewBuilder()
+	b.WithQueryConcurrency(n)
+	observer := newBarrierObserver(n, 5*time.Second)
+	b.WithQueryObserver(observer)
+	collector := &testDatapointCollector{}
+	b.AddDatapointCollector(collector)
+	for i := 0; i < n; i++ {
+		codeBundle := compileTestQuery(t, schema, strconv.Itoa(i)+" == "+strconv.Itoa(i), nil)
+		b.AddQuery(codeBundle, nil, nil)
+		for _, checksum := range CodepointChecksums(codeBundle) {
+			b.CollectDatapoint(checksum)
+		}
+	}
+
+	ge, err := b.Build(schema, runtime, "//asset")
+	require.NoError(t, err)
+	require.NoError(t, ge.Execute())
+
+	assert.Len(t, observer.started, n)
+	assert.False(t, observer.timedOut, "queries must run in parallel")
+	require.Len(t, collector.results, n)
+	for _, res := range collector.results {
+		require.NoError(t, res.Data.Error)
+		assert.Equal(t, true, res.Data.Value)
+	}
+}
+
+func TestExecutionManager_DependentQueries(t *testing.T) {
+	schema, runtime := testRuntime(t)
+
+	b := NewBuilder()
+	b.WithQueryConcurrency(4)
+	observer := newBarrierObserver(0, 0)
+	b.WithQueryObserver(observer)
+	collector := &testDatapointCollector{}
+	b.AddDatapointCollector(collector)
+
+	property := compileTestQuery(t, schema, "'hello'", nil)
+	propertyChecksum := CodepointChecksums(property)[0]
+	b.AddQuery(property, nil, nil)
+	b.CollectDatapoint(propertyChecksum)
+
+	dependents := map[string]struct{}{}
+	for _, code := range []string{"props.name == 'hello'", "props.name != 'world'"} {
+		codeBundle := compileTestQuery(t, schema, code, map[string]*llx.Primitive{
+			"name": {Type: string(types.String)},
+		})
+		b.AddQuery(codeBundle, map[string]string{"name": propertyChecksum}, nil)
+		for _, checksum := range CodepointChecksums(codeBundle) {
+			b.CollectDatapoint(checksum)
+			dependents[checksum] = struct{}{}
+		}
+	}
+
+	ge, err := b.Build(schema, runtime, "//asset")
+	require.NoError(t, err)
+	require.NoError(t, ge.Execute())
+
+	// the property query is started first and its result arrives before
+	// the results of the queries that depend on it
+	require.Len(t, observer.started, 3)
+	assert.Equal(t, property.CodeV2.Id, observer.started[0])
+	require.Len(t, collector.results, 1+len(dependents))
+	assert.Equal(t, propertyChecksum, collector.results[0].CodeID)
+	assert.Equal(t, "hello", collector.results[0].Data.Value)
+	for _, res := range collector.results[1:] {
+		assert.Contains(t, dependents, res.CodeID)
+		require.NoError(t, res.Data.Error)
+		assert.Equal(t, true, res.Data.Value, "dependent queries must run with their properties")
+	}
+}
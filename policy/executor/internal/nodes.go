@@ -256,6 +256,9 @@ func (nodeData *DatapointNodeData) recalculate() *envelope {
 // ReportingQueryNodeData is the data for queries of type ReportingQueryNodeType.
 type ReportingQueryNodeData struct {
 	queryID string
+	// staleMessage is set if the data of the query is too old to score it,
+	// see policy.MaxDataAgeTag
+	staleMessage string
 
 	results     map[string]*DataResult
 	invalidated bool
@@ -322,9 +325,13 @@ func (nodeData *ReportingQueryNodeData) score() *policy.Score {
 
 		if cur.Data.Error != nil {
 			var resourceNotFoundErr *resources.ResourceNotFound
+			var capabilityMissingErr *policy.CapabilityMissingError
+			var prerequisiteMissingErr *policy.PrerequisiteMissingError
 			if errors.As(cur.Data.Error, &resourceNotFoundErr) {
 				assetVanishedDuringScan = true
-			} else {
+			} else if !errors.As(cur.Data.Error, &capabilityMissingErr) && !errors.As(cur.Data.Error, &prerequisiteMissingErr) {
+				// queries without capabilities or prerequisites are skipped,
+				// see policy.Capabilities and policy.Prerequisites
 				allSkipped = false
 				foundError = true
 			}
@@ -357,7 +364,16 @@ func (nodeData *ReportingQueryNodeData) score() *policy.Score {
 	}
 
 	if allFound {
-		if assetVanishedDuringScan {
+		if nodeData.staleMessage != "" {
+			return &policy.Score{
+				QrId:            nodeData.queryID,
+				Type:            policy.ScoreType_Unscored,
+				Value:           0,
+				ScoreCompletion: 100,
+				Weight:          1,
+				Message:         nodeData.staleMessage,
+			}
+		} else if assetVanishedDuringScan {
 			return &policy.Score{
 				QrId:            nodeData.queryID,
 				Type:            policy.ScoreType_Unscored,
@@ -382,7 +398,7 @@ func (nodeData *ReportingQueryNodeData) score() *policy.Score {
 				Value:           0,
 				ScoreCompletion: 100,
 				Weight:          1,
-				Message:         "",
+				Message:         errorsMsg,
 			}
 		} else {
 			if scoreFound == nil {
@@ -419,6 +435,7 @@ type ReportingJobNodeData struct {
 	queryID       string
 	scoringSystem policy.ScoringSystem
 	isQuery       bool
+	informational bool
 
 	childScores map[NodeID]*reportingJobResult
 	datapoints  map[NodeID]*reportingJobDatapoint
@@ -474,6 +491,7 @@ func (nodeData *ReportingJobNodeData) recalculate() *envelope {
 				ScoreCompletion: 100,
 				Weight:          1,
 				Message:         err.Error(),
+				Informational:   nodeData.informational,
 			},
 		}
 	}
@@ -517,6 +535,9 @@ func (nodeData *ReportingJobNodeData) score() (*policy.Score, error) {
 		} else {
 			s = proto.Clone(v).(*policy.Score)
 		}
+		// informational checks are reported as such, so that their parents
+		// don't count them
+		s.Informational = nodeData.informational
 
 		// TODO: It's unclear if we should do this if the score is skipped or errored
 		// If the executor doesn't return something for every datapoint, then this will
@@ -630,6 +630,45 @@ func TestReportingQueryNode(t *testing.T) {
 				assert.Equal(t, 100, int(data.score.ScoreCompletion))
 			})
 		})
+		t.Run("unscored if data is stale", func(t *testing.T) {
+			nodeData := newNodeData()
+			nodeData.staleMessage = "data is too old"
+			nodeData.results = map[string]*DataResult{
+				"checksum1": {
+					checksum: "checksum1",
+					resolved: true,
+					value:    llx.BoolTrue.Result().RawResultV2(),
+				},
+			}
+
+			nodeData.initialize()
+			data := nodeData.recalculate()
+			require.NotNil(t, data)
+			require.NotNil(t, data.score)
+			assert.Equal(t, "testqueryid", data.score.QrId)
+			assert.Equal(t, policy.ScoreType_Unscored, data.score.Type)
+			assert.Equal(t, "data is too old", data.score.Message)
+			assert.Equal(t, 100, int(data.score.ScoreCompletion))
+		})
+		t.Run("skipped if prerequisites are missing", func(t *testing.T) {
+			err := &policy.PrerequisiteMissingError{Prerequisites: []string{"sudo"}, Reasons: []string{"no sudo"}}
+			nodeData := newNodeData()
+			nodeData.results = map[string]*DataResult{
+				"checksum1": {
+					checksum: "checksum1",
+					resolved: true,
+					value:    &llx.RawResult{CodeID: "checksum1", Data: &llx.RawData{Error: err}},
+				},
+			}
+
+			nodeData.initialize()
+			data := nodeData.recalculate()
+			require.NotNil(t, data)
+			require.NotNil(t, data.score)
+			assert.Equal(t, policy.ScoreType_Skip, data.score.Type)
+			assert.Equal(t, "prerequisite missing: the check requires sudo (no sudo)", data.score.Message)
+			assert.Equal(t, 100, int(data.score.ScoreCompletion))
+		})
 	})
 }
 
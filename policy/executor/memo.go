@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"sync"
+
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/cnspec/policy/executor/internal"
+)
+
+// ResultMemo shares the results of queries across assets, e.g. of
+// deterministic queries across assets that have the same platform
+// fingerprint, like fleets built from one golden image. Results are
+// partitioned by the assets that share them. It is safe for concurrent use.
+type ResultMemo struct {
+	lock    sync.RWMutex
+	results map[string]map[string]*llx.RawResult
+}
+
+// NewResultMemo creates an empty memo
+func NewResultMemo() *ResultMemo {
+	return &ResultMemo{
+		results: map[string]map[string]*llx.RawResult{},
+	}
+}
+
+func memoKey(partition string, queryID string) string {
+	return partition + "\x00" + queryID
+}
+
+func (m *ResultMemo) load(partition string, queryID string) (map[string]*llx.RawResult, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	res, ok := m.results[memoKey(partition, queryID)]
+	return res, ok
+}
+
+func (m *ResultMemo) store(partition string, queryID string, results map[string]*llx.RawResult) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.results[memoKey(partition, queryID)] = results
+}
+
+// Len returns the number of memoized query results
+func (m *ResultMemo) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.results)
+}
+
+// memoQuery is a query whose results are shared via a memo
+type memoQuery struct {
+	memo *ResultMemo
+	// partition groups the assets that share the results
+	partition string
+	checksums []string
+}
+
+// memoizableQueries returns all queries of the resolved policy whose results
+// are shared, mapped to the memo they are shared in and the checksums of all
+// their datapoints. Queries in the result cache are shared by their cache
+// key, deterministic queries by the platform fingerprint. Queries with
+// properties are excluded, since their results depend on the asset.
+func memoizableQueries(resolvedPolicy *policy.ResolvedPolicy, conf *executeConfig) map[string]memoQuery {
+	res := map[string]memoQuery{}
+	for codeID, eq := range resolvedPolicy.ExecutionJob.Queries {
+		if eq.Code == nil || len(eq.Properties) != 0 {
+			continue
+		}
+
+		var memo *ResultMemo
+		var partition string
+		if key, ok := conf.cacheKeys[codeID]; ok && conf.cache != nil {
+			memo, partition = conf.cache, key
+		} else if _, ok := conf.deterministic[codeID]; ok && conf.memo != nil && conf.fingerprint != "" {
+			memo, partition = conf.memo, conf.fingerprint
+		} else {
+			continue
+		}
+
+		res[codeID] = memoQuery{
+			memo:      memo,
+			partition: partition,
+			checksums: internal.CodepointChecksums(eq.Code),
+		}
+	}
+	return res
+}
+
+// memoCollector captures the results of memoizable queries during execution
+type memoCollector struct {
+	lock    sync.Mutex
+	results map[string]*llx.RawResult
+}
+
+func (c *memoCollector) SinkData(results []*llx.RawResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, rr := range results {
+		c.results[rr.CodeID] = rr
+	}
+}
+
+// storeResults adds all queries to their memo for which results were
+// captured for every datapoint. Queries with errors are not memoized.
+func (c *memoCollector) storeResults(queries map[string]memoQuery) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+OUTER:
+	for queryID, query := range queries {
+		results := make(map[string]*llx.RawResult, len(query.checksums))
+		for _, checksum := range query.checksums {
+			rr, ok := c.results[checksum]
+			if !ok || rr.Data == nil || rr.Data.Error != nil {
+				continue OUTER
+			}
+			results[checksum] = rr
+		}
+		query.memo.store(query.partition, queryID, results)
+	}
+}
@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestMemoizableQueries(t *testing.T) {
+	resolvedPolicy := &policy.ResolvedPolicy{
+		ExecutionJob: &policy.ExecutionJob{
+			Queries: map[string]*policy.ExecutionQuery{
+				"os":         {Code: testCode("os", "os", "name")},
+				"advisories": {Code: testCode("advisories", "platform", "advisories")},
+				"users":      {Code: testCode("users", "users", "list")},
+				"props": {
+					Code:       testCode("props", "file", "content"),
+					Properties: map[string]string{"path": "path-checksum"},
+				},
+			},
+		},
+	}
+
+	memo := NewResultMemo()
+	cache := NewResultMemo()
+	conf := &executeConfig{
+		memo:          memo,
+		fingerprint:   "ubuntu-22.04",
+		deterministic: map[string]struct{}{"os": {}, "advisories": {}, "props": {}},
+		cache:         cache,
+		cacheKeys:     map[string]string{"advisories": "debian", "props": "debian"},
+	}
+
+	res := memoizableQueries(resolvedPolicy, conf)
+	require.Len(t, res, 2)
+	assert.Equal(t, memoQuery{memo: memo, partition: "ubuntu-22.04", checksums: []string{"os-entrypoint"}}, res["os"])
+	// the result cache takes precedence over the platform fingerprint
+	assert.Equal(t, memoQuery{memo: cache, partition: "debian", checksums: []string{"advisories-entrypoint"}}, res["advisories"])
+
+	collector := &memoCollector{results: map[string]*llx.RawResult{
+		"os-entrypoint":         {CodeID: "os-entrypoint", Data: llx.StringData("ubuntu")},
+		"advisories-entrypoint": {CodeID: "advisories-entrypoint", Data: llx.StringData("none")},
+	}}
+	collector.storeResults(res)
+	assert.Equal(t, 1, memo.Len())
+	assert.Equal(t, 1, cache.Len())
+
+	cached, ok := cache.load("debian", "advisories")
+	require.True(t, ok)
+	assert.Equal(t, "none", cached["advisories-entrypoint"].Data.Value)
+	_, ok = cache.load("redhat", "advisories")
+	assert.False(t, ok)
+}
+
+func TestResultMemo(t *testing.T) {
+	memo := NewResultMemo()
+	results := map[string]*llx.RawResult{"os-entrypoint": {CodeID: "os-entrypoint", Data: llx.StringData("ubuntu")}}
+	memo.store("ubuntu-22.04", "os", results)
+
+	res, ok := memo.load("ubuntu-22.04", "os")
+	require.True(t, ok)
+	assert.Equal(t, results, res)
+	// other platforms don't share the results
+	_, ok = memo.load("ubuntu-20.04", "os")
+	assert.False(t, ok)
+	_, ok = memo.load("ubuntu-22.04", "users")
+	assert.False(t, ok)
+	assert.Equal(t, 1, memo.Len())
+}
+
+// run with -race, assets are scanned concurrently
+func TestResultMemoConcurrency(t *testing.T) {
+	memo := NewResultMemo()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			partition := "platform-" + strconv.Itoa(i%2)
+			for j := 0; j < 100; j++ {
+				queryID := "query-" + strconv.Itoa(j)
+				memo.store(partition, queryID, map[string]*llx.RawResult{queryID: {CodeID: queryID, Data: llx.IntData(int64(j))}})
+				memo.load(partition, queryID)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 200, memo.Len())
+}
+
+func TestMemoizableQueries_Deterministic(t *testing.T) {
+	resolvedPolicy := &policy.ResolvedPolicy{
+		ExecutionJob: &policy.ExecutionJob{
+			Queries: map[string]*policy.ExecutionQuery{
+				"os":    {Code: testCode("os", "os", "name")},
+				"users": {Code: testCode("users", "users", "list")},
+			},
+		},
+	}
+	deterministic := map[string]struct{}{"os": {}}
+
+	t.Run("only deterministic queries are shared", func(t *testing.T) {
+		memo := NewResultMemo()
+		res := memoizableQueries(resolvedPolicy, &executeConfig{memo: memo, fingerprint: "ubuntu-22.04", deterministic: deterministic})
+		require.Len(t, res, 1)
+		assert.Equal(t, memoQuery{memo: memo, partition: "ubuntu-22.04", checksums: []string{"os-entrypoint"}}, res["os"])
+	})
+
+	t.Run("assets without fingerprint don't share results", func(t *testing.T) {
+		res := memoizableQueries(resolvedPolicy, &executeConfig{memo: NewResultMemo(), deterministic: deterministic})
+		assert.Empty(t, res)
+	})
+
+	t.Run("memoization is opt-in", func(t *testing.T) {
+		res := memoizableQueries(resolvedPolicy, &executeConfig{fingerprint: "ubuntu-22.04", deterministic: deterministic})
+		assert.Empty(t, res)
+	})
+}
+
+func TestMemoCollector(t *testing.T) {
+	memo := NewResultMemo()
+	query := func(checksums ...string) memoQuery {
+		return memoQuery{memo: memo, partition: "ubuntu-22.04", checksums: checksums}
+	}
+	collector := &memoCollector{results: map[string]*llx.RawResult{}}
+	collector.SinkData([]*llx.RawResult{
+		{CodeID: "os-entrypoint", Data: llx.StringData("ubuntu")},
+		{CodeID: "kernel-entrypoint", Data: llx.StringData("5.15")},
+		{CodeID: "failed-entrypoint", Data: &llx.RawData{Error: errors.New("permission denied")}},
+	})
+
+	collector.storeResults(map[string]memoQuery{
+		"os":      query("os-entrypoint", "kernel-entrypoint"),
+		"partial": query("os-entrypoint", "missing-entrypoint"),
+		"failed":  query("failed-entrypoint"),
+	})
+	assert.Equal(t, 1, memo.Len())
+	res, ok := memo.load("ubuntu-22.04", "os")
+	require.True(t, ok)
+	assert.Len(t, res, 2)
+
+	// queries with missing or failed datapoints are run again by the next asset
+	_, ok = memo.load("ubuntu-22.04", "partial")
+	assert.False(t, ok)
+	_, ok = memo.load("ubuntu-22.04", "failed")
+	assert.False(t, ok)
+}
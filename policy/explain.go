@@ -0,0 +1,379 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// ResolvePlan explains how a policy is resolved for an asset, see
+// ExplainResolve
+type ResolvePlan struct {
+	PolicyMrn string
+	// AssetFilters are the asset filters that match the policy
+	AssetFilters []string
+	// Cached is true if the resolved policy is already cached, in which
+	// case it is used instead of resolving it again
+	Cached bool
+	// Space is set if the asset shares the resolved policy of its space
+	Space         string
+	Policies      []*PlannedPolicy
+	Queries       []*PlannedQuery
+	ReportingJobs []*PlannedReportingJob
+	Conflicts     []*PolicyConflict
+	// Errors are problems with the policies that didn't stop the resolution
+	Errors []string
+}
+
+// PlannedPolicy is a policy of the bundle and whether it is resolved
+type PlannedPolicy struct {
+	Mrn    string
+	Active bool
+	// Reason explains why the policy isn't active
+	Reason      string
+	ActivatedBy []string
+}
+
+// PlannedQuery is a check or data query of the bundle and whether it runs
+type PlannedQuery struct {
+	Mrn    string
+	CodeId string
+	IsData bool
+	Active bool
+	// Reason explains why the query doesn't run
+	Reason string
+	// DuplicateOf is the query that runs instead of this one, since both
+	// compile to the same code
+	DuplicateOf string
+	ActivatedBy []string
+}
+
+// PlannedReportingJob collects the results of a check or the scores of a
+// policy and reports them to its parents
+type PlannedReportingJob struct {
+	QrId string
+	// Mrns of the checks that this job scores, since check jobs are
+	// identified by the code ID
+	Mrns   []string
+	IsData bool
+	// Children are the jobs that feed into this one's score, by QrId
+	Children []string
+	// Notify are the jobs that this one's score feeds into, by QrId
+	Notify     []string
+	Datapoints int
+}
+
+// ExplainResolve runs the resolution of a policy without storing it and
+// explains its result: which policies matched the asset filters, which
+// queries run, which were deduplicated and which reporting jobs feed into
+// which scores.
+func (s *LocalServices) ExplainResolve(ctx context.Context, req *ResolveReq) (*ResolvePlan, error) {
+	if s.useUpstream() {
+		return nil, errors.New("cannot explain the resolution of policy '" + req.PolicyMrn + "', it is resolved upstream")
+	}
+
+	in, err := s.resolveInputs(ctx, req.PolicyMrn, req.AssetFilters)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.matchBundle(ctx, in, req.AssetFilters); err != nil {
+		return nil, err
+	}
+	if err = s.matchSpacePolicy(ctx, in); err != nil {
+		return nil, err
+	}
+	rp, cache, err := s.buildResolvedPolicy(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := explainResolvedPolicy(req.PolicyMrn, rp, cache)
+	cachedMrn := req.PolicyMrn
+	if in.space != nil {
+		plan.Space = in.space.Mrn
+		cachedMrn = in.space.Mrn
+	}
+	if cached, err := s.cachedResolvedPolicy(ctx, cachedMrn, rp.FiltersChecksum); err == nil && cached != nil {
+		plan.Cached = true
+	}
+	return plan, nil
+}
+
+func explainResolvedPolicy(policyMrn string, rp *ResolvedPolicy, cache *resolverCache) *ResolvePlan {
+	res := &ResolvePlan{
+		PolicyMrn: policyMrn,
+		Conflicts: cache.conflictList(),
+	}
+	for i := range rp.Filters {
+		res.AssetFilters = append(res.AssetFilters, strings.TrimSpace(rp.Filters[i].Mql))
+	}
+	sort.Strings(res.AssetFilters)
+	for i := range cache.errors {
+		res.Errors = append(res.Errors, cache.errors[i].ID+": "+cache.errors[i].Error)
+	}
+
+	activePolicies := map[string]struct{}{policyMrn: {}}
+	for _, rj := range cache.reportingJobsByChecksum {
+		if _, ok := cache.bundleMap.Policies[rj.QrId]; ok {
+			activePolicies[rj.QrId] = struct{}{}
+		}
+	}
+
+	policyMrns := sortedKeys(cache.bundleMap.Policies)
+	for _, mrn := range policyMrns {
+		p := &PlannedPolicy{Mrn: mrn, ActivatedBy: cache.activatedBy[mrn]}
+		if _, ok := activePolicies[mrn]; ok {
+			p.Active = true
+		} else {
+			p.Reason = cache.explainInactivePolicy(cache.bundleMap.Policies[mrn])
+		}
+		res.Policies = append(res.Policies, p)
+	}
+
+	res.Queries = explainQueries(rp, cache, policyMrns, activePolicies)
+	res.ReportingJobs = explainReportingJobs(rp, cache)
+	return res
+}
+
+func (r *resolverCache) explainInactivePolicy(p *Policy) string {
+	if by, ok := r.deactivatedBy[p.Mrn]; ok {
+		return "deactivated by " + strings.Join(by, ", ")
+	}
+	if p.Filters != nil && len(p.Filters.Items) != 0 {
+		matches := false
+		for codeID := range p.Filters.Items {
+			if _, ok := r.assetFilters[codeID]; ok {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return "its filters don't match the asset"
+		}
+	}
+	return "no active policy references it"
+}
+
+// queryRef is a reference of a policy group to a query
+type queryRef struct {
+	policyMrn    string
+	filtersMatch bool
+	isData       bool
+}
+
+func explainQueries(rp *ResolvedPolicy, cache *resolverCache, policyMrns []string, activePolicies map[string]struct{}) []*PlannedQuery {
+	refs := map[string][]queryRef{}
+	for _, policyMrn := range policyMrns {
+		p := cache.bundleMap.Policies[policyMrn]
+		for _, group := range p.Groups {
+			filtersMatch := group.Filters == nil || len(group.Filters.Items) == 0
+			if !filtersMatch {
+				for _, filter := range group.Filters.Items {
+					if _, ok := cache.assetFilters[filter.CodeId]; ok {
+						filtersMatch = true
+						break
+					}
+				}
+			}
+			for _, check := range group.Checks {
+				if check.Action == explorer.Mquery_UNKNOWN || check.Action == explorer.Mquery_ADD {
+					refs[check.Mrn] = append(refs[check.Mrn], queryRef{policyMrn: policyMrn, filtersMatch: filtersMatch})
+				}
+			}
+			for _, query := range group.Queries {
+				if query.Action == explorer.Mquery_UNKNOWN || query.Action == explorer.Mquery_ADD {
+					refs[query.Mrn] = append(refs[query.Mrn], queryRef{policyMrn: policyMrn, filtersMatch: filtersMatch, isData: true})
+				}
+			}
+		}
+	}
+
+	active := make(map[string]*explorer.Mquery, len(cache.queriesByChecksum))
+	activeData := map[string]struct{}{}
+	byCodeID := map[string][]string{}
+	for checksum, query := range cache.queriesByChecksum {
+		if rp.ExecutionJob.Queries[query.CodeId] == nil {
+			continue
+		}
+		active[query.Mrn] = query
+		if _, isData := cache.dataQueries[checksum]; isData {
+			activeData[query.Mrn] = struct{}{}
+		}
+		byCodeID[query.CodeId] = append(byCodeID[query.CodeId], query.Mrn)
+	}
+
+	res := make([]*PlannedQuery, 0, len(refs))
+	for _, mrn := range sortedKeys(refs) {
+		q := &PlannedQuery{Mrn: mrn, ActivatedBy: cache.activatedBy[mrn]}
+		for _, ref := range refs[mrn] {
+			q.IsData = q.IsData || ref.isData
+		}
+
+		if query, ok := active[mrn]; ok {
+			_, q.IsData = activeData[mrn]
+			q.Active = true
+			q.CodeId = query.CodeId
+			// the execution of queries with the same code is shared, the first
+			// query by MRN runs for all of them
+			same := byCodeID[query.CodeId]
+			sort.Strings(same)
+			if same[0] != mrn {
+				q.DuplicateOf = same[0]
+			}
+		} else {
+			q.Reason = cache.explainInactiveQuery(mrn, refs[mrn], activePolicies)
+		}
+		res = append(res, q)
+	}
+	return res
+}
+
+func (r *resolverCache) explainInactiveQuery(mrn string, refs []queryRef, activePolicies map[string]struct{}) string {
+	if by, ok := r.deactivatedBy[mrn]; ok {
+		return "deactivated by " + strings.Join(by, ", ")
+	}
+
+	var inactive []string
+	filtersMatch := false
+	for _, ref := range refs {
+		if _, ok := activePolicies[ref.policyMrn]; !ok {
+			inactive = appendUnique(inactive, ref.policyMrn)
+			continue
+		}
+		filtersMatch = filtersMatch || ref.filtersMatch
+	}
+	if len(inactive) == len(refs) {
+		return "none of its policies are active: " + strings.Join(inactive, ", ")
+	}
+	if !filtersMatch {
+		return "the filters of its groups don't match the asset"
+	}
+	return "it can't be compiled for the asset"
+}
+
+func explainReportingJobs(rp *ResolvedPolicy, cache *resolverCache) []*PlannedReportingJob {
+	jobs := rp.CollectorJob.ReportingJobs
+	qrID := func(uuid string) string {
+		if rj, ok := jobs[uuid]; ok {
+			return rj.QrId
+		}
+		return uuid
+	}
+
+	mrnsByCodeID := map[string][]string{}
+	for _, query := range cache.queriesByChecksum {
+		mrnsByCodeID[query.CodeId] = appendUnique(mrnsByCodeID[query.CodeId], query.Mrn)
+	}
+
+	res := make([]*PlannedReportingJob, 0, len(jobs))
+	for _, rj := range jobs {
+		job := &PlannedReportingJob{
+			QrId:       rj.QrId,
+			Mrns:       mrnsByCodeID[rj.QrId],
+			IsData:     rj.IsData,
+			Datapoints: len(rj.Datapoints),
+		}
+		sort.Strings(job.Mrns)
+		for uuid := range rj.ChildJobs {
+			job.Children = append(job.Children, qrID(uuid))
+		}
+		sort.Strings(job.Children)
+		for _, uuid := range rj.Notify {
+			job.Notify = append(job.Notify, qrID(uuid))
+		}
+		sort.Strings(job.Notify)
+		res = append(res, job)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].QrId < res[j].QrId
+	})
+	return res
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// String renders the plan for humans
+func (p *ResolvePlan) String() string {
+	var b strings.Builder
+	b.WriteString("resolution plan for " + p.PolicyMrn + "\n")
+	if p.Cached {
+		b.WriteString("  (a resolved policy for these asset filters is already cached)\n")
+	}
+	if p.Space != "" {
+		b.WriteString("  (shared with all assets of space " + p.Space + ")\n")
+	}
+
+	b.WriteString("\nasset filters:\n")
+	for _, filter := range p.AssetFilters {
+		b.WriteString("  " + filter + "\n")
+	}
+
+	b.WriteString("\npolicies:\n")
+	for _, policy := range p.Policies {
+		if policy.Active {
+			b.WriteString("  + " + policy.Mrn + "\n")
+		} else {
+			b.WriteString("  - " + policy.Mrn + ": " + policy.Reason + "\n")
+		}
+	}
+
+	b.WriteString("\nqueries:\n")
+	for _, query := range p.Queries {
+		kind := "check"
+		if query.IsData {
+			kind = "data"
+		}
+		switch {
+		case !query.Active:
+			b.WriteString("  - " + query.Mrn + " (" + kind + "): " + query.Reason + "\n")
+		case query.DuplicateOf != "":
+			b.WriteString("  = " + query.Mrn + " (" + kind + "): runs as " + query.DuplicateOf + "\n")
+		default:
+			b.WriteString("  + " + query.Mrn + " (" + kind + ")\n")
+		}
+	}
+
+	b.WriteString("\nreporting jobs:\n")
+	for _, rj := range p.ReportingJobs {
+		b.WriteString("  " + rj.QrId)
+		if len(rj.Mrns) != 0 {
+			b.WriteString(" (" + strings.Join(rj.Mrns, ", ") + ")")
+		}
+		b.WriteString(": " + strconv.Itoa(rj.Datapoints) + " datapoints")
+		if len(rj.Children) != 0 {
+			b.WriteString(", scores " + strings.Join(rj.Children, ", "))
+		}
+		if len(rj.Notify) != 0 {
+			b.WriteString(", reports to " + strings.Join(rj.Notify, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(p.Conflicts) != 0 {
+		b.WriteString("\nconflicts:\n")
+		for _, c := range p.Conflicts {
+			b.WriteString("  " + string(c.Kind) + " " + c.ID + ": applied " + strings.Join(c.Applied, ", ") +
+				", overridden " + strings.Join(c.Overridden, ", ") + "\n")
+		}
+	}
+
+	if len(p.Errors) != 0 {
+		b.WriteString("\nerrors:\n")
+		for _, e := range p.Errors {
+			b.WriteString("  " + e + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestExplainResolvedPolicy(t *testing.T) {
+	bundleMap := &PolicyBundleMap{
+		Policies: map[string]*Policy{
+			"//asset": {Mrn: "//asset", Groups: []*PolicyGroup{{
+				Policies: []*PolicyRef{{Mrn: "//policy/linux"}, {Mrn: "//policy/windows"}, {Mrn: "//policy/off"}},
+			}}},
+			"//policy/linux": {Mrn: "//policy/linux", Filters: &explorer.Filters{Items: map[string]*explorer.Mquery{"linux": {}}}, Groups: []*PolicyGroup{
+				{Checks: []*explorer.Mquery{{Mrn: "//check/a"}, {Mrn: "//check/b"}, {Mrn: "//check/removed"}}},
+				{
+					Filters: &explorer.Filters{Items: map[string]*explorer.Mquery{"debian": {CodeId: "debian"}}},
+					Checks:  []*explorer.Mquery{{Mrn: "//check/debian"}},
+				},
+			}},
+			"//policy/windows": {Mrn: "//policy/windows", Filters: &explorer.Filters{Items: map[string]*explorer.Mquery{"windows": {}}}, Groups: []*PolicyGroup{
+				{Checks: []*explorer.Mquery{{Mrn: "//check/win"}}},
+			}},
+			"//policy/off": {Mrn: "//policy/off", Filters: &explorer.Filters{Items: map[string]*explorer.Mquery{"linux": {}}}},
+		},
+	}
+
+	root := &ReportingJob{Uuid: "root-uuid", QrId: "root", ChildJobs: map[string]*explorer.Impact{"linux-uuid": {}}}
+	linux := &ReportingJob{Uuid: "linux-uuid", QrId: "//policy/linux", Notify: []string{"root-uuid"}, ChildJobs: map[string]*explorer.Impact{"code-uuid": {}}}
+	check := &ReportingJob{Uuid: "code-uuid", QrId: "code", Notify: []string{"linux-uuid"}, Datapoints: map[string]bool{"dp": true}}
+
+	cache := &resolverCache{
+		assetFilters: map[string]struct{}{"linux": {}},
+		bundleMap:    bundleMap,
+		queriesByChecksum: map[string]*explorer.Mquery{
+			"a": {Mrn: "//check/a", CodeId: "code"},
+			"b": {Mrn: "//check/b", CodeId: "code"},
+		},
+		dataQueries: map[string]struct{}{},
+		reportingJobsByChecksum: map[string]*ReportingJob{
+			"root": root, "//policy/linux": linux, "a": check,
+		},
+		activatedBy:   map[string][]string{"//policy/linux": {"//asset"}, "//check/a": {"//policy/linux"}, "//check/b": {"//policy/linux"}},
+		deactivatedBy: map[string][]string{"//check/removed": {"//asset"}, "//policy/off": {"//asset"}},
+		conflicts:     map[string]*PolicyConflict{},
+	}
+	rp := &ResolvedPolicy{
+		Filters:      []*explorer.Mquery{{Mql: "asset.family.contains('linux')"}},
+		ExecutionJob: &ExecutionJob{Queries: map[string]*ExecutionQuery{"code": {}}},
+		CollectorJob: &CollectorJob{ReportingJobs: map[string]*ReportingJob{
+			"root-uuid": root, "linux-uuid": linux, "code-uuid": check,
+		}},
+	}
+
+	plan := explainResolvedPolicy("//asset", rp, cache)
+	assert.Equal(t, []string{"asset.family.contains('linux')"}, plan.AssetFilters)
+
+	require.Len(t, plan.Policies, 4)
+	assert.Equal(t, &PlannedPolicy{Mrn: "//asset", Active: true}, plan.Policies[0])
+	assert.Equal(t, &PlannedPolicy{Mrn: "//policy/linux", Active: true, ActivatedBy: []string{"//asset"}}, plan.Policies[1])
+	assert.Equal(t, &PlannedPolicy{Mrn: "//policy/off", Reason: "deactivated by //asset"}, plan.Policies[2])
+	assert.Equal(t, &PlannedPolicy{Mrn: "//policy/windows", Reason: "its filters don't match the asset"}, plan.Policies[3])
+
+	queries := map[string]*PlannedQuery{}
+	for _, q := range plan.Queries {
+		queries[q.Mrn] = q
+	}
+	require.Len(t, queries, 5)
+	assert.True(t, queries["//check/a"].Active)
+	assert.Empty(t, queries["//check/a"].DuplicateOf)
+	assert.True(t, queries["//check/b"].Active)
+	assert.Equal(t, "//check/a", queries["//check/b"].DuplicateOf)
+	assert.Equal(t, "deactivated by //asset", queries["//check/removed"].Reason)
+	assert.Equal(t, "the filters of its groups don't match the asset", queries["//check/debian"].Reason)
+	assert.Equal(t, "none of its policies are active: //policy/windows", queries["//check/win"].Reason)
+
+	require.Len(t, plan.ReportingJobs, 3)
+	assert.Equal(t, &PlannedReportingJob{
+		QrId: "code", Mrns: []string{"//check/a", "//check/b"}, Notify: []string{"//policy/linux"}, Datapoints: 1,
+	}, plan.ReportingJobs[1])
+	assert.Equal(t, []string{"code"}, plan.ReportingJobs[0].Children)
+	assert.Equal(t, []string{"root"}, plan.ReportingJobs[0].Notify)
+
+	out := plan.String()
+	assert.Contains(t, out, "  - //policy/windows: its filters don't match the asset\n")
+	assert.Contains(t, out, "  = //check/b (check): runs as //check/a\n")
+	assert.Contains(t, out, "  code (//check/a, //check/b): 1 datapoints, reports to //policy/linux\n")
+}
@@ -0,0 +1,156 @@
+package policy
+
+import (
+	"sort"
+	"time"
+)
+
+// outcomes of a score, see ScoreOutcome
+const (
+	OutcomePass    = "pass"
+	OutcomeFail    = "fail"
+	OutcomeError   = "error"
+	OutcomeSkip    = "skip"
+	OutcomeUnknown = "unknown"
+)
+
+// ScoreOutcome reduces a score to the outcome that users see, e.g. a score
+// that goes from 30 to 40 still fails
+func ScoreOutcome(score *Score) string {
+	if score == nil {
+		return OutcomeUnknown
+	}
+	switch score.Type {
+	case ScoreType_Result:
+		if score.Value == 100 {
+			return OutcomePass
+		}
+		return OutcomeFail
+	case ScoreType_Error:
+		return OutcomeError
+	case ScoreType_Skip:
+		return OutcomeSkip
+	default:
+		return OutcomeUnknown
+	}
+}
+
+// ScoreHistoryEntry records that the value or outcome of a score changed
+type ScoreHistoryEntry struct {
+	QrId    string
+	Outcome string
+	Value   uint32
+	// ExecutionChecksum is the graph execution checksum of the resolved
+	// policy of the asset at that time. It changes whenever the policies,
+	// their queries or props change.
+	ExecutionChecksum string
+	Recorded          time.Time
+}
+
+// NewScoreHistoryEntry turns a score into an entry of the score history.
+// It returns false for incomplete results, which are not recorded.
+func NewScoreHistoryEntry(score *Score, executionChecksum string, recorded time.Time) (ScoreHistoryEntry, bool) {
+	if score == nil || (score.Type == ScoreType_Result && score.ScoreCompletion < 100) {
+		return ScoreHistoryEntry{}, false
+	}
+	return ScoreHistoryEntry{
+		QrId:              score.QrId,
+		Outcome:           ScoreOutcome(score),
+		Value:             score.Value,
+		ExecutionChecksum: executionChecksum,
+		Recorded:          recorded,
+	}, true
+}
+
+// Changes returns true if the entry differs from the previous entry of the
+// same score, i.e. if it has to be added to the history
+func (e ScoreHistoryEntry) Changes(prev ScoreHistoryEntry) bool {
+	return e.Outcome != prev.Outcome || e.Value != prev.Value || e.ExecutionChecksum != prev.ExecutionChecksum
+}
+
+// FlakyCheck is a check whose outcome flipped repeatedly on one asset,
+// while its policies stayed the same
+type FlakyCheck struct {
+	AssetMrn          string
+	QrId              string
+	Flips             int
+	ExecutionChecksum string
+	FirstFlip         time.Time
+	LastFlip          time.Time
+}
+
+// FlakinessOptions configure the detection of flaky checks
+type FlakinessOptions struct {
+	// MinFlips is the number of outcome changes after which a check is
+	// considered flaky, defaults to 3
+	MinFlips int
+	// Since ignores all history before this time (optional)
+	Since time.Time
+}
+
+const defaultMinFlips = 3
+
+// DetectFlakyChecks finds checks whose outcome flips frequently in the score
+// history of one asset. Flips are only counted while the execution checksum
+// stays the same; changed policies are expected to change outcomes.
+// The history must be ordered by time.
+func DetectFlakyChecks(assetMrn string, history []ScoreHistoryEntry, opts FlakinessOptions) []FlakyCheck {
+	minFlips := opts.MinFlips
+	if minFlips < 1 {
+		minFlips = defaultMinFlips
+	}
+
+	type state struct {
+		outcome  string
+		checksum string
+		flaky    FlakyCheck
+	}
+	states := map[string]*state{}
+	worst := map[string]FlakyCheck{}
+
+	for _, entry := range history {
+		if entry.Recorded.Before(opts.Since) {
+			continue
+		}
+
+		cur, ok := states[entry.QrId]
+		if !ok || cur.checksum != entry.ExecutionChecksum {
+			states[entry.QrId] = &state{
+				outcome:  entry.Outcome,
+				checksum: entry.ExecutionChecksum,
+				flaky: FlakyCheck{
+					AssetMrn:          assetMrn,
+					QrId:              entry.QrId,
+					ExecutionChecksum: entry.ExecutionChecksum,
+				},
+			}
+			continue
+		}
+
+		if cur.outcome == entry.Outcome {
+			continue
+		}
+		cur.outcome = entry.Outcome
+		cur.flaky.Flips++
+		if cur.flaky.FirstFlip.IsZero() {
+			cur.flaky.FirstFlip = entry.Recorded
+		}
+		cur.flaky.LastFlip = entry.Recorded
+
+		if cur.flaky.Flips >= minFlips && cur.flaky.Flips > worst[entry.QrId].Flips {
+			worst[entry.QrId] = cur.flaky
+		}
+	}
+
+	res := make([]FlakyCheck, 0, len(worst))
+	for _, flaky := range worst {
+		res = append(res, flaky)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Flips != res[j].Flips {
+			return res[i].Flips > res[j].Flips
+		}
+		return res[i].QrId < res[j].QrId
+	})
+	return res
+}
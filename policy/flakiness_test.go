@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreOutcome(t *testing.T) {
+	assert.Equal(t, OutcomeUnknown, ScoreOutcome(nil))
+	assert.Equal(t, OutcomePass, ScoreOutcome(&Score{Type: ScoreType_Result, Value: 100}))
+	assert.Equal(t, OutcomeFail, ScoreOutcome(&Score{Type: ScoreType_Result, Value: 40}))
+	assert.Equal(t, OutcomeError, ScoreOutcome(&Score{Type: ScoreType_Error}))
+	assert.Equal(t, OutcomeSkip, ScoreOutcome(&Score{Type: ScoreType_Skip}))
+}
+
+func TestDetectFlakyChecks(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	at := func(i int) time.Time { return start.Add(time.Duration(i) * time.Hour) }
+
+	history := []ScoreHistoryEntry{
+		// flips 3 times without policy changes
+		{QrId: "flaky", Outcome: OutcomePass, ExecutionChecksum: "a", Recorded: at(0)},
+		{QrId: "flaky", Outcome: OutcomeFail, ExecutionChecksum: "a", Recorded: at(1)},
+		{QrId: "flaky", Outcome: OutcomePass, ExecutionChecksum: "a", Recorded: at(2)},
+		{QrId: "flaky", Outcome: OutcomeError, ExecutionChecksum: "a", Recorded: at(3)},
+		// flips 3 times, but the policies change in between
+		{QrId: "changed", Outcome: OutcomePass, ExecutionChecksum: "a", Recorded: at(0)},
+		{QrId: "changed", Outcome: OutcomeFail, ExecutionChecksum: "a", Recorded: at(1)},
+		{QrId: "changed", Outcome: OutcomePass, ExecutionChecksum: "b", Recorded: at(2)},
+		{QrId: "changed", Outcome: OutcomeFail, ExecutionChecksum: "b", Recorded: at(3)},
+		// stable
+		{QrId: "stable", Outcome: OutcomeFail, ExecutionChecksum: "a", Recorded: at(0)},
+	}
+
+	res := DetectFlakyChecks("//asset", history, FlakinessOptions{})
+	require.Len(t, res, 1)
+	assert.Equal(t, FlakyCheck{
+		AssetMrn:          "//asset",
+		QrId:              "flaky",
+		Flips:             3,
+		ExecutionChecksum: "a",
+		FirstFlip:         at(1),
+		LastFlip:          at(3),
+	}, res[0])
+
+	t.Run("min flips", func(t *testing.T) {
+		res := DetectFlakyChecks("//asset", history, FlakinessOptions{MinFlips: 1})
+		require.Len(t, res, 2)
+		assert.Equal(t, "flaky", res[0].QrId)
+		assert.Equal(t, "changed", res[1].QrId)
+		assert.Equal(t, 1, res[1].Flips)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		res := DetectFlakyChecks("//asset", history, FlakinessOptions{Since: at(1)})
+		assert.Empty(t, res)
+	})
+}
@@ -32,13 +32,43 @@ func (s *LocalServices) SetBundle(ctx context.Context, bundle *Bundle) (*Empty,
 		return globalEmpty, err
 	}
 
+	var prevChecksums map[string]string
+	if s.ReResolver != nil {
+		prevChecksums = s.graphExecutionChecksums(ctx, bundleMap)
+	}
+
 	if err := s.setPolicyBundleFromMap(ctx, bundleMap); err != nil {
 		return nil, err
 	}
 
+	if s.ReResolver != nil {
+		var changed []string
+		for mrn, checksum := range s.graphExecutionChecksums(ctx, bundleMap) {
+			if prevChecksums[mrn] != checksum {
+				changed = append(changed, mrn)
+			}
+		}
+		if err := s.ReResolver.BundleUpdated(ctx, changed); err != nil {
+			logger.FromContext(ctx).Warn().Err(err).Msg("resolver> failed to schedule re-resolution of assets")
+		}
+	}
+
 	return globalEmpty, nil
 }
 
+// graphExecutionChecksums returns the stored graph execution checksums of
+// all policies in the bundle that exist
+func (s *LocalServices) graphExecutionChecksums(ctx context.Context, bundleMap *PolicyBundleMap) map[string]string {
+	res := make(map[string]string, len(bundleMap.Policies))
+	for mrn := range bundleMap.Policies {
+		policyObj, err := s.DataLake.GetRawPolicy(ctx, mrn)
+		if err == nil && policyObj != nil {
+			res[mrn] = policyObj.GraphExecutionChecksum
+		}
+	}
+	return res
+}
+
 // PreparePolicy takes a policy and an optional bundle and gets it
 // ready to be saved in the DB, including asset filters.
 //
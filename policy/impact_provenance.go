@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"context"
+	"sort"
+
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// ImpactProvenanceStore is implemented by datalakes that keep the impact
+// provenance of resolved policies
+type ImpactProvenanceStore interface {
+	// SetImpactProvenance stores the impact provenance that was recorded while
+	// resolving a policy
+	SetImpactProvenance(ctx context.Context, resolvedPolicy *ResolvedPolicy, provenance map[string][]*ImpactProvenance) error
+	// GetImpactProvenance returns the impact provenance that was recorded
+	// while resolving a policy
+	GetImpactProvenance(ctx context.Context, resolvedPolicy *ResolvedPolicy) (map[string][]*ImpactProvenance, error)
+}
+
+// addImpactProvenance records the impact of a child job that was added to
+// its parent by the policy that is currently being resolved
+func (p *policyResolverCache) addImpactProvenance(parentJob *ReportingJob, childJob *ReportingJob, id string, isPolicy bool, declared *explorer.Impact, informational bool) {
+	p.global.impactProvenance[parentJob.Uuid+"\x00"+childJob.Uuid] = &ImpactProvenance{
+		Policy:        p.policyMrn,
+		Id:            id,
+		IsPolicy:      isPolicy,
+		Declared:      declared,
+		Informational: informational,
+	}
+}
+
+// traceImpactModification records which policy's modification of the
+// impact of a child job took effect and which was discarded
+func (r *resolverCache) traceImpactModification(key string, applied *impactOverride, overridden *impactOverride) {
+	provenance, ok := r.impactProvenance[key]
+	if !ok {
+		return
+	}
+
+	provenance.ModifiedBy = applied.policyMrn
+	provenance.Priority = int32(applied.priority)
+	provenance.Overridden = removeString(provenance.Overridden, applied.policyMrn)
+	if overridden != nil {
+		provenance.Overridden = appendUnique(provenance.Overridden, overridden.policyMrn)
+		provenance.Rule = string(applied.rule(overridden))
+	}
+}
+
+// collectImpactProvenance returns the provenance of all impacts in the
+// collector job, indexed by the QrId of the child job, i.e. the code ID of
+// checks and the MRN of policies. It must be called after all impacts were
+// set, including the weighting by criticality.
+func (r *resolverCache) collectImpactProvenance(collectorJob *CollectorJob, criticality AssetCriticality) map[string][]*ImpactProvenance {
+	factor := criticality.Factor()
+	res := map[string][]*ImpactProvenance{}
+	for parentUuid, parentJob := range collectorJob.ReportingJobs {
+		for childUuid, impact := range parentJob.ChildJobs {
+			provenance, ok := r.impactProvenance[parentUuid+"\x00"+childUuid]
+			if !ok {
+				continue
+			}
+			childJob, ok := collectorJob.ReportingJobs[childUuid]
+			if !ok {
+				continue
+			}
+
+			// children without an impact of their own in the parent are
+			// scored by the impact they declare
+			provenance.Impact = impact
+			if impact == nil {
+				provenance.Impact = provenance.Declared
+			}
+			if factor != 1 && impact != nil && impact.Value > 0 {
+				provenance.Criticality = factor
+			}
+			sort.Strings(provenance.Overridden)
+			res[childJob.QrId] = append(res[childJob.QrId], provenance)
+		}
+	}
+
+	for _, list := range res {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Policy < list[j].Policy
+		})
+	}
+	return res
+}
+
+// IndexImpactProvenance converts impact provenance into the form that is
+// kept on reports, see Report.ImpactProvenance
+func IndexImpactProvenance(provenance map[string][]*ImpactProvenance) map[string]*ImpactProvenances {
+	if len(provenance) == 0 {
+		return nil
+	}
+	res := make(map[string]*ImpactProvenances, len(provenance))
+	for id, list := range provenance {
+		res[id] = &ImpactProvenances{List: list}
+	}
+	return res
+}
+
+// GetImpactProvenance returns how the effective impact of all checks and
+// policies of the asset's resolved policy came about, indexed by the code ID
+// of checks and the MRN of policies. It is empty if the datalake doesn't
+// keep impact provenance.
+func (s *LocalServices) GetImpactProvenance(ctx context.Context, assetMrn string) (map[string][]*ImpactProvenance, error) {
+	store, ok := s.DataLake.(ImpactProvenanceStore)
+	if !ok {
+		return map[string][]*ImpactProvenance{}, nil
+	}
+
+	resolvedPolicy, err := s.DataLake.GetResolvedPolicy(ctx, assetMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.GetImpactProvenance(ctx, resolvedPolicy)
+}
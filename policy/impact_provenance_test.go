@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestImpactProvenance(t *testing.T) {
+	global, parent, child := testConflictCache()
+	global.impactProvenance = map[string]*ImpactProvenance{}
+	child.QrId = "code-id"
+	collectorJob := &CollectorJob{
+		ReportingJobs: map[string]*ReportingJob{parent.Uuid: parent, child.Uuid: child},
+	}
+
+	owner := &policyResolverCache{
+		policyMrn:      "//policy",
+		parentPolicies: map[string]struct{}{"//asset": {}, "//policy": {}},
+		global:         global,
+	}
+	declared := &explorer.Impact{Value: 50}
+	parent.ChildJobs[child.Uuid] = declared
+	owner.addImpactProvenance(parent, child, "//check", false, declared, false)
+
+	t.Run("declared impact", func(t *testing.T) {
+		res := global.collectImpactProvenance(collectorJob, CriticalityMedium)
+		require.Len(t, res["code-id"], 1)
+		provenance := res["code-id"][0]
+		assert.Equal(t, "//policy", provenance.Policy)
+		assert.Equal(t, "//check", provenance.Id)
+		assert.Same(t, declared, provenance.Impact)
+		assert.Empty(t, provenance.ModifiedBy)
+		assert.Zero(t, provenance.Criticality)
+	})
+
+	t.Run("modified by parent policies", func(t *testing.T) {
+		near := &policyResolverCache{
+			policyMrn:      "//close",
+			parentPolicies: map[string]struct{}{"//asset": {}, "//close": {}},
+			global:         global,
+		}
+		deep := &policyResolverCache{
+			policyMrn:      "//deep",
+			parentPolicies: map[string]struct{}{"//asset": {}, "//a": {}, "//deep": {}},
+			global:         global,
+		}
+		deep.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 80})
+		near.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 20})
+
+		res := global.collectImpactProvenance(collectorJob, CriticalityMedium)
+		require.Len(t, res["code-id"], 1)
+		provenance := res["code-id"][0]
+		assert.Equal(t, int32(20), provenance.Impact.Value)
+		assert.Equal(t, int32(50), provenance.Declared.Value)
+		assert.Equal(t, "//close", provenance.ModifiedBy)
+		assert.Equal(t, []string{"//deep"}, provenance.Overridden)
+	})
+
+	t.Run("scaled by criticality", func(t *testing.T) {
+		res := global.collectImpactProvenance(collectorJob, CriticalityCritical)
+		require.Len(t, res["code-id"], 1)
+		assert.Equal(t, 2.0, res["code-id"][0].Criticality)
+	})
+}
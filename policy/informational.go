@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"strconv"
+
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// InformationalTag marks a check as informational, e.g. `true`. Informational
+// checks are executed and their results are recorded, but they never
+// contribute to the score of their policies, regardless of their impact.
+const InformationalTag = "cnspec/informational"
+
+// IsInformational returns true if the query is marked as informational
+func IsInformational(query *explorer.Mquery) bool {
+	if query == nil {
+		return false
+	}
+	v, err := strconv.ParseBool(query.Tags[InformationalTag])
+	return err == nil && v
+}
+
+// isInformational checks the query and its base query in the bundle, since
+// policies may reference checks without repeating their tags
+func (c *resolverCache) isInformational(query *explorer.Mquery) bool {
+	if IsInformational(query) {
+		return true
+	}
+	if c.bundleMap == nil {
+		return false
+	}
+	return IsInformational(c.bundleMap.Queries[query.Mrn])
+}
+
+// markInformational marks the reporting job of the check as informational
+// if the check is tagged as such or waived for the asset. Informational jobs
+// keep their impact, but their scores don't count towards their parents.
+func (c *resolverCache) markInformational(queryJob *ReportingJob, check *explorer.Mquery) bool {
+	if c.isInformational(check) || c.isExcepted(check) {
+		queryJob.Informational = true
+	}
+	return queryJob.Informational
+}
+
+// InformationalCodeIDs returns the code IDs of all checks in this bundle
+// which are marked as informational. The bundle must be compiled.
+func (p *Bundle) InformationalCodeIDs() map[string]struct{} {
+	res := map[string]struct{}{}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		if query.CodeId != "" && IsInformational(query) {
+			res[query.CodeId] = struct{}{}
+		}
+	}
+	return res
+}
@@ -0,0 +1,68 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestIsInformational(t *testing.T) {
+	assert.False(t, IsInformational(nil))
+	assert.False(t, IsInformational(&explorer.Mquery{}))
+	assert.False(t, IsInformational(&explorer.Mquery{Tags: map[string]string{InformationalTag: "no"}}))
+	assert.True(t, IsInformational(&explorer.Mquery{Tags: map[string]string{InformationalTag: "true"}}))
+}
+
+func TestInformationalChecksAreNotScored(t *testing.T) {
+	calculator := &averageScoreCalculator{}
+	calculator.Init()
+
+	passing := &Score{Type: ScoreType_Result, Value: 100, Weight: 1, ScoreCompletion: 100, DataCompletion: 100}
+	failing := &Score{Type: ScoreType_Result, Value: 0, Weight: 1, ScoreCompletion: 100, DataCompletion: 100, Informational: true}
+
+	AddSpecdScore(calculator, passing, true, nil)
+	// informational checks keep their impact, but are not counted
+	AddSpecdScore(calculator, failing, true, &explorer.Impact{Value: 100, Weight: 10})
+
+	res := calculator.Calculate()
+	assert.Equal(t, uint32(100), res.Value)
+}
+
+func TestResolverCache_IsInformational(t *testing.T) {
+	cache := &resolverCache{
+		bundleMap: &PolicyBundleMap{
+			Queries: map[string]*explorer.Mquery{
+				"//info": {Mrn: "//info", Tags: map[string]string{InformationalTag: "true"}},
+			},
+		},
+	}
+
+	assert.True(t, cache.isInformational(&explorer.Mquery{Mrn: "//info"}))
+	assert.False(t, cache.isInformational(&explorer.Mquery{Mrn: "//other"}))
+}
+
+func TestResolverCache_MarkInformational(t *testing.T) {
+	cache := &resolverCache{
+		bundleMap: &PolicyBundleMap{
+			Queries: map[string]*explorer.Mquery{
+				"//info": {Mrn: "//info", Tags: map[string]string{InformationalTag: "true"}},
+			},
+		},
+		exceptions: map[string]*Exception{
+			"//waived": {CheckMrn: "//waived"},
+		},
+	}
+
+	job := &ReportingJob{}
+	assert.False(t, cache.markInformational(job, &explorer.Mquery{Mrn: "//other"}))
+	assert.False(t, job.Informational)
+
+	job = &ReportingJob{}
+	assert.True(t, cache.markInformational(job, &explorer.Mquery{Mrn: "//info"}))
+	assert.True(t, job.Informational)
+
+	job = &ReportingJob{}
+	assert.True(t, cache.markInformational(job, &explorer.Mquery{Mrn: "//waived"}))
+	assert.True(t, job.Informational)
+}
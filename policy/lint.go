@@ -0,0 +1,218 @@
+package policy
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// LintSeverity is how severe a lint finding is
+type LintSeverity string
+
+const (
+	// LintError findings make the bundle invalid or break its execution
+	LintError LintSeverity = "error"
+	// LintWarning findings are likely mistakes, but the bundle still works
+	LintWarning LintSeverity = "warning"
+)
+
+// lint rules, see LintResult.RuleID
+const (
+	LintDuplicateUID       = "duplicate-uid"
+	LintUnusedQuery        = "unused-query"
+	LintCheckMissingImpact = "check-missing-impact"
+	LintMissingFilters     = "missing-filters"
+	LintInvalidVersion     = "invalid-version"
+	LintEmptyGroup         = "empty-group"
+	LintUnreachablePolicy  = "unreachable-policy"
+	// see NondeterminismReasons
+	LintNondeterministicQuery = "nondeterministic-query"
+)
+
+// LintResult is one finding of the linter
+type LintResult struct {
+	RuleID   string
+	Severity LintSeverity
+	Message  string
+	// ID is the UID or MRN of the policy or query the finding is about
+	ID string
+	// Location is only set if the bundle was linted with a source map
+	Location SourceLocation
+}
+
+// Lint checks a bundle for problems that ValidatePolicy doesn't catch, like
+// unused queries or checks without impact. Bundles don't need to be compiled.
+func Lint(bundle *Bundle) []LintResult {
+	return LintWithSources(bundle, nil)
+}
+
+// LintWithSources lints the bundle and adds the source location of every
+// finding, see BundleFromPathsWithSources
+func LintWithSources(bundle *Bundle, sources SourceMap) []LintResult {
+	return lintBundle(bundle, sources, time.Now())
+}
+
+type linter struct {
+	sources SourceMap
+	res     []LintResult
+}
+
+func (l *linter) add(ruleID string, severity LintSeverity, msg string, uid string, mrn string) {
+	id := uid
+	if id == "" {
+		id = mrn
+	}
+	loc, _ := l.sources.Lookup(uid, mrn)
+	l.res = append(l.res, LintResult{
+		RuleID:   ruleID,
+		Severity: severity,
+		Message:  msg,
+		ID:       id,
+		Location: loc,
+	})
+}
+
+func lintBundle(bundle *Bundle, sources SourceMap, now time.Time) []LintResult {
+	if bundle == nil {
+		return nil
+	}
+	l := &linter{sources: sources}
+
+	queries := map[string]*explorer.Mquery{}
+	for _, query := range bundle.Queries {
+		for _, id := range []string{query.Uid, query.Mrn} {
+			if id == "" {
+				continue
+			}
+			if _, ok := queries[id]; ok {
+				l.add(LintDuplicateUID, LintError, "query "+id+" is defined multiple times", query.Uid, query.Mrn)
+				break
+			}
+			queries[id] = query
+		}
+	}
+
+	policies := map[string]struct{}{}
+	used := map[string]struct{}{}
+	checks := map[string]struct{}{}
+	for _, policy := range bundle.Policies {
+		id := policy.Uid
+		if id == "" {
+			id = policy.Mrn
+		}
+		if _, ok := policies[id]; ok && id != "" {
+			l.add(LintDuplicateUID, LintError, "policy "+id+" is defined multiple times", policy.Uid, policy.Mrn)
+		}
+		policies[id] = struct{}{}
+
+		if policy.Version != "" {
+			if _, err := semver.NewVersion(policy.Version); err != nil {
+				l.add(LintInvalidVersion, LintError, "policy "+id+" has a version that is not semver: "+policy.Version, policy.Uid, policy.Mrn)
+			}
+		}
+
+		l.lintGroups(policy, id, queries, used, checks, now)
+	}
+
+	delete(used, "")
+	delete(checks, "")
+	l.lintNondeterminism(bundle, checks)
+	for _, query := range bundle.Queries {
+		if _, ok := used[query.Uid]; ok {
+			continue
+		}
+		if _, ok := used[query.Mrn]; ok {
+			continue
+		}
+		id := query.Uid
+		if id == "" {
+			id = query.Mrn
+		}
+		l.add(LintUnusedQuery, LintWarning, "query "+id+" is not used by any policy", query.Uid, query.Mrn)
+	}
+
+	sort.SliceStable(l.res, func(i, j int) bool {
+		a, b := l.res[i].Location, l.res[j].Location
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return l.res
+}
+
+func (l *linter) lintGroups(policy *Policy, id string, queries map[string]*explorer.Mquery, used map[string]struct{}, checks map[string]struct{}, now time.Time) {
+	hasFilters := false
+	hasRefs := false
+	hasActiveGroup := false
+
+	for i, group := range policy.Groups {
+		if group.Filters != nil && len(group.Filters.Items) != 0 {
+			hasFilters = true
+		}
+		if len(group.Policies) != 0 {
+			hasRefs = true
+		}
+		if !groupExpired(group, now) {
+			hasActiveGroup = true
+		}
+
+		if len(group.Policies) == 0 && len(group.Checks) == 0 && len(group.Queries) == 0 {
+			name := group.Title
+			if name == "" {
+				name = "#" + strconv.Itoa(i+1)
+			}
+			l.add(LintEmptyGroup, LintWarning, "group "+name+" of policy "+id+" has no policies, checks or queries", policy.Uid, policy.Mrn)
+		}
+
+		for _, check := range group.Checks {
+			used[check.Uid] = struct{}{}
+			used[check.Mrn] = struct{}{}
+			checks[check.Uid] = struct{}{}
+			checks[check.Mrn] = struct{}{}
+
+			if check.Impact != nil || IsInformational(check) {
+				continue
+			}
+			base := queries[check.Uid]
+			if base == nil {
+				base = queries[check.Mrn]
+			}
+			if base != nil && (base.Impact != nil || IsInformational(base)) {
+				continue
+			}
+
+			checkID := check.Uid
+			if checkID == "" {
+				checkID = check.Mrn
+			}
+			l.add(LintCheckMissingImpact, LintWarning, "check "+checkID+" in policy "+id+" has no impact", check.Uid, check.Mrn)
+		}
+
+		for _, query := range group.Queries {
+			used[query.Uid] = struct{}{}
+			used[query.Mrn] = struct{}{}
+		}
+	}
+
+	// policies get their filters from their groups or from the policies
+	// they reference; without either they never apply to any asset
+	if len(policy.Groups) != 0 && !hasFilters && !hasRefs {
+		l.add(LintMissingFilters, LintError, "policy "+id+" has no filters, it doesn't apply to any asset", policy.Uid, policy.Mrn)
+	}
+
+	if len(policy.Groups) != 0 && !hasActiveGroup {
+		l.add(LintUnreachablePolicy, LintWarning, "all groups of policy "+id+" have ended, it doesn't apply anymore", policy.Uid, policy.Mrn)
+	}
+}
+
+// groupExpired returns true if the group can never be active (anymore)
+func groupExpired(group *PolicyGroup, now time.Time) bool {
+	if group.EndDate == 0 {
+		return false
+	}
+	return group.EndDate <= group.StartDate || group.EndDate < now.Unix()
+}
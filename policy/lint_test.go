@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func lintRules(results []LintResult) map[string][]string {
+	res := map[string][]string{}
+	for _, r := range results {
+		res[r.RuleID] = append(res[r.RuleID], r.ID)
+	}
+	return res
+}
+
+func TestLint(t *testing.T) {
+	filters := &explorer.Filters{Items: map[string]*explorer.Mquery{"f": {Mql: "true"}}}
+	now := time.Unix(1700000000, 0)
+
+	bundle := &Bundle{
+		Policies: []*Policy{
+			{
+				Uid:     "valid",
+				Version: "1.0.0",
+				Groups: []*PolicyGroup{{
+					Filters: filters,
+					Checks:  []*explorer.Mquery{{Uid: "check-1"}, {Uid: "check-2"}},
+				}},
+			},
+			{
+				Uid:     "broken",
+				Version: "one",
+				Groups: []*PolicyGroup{
+					{Title: "empty", StartDate: 10, EndDate: 5},
+					{EndDate: now.Unix() - 1, Queries: []*explorer.Mquery{{Uid: "data-1"}}},
+				},
+			},
+			{Uid: "valid", Version: "1.0.1"},
+		},
+		Queries: []*explorer.Mquery{
+			{Uid: "check-1", Mql: "true", Impact: &explorer.Impact{Value: 50}},
+			{Uid: "check-2", Mql: "true"},
+			{Uid: "data-1", Mql: "true"},
+			{Uid: "unused", Mql: "true"},
+			{Uid: "unused", Mql: "false"},
+		},
+	}
+
+	rules := lintRules(lintBundle(bundle, nil, now))
+	assert.Equal(t, map[string][]string{
+		LintDuplicateUID:       {"unused", "valid"},
+		LintInvalidVersion:     {"broken"},
+		LintEmptyGroup:         {"broken"},
+		LintCheckMissingImpact: {"check-2"},
+		LintMissingFilters:     {"broken"},
+		LintUnreachablePolicy:  {"broken"},
+		LintUnusedQuery:        {"unused", "unused"},
+	}, rules)
+}
+
+func TestLint_Locations(t *testing.T) {
+	bundle := &Bundle{
+		Queries: []*explorer.Mquery{{Uid: "unused", Mql: "true"}},
+	}
+	sources := SourceMap{"unused": {File: "bundle.mql.yaml", Line: 12, Column: 3}}
+
+	results := LintWithSources(bundle, sources)
+	assert.Equal(t, []LintResult{{
+		RuleID:   LintUnusedQuery,
+		Severity: LintWarning,
+		Message:  "query unused is not used by any policy",
+		ID:       "unused",
+		Location: SourceLocation{File: "bundle.mql.yaml", Line: 12, Column: 3},
+	}}, results)
+}
+
+func TestLint_Empty(t *testing.T) {
+	assert.Empty(t, Lint(nil))
+	assert.Empty(t, Lint(&Bundle{}))
+}
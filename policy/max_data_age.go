@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaxDataAgeTag is the check tag that sets how old the data of the check may
+// be when it is scored, e.g. `24h`. Checks whose data is older, e.g. when
+// they are scored with recorded data or reused results of a previous scan,
+// are reported as unscored instead of relying on outdated evidence.
+const MaxDataAgeTag = "cnspec/max-data-age"
+
+// ParseMaxDataAge reads the max data age of a check from its tags. It
+// returns 0 if the check accepts data of any age.
+func ParseMaxDataAge(tags map[string]string) (time.Duration, error) {
+	v, ok := tags[MaxDataAgeTag]
+	if !ok {
+		return 0, nil
+	}
+
+	maxAge, err := time.ParseDuration(strings.TrimSpace(v))
+	if err != nil || maxAge <= 0 {
+		return 0, errors.New("invalid max data age '" + v + "', expected a positive duration, e.g. '24h'")
+	}
+	return maxAge, nil
+}
+
+// MaxDataAgeByCodeID collects the max data ages of all checks in this
+// bundle, indexed by their code ID. The bundle must be compiled.
+func (p *Bundle) MaxDataAgeByCodeID() (map[string]time.Duration, error) {
+	res := map[string]time.Duration{}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		maxAge, err := ParseMaxDataAge(query.Tags)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse max data age for query "+query.Mrn)
+		}
+		if maxAge != 0 && query.CodeId != "" {
+			res[query.CodeId] = maxAge
+		}
+	}
+	return res, nil
+}
+
+// DataAge describes how old the data is that the checks of an asset are
+// scored with
+type DataAge struct {
+	// MaxAges are the max data ages of checks, by code ID
+	MaxAges map[string]time.Duration
+	// Collected is when reused data was collected, by datapoint checksum.
+	// Reused data without a known collection time has a zero time. All
+	// other data is collected during the scan.
+	Collected map[string]time.Time
+	// Recorded is when the recording was made that the asset is scanned
+	// with, zero if the asset is scanned live
+	Recorded time.Time
+	// Now is when the asset is scanned
+	Now time.Time
+}
+
+// collectedAt returns when the data of the datapoint was collected
+func (a *DataAge) collectedAt(checksum string) time.Time {
+	if at, ok := a.Collected[checksum]; ok {
+		return at
+	}
+	if !a.Recorded.IsZero() {
+		return a.Recorded
+	}
+	return a.Now
+}
+
+// StaleMessage returns why the check can't be scored with its data, i.e.
+// the datapoints with the given checksums. It is empty if the data is
+// recent enough or the check has no max data age.
+func (a *DataAge) StaleMessage(codeID string, datapoints []string) string {
+	if a == nil {
+		return ""
+	}
+	maxAge, ok := a.MaxAges[codeID]
+	if !ok {
+		return ""
+	}
+
+	oldest := a.Now
+	for _, checksum := range datapoints {
+		if at := a.collectedAt(checksum); at.Before(oldest) {
+			oldest = at
+		}
+	}
+	if oldest.IsZero() {
+		return "data was collected at an unknown time, max data age is " + maxAge.String()
+	}
+	age := a.Now.Sub(oldest)
+	if age <= maxAge {
+		return ""
+	}
+	return "data is " + age.Round(time.Second).String() + " old, which exceeds the max data age of " + maxAge.String()
+}
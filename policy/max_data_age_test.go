@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestParseMaxDataAge(t *testing.T) {
+	maxAge, err := ParseMaxDataAge(map[string]string{MaxDataAgeTag: " 24h"})
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, maxAge)
+
+	maxAge, err = ParseMaxDataAge(map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), maxAge)
+
+	_, err = ParseMaxDataAge(map[string]string{MaxDataAgeTag: "yesterday"})
+	assert.Error(t, err)
+	_, err = ParseMaxDataAge(map[string]string{MaxDataAgeTag: "0s"})
+	assert.Error(t, err)
+
+	bundle := &Bundle{Queries: []*explorer.Mquery{
+		{Mrn: "//query/packages", CodeId: "packages", Tags: map[string]string{MaxDataAgeTag: "1h"}},
+		{Mrn: "//query/users", CodeId: "users"},
+	}}
+	maxAges, err := bundle.MaxDataAgeByCodeID()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"packages": time.Hour}, maxAges)
+}
+
+func TestDataAge_StaleMessage(t *testing.T) {
+	now := time.Now()
+	age := &DataAge{
+		MaxAges: map[string]time.Duration{"packages": time.Hour},
+		Now:     now,
+	}
+
+	// live data is always recent enough
+	assert.Empty(t, age.StaleMessage("packages", []string{"dp1"}))
+	assert.Empty(t, age.StaleMessage("users", []string{"dp1"}))
+
+	age.Collected = map[string]time.Time{"dp1": now.Add(-30 * time.Minute), "dp2": now.Add(-2 * time.Hour)}
+	assert.Empty(t, age.StaleMessage("packages", []string{"dp1", "dp3"}))
+	assert.Equal(t, "data is 2h0m0s old, which exceeds the max data age of 1h0m0s", age.StaleMessage("packages", []string{"dp1", "dp2"}))
+	assert.Empty(t, age.StaleMessage("users", []string{"dp2"}))
+
+	age.Collected = map[string]time.Time{"dp1": {}}
+	assert.Equal(t, "data was collected at an unknown time, max data age is 1h0m0s", age.StaleMessage("packages", []string{"dp1"}))
+
+	age.Collected = nil
+	age.Recorded = now.Add(-3 * time.Hour)
+	assert.Equal(t, "data is 3h0m0s old, which exceeds the max data age of 1h0m0s", age.StaleMessage("packages", []string{"dp1"}))
+
+	var none *DataAge
+	assert.Empty(t, none.StaleMessage("packages", []string{"dp1"}))
+}
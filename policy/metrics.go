@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "cnspec"
+
+// ResolverMetrics instruments the policy resolver with Prometheus metrics.
+// All methods are safe to call on nil, which disables the metrics.
+type ResolverMetrics struct {
+	resolveDuration *prometheus.HistogramVec
+	resolveRetries  prometheus.Counter
+	cacheLookups    *prometheus.CounterVec
+	cacheRejections prometheus.Counter
+	scores          *prometheus.CounterVec
+	datapoints      *prometheus.CounterVec
+}
+
+// NewResolverMetrics creates the resolver metrics and registers them with
+// the given registry, e.g. prometheus.DefaultRegisterer
+func NewResolverMetrics(reg prometheus.Registerer) (*ResolverMetrics, error) {
+	m := &ResolverMetrics{
+		resolveDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "resolver",
+			Name:      "resolve_duration_seconds",
+			Help:      "Duration of policy resolutions, including retries.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"result"}),
+		resolveRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "resolver",
+			Name:      "resolve_retries_total",
+			Help:      "Number of policy resolutions that were retried because of concurrent resolves.",
+		}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "resolver",
+			Name:      "cache_lookups_total",
+			Help:      "Lookups of cached resolved policies, by result (hit or miss).",
+		}, []string{"result"}),
+		cacheRejections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "resolver",
+			Name:      "cache_rejections_total",
+			Help:      "Resolved policies that were used without caching them, because the cache was full.",
+		}),
+		scores: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "resolver",
+			Name:      "scores_total",
+			Help:      "Scores that were stored, by result (updated or unchanged).",
+		}, []string{"result"}),
+		datapoints: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "resolver",
+			Name:      "datapoints_total",
+			Help:      "Datapoints that were stored, by result (updated or unchanged).",
+		}, []string{"result"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.resolveDuration, m.resolveRetries, m.cacheLookups, m.cacheRejections, m.scores, m.datapoints} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *ResolverMetrics) observeResolve(start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.resolveDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
+
+func (m *ResolverMetrics) retry() {
+	if m == nil {
+		return
+	}
+	m.resolveRetries.Inc()
+}
+
+func (m *ResolverMetrics) cacheLookup(hit bool) {
+	if m == nil {
+		return
+	}
+	if hit {
+		m.cacheLookups.WithLabelValues("hit").Inc()
+	} else {
+		m.cacheLookups.WithLabelValues("miss").Inc()
+	}
+}
+
+func (m *ResolverMetrics) cacheRejected() {
+	if m == nil {
+		return
+	}
+	m.cacheRejections.Inc()
+}
+
+func (m *ResolverMetrics) storedScores(total int, updated int) {
+	if m == nil {
+		return
+	}
+	m.scores.WithLabelValues("updated").Add(float64(updated))
+	m.scores.WithLabelValues("unchanged").Add(float64(total - updated))
+}
+
+func (m *ResolverMetrics) storedData(total int, updated int) {
+	if m == nil {
+		return
+	}
+	m.datapoints.WithLabelValues("updated").Add(float64(updated))
+	m.datapoints.WithLabelValues("unchanged").Add(float64(total - updated))
+}
@@ -0,0 +1,50 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := NewResolverMetrics(reg)
+	require.NoError(t, err)
+
+	m.observeResolve(time.Now(), nil)
+	m.observeResolve(time.Now(), errors.New("fail"))
+	m.retry()
+	m.cacheLookup(true)
+	m.cacheLookup(true)
+	m.cacheLookup(false)
+	m.cacheRejected()
+	m.storedScores(5, 2)
+	m.storedData(3, 3)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.resolveRetries))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.cacheLookups.WithLabelValues("hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheLookups.WithLabelValues("miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheRejections))
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.scores.WithLabelValues("unchanged")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.datapoints.WithLabelValues("unchanged")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.resolveDuration))
+
+	// metrics can only be registered once per registry
+	_, err = NewResolverMetrics(reg)
+	assert.Error(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		var m *ResolverMetrics
+		m.observeResolve(time.Now(), nil)
+		m.retry()
+		m.cacheLookup(true)
+		m.cacheRejected()
+		m.storedScores(1, 1)
+		m.storedData(1, 1)
+	})
+}
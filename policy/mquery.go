@@ -7,7 +7,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"go.mondoo.com/cnquery"
-	"go.mondoo.com/cnquery/checksums"
 	"go.mondoo.com/cnquery/explorer"
 	"go.mondoo.com/cnquery/llx"
 	"go.mondoo.com/cnquery/mqlc"
@@ -79,7 +78,7 @@ func (m *DeprecatedV7_Mquery) refreshChecksumAndType(props map[string]*llx.Primi
 		m.Type = string(types.Any)
 	}
 
-	c := checksums.New.
+	c := NewChecksum().
 		Add(m.Query).
 		Add(m.CodeId).
 		Add(m.Mrn).
@@ -200,7 +199,7 @@ func ChecksumAssetFilters(queries []*explorer.Mquery) (string, error) {
 		return queries[i].CodeId < queries[j].CodeId
 	})
 
-	afc := checksums.New
+	afc := NewChecksum()
 	for i := range queries {
 		afc = afc.Add(queries[i].CodeId)
 	}
@@ -0,0 +1,165 @@
+package policy
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// nondeterministicPatterns are MQL patterns whose results change between
+// runs, even if the asset didn't change. They are only heuristics on the
+// source of a query, not on its compiled code.
+var nondeterministicPatterns = []struct {
+	re     *regexp.Regexp
+	reason string
+}{
+	{regexp.MustCompile(`\btime\s*\.\s*(now|today|tomorrow|yesterday)\b`), "depends on the current time"},
+	{regexp.MustCompile(`\buptime\b`), "depends on the uptime of the asset"},
+	{regexp.MustCompile(`\brandom\b`), "uses random values"},
+	{regexp.MustCompile(`\.\s*(keys|values)\s*(\[\s*-?\d+\s*\]|\.\s*(first|last)\b)`), "accesses map keys or values by position, their order is not defined"},
+}
+
+var (
+	mqlStrings  = regexp.MustCompile(`"(\\.|[^"\\])*"|'(\\.|[^'\\])*'`)
+	mqlComments = regexp.MustCompile(`(?m)(//|#).*$`)
+)
+
+// NondeterminismReasons returns why the results of the MQL code may change
+// between runs on an unchanged asset, e.g. because it uses the current time.
+// It returns nothing if no such pattern was found.
+func NondeterminismReasons(mql string) []string {
+	if mql == "" {
+		return nil
+	}
+	code := mqlStrings.ReplaceAllString(mql, `""`)
+	code = mqlComments.ReplaceAllString(code, "")
+
+	var res []string
+	for _, p := range nondeterministicPatterns {
+		if p.re.MatchString(code) {
+			res = append(res, p.reason)
+		}
+	}
+	return res
+}
+
+type nondeterminismMarkingKey struct{}
+
+// WithNondeterminismMarking makes bundle compilation mark queries as not
+// deterministic if they are tagged with DeterministicTag, but use patterns
+// that produce nondeterministic results. Their results are then never shared
+// across assets.
+func WithNondeterminismMarking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nondeterminismMarkingKey{}, true)
+}
+
+func nondeterminismMarkingFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, _ := ctx.Value(nondeterminismMarkingKey{}).(bool)
+	return v
+}
+
+// warnNondeterministicQueries logs a warning for all checks and all queries
+// marked as deterministic, whose results may change between runs
+func (p *Bundle) warnNondeterministicQueries(ctx context.Context, checks map[string]struct{}) {
+	mark := nondeterminismMarkingFromContext(ctx)
+
+	for i := range p.Queries {
+		query := p.Queries[i]
+		_, isCheck := checks[query.Mrn]
+		deterministic := isDeterministic(query)
+		if !isCheck && !deterministic {
+			continue
+		}
+
+		reasons := NondeterminismReasons(query.Mql)
+		if len(reasons) == 0 {
+			continue
+		}
+
+		log.Warn().
+			Str("query", query.Mrn).
+			Str("reasons", strings.Join(reasons, ", ")).
+			Msg("query may produce different results on every run")
+
+		if deterministic && mark {
+			query.Tags[DeterministicTag] = "false"
+		}
+	}
+}
+
+func isDeterministic(query *explorer.Mquery) bool {
+	v, err := strconv.ParseBool(query.Tags[DeterministicTag])
+	return err == nil && v
+}
+
+// DeterministicCodeIDs returns the code IDs of all queries in this bundle
+// which are marked as deterministic. The bundle must be compiled.
+func (p *Bundle) DeterministicCodeIDs() map[string]struct{} {
+	res := map[string]struct{}{}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		if query.CodeId != "" && isDeterministic(query) {
+			res[query.CodeId] = struct{}{}
+		}
+	}
+	return res
+}
+
+// lintNondeterminism adds a finding for every check and every deterministic
+// query that uses nondeterministic patterns
+func (l *linter) lintNondeterminism(bundle *Bundle, checks map[string]struct{}) {
+	queries := bundle.Queries
+
+	// checks may be embedded in groups instead of the queries of the bundle
+	known := map[string]struct{}{}
+	for _, query := range bundle.Queries {
+		known[query.Uid] = struct{}{}
+		known[query.Mrn] = struct{}{}
+	}
+	for _, policy := range bundle.Policies {
+		for _, group := range policy.Groups {
+			for _, check := range group.Checks {
+				_, hasUID := known[check.Uid]
+				_, hasMrn := known[check.Mrn]
+				if check.Mql != "" && !hasUID && !hasMrn {
+					queries = append(queries, check)
+				}
+			}
+		}
+	}
+
+	for _, query := range queries {
+		_, isCheck := checks[query.Uid]
+		if !isCheck {
+			_, isCheck = checks[query.Mrn]
+		}
+		deterministic := isDeterministic(query)
+		if !isCheck && !deterministic {
+			continue
+		}
+
+		reasons := NondeterminismReasons(query.Mql)
+		if len(reasons) == 0 {
+			continue
+		}
+		sort.Strings(reasons)
+
+		id := query.Uid
+		if id == "" {
+			id = query.Mrn
+		}
+		msg := "query " + id + " may produce different results on every run, it " + strings.Join(reasons, " and ")
+		if deterministic {
+			msg += ", but is marked as deterministic"
+		}
+		l.add(LintNondeterministicQuery, LintWarning, msg, query.Uid, query.Mrn)
+	}
+}
@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestNondeterminismReasons(t *testing.T) {
+	tests := []struct {
+		mql     string
+		reasons int
+	}{
+		{"users.length > 0", 0},
+		{"file('/etc/passwd').modified < time.now - 30*time.day", 1},
+		{"os.uptime > 1*time.hour", 1},
+		{"parse.json('/x').params.keys[0] == 'a'", 1},
+		{"parse.json('/x').params.values.first == 1", 1},
+		{"parse.json('/x').params.keys.contains('a')", 0},
+		{"time.today > time.now && os.uptime > 0", 2},
+		// strings and comments are ignored
+		{"command('echo time.now').stdout != ''", 0},
+		{"true // compare to time.now", 0},
+	}
+
+	for i := range tests {
+		cur := tests[i]
+		t.Run(cur.mql, func(t *testing.T) {
+			assert.Len(t, NondeterminismReasons(cur.mql), cur.reasons)
+		})
+	}
+}
+
+func TestLint_Nondeterminism(t *testing.T) {
+	filters := &explorer.Filters{Items: map[string]*explorer.Mquery{"f": {Mql: "true"}}}
+	bundle := &Bundle{
+		Policies: []*Policy{{
+			Uid:     "policy",
+			Version: "1.0.0",
+			Groups: []*PolicyGroup{{
+				Filters: filters,
+				Checks: []*explorer.Mquery{
+					{Uid: "check-time"},
+					{Uid: "check-embedded", Mql: "os.uptime > 0", Impact: &explorer.Impact{Value: 10}},
+				},
+				Queries: []*explorer.Mquery{{Uid: "data-time"}, {Uid: "data-memo"}},
+			}},
+		}},
+		Queries: []*explorer.Mquery{
+			{Uid: "check-time", Mql: "time.now > time.yesterday", Impact: &explorer.Impact{Value: 50}},
+			{Uid: "data-time", Mql: "time.now"},
+			{Uid: "data-memo", Mql: "time.now", Tags: map[string]string{DeterministicTag: "true"}},
+		},
+	}
+
+	var ids []string
+	for _, res := range Lint(bundle) {
+		if res.RuleID == LintNondeterministicQuery {
+			ids = append(ids, res.ID)
+		}
+	}
+	assert.ElementsMatch(t, []string{"check-time", "check-embedded", "data-memo"}, ids)
+}
+
+func TestCompile_NondeterminismMarking(t *testing.T) {
+	newBundle := func() *Bundle {
+		return &Bundle{
+			Queries: []*explorer.Mquery{
+				{Uid: "memo-time", Mql: "time.now", Tags: map[string]string{DeterministicTag: "true"}},
+				{Uid: "memo-static", Mql: "true", Tags: map[string]string{DeterministicTag: "true"}},
+			},
+		}
+	}
+
+	bundle := newBundle()
+	_, err := bundle.Compile(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, bundle.DeterministicCodeIDs(), 2)
+
+	bundle = newBundle()
+	_, err = bundle.Compile(WithNondeterminismMarking(context.Background()), nil)
+	require.NoError(t, err)
+	ids := bundle.DeterministicCodeIDs()
+	require.Len(t, ids, 1)
+	assert.Contains(t, ids, bundle.Queries[1].CodeId)
+}
+
+func TestDeterministicCodeIDs(t *testing.T) {
+	bundle := &Bundle{
+		Queries: []*explorer.Mquery{
+			{Uid: "os", CodeId: "os-id", Tags: map[string]string{DeterministicTag: "true"}},
+			{Uid: "users", CodeId: "users-id", Tags: map[string]string{DeterministicTag: "false"}},
+			{Uid: "kernel", CodeId: "kernel-id", Tags: map[string]string{DeterministicTag: "maybe"}},
+			{Uid: "files", CodeId: "files-id"},
+			// uncompiled queries have no code ID yet
+			{Uid: "packages", Tags: map[string]string{DeterministicTag: "true"}},
+		},
+	}
+	assert.Equal(t, map[string]struct{}{"os-id": {}}, bundle.DeterministicCodeIDs())
+}
@@ -0,0 +1,330 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// ScoreChange is a score of an asset whose value or outcome changed
+type ScoreChange struct {
+	AssetMrn string
+	QrId     string
+	// Policies are the policies that the score belongs to, if the resolved
+	// policy of the asset is known
+	Policies []string
+	// Previous is nil for scores that weren't stored before
+	Previous *Score
+	Current  *Score
+}
+
+// ScoreNotifier is notified of all scores that changed in StoreResults
+type ScoreNotifier interface {
+	NotifyScoreChanges(ctx context.Context, changes []ScoreChange)
+}
+
+// ScoreNotifiers notifies multiple notifiers, e.g. webhooks and Slack
+type ScoreNotifiers []ScoreNotifier
+
+// NotifyScoreChanges notifies all notifiers of the changes
+func (n ScoreNotifiers) NotifyScoreChanges(ctx context.Context, changes []ScoreChange) {
+	for i := range n {
+		n[i].NotifyScoreChanges(ctx, changes)
+	}
+}
+
+// scoreChanges returns the changes of all updated, complete scores
+func scoreChanges(assetMrn string, prev map[string]*Score, scores []*Score, updated map[string]struct{}) []ScoreChange {
+	var res []ScoreChange
+	for _, score := range scores {
+		if _, ok := updated[score.QrId]; !ok {
+			continue
+		}
+		cur, ok := NewScoreHistoryEntry(score, "", time.Time{})
+		if !ok {
+			continue
+		}
+		if old, ok := NewScoreHistoryEntry(prev[score.QrId], "", time.Time{}); ok && !cur.Changes(old) {
+			continue
+		}
+		res = append(res, ScoreChange{
+			AssetMrn: assetMrn,
+			QrId:     score.QrId,
+			Previous: prev[score.QrId],
+			Current:  score,
+		})
+	}
+	return res
+}
+
+// scorePolicies maps the QR IDs of all reporting jobs to the QR IDs of
+// the jobs they report to, e.g. the policies of a check
+func scorePolicies(resolvedPolicy *ResolvedPolicy) map[string][]string {
+	if resolvedPolicy == nil || resolvedPolicy.CollectorJob == nil {
+		return nil
+	}
+	jobs := resolvedPolicy.CollectorJob.ReportingJobs
+
+	res := map[string][]string{}
+	for _, job := range jobs {
+		seen := map[string]struct{}{}
+		queue := append([]string{}, job.Notify...)
+		for len(queue) != 0 {
+			uuid := queue[0]
+			queue = queue[1:]
+			if _, ok := seen[uuid]; ok {
+				continue
+			}
+			seen[uuid] = struct{}{}
+
+			parent, ok := jobs[uuid]
+			if !ok {
+				continue
+			}
+			if parent.QrId != "root" {
+				res[job.QrId] = append(res[job.QrId], parent.QrId)
+			}
+			queue = append(queue, parent.Notify...)
+		}
+	}
+	return res
+}
+
+// TransitionKind is the kind of score change that a webhook is sent for
+type TransitionKind string
+
+const (
+	// TransitionFail is a score that failed after it passed before
+	TransitionFail TransitionKind = "fail"
+	// TransitionRecover is a score that passed after it failed before
+	TransitionRecover TransitionKind = "recover"
+	// TransitionThreshold is a score that dropped below the threshold of a route
+	TransitionThreshold TransitionKind = "threshold"
+)
+
+// WebhookRoute configures which score changes are sent to a webhook
+type WebhookRoute struct {
+	URL string
+	// Policies limits the route to scores of these policies (MRNs). The
+	// scores of the policies themselves are included. Empty matches all.
+	Policies []string
+	// Kinds limits the route to these transitions, defaults to
+	// TransitionFail and, if a threshold is set, TransitionThreshold
+	Kinds []TransitionKind
+	// Threshold sends TransitionThreshold when a score drops below it
+	Threshold uint32
+	// Headers are added to every request, e.g. for authentication
+	Headers map[string]string
+}
+
+// WebhookEvent is the JSON payload of a webhook
+type WebhookEvent struct {
+	Kind     TransitionKind `json:"kind"`
+	AssetMrn string         `json:"asset_mrn"`
+	QrId     string         `json:"qr_id"`
+	Policies []string       `json:"policies,omitempty"`
+	Previous *WebhookScore  `json:"previous,omitempty"`
+	Current  *WebhookScore  `json:"current"`
+	Time     time.Time      `json:"time"`
+}
+
+// WebhookScore is a score in a WebhookEvent
+type WebhookScore struct {
+	Value   uint32 `json:"value"`
+	Outcome string `json:"outcome"`
+	Message string `json:"message,omitempty"`
+}
+
+func newWebhookScore(score *Score) *WebhookScore {
+	if score == nil {
+		return nil
+	}
+	return &WebhookScore{
+		Value:   score.Value,
+		Outcome: ScoreOutcome(score),
+		Message: score.MessageLine(),
+	}
+}
+
+func (r *WebhookRoute) matchesPolicies(change ScoreChange) bool {
+	return changeInPolicies(change, r.Policies)
+}
+
+// changeInPolicies returns true if the changed score is one of the given
+// policies or belongs to one of them. Empty policies match all changes.
+func changeInPolicies(change ScoreChange, policies []string) bool {
+	if len(policies) == 0 {
+		return true
+	}
+	for _, mrn := range policies {
+		if mrn == change.QrId {
+			return true
+		}
+		for i := range change.Policies {
+			if mrn == change.Policies[i] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *WebhookRoute) wants(kind TransitionKind) bool {
+	if len(r.Kinds) == 0 {
+		return kind == TransitionFail || (kind == TransitionThreshold && r.Threshold != 0)
+	}
+	for i := range r.Kinds {
+		if r.Kinds[i] == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// transitions returns all kinds of transitions of the change that the
+// route sends webhooks for
+func (r *WebhookRoute) transitions(change ScoreChange) []TransitionKind {
+	if !r.matchesPolicies(change) {
+		return nil
+	}
+
+	var res []TransitionKind
+	prev, cur := ScoreOutcome(change.Previous), ScoreOutcome(change.Current)
+	if prev == OutcomePass && cur == OutcomeFail && r.wants(TransitionFail) {
+		res = append(res, TransitionFail)
+	}
+	if prev == OutcomeFail && cur == OutcomePass && r.wants(TransitionRecover) {
+		res = append(res, TransitionRecover)
+	}
+	if r.Threshold != 0 && change.Current.Type == ScoreType_Result && change.Current.Value < r.Threshold &&
+		(change.Previous == nil || change.Previous.Type != ScoreType_Result || change.Previous.Value >= r.Threshold) &&
+		r.wants(TransitionThreshold) {
+		res = append(res, TransitionThreshold)
+	}
+	return res
+}
+
+// DefaultWebhookQueueSize is the number of events that are buffered before
+// new events are dropped
+const DefaultWebhookQueueSize = 1000
+
+type webhookDelivery struct {
+	route *WebhookRoute
+	event WebhookEvent
+}
+
+// WebhookNotifier sends score changes as JSON POST requests to webhooks.
+// Requests are sent in the background, so that storing results isn't
+// slowed down by slow webhooks. Events are dropped if the queue is full.
+type WebhookNotifier struct {
+	routes []WebhookRoute
+	client *http.Client
+	queue  chan webhookDelivery
+	wg     sync.WaitGroup
+	now    func() time.Time
+}
+
+// NewWebhookNotifier validates the routes and starts sending webhooks
+func NewWebhookNotifier(routes []WebhookRoute, client *http.Client) (*WebhookNotifier, error) {
+	for i := range routes {
+		if routes[i].URL == "" {
+			return nil, errors.New("webhook route is missing a URL")
+		}
+		for _, kind := range routes[i].Kinds {
+			switch kind {
+			case TransitionFail, TransitionRecover, TransitionThreshold:
+			default:
+				return nil, errors.New("unknown transition '" + string(kind) + "' for webhook " + routes[i].URL)
+			}
+		}
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	n := &WebhookNotifier{
+		routes: routes,
+		client: client,
+		queue:  make(chan webhookDelivery, DefaultWebhookQueueSize),
+		now:    time.Now,
+	}
+	n.wg.Add(1)
+	go n.run()
+	return n, nil
+}
+
+// NotifyScoreChanges queues webhooks for all changes that match a route
+func (n *WebhookNotifier) NotifyScoreChanges(ctx context.Context, changes []ScoreChange) {
+	now := n.now()
+	for i := range n.routes {
+		route := &n.routes[i]
+		for _, change := range changes {
+			for _, kind := range route.transitions(change) {
+				delivery := webhookDelivery{
+					route: route,
+					event: WebhookEvent{
+						Kind:     kind,
+						AssetMrn: change.AssetMrn,
+						QrId:     change.QrId,
+						Policies: change.Policies,
+						Previous: newWebhookScore(change.Previous),
+						Current:  newWebhookScore(change.Current),
+						Time:     now,
+					},
+				}
+				select {
+				case n.queue <- delivery:
+				default:
+					log.Warn().Str("url", route.URL).Str("asset", change.AssetMrn).Msg("webhook queue is full, dropping score notification")
+				}
+			}
+		}
+	}
+}
+
+// Close sends all queued webhooks and stops the notifier
+func (n *WebhookNotifier) Close() {
+	close(n.queue)
+	n.wg.Wait()
+}
+
+func (n *WebhookNotifier) run() {
+	defer n.wg.Done()
+	for delivery := range n.queue {
+		if err := n.send(delivery); err != nil {
+			log.Warn().Err(err).Str("url", delivery.route.URL).Msg("failed to send score notification")
+		}
+	}
+}
+
+func (n *WebhookNotifier) send(delivery webhookDelivery) error {
+	body, err := json.Marshal(delivery.event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, delivery.route.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range delivery.route.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return errors.New("webhook responded with " + resp.Status)
+	}
+	return nil
+}
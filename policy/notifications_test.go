@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreChanges(t *testing.T) {
+	pass := &Score{QrId: "a", Type: ScoreType_Result, Value: 100, ScoreCompletion: 100}
+	fail := &Score{QrId: "a", Type: ScoreType_Result, Value: 0, ScoreCompletion: 100}
+	incomplete := &Score{QrId: "b", Type: ScoreType_Result, Value: 0, ScoreCompletion: 50}
+
+	changes := scoreChanges("asset", map[string]*Score{"a": pass}, []*Score{fail, incomplete}, map[string]struct{}{"a": {}, "b": {}})
+	require.Len(t, changes, 1)
+	assert.Equal(t, pass, changes[0].Previous)
+	assert.Equal(t, fail, changes[0].Current)
+
+	// scores that weren't updated don't change
+	changes = scoreChanges("asset", map[string]*Score{"a": pass}, []*Score{fail}, map[string]struct{}{})
+	assert.Empty(t, changes)
+}
+
+func TestScorePolicies(t *testing.T) {
+	resolvedPolicy := &ResolvedPolicy{CollectorJob: &CollectorJob{ReportingJobs: map[string]*ReportingJob{
+		"root":   {Uuid: "root", QrId: "root"},
+		"policy": {Uuid: "policy", QrId: "//policy", Notify: []string{"root"}},
+		"check":  {Uuid: "check", QrId: "check-id", Notify: []string{"policy"}},
+	}}}
+
+	policies := scorePolicies(resolvedPolicy)
+	assert.Equal(t, []string{"//policy"}, policies["check-id"])
+	assert.Empty(t, policies["//policy"])
+}
+
+func TestWebhookRoute(t *testing.T) {
+	pass := &Score{Type: ScoreType_Result, Value: 100, ScoreCompletion: 100}
+	fail := &Score{Type: ScoreType_Result, Value: 40, ScoreCompletion: 100}
+	low := &Score{Type: ScoreType_Result, Value: 20, ScoreCompletion: 100}
+
+	route := WebhookRoute{URL: "http://x", Policies: []string{"//policy"}, Threshold: 30}
+	change := ScoreChange{QrId: "check", Policies: []string{"//policy"}, Previous: pass, Current: fail}
+	assert.Equal(t, []TransitionKind{TransitionFail}, route.transitions(change))
+
+	change.Previous, change.Current = fail, low
+	assert.Equal(t, []TransitionKind{TransitionThreshold}, route.transitions(change))
+
+	change.Previous, change.Current = fail, pass
+	assert.Empty(t, route.transitions(change))
+	route.Kinds = []TransitionKind{TransitionRecover}
+	assert.Equal(t, []TransitionKind{TransitionRecover}, route.transitions(change))
+
+	change.Policies = []string{"//other"}
+	assert.Empty(t, route.transitions(change))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var lock sync.Mutex
+	var events []WebhookEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event WebhookEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		lock.Lock()
+		events = append(events, event)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	_, err := NewWebhookNotifier([]WebhookRoute{{URL: server.URL, Kinds: []TransitionKind{"nope"}}}, nil)
+	assert.Error(t, err)
+
+	n, err := NewWebhookNotifier([]WebhookRoute{{URL: server.URL, Headers: map[string]string{"Authorization": "secret"}}}, nil)
+	require.NoError(t, err)
+	n.NotifyScoreChanges(context.Background(), []ScoreChange{{
+		AssetMrn: "//asset",
+		QrId:     "check",
+		Previous: &Score{Type: ScoreType_Result, Value: 100, ScoreCompletion: 100},
+		Current:  &Score{Type: ScoreType_Result, Value: 0, ScoreCompletion: 100},
+	}})
+	n.Close()
+
+	require.Len(t, events, 1)
+	assert.Equal(t, TransitionFail, events[0].Kind)
+	assert.Equal(t, "//asset", events[0].AssetMrn)
+	assert.Equal(t, OutcomeFail, events[0].Current.Outcome)
+	assert.Equal(t, OutcomePass, events[0].Previous.Outcome)
+}
@@ -0,0 +1,18 @@
+package policy
+
+import "context"
+
+// OwnerScopedDataLake is implemented by datalakes that host isolated spaces
+// for multiple owners, e.g. the tenants of one service process. Policies,
+// assets, scores and reports stored in the space of one owner are invisible
+// to all other owners.
+type OwnerScopedDataLake interface {
+	DataLake
+	// OwnerMrn returns the owner of this space, empty for the shared space
+	OwnerMrn() string
+	// ForOwner returns the space of the owner with services that operate on
+	// it. The space is created if it doesn't exist yet.
+	ForOwner(ownerMrn string) (DataLake, *LocalServices, error)
+	// ListOwners returns all owners that have a space, sorted
+	ListOwners(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"context"
+
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+// PartialResultsReader is implemented by datalakes that can return the
+// results of an asset that they found, instead of failing on the first
+// missing score or datapoint, e.g. after a scan was interrupted
+type PartialResultsReader interface {
+	// GetScoresPartial returns the scores that were found and the sorted IDs
+	// of those that are missing
+	GetScoresPartial(ctx context.Context, assetMrn string, qrIDs []string) (map[string]*Score, []string, error)
+	// GetDataPartial returns the datapoints that were found and the sorted
+	// checksums of those that are missing
+	GetDataPartial(ctx context.Context, assetMrn string, fields map[string]types.Type) (map[string]*llx.Result, []string, error)
+	// GetPartialReport returns the report with all scores and data that were
+	// found and how complete it is
+	GetPartialReport(ctx context.Context, assetMrn string, qrID string) (*Report, *ReportCompleteness, error)
+}
+
+// ReportCompleteness tells how many of the scores and datapoints of an
+// asset's resolved policy made it into its report
+type ReportCompleteness struct {
+	ScoresTotal int `json:"scores_total"`
+	DataTotal   int `json:"data_total"`
+	// MissingScores are the IDs of scores that were not found, sorted
+	MissingScores []string `json:"missing_scores,omitempty"`
+	// MissingData are the checksums of datapoints that were not found, sorted
+	MissingData []string `json:"missing_data,omitempty"`
+}
+
+// Complete returns true if no score or datapoint is missing
+func (c *ReportCompleteness) Complete() bool {
+	return c == nil || (len(c.MissingScores) == 0 && len(c.MissingData) == 0)
+}
+
+// Ratio is the share of scores and datapoints that were found, between 0 and 1
+func (c *ReportCompleteness) Ratio() float64 {
+	if c == nil {
+		return 1
+	}
+	total := c.ScoresTotal + c.DataTotal
+	if total == 0 {
+		return 1
+	}
+	return float64(total-len(c.MissingScores)-len(c.MissingData)) / float64(total)
+}
@@ -0,0 +1,28 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportCompleteness(t *testing.T) {
+	var none *ReportCompleteness
+	assert.True(t, none.Complete())
+	assert.Equal(t, 1.0, none.Ratio())
+
+	complete := &ReportCompleteness{ScoresTotal: 3, DataTotal: 5}
+	assert.True(t, complete.Complete())
+	assert.Equal(t, 1.0, complete.Ratio())
+
+	partial := &ReportCompleteness{
+		ScoresTotal:   3,
+		DataTotal:     5,
+		MissingScores: []string{"check-1"},
+		MissingData:   []string{"abc"},
+	}
+	assert.False(t, partial.Complete())
+	assert.Equal(t, 0.75, partial.Ratio())
+
+	assert.Equal(t, 1.0, (&ReportCompleteness{}).Ratio())
+}
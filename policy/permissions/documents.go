@@ -0,0 +1,84 @@
+package permissions
+
+import (
+	"encoding/json"
+	"errors"
+
+	"sigs.k8s.io/yaml"
+)
+
+// awsPolicy is an AWS IAM policy document
+type awsPolicy struct {
+	Version   string               `json:"Version"`
+	Statement []awsPolicyStatement `json:"Statement"`
+}
+
+type awsPolicyStatement struct {
+	Sid      string   `json:"Sid,omitempty"`
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+// gcpRole is a GCP custom role, as used by `gcloud iam roles create --file`
+type gcpRole struct {
+	Title               string   `json:"title"`
+	Description         string   `json:"description"`
+	Stage               string   `json:"stage"`
+	IncludedPermissions []string `json:"includedPermissions"`
+}
+
+// azureRole is an Azure custom role definition
+type azureRole struct {
+	Name             string   `json:"Name"`
+	IsCustom         bool     `json:"IsCustom"`
+	Description      string   `json:"Description"`
+	Actions          []string `json:"Actions"`
+	NotActions       []string `json:"NotActions"`
+	AssignableScopes []string `json:"AssignableScopes"`
+}
+
+// Document renders the permissions of a provider into a document that can be
+// used to create a role with the provider's tools:
+//   - aws: an IAM policy (JSON)
+//   - gcp: a custom role for `gcloud iam roles create --file` (YAML)
+//   - azure: a custom role for `az role definition create` (JSON), whose
+//     assignable scope has to be filled in
+func (a *Analysis) Document(provider Provider, name string) ([]byte, error) {
+	perms := a.Permissions[provider]
+	if perms == nil {
+		perms = []string{}
+	}
+	description := "Least-privilege permissions to scan with cnspec"
+
+	switch provider {
+	case ProviderAWS:
+		return json.MarshalIndent(awsPolicy{
+			Version: "2012-10-17",
+			Statement: []awsPolicyStatement{{
+				Sid:      "CnspecScan",
+				Effect:   "Allow",
+				Action:   perms,
+				Resource: "*",
+			}},
+		}, "", "  ")
+	case ProviderGCP:
+		return yaml.Marshal(gcpRole{
+			Title:               name,
+			Description:         description,
+			Stage:               "GA",
+			IncludedPermissions: perms,
+		})
+	case ProviderAzure:
+		return json.MarshalIndent(azureRole{
+			Name:             name,
+			IsCustom:         true,
+			Description:      description,
+			Actions:          perms,
+			NotActions:       []string{},
+			AssignableScopes: []string{"/subscriptions/{subscriptionId}"},
+		}, "", "  ")
+	default:
+		return nil, errors.New("unsupported provider '" + string(provider) + "'")
+	}
+}
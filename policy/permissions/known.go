@@ -0,0 +1,200 @@
+package permissions
+
+import "strings"
+
+// knownPermissions maps resources and resource fields to the permissions
+// that their providers need to retrieve them. Keys ending in `.*` cover all
+// fields of a resource. Resources without any entry are reported as unknown.
+var knownPermissions = map[string][]string{
+	// AWS
+	"aws":                                    {},
+	"aws.account":                            {},
+	"aws.account.*":                          {"iam:ListAccountAliases", "organizations:DescribeOrganization"},
+	"aws.regions":                            {"ec2:DescribeRegions"},
+	"aws.vpcs":                               {"ec2:DescribeVpcs"},
+	"aws.vpc":                                {},
+	"aws.vpc.*":                              {"ec2:DescribeFlowLogs", "ec2:DescribeRouteTables"},
+	"aws.ec2":                                {},
+	"aws.ec2.instances":                      {"ec2:DescribeInstances"},
+	"aws.ec2.instance":                       {},
+	"aws.ec2.instance.*":                     {},
+	"aws.ec2.instance.ssm":                   {"ssm:DescribeInstanceInformation"},
+	"aws.ec2.securityGroups":                 {"ec2:DescribeSecurityGroups"},
+	"aws.ec2.securitygroup":                  {},
+	"aws.ec2.securitygroup.*":                {},
+	"aws.ec2.volumes":                        {"ec2:DescribeVolumes"},
+	"aws.ec2.volume":                         {},
+	"aws.ec2.volume.*":                       {},
+	"aws.ec2.snapshots":                      {"ec2:DescribeSnapshots", "ec2:DescribeSnapshotAttribute"},
+	"aws.ec2.snapshot":                       {},
+	"aws.ec2.snapshot.*":                     {},
+	"aws.ec2.ebsEncryptionByDefault":         {"ec2:GetEbsEncryptionByDefault"},
+	"aws.s3":                                 {},
+	"aws.s3.buckets":                         {"s3:ListAllMyBuckets", "s3:GetBucketLocation"},
+	"aws.s3.bucket":                          {},
+	"aws.s3.bucket.name":                     {},
+	"aws.s3.bucket.arn":                      {},
+	"aws.s3.bucket.location":                 {"s3:GetBucketLocation"},
+	"aws.s3.bucket.policy":                   {"s3:GetBucketPolicy"},
+	"aws.s3.bucket.acl":                      {"s3:GetBucketAcl"},
+	"aws.s3.bucket.public":                   {"s3:GetBucketAcl", "s3:GetBucketPolicyStatus"},
+	"aws.s3.bucket.owner":                    {"s3:GetBucketAcl"},
+	"aws.s3.bucket.versioning":               {"s3:GetBucketVersioning"},
+	"aws.s3.bucket.logging":                  {"s3:GetBucketLogging"},
+	"aws.s3.bucket.encryption":               {"s3:GetEncryptionConfiguration"},
+	"aws.s3.bucket.replication":              {"s3:GetReplicationConfiguration"},
+	"aws.s3.bucket.cors":                     {"s3:GetBucketCORS"},
+	"aws.s3.bucket.tags":                     {"s3:GetBucketTagging"},
+	"aws.s3.bucket.staticWebsiteHosting":     {"s3:GetBucketWebsite"},
+	"aws.s3.bucket.defaultLock":              {"s3:GetBucketObjectLockConfiguration"},
+	"aws.s3control":                          {},
+	"aws.s3control.accountPublicAccessBlock": {"s3:GetAccountPublicAccessBlock"},
+	"aws.iam":                                {},
+	"aws.iam.users":                          {"iam:ListUsers"},
+	"aws.iam.user":                           {},
+	"aws.iam.user.*":                         {},
+	"aws.iam.user.policies":                  {"iam:ListUserPolicies"},
+	"aws.iam.user.attachedPolicies":          {"iam:ListAttachedUserPolicies"},
+	"aws.iam.user.accessKeys":                {"iam:ListAccessKeys"},
+	"aws.iam.user.groups":                    {"iam:ListGroupsForUser"},
+	"aws.iam.roles":                          {"iam:ListRoles"},
+	"aws.iam.role":                           {},
+	"aws.iam.role.*":                         {},
+	"aws.iam.groups":                         {"iam:ListGroups"},
+	"aws.iam.group":                          {},
+	"aws.iam.group.*":                        {},
+	"aws.iam.policies":                       {"iam:ListPolicies"},
+	"aws.iam.attachedPolicies":               {"iam:ListPolicies"},
+	"aws.iam.policy":                         {},
+	"aws.iam.policy.*":                       {"iam:GetPolicy", "iam:GetPolicyVersion", "iam:ListEntitiesForPolicy"},
+	"aws.iam.credentialReport":               {"iam:GenerateCredentialReport", "iam:GetCredentialReport"},
+	"aws.iam.usercredentialreportentry":      {},
+	"aws.iam.usercredentialreportentry.*":    {},
+	"aws.iam.accountPasswordPolicy":          {"iam:GetAccountPasswordPolicy"},
+	"aws.iam.accountSummary":                 {"iam:GetAccountSummary"},
+	"aws.iam.virtualMfaDevices":              {"iam:ListVirtualMFADevices"},
+	"aws.iam.serverCertificates":             {"iam:ListServerCertificates"},
+	"aws.cloudtrail":                         {},
+	"aws.cloudtrail.trails":                  {"cloudtrail:DescribeTrails"},
+	"aws.cloudtrail.trail":                   {},
+	"aws.cloudtrail.trail.*":                 {},
+	"aws.cloudtrail.trail.status":            {"cloudtrail:GetTrailStatus"},
+	"aws.cloudtrail.trail.eventSelectors":    {"cloudtrail:GetEventSelectors"},
+	"aws.cloudtrail.trail.logGroup":          {"logs:DescribeLogGroups"},
+	"aws.config":                             {},
+	"aws.config.recorders":                   {"config:DescribeConfigurationRecorders", "config:DescribeConfigurationRecorderStatus"},
+	"aws.config.recorder":                    {},
+	"aws.config.recorder.*":                  {},
+	"aws.config.rules":                       {"config:DescribeConfigRules"},
+	"aws.kms":                                {},
+	"aws.kms.keys":                           {"kms:ListKeys"},
+	"aws.kms.key":                            {},
+	"aws.kms.key.*":                          {"kms:DescribeKey"},
+	"aws.kms.key.keyRotationEnabled":         {"kms:GetKeyRotationStatus"},
+	"aws.kms.key.metadata":                   {"kms:DescribeKey"},
+	"aws.rds":                                {},
+	"aws.rds.dbInstances":                    {"rds:DescribeDBInstances"},
+	"aws.rds.dbinstance":                     {},
+	"aws.rds.dbinstance.*":                   {},
+	"aws.rds.dbinstance.snapshots":           {"rds:DescribeDBSnapshots", "rds:DescribeDBSnapshotAttributes"},
+	"aws.rds.dbClusters":                     {"rds:DescribeDBClusters"},
+	"aws.rds.dbcluster":                      {},
+	"aws.rds.dbcluster.*":                    {},
+	"aws.securityhub":                        {},
+	"aws.securityhub.hubs":                   {"securityhub:DescribeHub"},
+	"aws.guardduty":                          {},
+	"aws.guardduty.detectors":                {"guardduty:ListDetectors", "guardduty:GetDetector"},
+	"aws.cloudwatch":                         {},
+	"aws.cloudwatch.logGroups":               {"logs:DescribeLogGroups"},
+	"aws.cloudwatch.alarms":                  {"cloudwatch:DescribeAlarms"},
+	"aws.lambda":                             {},
+	"aws.lambda.functions":                   {"lambda:ListFunctions"},
+	"aws.lambda.function":                    {},
+	"aws.lambda.function.*":                  {},
+	"aws.lambda.function.policy":             {"lambda:GetPolicy"},
+	"aws.efs":                                {},
+	"aws.efs.filesystems":                    {"elasticfilesystem:DescribeFileSystems"},
+	"aws.eks":                                {},
+	"aws.eks.clusters":                       {"eks:ListClusters", "eks:DescribeCluster"},
+	"aws.dynamodb":                           {},
+	"aws.dynamodb.tables":                    {"dynamodb:ListTables", "dynamodb:DescribeTable"},
+	"aws.sns":                                {},
+	"aws.sns.topics":                         {"sns:ListTopics"},
+	"aws.sqs":                                {},
+	"aws.sqs.queues":                         {"sqs:ListQueues"},
+
+	// GCP
+	"gcp":                          {},
+	"gcp.project":                  {},
+	"gcp.project.*":                {"resourcemanager.projects.get"},
+	"gcp.project.iamPolicy":        {"resourcemanager.projects.getIamPolicy"},
+	"gcp.compute":                  {},
+	"gcp.compute.instances":        {"compute.instances.list"},
+	"gcp.compute.instance":         {},
+	"gcp.compute.instance.*":       {},
+	"gcp.compute.firewalls":        {"compute.firewalls.list"},
+	"gcp.compute.networks":         {"compute.networks.list"},
+	"gcp.compute.subnetworks":      {"compute.subnetworks.list"},
+	"gcp.compute.disks":            {"compute.disks.list"},
+	"gcp.storage":                  {},
+	"gcp.storage.buckets":          {"storage.buckets.list"},
+	"gcp.storage.bucket":           {},
+	"gcp.storage.bucket.*":         {},
+	"gcp.storage.bucket.iamPolicy": {"storage.buckets.getIamPolicy"},
+	"gcp.sql":                      {},
+	"gcp.sql.instances":            {"cloudsql.instances.list"},
+	"gcp.kms":                      {},
+	"gcp.kms.keyrings":             {"cloudkms.keyRings.list", "cloudkms.cryptoKeys.list"},
+	"gcp.iam":                      {},
+	"gcp.iam.serviceAccounts":      {"iam.serviceAccounts.list", "iam.serviceAccountKeys.list"},
+	"gcp.logging":                  {},
+	"gcp.logging.sinks":            {"logging.sinks.list"},
+	"gcp.logging.metrics":          {"logging.logMetrics.list"},
+
+	// Azure
+	"azure":                                            {},
+	"azure.subscription":                               {},
+	"azure.subscription.*":                             {"Microsoft.Resources/subscriptions/read"},
+	"azure.compute":                                    {},
+	"azure.compute.vms":                                {"Microsoft.Compute/virtualMachines/read"},
+	"azure.compute.vm":                                 {},
+	"azure.compute.vm.*":                               {},
+	"azure.compute.vm.extensions":                      {"Microsoft.Compute/virtualMachines/extensions/read"},
+	"azure.compute.disks":                              {"Microsoft.Compute/disks/read"},
+	"azure.network":                                    {},
+	"azure.network.interfaces":                         {"Microsoft.Network/networkInterfaces/read"},
+	"azure.network.securityGroups":                     {"Microsoft.Network/networkSecurityGroups/read"},
+	"azure.network.watchers":                           {"Microsoft.Network/networkWatchers/read"},
+	"azure.storage":                                    {},
+	"azure.storage.accounts":                           {"Microsoft.Storage/storageAccounts/read"},
+	"azure.storage.account":                            {},
+	"azure.storage.account.*":                          {},
+	"azure.storage.account.containers":                 {"Microsoft.Storage/storageAccounts/blobServices/containers/read"},
+	"azure.keyvault":                                   {},
+	"azure.keyvault.vaults":                            {"Microsoft.KeyVault/vaults/read"},
+	"azure.sql":                                        {},
+	"azure.sql.servers":                                {"Microsoft.Sql/servers/read"},
+	"azure.monitor":                                    {},
+	"azure.monitor.logProfiles":                        {"Microsoft.Insights/logprofiles/read"},
+	"azure.monitor.activityLog":                        {"Microsoft.Insights/eventtypes/values/read"},
+	"azure.monitor.diagnosticSettings":                 {"Microsoft.Insights/diagnosticSettings/read"},
+	"azure.authorization":                              {},
+	"azure.authorization.roleDefinitions":              {"Microsoft.Authorization/roleDefinitions/read"},
+	"azure.cloudDefender":                              {},
+	"azure.cloudDefender.monitoringAgentAutoProvision": {"Microsoft.Security/autoProvisioningSettings/read"},
+}
+
+// lookup returns the permissions of a resource or resource field
+func lookup(id string) ([]string, bool) {
+	if perms, ok := knownPermissions[id]; ok {
+		return perms, true
+	}
+
+	// fall back to the permissions of all fields of the resource
+	idx := strings.LastIndexByte(id, '.')
+	if idx == -1 {
+		return nil, false
+	}
+	perms, ok := knownPermissions[id[:idx]+".*"]
+	return perms, ok
+}
@@ -0,0 +1,189 @@
+// Package permissions computes the cloud permissions that policies need, so
+// that operators can provision least-privilege roles for scanning.
+package permissions
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// Provider is a cloud provider with its own permission model
+type Provider string
+
+const (
+	ProviderAWS   Provider = "aws"
+	ProviderGCP   Provider = "gcp"
+	ProviderAzure Provider = "azure"
+)
+
+// Providers lists all supported providers
+var Providers = []Provider{ProviderAWS, ProviderAzure, ProviderGCP}
+
+// providerOf returns the provider of a resource like `aws.ec2.instance`,
+// or an empty string for resources that need no cloud permissions
+func providerOf(resource string) Provider {
+	prefix, _, _ := strings.Cut(resource, ".")
+	switch prefix {
+	case "aws":
+		return ProviderAWS
+	case "gcp":
+		return ProviderGCP
+	case "azure", "azurerm":
+		return ProviderAzure
+	default:
+		return ""
+	}
+}
+
+// Analysis is the result of analyzing a bundle
+type Analysis struct {
+	// Permissions are the sorted permissions per provider
+	Permissions map[Provider][]string
+	// Unknown lists resources (and resource fields) of cloud providers for
+	// which the required permissions aren't known. Policy documents may be
+	// incomplete if this isn't empty.
+	Unknown map[Provider][]string
+	// Queries lists the resources that every query uses, by query MRN
+	Queries map[string][]string
+	// Errors lists queries that could not be compiled, by query MRN
+	Errors map[string]string
+}
+
+// Analyze computes the minimal permissions that are needed to execute all
+// queries of the bundle. The bundle is not modified.
+func Analyze(ctx context.Context, bundle *policy.Bundle) (*Analysis, error) {
+	bundle = proto.Clone(bundle).(*policy.Bundle)
+	// queries that don't compile are reported per query
+	bundleMap, err := bundle.Compile(ctx, nil)
+	if bundleMap == nil {
+		return nil, err
+	}
+
+	res := &Analysis{
+		Permissions: map[Provider][]string{},
+		Unknown:     map[Provider][]string{},
+		Queries:     map[string][]string{},
+		Errors:      map[string]string{},
+	}
+	permissions := map[Provider]map[string]struct{}{}
+	unknown := map[Provider]map[string]struct{}{}
+
+	for _, query := range bundle.Queries {
+		code, ok := bundleMap.Code[query.Mrn]
+		if !ok {
+			// queries that are embedded in policies aren't in the bundle map
+			code, err = query.RefreshChecksumAndType(nil)
+			if err != nil {
+				res.Errors[query.Mrn] = err.Error()
+				continue
+			}
+		}
+		if code == nil || code.CodeV2 == nil {
+			res.Errors[query.Mrn] = "failed to compile query"
+			continue
+		}
+
+		resources := resourcesFromCode(code.CodeV2)
+		res.Queries[query.Mrn] = resources
+
+		for _, resource := range resources {
+			provider := providerOf(resource)
+			if provider == "" {
+				continue
+			}
+			perms, ok := lookup(resource)
+			if !ok {
+				addTo(unknown, provider, resource)
+				continue
+			}
+			for _, perm := range perms {
+				addTo(permissions, provider, perm)
+			}
+		}
+	}
+
+	for provider, set := range permissions {
+		res.Permissions[provider] = sortedKeys(set)
+	}
+	for provider, set := range unknown {
+		res.Unknown[provider] = sortedKeys(set)
+	}
+	return res, nil
+}
+
+// resourcesFromCode returns all resources and resource fields that the code
+// accesses, e.g. `aws.ec2.instances` and `aws.s3.bucket.policy`
+func resourcesFromCode(code *llx.CodeV2) []string {
+	set := map[string]struct{}{}
+	for _, block := range code.Blocks {
+		for _, chunk := range block.Chunks {
+			if chunk.Call != llx.Chunk_FUNCTION {
+				continue
+			}
+
+			// resources are created by functions without a binding
+			if chunk.Function == nil || chunk.Function.Binding == 0 {
+				set[chunk.Id] = struct{}{}
+				continue
+			}
+
+			bound := code.Chunk(chunk.Function.Binding)
+			if name := resourceName(bound); name != "" {
+				set[name+"."+chunk.Id] = struct{}{}
+			}
+		}
+	}
+	return sortedKeys(set)
+}
+
+// resourceName returns the resource that a chunk results in, if any
+func resourceName(chunk *llx.Chunk) string {
+	if chunk == nil {
+		return ""
+	}
+
+	var typ types.Type
+	switch {
+	case chunk.Call == llx.Chunk_FUNCTION && (chunk.Function == nil || chunk.Function.Binding == 0):
+		return chunk.Id
+	case chunk.Function != nil:
+		typ = types.Type(chunk.Function.Type)
+	case chunk.Primitive != nil:
+		// e.g. the bound value in a block like `aws.ec2.instances { state }`
+		typ = types.Type(chunk.Primitive.Type)
+	default:
+		return ""
+	}
+
+	for typ.IsArray() || typ.IsMap() {
+		typ = typ.Child()
+	}
+	if !typ.IsResource() {
+		return ""
+	}
+	return typ.ResourceName()
+}
+
+func addTo(m map[Provider]map[string]struct{}, provider Provider, value string) {
+	set, ok := m[provider]
+	if !ok {
+		set = map[string]struct{}{}
+		m[provider] = set
+	}
+	set[value] = struct{}{}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	res := make([]string, 0, len(set))
+	for k := range set {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
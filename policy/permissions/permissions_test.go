@@ -0,0 +1,83 @@
+package permissions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+func TestResourcesFromCode(t *testing.T) {
+	// aws.s3.buckets { policy }
+	code := &llx.CodeV2{
+		Blocks: []*llx.Block{
+			{
+				Chunks: []*llx.Chunk{
+					{Call: llx.Chunk_FUNCTION, Id: "aws.s3"},
+					{Call: llx.Chunk_FUNCTION, Id: "buckets", Function: &llx.Function{
+						Binding: 1<<32 | 1,
+						Type:    string(types.Array(types.Resource("aws.s3.bucket"))),
+					}},
+				},
+			},
+			{
+				Chunks: []*llx.Chunk{
+					{Call: llx.Chunk_PRIMITIVE, Id: "_", Primitive: &llx.Primitive{Type: string(types.Resource("aws.s3.bucket"))}},
+					{Call: llx.Chunk_FUNCTION, Id: "policy", Function: &llx.Function{
+						Binding: 2<<32 | 1,
+						Type:    string(types.Resource("aws.iam.policy")),
+					}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"aws.s3", "aws.s3.bucket.policy", "aws.s3.buckets"}, resourcesFromCode(code))
+}
+
+func TestLookup(t *testing.T) {
+	perms, ok := lookup("aws.s3.buckets")
+	require.True(t, ok)
+	assert.Equal(t, []string{"s3:ListAllMyBuckets", "s3:GetBucketLocation"}, perms)
+
+	// covered by all fields of the resource
+	perms, ok = lookup("aws.ec2.instance.state")
+	require.True(t, ok)
+	assert.Empty(t, perms)
+
+	_, ok = lookup("aws.s3.bucket.unknownField")
+	assert.False(t, ok)
+
+	assert.Equal(t, ProviderAzure, providerOf("azurerm.subscription"))
+	assert.Equal(t, Provider(""), providerOf("os.base"))
+}
+
+func TestDocument(t *testing.T) {
+	analysis := &Analysis{Permissions: map[Provider][]string{
+		ProviderAWS: {"ec2:DescribeInstances", "s3:ListAllMyBuckets"},
+		ProviderGCP: {"compute.instances.list"},
+	}}
+
+	raw, err := analysis.Document(ProviderAWS, "scan")
+	require.NoError(t, err)
+	var doc awsPolicy
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	assert.Equal(t, "2012-10-17", doc.Version)
+	require.Len(t, doc.Statement, 1)
+	assert.Equal(t, []string{"ec2:DescribeInstances", "s3:ListAllMyBuckets"}, doc.Statement[0].Action)
+
+	raw, err = analysis.Document(ProviderGCP, "scan")
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "- compute.instances.list")
+	assert.Contains(t, string(raw), "title: scan")
+
+	raw, err = analysis.Document(ProviderAzure, "scan")
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"Actions": []`)
+
+	_, err = analysis.Document("oracle", "scan")
+	assert.Error(t, err)
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
-	"go.mondoo.com/cnquery/checksums"
 	"go.mondoo.com/cnquery/explorer"
 	"go.mondoo.com/cnquery/mrn"
 	"go.mondoo.com/cnquery/types"
@@ -214,7 +213,7 @@ func MatchingAssetFilters(policyMrn string, assetFilters []*explorer.Mquery, p *
 }
 
 func getPolicyNoop(ctx context.Context, mrn string) (*Policy, error) {
-	return nil, errors.New("policy not found: " + mrn)
+	return nil, NewPolicyNotFoundError(mrn)
 }
 
 func getQueryNoop(ctx context.Context, mrn string) (*explorer.Mquery, error) {
@@ -250,8 +249,8 @@ func (p *Policy) UpdateChecksums(ctx context.Context,
 	// graph checksums. This code is identical to the complete computation
 	// but doesn't recompute any of the local checksums.
 
-	graphExecutionChecksum := checksums.New
-	graphContentChecksum := checksums.New
+	graphExecutionChecksum := NewChecksum()
+	graphContentChecksum := NewChecksum()
 
 	var err error
 	for i := range p.Groups {
@@ -317,10 +316,10 @@ func (p *Policy) updateAllChecksums(ctx context.Context,
 
 	var i int
 
-	executionChecksum := checksums.New
-	contentChecksum := checksums.New
-	graphExecutionChecksum := checksums.New
-	graphContentChecksum := checksums.New
+	executionChecksum := NewChecksum()
+	contentChecksum := NewChecksum()
+	graphExecutionChecksum := NewChecksum()
+	graphContentChecksum := NewChecksum()
 
 	// content fields in the policy
 	contentChecksum = contentChecksum.Add(p.Mrn).Add(p.Name).Add(p.Version).Add(p.OwnerMrn)
@@ -362,6 +361,11 @@ func (p *Policy) updateAllChecksums(ctx context.Context,
 	}
 	executionChecksum = executionChecksum.AddUint(uint64(p.ScoringSystem))
 
+	// the priority decides conflicts between policies, see PriorityTag
+	if priority, ok := p.Tags[PriorityTag]; ok {
+		executionChecksum = executionChecksum.Add(PriorityTag).Add(priority)
+	}
+
 	// PROPS (must be sorted)
 	sort.Slice(p.Props, func(i, j int) bool {
 		return p.Props[i].Mrn < p.Props[j].Mrn
@@ -498,7 +502,7 @@ func (p *Policy) updateAllChecksums(ctx context.Context,
 	}
 
 	p.LocalExecutionChecksum = executionChecksum.String()
-	p.LocalContentChecksum = executionChecksum.AddUint(uint64(contentChecksum)).String()
+	p.LocalContentChecksum = executionChecksum.AddChecksum(contentChecksum).String()
 
 	p.GraphExecutionChecksum = graphExecutionChecksum.Add(p.LocalExecutionChecksum).String()
 	p.GraphContentChecksum = graphContentChecksum.Add(p.LocalContentChecksum).String()
@@ -506,7 +510,7 @@ func (p *Policy) updateAllChecksums(ctx context.Context,
 	return nil
 }
 
-func checksumAddSpec(checksum checksums.Fast, spec *DeprecatedV7_ScoringSpec) checksums.Fast {
+func checksumAddSpec(checksum Checksum, spec *DeprecatedV7_ScoringSpec) Checksum {
 	checksum = checksum.AddUint((uint64(spec.Action) << 32) | (uint64(spec.ScoringSystem)))
 	var weightIsPrecentage uint64
 	if spec.WeightIsPercentage {
@@ -586,16 +590,11 @@ func (s *ScoringSystem) UnmarshalJSON(data []byte) error {
 		var name string
 		_ = json.Unmarshal(data, &name)
 
-		switch name {
-		case "highest impact":
-			*s = ScoringSystem_WORST
-		case "weighted":
-			*s = ScoringSystem_WEIGHTED
-		case "average", "":
-			*s = ScoringSystem_AVERAGE
-		default:
+		res, ok := ScoringSystemByName(name)
+		if !ok {
 			return errors.New("unknown scoring system: " + string(data))
 		}
+		*s = res
 	}
 	return nil
 }
@@ -0,0 +1,137 @@
+package policy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/explorer"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultPrecomputeConcurrency is the number of policies that are resolved in
+// parallel during precomputation, if nothing else is configured
+const DefaultPrecomputeConcurrency = 4
+
+// DistinctFilterSets removes all duplicate asset filter sets. Two sets are
+// the same if they contain the same filters, regardless of their order.
+// The returned sets are indexed by their checksum.
+func DistinctFilterSets(filterSets [][]*explorer.Mquery) (map[string][]*explorer.Mquery, error) {
+	res := map[string][]*explorer.Mquery{}
+	for i := range filterSets {
+		set := make([]*explorer.Mquery, len(filterSets[i]))
+		for j := range filterSets[i] {
+			set[j] = proto.Clone(filterSets[i][j]).(*explorer.Mquery)
+		}
+
+		checksum, err := ChecksumAssetFilters(set)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := res[checksum]; !ok {
+			res[checksum] = set
+		}
+	}
+	return res, nil
+}
+
+// PrecomputeAsset is an asset whose asset policy is resolved up front, see
+// PrecomputeAssetResolve
+type PrecomputeAsset struct {
+	Mrn         string
+	Filters     []*explorer.Mquery
+	Criticality AssetCriticality
+}
+
+// PrecomputeAssetResolve resolves the asset policies of the assets up front
+// and in parallel. This is meant for inventories with many assets: by
+// collecting the filters of all assets (e.g. from a previous scan) before
+// any asset is scanned, the resolved policies are cached and scans don't
+// have to wait for their first-time resolution.
+//
+// The policies must already be assigned to the assets. Asset policies leave
+// their MRN out of their execution checksum, so assets with the same
+// policies, properties, filters and criticality share one resolved policy:
+// only one asset per distinct filter set and criticality is resolved, the
+// scans of all others find its resolved policy in the cache.
+// Errors for individual assets are collected and returned together, all
+// other assets are still resolved.
+func (s *LocalServices) PrecomputeAssetResolve(ctx context.Context, assets []PrecomputeAsset, concurrency int) error {
+	distinct := map[string][]*explorer.Mquery{}
+	byChecksum := map[string]PrecomputeAsset{}
+	for i := range assets {
+		sets, err := DistinctFilterSets([][]*explorer.Mquery{assets[i].Filters})
+		if err != nil {
+			return err
+		}
+		for checksum, set := range sets {
+			if assets[i].Criticality.Factor() != 1 {
+				checksum = checksumStrings(checksum, criticalityChecksum(assets[i].Criticality))
+			}
+			if _, ok := distinct[checksum]; !ok {
+				distinct[checksum] = set
+				byChecksum[checksum] = assets[i]
+			}
+		}
+	}
+
+	if concurrency <= 0 {
+		concurrency = DefaultPrecomputeConcurrency
+	}
+
+	log.Debug().
+		Int("assets", len(assets)).
+		Int("distinct", len(distinct)).
+		Msg("resolver> precompute resolved policies")
+
+	return precomputeResolve(ctx, distinct, concurrency, func(ctx context.Context, checksum string, set []*explorer.Mquery) error {
+		asset := byChecksum[checksum]
+		_, err := s.resolve(WithAssetCriticality(ctx, asset.Criticality), asset.Mrn, set)
+		return err
+	})
+}
+
+// precomputeResolve calls resolve for all filter sets, which are indexed by
+// their checksum, with at most concurrency calls at the same time
+func precomputeResolve(ctx context.Context, distinct map[string][]*explorer.Mquery, concurrency int,
+	resolve func(ctx context.Context, checksum string, set []*explorer.Mquery) error,
+) error {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		errs  *multierror.Error
+		slots = make(chan struct{}, concurrency)
+	)
+
+	for checksum, set := range distinct {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(checksum string, set []*explorer.Mquery) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			if err := resolve(ctx, checksum, set); err != nil {
+				log.Debug().Err(err).Str("filters", checksum).Msg("resolver> failed to precompute resolved policy")
+				mu.Lock()
+				errs = multierror.Append(errs, err)
+				mu.Unlock()
+			}
+		}(checksum, set)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestDistinctFilterSets(t *testing.T) {
+	unix := &explorer.Mquery{Mql: "asset.family.contains(\"unix\")"}
+	windows := &explorer.Mquery{Mql: "asset.family.contains(\"windows\")"}
+	arch := &explorer.Mquery{Mql: "asset.platform == \"arch\""}
+
+	res, err := DistinctFilterSets([][]*explorer.Mquery{
+		{unix, arch},
+		{arch, unix},
+		{windows},
+		{unix},
+	})
+	require.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	// the original filters are not modified
+	assert.Empty(t, unix.CodeId)
+}
+
+func TestPrecomputeResolve(t *testing.T) {
+	unix := &explorer.Mquery{Mql: "asset.family.contains(\"unix\")"}
+	windows := &explorer.Mquery{Mql: "asset.family.contains(\"windows\")"}
+	arch := &explorer.Mquery{Mql: "asset.platform == \"arch\""}
+	distinct, err := DistinctFilterSets([][]*explorer.Mquery{{unix}, {windows}, {unix, arch}, {arch}})
+	require.NoError(t, err)
+
+	t.Run("resolves all sets with limited concurrency", func(t *testing.T) {
+		var running, maxRunning int32
+		var lock sync.Mutex
+		resolved := 0
+		err := precomputeResolve(context.Background(), distinct, 2, func(ctx context.Context, checksum string, set []*explorer.Mquery) error {
+			cur := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			lock.Lock()
+			if cur > maxRunning {
+				maxRunning = cur
+			}
+			resolved++
+			lock.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, resolved)
+		assert.LessOrEqual(t, maxRunning, int32(2))
+	})
+
+	t.Run("an error of one set doesn't stop the others", func(t *testing.T) {
+		var lock sync.Mutex
+		resolved := 0
+		err := precomputeResolve(context.Background(), distinct, 2, func(ctx context.Context, checksum string, set []*explorer.Mquery) error {
+			if len(set) == 1 && set[0].Mql == windows.Mql {
+				return errors.New("cannot resolve windows")
+			}
+			lock.Lock()
+			resolved++
+			lock.Unlock()
+			return nil
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot resolve windows")
+		assert.Equal(t, 3, resolved)
+	})
+
+	t.Run("stops waiting for a slot when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- precomputeResolve(ctx, distinct, 1, func(ctx context.Context, checksum string, set []*explorer.Mquery) error {
+				<-release
+				return nil
+			})
+		}()
+
+		cancel()
+		close(release)
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("precompute didn't stop after the context was canceled")
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"strings"
+)
+
+// PrerequisitesTag is the check tag that lists what a check needs from the
+// asset, separated by commas, e.g. `sudo` or `scope:compute.readonly`.
+// Prerequisites are evaluated once per asset. Checks whose prerequisites
+// aren't met are skipped with the reason, instead of failing with
+// permission errors.
+const PrerequisitesTag = "cnspec/requires"
+
+// ParsePrerequisites reads the prerequisites of a check from its tags,
+// sorted and without duplicates
+func ParsePrerequisites(tags map[string]string) []string {
+	v, ok := tags[PrerequisitesTag]
+	if !ok {
+		return nil
+	}
+
+	set := map[string]struct{}{}
+	for _, prerequisite := range strings.Split(v, ",") {
+		if prerequisite = strings.TrimSpace(prerequisite); prerequisite != "" {
+			set[prerequisite] = struct{}{}
+		}
+	}
+	return sortedKeys(set)
+}
+
+// PrerequisitesByCodeID collects the prerequisites of all checks and queries
+// in this bundle, indexed by their code ID. The bundle must be compiled.
+func (p *Bundle) PrerequisitesByCodeID() map[string][]string {
+	res := map[string][]string{}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		if query.CodeId == "" {
+			continue
+		}
+		if prerequisites := ParsePrerequisites(query.Tags); len(prerequisites) != 0 {
+			res[query.CodeId] = prerequisites
+		}
+	}
+	return res
+}
+
+// Prerequisites are the prerequisites of checks and whether an asset meets
+// them
+type Prerequisites struct {
+	// Required lists the prerequisites of checks, by code ID
+	Required map[string][]string
+	// Unmet are the prerequisites that the asset doesn't meet, mapped to the
+	// reason why
+	Unmet map[string]string
+}
+
+// PrerequisiteMissingError is the result of every datapoint of a check
+// whose prerequisites the asset doesn't meet
+type PrerequisiteMissingError struct {
+	// Prerequisites that aren't met, sorted
+	Prerequisites []string
+	// Reasons why they aren't met, in the same order
+	Reasons []string
+}
+
+func (e *PrerequisiteMissingError) Error() string {
+	parts := make([]string, len(e.Prerequisites))
+	for i := range e.Prerequisites {
+		parts[i] = e.Prerequisites[i]
+		if e.Reasons[i] != "" {
+			parts[i] += " (" + e.Reasons[i] + ")"
+		}
+	}
+	return "prerequisite missing: the check requires " + strings.Join(parts, ", ")
+}
+
+// Check returns a PrerequisiteMissingError if the asset doesn't meet all
+// prerequisites of the check with the given code ID
+func (p *Prerequisites) Check(codeID string) error {
+	if p == nil || len(p.Unmet) == 0 {
+		return nil
+	}
+
+	var res *PrerequisiteMissingError
+	for _, prerequisite := range p.Required[codeID] {
+		reason, ok := p.Unmet[prerequisite]
+		if !ok {
+			continue
+		}
+		if res == nil {
+			res = &PrerequisiteMissingError{}
+		}
+		res.Prerequisites = append(res.Prerequisites, prerequisite)
+		res.Reasons = append(res.Reasons, reason)
+	}
+	if res == nil {
+		return nil
+	}
+	return res
+}
+
+// All returns all prerequisites that any check requires, sorted
+func (p *Prerequisites) All() []string {
+	set := map[string]struct{}{}
+	for _, prerequisites := range p.Required {
+		for _, prerequisite := range prerequisites {
+			set[prerequisite] = struct{}{}
+		}
+	}
+	return sortedKeys(set)
+}
@@ -0,0 +1,50 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestParsePrerequisites(t *testing.T) {
+	assert.Equal(t, []string{"scope:compute.readonly", "sudo"}, ParsePrerequisites(map[string]string{PrerequisitesTag: "sudo, scope:compute.readonly,,sudo"}))
+	assert.Empty(t, ParsePrerequisites(map[string]string{}))
+
+	bundle := &Bundle{Queries: []*explorer.Mquery{
+		{Mrn: "//query/shadow", CodeId: "shadow", Tags: map[string]string{PrerequisitesTag: "sudo"}},
+		{Mrn: "//query/users", CodeId: "users"},
+	}}
+	assert.Equal(t, map[string][]string{"shadow": {"sudo"}}, bundle.PrerequisitesByCodeID())
+}
+
+func TestPrerequisites_Check(t *testing.T) {
+	prerequisites := &Prerequisites{
+		Required: map[string][]string{
+			"shadow":    {"sudo"},
+			"instances": {"scope:compute.readonly", "scope:storage.readonly"},
+			"users":     {"scope:storage.readonly"},
+		},
+		Unmet: map[string]string{
+			"sudo":                   "the connection neither uses sudo nor root",
+			"scope:compute.readonly": "",
+		},
+	}
+	assert.Equal(t, []string{"scope:compute.readonly", "scope:storage.readonly", "sudo"}, prerequisites.All())
+
+	err := prerequisites.Check("shadow")
+	var missing *PrerequisiteMissingError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{"sudo"}, missing.Prerequisites)
+	assert.Equal(t, "prerequisite missing: the check requires sudo (the connection neither uses sudo nor root)", err.Error())
+
+	err = prerequisites.Check("instances")
+	assert.Equal(t, "prerequisite missing: the check requires scope:compute.readonly", err.Error())
+
+	assert.NoError(t, prerequisites.Check("users"))
+	assert.NoError(t, prerequisites.Check("unknown"))
+
+	var none *Prerequisites
+	assert.NoError(t, none.Check("shadow"))
+}
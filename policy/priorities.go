@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// PriorityTag sets the priority of the modifications a policy makes to the
+// checks and policies it references, e.g. `cnspec/priority: "10"`. If
+// policies modify the impact of the same check in different ways, the
+// modification with the highest priority wins, see PolicyConflict. Policies
+// without the tag have priority 0, negative priorities are allowed.
+const PriorityTag = "cnspec/priority"
+
+// PolicyPriority returns the priority of a policy, see PriorityTag
+func PolicyPriority(policy *Policy) (int, error) {
+	if policy == nil {
+		return 0, nil
+	}
+	value, ok := policy.Tags[PriorityTag]
+	if !ok {
+		return 0, nil
+	}
+	res, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("invalid priority '" + value + "' in policy '" + policy.Mrn + "', it must be an integer")
+	}
+	return res, nil
+}
+
+// ConflictRule is the precedence rule that decided a conflict
+type ConflictRule string
+
+const (
+	// ConflictRulePriority means the policy with the highest priority won
+	ConflictRulePriority ConflictRule = "priority"
+	// ConflictRuleDepth means the policy closest to the asset won, since all
+	// policies had the same priority
+	ConflictRuleDepth ConflictRule = "depth"
+	// ConflictRuleMrn means the policy with the lowest MRN won, since all
+	// policies had the same priority and were equally close to the asset
+	ConflictRuleMrn ConflictRule = "mrn"
+	// ConflictRuleActivation means a check or policy stayed active, since
+	// deactivations only apply to the policy tree they are declared in
+	ConflictRuleActivation ConflictRule = "activation"
+)
+
+// policyPriority returns the priority of a policy in the resolved bundle.
+// Invalid priorities were rejected when the bundle was compiled.
+func (r *resolverCache) policyPriority(policyMrn string) int {
+	if r.bundleMap == nil {
+		return 0
+	}
+	res, _ := PolicyPriority(r.bundleMap.Policies[policyMrn])
+	return res
+}
+
+// SetBy returns the policy that set the effective impact, which is the
+// policy that added the check or policy unless another one modified it
+func (p *ImpactProvenance) SetBy() string {
+	if p.ModifiedBy != "" {
+		return p.ModifiedBy
+	}
+	return p.Policy
+}
+
+// ImpactSetBy returns how the effective impact of a check or policy of the
+// asset came about in each of its parent policies, sorted by parent policy.
+// The ID is the MRN of a check or policy or the code ID of a check. See
+// ImpactProvenance.SetBy for the policy that set the impact.
+func (s *LocalServices) ImpactSetBy(ctx context.Context, assetMrn string, id string) ([]*ImpactProvenance, error) {
+	provenance, err := s.GetImpactProvenance(ctx, assetMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	if res, ok := provenance[id]; ok {
+		return res, nil
+	}
+
+	res := []*ImpactProvenance{}
+	for _, list := range provenance {
+		for i := range list {
+			if list[i].Id == id {
+				res = append(res, list[i])
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Policy < res[j].Policy
+	})
+	return res, nil
+}
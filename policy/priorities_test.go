@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestPolicyPriority(t *testing.T) {
+	priority, err := PolicyPriority(&Policy{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, priority)
+
+	priority, err = PolicyPriority(&Policy{Tags: map[string]string{PriorityTag: "-5"}})
+	require.NoError(t, err)
+	assert.Equal(t, -5, priority)
+
+	_, err = PolicyPriority(&Policy{Mrn: "//a", Tags: map[string]string{PriorityTag: "high"}})
+	assert.EqualError(t, err, "invalid priority 'high' in policy '//a', it must be an integer")
+}
+
+func TestSetChildImpactPriority(t *testing.T) {
+	setup := func() (*resolverCache, *ReportingJob, *ReportingJob, *policyResolverCache, *policyResolverCache) {
+		global, parent, child := testConflictCache()
+		global.impactProvenance = map[string]*ImpactProvenance{}
+		global.bundleMap = &PolicyBundleMap{Policies: map[string]*Policy{
+			"//deep":  {Mrn: "//deep", Tags: map[string]string{PriorityTag: "10"}},
+			"//close": {Mrn: "//close"},
+		}}
+
+		deep := &policyResolverCache{
+			policyMrn:      "//deep",
+			parentPolicies: map[string]struct{}{"//asset": {}, "//a": {}, "//deep": {}},
+			global:         global,
+		}
+		near := &policyResolverCache{
+			policyMrn:      "//close",
+			parentPolicies: map[string]struct{}{"//asset": {}, "//close": {}},
+			global:         global,
+		}
+		return global, parent, child, deep, near
+	}
+
+	for _, deepFirst := range []bool{true, false} {
+		global, parent, child, deep, near := setup()
+		near.addImpactProvenance(parent, child, "//check", false, nil, false)
+
+		if deepFirst {
+			deep.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 80})
+			near.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 20})
+		} else {
+			near.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 20})
+			deep.setChildImpact(parent, child, "//check", false, &explorer.Impact{Value: 80})
+		}
+
+		assert.Equal(t, int32(80), parent.ChildJobs["child"].Value)
+		conflicts := global.conflictList()
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, []string{"//deep"}, conflicts[0].Applied)
+		assert.Equal(t, []string{"//close"}, conflicts[0].Overridden)
+		assert.Equal(t, ConflictRulePriority, conflicts[0].Rule)
+
+		provenance := global.impactProvenance["parent\x00child"]
+		assert.Equal(t, "//deep", provenance.SetBy())
+		assert.Equal(t, int32(10), provenance.Priority)
+		assert.Equal(t, string(ConflictRulePriority), provenance.Rule)
+	}
+}
+
+func TestConflictRules(t *testing.T) {
+	a := &impactOverride{policyMrn: "//a", depth: 2}
+	b := &impactOverride{policyMrn: "//b", depth: 2}
+	c := &impactOverride{policyMrn: "//c", depth: 1}
+	d := &impactOverride{policyMrn: "//d", depth: 3, priority: 1}
+
+	assert.Equal(t, ConflictRuleMrn, a.rule(b))
+	assert.Equal(t, ConflictRuleDepth, a.rule(c))
+	assert.Equal(t, ConflictRulePriority, d.rule(c))
+	assert.True(t, d.precedes(c))
+	assert.True(t, c.precedes(a))
+	assert.True(t, a.precedes(b))
+}
+
+func TestImpactProvenanceSetBy(t *testing.T) {
+	assert.Equal(t, "//policy", (&ImpactProvenance{Policy: "//policy"}).SetBy())
+	assert.Equal(t, "//parent", (&ImpactProvenance{Policy: "//policy", ModifiedBy: "//parent"}).SetBy())
+}
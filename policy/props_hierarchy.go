@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// EntityProps are the properties that are set on one entity (org, space, asset)
+type EntityProps struct {
+	EntityMrn string
+	Props     []*explorer.Property
+}
+
+// PropOverride is a value of a property that a more specific entity overrides
+type PropOverride struct {
+	EntityMrn string
+	Mql       string
+}
+
+// EffectiveProp is the value of a property that applies to an entity,
+// together with its provenance
+type EffectiveProp struct {
+	Prop *explorer.Property
+	// EntityMrn is the entity that set this value
+	EntityMrn string
+	// Inherited is true if the value was set on a parent entity
+	Inherited bool
+	// Overridden lists the values of parent entities that this value
+	// replaces, from the most general to the most specific entity
+	Overridden []PropOverride
+}
+
+// ResolveEffectiveProps merges the properties along an entity hierarchy,
+// which is ordered from the most general entity (e.g. the org) to the most
+// specific one (e.g. the asset). Properties of an entity override the ones
+// of its parents. The result is sorted by property MRN (or UID).
+func ResolveEffectiveProps(hierarchy []EntityProps) []*EffectiveProp {
+	if len(hierarchy) == 0 {
+		return nil
+	}
+	entityMrn := hierarchy[len(hierarchy)-1].EntityMrn
+
+	idx := map[string]*EffectiveProp{}
+	for _, level := range hierarchy {
+		for _, prop := range level.Props {
+			id := elementID(prop.Mrn, prop.Uid)
+			if id == "" {
+				continue
+			}
+
+			cur := &EffectiveProp{
+				Prop:      prop,
+				EntityMrn: level.EntityMrn,
+				Inherited: level.EntityMrn != entityMrn,
+			}
+			if prev, ok := idx[id]; ok {
+				cur.Overridden = append(prev.Overridden, PropOverride{EntityMrn: prev.EntityMrn, Mql: prev.Prop.Mql})
+			}
+			idx[id] = cur
+		}
+	}
+
+	res := make([]*EffectiveProp, 0, len(idx))
+	for _, prop := range idx {
+		res = append(res, prop)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return elementID(res[i].Prop.Mrn, res[i].Prop.Uid) < elementID(res[j].Prop.Mrn, res[j].Prop.Uid)
+	})
+	return res
+}
+
+// GetEffectiveProps returns all properties that apply to an entity, including
+// the ones it inherits from its parents (see LocalServices.EntityParents),
+// and which entity set each of them
+func (s *LocalServices) GetEffectiveProps(ctx context.Context, entityMrn string) ([]*EffectiveProp, error) {
+	var mrns []string
+	if s.EntityParents != nil {
+		mrns = append(mrns, s.EntityParents(entityMrn)...)
+	}
+	mrns = append(mrns, entityMrn)
+
+	hierarchy := make([]EntityProps, 0, len(mrns))
+	for i, mrn := range mrns {
+		isEntity := i == len(mrns)-1
+		if !isEntity {
+			// parents don't need to have any properties set
+			exists, err := s.DataLake.PolicyExists(ctx, mrn)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				continue
+			}
+		}
+
+		policyObj, err := s.DataLake.GetRawPolicy(ctx, mrn)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get properties of '"+mrn+"'")
+		}
+		hierarchy = append(hierarchy, EntityProps{EntityMrn: mrn, Props: policyObj.Props})
+	}
+
+	if len(hierarchy) == 0 || hierarchy[len(hierarchy)-1].EntityMrn != entityMrn {
+		hierarchy = append(hierarchy, EntityProps{EntityMrn: entityMrn})
+	}
+	return ResolveEffectiveProps(hierarchy), nil
+}
+
+// inheritedProps returns the properties that an entity inherits from its
+// parents and doesn't set itself
+func (s *LocalServices) inheritedProps(ctx context.Context, entityMrn string) ([]*explorer.Property, error) {
+	if s.EntityParents == nil {
+		return nil, nil
+	}
+
+	effective, err := s.GetEffectiveProps(ctx, entityMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*explorer.Property
+	for i := range effective {
+		if effective[i].Inherited {
+			res = append(res, effective[i].Prop)
+		}
+	}
+	return res, nil
+}
+
+// propsChecksum identifies a set of property values, e.g. inherited ones
+func propsChecksum(props []*explorer.Property) string {
+	strs := make([]string, 0, len(props)*2)
+	for i := range props {
+		strs = append(strs, elementID(props[i].Mrn, props[i].Uid), props[i].Mql)
+	}
+	return checksumStrings(strs...)
+}
@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestResolveEffectiveProps(t *testing.T) {
+	res := ResolveEffectiveProps([]EntityProps{
+		{EntityMrn: "//org", Props: []*explorer.Property{
+			{Mrn: "//props/a", Mql: "1"},
+			{Mrn: "//props/b", Mql: "1"},
+			{Mrn: "//props/c", Mql: "1"},
+		}},
+		{EntityMrn: "//space", Props: []*explorer.Property{
+			{Mrn: "//props/b", Mql: "2"},
+			{Mrn: "//props/c", Mql: "2"},
+		}},
+		{EntityMrn: "//asset", Props: []*explorer.Property{
+			{Mrn: "//props/c", Mql: "3"},
+			{Uid: "d", Mql: "3"},
+		}},
+	})
+	require.Len(t, res, 4)
+
+	assert.Equal(t, "//props/a", res[0].Prop.Mrn)
+	assert.Equal(t, "//org", res[0].EntityMrn)
+	assert.True(t, res[0].Inherited)
+	assert.Empty(t, res[0].Overridden)
+
+	assert.Equal(t, "2", res[1].Prop.Mql)
+	assert.Equal(t, "//space", res[1].EntityMrn)
+	assert.True(t, res[1].Inherited)
+	assert.Equal(t, []PropOverride{{EntityMrn: "//org", Mql: "1"}}, res[1].Overridden)
+
+	assert.Equal(t, "3", res[2].Prop.Mql)
+	assert.Equal(t, "//asset", res[2].EntityMrn)
+	assert.False(t, res[2].Inherited)
+	assert.Equal(t, []PropOverride{
+		{EntityMrn: "//org", Mql: "1"},
+		{EntityMrn: "//space", Mql: "2"},
+	}, res[2].Overridden)
+
+	assert.Equal(t, "d", res[3].Prop.Uid)
+	assert.False(t, res[3].Inherited)
+
+	t.Run("no hierarchy", func(t *testing.T) {
+		assert.Empty(t, ResolveEffectiveProps(nil))
+	})
+}
@@ -0,0 +1,321 @@
+package policy
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// PropLimits restricts the values that a property may be set to
+type PropLimits struct {
+	// Min and Max are the range of numeric properties (inclusive)
+	Min *float64
+	Max *float64
+	// Allowed lists all values that a string property may be set to
+	Allowed []string
+}
+
+// TypedPropsReq sets properties of an entity to Go values, which are
+// checked against the types of the properties' defaults and their limits
+type TypedPropsReq struct {
+	EntityMrn string
+	// Values are indexed by property UID or MRN. Supported are bools,
+	// numbers, strings and arrays and maps of them.
+	Values map[string]any
+	// Limits are optional and indexed like Values
+	Limits map[string]PropLimits
+}
+
+// PropValueToMql turns a Go value into an MQL literal and returns its type
+func PropValueToMql(value any) (string, types.Type, error) {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v), types.Bool, nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), types.Int, nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), types.Int, nil
+	case int64:
+		return strconv.FormatInt(v, 10), types.Int, nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), types.Int, nil
+	case float32:
+		return propFloatToMql(float64(v))
+	case float64:
+		return propFloatToMql(v)
+	case string:
+		return strconv.Quote(v), types.String, nil
+	case []string:
+		list := make([]any, len(v))
+		for i := range v {
+			list[i] = v[i]
+		}
+		return PropValueToMql(list)
+	case []any:
+		items := make([]string, len(v))
+		childType := types.Any
+		for i := range v {
+			mql, typ, err := PropValueToMql(v[i])
+			if err != nil {
+				return "", types.Nil, err
+			}
+			items[i] = mql
+			if i == 0 {
+				childType = typ
+			} else if typ != childType {
+				childType = types.Any
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]", types.Array(childType), nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, len(keys))
+		childType := types.Any
+		for i, k := range keys {
+			mql, typ, err := PropValueToMql(v[k])
+			if err != nil {
+				return "", types.Nil, err
+			}
+			items[i] = strconv.Quote(k) + ": " + mql
+			if i == 0 {
+				childType = typ
+			} else if typ != childType {
+				childType = types.Any
+			}
+		}
+		return "{" + strings.Join(items, ", ") + "}", types.Map(types.String, childType), nil
+	default:
+		return "", types.Nil, errors.Errorf("unsupported property value of type %T", value)
+	}
+}
+
+func propFloatToMql(v float64) (string, types.Type, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", types.Nil, errors.New("property values must be finite numbers")
+	}
+	res := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(res, ".") {
+		// otherwise MQL would treat it as int
+		res += ".0"
+	}
+	return res, types.Float, nil
+}
+
+// propTypeMatches returns true if a value of type actual can be used for
+// a property of type expected
+func propTypeMatches(expected types.Type, actual types.Type) bool {
+	switch {
+	case expected == "" || expected == types.Any || actual == expected:
+		return true
+	case expected == types.Float && actual == types.Int:
+		return true
+	case expected.IsArray() && actual.IsArray():
+		return actual.Child() == types.Any || propTypeMatches(expected.Child(), actual.Child())
+	case expected.IsMap() && actual.IsMap():
+		return actual.Child() == types.Any || propTypeMatches(expected.Child(), actual.Child())
+	default:
+		return false
+	}
+}
+
+// checkPropLimits validates a value against the limits of its property
+func checkPropLimits(id string, value any, limits PropLimits) error {
+	if limits.Min != nil || limits.Max != nil {
+		var f float64
+		switch v := value.(type) {
+		case int:
+			f = float64(v)
+		case int32:
+			f = float64(v)
+		case int64:
+			f = float64(v)
+		case uint32:
+			f = float64(v)
+		case float32:
+			f = float64(v)
+		case float64:
+			f = v
+		default:
+			return errors.New("property '" + id + "' has a range, but its value is not a number")
+		}
+		if limits.Min != nil && f < *limits.Min {
+			return errors.New("property '" + id + "' must be at least " + strconv.FormatFloat(*limits.Min, 'f', -1, 64))
+		}
+		if limits.Max != nil && f > *limits.Max {
+			return errors.New("property '" + id + "' must be at most " + strconv.FormatFloat(*limits.Max, 'f', -1, 64))
+		}
+	}
+
+	if len(limits.Allowed) != 0 {
+		s, ok := value.(string)
+		if !ok {
+			return errors.New("property '" + id + "' has allowed values, but its value is not a string")
+		}
+		for i := range limits.Allowed {
+			if limits.Allowed[i] == s {
+				return nil
+			}
+		}
+		return errors.New("property '" + id + "' must be one of: " + strings.Join(limits.Allowed, ", "))
+	}
+	return nil
+}
+
+// propDefaultType returns the type of a property's default value
+func propDefaultType(prop *explorer.Property) (types.Type, error) {
+	if prop.Type != "" {
+		return types.Type(prop.Type), nil
+	}
+	if prop.Mql == "" {
+		return types.Any, nil
+	}
+	prop = proto.Clone(prop).(*explorer.Property)
+	if _, err := prop.RefreshChecksumAndType(); err != nil {
+		return types.Nil, err
+	}
+	return types.Type(prop.Type), nil
+}
+
+// typedProps returns all properties with the given UID or MRN that have a
+// default value and fails if the type of their default isn't compatible
+func typedProps(props []*explorer.Property, id string, typ types.Type) ([]*explorer.Property, error) {
+	var res []*explorer.Property
+	for _, prop := range props {
+		if !propMatches(prop, id) || prop.Mql == "" {
+			continue
+		}
+		expected, err := propDefaultType(prop)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compile default of property '"+id+"'")
+		}
+		if !propTypeMatches(expected, typ) {
+			return nil, errors.New("property '" + id + "' must be of type " + expected.Label() + ", but got " + typ.Label())
+		}
+		res = append(res, prop)
+	}
+	return res, nil
+}
+
+// propMatches returns true if the property has the given UID or MRN
+func propMatches(prop *explorer.Property, id string) bool {
+	return prop.Mrn == id || (prop.Uid != "" && prop.Uid == id)
+}
+
+// allProps returns all properties of the bundle, its policies and queries
+func (p *Bundle) allProps() []*explorer.Property {
+	res := append([]*explorer.Property{}, p.Props...)
+	for _, policy := range p.Policies {
+		res = append(res, policy.Props...)
+		for _, group := range policy.Groups {
+			for _, query := range group.Queries {
+				res = append(res, query.Props...)
+			}
+			for _, check := range group.Checks {
+				res = append(res, check.Props...)
+			}
+		}
+	}
+	for _, query := range p.Queries {
+		res = append(res, query.Props...)
+	}
+	return res
+}
+
+// RenderWithProps returns a copy of the bundle whose properties are set to
+// the given values instead of their defaults, e.g. to use other thresholds
+// in another environment. Values are indexed by property UID or MRN and must
+// match the type of the property's default value.
+func (p *Bundle) RenderWithProps(values map[string]any) (*Bundle, error) {
+	res := proto.Clone(p).(*Bundle)
+	props := res.allProps()
+
+	for id, value := range values {
+		mql, typ, err := PropValueToMql(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid value for property '"+id+"'")
+		}
+
+		matching, err := typedProps(props, id, typ)
+		if err != nil {
+			return nil, err
+		}
+		if len(matching) == 0 {
+			return nil, errors.New("bundle has no property '" + id + "'")
+		}
+
+		for _, prop := range matching {
+			prop.Mql = mql
+			// these are refreshed when the bundle is compiled
+			prop.Type = ""
+			prop.CodeId = ""
+			prop.Checksum = ""
+		}
+	}
+
+	return res, nil
+}
+
+// SetTypedProps sets properties of an entity like SetProps, but validates
+// the values against the types of the properties' defaults in the policies
+// that are assigned to the entity and against the given limits
+func (s *LocalServices) SetTypedProps(ctx context.Context, req *TypedPropsReq) error {
+	bundle, err := s.DataLake.GetValidatedBundle(ctx, req.EntityMrn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get policies of '"+req.EntityMrn+"'")
+	}
+	defaults := bundle.allProps()
+
+	ids := make([]string, 0, len(req.Values))
+	for id := range req.Values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	propsReq := &explorer.PropsReq{
+		EntityMrn: req.EntityMrn,
+		Props:     make([]*explorer.Property, 0, len(ids)),
+	}
+	for _, id := range ids {
+		value := req.Values[id]
+		mql, typ, err := PropValueToMql(value)
+		if err != nil {
+			return errors.Wrap(err, "invalid value for property '"+id+"'")
+		}
+		if limits, ok := req.Limits[id]; ok {
+			if err := checkPropLimits(id, value, limits); err != nil {
+				return err
+			}
+		}
+
+		matching, err := typedProps(defaults, id, typ)
+		if err != nil {
+			return err
+		}
+		if len(matching) == 0 {
+			return errors.New("no policy of '" + req.EntityMrn + "' has a property '" + id + "'")
+		}
+
+		prop := &explorer.Property{Mql: mql}
+		if strings.HasPrefix(id, "//") {
+			prop.Mrn = id
+		} else {
+			prop.Uid = id
+		}
+		propsReq.Props = append(propsReq.Props, prop)
+	}
+
+	_, err = s.SetProps(ctx, propsReq)
+	return err
+}
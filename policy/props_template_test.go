@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/types"
+)
+
+func TestPropValueToMql(t *testing.T) {
+	tests := []struct {
+		value any
+		mql   string
+		typ   types.Type
+	}{
+		{true, "true", types.Bool},
+		{42, "42", types.Int},
+		{1.5, "1.5", types.Float},
+		{float64(3), "3.0", types.Float},
+		{"a \"b\"", `"a \"b\""`, types.String},
+		{[]string{"a", "b"}, `["a", "b"]`, types.Array(types.String)},
+		{[]any{1, "a"}, `[1, "a"]`, types.Array(types.Any)},
+		{map[string]any{"b": 2, "a": 1}, `{"a": 1, "b": 2}`, types.Map(types.String, types.Int)},
+	}
+	for _, test := range tests {
+		mql, typ, err := PropValueToMql(test.value)
+		require.NoError(t, err)
+		assert.Equal(t, test.mql, mql)
+		assert.Equal(t, test.typ, typ)
+	}
+
+	_, _, err := PropValueToMql(struct{}{})
+	assert.Error(t, err)
+}
+
+func TestPropTypeMatches(t *testing.T) {
+	assert.True(t, propTypeMatches(types.Float, types.Int))
+	assert.False(t, propTypeMatches(types.Int, types.Float))
+	assert.True(t, propTypeMatches(types.Array(types.String), types.Array(types.Any)))
+	assert.False(t, propTypeMatches(types.Array(types.String), types.Array(types.Int)))
+	assert.False(t, propTypeMatches(types.String, types.Array(types.String)))
+}
+
+func TestCheckPropLimits(t *testing.T) {
+	lo, hi := 1.0, 10.0
+	limits := PropLimits{Min: &lo, Max: &hi}
+	assert.NoError(t, checkPropLimits("threshold", 5, limits))
+	assert.Error(t, checkPropLimits("threshold", 0, limits))
+	assert.Error(t, checkPropLimits("threshold", 10.5, limits))
+	assert.Error(t, checkPropLimits("threshold", "5", limits))
+
+	limits = PropLimits{Allowed: []string{"dev", "prod"}}
+	assert.NoError(t, checkPropLimits("env", "prod", limits))
+	assert.Error(t, checkPropLimits("env", "test", limits))
+}
+
+func TestBundle_RenderWithProps(t *testing.T) {
+	bundle := &Bundle{
+		Props: []*explorer.Property{{Uid: "maxDays", Mql: "90", Type: string(types.Int)}},
+		Policies: []*Policy{{
+			Uid:   "policy",
+			Props: []*explorer.Property{{Uid: "owner", Mql: `"ops"`, Type: string(types.String)}},
+		}},
+	}
+
+	res, err := bundle.RenderWithProps(map[string]any{"maxDays": 30, "owner": "sec"})
+	require.NoError(t, err)
+	assert.Equal(t, "30", res.Props[0].Mql)
+	assert.Empty(t, res.Props[0].Type)
+	assert.Equal(t, `"sec"`, res.Policies[0].Props[0].Mql)
+	// the original bundle is unchanged
+	assert.Equal(t, "90", bundle.Props[0].Mql)
+
+	_, err = bundle.RenderWithProps(map[string]any{"maxDays": "30"})
+	assert.Error(t, err)
+	_, err = bundle.RenderWithProps(map[string]any{"missing": 1})
+	assert.Error(t, err)
+}
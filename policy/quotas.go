@@ -0,0 +1,261 @@
+package policy
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// QuotaResource is a resource whose usage by a space is limited, see Quota
+type QuotaResource string
+
+const (
+	QuotaScans       QuotaResource = "scans"
+	QuotaDatapoints  QuotaResource = "datapoints"
+	QuotaUploadBytes QuotaResource = "upload_bytes"
+)
+
+// Quota limits the daily usage of a space. Zero values are unlimited.
+type Quota struct {
+	// ScansPerDay limits how often assets of the space are scanned
+	ScansPerDay int64 `json:"scans_per_day,omitempty"`
+	// DatapointsPerDay limits how many datapoints are stored for assets of
+	// the space
+	DatapointsPerDay int64 `json:"datapoints_per_day,omitempty"`
+	// UploadBytesPerDay limits the size of the results that are stored for
+	// assets of the space
+	UploadBytesPerDay int64 `json:"upload_bytes_per_day,omitempty"`
+}
+
+// limit returns the limit of the resource, 0 if it is unlimited
+func (q *Quota) limit(resource QuotaResource) int64 {
+	if q == nil {
+		return 0
+	}
+	switch resource {
+	case QuotaScans:
+		return q.ScansPerDay
+	case QuotaDatapoints:
+		return q.DatapointsPerDay
+	case QuotaUploadBytes:
+		return q.UploadBytesPerDay
+	default:
+		return 0
+	}
+}
+
+// SpaceUsage is the usage of a space on one day (UTC)
+type SpaceUsage struct {
+	Scans       int64 `json:"scans"`
+	Datapoints  int64 `json:"datapoints"`
+	UploadBytes int64 `json:"upload_bytes"`
+}
+
+// get returns the usage of the resource
+func (u *SpaceUsage) get(resource QuotaResource) int64 {
+	switch resource {
+	case QuotaScans:
+		return u.Scans
+	case QuotaDatapoints:
+		return u.Datapoints
+	case QuotaUploadBytes:
+		return u.UploadBytes
+	default:
+		return 0
+	}
+}
+
+// UsageStore is implemented by datalakes that track the usage of spaces and
+// their quotas. Usage is counted per day, which is the start of a day in UTC.
+type UsageStore interface {
+	// SetQuota sets the quota of a space, nil removes it
+	SetQuota(ctx context.Context, spaceMrn string, quota *Quota) error
+	// GetQuota returns the quota of a space, nil if it has none
+	GetQuota(ctx context.Context, spaceMrn string) (*Quota, error)
+	// AddUsage adds to the usage of a space on the given day and returns
+	// the total usage of that day
+	AddUsage(ctx context.Context, spaceMrn string, day time.Time, usage SpaceUsage) (*SpaceUsage, error)
+	// GetUsage returns the usage of a space on the given day
+	GetUsage(ctx context.Context, spaceMrn string, day time.Time) (*SpaceUsage, error)
+}
+
+// ErrQuotaExceeded is the class of errors for spaces that exceeded one of
+// their quotas, use errors.Is to check for it
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaExceededError is returned if a request would exceed a quota of the
+// space of its asset
+type QuotaExceededError struct {
+	SpaceMrn string
+	Resource QuotaResource
+	Limit    int64
+	// Used is the usage of the resource on the day, including the request
+	Used int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return "quota exceeded: space " + e.SpaceMrn + " used " + strconv.FormatInt(e.Used, 10) +
+		" " + string(e.Resource) + " of " + strconv.FormatInt(e.Limit, 10) + " per day"
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// GRPCStatus reports exceeded quotas as exhausted resources to clients
+func (e *QuotaExceededError) GRPCStatus() *status.Status {
+	return status.New(codes.ResourceExhausted, e.Error())
+}
+
+// QuotaEnforcer counts the usage of spaces in the datalake, which must be a
+// UsageStore, and checks it against their quotas. Assets are attributed to
+// the space among their parents, see LocalServices.EntityParents.
+type QuotaEnforcer struct {
+	// OnExceeded is optional. It is called for every request that exceeds a
+	// quota and decides if the request is rejected with the returned error
+	// or allowed anyway, if it returns nil. By default, requests that exceed
+	// a quota are rejected.
+	OnExceeded func(ctx context.Context, err *QuotaExceededError) error
+
+	nowProvider func() time.Time
+}
+
+// NewQuotaEnforcer creates an enforcer that rejects all requests which
+// exceed a quota
+func NewQuotaEnforcer() *QuotaEnforcer {
+	return &QuotaEnforcer{nowProvider: time.Now}
+}
+
+// today returns the start of the current day in UTC
+func (q *QuotaEnforcer) today() time.Time {
+	now := time.Now
+	if q.nowProvider != nil {
+		now = q.nowProvider
+	}
+	return usageDay(now())
+}
+
+func usageDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// usageStore returns the datalake's usage store if quotas are enforced
+func (s *LocalServices) usageStore() (UsageStore, bool) {
+	if s.Quotas == nil {
+		return nil, false
+	}
+	store, ok := s.DataLake.(UsageStore)
+	return store, ok
+}
+
+// consumeQuota checks that the usage of the asset's space stays within its
+// quota and counts it. Usage that is rejected isn't counted.
+func (s *LocalServices) consumeQuota(ctx context.Context, assetMrn string, usage SpaceUsage) error {
+	store, ok := s.usageStore()
+	if !ok {
+		return nil
+	}
+	spaceMrn := s.assetSpace(assetMrn)
+	if spaceMrn == "" {
+		return nil
+	}
+
+	day := s.Quotas.today()
+	quota, err := store.GetQuota(ctx, spaceMrn)
+	if err != nil {
+		return err
+	}
+	if quota != nil {
+		used, err := store.GetUsage(ctx, spaceMrn, day)
+		if err != nil {
+			return err
+		}
+		for _, resource := range []QuotaResource{QuotaScans, QuotaDatapoints, QuotaUploadBytes} {
+			limit := quota.limit(resource)
+			add := usage.get(resource)
+			if limit == 0 || add == 0 || used.get(resource)+add <= limit {
+				continue
+			}
+
+			exceeded := &QuotaExceededError{
+				SpaceMrn: spaceMrn,
+				Resource: resource,
+				Limit:    limit,
+				Used:     used.get(resource) + add,
+			}
+			if s.Quotas.OnExceeded == nil {
+				return exceeded
+			}
+			if err := s.Quotas.OnExceeded(ctx, exceeded); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = store.AddUsage(ctx, spaceMrn, day, usage)
+	return err
+}
+
+// consumeResultsQuota counts the datapoints and size of stored results
+func (s *LocalServices) consumeResultsQuota(ctx context.Context, req *StoreResultsReq) error {
+	if s.Quotas == nil {
+		return nil
+	}
+	return s.consumeQuota(ctx, req.AssetMrn, SpaceUsage{
+		Datapoints:  int64(len(req.Data)),
+		UploadBytes: int64(proto.Size(req)),
+	})
+}
+
+// SpaceUsageReport is the usage of a space on one day and its quota
+type SpaceUsageReport struct {
+	SpaceMrn string      `json:"space_mrn"`
+	Day      time.Time   `json:"day"`
+	Usage    *SpaceUsage `json:"usage"`
+	// Quota is nil if the space has no quota
+	Quota *Quota `json:"quota,omitempty"`
+}
+
+// SetSpaceQuota sets the quota of a space, nil removes it. It fails if the
+// datalake doesn't track usage.
+func (s *LocalServices) SetSpaceQuota(ctx context.Context, spaceMrn string, quota *Quota) error {
+	store, ok := s.DataLake.(UsageStore)
+	if !ok {
+		return errors.New("the datalake doesn't support quotas")
+	}
+	return store.SetQuota(ctx, spaceMrn, quota)
+}
+
+// GetSpaceUsage reports the usage of a space on every day in the given
+// range (UTC, both inclusive), together with its quota
+func (s *LocalServices) GetSpaceUsage(ctx context.Context, spaceMrn string, from time.Time, to time.Time) ([]*SpaceUsageReport, error) {
+	store, ok := s.DataLake.(UsageStore)
+	if !ok {
+		return nil, errors.New("the datalake doesn't support quotas")
+	}
+
+	quota, err := store.GetQuota(ctx, spaceMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*SpaceUsageReport
+	for day := usageDay(from); !day.After(usageDay(to)); day = day.Add(24 * time.Hour) {
+		usage, err := store.GetUsage(ctx, spaceMrn, day)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &SpaceUsageReport{
+			SpaceMrn: spaceMrn,
+			Day:      day,
+			Usage:    usage,
+			Quota:    quota,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type usageDataLake struct {
+	DataLake
+	quotas map[string]*Quota
+	usage  map[string]SpaceUsage
+}
+
+func newUsageDataLake() *usageDataLake {
+	return &usageDataLake{quotas: map[string]*Quota{}, usage: map[string]SpaceUsage{}}
+}
+
+func (d *usageDataLake) SetQuota(ctx context.Context, spaceMrn string, quota *Quota) error {
+	d.quotas[spaceMrn] = quota
+	return nil
+}
+
+func (d *usageDataLake) GetQuota(ctx context.Context, spaceMrn string) (*Quota, error) {
+	return d.quotas[spaceMrn], nil
+}
+
+func (d *usageDataLake) AddUsage(ctx context.Context, spaceMrn string, day time.Time, usage SpaceUsage) (*SpaceUsage, error) {
+	key := spaceMrn + day.String()
+	res := d.usage[key]
+	res.Scans += usage.Scans
+	res.Datapoints += usage.Datapoints
+	res.UploadBytes += usage.UploadBytes
+	d.usage[key] = res
+	return &res, nil
+}
+
+func (d *usageDataLake) GetUsage(ctx context.Context, spaceMrn string, day time.Time) (*SpaceUsage, error) {
+	res := d.usage[spaceMrn+day.String()]
+	return &res, nil
+}
+
+func TestConsumeQuota(t *testing.T) {
+	ctx := context.Background()
+	space := "//captain.api.mondoo.app/spaces/team-a"
+	now := time.Date(2023, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	setup := func() (*LocalServices, *usageDataLake) {
+		db := newUsageDataLake()
+		s := NewLocalServices(db, "")
+		s.EntityParents = func(entityMrn string) []string {
+			if entityMrn == "//assets/other" {
+				return nil
+			}
+			return []string{space}
+		}
+		s.Quotas = NewQuotaEnforcer()
+		s.Quotas.nowProvider = func() time.Time { return now }
+		return s, db
+	}
+
+	t.Run("usage is tracked without quota", func(t *testing.T) {
+		s, _ := setup()
+		require.NoError(t, s.consumeQuota(ctx, "//assets/a", SpaceUsage{Scans: 1}))
+		require.NoError(t, s.consumeQuota(ctx, "//assets/a", SpaceUsage{Datapoints: 3, UploadBytes: 100}))
+		require.NoError(t, s.consumeQuota(ctx, "//assets/other", SpaceUsage{Scans: 1}))
+
+		reports, err := s.GetSpaceUsage(ctx, space, now.Add(-24*time.Hour), now)
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, &SpaceUsage{}, reports[0].Usage)
+		assert.Equal(t, time.Date(2023, 5, 6, 0, 0, 0, 0, time.UTC), reports[1].Day)
+		assert.Equal(t, &SpaceUsage{Scans: 1, Datapoints: 3, UploadBytes: 100}, reports[1].Usage)
+		assert.Nil(t, reports[1].Quota)
+	})
+
+	t.Run("exceeded quotas are rejected", func(t *testing.T) {
+		s, _ := setup()
+		require.NoError(t, s.SetSpaceQuota(ctx, space, &Quota{ScansPerDay: 2}))
+		require.NoError(t, s.consumeQuota(ctx, "//assets/a", SpaceUsage{Scans: 1}))
+		require.NoError(t, s.consumeQuota(ctx, "//assets/b", SpaceUsage{Scans: 1}))
+
+		err := s.consumeQuota(ctx, "//assets/a", SpaceUsage{Scans: 1})
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		var exceeded *QuotaExceededError
+		require.True(t, errors.As(err, &exceeded))
+		assert.Equal(t, &QuotaExceededError{SpaceMrn: space, Resource: QuotaScans, Limit: 2, Used: 3}, exceeded)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		// rejected usage isn't counted, other resources are unlimited
+		require.NoError(t, s.consumeQuota(ctx, "//assets/a", SpaceUsage{Datapoints: 1000}))
+		reports, err := s.GetSpaceUsage(ctx, space, now, now)
+		require.NoError(t, err)
+		assert.Equal(t, &SpaceUsage{Scans: 2, Datapoints: 1000}, reports[0].Usage)
+	})
+
+	t.Run("hooks can allow exceeded quotas", func(t *testing.T) {
+		s, _ := setup()
+		var hooked []*QuotaExceededError
+		s.Quotas.OnExceeded = func(ctx context.Context, err *QuotaExceededError) error {
+			hooked = append(hooked, err)
+			return nil
+		}
+		require.NoError(t, s.SetSpaceQuota(ctx, space, &Quota{DatapointsPerDay: 10}))
+		require.NoError(t, s.consumeQuota(ctx, "//assets/a", SpaceUsage{Datapoints: 11}))
+		require.Len(t, hooked, 1)
+		assert.Equal(t, QuotaDatapoints, hooked[0].Resource)
+	})
+}
@@ -0,0 +1,137 @@
+package policy
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// RefKind is the kind of an external reference of a check
+type RefKind string
+
+const (
+	// RefCVE is a vulnerability in the CVE list
+	RefCVE RefKind = "cve"
+	// RefKB is a vendor knowledge base article or documentation
+	RefKB RefKind = "kb"
+	// RefRunbook is an internal runbook, see RefClassifier.RunbookHosts
+	RefRunbook RefKind = "runbook"
+	// RefOther is any other link
+	RefOther RefKind = "other"
+)
+
+// CheckRef is an external reference of a check with its kind
+type CheckRef struct {
+	Kind  RefKind `json:"kind"`
+	Title string  `json:"title,omitempty"`
+	Url   string  `json:"url"`
+	// ID identifies the referenced entry, e.g. the CVE ID or KB number
+	ID string `json:"id,omitempty"`
+}
+
+var (
+	cveIDPattern = regexp.MustCompile(`(?i)\bCVE-\d{4}-\d{4,}\b`)
+	kbIDPattern  = regexp.MustCompile(`(?i)\bKB\d{5,}\b`)
+)
+
+// kbHosts are the hosts of vendor knowledge bases and documentation
+var kbHosts = []string{
+	"support.microsoft.com",
+	"learn.microsoft.com",
+	"docs.microsoft.com",
+	"access.redhat.com",
+	"docs.aws.amazon.com",
+	"cloud.google.com",
+	"kubernetes.io",
+	"ubuntu.com",
+	"docs.docker.com",
+	"www.cisecurity.org",
+}
+
+// cveHosts are the hosts of CVE databases
+var cveHosts = []string{
+	"nvd.nist.gov",
+	"cve.mitre.org",
+	"www.cve.org",
+}
+
+// RefClassifier determines the kind of check references
+type RefClassifier struct {
+	// RunbookHosts are the hosts of internal runbooks, e.g. a wiki. Their
+	// subdomains are included.
+	RunbookHosts []string
+}
+
+// DefaultRefClassifier classifies references without any internal hosts
+var DefaultRefClassifier = RefClassifier{}
+
+func hostMatches(host string, hosts []string) bool {
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstMatch returns the first match of the pattern in any of the strings
+func firstMatch(pattern *regexp.Regexp, strs ...string) string {
+	for _, s := range strs {
+		if m := pattern.FindString(s); m != "" {
+			return strings.ToUpper(m)
+		}
+	}
+	return ""
+}
+
+// Classify returns the reference with its kind
+func (c RefClassifier) Classify(ref *explorer.MqueryRef) CheckRef {
+	res := CheckRef{Kind: RefOther, Title: ref.Title, Url: ref.Url}
+
+	var host string
+	if u, err := url.Parse(ref.Url); err == nil {
+		host = strings.ToLower(u.Hostname())
+	}
+
+	// hosts are more specific than IDs, e.g. a vendor advisory for a CVE
+	// links to the vendor's fix
+	switch {
+	case hostMatches(host, c.RunbookHosts):
+		res.Kind = RefRunbook
+	case hostMatches(host, cveHosts):
+		res.Kind = RefCVE
+	case hostMatches(host, kbHosts) || strings.HasPrefix(host, "kb.") || strings.HasPrefix(host, "docs."):
+		res.Kind = RefKB
+	case cveIDPattern.MatchString(ref.Url) || cveIDPattern.MatchString(ref.Title):
+		res.Kind = RefCVE
+	case kbIDPattern.MatchString(ref.Title):
+		res.Kind = RefKB
+	}
+
+	switch res.Kind {
+	case RefCVE:
+		res.ID = firstMatch(cveIDPattern, ref.Url, ref.Title)
+	case RefKB:
+		res.ID = firstMatch(kbIDPattern, ref.Title, ref.Url)
+	}
+	return res
+}
+
+// CheckRefs returns the references of a query with their kinds, skipping
+// references without a URL
+func (c RefClassifier) CheckRefs(query *explorer.Mquery) []CheckRef {
+	if query == nil || len(query.Refs) == 0 {
+		return nil
+	}
+	res := make([]CheckRef, 0, len(query.Refs))
+	for _, ref := range query.Refs {
+		if ref == nil || ref.Url == "" {
+			continue
+		}
+		res = append(res, c.Classify(ref))
+	}
+	return res
+}
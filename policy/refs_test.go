@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestRefClassifier(t *testing.T) {
+	c := RefClassifier{RunbookHosts: []string{"wiki.example.com"}}
+	query := &explorer.Mquery{Refs: []*explorer.MqueryRef{
+		{Title: "Log4Shell", Url: "https://nvd.nist.gov/vuln/detail/cve-2021-44228"},
+		{Title: "KB5005413", Url: "https://support.microsoft.com/help/5005413"},
+		{Title: "Runbook", Url: "https://team.wiki.example.com/runbooks/log4j"},
+		{Title: "Blog", Url: "https://example.org/log4j"},
+		{Title: "No URL"},
+	}}
+
+	assert.Equal(t, []CheckRef{
+		{Kind: RefCVE, Title: "Log4Shell", Url: "https://nvd.nist.gov/vuln/detail/cve-2021-44228", ID: "CVE-2021-44228"},
+		{Kind: RefKB, Title: "KB5005413", Url: "https://support.microsoft.com/help/5005413", ID: "KB5005413"},
+		{Kind: RefRunbook, Title: "Runbook", Url: "https://team.wiki.example.com/runbooks/log4j"},
+		{Kind: RefOther, Title: "Blog", Url: "https://example.org/log4j"},
+	}, c.CheckRefs(query))
+
+	// runbooks are only known with their hosts
+	assert.Equal(t, RefOther, DefaultRefClassifier.Classify(query.Refs[2]).Kind)
+	assert.Empty(t, DefaultRefClassifier.CheckRefs(&explorer.Mquery{}))
+}
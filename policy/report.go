@@ -1,8 +1,15 @@
 package policy
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"hash"
+	"sort"
 
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"go.mondoo.com/cnquery/llx"
 )
@@ -19,6 +26,72 @@ func (r *Report) RawResults() map[string]*llx.RawResult {
 	return results
 }
 
+// ReportIDPrefix is the prefix of all content IDs of reports
+const ReportIDPrefix = "report-sha256:"
+
+func hashString(h hash.Hash, s string) {
+	var n [8]byte
+	binary.LittleEndian.PutUint64(n[:], uint64(len(s)))
+	h.Write(n[:])
+	h.Write([]byte(s))
+}
+
+func hashUint(h hash.Hash, v uint64) {
+	var n [8]byte
+	binary.LittleEndian.PutUint64(n[:], v)
+	h.Write(n[:])
+}
+
+// ContentID identifies the report by its asset, the checksum of the
+// resolved policy it was created with (its GraphExecutionChecksum) and its
+// scores. Timestamps are ignored, so that repeated scans with the same
+// results get the same ID.
+func (r *Report) ContentID(resolvedPolicyChecksum string) string {
+	h := sha256.New()
+	hashString(h, r.EntityMrn)
+	hashString(h, r.ScoringMrn)
+	hashString(h, resolvedPolicyChecksum)
+
+	ids := make([]string, 0, len(r.Scores))
+	for id := range r.Scores {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	hashUint(h, uint64(len(ids)))
+	for _, id := range ids {
+		score := r.Scores[id]
+		hashString(h, id)
+		hashUint(h, uint64(score.GetType()))
+		hashUint(h, uint64(score.GetValue()))
+		hashUint(h, uint64(score.GetWeight()))
+		hashUint(h, uint64(score.GetScoreCompletion()))
+		hashUint(h, uint64(score.GetDataCompletion()))
+		hashUint(h, uint64(score.GetDataTotal()))
+		hashString(h, score.GetMessage())
+	}
+
+	return ReportIDPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// StoreReport stores the current report of an asset under its content ID
+// and returns the ID. Storing the same results again doesn't create a new
+// report, which is indicated by created being false.
+func (s *LocalServices) StoreReport(ctx context.Context, assetMrn string) (id string, created bool, err error) {
+	report, err := s.DataLake.GetReport(ctx, assetMrn, assetMrn)
+	if err != nil {
+		return "", false, err
+	}
+	resolvedPolicy, err := s.DataLake.GetResolvedPolicy(ctx, assetMrn)
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to get resolved policy for report of '"+assetMrn+"'")
+	}
+
+	id = report.ContentID(resolvedPolicy.GetGraphExecutionChecksum())
+	created, err = s.DataLake.StoreReport(ctx, id, report)
+	return id, created, err
+}
+
 // Stats computes the stats for this report
 func (r *Report) ComputeStats(resolved *ResolvedPolicy) {
 	res := Stats{
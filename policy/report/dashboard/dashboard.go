@@ -0,0 +1,168 @@
+// Package dashboard serves a minimal, read-only web dashboard of the assets
+// and reports in a datalake, so that local scans can be browsed without the
+// full platform. Reports of single assets are rendered by the html package.
+package dashboard
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/cnspec/policy/report/html"
+	"go.mondoo.com/cnspec/policy/scan"
+)
+
+//go:embed assets.html
+var assetsTemplate string
+
+var tmpl = template.Must(template.New("assets").Parse(assetsTemplate))
+
+// DataLake is a datalake that can list the reports of all its assets
+type DataLake interface {
+	policy.DataLake
+	policy.ReportStreamer
+}
+
+type scoreView struct {
+	Value  uint32
+	Letter string
+	// Class is the CSS class for the rating, e.g. "low" or "critical"
+	Class string
+}
+
+type assetView struct {
+	Mrn     string
+	Link    string
+	Score   scoreView
+	Passed  uint32
+	Failed  uint32
+	Errors  uint32
+	Skipped uint32
+}
+
+type assetsView struct {
+	Generated string
+	Assets    []*assetView
+}
+
+type handler struct {
+	db  DataLake
+	mux *http.ServeMux
+}
+
+// NewHandler serves the dashboard of the datalake: the list of all scored
+// assets at `/` and the report of one asset at `/asset?mrn=<asset mrn>`.
+// Only GET requests are supported, nothing in the datalake is changed.
+func NewHandler(db DataLake) http.Handler {
+	h := &handler{db: db, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/", h.serveAssets)
+	h.mux.HandleFunc("/asset", h.serveAsset)
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func newScoreView(score *policy.Score) scoreView {
+	if score == nil {
+		return scoreView{Letter: "U", Class: "unrated"}
+	}
+	rating := score.Rating()
+	return scoreView{
+		Value:  score.Value,
+		Letter: rating.Letter(),
+		Class:  rating.FailureLabel(),
+	}
+}
+
+func newAssetView(report *policy.Report) *assetView {
+	res := &assetView{
+		Mrn:   report.EntityMrn,
+		Link:  "asset?mrn=" + url.QueryEscape(report.EntityMrn),
+		Score: newScoreView(report.Score),
+	}
+	if stats := report.Stats; stats != nil {
+		res.Passed = stats.GetPassed().GetTotal()
+		res.Failed = stats.GetFailed().GetTotal()
+		res.Errors = stats.GetErrors().GetTotal()
+		res.Skipped = stats.Skipped
+	}
+	return res
+}
+
+func (h *handler) serveAssets(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	view := &assetsView{Generated: time.Now().UTC().Format(time.RFC1123)}
+	err := h.db.StreamReports(r.Context(), policy.ReportStreamOptions{}, func(report *policy.Report) error {
+		view.Assets = append(view.Assets, newAssetView(report))
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("dashboard> failed to list assets")
+		http.Error(w, "failed to list assets", http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, view); err != nil {
+		log.Error().Err(err).Msg("dashboard> failed to render assets")
+		http.Error(w, "failed to render assets", http.StatusInternalServerError)
+		return
+	}
+	writeHTML(w, buf.Bytes())
+}
+
+func (h *handler) serveAsset(w http.ResponseWriter, r *http.Request) {
+	mrn := r.URL.Query().Get("mrn")
+	if mrn == "" {
+		http.Error(w, "an asset mrn is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	report, err := h.db.GetReport(ctx, mrn, mrn)
+	if err != nil || report == nil || report.Score == nil {
+		http.NotFound(w, r)
+		return
+	}
+	assetReport := &scan.AssetReport{Mrn: mrn, Report: report}
+
+	// without policies, the report still shows the asset's score
+	if assetReport.ResolvedPolicy, err = h.db.GetResolvedPolicy(ctx, mrn); err != nil {
+		log.Debug().Err(err).Str("asset", mrn).Msg("dashboard> no resolved policy for asset")
+	}
+	if assetReport.Bundle, err = h.db.GetValidatedBundle(ctx, mrn); err != nil {
+		log.Debug().Err(err).Str("asset", mrn).Msg("dashboard> no bundle for asset")
+	}
+
+	// pages are rendered completely before they are sent, so that errors
+	// can still be reported with their status
+	var buf bytes.Buffer
+	if err := html.Render(&buf, nil, assetReport); err != nil {
+		log.Error().Err(err).Str("asset", mrn).Msg("dashboard> failed to render report")
+		http.Error(w, "failed to render report", http.StatusInternalServerError)
+		return
+	}
+	writeHTML(w, buf.Bytes())
+}
+
+func writeHTML(w http.ResponseWriter, page []byte) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write(page); err != nil {
+		log.Debug().Err(err).Msg("dashboard> failed to send page")
+	}
+}
@@ -0,0 +1,93 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// testDataLake only implements the calls of the dashboard
+type testDataLake struct {
+	policy.DataLake
+	reports []*policy.Report
+}
+
+func (db *testDataLake) StreamReports(ctx context.Context, opts policy.ReportStreamOptions, f func(report *policy.Report) error) error {
+	for _, report := range db.reports {
+		if err := f(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *testDataLake) GetReport(ctx context.Context, assetMrn string, qrID string) (*policy.Report, error) {
+	for _, report := range db.reports {
+		if report.EntityMrn == assetMrn {
+			return report, nil
+		}
+	}
+	return nil, errors.New("asset not found")
+}
+
+func (db *testDataLake) GetResolvedPolicy(ctx context.Context, assetMrn string) (*policy.ResolvedPolicy, error) {
+	return nil, errors.New("no resolved policy")
+}
+
+func (db *testDataLake) GetValidatedBundle(ctx context.Context, mrn string) (*policy.Bundle, error) {
+	return nil, errors.New("no bundle")
+}
+
+func get(t *testing.T, server *httptest.Server, path string) (int, string) {
+	res, err := http.Get(server.URL + path)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res.StatusCode, string(body)
+}
+
+func TestDashboard(t *testing.T) {
+	db := &testDataLake{reports: []*policy.Report{
+		{
+			EntityMrn: "//assets/<web>",
+			Score:     &policy.Score{Type: policy.ScoreType_Result, Value: 80, ScoreCompletion: 100, DataCompletion: 100},
+			Stats: &policy.Stats{
+				Total:  3,
+				Passed: &policy.ScoreDistribution{Total: 2},
+				Failed: &policy.ScoreDistribution{Total: 1},
+			},
+		},
+	}}
+	server := httptest.NewServer(NewHandler(db))
+	defer server.Close()
+
+	status, body := get(t, server, "/")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "1 scored assets")
+	assert.Contains(t, body, `href="asset?mrn=%2F%2Fassets%2F%3Cweb%3E"`)
+	assert.Contains(t, body, "//assets/&lt;web&gt;")
+
+	status, body = get(t, server, "/asset?mrn=%2F%2Fassets%2F%3Cweb%3E")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "//assets/&lt;web&gt;")
+
+	status, _ = get(t, server, "/asset?mrn=%2F%2Fassets%2Fother")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = get(t, server, "/asset")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = get(t, server, "/other")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	res, err := http.Post(server.URL+"/", "text/plain", nil)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
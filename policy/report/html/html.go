@@ -0,0 +1,324 @@
+// Package html renders asset reports as standalone HTML pages. Styles are
+// inlined and no external resources are referenced, so rendered pages can be
+// emailed or archived as a single file.
+package html
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	cr "go.mondoo.com/cnquery/cli/reporter"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/shared"
+	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/cnspec/policy/scan"
+)
+
+//go:embed report.html
+var reportTemplate string
+
+var tmpl = template.Must(template.New("report").Parse(reportTemplate))
+
+type scoreView struct {
+	Value  uint32
+	Letter string
+	// Class is the CSS class for the rating, e.g. "low" or "critical"
+	Class string
+}
+
+type checkView struct {
+	Mrn         string
+	Title       string
+	Query       string
+	Status      string
+	Message     string
+	Impact      int32
+	Remediation []string
+	Refs        []policy.CheckRef
+	Score       scoreView
+	Band        policy.SeverityBand
+}
+
+type policyView struct {
+	Mrn      string
+	Title    string
+	Score    scoreView
+	Policies []*policyView
+	Checks   []*checkView
+}
+
+type dataView struct {
+	Mrn   string
+	Title string
+	Query string
+	Value string
+}
+
+type reportView struct {
+	AssetName string
+	AssetMrn  string
+	Platform  string
+	// Cloud is the provider, account and region of cloud assets
+	Cloud       string
+	Criticality string
+	Generated   string
+	Score       scoreView
+	Stats       *policy.Stats
+	Policies    []*policyView
+	Failed      []*checkView
+	Data        []*dataView
+}
+
+// Render writes the report of an asset as a standalone HTML page. The asset
+// is optional and only used for its name, platform, cloud context and
+// criticality.
+func Render(out io.Writer, assetObj *asset.Asset, report *scan.AssetReport) error {
+	view, err := newReportView(assetObj, report, time.Now())
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(out, view)
+}
+
+func newScoreView(score *policy.Score) scoreView {
+	if score == nil {
+		return scoreView{Letter: "U", Class: "unrated"}
+	}
+	rating := score.Rating()
+	return scoreView{
+		Value:  score.Value,
+		Letter: rating.Letter(),
+		Class:  rating.FailureLabel(),
+	}
+}
+
+func newReportView(assetObj *asset.Asset, report *scan.AssetReport, now time.Time) (*reportView, error) {
+	if report == nil || report.Report == nil {
+		return nil, errors.New("cannot render empty report")
+	}
+
+	res := &reportView{
+		AssetMrn:  report.Mrn,
+		Generated: now.UTC().Format(time.RFC1123),
+		Score:     newScoreView(report.Report.Score),
+		Stats:     report.Report.Stats,
+	}
+	if assetObj != nil {
+		res.AssetName = assetObj.Name
+		if assetObj.Platform != nil {
+			res.Platform = assetObj.Platform.Title
+		}
+		if cloud := policy.CloudContextFromAsset(assetObj); cloud != nil {
+			res.Cloud = strings.Join(nonEmpty(cloud.Provider, cloud.AccountId, cloud.Region), " / ")
+		}
+		res.Criticality = string(policy.AssetCriticalityFromAsset(assetObj))
+	}
+	if res.AssetName == "" {
+		res.AssetName = report.Mrn
+	}
+
+	if report.Bundle == nil || report.ResolvedPolicy == nil ||
+		report.ResolvedPolicy.CollectorJob == nil || report.ResolvedPolicy.ExecutionJob == nil {
+		return res, nil
+	}
+
+	bands := report.Bundle.SeverityBands()
+	bundle := report.Bundle.ToMap()
+	checks := map[string]*checkView{}
+	for _, query := range bundle.Queries {
+		score, ok := report.Report.Scores[query.CodeId]
+		if !ok {
+			continue
+		}
+		if _, ok := report.ResolvedPolicy.CollectorJob.ReportingQueries[query.CodeId]; !ok {
+			continue
+		}
+		check := newCheckView(query, score)
+		check.Band = bands.Band(score)
+		checks[query.Mrn] = check
+		if check.Status == "fail" || check.Status == "error" {
+			res.Failed = append(res.Failed, check)
+		}
+	}
+	sort.Slice(res.Failed, func(i, j int) bool {
+		if res.Failed[i].Impact == res.Failed[j].Impact {
+			return res.Failed[i].Title < res.Failed[j].Title
+		}
+		return res.Failed[i].Impact > res.Failed[j].Impact
+	})
+
+	res.Policies = policyTree(bundle, report.Report, checks)
+
+	data, err := dataViews(report, bundle)
+	if err != nil {
+		return nil, err
+	}
+	res.Data = data
+
+	return res, nil
+}
+
+func newCheckView(query *explorer.Mquery, score *policy.Score) *checkView {
+	res := &checkView{
+		Mrn:   query.Mrn,
+		Title: query.Title,
+		Query: query.Mql,
+		Score: newScoreView(score),
+	}
+	if res.Title == "" {
+		res.Title = query.Mrn
+	}
+	if query.Impact != nil {
+		res.Impact = query.Impact.Value
+	}
+	if query.Docs != nil && query.Docs.Remediation != nil {
+		for _, item := range query.Docs.Remediation.Items {
+			if desc := strings.TrimSpace(item.Desc); desc != "" {
+				res.Remediation = append(res.Remediation, desc)
+			}
+		}
+	}
+	res.Refs = policy.DefaultRefClassifier.CheckRefs(query)
+
+	switch score.Type {
+	case policy.ScoreType_Error:
+		res.Status = "error"
+		res.Message = score.MessageLine()
+	case policy.ScoreType_Skip:
+		res.Status = "skip"
+	case policy.ScoreType_Result:
+		switch {
+		case score.Informational:
+			res.Status = "info"
+		case score.Value == 100:
+			res.Status = "pass"
+		default:
+			res.Status = "fail"
+		}
+	default:
+		res.Status = "unknown"
+	}
+	return res
+}
+
+// policyTree builds the tree of all scored policies, starting with those
+// that are not referenced by any other policy
+func policyTree(bundle *policy.PolicyBundleMap, report *policy.Report, checks map[string]*checkView) []*policyView {
+	referenced := map[string]struct{}{}
+	for _, p := range bundle.Policies {
+		for _, group := range p.Groups {
+			for _, ref := range group.Policies {
+				referenced[ref.Mrn] = struct{}{}
+			}
+		}
+	}
+
+	mrns := make([]string, 0, len(bundle.Policies))
+	for mrn := range bundle.Policies {
+		if _, ok := referenced[mrn]; !ok {
+			mrns = append(mrns, mrn)
+		}
+	}
+	sort.Strings(mrns)
+
+	res := []*policyView{}
+	for _, mrn := range mrns {
+		if view := newPolicyView(bundle, report, checks, mrn, map[string]struct{}{}); view != nil {
+			res = append(res, view)
+		}
+	}
+	return res
+}
+
+func newPolicyView(bundle *policy.PolicyBundleMap, report *policy.Report, checks map[string]*checkView, mrn string, visited map[string]struct{}) *policyView {
+	p, ok := bundle.Policies[mrn]
+	if !ok {
+		return nil
+	}
+	score, ok := report.Scores[mrn]
+	if !ok {
+		return nil
+	}
+	if _, ok := visited[mrn]; ok {
+		return nil
+	}
+	visited[mrn] = struct{}{}
+
+	res := &policyView{
+		Mrn:   mrn,
+		Title: p.Name,
+		Score: newScoreView(score),
+	}
+	if res.Title == "" {
+		res.Title = mrn
+	}
+
+	for _, group := range p.Groups {
+		for _, ref := range group.Policies {
+			if child := newPolicyView(bundle, report, checks, ref.Mrn, visited); child != nil {
+				res.Policies = append(res.Policies, child)
+			}
+		}
+		for _, ref := range group.Checks {
+			if check, ok := checks[ref.Mrn]; ok {
+				res.Checks = append(res.Checks, check)
+			}
+		}
+	}
+	return res
+}
+
+func dataViews(report *scan.AssetReport, bundle *policy.PolicyBundleMap) ([]*dataView, error) {
+	queries := bundle.QueryMap()
+	results := report.Report.RawResults()
+	res := []*dataView{}
+	var err error
+	report.ResolvedPolicy.WithDataQueries(func(id string, query *policy.ExecutionQuery) {
+		q, ok := queries[id]
+		if !ok || err != nil || query.Code == nil {
+			return
+		}
+
+		buf := bytes.Buffer{}
+		if err = cr.BundleResultsToJSON(query.Code, results, &shared.IOWriter{Writer: &buf}); err != nil {
+			return
+		}
+		value := buf.String()
+		pretty := bytes.Buffer{}
+		if json.Indent(&pretty, buf.Bytes(), "", "  ") == nil {
+			value = pretty.String()
+		}
+
+		title := q.Title
+		if title == "" {
+			title = q.Mrn
+		}
+		res = append(res, &dataView{Mrn: q.Mrn, Title: title, Query: q.Mql, Value: value})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Title < res[j].Title
+	})
+	return res, nil
+}
+
+func nonEmpty(values ...string) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,132 @@
+package html
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/cnspec/policy/scan"
+)
+
+func testReport() *scan.AssetReport {
+	failing := &explorer.Mquery{
+		Mrn:    "//test/queries/failing",
+		CodeId: "failing-id",
+		Title:  "Ensure <b>escaping</b>",
+		Mql:    "true == false",
+		Impact: &explorer.Impact{Value: 80},
+		Docs: &explorer.MqueryDocs{
+			Remediation: &explorer.Remediation{
+				Items: []*explorer.TypedDoc{{Id: "default", Desc: "Set it to true."}},
+			},
+		},
+		Refs: []*explorer.MqueryRef{{Title: "CVE-2021-44228", Url: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}},
+	}
+	passing := &explorer.Mquery{
+		Mrn:    "//test/queries/passing",
+		CodeId: "passing-id",
+		Title:  "Passing check",
+		Mql:    "true",
+	}
+
+	return &scan.AssetReport{
+		Mrn: "//test/assets/1",
+		Bundle: &policy.Bundle{
+			Policies: []*policy.Policy{
+				{
+					Mrn:  "//test/policies/parent",
+					Name: "Parent policy",
+					Groups: []*policy.PolicyGroup{{
+						Policies: []*policy.PolicyRef{{Mrn: "//test/policies/child"}},
+					}},
+				},
+				{
+					Mrn:  "//test/policies/child",
+					Name: "Child policy",
+					Groups: []*policy.PolicyGroup{{
+						Checks: []*explorer.Mquery{{Mrn: failing.Mrn}, {Mrn: passing.Mrn}},
+					}},
+				},
+			},
+			Queries: []*explorer.Mquery{failing, passing},
+		},
+		ResolvedPolicy: &policy.ResolvedPolicy{
+			ExecutionJob: &policy.ExecutionJob{},
+			CollectorJob: &policy.CollectorJob{
+				ReportingQueries: map[string]*policy.StringArray{
+					"failing-id": {},
+					"passing-id": {},
+				},
+			},
+		},
+		Report: &policy.Report{
+			Score: &policy.Score{Type: policy.ScoreType_Result, Value: 40, DataCompletion: 100, ScoreCompletion: 100},
+			Scores: map[string]*policy.Score{
+				"//test/policies/parent": {Type: policy.ScoreType_Result, Value: 40, DataCompletion: 100, ScoreCompletion: 100},
+				"//test/policies/child":  {Type: policy.ScoreType_Result, Value: 40, DataCompletion: 100, ScoreCompletion: 100},
+				"failing-id":             {Type: policy.ScoreType_Result, Value: 0, DataCompletion: 100, ScoreCompletion: 100},
+				"passing-id":             {Type: policy.ScoreType_Result, Value: 100, DataCompletion: 100, ScoreCompletion: 100},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	buf := bytes.Buffer{}
+	err := Render(&buf, &asset.Asset{Name: "test-host"}, testReport())
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "<title>cnspec report: test-host</title>")
+	assert.Contains(t, out, "Parent policy")
+	assert.Contains(t, out, "Child policy")
+	assert.Contains(t, out, "Set it to true.")
+	assert.Contains(t, out, "Ensure &lt;b&gt;escaping&lt;/b&gt;")
+	assert.NotContains(t, out, "<b>escaping</b>")
+}
+
+func TestRender_AssetContext(t *testing.T) {
+	buf := bytes.Buffer{}
+	assetObj := &asset.Asset{
+		Name:        "test-host",
+		PlatformIds: []string{"//platformid.api.mondoo.app/runtime/aws/ec2/v1/accounts/123/regions/us-east-1/instances/i-1234"},
+		Labels:      map[string]string{policy.AssetCriticalityLabel: "high"},
+	}
+	require.NoError(t, Render(&buf, assetObj, testReport()))
+
+	out := buf.String()
+	assert.Contains(t, out, "Cloud: aws / 123 / us-east-1")
+	assert.Contains(t, out, "Criticality: high")
+}
+
+func TestRender_Tree(t *testing.T) {
+	view, err := newReportView(nil, testReport(), time.Time{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "//test/assets/1", view.AssetName)
+	require.Len(t, view.Policies, 1)
+	parent := view.Policies[0]
+	assert.Equal(t, "Parent policy", parent.Title)
+	require.Len(t, parent.Policies, 1)
+	child := parent.Policies[0]
+	assert.Equal(t, "Child policy", child.Title)
+	require.Len(t, child.Checks, 2)
+	assert.Equal(t, "fail", child.Checks[0].Status)
+	assert.Equal(t, "pass", child.Checks[1].Status)
+
+	require.Len(t, view.Failed, 1)
+	assert.Equal(t, "//test/queries/failing", view.Failed[0].Mrn)
+	assert.Equal(t, []string{"Set it to true."}, view.Failed[0].Remediation)
+	require.Len(t, view.Failed[0].Refs, 1)
+	assert.Equal(t, policy.RefCVE, view.Failed[0].Refs[0].Kind)
+}
+
+func TestRender_Empty(t *testing.T) {
+	err := Render(&bytes.Buffer{}, nil, &scan.AssetReport{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"google.golang.org/protobuf/proto"
+)
+
+// ScoreDelta is the change of one score between two reports
+type ScoreDelta struct {
+	// ID is the code ID of checks and the MRN of policies and assets
+	ID string `json:"id"`
+	// Old is nil if the score is new
+	Old *Score `json:"old,omitempty"`
+	// New is nil if the score was removed
+	New *Score `json:"new,omitempty"`
+	// Delta is the change of the score value, e.g. -20 for a score that
+	// went from 100 to 80
+	Delta int `json:"delta"`
+}
+
+// OldOutcome returns the outcome of the old score, see ScoreOutcome
+func (d *ScoreDelta) OldOutcome() string {
+	return ScoreOutcome(d.Old)
+}
+
+// NewOutcome returns the outcome of the new score, see ScoreOutcome
+func (d *ScoreDelta) NewOutcome() string {
+	return ScoreOutcome(d.New)
+}
+
+// DataChange is a datapoint whose value differs between two reports
+type DataChange struct {
+	Kind     ChangeKind `json:"kind"`
+	Checksum string     `json:"checksum"`
+}
+
+// ReportDiff lists the differences between two reports of the same asset,
+// e.g. before and after a change window. All lists are sorted by ID.
+type ReportDiff struct {
+	EntityMrn string `json:"entity_mrn"`
+	// Score is the change of the overall score, nil if it didn't change
+	Score *ScoreDelta `json:"score,omitempty"`
+	// NewlyFailing are the scores that fail or error now, but didn't before
+	NewlyFailing []*ScoreDelta `json:"newly_failing,omitempty"`
+	// NewlyPassing are the scores that pass now, but didn't before
+	NewlyPassing []*ScoreDelta `json:"newly_passing,omitempty"`
+	// Changed are all other scores whose value changed, e.g. a failing
+	// score whose value dropped even further
+	Changed []*ScoreDelta `json:"changed,omitempty"`
+	// Data lists all datapoints that were added, removed or changed
+	Data []DataChange `json:"data,omitempty"`
+}
+
+// IsEmpty returns true if both reports have the same scores and data
+func (d *ReportDiff) IsEmpty() bool {
+	return d.Score == nil && len(d.NewlyFailing) == 0 && len(d.NewlyPassing) == 0 &&
+		len(d.Changed) == 0 && len(d.Data) == 0
+}
+
+// HasRegressions returns true if any score newly fails or the overall score
+// dropped, e.g. to reject a change
+func (d *ReportDiff) HasRegressions() bool {
+	return len(d.NewlyFailing) != 0 || (d.Score != nil && d.Score.Delta < 0)
+}
+
+func isFailing(outcome string) bool {
+	return outcome == OutcomeFail || outcome == OutcomeError
+}
+
+func scoreValue(score *Score) int {
+	if score == nil || score.Type != ScoreType_Result {
+		return 0
+	}
+	return int(score.Value)
+}
+
+// diffScore compares two scores and returns nil if they are the same
+func diffScore(id string, old *Score, new *Score) *ScoreDelta {
+	if old == nil && new == nil {
+		return nil
+	}
+	if old != nil && new != nil && old.Type == new.Type && old.Value == new.Value {
+		return nil
+	}
+	return &ScoreDelta{
+		ID:    id,
+		Old:   old,
+		New:   new,
+		Delta: scoreValue(new) - scoreValue(old),
+	}
+}
+
+// CompareReports computes the differences between an old and a new report
+// of an asset. Either report may be nil, e.g. for the first scan.
+func CompareReports(old *Report, new *Report) *ReportDiff {
+	if old == nil {
+		old = &Report{}
+	}
+	if new == nil {
+		new = &Report{}
+	}
+
+	res := &ReportDiff{EntityMrn: new.EntityMrn}
+	if res.EntityMrn == "" {
+		res.EntityMrn = old.EntityMrn
+	}
+	res.Score = diffScore(res.EntityMrn, old.Score, new.Score)
+
+	for _, id := range unionKeys(old.Scores, new.Scores) {
+		delta := diffScore(id, old.Scores[id], new.Scores[id])
+		if delta == nil {
+			continue
+		}
+
+		oldOutcome, newOutcome := delta.OldOutcome(), delta.NewOutcome()
+		switch {
+		case isFailing(newOutcome) && !isFailing(oldOutcome):
+			res.NewlyFailing = append(res.NewlyFailing, delta)
+		case newOutcome == OutcomePass && oldOutcome != OutcomePass:
+			res.NewlyPassing = append(res.NewlyPassing, delta)
+		case delta.Delta != 0 || oldOutcome != newOutcome:
+			res.Changed = append(res.Changed, delta)
+		}
+	}
+
+	for _, checksum := range unionKeys(old.Data, new.Data) {
+		oldDatum, isOld := old.Data[checksum]
+		newDatum, isNew := new.Data[checksum]
+		switch {
+		case !isNew:
+			res.Data = append(res.Data, DataChange{Kind: ChangeRemoved, Checksum: checksum})
+		case !isOld:
+			res.Data = append(res.Data, DataChange{Kind: ChangeAdded, Checksum: checksum})
+		case !proto.Equal(oldDatum, newDatum):
+			res.Data = append(res.Data, DataChange{Kind: ChangeChanged, Checksum: checksum})
+		}
+	}
+
+	return res
+}
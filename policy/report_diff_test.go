@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+)
+
+func TestCompareReports(t *testing.T) {
+	pass := &Score{Type: ScoreType_Result, Value: 100}
+	fail := &Score{Type: ScoreType_Result, Value: 40}
+	old := &Report{
+		EntityMrn: "//asset",
+		Score:     &Score{Type: ScoreType_Result, Value: 80},
+		Scores: map[string]*Score{
+			"regressed": pass,
+			"fixed":     fail,
+			"worse":     fail,
+			"same":      pass,
+			"removed":   pass,
+		},
+		Data: map[string]*llx.Result{
+			"dp-same":    (&llx.RawResult{Data: llx.IntData(1), CodeID: "dp-same"}).Result(),
+			"dp-changed": (&llx.RawResult{Data: llx.IntData(1), CodeID: "dp-changed"}).Result(),
+			"dp-removed": (&llx.RawResult{Data: llx.IntData(1), CodeID: "dp-removed"}).Result(),
+		},
+	}
+	new := &Report{
+		EntityMrn: "//asset",
+		Score:     &Score{Type: ScoreType_Result, Value: 70},
+		Scores: map[string]*Score{
+			"regressed": {Type: ScoreType_Error},
+			"fixed":     pass,
+			"worse":     {Type: ScoreType_Result, Value: 10},
+			"same":      pass,
+			"added":     fail,
+		},
+		Data: map[string]*llx.Result{
+			"dp-same":    (&llx.RawResult{Data: llx.IntData(1), CodeID: "dp-same"}).Result(),
+			"dp-changed": (&llx.RawResult{Data: llx.IntData(2), CodeID: "dp-changed"}).Result(),
+			"dp-added":   (&llx.RawResult{Data: llx.IntData(1), CodeID: "dp-added"}).Result(),
+		},
+	}
+
+	diff := CompareReports(old, new)
+	require.NotNil(t, diff.Score)
+	assert.Equal(t, -10, diff.Score.Delta)
+	assert.True(t, diff.HasRegressions())
+	assert.False(t, diff.IsEmpty())
+
+	ids := func(deltas []*ScoreDelta) []string {
+		res := make([]string, len(deltas))
+		for i := range deltas {
+			res[i] = deltas[i].ID
+		}
+		return res
+	}
+	assert.Equal(t, []string{"added", "regressed"}, ids(diff.NewlyFailing))
+	assert.Equal(t, []string{"fixed"}, ids(diff.NewlyPassing))
+	assert.Equal(t, []string{"removed", "worse"}, ids(diff.Changed))
+	assert.Equal(t, -30, diff.Changed[1].Delta)
+	assert.Equal(t, OutcomeUnknown, diff.Changed[0].NewOutcome())
+
+	assert.Equal(t, []DataChange{
+		{Kind: ChangeAdded, Checksum: "dp-added"},
+		{Kind: ChangeChanged, Checksum: "dp-changed"},
+		{Kind: ChangeRemoved, Checksum: "dp-removed"},
+	}, diff.Data)
+
+	t.Run("same reports", func(t *testing.T) {
+		diff := CompareReports(old, old)
+		assert.True(t, diff.IsEmpty())
+		assert.False(t, diff.HasRegressions())
+	})
+
+	t.Run("first scan", func(t *testing.T) {
+		diff := CompareReports(nil, old)
+		assert.Equal(t, "//asset", diff.EntityMrn)
+		assert.Equal(t, []string{"fixed", "worse"}, ids(diff.NewlyFailing))
+		assert.Len(t, diff.NewlyPassing, 3)
+	})
+}
@@ -0,0 +1,155 @@
+package policy
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ReportStreamOptions select the reports of a stream
+type ReportStreamOptions struct {
+	// After skips all assets up to and including this MRN, so that clients
+	// can resume interrupted streams
+	After string
+	// IncludeData adds the data of all datapoints to the reports. It is
+	// left out by default, since it is much larger than the scores.
+	IncludeData bool
+}
+
+// ReportStreamer streams the reports of all assets, ordered by asset MRN.
+// The function is called for one report at a time; the streamer only loads
+// the next report once the function returned, so slow consumers slow down
+// the stream instead of buffering reports.
+type ReportStreamer interface {
+	StreamReports(ctx context.Context, opts ReportStreamOptions, f func(report *Report) error) error
+}
+
+// ReportStreamContentType is used for streamed reports: one JSON report per line
+const ReportStreamContentType = "application/x-ndjson"
+
+type reportStreamHandler struct {
+	streamer ReportStreamer
+}
+
+// NewReportStreamHandler serves reports as a stream of newline-delimited
+// JSON, which lets clients render results of very large fleets
+// incrementally instead of waiting for one huge response. Supported query
+// parameters are `after` and `data`, see ReportStreamOptions.
+//
+// This is plain HTTP instead of a policy service call, since ranger-rpc
+// only supports unary calls, which would have to hold all reports in one
+// response. If the stream fails after the first report, the connection is
+// aborted, so that clients see an incomplete stream instead of a clean end.
+func NewReportStreamHandler(streamer ReportStreamer) http.Handler {
+	return &reportStreamHandler{streamer: streamer}
+}
+
+func (h *reportStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	opts := ReportStreamOptions{After: r.URL.Query().Get("after")}
+	if data := r.URL.Query().Get("data"); data != "" {
+		v, err := strconv.ParseBool(data)
+		if err != nil {
+			http.Error(w, "invalid value for data: "+data, http.StatusBadRequest)
+			return
+		}
+		opts.IncludeData = v
+	}
+
+	w.Header().Set("Content-Type", ReportStreamContentType)
+	flusher, _ := w.(http.Flusher)
+	started := false
+	err := h.streamer.StreamReports(r.Context(), opts, func(report *Report) error {
+		line, err := protojson.Marshal(report)
+		if err != nil {
+			return err
+		}
+		started = true
+		// writes block once the client stops reading, which pauses the stream
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to stream reports")
+		if !started {
+			http.Error(w, "failed to stream reports", http.StatusInternalServerError)
+			return
+		}
+		// once the first report is sent, the status can't change anymore;
+		// aborting the response lets clients detect the incomplete stream,
+		// so that they can resume it with `after`
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// ReadReportStream reads all reports that are streamed from the URL, see
+// NewReportStreamHandler. The function is called for every report as it
+// arrives. It returns the MRN of the last asset that was received, so that
+// interrupted streams can be resumed. Streams that end before the server
+// sent all reports return an error.
+func ReadReportStream(ctx context.Context, client *http.Client, streamURL string, opts ReportStreamOptions, f func(report *Report) error) (string, error) {
+	u, err := url.Parse(streamURL)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid report stream url")
+	}
+	query := u.Query()
+	if opts.After != "" {
+		query.Set("after", opts.After)
+	}
+	if opts.IncludeData {
+		query.Set("data", "true")
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", ReportStreamContentType)
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", errors.New("failed to stream reports: " + res.Status)
+	}
+
+	last := opts.After
+	scanner := bufio.NewScanner(res.Body)
+	// reports with data can be large, scores alone are small
+	scanner.Buffer(make([]byte, 64*1024), 256*1024*1024)
+	for scanner.Scan() {
+		report := &Report{}
+		if err := protojson.Unmarshal(scanner.Bytes(), report); err != nil {
+			return last, errors.Wrap(err, "failed to parse streamed report")
+		}
+		if err := f(report); err != nil {
+			return last, err
+		}
+		last = report.EntityMrn
+	}
+	if err := scanner.Err(); err != nil {
+		return last, errors.Wrap(err, "report stream was interrupted")
+	}
+	return last, nil
+}
@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testReportStreamer struct {
+	reports []*Report
+	// fails the stream with err after failAfter reports, if set
+	err       error
+	failAfter int
+}
+
+func (s *testReportStreamer) StreamReports(ctx context.Context, opts ReportStreamOptions, f func(report *Report) error) error {
+	sort.Slice(s.reports, func(i, j int) bool {
+		return s.reports[i].EntityMrn < s.reports[j].EntityMrn
+	})
+	sent := 0
+	for _, report := range s.reports {
+		if report.EntityMrn <= opts.After {
+			continue
+		}
+		if s.err != nil && sent == s.failAfter {
+			return s.err
+		}
+		if err := f(report); err != nil {
+			return err
+		}
+		sent++
+	}
+	return nil
+}
+
+func TestReportStream(t *testing.T) {
+	streamer := &testReportStreamer{reports: []*Report{
+		{EntityMrn: "//assets/a", Score: &Score{Value: 10}},
+		{EntityMrn: "//assets/b", Score: &Score{Value: 20}},
+		{EntityMrn: "//assets/c", Score: &Score{Value: 30}},
+	}}
+	server := httptest.NewServer(NewReportStreamHandler(streamer))
+	defer server.Close()
+
+	var received []string
+	last, err := ReadReportStream(context.Background(), server.Client(), server.URL, ReportStreamOptions{}, func(report *Report) error {
+		received = append(received, report.EntityMrn)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"//assets/a", "//assets/b", "//assets/c"}, received)
+	assert.Equal(t, "//assets/c", last)
+
+	t.Run("resume after an asset", func(t *testing.T) {
+		var received []uint32
+		last, err := ReadReportStream(context.Background(), server.Client(), server.URL, ReportStreamOptions{After: "//assets/a"}, func(report *Report) error {
+			received = append(received, report.Score.Value)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint32{20, 30}, received)
+		assert.Equal(t, "//assets/c", last)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		last, err := ReadReportStream(context.Background(), server.Client(), server.URL, ReportStreamOptions{}, func(report *Report) error {
+			if report.EntityMrn == "//assets/b" {
+				return context.Canceled
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, "//assets/a", last)
+	})
+}
+
+func TestReportStream_Interrupted(t *testing.T) {
+	streamer := &testReportStreamer{
+		reports: []*Report{
+			{EntityMrn: "//assets/a"},
+			{EntityMrn: "//assets/b"},
+			{EntityMrn: "//assets/c"},
+		},
+		err:       errors.New("datalake is gone"),
+		failAfter: 1,
+	}
+	server := httptest.NewServer(NewReportStreamHandler(streamer))
+	defer server.Close()
+
+	var received []string
+	last, err := ReadReportStream(context.Background(), server.Client(), server.URL, ReportStreamOptions{}, func(report *Report) error {
+		received = append(received, report.EntityMrn)
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "report stream was interrupted")
+	assert.Equal(t, []string{"//assets/a"}, received)
+	assert.Equal(t, "//assets/a", last)
+
+	t.Run("errors before the first report fail the request", func(t *testing.T) {
+		streamer.failAfter = 0
+		_, err := ReadReportStream(context.Background(), server.Client(), server.URL, ReportStreamOptions{}, func(report *Report) error {
+			return nil
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "500")
+	})
+}
+
+func TestReportStream_InvalidParams(t *testing.T) {
+	server := httptest.NewServer(NewReportStreamHandler(&testReportStreamer{}))
+	defer server.Close()
+
+	_, err := ReadReportStream(context.Background(), server.Client(), server.URL+"?data=maybe", ReportStreamOptions{}, func(report *Report) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
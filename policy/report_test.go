@@ -0,0 +1,29 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport_ContentID(t *testing.T) {
+	newReport := func(value uint32, modified int64) *Report {
+		return &Report{
+			EntityMrn:  "//asset",
+			ScoringMrn: "//asset",
+			Modified:   modified,
+			Scores: map[string]*Score{
+				"a": {QrId: "a", Type: ScoreType_Result, Value: value, ScoreCompletion: 100, ValueModifiedTime: modified},
+				"b": {QrId: "b", Type: ScoreType_Skip},
+			},
+		}
+	}
+
+	id := newReport(100, 1).ContentID("checksum")
+	assert.True(t, strings.HasPrefix(id, ReportIDPrefix))
+	// timestamps don't change the ID
+	assert.Equal(t, id, newReport(100, 2).ContentID("checksum"))
+	assert.NotEqual(t, id, newReport(50, 1).ContentID("checksum"))
+	assert.NotEqual(t, id, newReport(100, 1).ContentID("other"))
+}
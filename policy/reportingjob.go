@@ -1,14 +1,14 @@
 package policy
 
 import (
+	"runtime"
 	"sort"
-
-	"go.mondoo.com/cnquery/checksums"
+	"sync"
 )
 
 // RefreshChecksum recalculates the reporting job checksum
 func (r *ReportingJob) RefreshChecksum() {
-	checksum := checksums.New
+	checksum := NewChecksum()
 	checksum = checksum.Add("v2")
 	checksum = checksum.Add(r.Uuid)
 	checksum = checksum.Add(r.QrId)
@@ -45,3 +45,41 @@ func (r *ReportingJob) RefreshChecksum() {
 	}
 	r.Checksum = checksum.String()
 }
+
+// parallelChecksumThreshold is the number of reporting jobs from which
+// their checksums are computed in parallel. Below it, starting workers
+// costs more than it saves.
+const parallelChecksumThreshold = 512
+
+// refreshReportingJobChecksums recalculates the checksums of all reporting
+// jobs with up to the given number of workers. Every job only depends on
+// its own fields, so the result doesn't depend on the order. 0 workers use
+// one per CPU.
+func refreshReportingJobChecksums(jobs map[string]*ReportingJob, workers int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers == 1 || len(jobs) < parallelChecksumThreshold {
+		for _, rj := range jobs {
+			rj.RefreshChecksum()
+		}
+		return
+	}
+
+	queue := make(chan *ReportingJob, workers*4)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for rj := range queue {
+				rj.RefreshChecksum()
+			}
+		}()
+	}
+	for _, rj := range jobs {
+		queue <- rj
+	}
+	close(queue)
+	wg.Wait()
+}
@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func testReportingJobs(n int) map[string]*ReportingJob {
+	res := make(map[string]*ReportingJob, n)
+	for i := 0; i < n; i++ {
+		id := "job" + strconv.Itoa(i)
+		res[id] = &ReportingJob{
+			Uuid:      id,
+			QrId:      "query" + strconv.Itoa(i),
+			ChildJobs: map[string]*explorer.Impact{"child" + strconv.Itoa(i): {Value: int32(i % 100), Weight: 1}},
+			Notify:    []string{"parent" + strconv.Itoa(i%7), "root"},
+		}
+	}
+	return res
+}
+
+func TestRefreshReportingJobChecksums(t *testing.T) {
+	sequential := testReportingJobs(2 * parallelChecksumThreshold)
+	refreshReportingJobChecksums(sequential, 1)
+
+	parallel := testReportingJobs(2 * parallelChecksumThreshold)
+	refreshReportingJobChecksums(parallel, 8)
+
+	for id, rj := range sequential {
+		assert.NotEmpty(t, rj.Checksum)
+		assert.Equal(t, rj.Checksum, parallel[id].Checksum, id)
+	}
+}
+
+func TestRefreshChecksums_Parallel(t *testing.T) {
+	checksum := func(workers int) string {
+		s := &LocalServices{ChecksumWorkers: workers}
+		collectorJob := &CollectorJob{
+			ReportingJobs: testReportingJobs(2 * parallelChecksumThreshold),
+			Datapoints:    map[string]*DataQueryInfo{"dp": {Type: "b", Notify: []string{"job1"}}},
+		}
+		s.refreshChecksums(&ExecutionJob{}, collectorJob)
+		return collectorJob.Checksum
+	}
+
+	assert.Equal(t, checksum(1), checksum(0))
+	assert.Equal(t, checksum(1), checksum(4))
+}
@@ -0,0 +1,330 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery"
+)
+
+// DefaultReResolveInterval is the default min time between two background
+// resolutions of the ReResolver
+const DefaultReResolveInterval = 200 * time.Millisecond
+
+type bypassResolvedPolicyCacheKey struct{}
+
+// withoutResolvedPolicyCache makes resolving ignore cached resolved
+// policies, e.g. because they were resolved by another version of cnspec
+func withoutResolvedPolicyCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassResolvedPolicyCacheKey{}, true)
+}
+
+func bypassResolvedPolicyCache(ctx context.Context) bool {
+	v, _ := ctx.Value(bypassResolvedPolicyCacheKey{}).(bool)
+	return v
+}
+
+// ReResolveOptions configures the ReResolver
+type ReResolveOptions struct {
+	// Interval is the min time between two resolutions, it defaults to
+	// DefaultReResolveInterval
+	Interval time.Duration
+	// StatePath is optional. If set, the last cnspec version and features
+	// are stored in this file, so that upgrades are detected across restarts.
+	StatePath string
+}
+
+type reResolveState struct {
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ReResolver re-resolves the policies of assets in the background, when
+// the bundles they use are updated or when cnspec or its features change.
+// The next scan of these assets then doesn't have to wait for resolution.
+// Set it as LocalServices.ReResolver to be notified of bundle updates.
+type ReResolver struct {
+	services  *LocalServices
+	assets    AssetLister
+	interval  time.Duration
+	statePath string
+
+	mu          sync.Mutex
+	queue       []string
+	pending     map[string]bool // asset MRN => bypass cached resolved policies
+	fingerprint string
+	// unsaved is the fingerprint that is stored once all assets were
+	// re-resolved, so that the work isn't lost if cnspec stops before
+	unsaved string
+
+	wake chan struct{}
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewReResolver creates a ReResolver for the assets of the services'
+// datalake, which has to implement AssetLister
+func NewReResolver(services *LocalServices, opts ReResolveOptions) (*ReResolver, error) {
+	assets, ok := services.DataLake.(AssetLister)
+	if !ok {
+		return nil, errors.New("cannot re-resolve policies, the datalake can't list its assets")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultReResolveInterval
+	}
+
+	return &ReResolver{
+		services:  services,
+		assets:    assets,
+		interval:  opts.Interval,
+		statePath: opts.StatePath,
+		pending:   map[string]bool{},
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+	}, nil
+}
+
+// Start re-resolving assets in the background until Stop is called
+func (r *ReResolver) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go r.run(ctx)
+}
+
+// Stop re-resolving assets. Assets that are still pending are resolved on
+// their next scan as usual.
+func (r *ReResolver) Stop() {
+	close(r.stop)
+	r.wg.Wait()
+}
+
+// Pending returns the number of assets that wait to be re-resolved
+func (r *ReResolver) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.queue)
+}
+
+// BundleUpdated re-resolves all assets whose resolved policy uses one of
+// the given policies
+func (r *ReResolver) BundleUpdated(ctx context.Context, policyMrns []string) error {
+	if r == nil || len(policyMrns) == 0 {
+		return nil
+	}
+
+	updated := make(map[string]struct{}, len(policyMrns))
+	for i := range policyMrns {
+		updated[policyMrns[i]] = struct{}{}
+	}
+
+	assetMrns, err := r.assets.ListAssetMrns(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list assets for re-resolution")
+	}
+
+	var affected []string
+	for _, assetMrn := range assetMrns {
+		resolvedPolicy, err := r.services.DataLake.GetResolvedPolicy(ctx, assetMrn)
+		if err != nil || resolvedPolicy == nil || resolvedPolicy.CollectorJob == nil {
+			// assets that were never resolved are resolved on their first scan
+			continue
+		}
+		for _, job := range resolvedPolicy.CollectorJob.ReportingJobs {
+			if _, ok := updated[job.QrId]; ok {
+				affected = append(affected, assetMrn)
+				break
+			}
+		}
+	}
+
+	r.schedule(affected, false)
+	return nil
+}
+
+// EnvironmentChanged compares the cnspec version and features with the ones
+// that were last seen and re-resolves all assets if they differ. Nothing is
+// re-resolved if no version was seen before. The new version is only stored
+// once all assets were re-resolved, so that a restart in between starts over.
+func (r *ReResolver) EnvironmentChanged(ctx context.Context, version string, features cnquery.Features) error {
+	fingerprint := checksumStrings(version, string(features))
+
+	r.mu.Lock()
+	previous := r.fingerprint
+	r.mu.Unlock()
+	if previous == "" {
+		var err error
+		if previous, err = r.loadFingerprint(); err != nil {
+			return err
+		}
+	}
+	if previous == fingerprint {
+		return nil
+	}
+
+	if previous == "" {
+		r.mu.Lock()
+		r.fingerprint = fingerprint
+		r.mu.Unlock()
+		return r.storeFingerprint(fingerprint)
+	}
+
+	assetMrns, err := r.assets.ListAssetMrns(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list assets for re-resolution")
+	}
+	r.mu.Lock()
+	r.fingerprint = fingerprint
+	r.unsaved = fingerprint
+	r.mu.Unlock()
+	if len(assetMrns) == 0 {
+		r.drained()
+		return nil
+	}
+
+	log.Info().Int("assets", len(assetMrns)).Msg("resolver> cnspec version or features changed, re-resolving all assets")
+	r.schedule(assetMrns, true)
+	return nil
+}
+
+// drained stores the fingerprint of a version change once no assets are
+// left to re-resolve
+func (r *ReResolver) drained() {
+	r.mu.Lock()
+	fingerprint := r.unsaved
+	if fingerprint == "" || len(r.queue) != 0 {
+		r.mu.Unlock()
+		return
+	}
+	r.unsaved = ""
+	r.mu.Unlock()
+
+	if err := r.storeFingerprint(fingerprint); err != nil {
+		log.Warn().Err(err).Msg("resolver> failed to store the version of re-resolved assets")
+	}
+}
+
+func (r *ReResolver) loadFingerprint() (string, error) {
+	if r.statePath == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(r.statePath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read re-resolution state")
+	}
+
+	var state reResolveState
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Warn().Err(err).Str("path", r.statePath).Msg("resolver> ignoring invalid re-resolution state")
+		return "", nil
+	}
+	return state.Fingerprint, nil
+}
+
+func (r *ReResolver) storeFingerprint(fingerprint string) error {
+	if r.statePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(reResolveState{Fingerprint: fingerprint})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.statePath), 0o755); err != nil {
+		return errors.Wrap(err, "failed to store re-resolution state")
+	}
+	return errors.Wrap(os.WriteFile(r.statePath, data, 0o644), "failed to store re-resolution state")
+}
+
+func (r *ReResolver) schedule(assetMrns []string, bypassCache bool) {
+	if len(assetMrns) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	for _, mrn := range assetMrns {
+		bypass, ok := r.pending[mrn]
+		if !ok {
+			r.queue = append(r.queue, mrn)
+		}
+		r.pending[mrn] = bypass || bypassCache
+	}
+	r.mu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *ReResolver) next() (string, bool, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.queue) == 0 {
+		return "", false, false
+	}
+	mrn := r.queue[0]
+	r.queue = r.queue[1:]
+	bypass := r.pending[mrn]
+	delete(r.pending, mrn)
+	return mrn, bypass, true
+}
+
+func (r *ReResolver) run(ctx context.Context) {
+	defer r.wg.Done()
+	for {
+		assetMrn, bypass, ok := r.next()
+		if !ok {
+			r.drained()
+			select {
+			case <-r.stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-r.wake:
+				continue
+			}
+		}
+
+		if err := r.reResolve(ctx, assetMrn, bypass); err != nil {
+			log.Warn().Err(err).Str("asset", assetMrn).Msg("resolver> failed to re-resolve asset in the background")
+		}
+
+		timer := time.NewTimer(r.interval)
+		select {
+		case <-r.stop:
+			timer.Stop()
+			return
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// reResolve resolves the policy of an asset again, with the asset filters
+// of its current resolved policy
+func (r *ReResolver) reResolve(ctx context.Context, assetMrn string, bypassCache bool) error {
+	resolvedPolicy, err := r.services.DataLake.GetResolvedPolicy(ctx, assetMrn)
+	if err != nil {
+		return err
+	}
+	if resolvedPolicy == nil || len(resolvedPolicy.Filters) == 0 {
+		return nil
+	}
+
+	if bypassCache {
+		ctx = withoutResolvedPolicyCache(ctx)
+	}
+	_, err = r.services.ResolveAndUpdateJobs(ctx, &UpdateAssetJobsReq{
+		AssetMrn:     assetMrn,
+		AssetFilters: resolvedPolicy.Filters,
+	})
+	return err
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery"
+)
+
+type testAssetLister []string
+
+func (l testAssetLister) ListAssetMrns(ctx context.Context) ([]string, error) {
+	return l, nil
+}
+
+func newTestReResolver(statePath string, assets ...string) *ReResolver {
+	return &ReResolver{
+		assets:    testAssetLister(assets),
+		statePath: statePath,
+		pending:   map[string]bool{},
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+	}
+}
+
+func TestReResolver_Schedule(t *testing.T) {
+	r := newTestReResolver("")
+	r.schedule([]string{"//a", "//b"}, false)
+	r.schedule([]string{"//b", "//c"}, true)
+	assert.Equal(t, 3, r.Pending())
+
+	mrn, bypass, ok := r.next()
+	require.True(t, ok)
+	assert.Equal(t, "//a", mrn)
+	assert.False(t, bypass)
+
+	mrn, bypass, ok = r.next()
+	require.True(t, ok)
+	assert.Equal(t, "//b", mrn)
+	assert.True(t, bypass)
+
+	r.next()
+	_, _, ok = r.next()
+	assert.False(t, ok)
+}
+
+func TestReResolver_EnvironmentChanged(t *testing.T) {
+	ctx := context.Background()
+	statePath := filepath.Join(t.TempDir(), "reresolve.json")
+
+	r := newTestReResolver(statePath, "//a", "//b")
+	// nothing is known about the previous version
+	require.NoError(t, r.EnvironmentChanged(ctx, "8.0.0", cnquery.DefaultFeatures))
+	assert.Equal(t, 0, r.Pending())
+	require.NoError(t, r.EnvironmentChanged(ctx, "8.0.0", cnquery.DefaultFeatures))
+	assert.Equal(t, 0, r.Pending())
+
+	// upgrades are detected after restarts
+	r = newTestReResolver(statePath, "//a", "//b")
+	require.NoError(t, r.EnvironmentChanged(ctx, "8.1.0", cnquery.DefaultFeatures))
+	assert.Equal(t, 2, r.Pending())
+	_, bypass, _ := r.next()
+	assert.True(t, bypass)
+
+	// the new version isn't stored before all assets are re-resolved
+	r.drained()
+	r = newTestReResolver(statePath, "//a", "//b")
+	require.NoError(t, r.EnvironmentChanged(ctx, "8.1.0", cnquery.DefaultFeatures))
+	assert.Equal(t, 2, r.Pending())
+
+	r.next()
+	r.next()
+	r.drained()
+	r = newTestReResolver(statePath, "//a", "//b")
+	require.NoError(t, r.EnvironmentChanged(ctx, "8.1.0", cnquery.DefaultFeatures))
+	assert.Equal(t, 0, r.Pending())
+}
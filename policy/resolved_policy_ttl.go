@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ResolvedPolicyTTL configures how long datalakes reuse a cached resolved
+// policy before it is resolved again. Long-running agents use it to pick up
+// content updates from upstream even if nothing changed locally.
+type ResolvedPolicyTTL struct {
+	// TTL of every resolved policy, 0 uses the default of the datalake
+	TTL time.Duration
+	// Jitter is the fraction of the TTL (between 0 and 1) by which the TTL
+	// of every resolved policy varies randomly. It spreads out the refreshes
+	// of many assets and agents, which would otherwise all expire at once.
+	Jitter float64
+}
+
+// Next returns the TTL for a resolved policy that is cached now. It returns
+// 0 if no TTL is configured.
+func (t ResolvedPolicyTTL) Next() time.Duration {
+	if t.TTL <= 0 {
+		return 0
+	}
+
+	jitter := t.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	if jitter <= 0 {
+		return t.TTL
+	}
+
+	// uniformly distributed in [TTL * (1 - jitter), TTL * (1 + jitter))
+	factor := 1 + jitter*(2*rand.Float64()-1)
+	res := time.Duration(float64(t.TTL) * factor)
+	if res <= 0 {
+		res = time.Second
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvedPolicyTTL(t *testing.T) {
+	assert.Equal(t, time.Duration(0), ResolvedPolicyTTL{}.Next())
+	assert.Equal(t, time.Hour, ResolvedPolicyTTL{TTL: time.Hour}.Next())
+
+	ttl := ResolvedPolicyTTL{TTL: time.Hour, Jitter: 0.1}
+	distinct := map[time.Duration]struct{}{}
+	for i := 0; i < 100; i++ {
+		next := ttl.Next()
+		assert.GreaterOrEqual(t, next, 54*time.Minute)
+		assert.Less(t, next, 66*time.Minute)
+		distinct[next] = struct{}{}
+	}
+	assert.Greater(t, len(distinct), 1)
+
+	// the jitter can't make the TTL negative
+	assert.Greater(t, ResolvedPolicyTTL{TTL: time.Hour, Jitter: 5}.Next(), time.Duration(0))
+}
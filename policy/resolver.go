@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/binary"
-	"math/rand"
 	"sort"
 	"strings"
 	"time"
@@ -12,14 +11,18 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/segmentio/fasthash/fnv1a"
-	"go.mondoo.com/cnquery/checksums"
 	"go.mondoo.com/cnquery/explorer"
 	"go.mondoo.com/cnquery/llx"
 	"go.mondoo.com/cnquery/logger"
 	"go.mondoo.com/cnquery/mrn"
+	"go.mondoo.com/cnquery/types"
 	"go.mondoo.com/ranger-rpc/codes"
 	"go.mondoo.com/ranger-rpc/status"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -38,8 +41,10 @@ func (s *LocalServices) Assign(ctx context.Context, assignment *PolicyAssignment
 	}
 
 	// all remote, call upstream
-	if s.Upstream != nil && !s.Incognito {
-		return s.Upstream.PolicyResolver.Assign(ctx, assignment)
+	if s.useUpstream() {
+		res, err := s.Upstream.PolicyResolver.Assign(ctx, assignment)
+		s.upstreamResult(err)
+		return res, err
 	}
 
 	// policies may be stored in upstream, cache them first
@@ -82,8 +87,10 @@ func (s *LocalServices) Unassign(ctx context.Context, assignment *PolicyAssignme
 	}
 
 	// all remote, call upstream
-	if s.Upstream != nil && !s.Incognito {
-		return s.Upstream.PolicyResolver.Unassign(ctx, assignment)
+	if s.useUpstream() {
+		res, err := s.Upstream.PolicyResolver.Unassign(ctx, assignment)
+		s.upstreamResult(err)
+		return res, err
 	}
 
 	deltas := map[string]*PolicyDelta{}
@@ -118,8 +125,10 @@ func (s *LocalServices) SetProps(ctx context.Context, req *explorer.PropsReq) (*
 
 // Resolve a given policy for a set of asset filters
 func (s *LocalServices) Resolve(ctx context.Context, req *ResolveReq) (*ResolvedPolicy, error) {
-	if s.Upstream != nil && !s.Incognito {
-		return s.Upstream.Resolve(ctx, req)
+	if s.useUpstream() {
+		res, err := s.Upstream.Resolve(ctx, req)
+		s.upstreamResult(err)
+		return res, err
 	}
 
 	return s.resolve(ctx, req.PolicyMrn, req.AssetFilters)
@@ -127,7 +136,12 @@ func (s *LocalServices) Resolve(ctx context.Context, req *ResolveReq) (*Resolved
 
 // ResolveAndUpdateJobs will resolve an asset's policy and update its jobs
 func (s *LocalServices) ResolveAndUpdateJobs(ctx context.Context, req *UpdateAssetJobsReq) (*ResolvedPolicy, error) {
-	if s.Upstream == nil || s.Incognito {
+	// every scan of an asset starts by resolving its jobs
+	if err := s.consumeQuota(ctx, req.AssetMrn, SpaceUsage{Scans: 1}); err != nil {
+		return nil, err
+	}
+
+	if !s.useUpstream() {
 		res, err := s.resolve(ctx, req.AssetMrn, req.AssetFilters)
 		if err != nil {
 			return nil, err
@@ -151,6 +165,7 @@ func (s *LocalServices) ResolveAndUpdateJobs(ctx context.Context, req *UpdateAss
 	}
 
 	res, err := s.Upstream.PolicyResolver.ResolveAndUpdateJobs(ctx, req)
+	s.upstreamResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -165,11 +180,13 @@ func (s *LocalServices) ResolveAndUpdateJobs(ctx context.Context, req *UpdateAss
 
 // UpdateAssetJobs by recalculating them
 func (s *LocalServices) UpdateAssetJobs(ctx context.Context, req *UpdateAssetJobsReq) (*Empty, error) {
-	if s.Upstream == nil || s.Incognito {
+	if !s.useUpstream() {
 		return globalEmpty, s.updateAssetJobs(ctx, req.AssetMrn, req.AssetFilters)
 	}
 
-	if _, err := s.Upstream.PolicyResolver.UpdateAssetJobs(ctx, req); err != nil {
+	_, err := s.Upstream.PolicyResolver.UpdateAssetJobs(ctx, req)
+	s.upstreamResult(err)
+	if err != nil {
 		return nil, err
 	}
 
@@ -177,6 +194,7 @@ func (s *LocalServices) UpdateAssetJobs(ctx context.Context, req *UpdateAssetJob
 		PolicyMrn:    req.AssetMrn,
 		AssetFilters: req.AssetFilters,
 	})
+	s.upstreamResult(err)
 	if err != nil {
 		return nil, errors.New("resolver> failed to resolve upstream jobs for caching: " + err.Error())
 	}
@@ -186,38 +204,172 @@ func (s *LocalServices) UpdateAssetJobs(ctx context.Context, req *UpdateAssetJob
 
 // GetResolvedPolicy for a given asset
 func (s *LocalServices) GetResolvedPolicy(ctx context.Context, mrn *Mrn) (*ResolvedPolicy, error) {
-	if s.Upstream != nil && !s.Incognito {
-		return s.Upstream.GetResolvedPolicy(ctx, mrn)
+	if s.useUpstream() {
+		res, err := s.Upstream.GetResolvedPolicy(ctx, mrn)
+		s.upstreamResult(err)
+		return res, err
 	}
 
 	res, err := s.DataLake.GetResolvedPolicy(ctx, mrn.Mrn)
 	return res, err
 }
 
+// GetResolutionConflicts returns the policy conflicts that were detected
+// when the resolved policy of the given asset was computed
+func (s *LocalServices) GetResolutionConflicts(ctx context.Context, assetMrn string) ([]*PolicyConflict, error) {
+	resolvedPolicy, err := s.DataLake.GetResolvedPolicy(ctx, assetMrn)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.DataLake.GetResolutionConflicts(ctx, resolvedPolicy)
+}
+
 // StoreResults saves the given scores and date for an asset
 func (s *LocalServices) StoreResults(ctx context.Context, req *StoreResultsReq) (*Empty, error) {
 	logger.AddTag(ctx, "asset", req.AssetMrn)
 
-	_, err := s.DataLake.UpdateScores(ctx, req.AssetMrn, req.Scores)
-	if err != nil {
+	if err := s.consumeResultsQuota(ctx, req); err != nil {
 		return globalEmpty, err
 	}
 
-	_, err = s.DataLake.UpdateData(ctx, req.AssetMrn, req.Data)
+	if err := s.applyExceptions(ctx, req.AssetMrn, req.Scores); err != nil {
+		return globalEmpty, err
+	}
+
+	var prevScores map[string]*Score
+	if s.Notifier != nil {
+		prevScores = s.previousScores(ctx, req.AssetMrn, req.Scores)
+	}
+
+	updatedScores, updatedData, err := s.storeResults(ctx, req)
 	if err != nil {
 		return globalEmpty, err
 	}
+	s.Metrics.storedScores(len(req.Scores), len(updatedScores))
+	s.Metrics.storedData(len(req.Data), len(updatedData))
+
+	if s.Notifier != nil {
+		s.notifyScoreChanges(ctx, req.AssetMrn, prevScores, req.Scores, updatedScores)
+	}
+
+	if err := s.DataLake.AppendScoreHistory(ctx, req.AssetMrn, req.Scores); err != nil {
+		return globalEmpty, err
+	}
+
+	if s.Upstream != nil && !s.Incognito && !s.UpstreamBreaker.Allow() {
+		s.queueUpload(ctx, req)
+		return globalEmpty, nil
+	}
 
-	if s.Upstream != nil && !s.Incognito {
-		_, err := s.Upstream.PolicyResolver.StoreResults(ctx, req)
+	if s.useUpstream() {
+		upstreamReq := req
+		data, hashes := s.UploadTracker.changedData(req.AssetMrn, req.Data)
+		if len(data) != len(req.Data) {
+			// upstream already has all datapoints and there is nothing else to send
+			if len(data) == 0 && len(req.Scores) == 0 && len(req.CvssScores) == 0 && len(req.NotifyUpdates) == 0 {
+				return globalEmpty, nil
+			}
+			upstreamReq = &StoreResultsReq{
+				AssetMrn:       req.AssetMrn,
+				Scores:         req.Scores,
+				Data:           data,
+				CvssScores:     req.CvssScores,
+				IsPreprocessed: req.IsPreprocessed,
+				NotifyUpdates:  req.NotifyUpdates,
+			}
+		}
+
+		_, err := s.Upstream.PolicyResolver.StoreResults(ctx, upstreamReq)
+		s.upstreamResult(err)
 		if err != nil {
+			if IsTransientUpstreamError(err) && s.queueUpload(ctx, upstreamReq) {
+				return globalEmpty, nil
+			}
 			return globalEmpty, err
 		}
+		s.UploadTracker.commit(req.AssetMrn, hashes, len(data))
 	}
 
 	return globalEmpty, nil
 }
 
+// storeResults stores the scores and data of the request in the datalake.
+// Datalakes that implement BatchUpdater store them atomically. It returns
+// the IDs of the updated scores and data.
+func (s *LocalServices) storeResults(ctx context.Context, req *StoreResultsReq) (map[string]struct{}, map[string]types.Type, error) {
+	batcher, ok := s.DataLake.(BatchUpdater)
+	if !ok {
+		updatedScores, err := s.DataLake.UpdateScores(ctx, req.AssetMrn, req.Scores)
+		if err != nil {
+			return nil, nil, err
+		}
+		updatedData, err := s.DataLake.UpdateData(ctx, req.AssetMrn, req.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return updatedScores, updatedData, nil
+	}
+
+	res, err := batcher.ApplyBatch(ctx, &BatchUpdate{
+		AssetMrn: req.AssetMrn,
+		Scores:   req.Scores,
+		Data:     req.Data,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := res.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	updatedScores := map[string]struct{}{}
+	updatedData := map[string]types.Type{}
+	for _, entry := range res.Entries {
+		if !entry.Updated {
+			continue
+		}
+		switch entry.Kind {
+		case BatchEntryScore:
+			updatedScores[entry.ID] = struct{}{}
+		case BatchEntryData:
+			updatedData[entry.ID] = entry.Type
+		}
+	}
+	return updatedScores, updatedData, nil
+}
+
+// previousScores returns the stored scores of an asset before they are updated
+func (s *LocalServices) previousScores(ctx context.Context, assetMrn string, scores []*Score) map[string]*Score {
+	res := make(map[string]*Score, len(scores))
+	for i := range scores {
+		score, err := s.DataLake.GetScore(ctx, assetMrn, scores[i].QrId)
+		if err != nil {
+			continue
+		}
+		res[scores[i].QrId] = &score
+	}
+	return res
+}
+
+func (s *LocalServices) notifyScoreChanges(ctx context.Context, assetMrn string, prev map[string]*Score, scores []*Score, updated map[string]struct{}) {
+	changes := scoreChanges(assetMrn, prev, scores, updated)
+	if len(changes) == 0 {
+		return
+	}
+
+	// the policies of a score are only needed for routing, if they can't
+	// be found, notifications are still sent
+	if resolvedPolicy, err := s.DataLake.GetResolvedPolicy(ctx, assetMrn); err == nil {
+		policies := scorePolicies(resolvedPolicy)
+		for i := range changes {
+			changes[i].Policies = policies[changes[i].QrId]
+		}
+	}
+
+	s.Notifier.NotifyScoreChanges(ctx, changes)
+}
+
 // GetReport retrieves a report for a given asset and policy
 func (s *LocalServices) GetReport(ctx context.Context, req *EntityScoreReq) (*Report, error) {
 	return s.DataLake.GetReport(ctx, req.EntityMrn, req.ScoreMrn)
@@ -284,12 +436,6 @@ func (s *LocalServices) CreatePolicyObject(policyMrn string, ownerMrn string) *P
 // POLICY RESOLUTION
 // =====================
 
-const (
-	maxResolveRetry              = 3
-	maxResolveRetryBackoff       = 25 * time.Millisecond
-	maxResolveRetryBackoffjitter = 25 * time.Millisecond
-)
-
 var ErrRetryResolution = errors.New("retry policy resolution")
 
 type policyResolutionError struct {
@@ -314,6 +460,20 @@ type resolverCache struct {
 	reportingJobsActive     map[string]bool
 	errors                  []*policyResolutionError
 	bundleMap               *PolicyBundleMap
+
+	// conflict detection across policies, see PolicyConflict
+	impactOverrides map[string]*impactOverride // parent job UUID + child job UUID => override
+	activatedBy     map[string][]string        // query/policy MRN => policies that added it
+	deactivatedBy   map[string][]string        // query/policy MRN => policies that removed it
+	conflicts       map[string]*PolicyConflict
+
+	// provenance of the impact of all child jobs, see ImpactProvenance
+	impactProvenance map[string]*ImpactProvenance // parent job UUID + child job UUID => provenance
+	// impactProvenance by child job QrId, set once the resolution is done
+	impactProvenanceList map[string][]*ImpactProvenance
+
+	// active exceptions of the asset by check MRN, see Exception
+	exceptions map[string]*Exception
 }
 
 type policyResolverCache struct {
@@ -322,6 +482,7 @@ type policyResolverCache struct {
 	parentPolicies  map[string]struct{} // tracks policies in the ancestry, to prevent loops
 	childPolicies   map[string]struct{} // tracks policies that were added below (at any level)
 	childQueries    map[string]struct{} // tracks queries that were added below (at any level)
+	policyMrn       string              // the policy that is currently being resolved
 	global          *resolverCache
 }
 
@@ -332,6 +493,14 @@ func checksum2string(checksum uint64) string {
 }
 
 func checksumStrings(strings ...string) string {
+	if GetHashAlgorithm() == HashSHA256 {
+		checksum := NewChecksum()
+		for i := range strings {
+			checksum = checksum.Add(strings[i])
+		}
+		return checksum.String()
+	}
+
 	checksum := fnv1a.Init64
 	for i := range strings {
 		checksum = fnv1a.AddString64(checksum, strings[i])
@@ -375,19 +544,41 @@ func (p *policyResolverCache) addChildren(other *policyResolverCache) {
 	}
 }
 
-func (s *LocalServices) resolve(ctx context.Context, policyMrn string, assetFilters []*explorer.Mquery) (*ResolvedPolicy, error) {
+func (s *LocalServices) resolve(ctx context.Context, policyMrn string, assetFilters []*explorer.Mquery) (_ *ResolvedPolicy, err error) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "resolver/resolve")
+	span.SetAttributes(attribute.String("policy", policyMrn))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+		s.Metrics.observeResolve(start, err)
+	}()
+
+	opts := s.ResolverOptions.withDefaults()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
 	logCtx := logger.FromContext(ctx)
-	for i := 0; i < maxResolveRetry; i++ {
+	for i := 0; i <= opts.MaxRetries; i++ {
 		resolvedPolicy, err := s.tryResolve(ctx, policyMrn, assetFilters)
 		if err != nil {
 			if !errors.Is(err, ErrRetryResolution) {
 				return nil, err
 			}
-			if i+1 < maxResolveRetry {
-				jitter := time.Duration(rand.Int63n(int64(maxResolveRetryBackoffjitter)))
-				sleepTime := maxResolveRetryBackoff + jitter
+			if i < opts.MaxRetries {
+				sleepTime := opts.retryWait()
 				logCtx.Error().Int("try", i+1).Dur("sleepTime", sleepTime).Msg("retrying policy resolution")
-				time.Sleep(sleepTime)
+				s.Metrics.retry()
+				span.AddEvent("retry", trace.WithAttributes(attribute.Int("try", i+1)))
+				if err := sleepContext(ctx, sleepTime); err != nil {
+					return nil, err
+				}
 			}
 		} else {
 			return resolvedPolicy, nil
@@ -396,71 +587,217 @@ func (s *LocalServices) resolve(ctx context.Context, policyMrn string, assetFilt
 	return nil, errors.New("concurrent policy resolve")
 }
 
-func (s *LocalServices) tryResolve(ctx context.Context, policyMrn string, assetFilters []*explorer.Mquery) (*ResolvedPolicy, error) {
-	logCtx := logger.FromContext(ctx)
+// resolveInput is everything that determines a resolved policy
+type resolveInput struct {
+	policyMrn            string
+	allFiltersChecksum   string
+	assetFiltersChecksum string
+	inheritedProps       []*explorer.Property
+	inheritedChecksum    string
+	exceptions           []*Exception
+	exceptionsSum        string
+	criticality          AssetCriticality
+	criticalitySum       string
+	// set by matchBundle
+	bundleMap       *PolicyBundleMap
+	policyObj       *Policy
+	matchingFilters []*explorer.Mquery
+	// set by matchSpacePolicy, if the asset shares the resolved policy of
+	// its space
+	space                *Policy
+	spaceFiltersChecksum string
+}
 
-	// phase 1: resolve asset filters and see if we can find a cached policy
+// resolveInputs collects everything that determines the resolved policy,
+// before the bundle is loaded
+func (s *LocalServices) resolveInputs(ctx context.Context, policyMrn string, assetFilters []*explorer.Mquery) (*resolveInput, error) {
 	// trying first with all asset filters
 	allFiltersChecksum, err := ChecksumAssetFilters(assetFilters)
 	if err != nil {
 		return nil, err
 	}
+	in := &resolveInput{policyMrn: policyMrn}
 
-	var rp *ResolvedPolicy
-	rp, err = s.DataLake.CachedResolvedPolicy(ctx, policyMrn, allFiltersChecksum, V2Code)
+	// properties inherited from parent entities aren't part of the policy's
+	// checksums, so they have to be part of the cache key instead
+	in.inheritedProps, err = s.inheritedProps(ctx, policyMrn)
 	if err != nil {
 		return nil, err
 	}
-	if rp != nil {
-		return rp, nil
+	if len(in.inheritedProps) != 0 {
+		in.inheritedChecksum = propsChecksum(in.inheritedProps)
+		allFiltersChecksum = checksumStrings(allFiltersChecksum, in.inheritedChecksum)
 	}
 
-	// next we will try to only use the matching asset filters for the given policy...
-	bundle, err := s.DataLake.GetValidatedBundle(ctx, policyMrn)
+	// the same applies to exceptions, which change once they expire
+	in.exceptions, err = s.ActiveExceptions(ctx, policyMrn, time.Now())
 	if err != nil {
 		return nil, err
 	}
-	bundleMap := bundle.ToMap()
+	if len(in.exceptions) != 0 {
+		in.exceptionsSum = exceptionsChecksum(in.exceptions)
+		allFiltersChecksum = checksumStrings(allFiltersChecksum, in.exceptionsSum)
+	}
+
+	// and to the criticality of the asset, which scales the impact of checks
+	in.criticality = assetCriticalityFromContext(ctx)
+	if in.criticality.Factor() != 1 {
+		in.criticalitySum = criticalityChecksum(in.criticality)
+		allFiltersChecksum = checksumStrings(allFiltersChecksum, in.criticalitySum)
+	}
+
+	in.allFiltersChecksum = allFiltersChecksum
+	return in, nil
+}
 
-	policyObj := bundleMap.Policies[policyMrn]
-	matchingFilters, err := MatchingAssetFilters(policyMrn, assetFilters, policyObj)
+// matchBundle loads the policy's bundle and only keeps the asset filters
+// that match the policy
+func (s *LocalServices) matchBundle(ctx context.Context, in *resolveInput, assetFilters []*explorer.Mquery) error {
+	_, bundleSpan := tracer.Start(ctx, "resolver/getBundle")
+	bundle, err := s.DataLake.GetValidatedBundle(ctx, in.policyMrn)
+	bundleSpan.End()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if len(matchingFilters) == 0 {
-		return nil, NewPolicyAssetMatchError(assetFilters, policyObj)
+	in.bundleMap = bundle.ToMap()
+
+	in.policyObj = in.bundleMap.Policies[in.policyMrn]
+	if len(in.inheritedProps) != 0 && in.policyObj != nil {
+		in.policyObj = proto.Clone(in.policyObj).(*Policy)
+		in.policyObj.Props = append(in.inheritedProps, in.policyObj.Props...)
+		in.bundleMap.Policies[in.policyMrn] = in.policyObj
+	}
+	in.matchingFilters, err = MatchingAssetFilters(in.policyMrn, assetFilters, in.policyObj)
+	if err != nil {
+		return err
+	}
+	if len(in.matchingFilters) == 0 {
+		return NewPolicyAssetMatchError(assetFilters, in.policyObj)
 	}
 
-	assetFiltersMap := make(map[string]struct{}, len(matchingFilters))
-	for i := range matchingFilters {
-		assetFiltersMap[matchingFilters[i].CodeId] = struct{}{}
+	in.assetFiltersChecksum, err = ChecksumAssetFilters(in.matchingFilters)
+	if err != nil {
+		return err
+	}
+	if in.inheritedChecksum != "" {
+		in.assetFiltersChecksum = checksumStrings(in.assetFiltersChecksum, in.inheritedChecksum)
+	}
+	if in.exceptionsSum != "" {
+		in.assetFiltersChecksum = checksumStrings(in.assetFiltersChecksum, in.exceptionsSum)
 	}
+	if in.criticalitySum != "" {
+		in.assetFiltersChecksum = checksumStrings(in.assetFiltersChecksum, in.criticalitySum)
+	}
+	return nil
+}
 
-	assetFiltersChecksum, err := ChecksumAssetFilters(matchingFilters)
+func (s *LocalServices) tryResolve(ctx context.Context, policyMrn string, assetFilters []*explorer.Mquery) (*ResolvedPolicy, error) {
+	// phase 1: resolve asset filters and see if we can find a cached policy
+	in, err := s.resolveInputs(ctx, policyMrn, assetFilters)
 	if err != nil {
 		return nil, err
 	}
 
+	var rp *ResolvedPolicy
+	rp, err = s.cachedResolvedPolicy(ctx, policyMrn, in.allFiltersChecksum)
+	if err != nil {
+		return nil, err
+	}
+	if rp != nil {
+		s.Metrics.cacheLookup(true)
+		return rp, nil
+	}
+
+	// next we will try to only use the matching asset filters for the given policy...
+	if err = s.matchBundle(ctx, in, assetFilters); err != nil {
+		return nil, err
+	}
+
 	// ... and if the filters changed, try to look up the resolved policy again
-	if assetFiltersChecksum != allFiltersChecksum {
-		rp, err = s.DataLake.CachedResolvedPolicy(ctx, policyMrn, assetFiltersChecksum, V2Code)
+	if in.assetFiltersChecksum != in.allFiltersChecksum {
+		rp, err = s.cachedResolvedPolicy(ctx, policyMrn, in.assetFiltersChecksum)
 		if err != nil {
 			return nil, err
 		}
 		if rp != nil {
+			s.Metrics.cacheLookup(true)
 			return rp, nil
 		}
 	}
 
+	// phase 2: optimizations for assets
+	// assets are always connected to a space, so figure out if a space policy exists.
+	// assets that only aggregate the space policy share its resolved policy
+	if err = s.matchSpacePolicy(ctx, in); err != nil {
+		return nil, err
+	}
+	if in.space != nil {
+		rp, err = s.cachedResolvedPolicy(ctx, in.space.Mrn, in.spaceFiltersChecksum)
+		if err != nil {
+			return nil, err
+		}
+		if rp != nil {
+			s.Metrics.cacheLookup(true)
+			return rp, nil
+		}
+	}
+	s.Metrics.cacheLookup(false)
+
+	resolvedPolicy, cache, err := s.buildResolvedPolicy(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.setResolvedPolicy(ctx, policyMrn, resolvedPolicy, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.DataLake.SetResolutionConflicts(ctx, resolvedPolicy, cache.conflictList())
+	if err != nil {
+		return nil, err
+	}
+
+	if store, ok := s.DataLake.(ImpactProvenanceStore); ok {
+		err = store.SetImpactProvenance(ctx, resolvedPolicy, cache.impactProvenanceList)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resolvedPolicy, nil
+}
+
+// buildResolvedPolicy runs phases 3-5 of the resolution, without storing
+// the resolved policy. It returns the resolver cache for inspection.
+func (s *LocalServices) buildResolvedPolicy(ctx context.Context, in *resolveInput) (_ *ResolvedPolicy, _ *resolverCache, err error) {
+	logCtx := logger.FromContext(ctx)
+	policyMrn := in.policyMrn
+	policyObj := in.policyObj
+
+	assetFiltersMap := make(map[string]struct{}, len(in.matchingFilters))
+	for i := range in.matchingFilters {
+		assetFiltersMap[in.matchingFilters[i].CodeId] = struct{}{}
+	}
+
 	// intermission: prep for the other phases
 	logCtx.Debug().
 		Str("policy mrn", policyMrn).
-		Interface("asset filters", matchingFilters).
+		Interface("asset filters", in.matchingFilters).
 		Msg("resolver> phase 1: no cached result, resolve the policy now")
 
+	// resolved policies that are shared by a space are identified by the
+	// space policy's graph
+	graphExecutionChecksum := policyObj.GraphExecutionChecksum
+	filtersChecksum := in.assetFiltersChecksum
+	if in.space != nil {
+		graphExecutionChecksum = in.space.GraphExecutionChecksum
+		filtersChecksum = in.spaceFiltersChecksum
+	}
+
 	cache := &resolverCache{
-		graphExecutionChecksum:  policyObj.GraphExecutionChecksum,
-		assetFiltersChecksum:    assetFiltersChecksum,
+		graphExecutionChecksum:  graphExecutionChecksum,
+		assetFiltersChecksum:    in.assetFiltersChecksum,
 		assetFilters:            assetFiltersMap,
 		executionQueries:        map[string]*ExecutionQuery{},
 		dataQueries:             map[string]struct{}{},
@@ -469,10 +806,24 @@ func (s *LocalServices) tryResolve(ctx context.Context, policyMrn string, assetF
 		reportingJobsByChecksum: map[string]*ReportingJob{},
 		reportingJobsByUUID:     map[string]*ReportingJob{},
 		reportingJobsActive:     map[string]bool{},
-		bundleMap:               bundleMap,
-	}
+		bundleMap:               in.bundleMap,
+		impactOverrides:         map[string]*impactOverride{},
+		activatedBy:             map[string][]string{},
+		deactivatedBy:           map[string][]string{},
+		conflicts:               map[string]*PolicyConflict{},
+		impactProvenance:        map[string]*ImpactProvenance{},
+		exceptions:              make(map[string]*Exception, len(in.exceptions)),
+	}
+	for i := range in.exceptions {
+		cache.exceptions[in.exceptions[i].CheckMrn] = in.exceptions[i]
+	}
+	defer func() {
+		if err != nil {
+			s.writeResolverSnapshot(ctx, policyMrn, cache, err)
+		}
+	}()
 
-	rjUUID := cache.relativeChecksum(policyObj.GraphExecutionChecksum)
+	rjUUID := cache.relativeChecksum(graphExecutionChecksum)
 
 	reportingJob := &ReportingJob{
 		Uuid:       rjUUID,
@@ -484,12 +835,6 @@ func (s *LocalServices) tryResolve(ctx context.Context, policyMrn string, assetF
 	cache.reportingJobsByUUID[reportingJob.Uuid] = reportingJob
 	cache.reportingJobsByChecksum[reportingJob.QrId] = reportingJob
 
-	// phase 2: optimizations for assets
-	// assets are always connected to a space, so figure out if a space policy exists
-	// everything else in an asset can be aggregated into a shared policy
-
-	// TODO: IMPLEMENT
-
 	// phase 3: build the policy and scoring tree
 	policyToJobsCache := &policyResolverCache{
 		removedPolicies: map[string]struct{}{},
@@ -505,12 +850,37 @@ func (s *LocalServices) tryResolve(ctx context.Context, policyMrn string, assetF
 			Err(err).
 			Str("policy", policyMrn).
 			Msg("resolver> phase 3: internal error, trying to turn policy mrn into jobs")
-		return nil, err
+		return nil, nil, err
 	}
 	logCtx.Debug().
 		Str("policy", policyMrn).
 		Msg("resolver> phase 3: turn policy into jobs [ok]")
 
+	sources := SourceMapFromContext(ctx)
+	for i := range cache.errors {
+		resolutionErr := cache.errors[i]
+		event := logCtx.Warn().
+			Str("policy", policyMrn).
+			Str("id", resolutionErr.ID)
+		if loc, ok := sources.Lookup(resolutionErr.ID); ok {
+			event = event.Str("source", loc.String())
+		}
+		event.Msg("resolver> phase 3: " + resolutionErr.Error)
+	}
+
+	cache.detectDeactivationConflicts()
+	conflicts := cache.conflictList()
+	for i := range conflicts {
+		conflict := conflicts[i]
+		logCtx.Warn().
+			Str("policy", policyMrn).
+			Str("kind", string(conflict.Kind)).
+			Str("id", conflict.ID).
+			Strs("applied", conflict.Applied).
+			Strs("overridden", conflict.Overridden).
+			Msg("resolver> phase 3: detected conflicting policy changes")
+	}
+
 	// phase 4: get all queries + assign them reporting jobs + update scoring jobs
 	executionJob, collectorJob, err := s.jobsToQueries(ctx, policyMrn, cache)
 	if err != nil {
@@ -518,35 +888,44 @@ func (s *LocalServices) tryResolve(ctx context.Context, policyMrn string, assetF
 			Err(err).
 			Str("policy", policyMrn).
 			Msg("resolver> phase 4: internal error, trying to turn policy jobs into queries")
-		return nil, err
+		return nil, nil, err
 	}
 	logCtx.Debug().
 		Str("policy", policyMrn).
 		Msg("resolver> phase 4: aggregate queries and jobs [ok]")
 
+	weightByCriticality(collectorJob, in.criticality)
+	cache.impactProvenanceList = cache.collectImpactProvenance(collectorJob, in.criticality)
+
 	// phase 5: refresh all checksums
+	_, checksumSpan := tracer.Start(ctx, "resolver/refreshChecksums")
 	s.refreshChecksums(executionJob, collectorJob)
+	checksumSpan.End()
 
 	// the final phases are done in the DataLake
-	for _, rj := range collectorJob.ReportingJobs {
-		rj.RefreshChecksum()
-	}
 
-	resolvedPolicy := ResolvedPolicy{
-		GraphExecutionChecksum: policyObj.GraphExecutionChecksum,
-		Filters:                matchingFilters,
-		FiltersChecksum:        assetFiltersChecksum,
+	return &ResolvedPolicy{
+		GraphExecutionChecksum: graphExecutionChecksum,
+		Filters:                in.matchingFilters,
+		FiltersChecksum:        filtersChecksum,
 		ExecutionJob:           executionJob,
 		CollectorJob:           collectorJob,
 		ReportingJobUuid:       reportingJob.Uuid,
-	}
+	}, cache, nil
+}
 
-	err = s.DataLake.SetResolvedPolicy(ctx, policyMrn, &resolvedPolicy, V2Code, false)
-	if err != nil {
-		return nil, err
+// cachedResolvedPolicy looks up a cached resolved policy in the datalake
+func (s *LocalServices) cachedResolvedPolicy(ctx context.Context, policyMrn string, filtersChecksum string) (*ResolvedPolicy, error) {
+	if bypassResolvedPolicyCache(ctx) {
+		return nil, nil
 	}
 
-	return &resolvedPolicy, nil
+	ctx, span := tracer.Start(ctx, "resolver/cachedResolvedPolicy")
+	defer span.End()
+
+	rp, err := s.DataLake.CachedResolvedPolicy(ctx, policyMrn, filtersChecksum, V2Code)
+	span.SetAttributes(attribute.Bool("hit", rp != nil))
+	return rp, err
 }
 
 func NewPolicyAssetMatchError(assetFilters []*explorer.Mquery, p *Policy) error {
@@ -596,7 +975,7 @@ func (s *LocalServices) refreshChecksums(executionJob *ExecutionJob, collectorJo
 		}
 		sort.Strings(queryKeys)
 
-		checksum := checksums.New
+		checksum := NewChecksum()
 		checksum = checksum.Add("v2")
 		for i := range queryKeys {
 			key := queryKeys[i]
@@ -607,12 +986,13 @@ func (s *LocalServices) refreshChecksums(executionJob *ExecutionJob, collectorJo
 
 	// collector job
 	{
-		checksum := checksums.New
+		checksum := NewChecksum()
 		{
+			refreshReportingJobChecksums(collectorJob.ReportingJobs, s.ChecksumWorkers)
+
 			reportingJobKeys := make([]string, len(collectorJob.ReportingJobs))
 			i := 0
-			for k, rj := range collectorJob.ReportingJobs {
-				rj.RefreshChecksum()
+			for k := range collectorJob.ReportingJobs {
 				reportingJobKeys[i] = k
 				i++
 			}
@@ -659,7 +1039,7 @@ func (s *LocalServices) policyToJobs(ctx context.Context, policyMrn string, owne
 
 	policyObj, ok := parentCache.global.bundleMap.Policies[policyMrn]
 	if !ok || policyObj == nil {
-		return errors.New("cannot find policy '" + policyMrn + "' while resolving")
+		return &ServiceError{Kind: ErrPolicyNotFound, Mrn: policyMrn, Msg: "cannot find policy '" + policyMrn + "' while resolving"}
 	}
 
 	if len(policyObj.Groups) == 0 {
@@ -668,6 +1048,7 @@ func (s *LocalServices) policyToJobs(ctx context.Context, policyMrn string, owne
 
 	cache := parentCache.clone()
 	cache.parentPolicies[policyMrn] = struct{}{}
+	cache.policyMrn = policyMrn
 
 	// properties to execution queries cache
 	parentCache.global.propsCache.Add(policyObj.Props...)
@@ -698,18 +1079,21 @@ func (s *LocalServices) policyToJobs(ctx context.Context, policyMrn string, owne
 			policy := group.Policies[i]
 			if policy.Action == PolicyRef_DEACTIVATE {
 				cache.removedPolicies[policy.Mrn] = struct{}{}
+				cache.global.deactivatedBy[policy.Mrn] = appendUnique(cache.global.deactivatedBy[policy.Mrn], policyMrn)
 			}
 		}
 		for i := range group.Checks {
 			check := group.Checks[i]
 			if check.Action == explorer.Mquery_DELETE {
 				cache.removedQueries[check.Mrn] = struct{}{}
+				cache.global.deactivatedBy[check.Mrn] = appendUnique(cache.global.deactivatedBy[check.Mrn], policyMrn)
 			}
 		}
 		for i := range group.Queries {
-			query := group.Checks[i]
+			query := group.Queries[i]
 			if query.Action == explorer.Mquery_DELETE {
 				cache.removedQueries[query.Mrn] = struct{}{}
+				cache.global.deactivatedBy[query.Mrn] = appendUnique(cache.global.deactivatedBy[query.Mrn], policyMrn)
 			}
 		}
 	}
@@ -757,7 +1141,7 @@ func (s *LocalServices) policyspecToJobs(ctx context.Context, group *PolicyGroup
 			// this set of asset filters
 			policyObj, ok := cache.global.bundleMap.Policies[policy.Mrn]
 			if !ok || policyObj == nil {
-				return errors.New("cannot find policy '" + policy.Mrn + "' while resolving")
+				return &ServiceError{Kind: ErrPolicyNotFound, Mrn: policy.Mrn, Msg: "cannot find policy '" + policy.Mrn + "' while resolving"}
 			}
 
 			var found bool
@@ -788,7 +1172,9 @@ func (s *LocalServices) policyspecToJobs(ctx context.Context, group *PolicyGroup
 			// local aspects for the resolved policy
 			policyJob.Notify = append(policyJob.Notify, ownerJob.Uuid)
 			ownerJob.ChildJobs[policyJob.Uuid] = scoring
+			cache.addImpactProvenance(ownerJob, policyJob, policy.Mrn, true, scoring, false)
 			cache.childPolicies[policy.Mrn] = struct{}{}
+			cache.global.activatedBy[policy.Mrn] = appendUnique(cache.global.activatedBy[policy.Mrn], cache.policyMrn)
 
 			if err := s.policyToJobs(ctx, policy.Mrn, policyJob, cache); err != nil {
 				return err
@@ -813,7 +1199,7 @@ func (s *LocalServices) policyspecToJobs(ctx context.Context, group *PolicyGroup
 			for _, id := range policyJob.Notify {
 				parentJob := cache.global.reportingJobsByUUID[id]
 				if parentJob != nil {
-					parentJob.ChildJobs[policyJob.Uuid] = scoring
+					cache.setChildImpact(parentJob, policyJob, policy.Mrn, true, scoring)
 				}
 			}
 		}
@@ -849,12 +1235,15 @@ func (s *LocalServices) policyspecToJobs(ctx context.Context, group *PolicyGroup
 				cache.global.reportingJobsByChecksum[check.Checksum] = queryJob
 				cache.global.reportingJobsByUUID[queryJob.Uuid] = queryJob
 			}
+			informational := cache.global.markInformational(queryJob, check)
 
 			// local aspects for the resolved policy
 			queryJob.Notify = append(queryJob.Notify, ownerJob.Uuid)
 
 			ownerJob.ChildJobs[queryJob.Uuid] = scoringSpec
+			cache.addImpactProvenance(ownerJob, queryJob, check.Mrn, false, check.Impact, informational)
 			cache.childQueries[check.Mrn] = struct{}{}
+			cache.global.activatedBy[check.Mrn] = appendUnique(cache.global.activatedBy[check.Mrn], cache.policyMrn)
 
 			// we set a placeholder for the execution query, just to indicate it will be added
 			cache.global.executionQueries[check.Checksum] = nil
@@ -876,10 +1265,11 @@ func (s *LocalServices) policyspecToJobs(ctx context.Context, group *PolicyGroup
 			}
 
 			queryJob := cache.global.reportingJobsByChecksum[check.Checksum]
+			cache.global.markInformational(queryJob, check)
 			for _, id := range queryJob.Notify {
 				parentJob := cache.global.reportingJobsByUUID[id]
 				if parentJob != nil {
-					parentJob.ChildJobs[queryJob.Uuid] = scoringSpec
+					cache.setChildImpact(parentJob, queryJob, check.Mrn, false, scoringSpec)
 				}
 			}
 
@@ -925,6 +1315,7 @@ func (s *LocalServices) policyspecToJobs(ctx context.Context, group *PolicyGroup
 
 			ownerJob.Datapoints[queryJob.Uuid] = true
 			cache.childQueries[query.Mrn] = struct{}{}
+			cache.global.activatedBy[query.Mrn] = appendUnique(cache.global.activatedBy[query.Mrn], cache.policyMrn)
 
 			// we set a placeholder for the execution query, just to indicate it will be added
 			cache.global.executionQueries[query.Checksum] = nil
@@ -950,6 +1341,7 @@ func (s *LocalServices) jobsToQueries(ctx context.Context, policyMrn string, cac
 	defer span.End()
 
 	logCtx := logger.FromContext(ctx)
+	sources := SourceMapFromContext(ctx)
 	collectorJob := &CollectorJob{
 		ReportingJobs:    map[string]*ReportingJob{},
 		ReportingQueries: map[string]*StringArray{},
@@ -1004,7 +1396,7 @@ func (s *LocalServices) jobsToQueries(ctx context.Context, policyMrn string, cac
 
 				executionQuery, dataChecksum, err := mquery2executionQuery(prop, nil, map[string]string{}, collectorJob, false)
 				if err != nil {
-					return nil, nil, errors.New("resolver> failed to compile query for MRN " + prop.Mrn + ": " + err.Error())
+					return nil, nil, sources.Wrap(errors.New("resolver> failed to compile query for MRN "+prop.Mrn+": "+err.Error()), prop.Mrn)
 				}
 				if dataChecksum == "" {
 					return nil, nil, errors.New("property returns too many value, cannot determine entrypoint checksum: '" + prop.Mql + "'")
@@ -1019,7 +1411,7 @@ func (s *LocalServices) jobsToQueries(ctx context.Context, policyMrn string, cac
 
 		executionQuery, _, err := mquery2executionQuery(query, propTypes, propToChecksums, collectorJob, !isDataQuery)
 		if err != nil {
-			return nil, nil, errors.New("resolver> failed to compile query for MRN " + query.Mrn + ": " + err.Error())
+			return nil, nil, sources.Wrap(errors.New("resolver> failed to compile query for MRN "+query.Mrn+": "+err.Error()), query.Mrn)
 		}
 
 		if executionQuery == nil {
@@ -1052,7 +1444,7 @@ func (s *LocalServices) jobsToQueries(ctx context.Context, policyMrn string, cac
 				Str("query", query.Mrn).
 				Str("policy", policyMrn).
 				Msg("resolver> phase 2: cannot find reporting job")
-			return nil, nil, errors.New("cannot find reporting job for query " + query.Mrn + " in policy " + policyMrn)
+			return nil, nil, sources.Wrap(errors.New("cannot find reporting job for query "+query.Mrn+" in policy "+policyMrn), query.Mrn)
 		}
 
 		// (2) Scoring Queries handling
@@ -1186,6 +1578,25 @@ func mquery2executionQuery(query queryLike, props map[string]*llx.Primitive, pro
 	return &res, dataChecksum, nil
 }
 
+// setResolvedPolicy stores the resolved policy. If the datalake can't cache
+// it because its cache is full, the resolved policy is used uncached, unless
+// the cache is required.
+func (s *LocalServices) setResolvedPolicy(ctx context.Context, mrn string, resolvedPolicy *ResolvedPolicy, cached bool) error {
+	err := s.DataLake.SetResolvedPolicy(ctx, mrn, resolvedPolicy, V2Code, cached)
+	if err == nil || !errors.Is(err, ErrResolvedPolicyCacheFull) {
+		return err
+	}
+
+	s.Metrics.cacheRejected()
+	if s.RequireResolvedPolicyCache {
+		return err
+	}
+	logger.FromContext(ctx).Warn().
+		Str("policy", mrn).
+		Msg("resolver> resolved policy cache is full, continuing without caching the resolved policy")
+	return nil
+}
+
 func (s *LocalServices) cacheUpstreamJobs(ctx context.Context, assetMrn string, resolvedPolicy *ResolvedPolicy) error {
 	var err error
 
@@ -1193,7 +1604,7 @@ func (s *LocalServices) cacheUpstreamJobs(ctx context.Context, assetMrn string,
 		return errors.New("resolver> failed to cache upstream jobs: " + err.Error())
 	}
 
-	err = s.DataLake.SetResolvedPolicy(ctx, assetMrn, resolvedPolicy, V2Code, true)
+	err = s.setResolvedPolicy(ctx, assetMrn, resolvedPolicy, true)
 	if err != nil {
 		return errors.New("resolver> failed to cache resolved upstream policy: " + err.Error())
 	}
@@ -0,0 +1,72 @@
+package policy
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultResolveMaxRetries = 2
+	DefaultResolveBackoff    = 25 * time.Millisecond
+	DefaultResolveJitter     = 25 * time.Millisecond
+)
+
+// ResolverOptions tune how policy resolutions are retried when they collide
+// with concurrent resolutions of the same policy, e.g. when many assets
+// with the same policies are scanned at once
+type ResolverOptions struct {
+	// MaxRetries is how often a resolution is retried after its first
+	// attempt. 0 uses DefaultResolveMaxRetries, a negative value disables
+	// retries.
+	MaxRetries int
+	// Backoff is the wait before every retry. 0 uses DefaultResolveBackoff.
+	Backoff time.Duration
+	// Jitter is the maximum random time that is added to the backoff, so that
+	// colliding resolutions don't retry in lockstep. 0 uses
+	// DefaultResolveJitter, a negative value disables it.
+	Jitter time.Duration
+	// Timeout limits a resolution with all of its retries. 0 only stops at the
+	// deadline of the request's context.
+	Timeout time.Duration
+}
+
+func (o ResolverOptions) withDefaults() ResolverOptions {
+	if o.MaxRetries == 0 {
+		o.MaxRetries = DefaultResolveMaxRetries
+	} else if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = DefaultResolveBackoff
+	}
+	if o.Jitter == 0 {
+		o.Jitter = DefaultResolveJitter
+	} else if o.Jitter < 0 {
+		o.Jitter = 0
+	}
+	return o
+}
+
+// retryWait returns how long to wait before the next retry
+func (o ResolverOptions) retryWait() time.Duration {
+	if o.Jitter <= 0 {
+		return o.Backoff
+	}
+	return o.Backoff + time.Duration(rand.Int63n(int64(o.Jitter)))
+}
+
+// sleepContext waits for the duration, unless the context is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "stopped waiting to retry policy resolution")
+	case <-timer.C:
+		return nil
+	}
+}
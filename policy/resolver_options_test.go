@@ -0,0 +1,46 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		opts := ResolverOptions{}.withDefaults()
+		assert.Equal(t, DefaultResolveMaxRetries, opts.MaxRetries)
+		assert.Equal(t, DefaultResolveBackoff, opts.Backoff)
+		assert.Equal(t, DefaultResolveJitter, opts.Jitter)
+		assert.Equal(t, time.Duration(0), opts.Timeout)
+	})
+
+	t.Run("disabled retries and jitter", func(t *testing.T) {
+		opts := ResolverOptions{MaxRetries: -1, Backoff: time.Second, Jitter: -1}.withDefaults()
+		assert.Equal(t, 0, opts.MaxRetries)
+		assert.Equal(t, time.Second, opts.retryWait())
+	})
+
+	t.Run("jitter is added to the backoff", func(t *testing.T) {
+		opts := ResolverOptions{Backoff: time.Second, Jitter: time.Millisecond}.withDefaults()
+		for i := 0; i < 10; i++ {
+			wait := opts.retryWait()
+			assert.GreaterOrEqual(t, wait, time.Second)
+			assert.Less(t, wait, time.Second+time.Millisecond)
+		}
+	})
+}
+
+func TestSleepContext(t *testing.T) {
+	require.NoError(t, sleepContext(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := sleepContext(ctx, time.Hour)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Minute)
+}
@@ -0,0 +1,250 @@
+package policy
+
+import (
+	"context"
+	"encoding/gob"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// ResolverSnapshotError is an error that was collected while resolving
+type ResolverSnapshotError struct {
+	ID       string
+	IsPolicy bool
+	Error    string
+}
+
+// ResolverSnapshot is the intermediate state of a policy resolution. It is
+// written for resolutions that fail, so that they can be reproduced, see
+// LocalServices.ResolverSnapshotDir.
+type ResolverSnapshot struct {
+	PolicyMrn              string
+	Created                time.Time
+	GraphExecutionChecksum string
+	AssetFiltersChecksum   string
+	// AssetFilters are the code IDs of the asset filters that matched
+	AssetFilters []string
+	// Error is the error the resolution failed with
+	Error string
+
+	// ExecutionQueries are indexed by their checksum
+	ExecutionQueries map[string]*ExecutionQuery
+	// DataQueries are the code IDs of all data queries
+	DataQueries []string
+	// Queries are indexed by their checksum
+	Queries map[string]*explorer.Mquery
+	// ReportingJobs are indexed by their UUID
+	ReportingJobs map[string]*ReportingJob
+	// ActiveReportingJobs are the UUIDs of the reporting jobs in use
+	ActiveReportingJobs []string
+	Errors              []ResolverSnapshotError
+	Conflicts           []*PolicyConflict
+}
+
+// snapshot captures the state of the resolver cache
+func (c *resolverCache) snapshot(policyMrn string, err error) *ResolverSnapshot {
+	res := &ResolverSnapshot{
+		PolicyMrn:              policyMrn,
+		Created:                time.Now(),
+		GraphExecutionChecksum: c.graphExecutionChecksum,
+		AssetFiltersChecksum:   c.assetFiltersChecksum,
+		ExecutionQueries:       make(map[string]*ExecutionQuery, len(c.executionQueries)),
+		Queries:                make(map[string]*explorer.Mquery, len(c.queriesByChecksum)),
+		ReportingJobs:          make(map[string]*ReportingJob, len(c.reportingJobsByUUID)),
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	for id := range c.assetFilters {
+		res.AssetFilters = append(res.AssetFilters, id)
+	}
+	sort.Strings(res.AssetFilters)
+
+	// execution queries are only placeholders until phase 4
+	for checksum, query := range c.executionQueries {
+		if query != nil {
+			res.ExecutionQueries[checksum] = query
+		}
+	}
+	for id := range c.dataQueries {
+		res.DataQueries = append(res.DataQueries, id)
+	}
+	sort.Strings(res.DataQueries)
+
+	for checksum, query := range c.queriesByChecksum {
+		if query != nil {
+			res.Queries[checksum] = query
+		}
+	}
+
+	for uuid, rj := range c.reportingJobsByUUID {
+		res.ReportingJobs[uuid] = rj
+	}
+	for uuid, active := range c.reportingJobsActive {
+		if active {
+			res.ActiveReportingJobs = append(res.ActiveReportingJobs, uuid)
+		}
+	}
+	sort.Strings(res.ActiveReportingJobs)
+
+	for i := range c.errors {
+		res.Errors = append(res.Errors, ResolverSnapshotError{
+			ID:       c.errors[i].ID,
+			IsPolicy: c.errors[i].IsPolicy,
+			Error:    c.errors[i].Error,
+		})
+	}
+	if c.conflicts != nil {
+		res.Conflicts = c.conflictList()
+	}
+
+	return res
+}
+
+// resolverSnapshotFile is the encoding of a snapshot. Protos can't be
+// encoded with gob, so they are stored in their binary encoding.
+type resolverSnapshotFile struct {
+	PolicyMrn              string
+	Created                time.Time
+	GraphExecutionChecksum string
+	AssetFiltersChecksum   string
+	AssetFilters           []string
+	Error                  string
+	ExecutionQueries       map[string][]byte
+	DataQueries            []string
+	Queries                map[string][]byte
+	ReportingJobs          map[string][]byte
+	ActiveReportingJobs    []string
+	Errors                 []ResolverSnapshotError
+	Conflicts              []*PolicyConflict
+}
+
+func marshalProtoMap[T proto.Message](m map[string]T) (map[string][]byte, error) {
+	res := make(map[string][]byte, len(m))
+	for k, v := range m {
+		data, err := proto.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode "+k)
+		}
+		res[k] = data
+	}
+	return res, nil
+}
+
+func unmarshalProtoMap[T proto.Message](m map[string][]byte, newT func() T) (map[string]T, error) {
+	res := make(map[string]T, len(m))
+	for k, data := range m {
+		v := newT()
+		if err := proto.Unmarshal(data, v); err != nil {
+			return nil, errors.Wrap(err, "failed to decode "+k)
+		}
+		res[k] = v
+	}
+	return res, nil
+}
+
+// Write encodes the snapshot with gob
+func (s *ResolverSnapshot) Write(w io.Writer) error {
+	file := resolverSnapshotFile{
+		PolicyMrn:              s.PolicyMrn,
+		Created:                s.Created,
+		GraphExecutionChecksum: s.GraphExecutionChecksum,
+		AssetFiltersChecksum:   s.AssetFiltersChecksum,
+		AssetFilters:           s.AssetFilters,
+		Error:                  s.Error,
+		DataQueries:            s.DataQueries,
+		ActiveReportingJobs:    s.ActiveReportingJobs,
+		Errors:                 s.Errors,
+		Conflicts:              s.Conflicts,
+	}
+
+	var err error
+	if file.ExecutionQueries, err = marshalProtoMap(s.ExecutionQueries); err != nil {
+		return err
+	}
+	if file.Queries, err = marshalProtoMap(s.Queries); err != nil {
+		return err
+	}
+	if file.ReportingJobs, err = marshalProtoMap(s.ReportingJobs); err != nil {
+		return err
+	}
+	return gob.NewEncoder(w).Encode(&file)
+}
+
+// ReadResolverSnapshot decodes a snapshot that was written with Write
+func ReadResolverSnapshot(r io.Reader) (*ResolverSnapshot, error) {
+	var file resolverSnapshotFile
+	if err := gob.NewDecoder(r).Decode(&file); err != nil {
+		return nil, errors.Wrap(err, "failed to read resolver snapshot")
+	}
+
+	res := &ResolverSnapshot{
+		PolicyMrn:              file.PolicyMrn,
+		Created:                file.Created,
+		GraphExecutionChecksum: file.GraphExecutionChecksum,
+		AssetFiltersChecksum:   file.AssetFiltersChecksum,
+		AssetFilters:           file.AssetFilters,
+		Error:                  file.Error,
+		DataQueries:            file.DataQueries,
+		ActiveReportingJobs:    file.ActiveReportingJobs,
+		Errors:                 file.Errors,
+		Conflicts:              file.Conflicts,
+	}
+
+	var err error
+	if res.ExecutionQueries, err = unmarshalProtoMap(file.ExecutionQueries, func() *ExecutionQuery { return &ExecutionQuery{} }); err != nil {
+		return nil, err
+	}
+	if res.Queries, err = unmarshalProtoMap(file.Queries, func() *explorer.Mquery { return &explorer.Mquery{} }); err != nil {
+		return nil, err
+	}
+	if res.ReportingJobs, err = unmarshalProtoMap(file.ReportingJobs, func() *ReportingJob { return &ReportingJob{} }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// resolverSnapshotName returns a unique file name for the snapshot
+func resolverSnapshotName(snapshot *ResolverSnapshot) string {
+	id := checksumStrings(snapshot.PolicyMrn, snapshot.AssetFiltersChecksum)
+	id = strings.NewReplacer("/", "_", "+", "-", "=", "").Replace(id)
+	return "resolver-" + snapshot.Created.UTC().Format("20060102T150405") + "-" + id + ".gob"
+}
+
+// writeResolverSnapshot writes the state of a failed resolution to the
+// snapshot directory. Failures are only logged, they must not hide the
+// error of the resolution.
+func (s *LocalServices) writeResolverSnapshot(ctx context.Context, policyMrn string, cache *resolverCache, resolveErr error) {
+	if s.ResolverSnapshotDir == "" || cache == nil {
+		return
+	}
+
+	snapshot := cache.snapshot(policyMrn, resolveErr)
+	path := filepath.Join(s.ResolverSnapshotDir, resolverSnapshotName(snapshot))
+	err := os.MkdirAll(s.ResolverSnapshotDir, 0o755)
+	if err == nil {
+		var f *os.File
+		f, err = os.Create(path)
+		if err == nil {
+			err = snapshot.Write(f)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn().Err(err).Str("policy", policyMrn).Msg("resolver> failed to write snapshot of failed resolution")
+		return
+	}
+	logger.FromContext(ctx).Warn().Str("policy", policyMrn).Str("path", path).Msg("resolver> wrote snapshot of failed resolution")
+}
@@ -0,0 +1,72 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSnapshotCache() *resolverCache {
+	global, parent, child := testConflictCache()
+	global.graphExecutionChecksum = "graph"
+	global.assetFiltersChecksum = "filters"
+	global.assetFilters = map[string]struct{}{"filter-b": {}, "filter-a": {}}
+	global.executionQueries = map[string]*ExecutionQuery{
+		"checksum1": {Query: "true", Checksum: "checksum1"},
+		"checksum2": nil,
+	}
+	global.dataQueries = map[string]struct{}{"data1": {}}
+	global.queriesByChecksum = map[string]*explorer.Mquery{"checksum1": {Mrn: "//check", Mql: "true"}}
+	global.reportingJobsByUUID = map[string]*ReportingJob{parent.Uuid: parent, child.Uuid: child}
+	global.reportingJobsActive = map[string]bool{parent.Uuid: true, child.Uuid: false}
+	global.errors = []*policyResolutionError{{ID: "//check", Error: "cannot modify query, it doesn't exist"}}
+	parent.ChildJobs[child.Uuid] = &explorer.Impact{Value: 80}
+	return global
+}
+
+func TestResolverSnapshot(t *testing.T) {
+	snapshot := testSnapshotCache().snapshot("//policy", errors.New("failed"))
+	assert.Equal(t, "failed", snapshot.Error)
+	assert.Equal(t, []string{"filter-a", "filter-b"}, snapshot.AssetFilters)
+	assert.Equal(t, []string{"parent"}, snapshot.ActiveReportingJobs)
+	assert.Len(t, snapshot.ExecutionQueries, 1)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "snapshot.gob"))
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, snapshot.Write(f))
+	_, err = f.Seek(0, 0)
+	require.NoError(t, err)
+
+	read, err := ReadResolverSnapshot(f)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.PolicyMrn, read.PolicyMrn)
+	assert.Equal(t, snapshot.Errors, read.Errors)
+	assert.Equal(t, snapshot.DataQueries, read.DataQueries)
+	require.Len(t, read.ReportingJobs, 2)
+	assert.True(t, proto.Equal(snapshot.ReportingJobs["parent"], read.ReportingJobs["parent"]))
+	assert.True(t, proto.Equal(snapshot.ExecutionQueries["checksum1"], read.ExecutionQueries["checksum1"]))
+	assert.Equal(t, "//check", read.Queries["checksum1"].Mrn)
+}
+
+func TestWriteResolverSnapshot(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	s := &LocalServices{}
+	s.writeResolverSnapshot(context.Background(), "//policy", testSnapshotCache(), errors.New("failed"))
+	_, err := os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "snapshots are only written if enabled")
+
+	s.ResolverSnapshotDir = dir
+	s.writeResolverSnapshot(context.Background(), "//policy", testSnapshotCache(), errors.New("failed"))
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Regexp(t, `^resolver-\d{8}T\d{6}-.+\.gob$`, files[0].Name())
+}
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ResultCacheTag opts a query into the cross-asset result cache. Its value
+// is the scope in which the results of the query are shared, see
+// ResultCacheScope. Use it for queries that don't depend on the asset
+// itself, e.g. policy metadata or upstream advisories, so that scanning many
+// similar assets doesn't run them again and again.
+const ResultCacheTag = "cnspec/cache"
+
+// ResultCacheScope determines which assets share the cached results of a
+// query
+type ResultCacheScope string
+
+const (
+	// CachePlatformFamily shares results across all assets of the same
+	// platform family, e.g. all debian-based assets
+	CachePlatformFamily ResultCacheScope = "platform-family"
+	// CacheConnection shares results across all assets of the same platform
+	// family that are scanned via the same connection, e.g. the same cloud
+	// account or API endpoint
+	CacheConnection ResultCacheScope = "connection"
+)
+
+// ParseResultCacheScope reads the cache scope of a query from its tags. It
+// returns an empty scope if the results of the query aren't cached.
+func ParseResultCacheScope(tags map[string]string) (ResultCacheScope, error) {
+	v, ok := tags[ResultCacheTag]
+	if !ok {
+		return "", nil
+	}
+
+	switch scope := ResultCacheScope(strings.TrimSpace(v)); scope {
+	case CachePlatformFamily, CacheConnection:
+		return scope, nil
+	default:
+		return "", errors.New("invalid cache scope '" + v + "', expected '" + string(CachePlatformFamily) + "' or '" + string(CacheConnection) + "'")
+	}
+}
+
+// ResultCacheScopes collects the cache scopes of all queries in this bundle
+// that opted into the result cache, indexed by their code ID. The bundle
+// must be compiled.
+func (p *Bundle) ResultCacheScopes() (map[string]ResultCacheScope, error) {
+	res := map[string]ResultCacheScope{}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		scope, err := ParseResultCacheScope(query.Tags)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse cache scope for query "+query.Mrn)
+		}
+		if scope != "" && query.CodeId != "" {
+			res[query.CodeId] = scope
+		}
+	}
+	return res, nil
+}
+
+// ResultCacheKey returns the key under which the results of queries with
+// the given scope are shared. Platform family and connection identify the
+// asset, see ResultCacheScope. The key is empty if the asset doesn't
+// provide what the scope needs, in which case nothing is cached.
+func ResultCacheKey(scope ResultCacheScope, family string, connection string) string {
+	if family == "" {
+		return ""
+	}
+	switch scope {
+	case CachePlatformFamily:
+		return NewChecksum().Add(string(scope)).Add(family).String()
+	case CacheConnection:
+		if connection == "" {
+			return ""
+		}
+		return NewChecksum().Add(string(scope)).Add(family).Add(connection).String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,47 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestParseResultCacheScope(t *testing.T) {
+	scope, err := ParseResultCacheScope(map[string]string{ResultCacheTag: " connection"})
+	require.NoError(t, err)
+	assert.Equal(t, CacheConnection, scope)
+
+	scope, err = ParseResultCacheScope(map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, ResultCacheScope(""), scope)
+
+	_, err = ParseResultCacheScope(map[string]string{ResultCacheTag: "global"})
+	assert.Error(t, err)
+
+	bundle := &Bundle{Queries: []*explorer.Mquery{
+		{Mrn: "//query/advisories", CodeId: "advisories", Tags: map[string]string{ResultCacheTag: "platform-family"}},
+		{Mrn: "//query/users", CodeId: "users"},
+	}}
+	scopes, err := bundle.ResultCacheScopes()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ResultCacheScope{"advisories": CachePlatformFamily}, scopes)
+}
+
+func TestResultCacheKey(t *testing.T) {
+	debian := ResultCacheKey(CachePlatformFamily, "debian/linux/unix", "conn1")
+	assert.NotEmpty(t, debian)
+	assert.Equal(t, debian, ResultCacheKey(CachePlatformFamily, "debian/linux/unix", "conn2"))
+	assert.NotEqual(t, debian, ResultCacheKey(CachePlatformFamily, "redhat/linux/unix", "conn1"))
+
+	account := ResultCacheKey(CacheConnection, "aws", "conn1")
+	assert.NotEmpty(t, account)
+	assert.NotEqual(t, account, ResultCacheKey(CacheConnection, "aws", "conn2"))
+	assert.NotEqual(t, account, ResultCacheKey(CachePlatformFamily, "aws", "conn1"))
+
+	// assets that can't be identified don't share results
+	assert.Empty(t, ResultCacheKey(CachePlatformFamily, "", "conn1"))
+	assert.Empty(t, ResultCacheKey(CacheConnection, "aws", ""))
+	assert.Empty(t, ResultCacheKey("", "aws", "conn1"))
+}
@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/llx"
+)
+
+const (
+	// DataSampleTag is the query tag to sample list results of a data query,
+	// e.g. `first:100` or `random:50`
+	DataSampleTag = "cnspec/sample"
+	// DataMaxSizeTag is the query tag to limit the size of data query results
+	// in bytes, e.g. `65536`
+	DataMaxSizeTag = "cnspec/max-size"
+	// DeterministicTag marks a query whose results only depend on the
+	// platform of an asset, e.g. `true`. Its results may be shared across
+	// assets with identical platforms.
+	DeterministicTag = "cnspec/deterministic"
+)
+
+// SamplingMode determines which items are kept when sampling a list
+type SamplingMode string
+
+const (
+	// SampleFirst keeps the first N items of a list
+	SampleFirst SamplingMode = "first"
+	// SampleRandom keeps N random items of a list, in their original order
+	SampleRandom SamplingMode = "random"
+)
+
+// DataSampling bounds the results of a data query. It is declared via query
+// tags (see DataSampleTag and DataMaxSizeTag) and enforced in the collector.
+type DataSampling struct {
+	Mode  SamplingMode
+	Limit int
+	// MaxBytes is the max size of a result, lists are truncated until they fit
+	MaxBytes int
+}
+
+// ParseDataSampling reads the sampling configuration from query tags.
+// It returns nil if no sampling is configured.
+func ParseDataSampling(tags map[string]string) (*DataSampling, error) {
+	sample, hasSample := tags[DataSampleTag]
+	maxSize, hasMaxSize := tags[DataMaxSizeTag]
+	if !hasSample && !hasMaxSize {
+		return nil, nil
+	}
+
+	res := &DataSampling{}
+	if hasSample {
+		mode, limit, ok := strings.Cut(strings.TrimSpace(sample), ":")
+		if !ok {
+			return nil, errors.New("invalid sampling '" + sample + "', expected e.g. 'first:100' or 'random:100'")
+		}
+
+		res.Mode = SamplingMode(mode)
+		if res.Mode != SampleFirst && res.Mode != SampleRandom {
+			return nil, errors.New("unsupported sampling mode '" + mode + "', use 'first' or 'random'")
+		}
+
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return nil, errors.New("invalid sampling limit '" + limit + "', expected a positive number")
+		}
+		res.Limit = n
+	}
+
+	if hasMaxSize {
+		n, err := strconv.Atoi(strings.TrimSpace(maxSize))
+		if err != nil || n <= 0 {
+			return nil, errors.New("invalid max size '" + maxSize + "', expected a positive number of bytes")
+		}
+		res.MaxBytes = n
+	}
+
+	return res, nil
+}
+
+// Sample returns a sampled copy of the given result. Only list results
+// are sampled, all other results are returned as they are.
+func (s *DataSampling) Sample(rr *llx.RawResult) *llx.RawResult {
+	if s == nil || s.Mode == "" || rr.Data == nil {
+		return rr
+	}
+	list, ok := rr.Data.Value.([]interface{})
+	if !ok || len(list) <= s.Limit {
+		return rr
+	}
+
+	var sampled []interface{}
+	switch s.Mode {
+	case SampleRandom:
+		idx := rand.Perm(len(list))[:s.Limit]
+		sort.Ints(idx)
+		sampled = make([]interface{}, len(idx))
+		for i := range idx {
+			sampled[i] = list[idx[i]]
+		}
+	default:
+		sampled = make([]interface{}, s.Limit)
+		copy(sampled, list)
+	}
+
+	return &llx.RawResult{
+		CodeID: rr.CodeID,
+		Data: &llx.RawData{
+			Type:  rr.Data.Type,
+			Value: sampled,
+			Error: rr.Data.Error,
+		},
+	}
+}
+
+// SamplingByCodeID collects the sampling configuration of all data queries in
+// this bundle, indexed by their code ID. The bundle must be compiled.
+func (p *Bundle) SamplingByCodeID() (map[string]*DataSampling, error) {
+	res := map[string]*DataSampling{}
+	for i := range p.Queries {
+		query := p.Queries[i]
+		sampling, err := ParseDataSampling(query.Tags)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sampling for query "+query.Mrn)
+		}
+		if sampling != nil && query.CodeId != "" {
+			res[query.CodeId] = sampling
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,63 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/llx"
+	"go.mondoo.com/cnquery/types"
+)
+
+func TestParseDataSampling(t *testing.T) {
+	t.Run("no tags", func(t *testing.T) {
+		res, err := ParseDataSampling(map[string]string{"other": "tag"})
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("sample and max size", func(t *testing.T) {
+		res, err := ParseDataSampling(map[string]string{
+			DataSampleTag:  "random:10",
+			DataMaxSizeTag: "1024",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &DataSampling{Mode: SampleRandom, Limit: 10, MaxBytes: 1024}, res)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := ParseDataSampling(map[string]string{DataSampleTag: "last:10"})
+		assert.Error(t, err)
+		_, err = ParseDataSampling(map[string]string{DataSampleTag: "first"})
+		assert.Error(t, err)
+		_, err = ParseDataSampling(map[string]string{DataMaxSizeTag: "-1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestDataSampling_Sample(t *testing.T) {
+	rr := &llx.RawResult{
+		CodeID: "id",
+		Data:   llx.ArrayData([]interface{}{int64(1), int64(2), int64(3), int64(4)}, types.Int),
+	}
+
+	t.Run("first", func(t *testing.T) {
+		res := (&DataSampling{Mode: SampleFirst, Limit: 2}).Sample(rr)
+		assert.Equal(t, []interface{}{int64(1), int64(2)}, res.Data.Value)
+		assert.Len(t, rr.Data.Value, 4)
+	})
+
+	t.Run("random keeps order", func(t *testing.T) {
+		res := (&DataSampling{Mode: SampleRandom, Limit: 3}).Sample(rr)
+		list := res.Data.Value.([]interface{})
+		require.Len(t, list, 3)
+		for i := 1; i < len(list); i++ {
+			assert.Less(t, list[i-1].(int64), list[i].(int64))
+		}
+	})
+
+	t.Run("small lists are unchanged", func(t *testing.T) {
+		res := (&DataSampling{Mode: SampleFirst, Limit: 10}).Sample(rr)
+		assert.Same(t, rr, res)
+	})
+}
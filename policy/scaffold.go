@@ -0,0 +1,200 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+// ScaffoldOptions configures a new policy bundle, see ScaffoldBundle
+type ScaffoldOptions struct {
+	// Name of the policy, required
+	Name string
+	// UID of the policy, generated from the name if empty
+	UID string
+	// Version defaults to 1.0.0
+	Version string
+	// Platform that the policy is written for, see ScaffoldPlatforms.
+	// Defaults to linux.
+	Platform string
+	// Authors of the policy (optional)
+	Authors []*explorer.Author
+}
+
+type scaffoldPlatform struct {
+	filter    string
+	checkMql  string
+	checkName string
+	dataMql   string
+	dataName  string
+	propUid   string
+	propMql   string
+	propTitle string
+}
+
+var scaffoldPlatforms = map[string]scaffoldPlatform{
+	"linux": {
+		filter:    "asset.family.contains('linux')",
+		checkName: "Ensure SSH MaxAuthTries is limited",
+		checkMql:  "sshd.config.params['MaxAuthTries'] <= props.maxAuthTries",
+		dataName:  "Gather the installed kernel",
+		dataMql:   "kernel.installed",
+		propUid:   "maxAuthTries",
+		propMql:   "4",
+		propTitle: "Maximum number of SSH authentication attempts",
+	},
+	"windows": {
+		filter:    "asset.family.contains('windows')",
+		checkName: "Ensure the minimum password length is set",
+		checkMql:  "secpol.systemaccess['MinimumPasswordLength'] >= props.minPasswordLength",
+		dataName:  "Gather the installed hotfixes",
+		dataMql:   "windows.hotfixes",
+		propUid:   "minPasswordLength",
+		propMql:   "14",
+		propTitle: "Minimum length of passwords",
+	},
+	"macos": {
+		filter:    "asset.platform == 'macos'",
+		checkName: "Ensure the firewall is enabled",
+		checkMql:  "parse.plist('/Library/Preferences/com.apple.alf.plist').params['globalstate'] >= props.firewallState",
+		dataName:  "Gather the installed applications",
+		dataMql:   "packages",
+		propUid:   "firewallState",
+		propMql:   "1",
+		propTitle: "Minimum state of the application firewall",
+	},
+	"kubernetes": {
+		filter:    "asset.platform == 'k8s-pod'",
+		checkName: "Ensure containers don't run privileged",
+		checkMql:  "k8s.pod.containers.all(securityContext['privileged'] != props.allowPrivileged)",
+		dataName:  "Gather the container images",
+		dataMql:   "k8s.pod.containers { image }",
+		propUid:   "allowPrivileged",
+		propMql:   "true",
+		propTitle: "Privileged mode that containers must not use",
+	},
+	"aws": {
+		filter:    "asset.platform == 'aws'",
+		checkName: "Ensure S3 buckets block public access",
+		checkMql:  "aws.s3.buckets.where(name != props.publicBucket).all(public == false)",
+		dataName:  "Gather all S3 buckets",
+		dataMql:   "aws.s3.buckets { name location }",
+		propUid:   "publicBucket",
+		propMql:   "''",
+		propTitle: "Bucket that is allowed to be public",
+	},
+	"gcp": {
+		filter:    "asset.platform == 'gcp-project'",
+		checkName: "Ensure compute instances have no public IPs",
+		checkMql:  "gcp.compute.instances.where(name != props.publicInstance).all(networkInterfaces.all(accessConfigs.length == 0))",
+		dataName:  "Gather all compute instances",
+		dataMql:   "gcp.compute.instances { name }",
+		propUid:   "publicInstance",
+		propMql:   "''",
+		propTitle: "Instance that is allowed to have a public IP",
+	},
+	"azure": {
+		filter:    "asset.platform == 'azure'",
+		checkName: "Ensure storage accounts only allow HTTPS",
+		checkMql:  "azure.storage.accounts.where(name != props.httpAccount).all(properties.supportsHttpsTrafficOnly == true)",
+		dataName:  "Gather all storage accounts",
+		dataMql:   "azure.storage.accounts { name location }",
+		propUid:   "httpAccount",
+		propMql:   "''",
+		propTitle: "Storage account that may allow HTTP",
+	},
+}
+
+// ScaffoldPlatforms lists the platforms that ScaffoldBundle supports
+func ScaffoldPlatforms() []string {
+	res := make([]string, 0, len(scaffoldPlatforms))
+	for k := range scaffoldPlatforms {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ScaffoldBundle generates the skeleton of a new policy bundle: a policy
+// with one group that is filtered to the platform, an example check and data
+// query, and a property that the policy overrides. The bundle uses UIDs only,
+// so it can be written to a file and edited. It passes Lint without findings.
+func ScaffoldBundle(opts ScaffoldOptions) (*Bundle, error) {
+	if strings.TrimSpace(opts.Name) == "" {
+		return nil, errors.New("cannot scaffold a policy without name")
+	}
+	if opts.Platform == "" {
+		opts.Platform = "linux"
+	}
+	platform, ok := scaffoldPlatforms[opts.Platform]
+	if !ok {
+		return nil, errors.New("cannot scaffold a policy for unknown platform '" + opts.Platform + "', supported platforms are: " + strings.Join(ScaffoldPlatforms(), ", "))
+	}
+	if opts.Version == "" {
+		opts.Version = "1.0.0"
+	}
+
+	uid := opts.UID
+	if uid == "" {
+		uid = strings.Trim(nonUIDChars.ReplaceAllString(strings.ToLower(opts.Name), "-"), "-")
+	}
+	if uid == "" {
+		return nil, errors.New("cannot generate a UID from policy name '" + opts.Name + "'")
+	}
+
+	check := &explorer.Mquery{
+		Uid:    uid + "-check-01",
+		Title:  platform.checkName,
+		Mql:    platform.checkMql,
+		Impact: &explorer.Impact{Value: 70},
+		Props: []*explorer.Property{{
+			Uid:   platform.propUid,
+			Title: platform.propTitle,
+			Mql:   platform.propMql,
+		}},
+		Docs: &explorer.MqueryDocs{
+			Desc: "Describe what this check verifies and why it matters.",
+			Remediation: &explorer.Remediation{
+				Items: []*explorer.TypedDoc{{
+					Id:   "default",
+					Desc: "Describe how to fix a failing check.",
+				}},
+			},
+		},
+	}
+	query := &explorer.Mquery{
+		Uid:   uid + "-query-01",
+		Title: platform.dataName,
+		Mql:   platform.dataMql,
+	}
+
+	p := &Policy{
+		Uid:     uid,
+		Name:    opts.Name,
+		Version: opts.Version,
+		Authors: opts.Authors,
+		Docs: &PolicyDocs{
+			Desc: "Describe what this policy covers.",
+		},
+		// overrides the default of the check's property
+		Props: []*explorer.Property{{
+			Uid: platform.propUid,
+			Mql: platform.propMql,
+		}},
+		Groups: []*PolicyGroup{{
+			Title: opts.Platform,
+			Filters: &explorer.Filters{Items: map[string]*explorer.Mquery{
+				platform.filter: {Mql: platform.filter},
+			}},
+			Checks:  []*explorer.Mquery{{Uid: check.Uid}},
+			Queries: []*explorer.Mquery{{Uid: query.Uid}},
+		}},
+	}
+
+	return &Bundle{
+		Policies: []*Policy{p},
+		Queries:  []*explorer.Mquery{check, query},
+	}, nil
+}
@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffoldBundle(t *testing.T) {
+	for _, platform := range ScaffoldPlatforms() {
+		t.Run(platform, func(t *testing.T) {
+			bundle, err := ScaffoldBundle(ScaffoldOptions{Name: "My New Policy", Platform: platform})
+			require.NoError(t, err)
+			require.Len(t, bundle.Policies, 1)
+
+			p := bundle.Policies[0]
+			assert.Equal(t, "my-new-policy", p.Uid)
+			assert.Equal(t, "1.0.0", p.Version)
+			require.Len(t, p.Groups, 1)
+			assert.Len(t, p.Groups[0].Filters.Items, 1)
+			assert.Len(t, bundle.Queries, 2)
+			assert.Empty(t, Lint(bundle))
+		})
+	}
+
+	_, err := ScaffoldBundle(ScaffoldOptions{})
+	assert.Error(t, err)
+	_, err = ScaffoldBundle(ScaffoldOptions{Name: "x", Platform: "plan9"})
+	assert.Error(t, err)
+}
@@ -27,19 +27,7 @@ func NewAggregateReporter() *AggregateReporter {
 
 func (r *AggregateReporter) AddReport(asset *asset.Asset, results *AssetReport) {
 	log.Debug().Str("asset", asset.Name).Msg("add scan result to report")
-	platformName := ""
-	if asset.Platform.Title == "" {
-		platformName = asset.Platform.Name
-	} else {
-		platformName = asset.Platform.Title
-	}
-
-	r.assets[asset.Mrn] = &policy.Asset{
-		Mrn:          asset.Mrn,
-		Name:         asset.Name,
-		PlatformName: platformName,
-		Url:          asset.Url,
-	}
+	r.assets[asset.Mrn] = reportAsset(asset)
 	r.assetReports[asset.Mrn] = results.Report
 	r.resolvedPolicies[asset.Mrn] = results.ResolvedPolicy
 
@@ -51,21 +39,29 @@ func (r *AggregateReporter) AddReport(asset *asset.Asset, results *AssetReport)
 
 func (r *AggregateReporter) AddScanError(asset *asset.Asset, err error) {
 	log.Debug().Str("asset", asset.Name).Msg("add scan error to report")
-	r.assets[asset.Mrn] = &policy.Asset{
-		Mrn:  asset.Mrn,
-		Name: asset.Name,
-		Url:  asset.Url,
+	r.assets[asset.Mrn] = reportAsset(asset)
+	r.assetErrors[asset.Mrn] = err
+}
+
+// reportAsset converts a scanned asset into the asset of a report, including
+// its cloud context, discovery lineage and criticality weighting
+func reportAsset(a *asset.Asset) *policy.Asset {
+	res := &policy.Asset{
+		Mrn:       a.Mrn,
+		Name:      a.Name,
+		Url:       a.Url,
+		Cloud:     policy.CloudContextFromAsset(a),
+		Discovery: policy.DiscoveryLineageFromAsset(a),
+		Weighting: policy.AssetCriticalityFromAsset(a).Weighting(),
 	}
-	if asset.Platform != nil {
-		platformName := ""
-		if asset.Platform.Title == "" {
-			platformName = asset.Platform.Name
+	if a.Platform != nil {
+		if a.Platform.Title == "" {
+			res.PlatformName = a.Platform.Name
 		} else {
-			platformName = asset.Platform.Title
+			res.PlatformName = a.Platform.Title
 		}
-		r.assets[asset.Mrn].PlatformName = platformName
 	}
-	r.assetErrors[asset.Mrn] = err
+	return res
 }
 
 func (r *AggregateReporter) Reports() *ScanResult {
@@ -0,0 +1,42 @@
+package scan
+
+import (
+	"time"
+
+	"go.mondoo.com/cnquery/motor"
+	"go.mondoo.com/cnquery/motor/providers/os"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// WithAuditTrail records all commands that are run on scanned assets and
+// the checks that ran them, see Report.AuditTrail. Only connections
+// that run commands are audited, calls to cloud APIs are not recorded.
+func WithAuditTrail() ScannerOption {
+	return func(s *LocalScanner) {
+		s.auditTrail = true
+	}
+}
+
+// auditedProvider records all commands of the connection in the trail
+type auditedProvider struct {
+	os.OperatingSystemProvider
+	trail *policy.AuditTrail
+}
+
+func (p *auditedProvider) RunCommand(command string) (*os.Command, error) {
+	start := time.Now()
+	res, err := p.OperatingSystemProvider.RunCommand(command)
+	exitStatus := -1
+	if res != nil {
+		exitStatus = res.ExitStatus
+	}
+	p.trail.Record(policy.AuditCommand, command, start, exitStatus, err)
+	return res, err
+}
+
+// auditConnection records all commands that run via the connection
+func auditConnection(m *motor.Motor, trail *policy.AuditTrail) {
+	if osProvider, ok := m.Provider.(os.OperatingSystemProvider); ok {
+		m.Provider = &auditedProvider{OperatingSystemProvider: osProvider, trail: trail}
+	}
+}
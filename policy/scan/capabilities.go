@@ -0,0 +1,78 @@
+package scan
+
+import (
+	"sort"
+
+	"go.mondoo.com/cnquery/motor/asset"
+	providers "go.mondoo.com/cnquery/motor/providers"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// providerResources are resources that only connections to their provider
+// support, e.g. `aws.ec2.instances` needs a connection to the AWS API
+var providerResources = map[string][]providers.ProviderType{
+	"aws":       {providers.ProviderType_AWS},
+	"gcp":       {providers.ProviderType_GCP},
+	"azure":     {providers.ProviderType_AZURE},
+	"k8s":       {providers.ProviderType_K8S},
+	"terraform": {providers.ProviderType_TERRAFORM},
+	"ms365":     {providers.ProviderType_MS365},
+	"github":    {providers.ProviderType_GITHUB},
+	"gitlab":    {providers.ProviderType_GITLAB},
+	"vsphere":   {providers.ProviderType_VSPHERE},
+}
+
+// commandResources need a connection that runs commands
+var commandResources = []string{"command", "powershell"}
+
+// staticBackends only read files and can't run commands, e.g. images
+var staticBackends = map[providers.ProviderType]struct{}{
+	providers.ProviderType_TAR:                 {},
+	providers.ProviderType_DOCKER_ENGINE_IMAGE: {},
+	providers.ProviderType_CONTAINER_REGISTRY:  {},
+	providers.ProviderType_FS:                  {},
+}
+
+// negotiateCapabilities determines the capabilities of the asset's
+// connections. Assets without connections are assumed to support everything.
+func negotiateCapabilities(assetObj *asset.Asset) *policy.Capabilities {
+	backends := map[providers.ProviderType]struct{}{}
+	for _, conn := range assetObj.Connections {
+		if conn != nil {
+			backends[conn.Backend] = struct{}{}
+		}
+	}
+	if len(backends) == 0 {
+		return nil
+	}
+
+	res := &policy.Capabilities{}
+	isAPI := false
+	for resource, supported := range providerResources {
+		found := false
+		for _, backend := range supported {
+			if _, ok := backends[backend]; ok {
+				found = true
+				break
+			}
+		}
+		if found {
+			isAPI = true
+		} else {
+			res.Missing = append(res.Missing, resource)
+		}
+	}
+
+	runsCommands := !isAPI
+	for backend := range backends {
+		if _, ok := staticBackends[backend]; ok {
+			runsCommands = false
+		}
+	}
+	if !runsCommands {
+		res.Missing = append(res.Missing, commandResources...)
+	}
+
+	sort.Strings(res.Missing)
+	return res
+}
@@ -0,0 +1,25 @@
+package scan
+
+import (
+	"context"
+
+	"go.mondoo.com/cnquery/upstream"
+)
+
+type upstreamCredentialsKey struct{}
+
+// WithUpstreamCredentials attaches service account credentials to the context
+// of a scan. Jobs run with this context report upstream on behalf of the
+// service account, overriding the scanner's default credentials. This lets a
+// single long-running service scan for multiple spaces.
+func WithUpstreamCredentials(ctx context.Context, creds *upstream.ServiceAccountCredentials) context.Context {
+	return context.WithValue(ctx, upstreamCredentialsKey{}, creds)
+}
+
+func upstreamCredentialsFromContext(ctx context.Context) *upstream.ServiceAccountCredentials {
+	if ctx == nil {
+		return nil
+	}
+	creds, _ := ctx.Value(upstreamCredentialsKey{}).(*upstream.ServiceAccountCredentials)
+	return creds
+}
@@ -0,0 +1,148 @@
+package scan
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "go.mondoo.com/cnquery/motor/inventory/v1"
+	"go.mondoo.com/cnquery/upstream"
+)
+
+// testServiceAccount creates credentials of a service account in the space
+func testServiceAccount(t *testing.T, endpoint string, spaceMrn string) *upstream.ServiceAccountCredentials {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: spaceMrn + "/serviceaccounts/test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &upstream.ServiceAccountCredentials{
+		Mrn:         spaceMrn + "/serviceaccounts/test",
+		ParentMrn:   spaceMrn,
+		ApiEndpoint: endpoint,
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})),
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})),
+	}
+}
+
+func TestGetUpstreamConfig(t *testing.T) {
+	contextCreds := testServiceAccount(t, "https://context.example.com", "//spaces/context")
+	jobCreds := testServiceAccount(t, "https://job.example.com", "//spaces/job")
+
+	tests := []struct {
+		name                string
+		allowJobCredentials bool
+		contextCreds        *upstream.ServiceAccountCredentials
+		jobCreds            *upstream.ServiceAccountCredentials
+		endpoint            string
+		spaceMrn            string
+	}{
+		{
+			name:     "scanner default",
+			endpoint: "https://scanner.example.com",
+			spaceMrn: "//spaces/scanner",
+		},
+		{
+			name:     "job credentials are ignored unless allowed",
+			jobCreds: jobCreds,
+			endpoint: "https://scanner.example.com",
+			spaceMrn: "//spaces/scanner",
+		},
+		{
+			name:                "job credentials",
+			allowJobCredentials: true,
+			jobCreds:            jobCreds,
+			endpoint:            "https://job.example.com",
+			spaceMrn:            "//spaces/job",
+		},
+		{
+			name:         "context credentials",
+			contextCreds: contextCreds,
+			endpoint:     "https://context.example.com",
+			spaceMrn:     "//spaces/context",
+		},
+		{
+			name:                "context credentials win over job credentials",
+			allowJobCredentials: true,
+			contextCreds:        contextCreds,
+			jobCreds:            jobCreds,
+			endpoint:            "https://context.example.com",
+			spaceMrn:            "//spaces/context",
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.name, func(t *testing.T) {
+			opts := []ScannerOption{WithUpstream("https://scanner.example.com", "//spaces/scanner")}
+			if test.allowJobCredentials {
+				opts = append(opts, AllowJobCredentials())
+			}
+			s := NewLocalScanner(opts...)
+
+			ctx := context.Background()
+			if test.contextCreds != nil {
+				ctx = WithUpstreamCredentials(ctx, test.contextCreds)
+			}
+			job := &Job{Inventory: &v1.Inventory{Spec: &v1.InventorySpec{UpstreamCredentials: test.jobCreds}}}
+
+			res, err := s.getUpstreamConfig(ctx, false, job)
+			require.NoError(t, err)
+			assert.Equal(t, test.endpoint, res.ApiEndpoint)
+			assert.Equal(t, test.spaceMrn, res.SpaceMrn)
+			assert.False(t, res.Incognito)
+			// the scanner's plugins are never modified by credentials of a job
+			assert.Empty(t, s.pluginsMap)
+		})
+	}
+}
+
+func TestGetUpstreamConfigErrors(t *testing.T) {
+	t.Run("incognito", func(t *testing.T) {
+		s := NewLocalScanner()
+		res, err := s.getUpstreamConfig(context.Background(), true, &Job{})
+		require.NoError(t, err)
+		assert.True(t, res.Incognito)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		creds := testServiceAccount(t, "https://context.example.com", "//spaces/context")
+		creds.PrivateKey = "not a key"
+
+		s := NewLocalScanner(WithUpstream("https://scanner.example.com", "//spaces/scanner"))
+		ctx := WithUpstreamCredentials(context.Background(), creds)
+		_, err := s.getUpstreamConfig(ctx, false, &Job{Inventory: &v1.Inventory{Spec: &v1.InventorySpec{}}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid upstream credentials for job")
+	})
+
+	t.Run("missing endpoint", func(t *testing.T) {
+		s := NewLocalScanner(WithUpstream("", "//spaces/scanner"))
+		_, err := s.getUpstreamConfig(context.Background(), false, &Job{Inventory: &v1.Inventory{Spec: &v1.InventorySpec{}}})
+		assert.EqualError(t, err, "missing upstream endpoint")
+	})
+
+	t.Run("missing space", func(t *testing.T) {
+		s := NewLocalScanner(WithUpstream("https://scanner.example.com", ""))
+		_, err := s.getUpstreamConfig(context.Background(), false, &Job{Inventory: &v1.Inventory{Spec: &v1.InventorySpec{}}})
+		assert.EqualError(t, err, "missing space mrn")
+	})
+}
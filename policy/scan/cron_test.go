@@ -0,0 +1,92 @@
+package scan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"*/15 * * * *",
+		"0 9-17 * * 1-5",
+		"0,30 * 1,15 * *",
+		"1-30/5 * * * *",
+		"0 0 * * 7",
+		"@hourly",
+		" @daily ",
+	} {
+		_, err := parseCron(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1-b * * * *",
+		"@often",
+	} {
+		_, err := parseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// a wednesday
+	from := time.Date(2026, time.October, 14, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.October, 14, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.October, 14, 10, 15, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, time.October, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{"30 8 * * *", time.Date(2026, time.October, 15, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		// sunday, as 0 and 7
+		{"0 0 * * 0", time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		// weekdays only, friday evening is followed by monday
+		{"0 9 * * 1-5", time.Date(2026, time.October, 15, 9, 0, 0, 0, time.UTC)},
+		// day of month or day of week, whichever comes first
+		{"0 0 20 * 5", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * 1", time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		// leap days
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			cron, err := parseCron(test.expr)
+			require.NoError(t, err)
+			assert.Equal(t, test.next, cron.next(from))
+		})
+	}
+
+	t.Run("matching times are not repeated", func(t *testing.T) {
+		cron, err := parseCron("0 * * * *")
+		require.NoError(t, err)
+		at := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+		assert.Equal(t, at.Add(time.Hour), cron.next(at))
+	})
+
+	t.Run("impossible dates never match", func(t *testing.T) {
+		cron, err := parseCron("0 0 30 2 *")
+		require.NoError(t, err)
+		assert.True(t, cron.next(from).IsZero())
+	})
+}
@@ -0,0 +1,65 @@
+package scan
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/motor/asset"
+	providers "go.mondoo.com/cnquery/motor/providers"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// recordedAt returns when the recording was made that the asset is scanned
+// with, zero if the asset is scanned live
+func recordedAt(assetObj *asset.Asset) time.Time {
+	for _, conn := range assetObj.Connections {
+		if conn == nil || conn.Backend != providers.ProviderType_MOCK {
+			continue
+		}
+		path := conn.Options["path"]
+		if path == "" {
+			continue
+		}
+		// recordings are written once, when the connection is closed
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("could not determine when the recording was made")
+			continue
+		}
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// dataAge describes how old the data of the asset is, see
+// policy.MaxDataAgeTag. If results of the previous scan are reused, they are
+// as old as their collection time in the datalake.
+func (s *localAssetScanner) dataAge(maxAges map[string]time.Duration, reusesResults bool) *policy.DataAge {
+	res := &policy.DataAge{
+		MaxAges:  maxAges,
+		Recorded: recordedAt(s.job.Asset),
+		Now:      time.Now(),
+	}
+	// replays are evaluated at the time of their recording
+	if recorded := replayFromContext(s.job.Ctx); !recorded.IsZero() {
+		res.Recorded = recorded
+		res.Now = recorded
+	}
+	if !reusesResults {
+		return res
+	}
+
+	// reused data without collection time counts as stale
+	store, ok := s.db.(policy.DataFreshnessStore)
+	if !ok {
+		return res
+	}
+	collected, err := store.GetDataCollected(s.job.Ctx, s.job.Asset.Mrn)
+	if err != nil {
+		log.Warn().Err(err).Str("asset", s.job.Asset.Name).Msg("could not get collection time of data, reused results count as stale")
+		return res
+	}
+	res.Collected = collected
+	return res
+}
@@ -0,0 +1,151 @@
+package scan
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// datalakePollInterval is how often the datalake queue checks for jobs that
+// were scheduled by other processes
+const datalakePollInterval = 5 * time.Second
+
+// jobQueue receives scheduled jobs and runs them in the background
+type jobQueue interface {
+	Channel() chan<- Job
+	Stop()
+}
+
+// datalakeQueueClient stores scheduled jobs in the sqlite datalake. Unlike the
+// disk queue, jobs stay in the datalake until they are done, so jobs that
+// were pending or running when the service stopped are resumed on restart.
+// Identical jobs that are scheduled while one is still pending are dropped.
+type datalakeQueueClient struct {
+	db      *sqlite.Db
+	once    sync.Once
+	wg      sync.WaitGroup
+	entries chan Job
+	notify  chan struct{}
+	done    chan struct{}
+	handler func(job *Job)
+}
+
+func newDatalakeQueueClient(path string, handler func(job *Job)) (*datalakeQueueClient, error) {
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resumed, err := db.ResumeScanJobs(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if resumed > 0 {
+		log.Info().Int("jobs", resumed).Msg("resuming scan jobs that were interrupted")
+	}
+
+	q := &datalakeQueueClient{
+		db:      db,
+		entries: make(chan Job),
+		notify:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		handler: handler,
+	}
+
+	q.wg.Add(2)
+	go q.pusher()
+	go q.popper()
+	return q, nil
+}
+
+// Stop closes the client
+func (c *datalakeQueueClient) Stop() {
+	c.once.Do(func() {
+		close(c.entries)
+		close(c.done)
+		c.wg.Wait()
+		c.db.Close()
+	})
+}
+
+func (c *datalakeQueueClient) Channel() chan<- Job {
+	return c.entries
+}
+
+// pusher stores all new jobs in the datalake
+func (c *datalakeQueueClient) pusher() {
+	defer c.wg.Done()
+	for sj := range c.entries {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(&sj)
+		if err != nil {
+			log.Warn().Err(err).Msg("cannot marshal scan job")
+			continue
+		}
+
+		added, err := c.db.EnqueueScanJob(context.Background(), policy.NewChecksum().Add(string(data)).String(), data)
+		if err != nil {
+			log.Warn().Err(err).Msg("cannot push scan job on datalake queue")
+			continue
+		}
+		if !added {
+			log.Debug().Msg("identical scan job is already scheduled, skipping")
+			continue
+		}
+
+		select {
+		case c.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// popper runs all pending jobs one after the other and removes them from
+// the datalake once they are done
+func (c *datalakeQueueClient) popper() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(datalakePollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			select {
+			case <-c.done:
+				return
+			default:
+			}
+
+			id, data, ok, err := c.db.NextScanJob(context.Background())
+			if err != nil {
+				log.Error().Err(err).Msg("could not pop job from datalake queue")
+				break
+			}
+			if !ok {
+				break
+			}
+
+			var scanJob Job
+			if err = proto.Unmarshal(data, &scanJob); err != nil {
+				log.Error().Err(err).Msg("could not unmarshal the scan job")
+			} else {
+				c.handler(&scanJob)
+			}
+
+			if err = c.db.CompleteScanJob(context.Background(), id); err != nil {
+				log.Error().Err(err).Msg("could not remove finished job from datalake queue")
+			}
+		}
+
+		select {
+		case <-c.done:
+			return
+		case <-c.notify:
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package scan
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
+	"google.golang.org/protobuf/proto"
+)
+
+func waitForJob(t *testing.T, jobs <-chan *Job) *Job {
+	select {
+	case job := <-jobs:
+		return job
+	case <-time.After(5 * time.Second):
+		t.Fatal("no scan job was run")
+		return nil
+	}
+}
+
+func TestDatalakeQueue(t *testing.T) {
+	t.Run("runs and removes enqueued jobs", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "datalake.db")
+		jobs := make(chan *Job, 10)
+		q, err := newDatalakeQueueClient(path, func(job *Job) { jobs <- job })
+		require.NoError(t, err)
+
+		q.Channel() <- Job{PolicyFilters: []string{"ssh-policy"}}
+		job := waitForJob(t, jobs)
+		assert.Equal(t, []string{"ssh-policy"}, job.PolicyFilters)
+		q.Stop()
+
+		db, err := sqlite.Open(path)
+		require.NoError(t, err)
+		defer db.Close()
+		_, _, ok, err := db.NextScanJob(context.Background())
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("drops identical pending jobs", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "datalake.db")
+		release := make(chan struct{})
+		jobs := make(chan *Job, 10)
+		q, err := newDatalakeQueueClient(path, func(job *Job) {
+			jobs <- job
+			<-release
+		})
+		require.NoError(t, err)
+		defer q.Stop()
+
+		// the first job blocks the queue, so the others stay pending
+		q.Channel() <- Job{PolicyFilters: []string{"first"}}
+		waitForJob(t, jobs)
+		q.Channel() <- Job{PolicyFilters: []string{"second"}}
+		q.Channel() <- Job{PolicyFilters: []string{"second"}}
+		close(release)
+
+		job := waitForJob(t, jobs)
+		assert.Equal(t, []string{"second"}, job.PolicyFilters)
+		select {
+		case job := <-jobs:
+			t.Fatalf("identical job was run twice: %v", job.PolicyFilters)
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("resumes interrupted jobs", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "datalake.db")
+		db, err := sqlite.Open(path)
+		require.NoError(t, err)
+		data, err := proto.Marshal(&Job{PolicyFilters: []string{"interrupted"}})
+		require.NoError(t, err)
+		_, err = db.EnqueueScanJob(context.Background(), "interrupted", data)
+		require.NoError(t, err)
+		// the job was running when the service stopped
+		_, _, ok, err := db.NextScanJob(context.Background())
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, db.Close())
+
+		jobs := make(chan *Job, 10)
+		q, err := newDatalakeQueueClient(path, func(job *Job) { jobs <- job })
+		require.NoError(t, err)
+		defer q.Stop()
+
+		job := waitForJob(t, jobs)
+		assert.Equal(t, []string{"interrupted"}, job.PolicyFilters)
+	})
+}
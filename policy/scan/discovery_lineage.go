@@ -0,0 +1,93 @@
+package scan
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/motor/inventory"
+	v1 "go.mondoo.com/cnquery/motor/inventory/v1"
+	"go.mondoo.com/cnquery/motor/vault"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// discoveryLineage derives the lineage of all assets that are discovered
+// from an inventory entry. The correlation ID only depends on the entry, so
+// that repeated scans of the same inventory link their assets.
+func discoveryLineage(root *asset.Asset) *policy.DiscoveryLineage {
+	res := &policy.DiscoveryLineage{
+		CorrelationId: policy.NewChecksum().Add(root.Name).Add(connectionIdentity(root)).String(),
+		Root:          root.Name,
+	}
+
+	for _, conn := range root.Connections {
+		if conn == nil {
+			continue
+		}
+		if res.Root == "" {
+			if conn.Host != "" {
+				res.Root = conn.Host
+			} else {
+				res.Root = conn.Backend.String()
+			}
+		}
+		for _, cred := range conn.Credentials {
+			if cred == nil {
+				continue
+			}
+			if cred.SecretId != "" {
+				res.Credential = cred.SecretId
+			} else {
+				res.Credential = cred.User
+			}
+			break
+		}
+		break
+	}
+	return res
+}
+
+// resolveInventory discovers the assets of every inventory entry separately,
+// so that all discovered assets carry the lineage of the entry they were
+// found from. It returns the credentials resolver for every asset.
+func resolveInventory(ctx context.Context, inv *v1.Inventory) ([]*asset.Asset, map[*asset.Asset]vault.Resolver, error) {
+	roots := inv.Spec.Assets
+
+	// every entry is resolved with the credentials and vault of the inventory
+	base := proto.Clone(inv).(*v1.Inventory)
+	base.Spec.Assets = nil
+
+	assets := []*asset.Asset{}
+	resolvers := map[*asset.Asset]vault.Resolver{}
+	failed := 0
+	for i := range roots {
+		root := roots[i]
+		lineage := discoveryLineage(root)
+
+		entry := proto.Clone(base).(*v1.Inventory)
+		entry.Spec.Assets = []*asset.Asset{root}
+		im, err := inventory.New(inventory.WithInventory(entry))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not load asset information")
+		}
+
+		assetErrors := im.Resolve(ctx)
+		for a := range assetErrors {
+			log.Error().Err(assetErrors[a]).Str("asset", a.Name).Msg("could not resolve asset")
+		}
+		failed += len(assetErrors)
+
+		for _, a := range im.GetAssets() {
+			lineage.Apply(a)
+			assets = append(assets, a)
+			resolvers[a] = im.GetCredsResolver()
+		}
+	}
+
+	if failed > 0 {
+		return nil, nil, errors.New("failed to resolve multiple assets")
+	}
+	return assets, resolvers, nil
+}
@@ -1,6 +1,7 @@
 package scan
 
 import (
+	"errors"
 	"strings"
 
 	"go.mondoo.com/cnquery/cli/theme"
@@ -32,11 +33,7 @@ func (c *ErrorReporter) AddScanError(asset *asset.Asset, err error) {
 	}
 	name := findNameForAsset(asset)
 	errMsg := assetScanErrToString(asset, err)
-	c.assets[asset.Mrn] = &policy.Asset{
-		Mrn:  asset.Mrn,
-		Name: asset.Name,
-		Url:  asset.Url,
-	}
+	c.assets[asset.Mrn] = reportAsset(asset)
 	c.errors[name] = errMsg
 }
 
@@ -62,6 +59,11 @@ func findNameForAsset(assetObj *asset.Asset) string {
 }
 
 func assetScanErrToString(assetObj *asset.Asset, err error) string {
+	var unreachable *UnreachableError
+	if errors.As(err, &unreachable) {
+		return "asset is unreachable, " + unreachable.Error() + "\n"
+	}
+
 	st, ok := pbStatus.FromError(err)
 	if !ok {
 		return err.Error()
@@ -2,20 +2,29 @@ package scan
 
 import (
 	"context"
-	"io"
-	"net/http"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 	"go.mondoo.com/cnspec/policy"
 )
 
 type fetcher struct {
-	cache map[string]*policy.Bundle
+	lock    sync.Mutex
+	bundles map[string]*fetchedBundle
+}
+
+// fetchedBundle is the source of a bundle and its compiled copy, which is
+// kept as long as the source is unchanged
+type fetchedBundle struct {
+	lock   sync.Mutex
+	source *policy.HTTPBundleSource
+	bundle *policy.Bundle
 }
 
 func newFetcher() *fetcher {
 	return &fetcher{
-		cache: map[string]*policy.Bundle{},
+		bundles: map[string]*fetchedBundle{},
 	}
 }
 
@@ -24,21 +33,11 @@ func (f *fetcher) fetchBundles(ctx context.Context, urls ...string) (*policy.Bun
 
 	for i := range urls {
 		url := urls[i]
-		if cur, ok := f.cache[url]; ok {
-			res.AddBundle(cur)
-			continue
-		}
-
-		cur, err := f.fetchBundle(url)
+		cur, err := f.fetchBundle(ctx, url)
 		if err != nil {
 			return nil, err
 		}
 
-		// need to generate MRNs for everything
-		if _, err := cur.Compile(ctx, nil); err != nil {
-			return nil, errors.Wrap(err, "failed to compile fetched bundle")
-		}
-
 		if err = res.AddBundle(cur); err != nil {
 			return nil, errors.Wrap(err, "failed to add fetched bundle")
 		}
@@ -47,35 +46,40 @@ func (f *fetcher) fetchBundles(ctx context.Context, urls ...string) (*policy.Bun
 	return res, nil
 }
 
-func (f *fetcher) fetchBundle(url string) (*policy.Bundle, error) {
-	client := http.Client{
-		CheckRedirect: func(r *http.Request, via []*http.Request) error {
-			r.URL.Opaque = r.URL.Path
-			return nil
-		},
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to set up request to fetch bundle")
+// fetchBundle returns the compiled bundle at the url. It is only fetched
+// and compiled again if it changed on the server. If the server can't be
+// reached, the bundle that was compiled before is used.
+func (f *fetcher) fetchBundle(ctx context.Context, url string) (*policy.Bundle, error) {
+	f.lock.Lock()
+	fetched, ok := f.bundles[url]
+	if !ok {
+		fetched = &fetchedBundle{source: policy.NewHTTPBundleSource(url)}
+		f.bundles[url] = fetched
 	}
+	f.lock.Unlock()
 
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; cnquery/1.0; +http://www.mondoo.com)")
+	fetched.lock.Lock()
+	defer fetched.lock.Unlock()
 
-	resp, err := client.Do(req)
+	bundle, changed, err := fetched.source.Fetch(ctx)
 	if err != nil {
-		return nil, err
+		if fetched.bundle == nil || ctx.Err() != nil {
+			return nil, err
+		}
+		log.Warn().Err(err).Str("url", url).Msg("could not refresh policy bundle, using the one fetched before")
+		return fetched.bundle, nil
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, errors.New("failed to fetch policy bundle from " + url + ": " + resp.Status)
+	if !changed {
+		return fetched.bundle, nil
 	}
 
-	raw, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	// need to generate MRNs for everything
+	if _, err := bundle.Compile(ctx, nil); err != nil {
+		// the source only sends conditional requests once it returned a
+		// bundle, so the next fetch has to start over
+		fetched.source = policy.NewHTTPBundleSource(url)
+		return nil, errors.Wrap(err, "failed to compile fetched bundle")
 	}
-
-	return policy.BundleFromYAML(raw)
+	fetched.bundle = bundle
+	return bundle, nil
 }
@@ -0,0 +1,59 @@
+package scan
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetcher(t *testing.T) {
+	data, err := os.ReadFile("../../examples/example.mql.yaml")
+	require.NoError(t, err)
+
+	var status int32 = http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if code := int(atomic.LoadInt32(&status)); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	f := newFetcher()
+	bundle, err := f.fetchBundle(ctx, server.URL)
+	require.NoError(t, err)
+	require.NotEmpty(t, bundle.Policies)
+	assert.NotEmpty(t, bundle.Policies[0].Mrn)
+
+	t.Run("unchanged bundles are reused", func(t *testing.T) {
+		res, err := f.fetchBundle(ctx, server.URL)
+		require.NoError(t, err)
+		assert.Same(t, bundle, res)
+	})
+
+	t.Run("the cached bundle is used if the server fails", func(t *testing.T) {
+		atomic.StoreInt32(&status, http.StatusNotFound)
+		defer atomic.StoreInt32(&status, http.StatusOK)
+
+		res, err := f.fetchBundle(ctx, server.URL)
+		require.NoError(t, err)
+		assert.Same(t, bundle, res)
+
+		// without a cached bundle the error is returned
+		_, err = newFetcher().fetchBundle(ctx, server.URL)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,97 @@
+package scan
+
+import (
+	"context"
+
+	"go.mondoo.com/cnquery/motor/asset"
+	v1 "go.mondoo.com/cnquery/motor/inventory/v1"
+)
+
+// BeforeInventoryResolveHook is called before the inventory of a job is
+// resolved. It may modify the inventory. An error aborts the job.
+type BeforeInventoryResolveHook func(ctx context.Context, inv *v1.Inventory) error
+
+// BeforeAssetHook is called before connecting to an asset, e.g. to mount
+// filesystems or warm up credentials. An error is reported as scan error
+// for the asset and the asset is skipped. With WithMaxConcurrency above 1,
+// it is called concurrently for the assets of a job.
+type BeforeAssetHook func(ctx context.Context, asset *asset.Asset) error
+
+// AfterAssetHook is called once the scan of an asset is done. Either the
+// report or the error of the scan is set. With WithMaxConcurrency above 1,
+// assets are scanned by a pool of workers and the hook is called
+// concurrently from all of them, so it must be safe for concurrent use.
+type AfterAssetHook func(ctx context.Context, asset *asset.Asset, report *AssetReport, err error)
+
+// AfterJobHook is called once all assets of a job are scanned. Either the
+// result or the error of the job is set.
+type AfterJobHook func(ctx context.Context, job *Job, result *ScanResult, err error)
+
+// hooks are the callbacks registered on the scanner, they are called in
+// the order in which they were registered. The first error of a before hook
+// stops all hooks registered after it, after hooks are always all called.
+type hooks struct {
+	beforeInventoryResolve []BeforeInventoryResolveHook
+	beforeAsset            []BeforeAssetHook
+	afterAsset             []AfterAssetHook
+	afterJob               []AfterJobHook
+}
+
+func (h *hooks) runBeforeInventoryResolve(ctx context.Context, inv *v1.Inventory) error {
+	for i := range h.beforeInventoryResolve {
+		if err := h.beforeInventoryResolve[i](ctx, inv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *hooks) runBeforeAsset(ctx context.Context, asset *asset.Asset) error {
+	for i := range h.beforeAsset {
+		if err := h.beforeAsset[i](ctx, asset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *hooks) runAfterAsset(ctx context.Context, asset *asset.Asset, report *AssetReport, err error) {
+	for i := range h.afterAsset {
+		h.afterAsset[i](ctx, asset, report, err)
+	}
+}
+
+func (h *hooks) runAfterJob(ctx context.Context, job *Job, result *ScanResult, err error) {
+	for i := range h.afterJob {
+		h.afterJob[i](ctx, job, result, err)
+	}
+}
+
+// WithBeforeInventoryResolveHook registers a callback that runs before the
+// inventory of each job is resolved
+func WithBeforeInventoryResolveHook(f BeforeInventoryResolveHook) ScannerOption {
+	return func(s *LocalScanner) {
+		s.hooks.beforeInventoryResolve = append(s.hooks.beforeInventoryResolve, f)
+	}
+}
+
+// WithBeforeAssetHook registers a callback that runs before each asset is scanned
+func WithBeforeAssetHook(f BeforeAssetHook) ScannerOption {
+	return func(s *LocalScanner) {
+		s.hooks.beforeAsset = append(s.hooks.beforeAsset, f)
+	}
+}
+
+// WithAfterAssetHook registers a callback that runs after each asset is scanned
+func WithAfterAssetHook(f AfterAssetHook) ScannerOption {
+	return func(s *LocalScanner) {
+		s.hooks.afterAsset = append(s.hooks.afterAsset, f)
+	}
+}
+
+// WithAfterJobHook registers a callback that runs after each job is done
+func WithAfterJobHook(f AfterJobHook) ScannerOption {
+	return func(s *LocalScanner) {
+		s.hooks.afterJob = append(s.hooks.afterJob, f)
+	}
+}
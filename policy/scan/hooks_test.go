@@ -0,0 +1,115 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/cli/progress"
+	"go.mondoo.com/cnquery/motor/asset"
+	v1 "go.mondoo.com/cnquery/motor/inventory/v1"
+	"go.mondoo.com/cnquery/resources"
+)
+
+func newHookedScanner(opts ...ScannerOption) *LocalScanner {
+	s := &LocalScanner{}
+	for i := range opts {
+		opts[i](s)
+	}
+	return s
+}
+
+func TestHooksOrder(t *testing.T) {
+	calls := []string{}
+	record := func(name string) {
+		calls = append(calls, name)
+	}
+
+	s := newHookedScanner(
+		WithBeforeInventoryResolveHook(func(ctx context.Context, inv *v1.Inventory) error { record("inventory-1"); return nil }),
+		WithBeforeInventoryResolveHook(func(ctx context.Context, inv *v1.Inventory) error { record("inventory-2"); return nil }),
+		WithBeforeAssetHook(func(ctx context.Context, a *asset.Asset) error { record("before-1"); return nil }),
+		WithBeforeAssetHook(func(ctx context.Context, a *asset.Asset) error { record("before-2"); return nil }),
+		WithAfterAssetHook(func(ctx context.Context, a *asset.Asset, report *AssetReport, err error) { record("after-1") }),
+		WithAfterAssetHook(func(ctx context.Context, a *asset.Asset, report *AssetReport, err error) { record("after-2") }),
+		WithAfterJobHook(func(ctx context.Context, job *Job, result *ScanResult, err error) { record("job-1") }),
+		WithAfterJobHook(func(ctx context.Context, job *Job, result *ScanResult, err error) { record("job-2") }),
+	)
+
+	ctx := context.Background()
+	require.NoError(t, s.hooks.runBeforeInventoryResolve(ctx, &v1.Inventory{}))
+	require.NoError(t, s.hooks.runBeforeAsset(ctx, &asset.Asset{}))
+	s.hooks.runAfterAsset(ctx, &asset.Asset{}, nil, nil)
+	s.hooks.runAfterJob(ctx, &Job{}, nil, nil)
+
+	assert.Equal(t, []string{
+		"inventory-1", "inventory-2",
+		"before-1", "before-2",
+		"after-1", "after-2",
+		"job-1", "job-2",
+	}, calls)
+}
+
+func TestHooksAbort(t *testing.T) {
+	failure := errors.New("failure")
+
+	t.Run("before hooks stop at the first error", func(t *testing.T) {
+		calls := 0
+		s := newHookedScanner(
+			WithBeforeInventoryResolveHook(func(ctx context.Context, inv *v1.Inventory) error { calls++; return failure }),
+			WithBeforeInventoryResolveHook(func(ctx context.Context, inv *v1.Inventory) error { calls++; return nil }),
+			WithBeforeAssetHook(func(ctx context.Context, a *asset.Asset) error { calls++; return failure }),
+			WithBeforeAssetHook(func(ctx context.Context, a *asset.Asset) error { calls++; return nil }),
+		)
+
+		ctx := context.Background()
+		assert.Equal(t, failure, s.hooks.runBeforeInventoryResolve(ctx, &v1.Inventory{}))
+		assert.Equal(t, failure, s.hooks.runBeforeAsset(ctx, &asset.Asset{}))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("a failed inventory hook aborts the job", func(t *testing.T) {
+		s := newHookedScanner(
+			WithBeforeInventoryResolveHook(func(ctx context.Context, inv *v1.Inventory) error { return failure }),
+		)
+
+		res, _, err := s.distributeJob(&Job{Inventory: &v1.Inventory{}}, context.Background(), resources.UpstreamConfig{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to prepare inventory")
+		assert.Nil(t, res)
+	})
+
+	t.Run("a failed asset hook skips the asset", func(t *testing.T) {
+		var afterErr error
+		var afterReport *AssetReport
+		afterCalls := 0
+		s := newHookedScanner(
+			WithBeforeAssetHook(func(ctx context.Context, a *asset.Asset) error { return failure }),
+			WithAfterAssetHook(func(ctx context.Context, a *asset.Asset, report *AssetReport, err error) {
+				afterCalls++
+				afterReport = report
+				afterErr = err
+			}),
+		)
+
+		reporter := NewAggregateReporter()
+		a := &asset.Asset{Mrn: "//assets/a", Name: "a"}
+		s.RunAssetJob(&AssetJob{
+			Asset:            a,
+			Ctx:              context.Background(),
+			Reporter:         reporter,
+			ProgressReporter: progress.Noop{},
+		})
+
+		// after hooks see the error of skipped assets
+		assert.Equal(t, 1, afterCalls)
+		assert.Nil(t, afterReport)
+		require.Error(t, afterErr)
+		assert.ErrorIs(t, afterErr, failure)
+
+		errs := reporter.Reports().GetFull().Errors
+		assert.Contains(t, errs[a.Mrn], "failed to prepare asset")
+	})
+}
@@ -2,8 +2,11 @@ package scan
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -21,7 +24,6 @@ import (
 	"go.mondoo.com/cnquery/motor"
 	"go.mondoo.com/cnquery/motor/asset"
 	"go.mondoo.com/cnquery/motor/discovery"
-	"go.mondoo.com/cnquery/motor/inventory"
 	v1 "go.mondoo.com/cnquery/motor/inventory/v1"
 	providers "go.mondoo.com/cnquery/motor/providers"
 	"go.mondoo.com/cnquery/motor/providers/resolver"
@@ -31,16 +33,23 @@ import (
 	"go.mondoo.com/cnquery/upstream"
 	"go.mondoo.com/cnspec"
 	"go.mondoo.com/cnspec/internal/datalakes/inmemory"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
 	"go.mondoo.com/cnspec/policy"
 	"go.mondoo.com/cnspec/policy/executor"
 	"go.mondoo.com/ranger-rpc"
 	"go.mondoo.com/ranger-rpc/codes"
 	"go.mondoo.com/ranger-rpc/status"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/protobuf/proto"
 )
 
+var tracer = otel.Tracer("go.mondoo.com/cnspec/policy/scan")
+
 type LocalScanner struct {
 	resolvedPolicyCache *inmemory.ResolvedPolicyCache
-	queue               *diskQueueClient
+	queue               jobQueue
 	ctx                 context.Context
 	fetcher             *fetcher
 
@@ -51,6 +60,48 @@ type LocalScanner struct {
 	spaceMrn           string
 	pluginsMap         map[string]ranger.ClientPlugin
 	disableProgressBar bool
+	// shared across all jobs, so that an upstream outage is detected once
+	// per space and endpoint
+	upstreamBreakers *policy.UpstreamBreakers
+	// shares results of deterministic queries across identical assets (optional)
+	resultMemo *executor.ResultMemo
+	// shares results of queries that opted into the result cache, see
+	// policy.ResultCacheTag
+	resultCache *executor.ResultMemo
+	// path to a persistent sqlite datalake; in-memory if empty
+	dataLakePath string
+	// callbacks around jobs and assets
+	hooks hooks
+	// number of assets that are scanned in parallel
+	maxConcurrency int
+	// observes the progress of all asset scans (optional)
+	progressFn ProgressFunc
+	// skip assets that were completely scanned before, see WithResume
+	resume bool
+	// probes network assets before connecting to them (optional)
+	reachability *reachabilityChecker
+	// keeps recordings of scanned assets (optional)
+	recordings *recordingCollector
+	// when cached resolved policies are resolved again (optional)
+	resolvedPolicyTTL policy.ResolvedPolicyTTL
+	// skips sending unchanged datapoints upstream (optional)
+	uploadTracker *policy.UploadTracker
+	// queues results while upstream is unreachable (optional)
+	offlineUploads *offlineUploads
+	// re-resolves the policies of assets in the background, see
+	// StartReResolver
+	reResolution reResolution
+	// records the commands run on scanned assets, see WithAuditTrail
+	auditTrail bool
+	// number of queries of an asset that are executed in parallel
+	queryConcurrency int
+	// only executes changed queries, see WithIncrementalRescan
+	incremental  bool
+	rescanMaxAge time.Duration
+	// writes snapshots of failed policy resolutions (optional)
+	resolverSnapshotDir string
+	// custom evaluators of check prerequisites, by name
+	prerequisiteEvaluators map[string]PrerequisiteEvaluator
 }
 
 type ScannerOption func(*LocalScanner)
@@ -82,12 +133,144 @@ func DisableProgressBar() ScannerOption {
 	}
 }
 
+// WithUpstreamBreaker configures after how many consecutive upstream failures
+// the scanner continues without upstream and how often it probes for recovery.
+// Failures are counted for every space and endpoint separately.
+func WithUpstreamBreaker(threshold int, probeInterval time.Duration) ScannerOption {
+	return func(s *LocalScanner) {
+		s.upstreamBreakers = policy.NewUpstreamBreakers(threshold, probeInterval)
+	}
+}
+
+// WithResultMemoization reuses the results of queries that are marked as
+// deterministic across all assets with the same platform, e.g. fleets of
+// assets built from one golden image. All other queries still run per asset.
+func WithResultMemoization() ScannerOption {
+	return func(s *LocalScanner) {
+		s.resultMemo = executor.NewResultMemo()
+	}
+}
+
+// WithDataLake persists policies, resolved policies, scores and data in a
+// SQLite database at the given path, so that they are kept across runs.
+// Incognito assets without an MRN get one that is derived from their
+// platform ID, so that the next scan finds them again.
+func WithDataLake(path string) ScannerOption {
+	return func(s *LocalScanner) {
+		s.dataLakePath = path
+	}
+}
+
+// WithMaxConcurrency sets how many assets of a job are scanned in parallel.
+// Defaults to 1, i.e. assets are scanned one after the other.
+func WithMaxConcurrency(n int) ScannerOption {
+	return func(s *LocalScanner) {
+		s.maxConcurrency = n
+	}
+}
+
+// WithResume continues an interrupted scan: assets that were completely
+// scanned before are not scanned again, as long as their resolved policy
+// hasn't changed. Their report is taken from the datalake instead. This
+// requires a persistent datalake, see WithDataLake.
+func WithResume() ScannerOption {
+	return func(s *LocalScanner) {
+		s.resume = true
+	}
+}
+
+// WithReachabilityChecks resolves the hosts of network assets and probes
+// their ports before connecting to them. At most probesPerSecond probes are
+// sent. Assets that can't be reached fail with an UnreachableError instead
+// of a generic connection error.
+func WithReachabilityChecks(probesPerSecond int, timeout time.Duration) ScannerOption {
+	return func(s *LocalScanner) {
+		s.reachability = newReachabilityChecker(probesPerSecond, timeout)
+	}
+}
+
+// WithResolvedPolicyCache configures the size limit, TTL and eviction policy
+// of the cache for resolved policies, which is shared by all scanned assets
+func WithResolvedPolicyCache(opts ResolvedPolicyCacheOptions) ScannerOption {
+	return func(s *LocalScanner) {
+		s.resolvedPolicyCache = inmemory.NewResolvedPolicyCacheWithOptions(opts.cacheOptions())
+	}
+}
+
+// WithResolvedPolicyTTL makes long-running scanners resolve policies again
+// after the given TTL, even if their checksums didn't change locally. The TTL
+// varies randomly by the jitter fraction, so that assets don't all refresh
+// at once.
+func WithResolvedPolicyTTL(ttl time.Duration, jitter float64) ScannerOption {
+	return func(s *LocalScanner) {
+		s.resolvedPolicyTTL = policy.ResolvedPolicyTTL{TTL: ttl, Jitter: jitter}
+	}
+}
+
+// WithDifferentialUpload only sends datapoints upstream whose value changed
+// since they were last sent by this scanner. This cuts upload sizes for
+// long-running scanners of stable systems. See AssetReport.Upload for the
+// number of datapoints that were sent and skipped per scan.
+func WithDifferentialUpload() ScannerOption {
+	return func(s *LocalScanner) {
+		s.uploadTracker = policy.NewUploadTracker()
+	}
+}
+
+// WithQueryConcurrency executes up to n independent queries of an asset in
+// parallel, which cuts the scan time of assets with many checks. Defaults to
+// 1. Audited scans always execute queries one after the other, so that
+// commands can be attributed to checks, see WithAuditTrail.
+func WithQueryConcurrency(n int) ScannerOption {
+	return func(s *LocalScanner) {
+		s.queryConcurrency = n
+	}
+}
+
+// WithIncrementalRescan only executes the queries of an asset that changed
+// since its last complete scan, e.g. after a slight policy update, or whose
+// data expired (see policy.DataTTLTag), and reuses the results of all other
+// queries. Scores are recalculated from all results. Once the last complete
+// scan is older than maxAge, all queries are executed again; a maxAge of 0
+// always reuses results. This requires a persistent datalake, see
+// WithDataLake.
+func WithIncrementalRescan(maxAge time.Duration) ScannerOption {
+	return func(s *LocalScanner) {
+		s.incremental = true
+		s.rescanMaxAge = maxAge
+	}
+}
+
+// WithResolverSnapshots writes the intermediate state of policy resolutions
+// that fail into the directory, so that they can be reproduced, see
+// policy.ResolverSnapshot.
+func WithResolverSnapshots(dir string) ScannerOption {
+	return func(s *LocalScanner) {
+		s.resolverSnapshotDir = dir
+	}
+}
+
+// WithPrerequisiteEvaluator evaluates the prerequisite with the given name
+// for all assets, e.g. `scope` for prerequisites like `scope:compute.readonly`.
+// It replaces the built-in evaluator of the same name, if there is one.
+func WithPrerequisiteEvaluator(name string, evaluate PrerequisiteEvaluator) ScannerOption {
+	return func(s *LocalScanner) {
+		if s.prerequisiteEvaluators == nil {
+			s.prerequisiteEvaluators = map[string]PrerequisiteEvaluator{}
+		}
+		s.prerequisiteEvaluators[name] = evaluate
+	}
+}
+
 func NewLocalScanner(opts ...ScannerOption) *LocalScanner {
 	ls := &LocalScanner{
-		resolvedPolicyCache: inmemory.NewResolvedPolicyCache(ResolvedPolicyCacheSize),
+		resolvedPolicyCache: inmemory.NewResolvedPolicyCacheWithOptions(defaultResolvedPolicyCacheOptions.cacheOptions()),
 		fetcher:             newFetcher(),
 		ctx:                 context.Background(),
 		pluginsMap:          map[string]ranger.ClientPlugin{},
+		upstreamBreakers:    policy.NewUpstreamBreakers(policy.DefaultUpstreamFailureThreshold, policy.DefaultUpstreamProbeInterval),
+		resultCache:         executor.NewResultMemo(),
+		maxConcurrency:      1,
 	}
 
 	for i := range opts {
@@ -97,17 +280,40 @@ func NewLocalScanner(opts ...ScannerOption) *LocalScanner {
 	return ls
 }
 
+// ResolvedPolicyCacheStats returns the counters of the cache for resolved
+// policies, e.g. to tune its size for many different asset filters
+func (s *LocalScanner) ResolvedPolicyCacheStats() ResolvedPolicyCacheStats {
+	return cacheStats(s.resolvedPolicyCache.Stats())
+}
+
+// EnableQueue starts processing scheduled jobs in the background. If a
+// datalake is configured, scheduled jobs are persisted there and survive
+// restarts, otherwise they are kept in a disk queue.
 func (s *LocalScanner) EnableQueue() error {
-	var err error
-	s.queue, err = newDqueClient(defaultDqueConfig, func(job *Job) {
+	handler := func(job *Job) {
 		// this is the handler for jobs, when they are picked up
 		ctx := cnquery.SetFeatures(s.ctx, cnquery.DefaultFeatures)
 		_, err := s.Run(ctx, job)
 		if err != nil {
 			log.Error().Err(err).Msg("could not complete the scan")
 		}
-	})
-	return err
+	}
+
+	if s.dataLakePath != "" {
+		queue, err := newDatalakeQueueClient(s.dataLakePath, handler)
+		if err != nil {
+			return err
+		}
+		s.queue = queue
+		return nil
+	}
+
+	queue, err := newDqueClient(defaultDqueConfig, handler)
+	if err != nil {
+		return err
+	}
+	s.queue = queue
+	return nil
 }
 
 func (s *LocalScanner) Schedule(ctx context.Context, job *Job) (*Empty, error) {
@@ -137,11 +343,12 @@ func (s *LocalScanner) Run(ctx context.Context, job *Job) (*ScanResult, error) {
 	}
 
 	dctx := discovery.InitCtx(ctx)
-	upstreamConfig, err := s.getUpstreamConfig(false, job)
+	upstreamConfig, err := s.getUpstreamConfig(ctx, false, job)
 	if err != nil {
 		return nil, err
 	}
 	reports, _, err := s.distributeJob(job, dctx, upstreamConfig)
+	s.hooks.runAfterJob(ctx, job, reports, err)
 	if err != nil {
 		return nil, err
 	}
@@ -164,11 +371,12 @@ func (s *LocalScanner) RunIncognito(ctx context.Context, job *Job) (*ScanResult,
 
 	dctx := discovery.InitCtx(ctx)
 
-	upstreamConfig, err := s.getUpstreamConfig(true, job)
+	upstreamConfig, err := s.getUpstreamConfig(ctx, true, job)
 	if err != nil {
 		return nil, err
 	}
 	reports, _, err := s.distributeJob(job, dctx, upstreamConfig)
+	s.hooks.runAfterJob(ctx, job, reports, err)
 	if err != nil {
 		return nil, err
 	}
@@ -177,21 +385,15 @@ func (s *LocalScanner) RunIncognito(ctx context.Context, job *Job) (*ScanResult,
 }
 
 func (s *LocalScanner) distributeJob(job *Job, ctx context.Context, upstreamConfig resources.UpstreamConfig) (*ScanResult, bool, error) {
-	log.Info().Msgf("discover related assets for %d asset(s)", len(job.Inventory.Spec.Assets))
-	im, err := inventory.New(inventory.WithInventory(job.Inventory))
-	if err != nil {
-		return nil, false, errors.Wrap(err, "could not load asset information")
+	if err := s.hooks.runBeforeInventoryResolve(ctx, job.Inventory); err != nil {
+		return nil, false, errors.Wrap(err, "failed to prepare inventory")
 	}
 
-	assetErrors := im.Resolve(ctx)
-	if len(assetErrors) > 0 {
-		for a := range assetErrors {
-			log.Error().Err(assetErrors[a]).Str("asset", a.Name).Msg("could not resolve asset")
-		}
-		return nil, false, errors.New("failed to resolve multiple assets")
+	log.Info().Msgf("discover related assets for %d asset(s)", len(job.Inventory.Spec.Assets))
+	assetList, credsResolvers, err := resolveInventory(ctx, job.Inventory)
+	if err != nil {
+		return nil, false, err
 	}
-
-	assetList := im.GetAssets()
 	if len(assetList) == 0 {
 		return nil, false, errors.New("could not find an asset that we can connect to")
 	}
@@ -201,16 +403,20 @@ func (s *LocalScanner) distributeJob(job *Job, ctx context.Context, upstreamConf
 		for i := range assetList {
 			cur := assetList[i]
 			if cur.Mrn == "" && cur.Id == "" {
-				randID := "//" + policy.POLICY_SERVICE_NAME + "/" + policy.MRN_RESOURCE_ASSET + "/" + ksuid.New().String()
-				x, err := mrn.NewMRN(randID)
+				// assets in a persistent datalake must be found again by the
+				// next scan, e.g. to resume or rescan them incrementally
+				assetMrn, err := incognitoAssetMrn(cur, s.dataLakePath != "")
 				if err != nil {
 					return nil, false, errors.Wrap(err, "failed to generate a random asset MRN")
 				}
-				cur.Mrn = x.String()
+				cur.Mrn = assetMrn
 			}
 		}
 	}
 
+	// assets that share their filters don't wait for each other's resolution
+	s.precomputeResolve(ctx, job, upstreamConfig, assetList)
+
 	// plan scan jobs
 	var reporter Reporter
 	switch job.ReportType {
@@ -223,6 +429,7 @@ func (s *LocalScanner) distributeJob(job *Job, ctx context.Context, upstreamConf
 	default:
 		return nil, false, errors.Errorf("unknown report type: %s", job.ReportType)
 	}
+	reporter = newSyncReporter(reporter)
 
 	progressBarElements := map[string]string{}
 	orderedKeys := []string{}
@@ -246,33 +453,33 @@ func (s *LocalScanner) distributeJob(job *Job, ctx context.Context, upstreamConf
 	finished := false
 	go func() {
 		defer scanGroup.Done()
-		for i := range assetList {
-			asset := assetList[i]
 
-			// Make sure the context has not been canceled in the meantime. Note that this approach works only for single threaded execution. If we have more than 1 thread calling this function,
-			// we need to solve this at a different level.
-			select {
-			case <-ctx.Done():
-				log.Warn().Msg("request context has been canceled")
-				// When we scan concurrently, we need to call Errored(asset.Mrn) status for this asset
-				multiprogress.Close()
-				return
-			default:
-			}
-
-			p := &progress.MultiProgressAdapter{Key: asset.PlatformIds[0], Multi: multiprogress}
+		canceled := scanAssets(ctx, assetList, s.maxConcurrency, func(asset *asset.Asset) {
+			// every asset gets its own context, so that its connections and
+			// queries are released once it is done
+			assetCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			p := newProgressTracker(&progress.MultiProgressAdapter{Key: asset.PlatformIds[0], Multi: multiprogress}, asset, s.progressFn)
 			s.RunAssetJob(&AssetJob{
 				DoRecord:         job.DoRecord,
 				UpstreamConfig:   upstreamConfig,
 				Asset:            asset,
-				Bundle:           job.Bundle,
+				Bundle:           assetBundle(job.Bundle),
 				PolicyFilters:    job.PolicyFilters,
 				Props:            job.Props,
-				Ctx:              ctx,
-				CredsResolver:    im.GetCredsResolver(),
+				Ctx:              assetCtx,
+				CredsResolver:    credsResolvers[asset],
 				Reporter:         reporter,
 				ProgressReporter: p,
 			})
+		}, func(asset *asset.Asset) {
+			p := newProgressTracker(&progress.MultiProgressAdapter{Key: asset.PlatformIds[0], Multi: multiprogress}, asset, s.progressFn)
+			p.Errored()
+		})
+
+		if canceled {
+			multiprogress.Close()
+			return
 		}
 		finished = true
 	}()
@@ -289,7 +496,114 @@ func (s *LocalScanner) distributeJob(job *Job, ctx context.Context, upstreamConf
 	return reporter.Reports(), finished, nil
 }
 
+// scanAssets scans the assets with up to maxConcurrency workers. Once the
+// context is canceled, assets that were not handed to a worker yet are marked
+// as errored instead. It returns true if the context was canceled.
+func scanAssets(ctx context.Context, assetList []*asset.Asset, maxConcurrency int, scan func(*asset.Asset), errored func(*asset.Asset)) bool {
+	workers := maxConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(assetList) {
+		workers = len(assetList)
+	}
+
+	assets := make(chan *asset.Asset)
+	workerGroup := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		workerGroup.Add(1)
+		go func() {
+			defer workerGroup.Done()
+			for asset := range assets {
+				scan(asset)
+			}
+		}()
+	}
+
+	canceled := false
+	for i := range assetList {
+		asset := assetList[i]
+
+		// Make sure the context has not been canceled in the meantime. Assets
+		// that were not handed to a worker yet are marked as errored.
+		if !canceled {
+			select {
+			case <-ctx.Done():
+				log.Warn().Msg("request context has been canceled")
+				canceled = true
+			case assets <- asset:
+				continue
+			}
+		}
+
+		errored(asset)
+	}
+	close(assets)
+	workerGroup.Wait()
+	return canceled
+}
+
+// incognitoAssetMrn creates an MRN for an asset that has none. Stable MRNs
+// are derived from the asset's platform ID, so that the asset gets the same
+// MRN in every scan. All other MRNs are random.
+func incognitoAssetMrn(a *asset.Asset, stable bool) (string, error) {
+	id := ksuid.New().String()
+	if stable && len(a.PlatformIds) != 0 {
+		sum := sha256.Sum256([]byte(a.PlatformIds[0]))
+		id = hex.EncodeToString(sum[:])
+	}
+	x, err := mrn.NewMRN("//" + policy.POLICY_SERVICE_NAME + "/" + policy.MRN_RESOURCE_ASSET + "/" + id)
+	if err != nil {
+		return "", err
+	}
+	return x.String(), nil
+}
+
+// assetBundle copies the job's bundle for one of its assets. Preparing an
+// asset filters and compiles its bundle, which must not change the bundle
+// of assets that are scanned at the same time.
+func assetBundle(bundle *policy.Bundle) *policy.Bundle {
+	if bundle == nil {
+		return nil
+	}
+	return proto.Clone(bundle).(*policy.Bundle)
+}
+
 func (s *LocalScanner) RunAssetJob(job *AssetJob) {
+	var report *AssetReport
+	var scanErr error
+	var errorClass ErrorClass
+	started := time.Now()
+
+	// all spans of resolving and executing policies for this asset are
+	// children of this span
+	ctx, span := tracer.Start(job.Ctx, "scan/RunAssetJob")
+	span.SetAttributes(attribute.String("asset.name", job.Asset.Name), attribute.String("asset.mrn", job.Asset.Mrn))
+	job.Ctx = ctx
+	defer func() {
+		if scanErr != nil {
+			span.RecordError(scanErr)
+			span.SetStatus(otelcodes.Error, scanErr.Error())
+		}
+		span.End()
+	}()
+
+	defer func() {
+		if summary := summaryFromContext(job.Ctx); summary != nil {
+			summary.add(job.Asset, started, errorClass, scanErr)
+		}
+		s.hooks.runAfterAsset(job.Ctx, job.Asset, report, scanErr)
+	}()
+
+	if err := s.hooks.runBeforeAsset(job.Ctx, job.Asset); err != nil {
+		scanErr = errors.Wrap(err, "failed to prepare asset")
+		errorClass = ErrorClassPrepare
+		job.Reporter.AddScanError(job.Asset, scanErr)
+		job.ProgressReporter.Score("X")
+		job.ProgressReporter.Errored()
+		return
+	}
+
 	log.Debug().Msgf("connecting to asset %s", job.Asset.HumanName())
 
 	var upstream *policy.Services
@@ -302,9 +616,23 @@ func (s *LocalScanner) RunAssetJob(job *AssetJob) {
 		}
 	}
 
+	if s.reachability != nil {
+		if err := s.reachability.check(job.Ctx, job.Asset); err != nil {
+			log.Debug().Err(err).Str("asset", job.Asset.Name).Msg("asset is not reachable")
+			scanErr = err
+			errorClass = ErrorClassUnreachable
+			job.Reporter.AddScanError(job.Asset, err)
+			job.ProgressReporter.Score("X")
+			job.ProgressReporter.Errored()
+			return
+		}
+	}
+
 	// run over all connections
 	connections, err := resolver.OpenAssetConnections(job.Ctx, job.Asset, job.CredsResolver, job.DoRecord)
 	if err != nil {
+		scanErr = err
+		errorClass = ErrorClassConnection
 		job.Reporter.AddScanError(job.Asset, err)
 		job.ProgressReporter.Score("X")
 		job.ProgressReporter.Errored()
@@ -325,10 +653,20 @@ func (s *LocalScanner) RunAssetJob(job *AssetJob) {
 		// use defer in the loop m.Close() for each connection will only be executed once the entire loop is
 		// finished.
 		func(m *motor.Motor) {
+			// recordings are written when the connection is closed
+			if job.DoRecord && s.recordings != nil {
+				defer s.recordings.collect(job, time.Now())
+			}
 			// ensures temporary files get deleted
 			defer m.Close()
 
+			if s.auditTrail {
+				job.auditTrail = policy.NewAuditTrail()
+				auditConnection(m, job.auditTrail)
+			}
+
 			log.Debug().Msg("established connection")
+			reportProgress(job.ProgressReporter, ProgressConnected, nil)
 			// It's possible that the platform information was not collected at all or only partially during the
 			// discovery phase.
 			// For example, the ebs discovery does not detect the platform because it requires mounting
@@ -353,6 +691,8 @@ func (s *LocalScanner) RunAssetJob(job *AssetJob) {
 				})
 				if err != nil {
 					log.Error().Err(err).Msgf("failed to synchronize asset to Mondoo Platform %s", job.Asset.Mrn)
+					scanErr = err
+					errorClass = ErrorClassUpstream
 					job.Reporter.AddScanError(job.Asset, err)
 					job.ProgressReporter.Score("X")
 					job.ProgressReporter.Errored()
@@ -369,12 +709,15 @@ func (s *LocalScanner) RunAssetJob(job *AssetJob) {
 			results, err := s.runMotorizedAsset(job)
 			if err != nil {
 				log.Debug().Str("asset", job.Asset.Name).Msg("could not complete scan for asset")
+				scanErr = err
+				errorClass = ErrorClassScan
 				job.Reporter.AddScanError(job.Asset, err)
 				job.ProgressReporter.Score("X")
 				job.ProgressReporter.Errored()
 				return
 			}
 
+			report = results
 			job.Reporter.AddReport(job.Asset, results)
 		}(connections[c])
 	}
@@ -390,7 +733,22 @@ func (s *LocalScanner) runMotorizedAsset(job *AssetJob) (*AssetReport, error) {
 	var res *AssetReport
 	var policyErr error
 
-	runtimeErr := inmemory.WithDb(s.resolvedPolicyCache, func(db *inmemory.Db, services *policy.LocalServices) error {
+	withDb := func(f func(db policy.DataLake, services *policy.LocalServices) error) error {
+		if s.dataLakePath != "" {
+			return sqlite.WithDb(s.dataLakePath, func(db *sqlite.Db, services *policy.LocalServices) error {
+				db.SetResolvedPolicyTTL(s.resolvedPolicyTTL)
+				services.ReResolver = s.reResolution.get()
+				return f(db, services)
+			})
+		}
+		return inmemory.WithDb(s.resolvedPolicyCache, func(db *inmemory.Db, services *policy.LocalServices) error {
+			db.SetResolvedPolicyTTL(s.resolvedPolicyTTL)
+			return f(db, services)
+		})
+	}
+
+	runtimeErr := withDb(func(db policy.DataLake, services *policy.LocalServices) error {
+		services.ResolverSnapshotDir = s.resolverSnapshotDir
 		if job.UpstreamConfig.ApiEndpoint != "" && !job.UpstreamConfig.Incognito {
 			log.Debug().Msg("using API endpoint " + job.UpstreamConfig.ApiEndpoint)
 			upstream, err := policy.NewRemoteServices(job.UpstreamConfig.ApiEndpoint, job.UpstreamConfig.Plugins)
@@ -398,6 +756,18 @@ func (s *LocalScanner) runMotorizedAsset(job *AssetJob) (*AssetReport, error) {
 				return err
 			}
 			services.Upstream = upstream
+			services.UpstreamBreaker = s.upstreamBreakers.Get(job.UpstreamConfig.ApiEndpoint, job.UpstreamConfig.SpaceMrn)
+			services.UploadTracker = s.uploadTracker
+			services.OfflineQueue = s.offlineQueue(db, upstream, job.UpstreamConfig.SpaceMrn)
+			s.uploadTracker.ResetStats(job.Asset.Mrn)
+		}
+
+		if store, ok := db.(policy.DiscoveryLineageStore); ok {
+			if lineage := policy.DiscoveryLineageFromAsset(job.Asset); lineage != nil {
+				if err := store.SetDiscoveryLineage(job.Ctx, job.Asset.Mrn, lineage); err != nil {
+					log.Warn().Err(err).Str("asset", job.Asset.Name).Msg("failed to store the discovery lineage of the asset")
+				}
+			}
 		}
 
 		registry := all.Registry
@@ -405,11 +775,20 @@ func (s *LocalScanner) runMotorizedAsset(job *AssetJob) (*AssetReport, error) {
 		runtime := resources.NewRuntime(registry, job.connection)
 		runtime.UpstreamConfig = &job.UpstreamConfig
 
+		// replays reproduce the recorded scan, see LocalScanner.Replay
+		isReplay := !replayFromContext(job.Ctx).IsZero()
 		scanner := &localAssetScanner{
 			db:               db,
 			services:         services,
 			job:              job,
 			fetcher:          s.fetcher,
+			resultMemo:       s.resultMemo,
+			resultCache:      s.resultCache,
+			resume:           s.resume && !isReplay,
+			queryConcurrency: s.queryConcurrency,
+			incremental:      s.incremental && !isReplay,
+			rescanMaxAge:     s.rescanMaxAge,
+			prerequisites:    s.prerequisiteEvaluators,
 			Registry:         registry,
 			Schema:           schema,
 			Runtime:          runtime,
@@ -417,6 +796,10 @@ func (s *LocalScanner) runMotorizedAsset(job *AssetJob) (*AssetReport, error) {
 		}
 		log.Debug().Str("asset", job.Asset.Name).Msg("run scan")
 		res, policyErr = scanner.run()
+		if res != nil && services.UploadTracker != nil {
+			res.Upload = services.UploadTracker.Stats(job.Asset.Mrn)
+			log.Debug().Str("asset", job.Asset.Name).Int("sent", res.Upload.Sent).Int("skipped", res.Upload.Skipped).Msg("uploaded datapoints")
+		}
 		return policyErr
 	})
 	if runtimeErr != nil {
@@ -507,10 +890,26 @@ func (s *LocalScanner) GarbageCollectAssets(ctx context.Context, garbageCollectO
 	return &Empty{}, err
 }
 
+// upstreamHealthService is the service name for health checks of the upstream connection
+const upstreamHealthService = "upstream"
+
 func (s *LocalScanner) HealthCheck(ctx context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
 	// check the server overall health status.
+	servingStatus := HealthCheckResponse_SERVING
+
+	// the upstream connection of the scanner's own space can be checked
+	// separately. if upstream is unavailable, scans continue locally where
+	// possible
+	if req.GetService() == upstreamHealthService {
+		health := s.upstreamBreakers.Health(s.apiEndpoint, s.spaceMrn)
+		if health.State == policy.CircuitOpen {
+			servingStatus = HealthCheckResponse_NOT_SERVING
+			log.Debug().Err(health.LastError).Time("since", health.Since).Msg("upstream is not reachable")
+		}
+	}
+
 	return &HealthCheckResponse{
-		Status:     HealthCheckResponse_SERVING,
+		Status:     servingStatus,
 		Time:       time.Now().Format(time.RFC3339),
 		ApiVersion: "v1",
 		Build:      cnspec.GetBuild(),
@@ -518,7 +917,11 @@ func (s *LocalScanner) HealthCheck(ctx context.Context, req *HealthCheckRequest)
 	}, nil
 }
 
-func (s *LocalScanner) getUpstreamConfig(incognito bool, job *Job) (resources.UpstreamConfig, error) {
+// getUpstreamConfig determines the upstream connection for a job. Credentials
+// are taken from the context (see WithUpstreamCredentials) first, then from
+// the job's inventory if the scanner allows job credentials, and finally
+// fall back to the scanner's own configuration.
+func (s *LocalScanner) getUpstreamConfig(ctx context.Context, incognito bool, job *Job) (resources.UpstreamConfig, error) {
 	if incognito {
 		return resources.UpstreamConfig{Incognito: true}, nil
 	}
@@ -531,12 +934,22 @@ func (s *LocalScanner) getUpstreamConfig(incognito bool, job *Job) (resources.Up
 	endpoint := s.apiEndpoint
 	spaceMrn := s.spaceMrn
 
-	jobCredentials := job.Inventory.Spec.UpstreamCredentials
-	if s.allowJobCredentials && jobCredentials != nil {
-		certAuth, _ := upstream.NewServiceAccountRangerPlugin(jobCredentials)
+	jobCredentials := upstreamCredentialsFromContext(ctx)
+	if jobCredentials == nil && s.allowJobCredentials {
+		jobCredentials = job.Inventory.Spec.UpstreamCredentials
+	}
+	if jobCredentials != nil {
+		certAuth, err := upstream.NewServiceAccountRangerPlugin(jobCredentials)
+		if err != nil {
+			return resources.UpstreamConfig{}, errors.Wrap(err, "invalid upstream credentials for job")
+		}
 		pluginsCopyMap[certAuth.GetName()] = certAuth
 		endpoint = jobCredentials.GetApiEndpoint()
 		spaceMrn = jobCredentials.GetParentMrn()
+		log.Debug().
+			Str("service-account", jobCredentials.GetMrn()).
+			Str("space", spaceMrn).
+			Msg("using upstream credentials of the job")
 	}
 
 	plugins := []ranger.ClientPlugin{}
@@ -559,11 +972,34 @@ func (s *LocalScanner) getUpstreamConfig(incognito bool, job *Job) (resources.Up
 	}, nil
 }
 
+// checkpointStore is implemented by datalakes that remember which assets
+// were completely scanned, see WithResume
+type checkpointStore interface {
+	GetScanCheckpoint(ctx context.Context, assetMrn string) (string, error)
+	GetScanCheckpointTime(ctx context.Context, assetMrn string) (time.Time, error)
+	SetScanCheckpoint(ctx context.Context, assetMrn string, graphExecutionChecksum string) error
+}
+
 type localAssetScanner struct {
-	db       *inmemory.Db
+	db       policy.DataLake
 	services *policy.LocalServices
 	job      *AssetJob
 	fetcher  *fetcher
+	// optional, see WithResultMemoization
+	resultMemo *executor.ResultMemo
+	// shared across all assets of the scanner, see policy.ResultCacheTag
+	resultCache *executor.ResultMemo
+	// optional, see WithResume
+	resume bool
+	// number of queries that are executed in parallel
+	queryConcurrency int
+	// optional, see WithIncrementalRescan
+	incremental  bool
+	rescanMaxAge time.Duration
+	// custom evaluators of check prerequisites (optional)
+	prerequisites map[string]PrerequisiteEvaluator
+	// inline suppressions found in the sources of IaC assets
+	suppressions []*Suppression
 
 	Registry         *resources.Registry
 	Schema           *resources.Schema
@@ -575,6 +1011,12 @@ type localAssetScanner struct {
 // case of an error, the results may contain partial results. The error is only returned if the scan failed to run not
 // when individual policies failed.
 func (s *localAssetScanner) run() (*AssetReport, error) {
+	suppressions, err := collectSuppressions(s.job.Asset)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read inline suppressions")
+	}
+	s.suppressions = suppressions
+
 	if err := s.prepareAsset(); err != nil {
 		return nil, err
 	}
@@ -594,10 +1036,20 @@ func (s *localAssetScanner) run() (*AssetReport, error) {
 	if err != nil {
 		return ar, err
 	}
+	if s.job.auditTrail != nil {
+		report.AuditTrail = s.job.auditTrail.Entries(bundle)
+	}
+	if impacts, err := s.services.GetImpactProvenance(s.job.Ctx, s.job.Asset.Mrn); err != nil {
+		log.Debug().Err(err).Str("asset", s.job.Asset.Mrn).Msg("could not get impact provenance")
+	} else {
+		report.ImpactProvenance = policy.IndexImpactProvenance(impacts)
+	}
 	s.ProgressReporter.Score(report.Score.Rating().Letter())
 	s.ProgressReporter.Completed()
+	reportProgress(s.ProgressReporter, ProgressScored, report.Score)
 
 	log.Debug().Str("asset", s.job.Asset.Mrn).Msg("scan complete")
+	ar.Suppressions = matchSuppressions(bundle, s.suppressions)
 	ar.Report = report
 	return ar, nil
 }
@@ -656,8 +1108,14 @@ func (s *localAssetScanner) prepareAsset() error {
 		return noPolicyErr(availablePolicies, s.job.PolicyFilters)
 	}
 
+	// memoized results of nondeterministic queries would be stale
+	ctx := s.job.Ctx
+	if s.resultMemo != nil {
+		ctx = policy.WithNondeterminismMarking(ctx)
+	}
+
 	// FIXME: we do not currently respect policy filters!
-	_, err := hub.SetBundle(s.job.Ctx, s.job.Bundle)
+	_, err := hub.SetBundle(ctx, s.job.Bundle)
 	if err != nil {
 		return err
 	}
@@ -676,27 +1134,35 @@ func (s *localAssetScanner) prepareAsset() error {
 		return err
 	}
 
-	if len(s.job.Props) != 0 {
-		propsReq := explorer.PropsReq{
-			EntityMrn: s.job.Asset.Mrn,
-			Props:     make([]*explorer.Property, len(s.job.Props)),
-		}
-		i := 0
-		for k, v := range s.job.Props {
-			propsReq.Props[i] = &explorer.Property{
-				Uid: k,
-				Mql: v,
-			}
-			i++
-		}
+	// suppressed checks are still executed, but they are waived
+	if err := s.syncSuppressions(s.job.Ctx); err != nil {
+		return errors.Wrap(err, "failed to apply inline suppressions")
+	}
 
-		_, err = resolver.SetProps(s.job.Ctx, &propsReq)
-		if err != nil {
-			return err
+	return setJobProps(s.job.Ctx, resolver, s.job.Asset.Mrn, s.job.Props)
+}
+
+// setJobProps overrides the properties of the asset with the ones of the job
+func setJobProps(ctx context.Context, resolver policy.PolicyResolver, assetMrn string, props map[string]string) error {
+	if len(props) == 0 {
+		return nil
+	}
+
+	propsReq := explorer.PropsReq{
+		EntityMrn: assetMrn,
+		Props:     make([]*explorer.Property, len(props)),
+	}
+	i := 0
+	for k, v := range props {
+		propsReq.Props[i] = &explorer.Property{
+			Uid: k,
+			Mql: v,
 		}
+		i++
 	}
 
-	return nil
+	_, err := resolver.SetProps(ctx, &propsReq)
+	return err
 }
 
 var assetDetectBundle = executor.MustCompile("asset { kind platform runtime version family }")
@@ -748,8 +1214,11 @@ func (s *localAssetScanner) runPolicy() (*policy.Bundle, *policy.ResolvedPolicy,
 	var hub policy.PolicyHub = s.services
 	var resolver policy.PolicyResolver = s.services
 
+	reportProgress(s.ProgressReporter, ProgressResolving, nil)
 	log.Debug().Str("asset", s.job.Asset.Mrn).Msg("client> request policies bundle for asset")
-	assetBundle, err := hub.GetBundle(s.job.Ctx, &policy.Mrn{Mrn: s.job.Asset.Mrn})
+	ctx, bundleSpan := tracer.Start(s.job.Ctx, "scan/getBundle")
+	assetBundle, err := hub.GetBundle(ctx, &policy.Mrn{Mrn: s.job.Asset.Mrn})
+	bundleSpan.End()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -771,25 +1240,242 @@ func (s *localAssetScanner) runPolicy() (*policy.Bundle, *policy.ResolvedPolicy,
 	log.Debug().Str("asset", s.job.Asset.Mrn).Msg("client> shell update filters")
 	logger.DebugJSON(filters)
 
-	resolvedPolicy, err := resolver.ResolveAndUpdateJobs(s.job.Ctx, &policy.UpdateAssetJobsReq{
+	// checks that the connection can't run are skipped during execution
+	capabilities := negotiateCapabilities(s.job.Asset)
+	if capabilities != nil {
+		log.Debug().Str("asset", s.job.Asset.Mrn).Strs("missing", capabilities.Missing).Msg("client> negotiated capabilities")
+	}
+
+	checkpoints, hasCheckpoints := s.db.(checkpointStore)
+	var previousPolicy *policy.ResolvedPolicy
+	var previousResults map[string]*llx.RawResult
+	if s.incremental && hasCheckpoints {
+		previousPolicy, previousResults = s.previousScan(checkpoints)
+	}
+
+	// failed checks weigh more on critical assets
+	ctx = policy.WithAssetCriticality(s.job.Ctx, policy.AssetCriticalityFromAsset(s.job.Asset))
+	ctx, resolveSpan := tracer.Start(ctx, "scan/resolve")
+	resolvedPolicy, err := resolver.ResolveAndUpdateJobs(ctx, &policy.UpdateAssetJobsReq{
 		AssetMrn:     s.job.Asset.Mrn,
 		AssetFilters: filters,
 	})
+	resolveSpan.End()
 	if err != nil {
 		return s.job.Bundle, resolvedPolicy, err
 	}
 	log.Debug().Str("asset", s.job.Asset.Mrn).Msg("client> got resolved policy bundle for asset")
 	logger.DebugDumpJSON("resolvedPolicy", resolvedPolicy)
 
+	sampling, err := assetBundle.SamplingByCodeID()
+	if err != nil {
+		return s.job.Bundle, resolvedPolicy, err
+	}
+
+	opts := []executor.ExecuteOption{executor.WithDataSampling(sampling), executor.WithCapabilities(capabilities)}
+	// checks whose prerequisites the asset doesn't meet are skipped
+	if prerequisites := evaluatePrerequisites(s.job.Ctx, s.job.Asset, assetBundle.PrerequisitesByCodeID(), s.prerequisites); prerequisites != nil {
+		log.Debug().Str("asset", s.job.Asset.Mrn).Interface("unmet", prerequisites.Unmet).Msg("client> evaluated prerequisites")
+		opts = append(opts, executor.WithPrerequisites(prerequisites))
+	}
+	if fingerprint := platformFingerprint(s.job.Asset); s.resultMemo != nil && fingerprint != "" {
+		opts = append(opts, executor.WithResultMemo(s.resultMemo, fingerprint, assetBundle.DeterministicCodeIDs()))
+	}
+	if s.resultCache != nil {
+		cacheScopes, err := assetBundle.ResultCacheScopes()
+		if err != nil {
+			return s.job.Bundle, resolvedPolicy, err
+		}
+		if keys := resultCacheKeys(s.job.Asset, cacheScopes); len(keys) != 0 {
+			opts = append(opts, executor.WithResultCache(s.resultCache, keys))
+		}
+	}
+	if s.job.auditTrail != nil {
+		opts = append(opts, executor.WithQueryObserver(s.job.auditTrail))
+	} else if s.queryConcurrency > 1 {
+		opts = append(opts, executor.WithQueryConcurrency(s.queryConcurrency))
+	}
+
+	if previousPolicy != nil {
+		opts = append(opts, executor.WithChangedQueries(previousPolicy, previousResults))
+	}
+
+	maxDataAges, err := assetBundle.MaxDataAgeByCodeID()
+	if err != nil {
+		return s.job.Bundle, resolvedPolicy, err
+	}
+	if len(maxDataAges) != 0 {
+		opts = append(opts, executor.WithDataAge(s.dataAge(maxDataAges, previousPolicy != nil)))
+	}
+
+	if s.resume && hasCheckpoints {
+		done, err := s.scannedBefore(checkpoints, resolvedPolicy)
+		if err != nil {
+			return s.job.Bundle, resolvedPolicy, err
+		}
+		if done {
+			log.Info().Str("asset", s.job.Asset.Name).Msg("asset was scanned before, resuming with its last report")
+			return assetBundle, resolvedPolicy, nil
+		}
+	}
+
 	features := cnquery.GetFeatures(s.job.Ctx)
-	err = executor.ExecuteResolvedPolicy(s.Schema, s.Runtime, resolver, s.job.Asset.Mrn, resolvedPolicy, features, s.ProgressReporter)
+	_, executeSpan := tracer.Start(s.job.Ctx, "scan/execute")
+	err = executor.ExecuteResolvedPolicy(s.Schema, s.Runtime, resolver, s.job.Asset.Mrn, resolvedPolicy, features, s.ProgressReporter, opts...)
+	executeSpan.End()
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if hasCheckpoints {
+		if err = checkpoints.SetScanCheckpoint(s.job.Ctx, s.job.Asset.Mrn, resolvedPolicy.GraphExecutionChecksum); err != nil {
+			log.Warn().Err(err).Str("asset", s.job.Asset.Name).Msg("could not store scan checkpoint")
+		}
+	}
+
 	return assetBundle, resolvedPolicy, nil
 }
 
+// previousScan returns the resolved policy and results of the asset's last
+// complete scan, if they can be reused for an incremental rescan
+// scannedBefore returns true if the asset was completely scanned with the
+// same resolved policy before, see WithResume
+func (s *localAssetScanner) scannedBefore(checkpoints checkpointStore, resolvedPolicy *policy.ResolvedPolicy) (bool, error) {
+	checksum, err := checkpoints.GetScanCheckpoint(s.job.Ctx, s.job.Asset.Mrn)
+	if err != nil {
+		return false, err
+	}
+	return checksum != "" && checksum == resolvedPolicy.GraphExecutionChecksum, nil
+}
+
+func (s *localAssetScanner) previousScan(checkpoints checkpointStore) (*policy.ResolvedPolicy, map[string]*llx.RawResult) {
+	completed, err := checkpoints.GetScanCheckpointTime(s.job.Ctx, s.job.Asset.Mrn)
+	if err != nil {
+		log.Warn().Err(err).Str("asset", s.job.Asset.Name).Msg("could not get scan checkpoint, executing all queries")
+		return nil, nil
+	}
+	if completed.IsZero() {
+		log.Debug().Str("asset", s.job.Asset.Name).Msg("asset was never completely scanned, executing all queries")
+		return nil, nil
+	}
+	if s.rescanMaxAge > 0 && time.Since(completed) > s.rescanMaxAge {
+		log.Debug().Str("asset", s.job.Asset.Name).Time("completed", completed).Msg("results of last scan expired, executing all queries")
+		return nil, nil
+	}
+
+	previousPolicy, err := s.db.GetResolvedPolicy(s.job.Ctx, s.job.Asset.Mrn)
+	if err != nil || previousPolicy == nil {
+		log.Debug().Err(err).Str("asset", s.job.Asset.Name).Msg("no previous resolved policy, executing all queries")
+		return nil, nil
+	}
+	report, err := s.services.GetReport(s.job.Ctx, &policy.EntityScoreReq{EntityMrn: s.job.Asset.Mrn, ScoreMrn: s.job.Asset.Mrn})
+	if err != nil {
+		log.Debug().Err(err).Str("asset", s.job.Asset.Name).Msg("no previous report, executing all queries")
+		return nil, nil
+	}
+
+	results := executor.PreviousResults(report)
+
+	// expired data is collected again, see policy.DataTTLTag
+	freshness, err := s.services.GetDataFreshness(s.job.Ctx, s.job.Asset.Mrn)
+	if err != nil {
+		log.Warn().Err(err).Str("asset", s.job.Asset.Name).Msg("could not get freshness of data, executing all queries")
+		return nil, nil
+	}
+	stale := policy.StaleDatapoints(freshness)
+	for _, checksum := range stale {
+		delete(results, checksum)
+	}
+
+	log.Debug().Str("asset", s.job.Asset.Name).Time("completed", completed).Int("stale", len(stale)).Msg("rescan changed queries only")
+	return previousPolicy, results
+}
+
+// platformFingerprint identifies assets that run the exact same platform.
+// It is empty if the platform of the asset is unknown.
+func platformFingerprint(assetObj *asset.Asset) string {
+	if assetObj == nil || assetObj.Platform == nil || assetObj.Platform.Name == "" {
+		return ""
+	}
+
+	p := assetObj.Platform
+	return policy.NewChecksum().
+		Add(p.Name).
+		Add(p.Release).
+		Add(p.Build).
+		Add(p.Arch).
+		Add(p.Kind.String()).
+		Add(p.Runtime).
+		String()
+}
+
+// platformFamily identifies assets of the same platform family, e.g. all
+// debian-based assets. It is empty if the platform of the asset is unknown.
+func platformFamily(assetObj *asset.Asset) string {
+	if assetObj == nil || assetObj.Platform == nil {
+		return ""
+	}
+	if len(assetObj.Platform.Family) != 0 {
+		return strings.Join(assetObj.Platform.Family, "/")
+	}
+	return assetObj.Platform.Name
+}
+
+// connectionIdentity identifies the endpoint and identity that the asset is
+// scanned with, e.g. the same cloud account or API. Secrets are never part
+// of it. It is empty if the asset has no connection.
+func connectionIdentity(assetObj *asset.Asset) string {
+	if assetObj == nil {
+		return ""
+	}
+
+	sum := policy.NewChecksum()
+	found := false
+	for _, conn := range assetObj.Connections {
+		if conn == nil {
+			continue
+		}
+		found = true
+		sum = sum.Add(conn.Backend.String()).Add(conn.Host).AddUint(uint64(conn.Port))
+		for _, cred := range conn.Credentials {
+			if cred != nil {
+				sum = sum.Add(cred.User)
+			}
+		}
+		keys := make([]string, 0, len(conn.Options))
+		for k := range conn.Options {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sum = sum.Add(k).Add(conn.Options[k])
+		}
+	}
+	if !found {
+		return ""
+	}
+	return sum.String()
+}
+
+// resultCacheKeys returns the cache keys of all queries that opted into the
+// result cache, indexed by code ID. Queries whose scope the asset can't be
+// identified for are not cached.
+func resultCacheKeys(assetObj *asset.Asset, scopes map[string]policy.ResultCacheScope) map[string]string {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	family := platformFamily(assetObj)
+	connection := connectionIdentity(assetObj)
+	res := make(map[string]string, len(scopes))
+	for codeID, scope := range scopes {
+		if key := policy.ResultCacheKey(scope, family, connection); key != "" {
+			res[codeID] = key
+		}
+	}
+	return res
+}
+
 func (s *localAssetScanner) getReport() (*policy.Report, error) {
 	var resolver policy.PolicyResolver = s.services
 
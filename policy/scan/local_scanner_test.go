@@ -0,0 +1,263 @@
+package scan
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/motor/platform"
+	"go.mondoo.com/cnquery/resources"
+	"go.mondoo.com/cnspec/internal/datalakes/inmemory"
+	"go.mondoo.com/cnspec/policy"
+)
+
+const testScanBundle = `
+policies:
+  - uid: ssh-policy
+    name: SSH Policy
+    version: "1.0.0"
+    groups:
+      - filters: asset.family.contains('unix')
+        checks:
+          - uid: ssh-check
+  - uid: tls-policy
+    name: TLS Policy
+    version: "1.0.0"
+    groups:
+      - filters: asset.family.contains('unix')
+        checks:
+          - uid: tls-check
+queries:
+  - uid: ssh-check
+    title: SSH is hardened
+    mql: true == true
+  - uid: tls-check
+    title: TLS is hardened
+    mql: true == true
+`
+
+// run with -race, assets of one job are prepared in parallel
+func TestPrepareAssetsConcurrently(t *testing.T) {
+	bundle, err := policy.BundleFromYAML([]byte(testScanBundle))
+	require.NoError(t, err)
+
+	assets := []*asset.Asset{
+		{Name: "web-01", Mrn: "//policy.api.mondoo.app/assets/web-01"},
+		{Name: "web-02", Mrn: "//policy.api.mondoo.app/assets/web-02"},
+		{Name: "web-03", Mrn: "//policy.api.mondoo.app/assets/web-03"},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(assets))
+	bundles := make([]*policy.Bundle, len(assets))
+	for i := range assets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, services, err := inmemory.NewServices(nil)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			scanner := &localAssetScanner{
+				services: services,
+				job: &AssetJob{
+					UpstreamConfig: resources.UpstreamConfig{Incognito: true},
+					Asset:          assets[i],
+					Bundle:         assetBundle(bundle),
+					PolicyFilters:  []string{"ssh-policy"},
+					Ctx:            context.Background(),
+				},
+			}
+			errs[i] = scanner.prepareAsset()
+			bundles[i] = scanner.job.Bundle
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range assets {
+		require.NoError(t, errs[i])
+		require.Len(t, bundles[i].Policies, 1)
+		assert.Equal(t, "ssh-policy", bundles[i].Policies[0].Uid)
+	}
+
+	// the job's bundle is shared by all assets and stays as it was
+	require.Len(t, bundle.Policies, 2)
+	assert.Equal(t, "ssh-policy", bundle.Policies[0].Uid)
+	assert.Equal(t, "tls-policy", bundle.Policies[1].Uid)
+	assert.Empty(t, bundle.Policies[0].Mrn)
+}
+
+func TestScanAssetsCanceled(t *testing.T) {
+	assetList := make([]*asset.Asset, 10)
+	for i := range assetList {
+		assetList[i] = &asset.Asset{Mrn: "//assets/" + strconv.Itoa(i)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const workers = 3
+	started := make(chan struct{}, workers)
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+
+	var lock sync.Mutex
+	scanned := map[string]struct{}{}
+	errored := map[string]struct{}{}
+
+	done := make(chan bool)
+	go func() {
+		done <- scanAssets(ctx, assetList, workers, func(a *asset.Asset) {
+			lock.Lock()
+			scanned[a.Mrn] = struct{}{}
+			lock.Unlock()
+			started <- struct{}{}
+			<-release
+		}, func(a *asset.Asset) {
+			// the first errored asset means that the job stopped handing out
+			// assets, so the running scans may finish
+			releaseOnce.Do(func() { close(release) })
+			lock.Lock()
+			errored[a.Mrn] = struct{}{}
+			lock.Unlock()
+		})
+	}()
+
+	// all workers are busy when the job is canceled
+	for i := 0; i < workers; i++ {
+		<-started
+	}
+	cancel()
+
+	assert.True(t, <-done)
+	assert.Len(t, scanned, workers)
+	assert.Len(t, errored, len(assetList)-workers)
+	for mrn := range scanned {
+		assert.NotContains(t, errored, mrn)
+	}
+}
+
+func TestScanAssetsConcurrency(t *testing.T) {
+	assetList := make([]*asset.Asset, 20)
+	for i := range assetList {
+		assetList[i] = &asset.Asset{Mrn: "//assets/" + strconv.Itoa(i)}
+	}
+
+	var lock sync.Mutex
+	running, maxRunning, scanned := 0, 0, 0
+	canceled := scanAssets(context.Background(), assetList, 4, func(a *asset.Asset) {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		lock.Lock()
+		running--
+		scanned++
+		lock.Unlock()
+	}, func(a *asset.Asset) {
+		t.Errorf("asset %s must not be marked as errored", a.Mrn)
+	})
+
+	assert.False(t, canceled)
+	assert.Equal(t, len(assetList), scanned)
+	assert.LessOrEqual(t, maxRunning, 4)
+}
+
+func TestAssetBundle(t *testing.T) {
+	assert.Nil(t, assetBundle(nil))
+
+	bundle, err := policy.BundleFromYAML([]byte(testScanBundle))
+	require.NoError(t, err)
+	res := assetBundle(bundle)
+	res.FilterPolicies([]string{"tls-policy"})
+	assert.Len(t, res.Policies, 1)
+	assert.Len(t, bundle.Policies, 2)
+}
+
+func TestIncognitoAssetMrn(t *testing.T) {
+	a := &asset.Asset{Name: "web-01", PlatformIds: []string{"//platformid.api.mondoo.app/hostname/web-01"}}
+
+	first, err := incognitoAssetMrn(a, true)
+	require.NoError(t, err)
+	second, err := incognitoAssetMrn(a, true)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Contains(t, first, "//policy.api.mondoo.com/assets/")
+
+	random, err := incognitoAssetMrn(a, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, random)
+
+	// assets without platform IDs can't be found again
+	first, err = incognitoAssetMrn(&asset.Asset{Name: "unknown"}, true)
+	require.NoError(t, err)
+	second, err = incognitoAssetMrn(&asset.Asset{Name: "unknown"}, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+type testCheckpoints map[string]string
+
+func (c testCheckpoints) GetScanCheckpoint(ctx context.Context, assetMrn string) (string, error) {
+	return c[assetMrn], nil
+}
+
+func (c testCheckpoints) GetScanCheckpointTime(ctx context.Context, assetMrn string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (c testCheckpoints) SetScanCheckpoint(ctx context.Context, assetMrn string, graphExecutionChecksum string) error {
+	c[assetMrn] = graphExecutionChecksum
+	return nil
+}
+
+func TestScannedBefore(t *testing.T) {
+	checkpoints := testCheckpoints{"//asset/scanned": "checksum"}
+	scanner := func(assetMrn string) *localAssetScanner {
+		return &localAssetScanner{job: &AssetJob{Asset: &asset.Asset{Mrn: assetMrn}, Ctx: context.Background()}}
+	}
+
+	done, err := scanner("//asset/scanned").scannedBefore(checkpoints, &policy.ResolvedPolicy{GraphExecutionChecksum: "checksum"})
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	// the policies changed since the last scan
+	done, err = scanner("//asset/scanned").scannedBefore(checkpoints, &policy.ResolvedPolicy{GraphExecutionChecksum: "changed"})
+	require.NoError(t, err)
+	assert.False(t, done)
+
+	done, err = scanner("//asset/new").scannedBefore(checkpoints, &policy.ResolvedPolicy{})
+	require.NoError(t, err)
+	assert.False(t, done)
+}
+
+func TestPlatformFingerprint(t *testing.T) {
+	golden := func() *asset.Asset {
+		return &asset.Asset{Platform: &platform.Platform{Name: "ubuntu", Release: "22.04", Arch: "x86_64"}}
+	}
+
+	a, b := golden(), golden()
+	b.Name = "web-02"
+	assert.NotEmpty(t, platformFingerprint(a))
+	assert.Equal(t, platformFingerprint(a), platformFingerprint(b))
+
+	b.Platform.Release = "22.10"
+	assert.NotEqual(t, platformFingerprint(a), platformFingerprint(b))
+
+	// assets of unknown platforms don't share results
+	assert.Empty(t, platformFingerprint(nil))
+	assert.Empty(t, platformFingerprint(&asset.Asset{}))
+	assert.Empty(t, platformFingerprint(&asset.Asset{Platform: &platform.Platform{}}))
+}
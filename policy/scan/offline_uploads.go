@@ -0,0 +1,104 @@
+package scan
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// offlineUploads queues results in the persistent datalake while upstream
+// is unreachable and sends them in the background once it is back. Every
+// upstream space has its own syncer, which sends the results of the space
+// with its credentials.
+type offlineUploads struct {
+	opts     policy.UploadSyncOptions
+	warnOnce sync.Once
+	lock     sync.Mutex
+	db       *sqlite.Db
+	syncers  map[string]*policy.UploadSyncer
+}
+
+// WithOfflineUploads keeps the results of assets that can't be sent upstream,
+// because it is unreachable, in the datalake and sends them in the
+// background with exponential backoff, see policy.UploadSyncer. This
+// requires a persistent datalake, see WithDataLake.
+func WithOfflineUploads(opts policy.UploadSyncOptions) ScannerOption {
+	return func(s *LocalScanner) {
+		s.offlineUploads = &offlineUploads{opts: opts, syncers: map[string]*policy.UploadSyncer{}}
+	}
+}
+
+// offlineQueue returns the offline queue of the datalake for the space and
+// makes sure that the syncer of the space runs with the upstream of the
+// job. It returns nil if the datalake can't queue uploads.
+func (s *LocalScanner) offlineQueue(db policy.DataLake, upstream *policy.Services, spaceMrn string) *policy.OfflineQueue {
+	if s.offlineUploads == nil {
+		return nil
+	}
+	if s.dataLakePath == "" {
+		// in-memory datalakes only live as long as the scan of their asset
+		s.offlineUploads.warnOnce.Do(func() {
+			log.Warn().Msg("offline uploads require a persistent datalake, results are not queued")
+		})
+		return nil
+	}
+	queue, ok := db.(policy.UploadQueue)
+	if !ok {
+		return nil
+	}
+
+	syncer := s.offlineUploads.syncer(s.dataLakePath, upstream, spaceMrn)
+	if syncer == nil {
+		return nil
+	}
+	return &policy.OfflineQueue{Queue: queue, SpaceMrn: spaceMrn, Syncer: syncer}
+}
+
+// syncer returns the running syncer of the space and starts it with the
+// upstream of the job if there is none yet
+func (o *offlineUploads) syncer(dataLakePath string, upstream *policy.Services, spaceMrn string) *policy.UploadSyncer {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if syncer, ok := o.syncers[spaceMrn]; ok {
+		return syncer
+	}
+
+	if o.db == nil {
+		// the datalake of an asset scan is closed once the scan is done, the
+		// syncers share their own connection
+		syncDb, err := sqlite.Open(dataLakePath)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to open the datalake to send queued results")
+			return nil
+		}
+		o.db = syncDb
+	}
+
+	syncer := policy.NewUploadSyncer(o.db, spaceMrn, upstream.PolicyResolver, o.opts)
+	syncer.Start()
+	o.syncers[spaceMrn] = syncer
+	return syncer
+}
+
+// CloseOfflineUploads stops sending queued results. Results that weren't
+// sent stay in the datalake and are sent by the next scanner.
+func (s *LocalScanner) CloseOfflineUploads() {
+	if s.offlineUploads == nil {
+		return
+	}
+	o := s.offlineUploads
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	for spaceMrn, syncer := range o.syncers {
+		syncer.Close()
+		delete(o.syncers, spaceMrn)
+	}
+	if o.db != nil {
+		o.db.Close()
+		o.db = nil
+	}
+}
@@ -0,0 +1,159 @@
+package scan
+
+import (
+	"context"
+	"sort"
+
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnspec/policy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes for check results emitted to OpenTelemetry
+const (
+	OtelCheckResultEvent = "cnspec.check.result"
+
+	OtelAttrAssetMrn      = attribute.Key("cnspec.asset.mrn")
+	OtelAttrAssetName     = attribute.Key("cnspec.asset.name")
+	OtelAttrAssetPlatform = attribute.Key("cnspec.asset.platform")
+	OtelAttrPolicyMrns    = attribute.Key("cnspec.policy.mrns")
+	OtelAttrCheckMrn      = attribute.Key("cnspec.check.mrn")
+	OtelAttrCheckTitle    = attribute.Key("cnspec.check.title")
+	OtelAttrCheckCodeID   = attribute.Key("cnspec.check.code_id")
+	OtelAttrScoreValue    = attribute.Key("cnspec.score.value")
+	OtelAttrScoreType     = attribute.Key("cnspec.score.type")
+	OtelAttrScoreMessage  = attribute.Key("cnspec.score.message")
+)
+
+// OtelReporter emits every check result of a scan as an OpenTelemetry event
+// and forwards all results to the wrapped reporter. Each asset is reported as
+// one span, which carries one event per check. This allows observability
+// pipelines to correlate compliance results with other telemetry.
+type OtelReporter struct {
+	Reporter
+	tracer trace.Tracer
+}
+
+// NewOtelReporter wraps the given reporter. If no tracer provider is given,
+// the global tracer provider is used.
+func NewOtelReporter(reporter Reporter, provider trace.TracerProvider) Reporter {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &OtelReporter{
+		Reporter: reporter,
+		tracer:   provider.Tracer("go.mondoo.com/cnspec/policy/scan"),
+	}
+}
+
+func (r *OtelReporter) AddReport(asset *asset.Asset, results *AssetReport) {
+	r.emitReport(asset, results)
+	r.Reporter.AddReport(asset, results)
+}
+
+func (r *OtelReporter) AddScanError(asset *asset.Asset, err error) {
+	_, span := r.tracer.Start(context.Background(), "cnspec.scan.asset", trace.WithAttributes(otelAssetAttributes(asset)...))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	span.End()
+
+	r.Reporter.AddScanError(asset, err)
+}
+
+func (r *OtelReporter) emitReport(asset *asset.Asset, results *AssetReport) {
+	if results == nil || results.Report == nil || results.ResolvedPolicy == nil || results.Bundle == nil {
+		return
+	}
+
+	_, span := r.tracer.Start(context.Background(), "cnspec.scan.asset", trace.WithAttributes(otelAssetAttributes(asset)...))
+	defer span.End()
+
+	if results.Report.Score != nil {
+		span.SetAttributes(
+			OtelAttrScoreValue.Int64(int64(results.Report.Score.Value)),
+			OtelAttrScoreType.String(results.Report.Score.TypeLabel()),
+		)
+	}
+
+	queries := results.Bundle.ToMap().QueryMap()
+	collectorJob := results.ResolvedPolicy.CollectorJob
+
+	ids := make([]string, 0, len(collectorJob.ReportingQueries))
+	for id := range collectorJob.ReportingQueries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		score, ok := results.Report.Scores[id]
+		if !ok || score == nil {
+			continue
+		}
+
+		attrs := []attribute.KeyValue{
+			OtelAttrCheckCodeID.String(id),
+			OtelAttrPolicyMrns.StringSlice(checkPolicyMrns(collectorJob, id, results.Mrn)),
+			OtelAttrScoreValue.Int64(int64(score.Value)),
+			OtelAttrScoreType.String(score.TypeLabel()),
+		}
+		if query, ok := queries[id]; ok {
+			attrs = append(attrs,
+				OtelAttrCheckMrn.String(query.Mrn),
+				OtelAttrCheckTitle.String(query.Title),
+			)
+		}
+		if score.Message != "" {
+			attrs = append(attrs, OtelAttrScoreMessage.String(score.MessageLine()))
+		}
+
+		span.AddEvent(OtelCheckResultEvent, trace.WithAttributes(attrs...))
+	}
+}
+
+func otelAssetAttributes(asset *asset.Asset) []attribute.KeyValue {
+	if asset == nil {
+		return nil
+	}
+
+	res := []attribute.KeyValue{
+		OtelAttrAssetMrn.String(asset.Mrn),
+		OtelAttrAssetName.String(asset.Name),
+	}
+	if asset.Platform != nil {
+		res = append(res, OtelAttrAssetPlatform.String(asset.Platform.Name))
+	}
+	return res
+}
+
+// checkPolicyMrns returns the policies that directly include a check
+func checkPolicyMrns(collectorJob *policy.CollectorJob, codeID string, assetMrn string) []string {
+	res := []string{}
+	jobs, ok := collectorJob.ReportingQueries[codeID]
+	if !ok {
+		return res
+	}
+
+	seen := map[string]struct{}{}
+	for _, uuid := range jobs.Items {
+		rj, ok := collectorJob.ReportingJobs[uuid]
+		if !ok {
+			continue
+		}
+		for _, parentID := range rj.Notify {
+			parent, ok := collectorJob.ReportingJobs[parentID]
+			if !ok || parent.QrId == "root" || parent.QrId == assetMrn {
+				continue
+			}
+			if _, ok := seen[parent.QrId]; ok {
+				continue
+			}
+			seen[parent.QrId] = struct{}{}
+			res = append(res, parent.QrId)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
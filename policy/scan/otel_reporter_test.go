@@ -0,0 +1,191 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/motor/platform"
+	"go.mondoo.com/cnspec/policy"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type recordedEvent struct {
+	name  string
+	attrs map[attribute.Key]attribute.Value
+}
+
+// recordedSpan records what the reporter emits, all other span methods are
+// no-ops
+type recordedSpan struct {
+	trace.Span
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	events []recordedEvent
+	errs   []error
+	status codes.Code
+	ended  bool
+}
+
+func attrMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	res := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, attr := range attrs {
+		res[attr.Key] = attr.Value
+	}
+	return res
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for k, v := range attrMap(kv) {
+		s.attrs[k] = v
+	}
+}
+
+func (s *recordedSpan) AddEvent(name string, options ...trace.EventOption) {
+	cfg := trace.NewEventConfig(options...)
+	s.events = append(s.events, recordedEvent{name: name, attrs: attrMap(cfg.Attributes())})
+}
+
+func (s *recordedSpan) RecordError(err error, options ...trace.EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, description string) {
+	s.status = code
+}
+
+func (s *recordedSpan) End(options ...trace.SpanEndOption) {
+	s.ended = true
+}
+
+// spanRecorder is a tracer provider that keeps all spans that were started
+type spanRecorder struct {
+	lock  sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *spanRecorder) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	return r
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(options...)
+	span := &recordedSpan{
+		Span:  trace.SpanFromContext(ctx),
+		name:  name,
+		attrs: attrMap(cfg.Attributes()),
+	}
+	r.lock.Lock()
+	r.spans = append(r.spans, span)
+	r.lock.Unlock()
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func testOtelAssetReport() (*asset.Asset, *AssetReport) {
+	a := &asset.Asset{
+		Mrn:      "//asset",
+		Name:     "web-01",
+		Platform: &platform.Platform{Name: "debian"},
+	}
+	report := &AssetReport{
+		Mrn: "//asset",
+		Bundle: &policy.Bundle{
+			Queries: []*explorer.Mquery{
+				{Mrn: "//check/ssh", CodeId: "ssh-code", Title: "SSH is hardened"},
+			},
+		},
+		ResolvedPolicy: &policy.ResolvedPolicy{
+			CollectorJob: &policy.CollectorJob{
+				ReportingJobs: map[string]*policy.ReportingJob{
+					"root":   {Uuid: "root", QrId: "root"},
+					"policy": {Uuid: "policy", QrId: "//policy", Notify: []string{"root"}},
+					"ssh":    {Uuid: "ssh", QrId: "ssh-code", Notify: []string{"policy"}},
+					"tls":    {Uuid: "tls", QrId: "tls-code", Notify: []string{"policy"}},
+				},
+				ReportingQueries: map[string]*policy.StringArray{
+					"ssh-code": {Items: []string{"ssh"}},
+					"tls-code": {Items: []string{"tls"}},
+				},
+			},
+		},
+		Report: &policy.Report{
+			Score: &policy.Score{Value: 50, Type: policy.ScoreType_Result},
+			Scores: map[string]*policy.Score{
+				"ssh-code": {QrId: "ssh-code", Value: 100, Type: policy.ScoreType_Result},
+				"tls-code": {QrId: "tls-code", Value: 0, Type: policy.ScoreType_Result, Message: "expected\ntls 1.2"},
+			},
+		},
+	}
+	return a, report
+}
+
+func TestOtelReporter(t *testing.T) {
+	t.Run("one event per check", func(t *testing.T) {
+		recorder := &spanRecorder{}
+		reporter := NewOtelReporter(NewAggregateReporter(), recorder)
+
+		a, report := testOtelAssetReport()
+		reporter.AddReport(a, report)
+
+		require.Len(t, recorder.spans, 1)
+		span := recorder.spans[0]
+		assert.True(t, span.ended)
+		assert.Equal(t, "cnspec.scan.asset", span.name)
+		assert.Equal(t, "//asset", span.attrs[OtelAttrAssetMrn].AsString())
+		assert.Equal(t, "web-01", span.attrs[OtelAttrAssetName].AsString())
+		assert.Equal(t, "debian", span.attrs[OtelAttrAssetPlatform].AsString())
+		assert.Equal(t, int64(50), span.attrs[OtelAttrScoreValue].AsInt64())
+
+		require.Len(t, span.events, 2)
+		ssh := span.events[0]
+		assert.Equal(t, OtelCheckResultEvent, ssh.name)
+		assert.Equal(t, "ssh-code", ssh.attrs[OtelAttrCheckCodeID].AsString())
+		assert.Equal(t, "//check/ssh", ssh.attrs[OtelAttrCheckMrn].AsString())
+		assert.Equal(t, "SSH is hardened", ssh.attrs[OtelAttrCheckTitle].AsString())
+		assert.Equal(t, []string{"//policy"}, ssh.attrs[OtelAttrPolicyMrns].AsStringSlice())
+		assert.Equal(t, int64(100), ssh.attrs[OtelAttrScoreValue].AsInt64())
+		assert.Equal(t, "result", ssh.attrs[OtelAttrScoreType].AsString())
+		_, ok := ssh.attrs[OtelAttrScoreMessage]
+		assert.False(t, ok)
+
+		tls := span.events[1]
+		assert.Equal(t, "tls-code", tls.attrs[OtelAttrCheckCodeID].AsString())
+		assert.Equal(t, int64(0), tls.attrs[OtelAttrScoreValue].AsInt64())
+		assert.Equal(t, "expected tls 1.2", tls.attrs[OtelAttrScoreMessage].AsString())
+		_, ok = tls.attrs[OtelAttrCheckMrn]
+		assert.False(t, ok)
+	})
+
+	t.Run("scan errors", func(t *testing.T) {
+		recorder := &spanRecorder{}
+		reporter := NewOtelReporter(NewAggregateReporter(), recorder)
+
+		a, _ := testOtelAssetReport()
+		reporter.AddScanError(a, errors.New("connection refused"))
+
+		require.Len(t, recorder.spans, 1)
+		span := recorder.spans[0]
+		assert.True(t, span.ended)
+		assert.Equal(t, codes.Error, span.status)
+		require.Len(t, span.errs, 1)
+		assert.EqualError(t, span.errs[0], "connection refused")
+		assert.Empty(t, span.events)
+	})
+
+	t.Run("reports without results", func(t *testing.T) {
+		recorder := &spanRecorder{}
+		reporter := NewOtelReporter(NewAggregateReporter(), recorder)
+
+		a, report := testOtelAssetReport()
+		report.ResolvedPolicy = nil
+		reporter.AddReport(a, report)
+		assert.Empty(t, recorder.spans)
+	})
+}
@@ -0,0 +1,81 @@
+package scan
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/resources"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// precomputeResolve resolves the asset policies of the job's assets for the
+// asset filters that the assets had in their previous scan, before any
+// asset is connected. The policies of the bundle are assigned to the assets
+// like the asset scans do, so that the scans find the precomputed resolved
+// policies in the cache. This needs a persistent datalake, both for the
+// filters of the previous scans and to keep the resolved policies for the
+// asset scans. Assets that are resolved upstream aren't precomputed.
+// Failures only cost time, the assets are resolved during their scan.
+func (s *LocalScanner) precomputeResolve(ctx context.Context, job *Job, upstreamConfig resources.UpstreamConfig, assets []*asset.Asset) {
+	if s.dataLakePath == "" || job.Bundle == nil || len(assets) < 2 || !upstreamConfig.Incognito {
+		return
+	}
+
+	err := sqlite.WithDb(s.dataLakePath, func(db *sqlite.Db, services *policy.LocalServices) error {
+		db.SetResolvedPolicyTTL(s.resolvedPolicyTTL)
+
+		precompute := []policy.PrecomputeAsset{}
+		for i := range assets {
+			if assets[i].Mrn == "" {
+				continue
+			}
+			rp, err := db.GetResolvedPolicy(ctx, assets[i].Mrn)
+			if err != nil || len(rp.Filters) == 0 {
+				// the asset wasn't scanned before
+				continue
+			}
+			precompute = append(precompute, policy.PrecomputeAsset{
+				Mrn:         assets[i].Mrn,
+				Filters:     rp.Filters,
+				Criticality: policy.AssetCriticalityFromAsset(assets[i]),
+			})
+		}
+		if len(precompute) == 0 {
+			return nil
+		}
+
+		// the bundle is filtered and compiled the same way as in the asset
+		// scans, so that the asset policies get the same checksums
+		bundle := proto.Clone(job.Bundle).(*policy.Bundle)
+		bundle.FilterPolicies(job.PolicyFilters)
+		if len(bundle.Policies) == 0 {
+			return nil
+		}
+		bundleCtx := ctx
+		if s.resultMemo != nil {
+			bundleCtx = policy.WithNondeterminismMarking(ctx)
+		}
+		if _, err := services.SetBundle(bundleCtx, bundle); err != nil {
+			return err
+		}
+
+		policyMrns := bundle.PolicyMRNs()
+		for i := range precompute {
+			assetMrn := precompute[i].Mrn
+			if _, err := services.Assign(ctx, &policy.PolicyAssignment{AssetMrn: assetMrn, PolicyMrns: policyMrns}); err != nil {
+				return err
+			}
+			if err := setJobProps(ctx, services, assetMrn, job.Props); err != nil {
+				return err
+			}
+		}
+
+		return services.PrecomputeAssetResolve(ctx, precompute, s.maxConcurrency)
+	})
+	if err != nil {
+		log.Debug().Err(err).Msg("failed to precompute resolved policies")
+	}
+}
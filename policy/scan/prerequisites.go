@@ -0,0 +1,95 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/motor/asset"
+	providers "go.mondoo.com/cnquery/motor/providers"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// PrerequisiteEvaluator decides whether an asset meets a prerequisite of
+// checks, see policy.PrerequisitesTag. It gets the argument of the
+// prerequisite, e.g. `compute.readonly` for `scope:compute.readonly`, and
+// returns why the asset doesn't meet it, or an empty string if it does.
+type PrerequisiteEvaluator func(ctx context.Context, assetObj *asset.Asset, arg string) string
+
+// defaultPrerequisiteEvaluators are the built-in prerequisites
+var defaultPrerequisiteEvaluators = map[string]PrerequisiteEvaluator{
+	"sudo":  sudoPrerequisite,
+	"scope": scopePrerequisite,
+}
+
+// sudoPrerequisite is met if the asset is scanned with elevated privileges,
+// i.e. via sudo or as root
+func sudoPrerequisite(ctx context.Context, assetObj *asset.Asset, arg string) string {
+	for _, conn := range assetObj.Connections {
+		if conn == nil {
+			continue
+		}
+		if conn.Sudo != nil && conn.Sudo.Active {
+			return ""
+		}
+		for _, cred := range conn.Credentials {
+			if cred != nil && cred.User == "root" {
+				return ""
+			}
+		}
+		if conn.Backend == providers.ProviderType_LOCAL_OS && os.Geteuid() == 0 {
+			return ""
+		}
+	}
+	return "the connection neither uses sudo nor root"
+}
+
+// scopePrerequisite is met if the connection grants the API scope, which is
+// configured in its comma-separated `scopes` option
+func scopePrerequisite(ctx context.Context, assetObj *asset.Asset, arg string) string {
+	if arg == "" {
+		return "no scope was specified"
+	}
+	for _, conn := range assetObj.Connections {
+		if conn == nil {
+			continue
+		}
+		for _, scope := range strings.Split(conn.Options["scopes"], ",") {
+			if strings.TrimSpace(scope) == arg {
+				return ""
+			}
+		}
+	}
+	return "the connection doesn't grant the scope " + arg
+}
+
+// evaluatePrerequisites evaluates every prerequisite of the checks once for
+// the asset. Custom evaluators take precedence over the built-in ones.
+// Prerequisites without evaluator are assumed to be met, so that their
+// checks still run.
+func evaluatePrerequisites(ctx context.Context, assetObj *asset.Asset, required map[string][]string, evaluators map[string]PrerequisiteEvaluator) *policy.Prerequisites {
+	if len(required) == 0 {
+		return nil
+	}
+
+	res := &policy.Prerequisites{
+		Required: required,
+		Unmet:    map[string]string{},
+	}
+	for _, prerequisite := range res.All() {
+		name, arg, _ := strings.Cut(prerequisite, ":")
+		evaluate, ok := evaluators[name]
+		if !ok {
+			evaluate, ok = defaultPrerequisiteEvaluators[name]
+		}
+		if !ok {
+			log.Warn().Str("asset", assetObj.Mrn).Str("prerequisite", prerequisite).Msg("unknown prerequisite, run checks that require it anyway")
+			continue
+		}
+		if reason := evaluate(ctx, assetObj, strings.TrimSpace(arg)); reason != "" {
+			res.Unmet[prerequisite] = reason
+		}
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package scan
+
+import (
+	"go.mondoo.com/cnquery/cli/progress"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// ProgressState is the phase an asset scan is in
+type ProgressState int
+
+const (
+	// ProgressConnected is sent once the connection to the asset is established
+	ProgressConnected ProgressState = iota + 1
+	// ProgressResolving is sent while policies are resolved for the asset
+	ProgressResolving
+	// ProgressExecuting is sent whenever queries finished running
+	ProgressExecuting
+	// ProgressScored is sent once the asset is scored, this is the last event
+	ProgressScored
+	// ProgressErrored is sent if the asset could not be scanned, this is the last event
+	ProgressErrored
+)
+
+func (s ProgressState) String() string {
+	switch s {
+	case ProgressConnected:
+		return "connected"
+	case ProgressResolving:
+		return "resolving"
+	case ProgressExecuting:
+		return "executing"
+	case ProgressScored:
+		return "scored"
+	case ProgressErrored:
+		return "errored"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressEvent describes the progress of one asset scan
+type ProgressEvent struct {
+	AssetMrn  string
+	AssetName string
+	State     ProgressState
+	// Completed and Total queries, set while executing
+	Completed int
+	Total     int
+	// Score is set once the asset is scored
+	Score *policy.Score
+}
+
+// ProgressFunc receives progress events. With concurrent scans it is called
+// from multiple goroutines and must not block for long.
+type ProgressFunc func(event ProgressEvent)
+
+// WithProgress registers a callback that observes the progress of all asset
+// scans, e.g. to drive a UI for long multi-asset scans.
+func WithProgress(f ProgressFunc) ScannerOption {
+	return func(s *LocalScanner) {
+		s.progressFn = f
+	}
+}
+
+// WithProgressChannel sends all progress events to the channel. Scans block
+// until their events are received.
+func WithProgressChannel(events chan<- ProgressEvent) ScannerOption {
+	return WithProgress(func(event ProgressEvent) {
+		events <- event
+	})
+}
+
+// progressTracker forwards the progress of an asset scan to the progress
+// function, in addition to the progress bars
+type progressTracker struct {
+	progress.Progress
+	asset *asset.Asset
+	fn    ProgressFunc
+}
+
+func newProgressTracker(p progress.Progress, asset *asset.Asset, fn ProgressFunc) progress.Progress {
+	if fn == nil {
+		return p
+	}
+	return &progressTracker{Progress: p, asset: asset, fn: fn}
+}
+
+func (p *progressTracker) send(event ProgressEvent) {
+	event.AssetMrn = p.asset.Mrn
+	event.AssetName = p.asset.Name
+	p.fn(event)
+}
+
+func (p *progressTracker) OnProgress(current int, total int) {
+	p.Progress.OnProgress(current, total)
+	p.send(ProgressEvent{State: ProgressExecuting, Completed: current, Total: total})
+}
+
+func (p *progressTracker) Errored() {
+	p.Progress.Errored()
+	p.send(ProgressEvent{State: ProgressErrored})
+}
+
+// reportProgress sends an event for states that the progress bars don't track
+func reportProgress(p progress.Progress, state ProgressState, score *policy.Score) {
+	if tracker, ok := p.(*progressTracker); ok {
+		tracker.send(ProgressEvent{State: state, Score: score})
+	}
+}
@@ -0,0 +1,78 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/cli/progress"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// countingProgress counts the calls that reach the progress bars
+type countingProgress struct {
+	progress.Noop
+	progressCalls int
+	errored       int
+}
+
+func (p *countingProgress) OnProgress(current int, total int) { p.progressCalls++ }
+func (p *countingProgress) Errored()                          { p.errored++ }
+
+func TestProgressTracker(t *testing.T) {
+	a := &asset.Asset{Mrn: "//assets/a", Name: "a"}
+
+	t.Run("without a progress function the bars are used as is", func(t *testing.T) {
+		bars := &countingProgress{}
+		assert.Same(t, bars, newProgressTracker(bars, a, nil))
+
+		// states that the bars don't track are dropped
+		reportProgress(bars, ProgressConnected, nil)
+	})
+
+	t.Run("events are sent in addition to the bars", func(t *testing.T) {
+		events := []ProgressEvent{}
+		bars := &countingProgress{}
+		p := newProgressTracker(bars, a, func(event ProgressEvent) {
+			events = append(events, event)
+		})
+
+		score := &policy.Score{Value: 80}
+		reportProgress(p, ProgressConnected, nil)
+		reportProgress(p, ProgressResolving, nil)
+		p.OnProgress(1, 4)
+		p.OnProgress(4, 4)
+		reportProgress(p, ProgressScored, score)
+
+		assert.Equal(t, 2, bars.progressCalls)
+		assert.Equal(t, []ProgressEvent{
+			{AssetMrn: a.Mrn, AssetName: a.Name, State: ProgressConnected},
+			{AssetMrn: a.Mrn, AssetName: a.Name, State: ProgressResolving},
+			{AssetMrn: a.Mrn, AssetName: a.Name, State: ProgressExecuting, Completed: 1, Total: 4},
+			{AssetMrn: a.Mrn, AssetName: a.Name, State: ProgressExecuting, Completed: 4, Total: 4},
+			{AssetMrn: a.Mrn, AssetName: a.Name, State: ProgressScored, Score: score},
+		}, events)
+	})
+
+	t.Run("errors are sent as the last event", func(t *testing.T) {
+		events := make(chan ProgressEvent, 1)
+		s := newHookedScanner(WithProgressChannel(events))
+		bars := &countingProgress{}
+		p := newProgressTracker(bars, a, s.progressFn)
+
+		p.Errored()
+		assert.Equal(t, 1, bars.errored)
+		require.Len(t, events, 1)
+		assert.Equal(t, ProgressEvent{AssetMrn: a.Mrn, AssetName: a.Name, State: ProgressErrored}, <-events)
+	})
+}
+
+func TestProgressState(t *testing.T) {
+	assert.Equal(t, "connected", ProgressConnected.String())
+	assert.Equal(t, "resolving", ProgressResolving.String())
+	assert.Equal(t, "executing", ProgressExecuting.String())
+	assert.Equal(t, "scored", ProgressScored.String())
+	assert.Equal(t, "errored", ProgressErrored.String())
+	assert.Equal(t, "unknown", ProgressState(0).String())
+}
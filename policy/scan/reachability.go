@@ -0,0 +1,117 @@
+package scan
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/motor/providers"
+	"golang.org/x/time/rate"
+)
+
+// ErrUnreachable is the class of errors for assets that could not be
+// reached over the network, use errors.Is to check for it
+var ErrUnreachable = errors.New("asset is unreachable")
+
+// UnreachableError is returned for assets whose host could not be resolved
+// or whose port did not accept connections
+type UnreachableError struct {
+	Host string
+	// Port is 0 if the host could not be resolved
+	Port int
+	Err  error
+}
+
+func (e *UnreachableError) Error() string {
+	if e.Port == 0 {
+		return "cannot resolve host " + e.Host + ": " + e.Err.Error()
+	}
+	return "cannot reach " + net.JoinHostPort(e.Host, strconv.Itoa(e.Port)) + ": " + e.Err.Error()
+}
+
+func (e *UnreachableError) Unwrap() error {
+	return e.Err
+}
+
+func (e *UnreachableError) Is(target error) bool {
+	return target == ErrUnreachable
+}
+
+// defaultPorts of network backends, used if the connection has no port set
+var defaultPorts = map[providers.ProviderType]int{
+	providers.ProviderType_SSH:   22,
+	providers.ProviderType_WINRM: 5985,
+}
+
+// reachabilityChecker resolves and probes network assets before the scanner
+// opens full connections to them. Probes are rate-limited, so that large
+// inventories don't flood the network.
+type reachabilityChecker struct {
+	limiter  *rate.Limiter
+	timeout  time.Duration
+	resolver *net.Resolver
+}
+
+func newReachabilityChecker(probesPerSecond int, timeout time.Duration) *reachabilityChecker {
+	if probesPerSecond < 1 {
+		probesPerSecond = 1
+	}
+	return &reachabilityChecker{
+		limiter:  rate.NewLimiter(rate.Limit(probesPerSecond), probesPerSecond),
+		timeout:  timeout,
+		resolver: net.DefaultResolver,
+	}
+}
+
+// check returns an UnreachableError if any network connection of the asset
+// cannot be reached. Connections without a host are not checked.
+func (c *reachabilityChecker) check(ctx context.Context, assetObj *asset.Asset) error {
+	for _, conn := range assetObj.Connections {
+		if conn == nil || conn.Host == "" {
+			continue
+		}
+		if err := c.checkConnection(ctx, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *reachabilityChecker) checkConnection(ctx context.Context, conn *providers.Config) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	host := conn.Host
+	if net.ParseIP(host) == nil {
+		addrs, err := c.resolver.LookupHost(ctx, host)
+		if err != nil {
+			return &UnreachableError{Host: host, Err: err}
+		}
+		if len(addrs) == 0 {
+			return &UnreachableError{Host: host, Err: errors.New("no addresses found")}
+		}
+	}
+
+	port := int(conn.Port)
+	if port == 0 {
+		port = defaultPorts[conn.Backend]
+	}
+	if port == 0 {
+		// we don't know where to probe this backend
+		return nil
+	}
+
+	dialer := net.Dialer{}
+	tcp, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return &UnreachableError{Host: host, Port: port, Err: err}
+	}
+	return tcp.Close()
+}
@@ -0,0 +1,122 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/motor/providers"
+)
+
+// listen opens a local port that accepts connections until the test ends
+func listen(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// closedPort returns a local port that doesn't accept connections
+func closedPort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+	return port
+}
+
+func sshAsset(host string, port int) *asset.Asset {
+	return &asset.Asset{Connections: []*providers.Config{{
+		Backend: providers.ProviderType_SSH,
+		Host:    host,
+		Port:    int32(port),
+	}}}
+}
+
+func TestReachabilityChecker(t *testing.T) {
+	ctx := context.Background()
+	c := newReachabilityChecker(100, time.Second)
+
+	t.Run("open port", func(t *testing.T) {
+		assert.NoError(t, c.check(ctx, sshAsset("127.0.0.1", listen(t))))
+	})
+
+	t.Run("closed port", func(t *testing.T) {
+		port := closedPort(t)
+		err := c.check(ctx, sshAsset("127.0.0.1", port))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrUnreachable)
+
+		var unreachable *UnreachableError
+		require.True(t, errors.As(err, &unreachable))
+		assert.Equal(t, "127.0.0.1", unreachable.Host)
+		assert.Equal(t, port, unreachable.Port)
+		assert.Contains(t, err.Error(), "cannot reach 127.0.0.1:"+strconv.Itoa(port))
+	})
+
+	t.Run("unresolvable host", func(t *testing.T) {
+		c := newReachabilityChecker(100, time.Second)
+		c.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return nil, errors.New("no DNS in tests")
+			},
+		}
+
+		err := c.check(ctx, sshAsset("host.invalid", 22))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrUnreachable)
+
+		var unreachable *UnreachableError
+		require.True(t, errors.As(err, &unreachable))
+		assert.Equal(t, "host.invalid", unreachable.Host)
+		assert.Equal(t, 0, unreachable.Port)
+		assert.Contains(t, err.Error(), "cannot resolve host host.invalid")
+	})
+
+	t.Run("connections without host or port are skipped", func(t *testing.T) {
+		a := &asset.Asset{Connections: []*providers.Config{
+			nil,
+			{Backend: providers.ProviderType_LOCAL_OS},
+			{Backend: providers.ProviderType_AWS, Host: "127.0.0.1"},
+		}}
+		assert.NoError(t, c.check(ctx, a))
+	})
+}
+
+func TestReachabilityRateLimit(t *testing.T) {
+	ctx := context.Background()
+	port := listen(t)
+
+	// the first two probes pass right away, the next ones wait for the limit
+	c := newReachabilityChecker(2, time.Second)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, c.check(ctx, sshAsset("127.0.0.1", port)))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+	// probes that wait for the limit stop with the context
+	c = newReachabilityChecker(1, time.Second)
+	require.NoError(t, c.check(ctx, sshAsset("127.0.0.1", port)))
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err := c.check(canceled, sshAsset("127.0.0.1", port))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUnreachable)
+}
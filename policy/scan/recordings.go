@@ -0,0 +1,92 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnspec/internal/recordings"
+)
+
+// motor writes recordings into the working directory when a recorded
+// connection is closed
+const recordingPattern = "recording-*.toml"
+
+// WithRecordingStore keeps the recordings of all assets that are scanned with
+// DoRecord in the given store, together with metadata to find them again
+func WithRecordingStore(store recordings.Store) ScannerOption {
+	return func(s *LocalScanner) {
+		s.recordings = &recordingCollector{
+			store:   store,
+			claimed: map[string]struct{}{},
+		}
+	}
+}
+
+type recordingCollector struct {
+	store recordings.Store
+	// assets are scanned in parallel, so every file is only stored once
+	mu      sync.Mutex
+	claimed map[string]struct{}
+}
+
+// claim returns all recordings in the working directory that were written
+// since the given time and weren't stored yet
+func (c *recordingCollector) claim(since time.Time) []string {
+	paths, err := filepath.Glob(recordingPattern)
+	if err != nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var res []string
+	for _, path := range paths {
+		if _, ok := c.claimed[path]; ok {
+			continue
+		}
+		info, err := os.Stat(path)
+		// recordings are named by the second they were written
+		if err != nil || info.ModTime().Before(since.Truncate(time.Second)) {
+			continue
+		}
+		c.claimed[path] = struct{}{}
+		res = append(res, path)
+	}
+	return res
+}
+
+// collect stores the recordings of an asset once its connection is closed
+func (c *recordingCollector) collect(job *AssetJob, since time.Time) {
+	meta := recordings.Metadata{
+		AssetMrn:    job.Asset.Mrn,
+		AssetName:   job.Asset.Name,
+		PlatformIds: job.Asset.PlatformIds,
+	}
+	if job.Bundle != nil {
+		if checksum, err := job.Bundle.SourceHash(); err == nil {
+			meta.BundleChecksum = checksum
+		}
+	}
+
+	for _, path := range c.claim(since) {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("failed to read recording")
+			continue
+		}
+
+		cur := meta
+		cur.Recorded = time.Now()
+		err = c.store.Put(job.Ctx, &cur, f)
+		f.Close()
+		if err != nil {
+			log.Warn().Err(err).Str("path", path).Str("asset", job.Asset.Name).Msg("failed to store recording")
+			continue
+		}
+		log.Info().Str("id", cur.ID).Str("asset", job.Asset.Name).Msg("stored recording")
+	}
+}
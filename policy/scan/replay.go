@@ -0,0 +1,139 @@
+package scan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/motor/asset"
+	v1 "go.mondoo.com/cnquery/motor/inventory/v1"
+	providers "go.mondoo.com/cnquery/motor/providers"
+	"go.mondoo.com/cnquery/mrn"
+	"go.mondoo.com/cnspec/internal/recordings"
+	"go.mondoo.com/cnspec/policy"
+	"go.mondoo.com/ranger-rpc/codes"
+	"go.mondoo.com/ranger-rpc/status"
+)
+
+// ReplayJob reproduces the scan of a recorded asset offline, e.g. from a
+// recording that is attached to a bug report instead of access to the live
+// system
+type ReplayJob struct {
+	// Recording is the path of a recorded provider session, see
+	// AssetJob.DoRecord
+	Recording string
+	// Bundle is the policy bundle that the asset was scanned with
+	Bundle        *policy.Bundle
+	PolicyFilters []string
+	Props         map[string]string
+	// AssetMrn of the recorded asset. If empty, it is derived from the
+	// recording, so that all replays of a recording report the same asset.
+	AssetMrn string
+	// Recorded is when the recording was made, defaults to the modification
+	// time of the recording. Data ages are evaluated at this time, so that
+	// checks with a max data age don't turn stale in replays.
+	Recorded time.Time
+	// BundleChecksum is the source hash of the bundle that was used for the
+	// recording (optional). Replays with other bundles produce other reports.
+	BundleChecksum string
+}
+
+// NewReplayJob fetches a recording from the store into the directory and
+// prepares its replay with the given bundle
+func NewReplayJob(ctx context.Context, store recordings.Store, id string, dir string, bundle *policy.Bundle) (*ReplayJob, error) {
+	meta, path, err := recordings.FetchWithMetadata(ctx, store, id, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch recording "+id)
+	}
+	return &ReplayJob{
+		Recording:      path,
+		Bundle:         bundle,
+		AssetMrn:       meta.AssetMrn,
+		Recorded:       meta.Recorded,
+		BundleChecksum: meta.BundleChecksum,
+	}, nil
+}
+
+type replayKey struct{}
+
+// withReplay marks the scan as the replay of a recording that was made at
+// the given time
+func withReplay(ctx context.Context, recorded time.Time) context.Context {
+	return context.WithValue(ctx, replayKey{}, recorded)
+}
+
+// replayFromContext returns when the replayed recording was made, zero if
+// the scan isn't a replay
+func replayFromContext(ctx context.Context) time.Time {
+	if ctx == nil {
+		return time.Time{}
+	}
+	v, _ := ctx.Value(replayKey{}).(time.Time)
+	return v
+}
+
+// Replay scans the recorded asset with the bundle of the job. Replays run
+// incognito and never reuse results of previous scans, so that they
+// reproduce the report of the recorded scan.
+func (s *LocalScanner) Replay(ctx context.Context, job *ReplayJob) (*ScanResult, error) {
+	if job == nil || job.Recording == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing recording")
+	}
+	if job.Bundle == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "missing bundle, replays need the bundle that the asset was scanned with")
+	}
+
+	if job.BundleChecksum != "" {
+		if checksum, err := job.Bundle.SourceHash(); err == nil && checksum != job.BundleChecksum {
+			log.Warn().Str("recording", job.Recording).Msg("the recording was made with another bundle, the report may differ")
+		}
+	}
+
+	recorded := job.Recorded
+	assetMrn := job.AssetMrn
+	if recorded.IsZero() || assetMrn == "" {
+		data, err := os.ReadFile(job.Recording)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read recording")
+		}
+		if recorded.IsZero() {
+			info, err := os.Stat(job.Recording)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read recording")
+			}
+			recorded = info.ModTime()
+		}
+		if assetMrn == "" {
+			sum := sha256.Sum256(data)
+			x, err := mrn.NewMRN("//" + policy.POLICY_SERVICE_NAME + "/" + policy.MRN_RESOURCE_ASSET + "/" + hex.EncodeToString(sum[:]))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to generate the asset MRN of the recording")
+			}
+			assetMrn = x.String()
+		}
+	}
+
+	inv := &v1.Inventory{
+		Spec: &v1.InventorySpec{
+			Assets: []*asset.Asset{{
+				Mrn: assetMrn,
+				Connections: []*providers.Config{{
+					Backend: providers.ProviderType_MOCK,
+					Options: map[string]string{"path": job.Recording},
+				}},
+			}},
+		},
+	}
+
+	return s.RunIncognito(withReplay(ctx, recorded), &Job{
+		Inventory:     inv,
+		Bundle:        job.Bundle,
+		PolicyFilters: job.PolicyFilters,
+		Props:         job.Props,
+		ReportType:    ReportType_FULL,
+	})
+}
@@ -1,6 +1,8 @@
 package scan
 
 import (
+	"sync"
+
 	"go.mondoo.com/cnquery/motor/asset"
 	"go.mondoo.com/cnspec/policy"
 )
@@ -10,6 +12,11 @@ type AssetReport struct {
 	ResolvedPolicy *policy.ResolvedPolicy
 	Bundle         *policy.Bundle
 	Report         *policy.Report
+	// Suppressions of checks by inline annotations in the scanned source
+	Suppressions []*Suppression
+	// Upload counts the datapoints that were sent upstream and those that
+	// were skipped as unchanged, see WithDifferentialUpload
+	Upload policy.UploadStats
 }
 
 type Reporter interface {
@@ -17,3 +24,31 @@ type Reporter interface {
 	AddScanError(asset *asset.Asset, err error)
 	Reports() *ScanResult
 }
+
+// syncReporter makes any reporter safe for use by concurrent asset scans
+type syncReporter struct {
+	lock     sync.Mutex
+	reporter Reporter
+}
+
+func newSyncReporter(reporter Reporter) Reporter {
+	return &syncReporter{reporter: reporter}
+}
+
+func (r *syncReporter) AddReport(asset *asset.Asset, results *AssetReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.AddReport(asset, results)
+}
+
+func (r *syncReporter) AddScanError(asset *asset.Asset, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.AddScanError(asset, err)
+}
+
+func (r *syncReporter) Reports() *ScanResult {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.reporter.Reports()
+}
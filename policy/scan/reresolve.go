@@ -0,0 +1,84 @@
+package scan
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery"
+	"go.mondoo.com/cnspec/internal/datalakes/sqlite"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// reResolution runs a policy.ReResolver on its own connection to the
+// persistent datalake, since the datalake of an asset scan is closed once
+// the scan is done
+type reResolution struct {
+	lock     sync.Mutex
+	db       *sqlite.Db
+	resolver *policy.ReResolver
+}
+
+func (r *reResolution) get() *policy.ReResolver {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.resolver
+}
+
+// StartReResolver re-resolves the policies of the assets in the datalake in
+// the background, for all assets if the cnspec version or features changed
+// since the last start and for the assets that use updated bundles. The next
+// scan of these assets then doesn't wait for resolution. This requires a
+// persistent datalake, see WithDataLake.
+func (s *LocalScanner) StartReResolver(ctx context.Context, version string, features cnquery.Features) error {
+	if s.dataLakePath == "" {
+		return errors.New("re-resolving policies requires a persistent datalake")
+	}
+
+	r := &s.reResolution
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.resolver != nil {
+		return nil
+	}
+
+	db, services, err := sqlite.NewServices(s.dataLakePath)
+	if err != nil {
+		return err
+	}
+	resolver, err := policy.NewReResolver(services, policy.ReResolveOptions{
+		StatePath: s.dataLakePath + ".reresolve.json",
+	})
+	if err != nil {
+		db.Close()
+		return err
+	}
+	services.ReResolver = resolver
+
+	ctx = cnquery.SetFeatures(ctx, features)
+	if err := resolver.EnvironmentChanged(ctx, version, features); err != nil {
+		db.Close()
+		return err
+	}
+	resolver.Start(ctx)
+
+	r.db = db
+	r.resolver = resolver
+	return nil
+}
+
+// StopReResolver stops re-resolving policies in the background. Assets that
+// weren't re-resolved yet are resolved on their next scan.
+func (s *LocalScanner) StopReResolver() {
+	r := &s.reResolution
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.resolver == nil {
+		return
+	}
+
+	r.resolver.Stop()
+	r.db.Close()
+	r.resolver = nil
+	r.db = nil
+}
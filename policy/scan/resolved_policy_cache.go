@@ -0,0 +1,73 @@
+package scan
+
+import (
+	"time"
+
+	"go.mondoo.com/cnspec/internal/datalakes/inmemory"
+)
+
+// EvictionPolicy decides which resolved policies are removed first when the
+// cache is full, see WithResolvedPolicyCache
+type EvictionPolicy string
+
+const (
+	// EvictLRU removes the least recently used resolved policies first
+	EvictLRU = EvictionPolicy(inmemory.EvictLRU)
+	// EvictLFU removes the least frequently used resolved policies first
+	EvictLFU = EvictionPolicy(inmemory.EvictLFU)
+	// EvictSegmentedLRU removes resolved policies in LRU order, but the ones
+	// that were used at least FrequentHits times are removed last. This keeps
+	// the filter sets of many assets cached, even if assets with rare filter
+	// sets are scanned in between.
+	EvictSegmentedLRU = EvictionPolicy(inmemory.EvictSegmentedLRU)
+)
+
+// ResolvedPolicyCacheOptions configure the cache for resolved policies,
+// which is shared by all assets of a scanner
+type ResolvedPolicyCacheOptions struct {
+	// SizeLimit is the maximum size of all resolved policies in bytes, 0 is
+	// unlimited
+	SizeLimit int64
+	// TTL is the time after which resolved policies expire, defaults to 1h
+	TTL time.Duration
+	// Eviction defaults to EvictLRU
+	Eviction EvictionPolicy
+	// FrequentHits is used by EvictSegmentedLRU, defaults to 2
+	FrequentHits uint64
+}
+
+// ResolvedPolicyCacheStats are the counters of the cache for resolved
+// policies
+type ResolvedPolicyCacheStats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	// Rejections counts resolved policies that were too large to be cached
+	Rejections uint64
+	Entries    int
+	Size       int64
+	SizeLimit  int64
+}
+
+func (o ResolvedPolicyCacheOptions) cacheOptions() inmemory.ResolvedPolicyCacheOptions {
+	return inmemory.ResolvedPolicyCacheOptions{
+		SizeLimit:    o.SizeLimit,
+		TTL:          o.TTL,
+		Eviction:     inmemory.EvictionPolicy(o.Eviction),
+		FrequentHits: o.FrequentHits,
+	}
+}
+
+func cacheStats(stats inmemory.ResolvedPolicyCacheStats) ResolvedPolicyCacheStats {
+	return ResolvedPolicyCacheStats{
+		Hits:        stats.Hits,
+		Misses:      stats.Misses,
+		Evictions:   stats.Evictions,
+		Expirations: stats.Expirations,
+		Rejections:  stats.Rejections,
+		Entries:     stats.Entries,
+		Size:        stats.Size,
+		SizeLimit:   stats.SizeLimit,
+	}
+}
@@ -0,0 +1,33 @@
+package scan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnspec/internal/datalakes/inmemory"
+)
+
+func TestResolvedPolicyCacheOptions(t *testing.T) {
+	opts := ResolvedPolicyCacheOptions{
+		SizeLimit:    1024,
+		TTL:          time.Minute,
+		Eviction:     EvictLFU,
+		FrequentHits: 3,
+	}
+	assert.Equal(t, inmemory.ResolvedPolicyCacheOptions{
+		SizeLimit:    1024,
+		TTL:          time.Minute,
+		Eviction:     inmemory.EvictLFU,
+		FrequentHits: 3,
+	}, opts.cacheOptions())
+
+	// scanners keep frequently used filter sets cached by default
+	assert.Equal(t, inmemory.EvictSegmentedLRU, defaultResolvedPolicyCacheOptions.cacheOptions().Eviction)
+	assert.Equal(t, int64(ResolvedPolicyCacheSize), defaultResolvedPolicyCacheOptions.SizeLimit)
+
+	s := NewLocalScanner()
+	assert.Equal(t, int64(ResolvedPolicyCacheSize), s.ResolvedPolicyCacheStats().SizeLimit)
+	s = NewLocalScanner(WithResolvedPolicyCache(ResolvedPolicyCacheOptions{SizeLimit: 1024, Eviction: EvictSegmentedLRU}))
+	assert.Equal(t, int64(1024), s.ResolvedPolicyCacheStats().SizeLimit)
+}
@@ -21,6 +21,13 @@ import (
 // 50MB default size
 const ResolvedPolicyCacheSize = 52428800
 
+// filter sets of many assets stay cached, even if assets with rare filter
+// sets are scanned in between
+var defaultResolvedPolicyCacheOptions = ResolvedPolicyCacheOptions{
+	SizeLimit: ResolvedPolicyCacheSize,
+	Eviction:  EvictSegmentedLRU,
+}
+
 func init() {
 	rand.Seed(time.Now().UnixNano())
 }
@@ -37,4 +44,6 @@ type AssetJob struct {
 	Reporter         Reporter
 	connection       *motor.Motor
 	ProgressReporter progress.Progress
+	// auditTrail records the commands run on the asset (optional)
+	auditTrail *policy.AuditTrail
 }
@@ -0,0 +1,303 @@
+package scan
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery"
+	"go.mondoo.com/cnspec/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// ScheduledJob is a scan job that runs repeatedly, either in a fixed
+// interval or on a cron schedule
+type ScheduledJob struct {
+	// Name identifies the job, it must be unique per ScheduledService
+	Name string
+	Job  *Job
+	// Interval runs the job every interval, starting right away
+	Interval time.Duration
+	// Cron runs the job on a cron schedule with 5 fields, e.g. `*/15 * * * *`
+	// or `@hourly`. It is used instead of the interval.
+	Cron string
+	// Incognito runs the job without sending results upstream
+	Incognito bool
+}
+
+// ScoreDelta is a score that changed between two runs of a scheduled job
+type ScoreDelta struct {
+	Job string
+	// AssetMrn is the MRN of the asset in the run that reported the change.
+	// Incognito runs may give the same asset a new MRN in every run.
+	AssetMrn  string
+	AssetName string
+	QrId      string
+	// Previous is nil for scores that weren't reported before
+	Previous *policy.Score
+	// Current is nil for scores that aren't reported anymore
+	Current *policy.Score
+}
+
+// DeltaPublisher receives the score deltas of every run of a scheduled job.
+// It isn't called for runs in which no score changed.
+type DeltaPublisher func(ctx context.Context, deltas []ScoreDelta)
+
+// ScheduledService runs scan jobs continuously on a LocalScanner. Since all
+// runs share the scanner, they also share its cache of resolved policies.
+// Only the scores that changed since the previous run are published.
+type ScheduledService struct {
+	scanner *LocalScanner
+	publish DeltaPublisher
+
+	lock    sync.Mutex
+	jobs    map[string]*scheduledJob
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+type scheduledJob struct {
+	ScheduledJob
+	cron *cronSchedule
+	// last scores per asset, to compute deltas, see assetKey
+	assets map[string]*scheduledAsset
+	cancel context.CancelFunc
+}
+
+type scheduledAsset struct {
+	mrn    string
+	name   string
+	scores map[string]*policy.Score
+}
+
+// NewScheduledService creates a service that runs jobs on the scanner and
+// publishes their score deltas
+func NewScheduledService(scanner *LocalScanner, publish DeltaPublisher) *ScheduledService {
+	return &ScheduledService{
+		scanner: scanner,
+		publish: publish,
+		jobs:    map[string]*scheduledJob{},
+	}
+}
+
+// Add registers a job. If the service is already running, the job starts
+// right away.
+func (s *ScheduledService) Add(job ScheduledJob) error {
+	if job.Name == "" {
+		return errors.New("scheduled job needs a name")
+	}
+	if job.Job == nil || job.Job.Inventory == nil {
+		return errors.New("scheduled job " + job.Name + " has no inventory")
+	}
+
+	sj := &scheduledJob{ScheduledJob: job}
+	if job.Cron != "" {
+		cron, err := parseCron(job.Cron)
+		if err != nil {
+			return err
+		}
+		sj.cron = cron
+	} else if job.Interval <= 0 {
+		return errors.New("scheduled job " + job.Name + " needs an interval or a cron schedule")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.jobs[job.Name]; ok {
+		return errors.New("scheduled job " + job.Name + " already exists")
+	}
+	s.jobs[job.Name] = sj
+	if s.ctx != nil {
+		s.startJob(sj)
+	}
+	return nil
+}
+
+// Remove stops a job and forgets its scores
+func (s *ScheduledService) Remove(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if sj, ok := s.jobs[name]; ok {
+		if sj.cancel != nil {
+			sj.cancel()
+		}
+		delete(s.jobs, name)
+	}
+}
+
+// Start runs all jobs in the background until Stop is called or the
+// context is done
+func (s *ScheduledService) Start(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.ctx != nil {
+		return errors.New("scheduled service is already running")
+	}
+
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	for _, sj := range s.jobs {
+		s.startJob(sj)
+	}
+	return nil
+}
+
+// Stop cancels all jobs and waits for running scans to finish
+func (s *ScheduledService) Stop() {
+	s.lock.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.ctx = nil
+	s.cancel = nil
+	s.lock.Unlock()
+
+	s.running.Wait()
+}
+
+// startJob requires the lock to be held
+func (s *ScheduledService) startJob(sj *scheduledJob) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	sj.cancel = cancel
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		for {
+			wait := sj.Interval
+			if sj.cron != nil {
+				now := time.Now()
+				next := sj.cron.next(now)
+				if next.IsZero() {
+					log.Error().Str("job", sj.Name).Str("cron", sj.Cron).Msg("cron schedule never matches, stopping scheduled job")
+					return
+				}
+				wait = next.Sub(now)
+			}
+
+			// interval jobs run right away and then wait
+			if sj.cron == nil {
+				s.runJob(ctx, sj)
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			if sj.cron != nil {
+				s.runJob(ctx, sj)
+			}
+		}
+	}()
+}
+
+func (s *ScheduledService) runJob(ctx context.Context, sj *scheduledJob) {
+	log.Debug().Str("job", sj.Name).Msg("run scheduled scan")
+
+	// scans may modify the job, e.g. while resolving the inventory
+	job := proto.Clone(sj.Job).(*Job)
+	ctx = cnquery.SetFeatures(ctx, cnquery.DefaultFeatures)
+
+	var res *ScanResult
+	var err error
+	if sj.Incognito {
+		res, err = s.scanner.RunIncognito(ctx, job)
+	} else {
+		res, err = s.scanner.Run(ctx, job)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Error().Err(err).Str("job", sj.Name).Msg("scheduled scan failed")
+		}
+		return
+	}
+
+	deltas := sj.deltas(res.GetFull())
+	if len(deltas) != 0 && s.publish != nil {
+		s.publish(ctx, deltas)
+	}
+}
+
+// assetKey identifies an asset across runs. Asset MRNs of incognito runs
+// change from run to run, so assets are found by their name if they have one.
+func assetKey(reports *policy.ReportCollection, assetMrn string) string {
+	if a := reports.Assets[assetMrn]; a != nil && a.Name != "" {
+		return a.Name
+	}
+	return assetMrn
+}
+
+// deltas compares the scores of the reports to those of the previous run.
+// Assets that failed to scan keep their previous scores, assets that are
+// not part of the run anymore are forgotten and all their scores reported
+// as removed.
+func (sj *scheduledJob) deltas(reports *policy.ReportCollection) []ScoreDelta {
+	if reports == nil {
+		return nil
+	}
+	if sj.assets == nil {
+		sj.assets = map[string]*scheduledAsset{}
+	}
+
+	seen := make(map[string]struct{}, len(reports.Reports)+len(reports.Errors))
+	for assetMrn := range reports.Errors {
+		seen[assetKey(reports, assetMrn)] = struct{}{}
+	}
+
+	var res []ScoreDelta
+	for assetMrn, report := range reports.Reports {
+		key := assetKey(reports, assetMrn)
+		seen[key] = struct{}{}
+		cur := &scheduledAsset{
+			mrn:    assetMrn,
+			name:   reports.Assets[assetMrn].GetName(),
+			scores: make(map[string]*policy.Score, len(report.Scores)),
+		}
+
+		var prev map[string]*policy.Score
+		if old, ok := sj.assets[key]; ok {
+			prev = old.scores
+		}
+		for qrID, score := range report.Scores {
+			cur.scores[qrID] = score
+			old, ok := prev[qrID]
+			if !ok || old.Value != score.Value || old.Type != score.Type {
+				res = append(res, cur.delta(sj.Name, qrID, old, score))
+			}
+		}
+		for qrID, old := range prev {
+			if _, ok := cur.scores[qrID]; !ok {
+				res = append(res, cur.delta(sj.Name, qrID, old, nil))
+			}
+		}
+		sj.assets[key] = cur
+	}
+
+	for key, old := range sj.assets {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		for qrID, score := range old.scores {
+			res = append(res, old.delta(sj.Name, qrID, score, nil))
+		}
+		delete(sj.assets, key)
+	}
+	return res
+}
+
+func (a *scheduledAsset) delta(job string, qrID string, previous *policy.Score, current *policy.Score) ScoreDelta {
+	return ScoreDelta{
+		Job:       job,
+		AssetMrn:  a.mrn,
+		AssetName: a.name,
+		QrId:      qrID,
+		Previous:  previous,
+		Current:   current,
+	}
+}
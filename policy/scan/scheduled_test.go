@@ -0,0 +1,139 @@
+package scan
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// testRun builds the reports of a run, scores are keyed by asset MRN and
+// every asset is named by its entry in names
+func testRun(names map[string]string, scores map[string]map[string]uint32, errs ...string) *policy.ReportCollection {
+	res := &policy.ReportCollection{
+		Assets:  map[string]*policy.Asset{},
+		Reports: map[string]*policy.Report{},
+		Errors:  map[string]string{},
+	}
+	for assetMrn, name := range names {
+		res.Assets[assetMrn] = &policy.Asset{Mrn: assetMrn, Name: name}
+	}
+	for assetMrn, values := range scores {
+		report := &policy.Report{EntityMrn: assetMrn, Scores: map[string]*policy.Score{}}
+		for qrID, value := range values {
+			report.Scores[qrID] = &policy.Score{QrId: qrID, Value: value, Type: policy.ScoreType_Result}
+		}
+		res.Reports[assetMrn] = report
+	}
+	for _, assetMrn := range errs {
+		res.Errors[assetMrn] = "failed to connect"
+	}
+	return res
+}
+
+func sortDeltas(deltas []ScoreDelta) []ScoreDelta {
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].AssetName != deltas[j].AssetName {
+			return deltas[i].AssetName < deltas[j].AssetName
+		}
+		return deltas[i].QrId < deltas[j].QrId
+	})
+	return deltas
+}
+
+func TestScheduledJobDeltas(t *testing.T) {
+	sj := &scheduledJob{ScheduledJob: ScheduledJob{Name: "fleet"}}
+	assert.Empty(t, sj.deltas(nil))
+
+	// incognito runs give assets a new MRN every time
+	deltas := sortDeltas(sj.deltas(testRun(
+		map[string]string{"//run1/web": "web", "//run1/db": "db"},
+		map[string]map[string]uint32{
+			"//run1/web": {"ssh": 100, "tls": 0},
+			"//run1/db":  {"ssh": 100},
+		},
+	)))
+	require.Len(t, deltas, 3)
+	for _, delta := range deltas {
+		assert.Equal(t, "fleet", delta.Job)
+		assert.Nil(t, delta.Previous)
+		assert.NotNil(t, delta.Current)
+	}
+
+	t.Run("unchanged scores of renamed MRNs are no deltas", func(t *testing.T) {
+		deltas := sortDeltas(sj.deltas(testRun(
+			map[string]string{"//run2/web": "web", "//run2/db": "db"},
+			map[string]map[string]uint32{
+				"//run2/web": {"ssh": 100, "tls": 100},
+				"//run2/db":  {"ssh": 100},
+			},
+		)))
+		require.Len(t, deltas, 1)
+		assert.Equal(t, "web", deltas[0].AssetName)
+		assert.Equal(t, "//run2/web", deltas[0].AssetMrn)
+		assert.Equal(t, "tls", deltas[0].QrId)
+		assert.Equal(t, uint32(0), deltas[0].Previous.Value)
+		assert.Equal(t, uint32(100), deltas[0].Current.Value)
+	})
+
+	t.Run("scores that aren't reported anymore are removed", func(t *testing.T) {
+		deltas := sortDeltas(sj.deltas(testRun(
+			map[string]string{"//run3/web": "web", "//run3/db": "db"},
+			map[string]map[string]uint32{
+				"//run3/web": {"ssh": 100},
+				"//run3/db":  {"ssh": 100},
+			},
+		)))
+		require.Len(t, deltas, 1)
+		assert.Equal(t, "tls", deltas[0].QrId)
+		assert.NotNil(t, deltas[0].Previous)
+		assert.Nil(t, deltas[0].Current)
+	})
+
+	t.Run("failed assets keep their scores", func(t *testing.T) {
+		deltas := sj.deltas(testRun(
+			map[string]string{"//run4/web": "web", "//run4/db": "db"},
+			map[string]map[string]uint32{"//run4/web": {"ssh": 100}},
+			"//run4/db",
+		))
+		assert.Empty(t, deltas)
+		assert.Contains(t, sj.assets, "db")
+	})
+
+	t.Run("assets that are gone are pruned", func(t *testing.T) {
+		deltas := sj.deltas(testRun(
+			map[string]string{"//run5/web": "web"},
+			map[string]map[string]uint32{"//run5/web": {"ssh": 100}},
+		))
+		require.Len(t, deltas, 1)
+		assert.Equal(t, "db", deltas[0].AssetName)
+		assert.Equal(t, "//run3/db", deltas[0].AssetMrn)
+		assert.NotNil(t, deltas[0].Previous)
+		assert.Nil(t, deltas[0].Current)
+		assert.Len(t, sj.assets, 1)
+		assert.NotContains(t, sj.assets, "db")
+
+		// a returning asset is new again
+		deltas = sj.deltas(testRun(
+			map[string]string{"//run6/web": "web", "//run6/db": "db"},
+			map[string]map[string]uint32{
+				"//run6/web": {"ssh": 100},
+				"//run6/db":  {"ssh": 100},
+			},
+		))
+		require.Len(t, deltas, 1)
+		assert.Nil(t, deltas[0].Previous)
+	})
+}
+
+func TestAssetKey(t *testing.T) {
+	reports := &policy.ReportCollection{Assets: map[string]*policy.Asset{
+		"//assets/named":   {Mrn: "//assets/named", Name: "web"},
+		"//assets/unnamed": {Mrn: "//assets/unnamed"},
+	}}
+	assert.Equal(t, "web", assetKey(reports, "//assets/named"))
+	assert.Equal(t, "//assets/unnamed", assetKey(reports, "//assets/unnamed"))
+	assert.Equal(t, "//assets/unknown", assetKey(reports, "//assets/unknown"))
+}
@@ -0,0 +1,229 @@
+package scan
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/motor/asset"
+)
+
+// Verdict of an asset in a ScanSummary
+type Verdict string
+
+const (
+	// VerdictPassed is given to assets that passed all checks
+	VerdictPassed Verdict = "passed"
+	// VerdictFailed is given to assets that failed at least one check
+	VerdictFailed Verdict = "failed"
+	// VerdictErrored is given to assets that could not be scanned
+	VerdictErrored Verdict = "errored"
+)
+
+// ErrorClass tells at which stage the scan of an asset failed
+type ErrorClass string
+
+const (
+	// ErrorClassPrepare is set if a before-asset hook failed
+	ErrorClassPrepare ErrorClass = "prepare"
+	// ErrorClassUnreachable is set if the asset could not be reached over the
+	// network, see WithReachabilityChecks
+	ErrorClassUnreachable ErrorClass = "unreachable"
+	// ErrorClassConnection is set if no connection to the asset could be opened
+	ErrorClassConnection ErrorClass = "connection"
+	// ErrorClassUpstream is set if the asset could not be synchronized with
+	// upstream
+	ErrorClassUpstream ErrorClass = "upstream"
+	// ErrorClassCanceled is set if the scan was canceled
+	ErrorClassCanceled ErrorClass = "canceled"
+	// ErrorClassScan is set if the policies of the asset could not be
+	// resolved or executed
+	ErrorClassScan ErrorClass = "scan"
+)
+
+// ScanSummary is a machine-readable summary of a scan. It lets embedders log
+// and gate scans without evaluating the reports.
+type ScanSummary struct {
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	// Assets are sorted by MRN
+	Assets []*AssetSummary `json:"assets"`
+	// PolicyChecksums are the graph content checksums of all policies in the
+	// bundle, indexed by policy MRN
+	PolicyChecksums map[string]string `json:"policy_checksums,omitempty"`
+	// WorstScore of all scanned assets, 0 if no asset was scanned
+	WorstScore uint32 `json:"worst_score"`
+	Passed     int    `json:"passed"`
+	Failed     int    `json:"failed"`
+	Errored    int    `json:"errored"`
+}
+
+// AssetSummary is the summary of one asset in a ScanSummary
+type AssetSummary struct {
+	Mrn     string  `json:"mrn"`
+	Name    string  `json:"name"`
+	Verdict Verdict `json:"verdict"`
+	// Score of the asset, if it was scanned
+	Score uint32 `json:"score,omitempty"`
+	// Checks counts the checks by their result, if the asset was scanned
+	Checks *CheckCounts `json:"checks,omitempty"`
+	// ResolvedPolicyChecksum is the graph execution checksum of the policy
+	// that the asset was scanned with
+	ResolvedPolicyChecksum string `json:"resolved_policy_checksum,omitempty"`
+	// ErrorClass and Error are set if the asset could not be scanned
+	ErrorClass ErrorClass    `json:"error_class,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Started    time.Time     `json:"started,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+}
+
+// CheckCounts counts the checks of an asset by their result
+type CheckCounts struct {
+	Passed  uint32 `json:"passed"`
+	Failed  uint32 `json:"failed"`
+	Errored uint32 `json:"errored"`
+	Skipped uint32 `json:"skipped"`
+}
+
+// RunIncognitoWithSummary runs the job like RunIncognito and summarizes the
+// result
+func (s *LocalScanner) RunIncognitoWithSummary(ctx context.Context, job *Job) (*ScanResult, *ScanSummary, error) {
+	collector := &summaryCollector{assets: map[string]*assetTiming{}}
+	started := time.Now()
+	res, err := s.RunIncognito(withSummary(ctx, collector), job)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, collector.summarize(res, started, time.Since(started)), nil
+}
+
+// assetTiming records when the scan of an asset ran and at which stage it
+// failed
+type assetTiming struct {
+	started    time.Time
+	duration   time.Duration
+	errorClass ErrorClass
+}
+
+// summaryCollector collects the timing of all assets of a scan
+type summaryCollector struct {
+	mu     sync.Mutex
+	assets map[string]*assetTiming
+}
+
+func (c *summaryCollector) add(assetObj *asset.Asset, started time.Time, errorClass ErrorClass, err error) {
+	if err != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		errorClass = ErrorClassCanceled
+	}
+
+	c.mu.Lock()
+	c.assets[assetObj.Mrn] = &assetTiming{
+		started:    started,
+		duration:   time.Since(started),
+		errorClass: errorClass,
+	}
+	c.mu.Unlock()
+}
+
+func (c *summaryCollector) summarize(res *ScanResult, started time.Time, duration time.Duration) *ScanSummary {
+	summary := &ScanSummary{
+		Started:  started,
+		Duration: duration,
+		Assets:   []*AssetSummary{},
+	}
+	if res.GetWorstScore() != nil {
+		summary.WorstScore = res.GetWorstScore().Value
+	}
+
+	full := res.GetFull()
+	if full == nil {
+		return summary
+	}
+
+	if full.Bundle != nil {
+		summary.PolicyChecksums = make(map[string]string, len(full.Bundle.Policies))
+		for _, p := range full.Bundle.Policies {
+			summary.PolicyChecksums[p.Mrn] = p.GraphContentChecksum
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for mrn, a := range full.Assets {
+		cur := &AssetSummary{
+			Mrn:  mrn,
+			Name: a.Name,
+		}
+		timing, ok := c.assets[mrn]
+		if ok {
+			cur.Started = timing.started
+			cur.Duration = timing.duration
+		}
+
+		if msg, ok := full.Errors[mrn]; ok {
+			cur.Verdict = VerdictErrored
+			cur.Error = msg
+			cur.ErrorClass = ErrorClassScan
+			if timing != nil && timing.errorClass != "" {
+				cur.ErrorClass = timing.errorClass
+			}
+			summary.Errored++
+			summary.Assets = append(summary.Assets, cur)
+			continue
+		}
+
+		report := full.Reports[mrn]
+		cur.Verdict = VerdictPassed
+		if report != nil {
+			if report.Score != nil {
+				cur.Score = report.Score.Value
+			}
+			if report.Stats != nil {
+				cur.Checks = &CheckCounts{
+					Passed:  report.Stats.Passed.GetTotal(),
+					Failed:  report.Stats.Failed.GetTotal(),
+					Errored: report.Stats.Errors.GetTotal(),
+					Skipped: report.Stats.Skipped,
+				}
+				if cur.Checks.Failed != 0 || cur.Checks.Errored != 0 {
+					cur.Verdict = VerdictFailed
+				}
+			}
+		}
+		if rp := full.ResolvedPolicies[mrn]; rp != nil {
+			cur.ResolvedPolicyChecksum = rp.GraphExecutionChecksum
+		}
+
+		if cur.Verdict == VerdictFailed {
+			summary.Failed++
+		} else {
+			summary.Passed++
+		}
+		summary.Assets = append(summary.Assets, cur)
+	}
+
+	sort.Slice(summary.Assets, func(i, j int) bool {
+		return summary.Assets[i].Mrn < summary.Assets[j].Mrn
+	})
+	return summary
+}
+
+type summaryKey struct{}
+
+// withSummary collects the timing of all assets of the scan
+func withSummary(ctx context.Context, c *summaryCollector) context.Context {
+	return context.WithValue(ctx, summaryKey{}, c)
+}
+
+// summaryFromContext returns the collector of the scan, nil if the scan
+// isn't summarized
+func summaryFromContext(ctx context.Context) *summaryCollector {
+	if ctx == nil {
+		return nil
+	}
+	v, _ := ctx.Value(summaryKey{}).(*summaryCollector)
+	return v
+}
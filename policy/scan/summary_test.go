@@ -0,0 +1,143 @@
+package scan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func testStats(passed, failed, errored, skipped uint32) *policy.Stats {
+	return &policy.Stats{
+		Passed:  &policy.ScoreDistribution{Total: passed},
+		Failed:  &policy.ScoreDistribution{Total: failed},
+		Errors:  &policy.ScoreDistribution{Total: errored},
+		Skipped: skipped,
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	full := &policy.ReportCollection{
+		Assets: map[string]*policy.Asset{
+			"//assets/passed":      {Mrn: "//assets/passed", Name: "passed"},
+			"//assets/failed":      {Mrn: "//assets/failed", Name: "failed"},
+			"//assets/erroring":    {Mrn: "//assets/erroring", Name: "erroring"},
+			"//assets/unreachable": {Mrn: "//assets/unreachable", Name: "unreachable"},
+			"//assets/broken":      {Mrn: "//assets/broken", Name: "broken"},
+			"//assets/canceled":    {Mrn: "//assets/canceled", Name: "canceled"},
+			"//assets/unscored":    {Mrn: "//assets/unscored", Name: "unscored"},
+		},
+		Bundle: &policy.Bundle{Policies: []*policy.Policy{
+			{Mrn: "//policies/ssh", GraphContentChecksum: "ssh-checksum"},
+		}},
+		Reports: map[string]*policy.Report{
+			"//assets/passed": {
+				Score: &policy.Score{Value: 100},
+				Stats: testStats(3, 0, 0, 1),
+			},
+			"//assets/failed": {
+				Score: &policy.Score{Value: 40},
+				Stats: testStats(2, 1, 0, 0),
+			},
+			"//assets/erroring": {
+				Score: &policy.Score{Value: 80},
+				Stats: testStats(2, 0, 1, 0),
+			},
+			// reports without stats don't fail the asset
+			"//assets/unscored": {},
+		},
+		Errors: map[string]string{
+			"//assets/unreachable": "dial tcp: i/o timeout",
+			"//assets/broken":      "failed to resolve policies",
+			"//assets/canceled":    "context canceled",
+		},
+		ResolvedPolicies: map[string]*policy.ResolvedPolicy{
+			"//assets/passed": {GraphExecutionChecksum: "rp-checksum"},
+		},
+	}
+	res := &ScanResult{
+		WorstScore: &policy.Score{Value: 40},
+		Result:     &ScanResult_Full{Full: full},
+	}
+
+	collector := &summaryCollector{assets: map[string]*assetTiming{}}
+	collector.add(&asset.Asset{Mrn: "//assets/passed"}, started, "", nil)
+	collector.add(&asset.Asset{Mrn: "//assets/unreachable"}, started, ErrorClassUnreachable, errors.New("dial tcp: i/o timeout"))
+	collector.add(&asset.Asset{Mrn: "//assets/canceled"}, started, ErrorClassConnection, errors.Wrap(context.Canceled, "failed to connect"))
+
+	summary := collector.summarize(res, started, time.Minute)
+	assert.Equal(t, started, summary.Started)
+	assert.Equal(t, time.Minute, summary.Duration)
+	assert.Equal(t, uint32(40), summary.WorstScore)
+	assert.Equal(t, map[string]string{"//policies/ssh": "ssh-checksum"}, summary.PolicyChecksums)
+
+	assert.Equal(t, 2, summary.Passed)
+	assert.Equal(t, 2, summary.Failed)
+	assert.Equal(t, 3, summary.Errored)
+	require.Len(t, summary.Assets, 7)
+
+	assets := map[string]*AssetSummary{}
+	mrns := []string{}
+	for _, a := range summary.Assets {
+		assets[a.Name] = a
+		mrns = append(mrns, a.Mrn)
+	}
+	assert.IsIncreasing(t, mrns)
+
+	t.Run("verdicts", func(t *testing.T) {
+		passed := assets["passed"]
+		assert.Equal(t, VerdictPassed, passed.Verdict)
+		assert.Equal(t, uint32(100), passed.Score)
+		assert.Equal(t, &CheckCounts{Passed: 3, Skipped: 1}, passed.Checks)
+		assert.Equal(t, "rp-checksum", passed.ResolvedPolicyChecksum)
+		assert.Equal(t, started, passed.Started)
+
+		failed := assets["failed"]
+		assert.Equal(t, VerdictFailed, failed.Verdict)
+		assert.Equal(t, uint32(40), failed.Score)
+		assert.Equal(t, &CheckCounts{Passed: 2, Failed: 1}, failed.Checks)
+
+		// checks that errored fail the asset
+		assert.Equal(t, VerdictFailed, assets["erroring"].Verdict)
+		assert.Equal(t, uint32(1), assets["erroring"].Checks.Errored)
+
+		assert.Equal(t, VerdictPassed, assets["unscored"].Verdict)
+		assert.Nil(t, assets["unscored"].Checks)
+	})
+
+	t.Run("error classes", func(t *testing.T) {
+		unreachable := assets["unreachable"]
+		assert.Equal(t, VerdictErrored, unreachable.Verdict)
+		assert.Equal(t, ErrorClassUnreachable, unreachable.ErrorClass)
+		assert.Equal(t, "dial tcp: i/o timeout", unreachable.Error)
+		assert.Nil(t, unreachable.Checks)
+
+		// errors without a recorded stage happened during the scan
+		assert.Equal(t, ErrorClassScan, assets["broken"].ErrorClass)
+		assert.True(t, assets["broken"].Started.IsZero())
+
+		// cancellation wins over the stage that noticed it
+		assert.Equal(t, ErrorClassCanceled, assets["canceled"].ErrorClass)
+	})
+}
+
+func TestSummarizeWithoutReports(t *testing.T) {
+	collector := &summaryCollector{assets: map[string]*assetTiming{}}
+	summary := collector.summarize(&ScanResult{}, time.Now(), time.Second)
+	assert.Empty(t, summary.Assets)
+	assert.Zero(t, summary.WorstScore)
+	assert.Zero(t, summary.Passed+summary.Failed+summary.Errored)
+}
+
+func TestSummaryFromContext(t *testing.T) {
+	assert.Nil(t, summaryFromContext(context.Background()))
+
+	collector := &summaryCollector{assets: map[string]*assetTiming{}}
+	assert.Same(t, collector, summaryFromContext(withSummary(context.Background(), collector)))
+}
@@ -0,0 +1,267 @@
+package scan
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/motor/providers"
+	"go.mondoo.com/cnspec/policy"
+)
+
+// suppressionMarker starts an inline suppression in a comment of the scanned
+// source, e.g.:
+//
+//	# cnspec-ignore: terraform-aws-security-s3-bucket-versioning-enabled reason=logs only
+const suppressionMarker = "cnspec-ignore:"
+
+// suppressionJustification starts the justification of the exceptions that
+// waive suppressed checks
+const suppressionJustification = "suppressed by "
+
+// maxSuppressionLineSize is the longest line that is searched for
+// suppressions. Files with longer lines, e.g. minified JSON, are skipped.
+const maxSuppressionLineSize = 1024 * 1024
+
+// Suppression is an inline annotation in the scanned source that suppresses
+// one check. Suppressed checks are waived with an exception on the asset
+// (see policy.Exception), which points to the annotation.
+type Suppression struct {
+	// CheckID is the UID or MRN of the suppressed check
+	CheckID string
+	Reason  string
+	File    string
+	Line    int
+}
+
+// String describes where the suppression comes from
+func (s *Suppression) String() string {
+	res := suppressionJustification + s.File + ":" + strconv.Itoa(s.Line)
+	if s.Reason != "" {
+		res += ": " + s.Reason
+	}
+	return res
+}
+
+// matches returns true if the suppression refers to the given check
+func (s *Suppression) matches(query *explorer.Mquery) bool {
+	if s.CheckID == "" || query == nil {
+		return false
+	}
+	return s.CheckID == query.Uid || s.CheckID == query.Mrn || strings.HasSuffix(query.Mrn, "/"+s.CheckID)
+}
+
+// ParseSuppressions finds all suppression comments in the source. Comments
+// start with `#` or `//` and may list multiple checks separated by commas.
+func ParseSuppressions(r io.Reader, file string) ([]*Suppression, error) {
+	var res []*Suppression
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSuppressionLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+
+		idx := strings.Index(text, suppressionMarker)
+		if idx == -1 || !isComment(text[:idx]) {
+			continue
+		}
+
+		spec := strings.TrimSpace(text[idx+len(suppressionMarker):])
+		var reason string
+		if i := strings.Index(spec, "reason="); i != -1 {
+			reason = strings.Trim(strings.TrimSpace(spec[i+len("reason="):]), "\"'")
+			spec = spec[:i]
+		}
+
+		for _, id := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			res = append(res, &Suppression{CheckID: id, Reason: reason, File: file, Line: line})
+		}
+	}
+
+	return res, scanner.Err()
+}
+
+// isComment returns true if the prefix of a suppression marker opens a comment
+func isComment(prefix string) bool {
+	prefix = strings.TrimSpace(prefix)
+	return strings.HasSuffix(prefix, "#") || strings.HasSuffix(prefix, "//")
+}
+
+// iacBackends are the providers that scan source files on disk
+var iacBackends = map[providers.ProviderType]struct{}{
+	providers.ProviderType_TERRAFORM: {},
+	providers.ProviderType_K8S:       {},
+}
+
+// iacExtensions are the files that are searched for suppressions
+var iacExtensions = map[string]struct{}{
+	".tf":   {},
+	".hcl":  {},
+	".yaml": {},
+	".yml":  {},
+	".json": {},
+}
+
+// collectSuppressions finds all suppressions in the source files of IaC assets.
+// Other assets never have suppressions.
+func collectSuppressions(assetObj *asset.Asset) ([]*Suppression, error) {
+	var res []*Suppression
+	for _, conn := range assetObj.Connections {
+		if conn == nil {
+			continue
+		}
+		if _, ok := iacBackends[conn.Backend]; !ok {
+			continue
+		}
+		path := conn.Options["path"]
+		if path == "" {
+			continue
+		}
+
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if name := d.Name(); file != path && (name == ".git" || name == ".terraform") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if _, ok := iacExtensions[filepath.Ext(file)]; !ok && d.Name() != "Dockerfile" {
+				return nil
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			suppressions, err := ParseSuppressions(f, file)
+			if errors.Is(err, bufio.ErrTooLong) {
+				log.Warn().Str("file", file).Msg("skipping suppressions of a file with very long lines")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			res = append(res, suppressions...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// syncSuppressions waives the suppressed checks of the asset with
+// exceptions. Exceptions of suppressions that were removed from the sources
+// are removed as well. Other exceptions of the asset are kept.
+func (s *localAssetScanner) syncSuppressions(ctx context.Context) error {
+	store, ok := s.db.(policy.ExceptionStore)
+	if !ok {
+		if len(s.suppressions) != 0 {
+			log.Warn().Str("asset", s.job.Asset.Name).Msg("the datalake does not support exceptions, ignoring suppressions")
+		}
+		return nil
+	}
+	assetMrn := s.job.Asset.Mrn
+
+	existing, err := store.ListExceptions(ctx, assetMrn)
+	if err != nil {
+		return err
+	}
+	excepted := make(map[string]struct{}, len(existing))
+	for i := range existing {
+		if !strings.HasPrefix(existing[i].Justification, suppressionJustification) {
+			excepted[existing[i].CheckMrn] = struct{}{}
+		}
+	}
+
+	suppressed := map[string]*policy.Exception{}
+	var checkMrns []string
+	for _, suppression := range s.suppressions {
+		for _, query := range s.job.Bundle.Queries {
+			if query.Mrn == "" || !suppression.matches(query) {
+				continue
+			}
+			if _, ok := suppressed[query.Mrn]; ok {
+				continue
+			}
+			if _, ok := excepted[query.Mrn]; ok {
+				// exceptions that were made explicitly take precedence
+				continue
+			}
+			suppressed[query.Mrn] = &policy.Exception{
+				CheckMrn:      query.Mrn,
+				EntityMrn:     assetMrn,
+				Justification: suppression.String(),
+			}
+			checkMrns = append(checkMrns, query.Mrn)
+		}
+	}
+
+	for i := range existing {
+		exception := existing[i]
+		if !strings.HasPrefix(exception.Justification, suppressionJustification) {
+			continue
+		}
+		if cur, ok := suppressed[exception.CheckMrn]; ok && cur.Justification == exception.Justification {
+			// unchanged, keep it as it is
+			delete(suppressed, exception.CheckMrn)
+			continue
+		}
+		if err := s.services.RemoveException(ctx, assetMrn, exception.CheckMrn); err != nil {
+			return err
+		}
+	}
+
+	for _, checkMrn := range checkMrns {
+		exception, ok := suppressed[checkMrn]
+		if !ok {
+			continue
+		}
+		if err := s.services.AddException(ctx, exception); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchSuppressions returns the suppressions that matched any check of the
+// bundle and warns about all others
+func matchSuppressions(bundle *policy.Bundle, suppressions []*Suppression) []*Suppression {
+	if bundle == nil || len(suppressions) == 0 {
+		return nil
+	}
+
+	var res []*Suppression
+	for _, s := range suppressions {
+		matched := false
+		for _, query := range bundle.Queries {
+			if s.matches(query) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			res = append(res, s)
+		} else {
+			log.Warn().Str("check", s.CheckID).Str("file", s.File).Int("line", s.Line).Msg("suppression does not match any check")
+		}
+	}
+	return res
+}
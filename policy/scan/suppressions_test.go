@@ -0,0 +1,140 @@
+package scan
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnquery/motor/providers"
+	"go.mondoo.com/cnquery/resources"
+	"go.mondoo.com/cnspec/internal/datalakes/inmemory"
+	"go.mondoo.com/cnspec/policy"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	source := `resource "aws_s3_bucket" "logs" {
+  # cnspec-ignore: s3-versioning, s3-logging reason="logs only"
+  bucket = "logs"
+  // cnspec-ignore: s3-encryption
+  acl = "cnspec-ignore: not-a-comment"
+}
+`
+	res, err := ParseSuppressions(strings.NewReader(source), "main.tf")
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, &Suppression{CheckID: "s3-versioning", Reason: "logs only", File: "main.tf", Line: 2}, res[0])
+	assert.Equal(t, &Suppression{CheckID: "s3-logging", Reason: "logs only", File: "main.tf", Line: 2}, res[1])
+	assert.Equal(t, &Suppression{CheckID: "s3-encryption", File: "main.tf", Line: 4}, res[2])
+	assert.Equal(t, "suppressed by main.tf:2: logs only", res[0].String())
+	assert.Equal(t, "suppressed by main.tf:4", res[2].String())
+
+	t.Run("long lines", func(t *testing.T) {
+		long := "{\"data\": \"" + strings.Repeat("x", 128*1024) + "\"}\n# cnspec-ignore: check\n"
+		res, err := ParseSuppressions(strings.NewReader(long), "data.json")
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 2, res[0].Line)
+
+		tooLong := strings.Repeat("x", maxSuppressionLineSize+1)
+		_, err = ParseSuppressions(strings.NewReader(tooLong), "data.json")
+		assert.ErrorIs(t, err, bufio.ErrTooLong)
+	})
+}
+
+func TestCollectSuppressions(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte("# cnspec-ignore: s3-versioning\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "minified.json"), []byte(strings.Repeat("x", maxSuppressionLineSize+1)), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# cnspec-ignore: ignored\n"), 0o600))
+
+	res, err := collectSuppressions(&asset.Asset{
+		Connections: []*providers.Config{{
+			Backend: providers.ProviderType_TERRAFORM,
+			Options: map[string]string{"path": dir},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "s3-versioning", res[0].CheckID)
+
+	// only IaC assets have suppressions
+	res, err = collectSuppressions(&asset.Asset{
+		Connections: []*providers.Config{{
+			Backend: providers.ProviderType_SSH,
+			Options: map[string]string{"path": dir},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestMatchSuppressions(t *testing.T) {
+	bundle := &policy.Bundle{Queries: []*explorer.Mquery{
+		{Uid: "ssh-check", Mrn: "//local.cnspec.io/run/local-execution/queries/ssh-check"},
+	}}
+	res := matchSuppressions(bundle, []*Suppression{
+		{CheckID: "ssh-check", File: "main.tf", Line: 1},
+		{CheckID: "//local.cnspec.io/run/local-execution/queries/ssh-check", File: "main.tf", Line: 2},
+		{CheckID: "unknown", File: "main.tf", Line: 3},
+	})
+	require.Len(t, res, 2)
+	assert.Equal(t, 1, res[0].Line)
+	assert.Equal(t, 2, res[1].Line)
+}
+
+func TestSyncSuppressions(t *testing.T) {
+	bundle, err := policy.BundleFromYAML([]byte(testScanBundle))
+	require.NoError(t, err)
+	_, services, err := inmemory.NewServices(nil)
+	require.NoError(t, err)
+
+	a := &asset.Asset{Name: "main.tf", Mrn: "//policy.api.mondoo.app/assets/main.tf"}
+	scanner := &localAssetScanner{
+		db:       services.DataLake,
+		services: services,
+		job: &AssetJob{
+			UpstreamConfig: resources.UpstreamConfig{Incognito: true},
+			Asset:          a,
+			Bundle:         bundle,
+			Ctx:            context.Background(),
+		},
+		suppressions: []*Suppression{{CheckID: "ssh-check", Reason: "bastion", File: "main.tf", Line: 3}},
+	}
+	require.NoError(t, scanner.prepareAsset())
+
+	exceptions, err := services.ActiveExceptions(context.Background(), a.Mrn, time.Now())
+	require.NoError(t, err)
+	require.Len(t, exceptions, 1)
+	assert.True(t, strings.HasSuffix(exceptions[0].CheckMrn, "/ssh-check"))
+	assert.Equal(t, "suppressed by main.tf:3: bastion", exceptions[0].Justification)
+
+	// explicit exceptions are kept, suppressions that were removed are not
+	var tlsMrn string
+	for _, query := range scanner.job.Bundle.Queries {
+		if query.Uid == "tls-check" {
+			tlsMrn = query.Mrn
+		}
+	}
+	require.NotEmpty(t, tlsMrn)
+	require.NoError(t, services.AddException(context.Background(), &policy.Exception{
+		CheckMrn:      tlsMrn,
+		EntityMrn:     a.Mrn,
+		Justification: "legacy clients",
+	}))
+	scanner.suppressions = []*Suppression{{CheckID: "tls-check", File: "main.tf", Line: 7}}
+	require.NoError(t, scanner.syncSuppressions(context.Background()))
+
+	exceptions, err = services.ActiveExceptions(context.Background(), a.Mrn, time.Now())
+	require.NoError(t, err)
+	require.Len(t, exceptions, 1)
+	assert.Equal(t, tlsMrn, exceptions[0].CheckMrn)
+	assert.Equal(t, "legacy clients", exceptions[0].Justification)
+}
@@ -0,0 +1,124 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/cli/progress"
+	"go.mondoo.com/cnquery/motor/asset"
+	"go.mondoo.com/cnspec/internal/datalakes/inmemory"
+	"go.mondoo.com/cnspec/policy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	globalRecorderOnce sync.Once
+	globalRecorder     = &spanRecorder{}
+)
+
+// recordGlobalSpans makes the global tracer provider, which the resolver and
+// the scanner trace with, record all spans. The global provider can only be
+// set once, so all tests share the recorder, which is emptied here.
+func recordGlobalSpans() *spanRecorder {
+	globalRecorderOnce.Do(func() {
+		otel.SetTracerProvider(globalRecorder)
+	})
+	globalRecorder.lock.Lock()
+	globalRecorder.spans = nil
+	globalRecorder.lock.Unlock()
+	return globalRecorder
+}
+
+func (r *spanRecorder) byName(name string) []*recordedSpan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var res []*recordedSpan
+	for _, span := range r.spans {
+		if span.name == name {
+			res = append(res, span)
+		}
+	}
+	return res
+}
+
+// hasAncestor checks if the span was started within the ancestor span
+func hasAncestor(span *recordedSpan, ancestor *recordedSpan) bool {
+	for {
+		parent, ok := span.Span.(*recordedSpan)
+		if !ok {
+			return false
+		}
+		if parent == ancestor {
+			return true
+		}
+		span = parent
+	}
+}
+
+func TestResolverSpans(t *testing.T) {
+	recorder := recordGlobalSpans()
+	ctx := context.Background()
+
+	bundle, err := policy.BundleFromPaths("../../examples/example.mql.yaml")
+	require.NoError(t, err)
+	_, services, err := inmemory.NewServices(nil)
+	require.NoError(t, err)
+
+	assetMrn := "//policy.api.mondoo.app/assets/web-01"
+	_, err = services.SetBundle(ctx, bundle)
+	require.NoError(t, err)
+	_, err = services.Assign(ctx, &policy.PolicyAssignment{AssetMrn: assetMrn, PolicyMrns: bundle.PolicyMRNs()})
+	require.NoError(t, err)
+	filters, err := services.GetPolicyFilters(ctx, &policy.Mrn{Mrn: assetMrn})
+	require.NoError(t, err)
+	_, err = services.ResolveAndUpdateJobs(ctx, &policy.UpdateAssetJobsReq{AssetMrn: assetMrn, AssetFilters: filters.Items})
+	require.NoError(t, err)
+
+	resolves := recorder.byName("resolver/resolve")
+	require.Len(t, resolves, 1)
+	resolve := resolves[0]
+	assert.True(t, resolve.ended)
+	assert.Equal(t, codes.Unset, resolve.status)
+	assert.Equal(t, assetMrn, resolve.attrs["policy"].AsString())
+
+	// the steps of the resolution are children of the resolve span
+	for _, name := range []string{"resolver/policyToJobs", "resolver/jobsToQueries"} {
+		spans := recorder.byName(name)
+		require.NotEmpty(t, spans, name)
+		for _, span := range spans {
+			assert.True(t, span.ended, name)
+			assert.True(t, hasAncestor(span, resolve), name)
+		}
+	}
+}
+
+func TestRunAssetJobSpan(t *testing.T) {
+	recorder := recordGlobalSpans()
+	failure := errors.New("no credentials")
+	s := newHookedScanner(
+		WithBeforeAssetHook(func(ctx context.Context, a *asset.Asset) error { return failure }),
+	)
+
+	s.RunAssetJob(&AssetJob{
+		Asset:            &asset.Asset{Mrn: "//assets/a", Name: "a"},
+		Ctx:              context.Background(),
+		Reporter:         NewAggregateReporter(),
+		ProgressReporter: progress.Noop{},
+	})
+
+	spans := recorder.byName("scan/RunAssetJob")
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.True(t, span.ended)
+	assert.Equal(t, "a", span.attrs["asset.name"].AsString())
+	assert.Equal(t, "//assets/a", span.attrs["asset.mrn"].AsString())
+	assert.Equal(t, codes.Error, span.status)
+	require.Len(t, span.errs, 1)
+	assert.ErrorIs(t, span.errs[0], failure)
+}
@@ -1,9 +1,6 @@
 package policy
 
 import (
-	"strconv"
-
-	"github.com/pkg/errors"
 	"go.mondoo.com/cnquery/explorer"
 	"google.golang.org/protobuf/proto"
 )
@@ -56,15 +53,9 @@ func AddSpecdScore(calculator ScoreCalculator, s *Score, found bool, impact *exp
 		}
 	}
 
-	// we ignore the UNSPECIFIED specs
-	if impact == nil {
-		calculator.Add(score)
-		return
-	}
-
-	// everything else is modify or activate
-
-	if impact.Weight == 0 {
+	// informational scores are reported, but never counted, just like scores
+	// without weight
+	if s.Informational || (impact != nil && impact.Weight == 0) {
 		calculator.Add(&Score{
 			// We override the type because:
 			// 1. If it is set to Result, its value will be added to the total
@@ -84,6 +75,14 @@ func AddSpecdScore(calculator ScoreCalculator, s *Score, found bool, impact *exp
 		return
 	}
 
+	// we ignore the UNSPECIFIED specs
+	if impact == nil {
+		calculator.Add(score)
+		return
+	}
+
+	// everything else is modify or activate
+
 	if impact.Weight != -1 {
 		score.Weight = uint32(impact.Weight)
 	}
@@ -352,7 +351,11 @@ func NewScoreCalculator(scoringSystem ScoringSystem) (ScoreCalculator, error) {
 	case ScoringSystem_WORST:
 		res = &worstScoreCalculator{}
 	default:
-		return nil, errors.New("don't know how to create scoring calculator for system " + strconv.Itoa(int(scoringSystem)))
+		custom, err := newCustomScoreCalculator(scoringSystem)
+		if err != nil {
+			return nil, err
+		}
+		res = custom
 	}
 	res.Init()
 	return res, nil
@@ -263,3 +263,47 @@ func TestImpact(t *testing.T) {
 		require.EqualValues(t, 80, int(s.Value))
 	})
 }
+
+func TestCustomScoringSystem(t *testing.T) {
+	id, err := RegisterScoringSystem("Test Best", func(children []*Score) Score {
+		res := Score{ScoreCompletion: 100, DataCompletion: 100, Type: ScoreType_Unscored}
+		for _, child := range children {
+			if child.Type == ScoreType_Result && (res.Type != ScoreType_Result || child.Value > res.Value) {
+				res.Type = ScoreType_Result
+				res.Value = child.Value
+			}
+		}
+		return res
+	})
+	require.NoError(t, err)
+
+	byName, ok := ScoringSystemByName("test best")
+	require.True(t, ok)
+	assert.Equal(t, id, byName)
+
+	var s ScoringSystem
+	require.NoError(t, s.UnmarshalJSON([]byte(`"test best"`)))
+	assert.Equal(t, id, s)
+
+	testScoring(t, func() ScoreCalculator {
+		res, err := NewScoreCalculator(id)
+		require.NoError(t, err)
+		return res
+	}, []scoreTest{
+		{
+			in: []*Score{
+				{Value: 20, Type: ScoreType_Result},
+				{Value: 100, Type: ScoreType_Skip},
+				{Value: 80, Type: ScoreType_Result},
+			},
+			out: &Score{Value: 80, ScoreCompletion: 100, DataCompletion: 100, Type: ScoreType_Result},
+		},
+	})
+
+	_, err = RegisterScoringSystem("test best", func(children []*Score) Score { return Score{} })
+	assert.Error(t, err)
+	_, err = RegisterScoringSystem("weighted", func(children []*Score) Score { return Score{} })
+	assert.Error(t, err)
+	_, err = RegisterScoringSystem("other", nil)
+	assert.Error(t, err)
+}
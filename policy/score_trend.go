@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"time"
+)
+
+// TrendPoint is the state of a score during one interval of a trend line
+type TrendPoint struct {
+	// Time is the start of the interval
+	Time time.Time
+	// Value is the score value at the end of the interval
+	Value uint32
+	// Min and Max are the lowest and highest values during the interval
+	Min uint32
+	Max uint32
+	// Changes is the number of recorded changes within the interval
+	Changes int
+	// Known is false if the score had no value yet during the interval
+	Known bool
+}
+
+// ScoreTrend turns the history of a score into a trend line for the time
+// between start and end, with one point per interval. Scores keep their
+// value until the next change is recorded, so the history has to start with
+// the last change before start (as returned by DataLake.GetScoreHistory).
+// History entries must be ordered by time.
+func ScoreTrend(history []ScoreHistoryEntry, start time.Time, end time.Time, interval time.Duration) []TrendPoint {
+	if interval <= 0 || !start.Before(end) {
+		return []TrendPoint{}
+	}
+
+	res := make([]TrendPoint, 0, int(end.Sub(start)/interval)+1)
+	var cur TrendPoint
+	idx := 0
+
+	// carry the value from before the trend starts
+	for idx < len(history) && history[idx].Recorded.Before(start) {
+		cur.Value = history[idx].Value
+		cur.Known = true
+		idx++
+	}
+
+	for t := start; t.Before(end); t = t.Add(interval) {
+		point := TrendPoint{
+			Time:  t,
+			Value: cur.Value,
+			Min:   cur.Value,
+			Max:   cur.Value,
+			Known: cur.Known,
+		}
+
+		next := t.Add(interval)
+		for idx < len(history) && history[idx].Recorded.Before(next) {
+			value := history[idx].Value
+			if !point.Known {
+				point.Min, point.Max = value, value
+				point.Known = true
+			}
+			if value < point.Min {
+				point.Min = value
+			}
+			if value > point.Max {
+				point.Max = value
+			}
+			point.Value = value
+			point.Changes++
+			idx++
+		}
+
+		res = append(res, point)
+		cur = point
+	}
+
+	return res
+}
+
+// TrendSlope returns the slope of the least-squares line through the known
+// points of a trend, in score points per interval. A positive slope means
+// that the score improves. It returns 0 if fewer than 2 points are known.
+func TrendSlope(points []TrendPoint) float64 {
+	var n, sumX, sumY, sumXY, sumXX float64
+	for i := range points {
+		if !points[i].Known {
+			continue
+		}
+		x := float64(i)
+		y := float64(points[i].Value)
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if n < 2 || denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreTrend(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	hour := time.Hour
+	history := []ScoreHistoryEntry{
+		{QrId: "check", Value: 20, Recorded: start.Add(-2 * hour)},
+		{QrId: "check", Value: 40, Recorded: start.Add(hour + time.Minute)},
+		{QrId: "check", Value: 100, Recorded: start.Add(hour + 2*time.Minute)},
+		{QrId: "check", Value: 80, Recorded: start.Add(3 * hour)},
+	}
+
+	points := ScoreTrend(history, start, start.Add(4*hour), hour)
+	require.Len(t, points, 4)
+
+	assert.Equal(t, TrendPoint{Time: start, Value: 20, Min: 20, Max: 20, Known: true}, points[0])
+	assert.Equal(t, TrendPoint{Time: start.Add(hour), Value: 100, Min: 20, Max: 100, Changes: 2, Known: true}, points[1])
+	assert.Equal(t, TrendPoint{Time: start.Add(2 * hour), Value: 100, Min: 100, Max: 100, Known: true}, points[2])
+	assert.Equal(t, TrendPoint{Time: start.Add(3 * hour), Value: 80, Min: 80, Max: 100, Changes: 1, Known: true}, points[3])
+
+	assert.Greater(t, TrendSlope(points), 0.0)
+
+	t.Run("without earlier history", func(t *testing.T) {
+		points := ScoreTrend(history[2:], start, start.Add(3*hour), hour)
+		require.Len(t, points, 3)
+		assert.False(t, points[0].Known)
+		assert.Equal(t, TrendPoint{Time: start.Add(hour), Value: 100, Min: 100, Max: 100, Changes: 1, Known: true}, points[1])
+		assert.Equal(t, 0.0, TrendSlope(points))
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		assert.Empty(t, ScoreTrend(history, start, start, hour))
+		assert.Empty(t, ScoreTrend(history, start, start.Add(hour), 0))
+	})
+}
+
+func TestScoreHistoryEntry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	_, ok := NewScoreHistoryEntry(&Score{QrId: "a", Type: ScoreType_Result, ScoreCompletion: 50}, "x", now)
+	assert.False(t, ok)
+
+	entry, ok := NewScoreHistoryEntry(&Score{QrId: "a", Type: ScoreType_Result, ScoreCompletion: 100, Value: 100}, "x", now)
+	require.True(t, ok)
+	assert.False(t, entry.Changes(entry))
+
+	other := entry
+	other.Value = 50
+	assert.True(t, entry.Changes(other))
+	other = entry
+	other.ExecutionChecksum = "y"
+	assert.True(t, entry.Changes(other))
+}
@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mondoo.com/cnquery/explorer"
+	"google.golang.org/protobuf/proto"
+)
+
+// ScoringPresetTag selects a scoring preset by name, e.g.
+// `mondoo.com/scoring-preset: strict`. On policies, the preset sets the
+// scoring system of the policy and the scoring of all its checks. On checks,
+// it overrides the preset of the policy.
+const ScoringPresetTag = "mondoo.com/scoring-preset"
+
+// ScoringPreset is a named scoring configuration, so that policies share
+// the same scoring instead of repeating impacts on every check. Values that
+// policies and checks set explicitly take precedence over the preset.
+type ScoringPreset struct {
+	Name string
+	// ScoringSystem combines the scores of the checks of a policy
+	ScoringSystem ScoringSystem
+	// Scoring combines the results of a check
+	Scoring explorer.Impact_ScoringSystem
+	// Weight of every check in the score of its policy
+	Weight int32
+}
+
+// ScoringPresets are the built-in presets, indexed by name
+var ScoringPresets = map[string]*ScoringPreset{
+	// strict scores policies by their worst check and checks by their
+	// worst result
+	"strict": {
+		Name:          "strict",
+		ScoringSystem: ScoringSystem_WORST,
+		Scoring:       explorer.Impact_WORST,
+		Weight:        1,
+	},
+	// standard is the default scoring of policies
+	"standard": {
+		Name:          "standard",
+		ScoringSystem: ScoringSystem_AVERAGE,
+		Weight:        1,
+	},
+	// lenient averages the results of checks, so that checks with many
+	// results aren't failed by a single one
+	"lenient": {
+		Name:          "lenient",
+		ScoringSystem: ScoringSystem_AVERAGE,
+		Scoring:       explorer.Impact_AVERAGE,
+		Weight:        1,
+	},
+	// informational reports results without scoring them
+	"informational": {
+		Name:          "informational",
+		ScoringSystem: ScoringSystem_DATA_ONLY,
+		Weight:        0,
+	},
+}
+
+// ScoringPresetByName returns the preset with the given name, which is
+// case-insensitive
+func ScoringPresetByName(name string) (*ScoringPreset, bool) {
+	res, ok := ScoringPresets[strings.ToLower(strings.TrimSpace(name))]
+	return res, ok
+}
+
+// scoringPresetNames returns the sorted names of all presets
+func scoringPresetNames() string {
+	names := make([]string, 0, len(ScoringPresets))
+	for name := range ScoringPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// scoringPresetFromTags returns the preset selected by the tags, nil if
+// there is none
+func scoringPresetFromTags(tags map[string]string) (*ScoringPreset, error) {
+	name, ok := tags[ScoringPresetTag]
+	if !ok {
+		return nil, nil
+	}
+	res, ok := ScoringPresetByName(name)
+	if !ok {
+		return nil, errors.New("unknown scoring preset '" + name + "', available presets: " + scoringPresetNames())
+	}
+	return res, nil
+}
+
+// ApplyToPolicy sets the scoring system of the policy, unless it has one
+func (p *ScoringPreset) ApplyToPolicy(policy *Policy) {
+	if policy.ScoringSystem == ScoringSystem_SCORING_UNSPECIFIED {
+		policy.ScoringSystem = p.ScoringSystem
+	}
+}
+
+// ApplyToCheck sets the scoring and weight of the check's impact, unless
+// it has them. Weights of 0 are treated as unset. Checks without an impact
+// start from the impact of their base query, if any, or from a neutral
+// impact of 100, which doesn't raise the scores of failed results.
+func (p *ScoringPreset) ApplyToCheck(check *explorer.Mquery, base *explorer.Mquery) {
+	if check.Impact == nil {
+		if base != nil && base.Impact != nil {
+			check.Impact = proto.Clone(base.Impact).(*explorer.Impact)
+		} else {
+			check.Impact = &explorer.Impact{Value: 100, Weight: -1}
+		}
+	}
+	if check.Impact.Scoring == explorer.Impact_SCORING_UNSPECIFIED {
+		check.Impact.Scoring = p.Scoring
+	}
+	if check.Impact.Weight <= 0 {
+		check.Impact.Weight = p.Weight
+	}
+}
+
+// applyScoringPreset applies the preset of the check or, if it has none,
+// the preset of its policy. The base query is the check's definition in
+// the bundle, if it only references it.
+func applyScoringPreset(policyPreset *ScoringPreset, check *explorer.Mquery, base *explorer.Mquery) error {
+	preset, err := scoringPresetFromTags(check.Tags)
+	if err == nil && preset == nil && base != nil {
+		preset, err = scoringPresetFromTags(base.Tags)
+	}
+	if err != nil {
+		return err
+	}
+	if preset == nil {
+		preset = policyPreset
+	}
+	if preset != nil {
+		preset.ApplyToCheck(check, base)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestScoringPresetByName(t *testing.T) {
+	preset, ok := ScoringPresetByName(" Strict ")
+	require.True(t, ok)
+	assert.Equal(t, ScoringSystem_WORST, preset.ScoringSystem)
+
+	_, ok = ScoringPresetByName("unknown")
+	assert.False(t, ok)
+}
+
+func TestCompile_ScoringPresets(t *testing.T) {
+	newBundle := func(preset string) *Bundle {
+		return &Bundle{
+			Policies: []*Policy{{
+				Uid:     "policy",
+				Version: "1.0.0",
+				Tags:    map[string]string{ScoringPresetTag: preset},
+				Groups: []*PolicyGroup{{
+					Checks: []*explorer.Mquery{
+						{Uid: "check-ref"},
+						{Uid: "check-embedded", Mql: "1 == 1"},
+						{Uid: "check-weighted", Mql: "2 == 2", Impact: &explorer.Impact{Value: 30, Weight: 5}},
+						{Uid: "check-lenient", Mql: "3 == 3", Tags: map[string]string{ScoringPresetTag: "lenient"}},
+					},
+				}},
+			}},
+			Queries: []*explorer.Mquery{
+				{Uid: "check-ref", Mql: "true", Impact: &explorer.Impact{Value: 80}},
+			},
+		}
+	}
+
+	bundle := newBundle("strict")
+	_, err := bundle.Compile(context.Background(), nil)
+	require.NoError(t, err)
+
+	policy := bundle.Policies[0]
+	assert.Equal(t, ScoringSystem_WORST, policy.ScoringSystem)
+	checks := policy.Groups[0].Checks
+
+	// referenced checks keep the impact of their query
+	assert.Equal(t, int32(80), checks[0].Impact.Value)
+	assert.Equal(t, int32(1), checks[0].Impact.Weight)
+	assert.Equal(t, explorer.Impact_WORST, checks[0].Impact.Scoring)
+
+	// checks without impact don't raise the scores of failed results
+	assert.Equal(t, int32(100), checks[1].Impact.Value)
+	assert.Equal(t, explorer.Impact_WORST, checks[1].Impact.Scoring)
+
+	// explicit weights win over the preset
+	assert.Equal(t, int32(5), checks[2].Impact.Weight)
+	assert.Equal(t, int32(30), checks[2].Impact.Value)
+
+	// presets of checks win over the preset of the policy
+	assert.Equal(t, explorer.Impact_AVERAGE, checks[3].Impact.Scoring)
+
+	t.Run("unknown presets fail", func(t *testing.T) {
+		_, err := newBundle("relaxed").Compile(context.Background(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown scoring preset 'relaxed'")
+	})
+}
@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ScoringFunc combines the scores of all children of a policy or query
+// into one score. Skipped children are not passed to it, children that only
+// collect data are passed as ScoreType_Unscored.
+type ScoringFunc func(children []*Score) Score
+
+// customScoringSystemBase is the first ID of custom scoring systems, it
+// leaves plenty of room for future built-in systems
+const customScoringSystemBase = 1 << 16
+
+var customScoringSystems = struct {
+	sync.RWMutex
+	byID   map[ScoringSystem]ScoringFunc
+	byName map[string]ScoringSystem
+}{
+	byID:   map[ScoringSystem]ScoringFunc{},
+	byName: map[string]ScoringSystem{},
+}
+
+// customScoringSystemID derives the ID of a custom scoring system from its
+// name, so that policies that use it have the same checksums in every
+// process, independent of the order of registration
+func customScoringSystemID(name string) ScoringSystem {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return ScoringSystem(customScoringSystemBase + h.Sum32()%(1<<30))
+}
+
+// RegisterScoringSystem adds a custom scoring system, which policies can
+// select by its name, e.g. `scoring_system: weighted-decay`. It returns the
+// ID of the scoring system. Names are case-insensitive and can't replace
+// built-in scoring systems.
+func RegisterScoringSystem(name string, fn ScoringFunc) (ScoringSystem, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return 0, errors.New("cannot register scoring system without a name")
+	}
+	if fn == nil {
+		return 0, errors.New("cannot register scoring system '" + name + "' without a scoring function")
+	}
+	if _, ok := builtinScoringSystem(name); ok {
+		return 0, errors.New("cannot replace built-in scoring system '" + name + "'")
+	}
+
+	id := customScoringSystemID(name)
+
+	customScoringSystems.Lock()
+	defer customScoringSystems.Unlock()
+	if _, ok := customScoringSystems.byName[name]; ok {
+		return 0, errors.New("scoring system '" + name + "' is already registered")
+	}
+	if _, ok := customScoringSystems.byID[id]; ok {
+		return 0, errors.New("scoring system '" + name + "' collides with another registered scoring system, please choose another name")
+	}
+	customScoringSystems.byID[id] = fn
+	customScoringSystems.byName[name] = id
+	return id, nil
+}
+
+// builtinScoringSystem maps the names of built-in scoring systems that
+// policies use
+func builtinScoringSystem(name string) (ScoringSystem, bool) {
+	switch name {
+	case "highest impact":
+		return ScoringSystem_WORST, true
+	case "weighted":
+		return ScoringSystem_WEIGHTED, true
+	case "average", "":
+		return ScoringSystem_AVERAGE, true
+	default:
+		return 0, false
+	}
+}
+
+// ScoringSystemByName returns the built-in or registered scoring system
+func ScoringSystemByName(name string) (ScoringSystem, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if res, ok := builtinScoringSystem(name); ok {
+		return res, true
+	}
+
+	customScoringSystems.RLock()
+	defer customScoringSystems.RUnlock()
+	res, ok := customScoringSystems.byName[name]
+	return res, ok
+}
+
+func customScoringFunc(scoringSystem ScoringSystem) (ScoringFunc, bool) {
+	customScoringSystems.RLock()
+	defer customScoringSystems.RUnlock()
+	fn, ok := customScoringSystems.byID[scoringSystem]
+	return fn, ok
+}
+
+// customScoreCalculator collects all scores for a ScoringFunc
+type customScoreCalculator struct {
+	fn       ScoringFunc
+	children []*Score
+}
+
+func (c *customScoreCalculator) Init() {
+	c.children = nil
+}
+
+func (c *customScoreCalculator) Add(score *Score) {
+	if score.Type == ScoreType_Skip {
+		return
+	}
+	c.children = append(c.children, score)
+}
+
+func (c *customScoreCalculator) Calculate() *Score {
+	res := c.fn(c.children)
+	return &res
+}
+
+func newCustomScoreCalculator(scoringSystem ScoringSystem) (ScoreCalculator, error) {
+	fn, ok := customScoringFunc(scoringSystem)
+	if !ok {
+		return nil, errors.New("don't know how to create scoring calculator for system " + strconv.Itoa(int(scoringSystem)))
+	}
+	return &customScoreCalculator{fn: fn}, nil
+}
@@ -37,6 +37,51 @@ type LocalServices struct {
 	DataLake  DataLake
 	Upstream  *Services
 	Incognito bool
+	// UpstreamBreaker is optional. If set, repeated upstream failures make
+	// these services fall back to local behavior until upstream recovers.
+	UpstreamBreaker *UpstreamBreaker
+	// EntityParents is optional. It returns the parents of an entity, from
+	// the most general to the most specific one, e.g. the org and space of
+	// an asset. Entities inherit all properties that their parents set.
+	EntityParents func(entityMrn string) []string
+	// Metrics is optional. If set, resolutions and stored results are
+	// recorded as Prometheus metrics.
+	Metrics *ResolverMetrics
+	// OfflineQueue is optional. If set, results that can't be sent upstream
+	// because it is unreachable are queued instead of failing, so that the
+	// UploadSyncer of their space sends them later.
+	OfflineQueue *OfflineQueue
+	// UploadTracker is optional. If set, datapoints whose value didn't change
+	// since they were last sent are not sent upstream again.
+	UploadTracker *UploadTracker
+	// RequireResolvedPolicyCache makes resolving fail if resolved policies
+	// can't be cached because the cache is full. By default, they are used
+	// without caching them and a warning is logged.
+	RequireResolvedPolicyCache bool
+	// Notifier is optional. If set, it is notified of all scores whose value
+	// or outcome changed, see WebhookNotifier and SlackNotifier.
+	Notifier ScoreNotifier
+	// ReResolver is optional. If set, assets that use policies of updated
+	// bundles are re-resolved in the background.
+	ReResolver *ReResolver
+	// Quotas is optional. If set, the scans and stored results of assets
+	// are counted towards the quotas of their space, see QuotaEnforcer.
+	Quotas *QuotaEnforcer
+	// ChecksumWorkers limits how many reporting job checksums are computed
+	// in parallel while resolving. 0 uses one worker per CPU, 1 computes
+	// them sequentially.
+	ChecksumWorkers int
+	// ResolverOptions tune how resolutions are retried when they collide with
+	// concurrent resolutions of the same policy. The zero value uses the
+	// defaults.
+	ResolverOptions ResolverOptions
+	// ResolverSnapshotDir is optional. If set, the intermediate state of
+	// resolutions that fail is written to this directory, so that they can
+	// be reproduced, see ResolverSnapshot.
+	ResolverSnapshotDir string
+	// ExceptionApprover is optional. If set, new exceptions have to be
+	// approved before they waive checks, see ExceptionApprovers.
+	ExceptionApprover ExceptionApprover
 }
 
 // NewLocalServices initializes a reasonably configured local services struct
@@ -48,6 +93,26 @@ func NewLocalServices(datalake DataLake, uuid string) *LocalServices {
 	}
 }
 
+// useUpstream returns true if a call should be forwarded to upstream
+func (s *LocalServices) useUpstream() bool {
+	return s.Upstream != nil && !s.Incognito && s.UpstreamBreaker.Allow()
+}
+
+// upstreamResult records the outcome of an upstream call with the breaker
+func (s *LocalServices) upstreamResult(err error) {
+	if err == nil {
+		s.UpstreamBreaker.Success()
+		return
+	}
+	s.UpstreamBreaker.Failure(err, s.probeUpstream)
+}
+
+// probeUpstream makes a cheap call to check if upstream is reachable
+func (s *LocalServices) probeUpstream(ctx context.Context) error {
+	_, err := s.Upstream.PolicyHub.DefaultPolicies(ctx, &DefaultPoliciesReq{})
+	return err
+}
+
 // NewRemoteServices initializes a services struct with a remote endpoint
 func NewRemoteServices(addr string, auth []ranger.ClientPlugin) (*Services, error) {
 	client := ranger.DefaultHttpClient()
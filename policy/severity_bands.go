@@ -0,0 +1,171 @@
+package policy
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// SeverityBand is the severity of a score, e.g. for ticketing or alerting
+type SeverityBand string
+
+const (
+	BandCritical SeverityBand = "critical"
+	BandHigh     SeverityBand = "high"
+	BandMedium   SeverityBand = "medium"
+	BandLow      SeverityBand = "low"
+	BandPass     SeverityBand = "pass"
+)
+
+// SeverityBandsTag is the policy tag that stores the severity bands of the
+// policy, e.g. `mondoo.com/severity-bands: 10,30,60,100`
+const SeverityBandsTag = "mondoo.com/severity-bands"
+
+// SeverityBands map scores to bands. Every threshold is the exclusive upper
+// bound of its band, e.g. scores below Critical are critical. Scores of at
+// least Low pass.
+type SeverityBands struct {
+	Critical uint32
+	High     uint32
+	Medium   uint32
+	Low      uint32
+}
+
+// DefaultSeverityBands match the failure labels of score ratings
+var DefaultSeverityBands = SeverityBands{Critical: 10, High: 30, Medium: 60, Low: 100}
+
+// ParseSeverityBands parses the thresholds of critical, high, medium and
+// low scores, e.g. `10,30,60,100`
+func ParseSeverityBands(s string) (*SeverityBands, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("severity bands need 4 thresholds for critical, high, medium and low, e.g. 10,30,60,100")
+	}
+
+	thresholds := make([]uint32, len(parts))
+	for i := range parts {
+		v, err := strconv.ParseUint(strings.TrimSpace(parts[i]), 10, 32)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid severity band threshold '"+parts[i]+"'")
+		}
+		thresholds[i] = uint32(v)
+	}
+
+	res := &SeverityBands{Critical: thresholds[0], High: thresholds[1], Medium: thresholds[2], Low: thresholds[3]}
+	if err := res.Validate(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Validate that thresholds are ascending and at most 100
+func (b *SeverityBands) Validate() error {
+	if b.Critical > b.High || b.High > b.Medium || b.Medium > b.Low {
+		return errors.New("severity band thresholds must be ascending from critical to low")
+	}
+	if b.Low > 100 {
+		return errors.New("severity band thresholds must be at most 100")
+	}
+	return nil
+}
+
+func (b *SeverityBands) String() string {
+	return strconv.FormatUint(uint64(b.Critical), 10) + "," +
+		strconv.FormatUint(uint64(b.High), 10) + "," +
+		strconv.FormatUint(uint64(b.Medium), 10) + "," +
+		strconv.FormatUint(uint64(b.Low), 10)
+}
+
+// ValueBand returns the band of a score value
+func (b *SeverityBands) ValueBand(value uint32) SeverityBand {
+	if b == nil {
+		b = &DefaultSeverityBands
+	}
+	switch {
+	case value < b.Critical:
+		return BandCritical
+	case value < b.High:
+		return BandHigh
+	case value < b.Medium:
+		return BandMedium
+	case value < b.Low:
+		return BandLow
+	default:
+		return BandPass
+	}
+}
+
+// Band returns the band of a score. Only results have a band, all other
+// scores, like skipped or errored ones, return an empty band.
+func (b *SeverityBands) Band(score *Score) SeverityBand {
+	if score == nil || score.Type != ScoreType_Result || score.Completion() == 0 {
+		return ""
+	}
+	return b.ValueBand(score.Value)
+}
+
+// SeverityBands returns the bands stored on the policy, if any
+func (p *Policy) SeverityBands() (*SeverityBands, error) {
+	tag, ok := p.Tags[SeverityBandsTag]
+	if !ok {
+		return nil, nil
+	}
+	return ParseSeverityBands(tag)
+}
+
+// SeverityBands returns the bands of the first policy that has any, by MRN,
+// or the default bands. Invalid bands are ignored.
+func (p *Bundle) SeverityBands() *SeverityBands {
+	if p == nil {
+		return &DefaultSeverityBands
+	}
+
+	policies := make([]*Policy, len(p.Policies))
+	copy(policies, p.Policies)
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Mrn < policies[j].Mrn
+	})
+
+	for _, policy := range policies {
+		bands, err := policy.SeverityBands()
+		if err != nil {
+			log.Warn().Err(err).Str("policy", policy.Mrn).Msg("ignoring invalid severity bands of policy")
+			continue
+		}
+		if bands != nil {
+			return bands
+		}
+	}
+	return &DefaultSeverityBands
+}
+
+// SetSeverityBands stores the bands on all policies of the bundle, e.g. to
+// use bands that are provided at scan time
+func (p *Bundle) SetSeverityBands(bands *SeverityBands) {
+	for _, policy := range p.Policies {
+		if policy.Tags == nil {
+			policy.Tags = map[string]string{}
+		}
+		policy.Tags[SeverityBandsTag] = bands.String()
+	}
+}
+
+// SeverityBand returns the band of the report's overall score
+func (r *Report) SeverityBand(bands *SeverityBands) SeverityBand {
+	return bands.Band(r.Score)
+}
+
+// SeverityBands returns the band of every score of the report, by QrId.
+// Scores without a band are omitted.
+func (r *Report) SeverityBands(bands *SeverityBands) map[string]SeverityBand {
+	res := make(map[string]SeverityBand, len(r.Scores))
+	for id, score := range r.Scores {
+		if band := bands.Band(score); band != "" {
+			res[id] = band
+		}
+	}
+	return res
+}
@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverityBands(t *testing.T) {
+	bands, err := ParseSeverityBands("20, 40,70,90")
+	require.NoError(t, err)
+	assert.Equal(t, &SeverityBands{Critical: 20, High: 40, Medium: 70, Low: 90}, bands)
+	assert.Equal(t, "20,40,70,90", bands.String())
+
+	_, err = ParseSeverityBands("10,30,60")
+	assert.Error(t, err)
+	_, err = ParseSeverityBands("10,30,sixty,100")
+	assert.Error(t, err)
+	_, err = ParseSeverityBands("30,10,60,100")
+	assert.Error(t, err)
+	_, err = ParseSeverityBands("10,30,60,101")
+	assert.Error(t, err)
+}
+
+func TestSeverityBands_Band(t *testing.T) {
+	bands := &SeverityBands{Critical: 20, High: 40, Medium: 70, Low: 90}
+	assert.Equal(t, BandCritical, bands.ValueBand(0))
+	assert.Equal(t, BandHigh, bands.ValueBand(20))
+	assert.Equal(t, BandMedium, bands.ValueBand(69))
+	assert.Equal(t, BandLow, bands.ValueBand(70))
+	assert.Equal(t, BandPass, bands.ValueBand(90))
+
+	var defaults *SeverityBands
+	assert.Equal(t, BandMedium, defaults.ValueBand(50))
+
+	assert.Equal(t, BandHigh, bands.Band(&Score{Type: ScoreType_Result, Value: 30, ScoreCompletion: 100, DataCompletion: 100}))
+	assert.Equal(t, SeverityBand(""), bands.Band(&Score{Type: ScoreType_Skip}))
+	assert.Equal(t, SeverityBand(""), bands.Band(&Score{Type: ScoreType_Error, Value: 0}))
+	assert.Equal(t, SeverityBand(""), bands.Band(nil))
+}
+
+func TestBundle_SeverityBands(t *testing.T) {
+	var bundle *Bundle
+	assert.Equal(t, &DefaultSeverityBands, bundle.SeverityBands())
+
+	bundle = &Bundle{Policies: []*Policy{
+		{Mrn: "//policy/b", Tags: map[string]string{SeverityBandsTag: "20,40,70,90"}},
+		{Mrn: "//policy/a", Tags: map[string]string{SeverityBandsTag: "invalid"}},
+		{Mrn: "//policy/c"},
+	}}
+	assert.Equal(t, &SeverityBands{Critical: 20, High: 40, Medium: 70, Low: 90}, bundle.SeverityBands())
+
+	bundle.SetSeverityBands(&SeverityBands{Critical: 5, High: 10, Medium: 50, Low: 80})
+	assert.Equal(t, "5,10,50,80", bundle.Policies[2].Tags[SeverityBandsTag])
+	assert.Equal(t, &SeverityBands{Critical: 5, High: 10, Medium: 50, Low: 80}, bundle.SeverityBands())
+}
+
+func TestReport_SeverityBands(t *testing.T) {
+	report := &Report{
+		Score: &Score{Type: ScoreType_Result, Value: 50, ScoreCompletion: 100, DataCompletion: 100},
+		Scores: map[string]*Score{
+			"pass": {Type: ScoreType_Result, Value: 100, ScoreCompletion: 100, DataCompletion: 100},
+			"fail": {Type: ScoreType_Result, Value: 0, ScoreCompletion: 100, DataCompletion: 100},
+			"skip": {Type: ScoreType_Skip},
+		},
+	}
+	assert.Equal(t, BandMedium, report.SeverityBand(nil))
+	assert.Equal(t, map[string]SeverityBand{"pass": BandPass, "fail": BandCritical}, report.SeverityBands(nil))
+}
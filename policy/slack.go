@@ -0,0 +1,354 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+const (
+	// SlackPostMessageURL is the Slack API method used with bot tokens
+	SlackPostMessageURL = "https://slack.com/api/chat.postMessage"
+	// DefaultSlackMaxChecks is the max number of checks listed in one message
+	DefaultSlackMaxChecks = 20
+	// slackMaxRemediation is the max length of the remediation of a check
+	slackMaxRemediation = 300
+)
+
+// QueryGetter looks up queries by their MRN, e.g. a DataLake
+type QueryGetter interface {
+	GetQuery(ctx context.Context, mrn string) (*explorer.Mquery, error)
+}
+
+// SlackRoute configures which failed checks are sent to a Slack channel.
+// Either WebhookURL or Token and Channel are required.
+type SlackRoute struct {
+	// WebhookURL is an incoming webhook of a Slack app
+	WebhookURL string
+	// Token is the bot token used to post to Channel
+	Token   string
+	Channel string
+	// Policies limits the route to checks of these policies (MRNs). Empty
+	// matches all.
+	Policies []string
+	// MinImpact limits the route to checks with at least this impact (0-100)
+	MinImpact int32
+}
+
+func (r *SlackRoute) name() string {
+	if r.WebhookURL != "" {
+		return r.WebhookURL
+	}
+	return r.Channel
+}
+
+// slackCheck is a newly failed check in a Slack message
+type slackCheck struct {
+	mrn         string
+	title       string
+	impact      int32
+	remediation string
+	refs        []CheckRef
+}
+
+type slackDelivery struct {
+	route    *SlackRoute
+	assetMrn string
+	changes  []ScoreChange
+}
+
+// SlackNotifier sends the newly failed checks of an asset to Slack, one
+// message per asset and route. Checks without a previous score, e.g. on
+// the first scan of an asset, are not reported. Like the WebhookNotifier,
+// messages are sent in the background and dropped if the queue is full.
+type SlackNotifier struct {
+	routes    []SlackRoute
+	queries   QueryGetter
+	client    *http.Client
+	queue     chan slackDelivery
+	wg        sync.WaitGroup
+	apiURL    string
+	maxChecks int
+}
+
+// NewSlackNotifier validates the routes and starts sending messages. The
+// queries provide the title, impact and remediation of failed checks.
+func NewSlackNotifier(routes []SlackRoute, queries QueryGetter, client *http.Client) (*SlackNotifier, error) {
+	for i := range routes {
+		r := &routes[i]
+		if r.WebhookURL == "" && (r.Token == "" || r.Channel == "") {
+			return nil, errors.New("slack route needs a webhook URL or a token and channel")
+		}
+		if r.MinImpact < 0 || r.MinImpact > 100 {
+			return nil, errors.New("min impact of slack route " + r.name() + " must be between 0 and 100")
+		}
+	}
+	if queries == nil {
+		return nil, errors.New("slack notifier needs queries to describe failed checks")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	n := &SlackNotifier{
+		routes:    routes,
+		queries:   queries,
+		client:    client,
+		queue:     make(chan slackDelivery, DefaultWebhookQueueSize),
+		apiURL:    SlackPostMessageURL,
+		maxChecks: DefaultSlackMaxChecks,
+	}
+	n.wg.Add(1)
+	go n.run()
+	return n, nil
+}
+
+// newlyFailed returns true if the check passed, errored or was skipped
+// before and fails now
+func newlyFailed(change ScoreChange) bool {
+	if change.Previous == nil || ScoreOutcome(change.Current) != OutcomeFail {
+		return false
+	}
+	return ScoreOutcome(change.Previous) != OutcomeFail
+}
+
+// NotifyScoreChanges queues a message for every route that matches one of
+// the newly failed scores
+func (n *SlackNotifier) NotifyScoreChanges(ctx context.Context, changes []ScoreChange) {
+	var failed []ScoreChange
+	for _, change := range changes {
+		if newlyFailed(change) {
+			failed = append(failed, change)
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+
+	for i := range n.routes {
+		route := &n.routes[i]
+		var matching []ScoreChange
+		for _, change := range failed {
+			if changeInPolicies(change, route.Policies) {
+				matching = append(matching, change)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		select {
+		case n.queue <- slackDelivery{route: route, assetMrn: matching[0].AssetMrn, changes: matching}:
+		default:
+			log.Warn().Str("slack", route.name()).Str("asset", matching[0].AssetMrn).Msg("slack queue is full, dropping failed checks")
+		}
+	}
+}
+
+// Close sends all queued messages and stops the notifier
+func (n *SlackNotifier) Close() {
+	close(n.queue)
+	n.wg.Wait()
+}
+
+func (n *SlackNotifier) run() {
+	defer n.wg.Done()
+	for delivery := range n.queue {
+		checks := n.failedChecks(context.Background(), delivery)
+		if len(checks) == 0 {
+			continue
+		}
+		if err := n.send(delivery, checks); err != nil {
+			log.Warn().Err(err).Str("slack", delivery.route.name()).Msg("failed to send failed checks to slack")
+		}
+	}
+}
+
+// failedChecks looks up the changed scores and returns all checks with at
+// least the min impact of the route, sorted by impact. Scores that aren't
+// queries, like the scores of policies, are ignored.
+func (n *SlackNotifier) failedChecks(ctx context.Context, delivery slackDelivery) []slackCheck {
+	var res []slackCheck
+	for _, change := range delivery.changes {
+		query, err := n.queries.GetQuery(ctx, change.QrId)
+		if err != nil || query == nil {
+			continue
+		}
+
+		check := slackCheck{
+			mrn:         query.Mrn,
+			title:       query.Title,
+			impact:      -1,
+			remediation: remediationSnippet(query),
+			refs:        DefaultRefClassifier.CheckRefs(query),
+		}
+		if check.title == "" {
+			check.title = change.QrId
+		}
+		if query.Impact != nil {
+			check.impact = query.Impact.Value
+		}
+		if check.impact < delivery.route.MinImpact {
+			continue
+		}
+		res = append(res, check)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].impact == res[j].impact {
+			return res[i].title < res[j].title
+		}
+		return res[i].impact > res[j].impact
+	})
+	return res
+}
+
+// remediationSnippet returns the start of the remediation of a query
+func remediationSnippet(query *explorer.Mquery) string {
+	if query.Docs == nil || query.Docs.Remediation == nil {
+		return ""
+	}
+	for _, item := range query.Docs.Remediation.Items {
+		desc := strings.TrimSpace(item.Desc)
+		if desc == "" {
+			continue
+		}
+		if runes := []rune(desc); len(runes) > slackMaxRemediation {
+			desc = strings.TrimSpace(string(runes[:slackMaxRemediation])) + "…"
+		}
+		return desc
+	}
+	return ""
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackMessage struct {
+	Channel string       `json:"channel,omitempty"`
+	Text    string       `json:"text"`
+	Blocks  []slackBlock `json:"blocks"`
+}
+
+func (n *SlackNotifier) message(delivery slackDelivery, checks []slackCheck) slackMessage {
+	summary := strconv.Itoa(len(checks)) + " newly failed check"
+	if len(checks) != 1 {
+		summary += "s"
+	}
+	summary += " on " + delivery.assetMrn
+
+	blocks := []slackBlock{{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: "*" + slackEscape(summary) + "*"},
+	}}
+	for i, check := range checks {
+		if i == n.maxChecks {
+			blocks = append(blocks, slackBlock{
+				Type: "section",
+				Text: &slackText{Type: "mrkdwn", Text: "… and " + strconv.Itoa(len(checks)-i) + " more"},
+			})
+			break
+		}
+
+		text := "*" + slackEscape(check.title) + "*"
+		if check.impact >= 0 {
+			text += "  (impact " + strconv.Itoa(int(check.impact)) + ")"
+		}
+		text += "\n`" + slackEscape(check.mrn) + "`"
+		if check.remediation != "" {
+			text += "\n>" + strings.ReplaceAll(slackEscape(check.remediation), "\n", "\n>")
+		}
+		if len(check.refs) != 0 {
+			links := make([]string, len(check.refs))
+			for j, ref := range check.refs {
+				title := ref.Title
+				if title == "" {
+					title = ref.Url
+				}
+				links[j] = "<" + slackEscape(ref.Url) + "|" + slackEscape(title) + ">"
+			}
+			text += "\n" + strings.Join(links, " · ")
+		}
+		blocks = append(blocks, slackBlock{Type: "divider"}, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: text},
+		})
+	}
+
+	return slackMessage{
+		Channel: delivery.route.Channel,
+		Text:    summary,
+		Blocks:  blocks,
+	}
+}
+
+// slackEscape escapes the control characters of Slack's mrkdwn
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func (n *SlackNotifier) send(delivery slackDelivery, checks []slackCheck) error {
+	msg := n.message(delivery, checks)
+	url := delivery.route.WebhookURL
+	if url == "" {
+		url = n.apiURL
+	} else {
+		// incoming webhooks are bound to their channel
+		msg.Channel = ""
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if delivery.route.WebhookURL == "" {
+		req.Header.Set("Authorization", "Bearer "+delivery.route.Token)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return errors.New("slack responded with " + resp.Status)
+	}
+	if delivery.route.WebhookURL != "" {
+		return nil
+	}
+
+	// the API responds with 200 and reports errors in the body
+	var res struct {
+		Ok    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return errors.Wrap(err, "failed to decode slack response")
+	}
+	if !res.Ok {
+		return errors.New("slack responded with error: " + res.Error)
+	}
+	return nil
+}
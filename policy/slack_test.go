@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+type testQueries map[string]*explorer.Mquery
+
+func (q testQueries) GetQuery(ctx context.Context, mrn string) (*explorer.Mquery, error) {
+	if query, ok := q[mrn]; ok {
+		return query, nil
+	}
+	return nil, errors.New("query '" + mrn + "' not found")
+}
+
+func TestSlackNotifier(t *testing.T) {
+	var lock sync.Mutex
+	var messages []slackMessage
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg slackMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		lock.Lock()
+		messages = append(messages, msg)
+		auth = append(auth, r.Header.Get("Authorization"))
+		lock.Unlock()
+		if r.URL.Path == "/api" {
+			w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer server.Close()
+
+	_, err := NewSlackNotifier([]SlackRoute{{Channel: "#security"}}, testQueries{}, nil)
+	assert.Error(t, err)
+
+	queries := testQueries{
+		"//check/ssh": {
+			Mrn:    "//check/ssh",
+			Title:  "Disable SSH root login",
+			Impact: &explorer.Impact{Value: 80},
+			Docs: &explorer.MqueryDocs{Remediation: &explorer.Remediation{Items: []*explorer.TypedDoc{
+				{Desc: "Set `PermitRootLogin no` in sshd_config"},
+			}}},
+		},
+		"//check/motd": {Mrn: "//check/motd", Title: "Set a MOTD", Impact: &explorer.Impact{Value: 10}},
+	}
+	n, err := NewSlackNotifier([]SlackRoute{
+		{WebhookURL: server.URL + "/hook", Policies: []string{"//policy"}},
+		{Token: "xoxb-token", Channel: "#security", MinImpact: 50},
+	}, queries, nil)
+	require.NoError(t, err)
+	n.apiURL = server.URL + "/api"
+
+	pass := &Score{Type: ScoreType_Result, Value: 100, ScoreCompletion: 100}
+	fail := &Score{Type: ScoreType_Result, Value: 0, ScoreCompletion: 100}
+	n.NotifyScoreChanges(context.Background(), []ScoreChange{
+		{AssetMrn: "//asset", QrId: "//check/ssh", Policies: []string{"//policy"}, Previous: pass, Current: fail},
+		{AssetMrn: "//asset", QrId: "//check/motd", Policies: []string{"//policy"}, Previous: pass, Current: fail},
+		// policies aren't checks
+		{AssetMrn: "//asset", QrId: "//policy", Previous: pass, Current: fail},
+		// new and recovered checks aren't reported
+		{AssetMrn: "//asset", QrId: "//check/new", Current: fail},
+		{AssetMrn: "//asset", QrId: "//check/fixed", Previous: fail, Current: pass},
+	})
+	n.Close()
+
+	require.Len(t, messages, 2)
+
+	hook := messages[0]
+	assert.Empty(t, hook.Channel)
+	assert.Empty(t, auth[0])
+	assert.Equal(t, "2 newly failed checks on //asset", hook.Text)
+	require.Len(t, hook.Blocks, 5)
+	assert.True(t, strings.HasPrefix(hook.Blocks[2].Text.Text, "*Disable SSH root login*  (impact 80)"))
+	assert.Contains(t, hook.Blocks[2].Text.Text, "\n>Set `PermitRootLogin no` in sshd_config")
+	assert.True(t, strings.HasPrefix(hook.Blocks[4].Text.Text, "*Set a MOTD*"))
+
+	api := messages[1]
+	assert.Equal(t, "#security", api.Channel)
+	assert.Equal(t, "Bearer xoxb-token", auth[1])
+	assert.Equal(t, "1 newly failed check on //asset", api.Text)
+}
+
+func TestSlackNotifier_MaxChecks(t *testing.T) {
+	n := &SlackNotifier{maxChecks: 1}
+	msg := n.message(slackDelivery{route: &SlackRoute{}, assetMrn: "//asset"}, []slackCheck{
+		{mrn: "//a", title: "a <b>", impact: -1},
+		{mrn: "//c", title: "c"},
+		{mrn: "//d", title: "d"},
+	})
+	require.Len(t, msg.Blocks, 4)
+	assert.Equal(t, "*a &lt;b&gt;*\n`//a`", msg.Blocks[2].Text.Text)
+	assert.Equal(t, "… and 2 more", msg.Blocks[3].Text.Text)
+}
@@ -0,0 +1,175 @@
+package policy
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// AssetLister is implemented by datalakes that can list all their assets
+type AssetLister interface {
+	ListAssetMrns(ctx context.Context) ([]string, error)
+}
+
+// SnapshotFormat is the file format of a snapshot
+type SnapshotFormat string
+
+// SnapshotCSV writes one row per line with a header, see snapshotColumns
+const SnapshotCSV SnapshotFormat = "csv"
+
+// SnapshotSelector selects the assets and policies of a snapshot
+type SnapshotSelector struct {
+	// AssetMrns defaults to all assets of the datalake, see AssetLister
+	AssetMrns []string
+	// PolicyMrns limits the snapshot to scores of these policies, including
+	// the scores of the policies themselves. Empty selects all scores.
+	PolicyMrns []string
+	// Bands map scores to severity bands, defaults to DefaultSeverityBands
+	Bands *SeverityBands
+}
+
+// SnapshotRow is one score of an asset in one policy. Scores that belong to
+// multiple policies have one row per policy.
+type SnapshotRow struct {
+	AssetMrn string
+	// PolicyMrn is empty for scores that don't belong to a policy, like the
+	// score of the asset itself
+	PolicyMrn string
+	QrId      string
+	Outcome   string
+	Value     uint32
+	Weight    uint32
+	// Completion is the data and score completion in percent
+	Completion uint32
+	// ValueModified is the time in seconds since the epoch at which the
+	// value of the score last changed
+	ValueModified int64
+	Message       string
+	// Band is the severity band of the score, empty for scores without one
+	Band SeverityBand
+}
+
+// Snapshot is a denormalized table of the scores of many assets, e.g. to
+// load them into BI tools
+type Snapshot struct {
+	Rows []SnapshotRow
+}
+
+// Snapshot collects the scores of all selected assets from the datalake
+func (s *LocalServices) Snapshot(ctx context.Context, selector SnapshotSelector) (*Snapshot, error) {
+	assetMrns := selector.AssetMrns
+	if len(assetMrns) == 0 {
+		lister, ok := s.DataLake.(AssetLister)
+		if !ok {
+			return nil, errors.New("the datalake can't list its assets, select the assets of the snapshot")
+		}
+		var err error
+		if assetMrns, err = lister.ListAssetMrns(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to list assets for snapshot")
+		}
+	}
+
+	policyFilter := map[string]struct{}{}
+	for _, mrn := range selector.PolicyMrns {
+		policyFilter[mrn] = struct{}{}
+	}
+
+	res := &Snapshot{}
+	for _, assetMrn := range assetMrns {
+		report, err := s.DataLake.GetReport(ctx, assetMrn, assetMrn)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get report of asset '"+assetMrn+"' for snapshot")
+		}
+
+		var policies map[string][]string
+		if resolvedPolicy, err := s.DataLake.GetResolvedPolicy(ctx, assetMrn); err == nil {
+			policies = scorePolicies(resolvedPolicy)
+		}
+		isPolicy := map[string]struct{}{}
+		for _, mrns := range policies {
+			for _, mrn := range mrns {
+				isPolicy[mrn] = struct{}{}
+			}
+		}
+
+		qrIDs := make([]string, 0, len(report.Scores))
+		for qrID := range report.Scores {
+			qrIDs = append(qrIDs, qrID)
+		}
+		sort.Strings(qrIDs)
+
+		for _, qrID := range qrIDs {
+			score := report.Scores[qrID]
+			scorePolicies := policies[qrID]
+			// policies are listed with their own score
+			if _, ok := isPolicy[qrID]; ok {
+				scorePolicies = append([]string{qrID}, scorePolicies...)
+			}
+			if len(scorePolicies) == 0 {
+				scorePolicies = []string{""}
+			}
+
+			for _, policyMrn := range scorePolicies {
+				if _, ok := policyFilter[policyMrn]; len(policyFilter) != 0 && !ok {
+					continue
+				}
+				res.Rows = append(res.Rows, SnapshotRow{
+					AssetMrn:      assetMrn,
+					PolicyMrn:     policyMrn,
+					QrId:          qrID,
+					Outcome:       ScoreOutcome(score),
+					Value:         score.Value,
+					Weight:        score.Weight,
+					Completion:    score.Completion(),
+					ValueModified: score.ValueModifiedTime,
+					Message:       score.MessageLine(),
+					Band:          selector.Bands.Band(score),
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+var snapshotColumns = []string{"asset_mrn", "policy_mrn", "qr_id", "outcome", "value", "weight", "completion", "value_modified", "message", "band"}
+
+// Write writes the snapshot in the given format
+func (s *Snapshot) Write(w io.Writer, format SnapshotFormat) error {
+	switch format {
+	case SnapshotCSV:
+		return s.writeCSV(w)
+	default:
+		return errors.New("unknown snapshot format '" + string(format) + "', supported is csv")
+	}
+}
+
+func (s *Snapshot) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(snapshotColumns); err != nil {
+		return err
+	}
+	for _, row := range s.Rows {
+		err := cw.Write([]string{
+			row.AssetMrn,
+			row.PolicyMrn,
+			row.QrId,
+			row.Outcome,
+			strconv.FormatUint(uint64(row.Value), 10),
+			strconv.FormatUint(uint64(row.Weight), 10),
+			strconv.FormatUint(uint64(row.Completion), 10),
+			strconv.FormatInt(row.ValueModified, 10),
+			row.Message,
+			string(row.Band),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,29 @@
+package policy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSnapshot() *Snapshot {
+	return &Snapshot{Rows: []SnapshotRow{
+		{AssetMrn: "//asset/1", PolicyMrn: "//policy/1", QrId: "//check/1", Outcome: OutcomePass, Value: 100, Weight: 1, Completion: 100, ValueModified: 1700000000, Band: BandPass},
+		{AssetMrn: "//asset/1", PolicyMrn: "//policy/1", QrId: "//check/2", Outcome: OutcomeFail, Value: 0, Weight: 1, Completion: 100, ValueModified: 1700000001, Message: "failed, \"really\"", Band: BandCritical},
+	}}
+}
+
+func TestSnapshot_WriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testSnapshot().Write(&buf, SnapshotCSV))
+	assert.Equal(t, "asset_mrn,policy_mrn,qr_id,outcome,value,weight,completion,value_modified,message,band\n"+
+		"//asset/1,//policy/1,//check/1,pass,100,1,100,1700000000,,pass\n"+
+		"//asset/1,//policy/1,//check/2,fail,0,1,100,1700000001,\"failed, \"\"really\"\"\",critical\n", buf.String())
+}
+
+func TestSnapshot_WriteUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, testSnapshot().Write(&buf, SnapshotFormat("parquet")))
+}
@@ -0,0 +1,169 @@
+package policy
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// SourceLocation is the position of a policy, query or property in its
+// source file
+type SourceLocation struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (l SourceLocation) String() string {
+	return l.File + ":" + strconv.Itoa(l.Line)
+}
+
+// SourceMap tracks where policies, queries and properties are defined. They
+// are indexed by the UID or MRN used in the source file. Once a bundle is
+// compiled, all its MRNs are added as well.
+type SourceMap map[string]SourceLocation
+
+// Lookup returns the location of the first ID that is found
+func (s SourceMap) Lookup(ids ...string) (SourceLocation, bool) {
+	for i := range ids {
+		if ids[i] == "" {
+			continue
+		}
+		if loc, ok := s[ids[i]]; ok {
+			return loc, true
+		}
+	}
+	return SourceLocation{}, false
+}
+
+// Wrap prefixes the error with the source location of the first ID that is
+// found, e.g. `examples/ssh.mql.yaml:87: ...`
+func (s SourceMap) Wrap(err error, ids ...string) error {
+	if err == nil {
+		return nil
+	}
+	loc, ok := s.Lookup(ids...)
+	if !ok {
+		return err
+	}
+	return errors.Wrap(err, loc.String())
+}
+
+// alias makes the location of an ID available under another ID, e.g. for
+// the MRN of a query which was defined by its UID
+func (s SourceMap) alias(id string, other string) {
+	if s == nil || id == "" || other == "" || id == other {
+		return
+	}
+	if _, ok := s[other]; ok {
+		return
+	}
+	if loc, ok := s[id]; ok {
+		s[other] = loc
+	}
+}
+
+func (s SourceMap) add(id string, loc SourceLocation) {
+	if id == "" {
+		return
+	}
+	// the first definition wins, later ones are references or duplicates
+	if _, ok := s[id]; !ok {
+		s[id] = loc
+	}
+}
+
+type sourceMapKey struct{}
+
+// WithSourceMap attaches the source map to the context. Bundle compilation
+// and policy resolution use it to add source locations to their errors.
+func WithSourceMap(ctx context.Context, sources SourceMap) context.Context {
+	if sources == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, sourceMapKey{}, sources)
+}
+
+// SourceMapFromContext returns the source map of the context, if any
+func SourceMapFromContext(ctx context.Context) SourceMap {
+	if ctx == nil {
+		return nil
+	}
+	sources, _ := ctx.Value(sourceMapKey{}).(SourceMap)
+	return sources
+}
+
+// sourcesFromYAML collects the locations of all policies, queries and
+// properties in a bundle file
+func sourcesFromYAML(file string, data []byte, sources SourceMap) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	bundle := root.Content[0]
+	// top-level queries and props are definitions, so they go first
+	for _, item := range yamlSequence(bundle, "queries") {
+		addYamlSource(file, item, sources)
+	}
+	for _, item := range yamlSequence(bundle, "props") {
+		addYamlSource(file, item, sources)
+	}
+
+	for _, policy := range yamlSequence(bundle, "policies") {
+		addYamlSource(file, policy, sources)
+		for _, prop := range yamlSequence(policy, "props") {
+			addYamlSource(file, prop, sources)
+		}
+		for _, group := range yamlSequence(policy, "groups") {
+			for _, item := range yamlSequence(group, "checks") {
+				addYamlSource(file, item, sources)
+			}
+			for _, item := range yamlSequence(group, "queries") {
+				addYamlSource(file, item, sources)
+			}
+		}
+	}
+
+	return nil
+}
+
+func addYamlSource(file string, node *yaml.Node, sources SourceMap) {
+	loc := SourceLocation{File: file, Line: node.Line, Column: node.Column}
+	sources.add(yamlValue(node, "uid"), loc)
+	sources.add(yamlValue(node, "mrn"), loc)
+}
+
+// yamlField returns the value of a field in a mapping node
+func yamlField(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func yamlValue(node *yaml.Node, key string) string {
+	field := yamlField(node, key)
+	if field == nil || field.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return field.Value
+}
+
+func yamlSequence(node *yaml.Node, key string) []*yaml.Node {
+	field := yamlField(node, key)
+	if field == nil || field.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return field.Content
+}
@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleFromPathsWithSources(t *testing.T) {
+	bundle, sources, err := BundleFromPathsWithSources("../examples/example.mql.yaml")
+	require.NoError(t, err)
+	require.NotNil(t, bundle)
+
+	loc, ok := sources.Lookup("example1")
+	require.True(t, ok)
+	assert.Equal(t, "../examples/example.mql.yaml:4", loc.String())
+
+	loc, ok = sources.Lookup("sshd-02")
+	require.True(t, ok)
+	assert.Equal(t, 28, loc.Line)
+
+	// shared is referenced in the policy, but defined in the queries
+	loc, ok = sources.Lookup("shared")
+	require.True(t, ok)
+	assert.Greater(t, loc.Line, 40)
+
+	t.Run("compile adds mrns", func(t *testing.T) {
+		_, err := bundle.Compile(WithSourceMap(context.Background(), sources), nil)
+		require.NoError(t, err)
+
+		_, ok := sources.Lookup(bundle.Policies[0].Mrn)
+		assert.True(t, ok)
+		for i := range bundle.Queries {
+			_, ok := sources.Lookup(bundle.Queries[i].Mrn)
+			assert.True(t, ok, bundle.Queries[i].Mrn)
+		}
+	})
+}
+
+func TestSourceMapWrap(t *testing.T) {
+	sources := SourceMap{"ssh": {File: "examples/ssh.mql.yaml", Line: 87}}
+
+	err := sources.Wrap(errors.New("failed"), "other", "ssh")
+	assert.EqualError(t, err, "examples/ssh.mql.yaml:87: failed")
+
+	err = sources.Wrap(errors.New("failed"), "other")
+	assert.EqualError(t, err, "failed")
+
+	var empty SourceMap
+	assert.EqualError(t, empty.Wrap(errors.New("failed"), "ssh"), "failed")
+	assert.Nil(t, sources.Wrap(nil, "ssh"))
+}
+
+func TestSourceMapFromContext(t *testing.T) {
+	assert.Nil(t, SourceMapFromContext(context.Background()))
+
+	sources := SourceMap{}
+	ctx := WithSourceMap(context.Background(), sources)
+	sources["a"] = SourceLocation{File: "a.mql.yaml", Line: 1}
+	_, ok := SourceMapFromContext(ctx).Lookup("a")
+	assert.True(t, ok)
+}
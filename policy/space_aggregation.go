@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"context"
+
+	"go.mondoo.com/cnquery/mrn"
+)
+
+// assetSpace returns the space that the asset is attached to, or an empty
+// string if it has none
+func (s *LocalServices) assetSpace(assetMrn string) string {
+	if s.EntityParents == nil {
+		return ""
+	}
+	parents := s.EntityParents(assetMrn)
+	for i := len(parents) - 1; i >= 0; i-- {
+		if x, _ := mrn.GetResource(parents[i], MRN_RESOURCE_SPACE); x != "" {
+			return parents[i]
+		}
+	}
+	return ""
+}
+
+// assetSpacePolicy returns the policy of the space that the asset is
+// attached to, see LocalServices.EntityParents. It is nil if the asset isn't
+// attached to a space or the space has no policy.
+func (s *LocalServices) assetSpacePolicy(ctx context.Context, assetMrn string) (*Policy, error) {
+	spaceMrn := s.assetSpace(assetMrn)
+	if spaceMrn == "" {
+		return nil, nil
+	}
+
+	exists, err := s.DataLake.PolicyExists(ctx, spaceMrn)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return s.DataLake.GetValidatedPolicy(ctx, spaceMrn)
+}
+
+// aggregatesSpacePolicy returns true if the asset's policy only activates
+// the policy of its space. Such assets resolve to the same policy as all
+// other assets of the space with the same asset filters.
+func aggregatesSpacePolicy(assetPolicy *Policy, spaceMrn string) bool {
+	if assetPolicy == nil || len(assetPolicy.Props) != 0 {
+		return false
+	}
+
+	found := false
+	for _, group := range assetPolicy.Groups {
+		if len(group.Checks) != 0 || len(group.Queries) != 0 {
+			return false
+		}
+		if group.Filters != nil && len(group.Filters.Items) != 0 {
+			return false
+		}
+		for _, ref := range group.Policies {
+			if ref.Mrn != spaceMrn || found {
+				return false
+			}
+			if ref.Action != PolicyRef_UNSPECIFIED && ref.Action != PolicyRef_ACTIVATE {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// spaceFiltersChecksum is the cache key of resolved policies that are
+// shared by all assets of a space, together with the space policy's graph
+// execution checksum
+func spaceFiltersChecksum(space *Policy, assetFiltersChecksum string) string {
+	return checksumStrings("space", space.GraphExecutionChecksum, assetFiltersChecksum)
+}
+
+// matchSpacePolicy sets the space policy of the resolution, if the asset
+// only aggregates its space's policy
+func (s *LocalServices) matchSpacePolicy(ctx context.Context, in *resolveInput) error {
+	if x, _ := mrn.GetResource(in.policyMrn, MRN_RESOURCE_ASSET); x == "" {
+		return nil
+	}
+
+	space, err := s.assetSpacePolicy(ctx, in.policyMrn)
+	if err != nil {
+		return err
+	}
+	if space == nil || space.GraphExecutionChecksum == "" || !aggregatesSpacePolicy(in.policyObj, space.Mrn) {
+		return nil
+	}
+
+	in.space = space
+	in.spaceFiltersChecksum = spaceFiltersChecksum(space, in.assetFiltersChecksum)
+	return nil
+}
@@ -0,0 +1,43 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnquery/explorer"
+)
+
+func TestAggregatesSpacePolicy(t *testing.T) {
+	space := "//captain.api.mondoo.app/spaces/test"
+	spaceRef := func(action PolicyRef_Action) *Policy {
+		return &Policy{Groups: []*PolicyGroup{{Policies: []*PolicyRef{{Mrn: space, Action: action}}}}}
+	}
+
+	assert.True(t, aggregatesSpacePolicy(spaceRef(PolicyRef_UNSPECIFIED), space))
+	assert.True(t, aggregatesSpacePolicy(spaceRef(PolicyRef_ACTIVATE), space))
+	assert.False(t, aggregatesSpacePolicy(spaceRef(PolicyRef_DEACTIVATE), space))
+	assert.False(t, aggregatesSpacePolicy(spaceRef(PolicyRef_ACTIVATE), "//captain.api.mondoo.app/spaces/other"))
+	assert.False(t, aggregatesSpacePolicy(&Policy{}, space))
+	assert.False(t, aggregatesSpacePolicy(nil, space))
+
+	withCheck := spaceRef(PolicyRef_ACTIVATE)
+	withCheck.Groups = append(withCheck.Groups, &PolicyGroup{Checks: []*explorer.Mquery{{Mrn: "//check"}}})
+	assert.False(t, aggregatesSpacePolicy(withCheck, space))
+
+	withPolicy := spaceRef(PolicyRef_ACTIVATE)
+	withPolicy.Groups[0].Policies = append(withPolicy.Groups[0].Policies, &PolicyRef{Mrn: "//policy"})
+	assert.False(t, aggregatesSpacePolicy(withPolicy, space))
+
+	withProps := spaceRef(PolicyRef_ACTIVATE)
+	withProps.Props = []*explorer.Property{{Mrn: "//prop"}}
+	assert.False(t, aggregatesSpacePolicy(withProps, space))
+}
+
+func TestSpaceFiltersChecksum(t *testing.T) {
+	a := &Policy{GraphExecutionChecksum: "a"}
+	b := &Policy{GraphExecutionChecksum: "b"}
+
+	assert.Equal(t, spaceFiltersChecksum(a, "filters"), spaceFiltersChecksum(a, "filters"))
+	assert.NotEqual(t, spaceFiltersChecksum(a, "filters"), spaceFiltersChecksum(b, "filters"))
+	assert.NotEqual(t, spaceFiltersChecksum(a, "filters"), spaceFiltersChecksum(a, "other"))
+}
@@ -0,0 +1,271 @@
+package policy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DefaultUploadMinBackoff  = 5 * time.Second
+	DefaultUploadMaxBackoff  = 5 * time.Minute
+	DefaultUploadBatchSize   = 20
+	DefaultUploadMaxAttempts = 10
+)
+
+// QueuedUpload are results that couldn't be sent upstream yet
+type QueuedUpload struct {
+	ID int64
+	// SpaceMrn is the upstream space of the results, only the credentials of
+	// this space may send them
+	SpaceMrn string
+	Req      *StoreResultsReq
+	Queued   time.Time
+	// Attempts counts the uploads that upstream rejected, transient failures
+	// while it is unreachable don't count
+	Attempts  int
+	LastError string
+}
+
+// UploadQueue is implemented by datalakes that can keep results which
+// couldn't be sent upstream, so that they are sent once upstream is
+// reachable again, see UploadSyncer. Uploads are queued per upstream space.
+type UploadQueue interface {
+	// EnqueueUpload adds results of the space to the end of the queue
+	EnqueueUpload(ctx context.Context, spaceMrn string, req *StoreResultsReq) error
+	// PendingUploads returns up to limit uploads of the space whose ID is
+	// greater than afterID, oldest first
+	PendingUploads(ctx context.Context, spaceMrn string, afterID int64, limit int) ([]*QueuedUpload, error)
+	// CompleteUpload removes an upload from the queue
+	CompleteUpload(ctx context.Context, id int64) error
+	// FailUpload counts a rejected attempt of an upload
+	FailUpload(ctx context.Context, id int64, reason string) error
+}
+
+// OfflineQueue queues the results of one upstream space while upstream is
+// unreachable, see LocalServices.OfflineQueue
+type OfflineQueue struct {
+	Queue UploadQueue
+	// SpaceMrn is the space whose credentials send the queued results
+	SpaceMrn string
+	// Syncer sends the queued results of the space and is notified of every
+	// queued upload (optional)
+	Syncer *UploadSyncer
+}
+
+// queueUpload keeps results that couldn't be sent upstream in the offline
+// queue. It returns false if there is no queue or it failed.
+func (s *LocalServices) queueUpload(ctx context.Context, req *StoreResultsReq) bool {
+	if s.OfflineQueue == nil || s.OfflineQueue.Queue == nil {
+		return false
+	}
+	if err := s.OfflineQueue.Queue.EnqueueUpload(ctx, s.OfflineQueue.SpaceMrn, req); err != nil {
+		log.Warn().Err(err).Str("asset", req.AssetMrn).Msg("upstream> failed to queue results")
+		return false
+	}
+	log.Debug().Str("asset", req.AssetMrn).Str("space", s.OfflineQueue.SpaceMrn).Msg("upstream> queued results until upstream is reachable")
+	s.OfflineQueue.Syncer.Notify()
+	return true
+}
+
+// UploadSyncOptions configure an UploadSyncer
+type UploadSyncOptions struct {
+	// MinBackoff is the wait after the first failed upload, it doubles with
+	// every consecutive failure up to MaxBackoff. MaxBackoff is also the
+	// interval in which an empty queue is checked for new uploads.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// BatchSize is the number of uploads that are read from the queue at once
+	BatchSize int
+	// MaxAttempts is the number of times an upload may be rejected by
+	// upstream before it is dropped
+	MaxAttempts int
+}
+
+func (o UploadSyncOptions) withDefaults() UploadSyncOptions {
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = DefaultUploadMinBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultUploadMaxBackoff
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = o.MinBackoff
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultUploadBatchSize
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultUploadMaxAttempts
+	}
+	return o
+}
+
+// backoff returns how long to wait after the given number of consecutive
+// failures
+func (o UploadSyncOptions) backoff(failures int) time.Duration {
+	res := o.MinBackoff
+	for i := 1; i < failures && res < o.MaxBackoff; i++ {
+		res *= 2
+	}
+	if res > o.MaxBackoff {
+		res = o.MaxBackoff
+	}
+	return res
+}
+
+// UploadSyncer sends the queued results of one space upstream in the
+// background, with the credentials of that space. While upstream is
+// unreachable, it backs off exponentially, so that intermittently-connected
+// agents don't lose results and don't flood upstream once it is back.
+type UploadSyncer struct {
+	queue    UploadQueue
+	spaceMrn string
+	upstream PolicyResolver
+	opts     UploadSyncOptions
+
+	once     sync.Once
+	wg       sync.WaitGroup
+	notify   chan struct{}
+	done     chan struct{}
+	lock     sync.Mutex
+	failures int
+}
+
+// NewUploadSyncer creates a syncer that sends the uploads of the space in
+// the queue to upstream, which must use the credentials of the space. It
+// doesn't run until it is started.
+func NewUploadSyncer(queue UploadQueue, spaceMrn string, upstream PolicyResolver, opts UploadSyncOptions) *UploadSyncer {
+	return &UploadSyncer{
+		queue:    queue,
+		spaceMrn: spaceMrn,
+		upstream: upstream,
+		opts:     opts.withDefaults(),
+		notify:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the syncer in the background until it is closed
+func (s *UploadSyncer) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Notify makes the syncer check the queue, e.g. after new uploads were
+// queued or upstream became reachable again. While the syncer backs off
+// from failed uploads, it checks the queue once the backoff is over.
+func (s *UploadSyncer) Notify() {
+	if s == nil {
+		return
+	}
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops the syncer. Uploads that weren't sent stay in the queue.
+func (s *UploadSyncer) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+	})
+}
+
+func (s *UploadSyncer) run() {
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.done
+		cancel()
+	}()
+
+	for {
+		_, err := s.Flush(ctx)
+
+		wait := s.opts.MaxBackoff
+		if err != nil {
+			s.lock.Lock()
+			wait = s.opts.backoff(s.failures)
+			s.lock.Unlock()
+			log.Debug().Err(err).Str("space", s.spaceMrn).Dur("backoff", wait).Msg("upstream> failed to send queued results")
+		}
+
+		timer := time.NewTimer(wait)
+		notify := s.notify
+		if err != nil {
+			// results are queued while upstream is unreachable, they must
+			// not cut the backoff short
+			notify = nil
+		}
+		select {
+		case <-s.done:
+			timer.Stop()
+			return
+		case <-notify:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// Flush sends queued uploads until the queue is empty or upstream is
+// unreachable, and returns how many were sent. Uploads that upstream rejects
+// are retried later and dropped after too many attempts.
+func (s *UploadSyncer) Flush(ctx context.Context) (int, error) {
+	sent := 0
+	var cursor int64
+	for {
+		uploads, err := s.queue.PendingUploads(ctx, s.spaceMrn, cursor, s.opts.BatchSize)
+		if err != nil {
+			return sent, err
+		}
+		if len(uploads) == 0 {
+			return sent, nil
+		}
+
+		for _, upload := range uploads {
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+			cursor = upload.ID
+
+			_, err := s.upstream.StoreResults(ctx, upload.Req)
+			if err != nil && IsTransientUpstreamError(err) {
+				s.lock.Lock()
+				s.failures++
+				s.lock.Unlock()
+				return sent, err
+			}
+
+			s.lock.Lock()
+			s.failures = 0
+			s.lock.Unlock()
+
+			if err == nil {
+				if err := s.queue.CompleteUpload(ctx, upload.ID); err != nil {
+					return sent, err
+				}
+				sent++
+				continue
+			}
+
+			if upload.Attempts+1 >= s.opts.MaxAttempts {
+				log.Warn().Err(err).Str("asset", upload.Req.AssetMrn).Int("attempts", upload.Attempts+1).Msg("upstream> dropping queued results that were rejected too often")
+				if err := s.queue.CompleteUpload(ctx, upload.ID); err != nil {
+					return sent, err
+				}
+				continue
+			}
+			// rejected uploads are retried with the next flush
+			if err := s.queue.FailUpload(ctx, upload.ID, err.Error()); err != nil {
+				return sent, err
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testUploadQueue struct {
+	uploads []*QueuedUpload
+	seq     int64
+}
+
+func (q *testUploadQueue) EnqueueUpload(ctx context.Context, spaceMrn string, req *StoreResultsReq) error {
+	q.seq++
+	q.uploads = append(q.uploads, &QueuedUpload{ID: q.seq, SpaceMrn: spaceMrn, Req: req})
+	return nil
+}
+
+func (q *testUploadQueue) PendingUploads(ctx context.Context, spaceMrn string, afterID int64, limit int) ([]*QueuedUpload, error) {
+	res := []*QueuedUpload{}
+	for _, upload := range q.uploads {
+		if upload.SpaceMrn == spaceMrn && upload.ID > afterID && len(res) < limit {
+			res = append(res, upload)
+		}
+	}
+	return res, nil
+}
+
+func (q *testUploadQueue) CompleteUpload(ctx context.Context, id int64) error {
+	for i := range q.uploads {
+		if q.uploads[i].ID == id {
+			q.uploads = append(q.uploads[:i], q.uploads[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (q *testUploadQueue) FailUpload(ctx context.Context, id int64, reason string) error {
+	for _, upload := range q.uploads {
+		if upload.ID == id {
+			upload.Attempts++
+			upload.LastError = reason
+		}
+	}
+	return nil
+}
+
+// testUploadResolver only implements StoreResults
+type testUploadResolver struct {
+	PolicyResolver
+	errs map[string]error
+	sent []string
+}
+
+func (r *testUploadResolver) StoreResults(ctx context.Context, req *StoreResultsReq) (*Empty, error) {
+	if err := r.errs[req.AssetMrn]; err != nil {
+		return nil, err
+	}
+	r.sent = append(r.sent, req.AssetMrn)
+	return globalEmpty, nil
+}
+
+const (
+	testSpaceA = "//captain.api.mondoo.app/spaces/a"
+	testSpaceB = "//captain.api.mondoo.app/spaces/b"
+)
+
+func TestUploadSyncer(t *testing.T) {
+	enqueue := func(q *testUploadQueue, assets ...string) {
+		for _, asset := range assets {
+			require.NoError(t, q.EnqueueUpload(context.Background(), testSpaceA, &StoreResultsReq{AssetMrn: asset}))
+		}
+	}
+
+	t.Run("sends all uploads in order", func(t *testing.T) {
+		queue := &testUploadQueue{}
+		enqueue(queue, "//a", "//b", "//c")
+		resolver := &testUploadResolver{}
+		syncer := NewUploadSyncer(queue, testSpaceA, resolver, UploadSyncOptions{BatchSize: 2})
+
+		sent, err := syncer.Flush(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, sent)
+		assert.Equal(t, []string{"//a", "//b", "//c"}, resolver.sent)
+		assert.Empty(t, queue.uploads)
+	})
+
+	t.Run("stops while upstream is unreachable", func(t *testing.T) {
+		queue := &testUploadQueue{}
+		enqueue(queue, "//a", "//b")
+		resolver := &testUploadResolver{errs: map[string]error{"//a": io.ErrUnexpectedEOF}}
+		syncer := NewUploadSyncer(queue, testSpaceA, resolver, UploadSyncOptions{})
+
+		sent, err := syncer.Flush(context.Background())
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, 0, sent)
+		assert.Len(t, queue.uploads, 2)
+		assert.Equal(t, 0, queue.uploads[0].Attempts)
+	})
+
+	t.Run("retries and drops rejected uploads", func(t *testing.T) {
+		queue := &testUploadQueue{}
+		enqueue(queue, "//a", "//b")
+		resolver := &testUploadResolver{errs: map[string]error{"//a": errors.New("invalid asset")}}
+		syncer := NewUploadSyncer(queue, testSpaceA, resolver, UploadSyncOptions{MaxAttempts: 2})
+
+		sent, err := syncer.Flush(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, queue.uploads, 1)
+		assert.Equal(t, 1, queue.uploads[0].Attempts)
+		assert.Equal(t, "invalid asset", queue.uploads[0].LastError)
+
+		sent, err = syncer.Flush(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Empty(t, queue.uploads)
+	})
+
+	t.Run("only sends the uploads of its space", func(t *testing.T) {
+		queue := &testUploadQueue{}
+		ctx := context.Background()
+		require.NoError(t, queue.EnqueueUpload(ctx, testSpaceA, &StoreResultsReq{AssetMrn: "//a"}))
+		require.NoError(t, queue.EnqueueUpload(ctx, testSpaceB, &StoreResultsReq{AssetMrn: "//b"}))
+		require.NoError(t, queue.EnqueueUpload(ctx, testSpaceA, &StoreResultsReq{AssetMrn: "//c"}))
+
+		resolverA := &testUploadResolver{}
+		sent, err := NewUploadSyncer(queue, testSpaceA, resolverA, UploadSyncOptions{BatchSize: 1}).Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Equal(t, []string{"//a", "//c"}, resolverA.sent)
+		require.Len(t, queue.uploads, 1)
+		assert.Equal(t, testSpaceB, queue.uploads[0].SpaceMrn)
+
+		resolverB := &testUploadResolver{}
+		sent, err = NewUploadSyncer(queue, testSpaceB, resolverB, UploadSyncOptions{}).Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.Equal(t, []string{"//b"}, resolverB.sent)
+		assert.Empty(t, queue.uploads)
+	})
+}
+
+func TestUploadSyncBackoff(t *testing.T) {
+	opts := UploadSyncOptions{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}.withDefaults()
+	assert.Equal(t, time.Second, opts.backoff(1))
+	assert.Equal(t, 2*time.Second, opts.backoff(2))
+	assert.Equal(t, 8*time.Second, opts.backoff(4))
+	assert.Equal(t, 10*time.Second, opts.backoff(5))
+	assert.Equal(t, 10*time.Second, opts.backoff(100))
+}
+
+func TestQueueUpload(t *testing.T) {
+	queue := &testUploadQueue{}
+	syncer := NewUploadSyncer(queue, testSpaceA, &testUploadResolver{}, UploadSyncOptions{})
+	s := &LocalServices{OfflineQueue: &OfflineQueue{Queue: queue, SpaceMrn: testSpaceA, Syncer: syncer}}
+	assert.True(t, s.queueUpload(context.Background(), &StoreResultsReq{AssetMrn: "//a"}))
+	require.Len(t, queue.uploads, 1)
+	assert.Equal(t, "//a", queue.uploads[0].Req.AssetMrn)
+	assert.Equal(t, testSpaceA, queue.uploads[0].SpaceMrn)
+	// the syncer checks the queue right away
+	assert.Len(t, syncer.notify, 1)
+
+	// the syncer is optional
+	s.OfflineQueue.Syncer = nil
+	assert.True(t, s.queueUpload(context.Background(), &StoreResultsReq{AssetMrn: "//b"}))
+	assert.Len(t, queue.uploads, 2)
+
+	s.OfflineQueue = nil
+	assert.False(t, s.queueUpload(context.Background(), &StoreResultsReq{AssetMrn: "//c"}))
+}
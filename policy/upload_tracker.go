@@ -0,0 +1,132 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"go.mondoo.com/cnquery/llx"
+	"google.golang.org/protobuf/proto"
+)
+
+// UploadStats counts the datapoints of an asset that were sent upstream and
+// those that were skipped because upstream already had their value
+type UploadStats struct {
+	Sent    int
+	Skipped int
+}
+
+// UploadTracker remembers the hashes of the datapoints that were last sent
+// upstream for every asset, so that unchanged values are not sent again.
+// It is safe for concurrent use.
+type UploadTracker struct {
+	lock   sync.Mutex
+	hashes map[string]map[string]string
+	stats  map[string]UploadStats
+}
+
+// NewUploadTracker creates a tracker that hasn't seen any uploads yet
+func NewUploadTracker() *UploadTracker {
+	return &UploadTracker{
+		hashes: map[string]map[string]string{},
+		stats:  map[string]UploadStats{},
+	}
+}
+
+func hashResult(res *llx.Result) string {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(res)
+	if err != nil {
+		// unhashable values are always sent
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// changedData returns the datapoints whose value differs from the last
+// upload, together with their hashes. The hashes only become the baseline
+// once they are committed after a successful upload.
+func (t *UploadTracker) changedData(assetMrn string, data map[string]*llx.Result) (map[string]*llx.Result, map[string]string) {
+	if t == nil || len(data) == 0 {
+		return data, nil
+	}
+
+	res := make(map[string]*llx.Result, len(data))
+	hashes := make(map[string]string, len(data))
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	uploaded := t.hashes[assetMrn]
+	stats := t.stats[assetMrn]
+	for id, datum := range data {
+		hash := hashResult(datum)
+		if hash != "" && uploaded[id] == hash {
+			stats.Skipped++
+			continue
+		}
+		res[id] = datum
+		if hash != "" {
+			hashes[id] = hash
+		}
+	}
+	t.stats[assetMrn] = stats
+
+	return res, hashes
+}
+
+// commit records the hashes of datapoints that were uploaded successfully
+func (t *UploadTracker) commit(assetMrn string, hashes map[string]string, sent int) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	uploaded, ok := t.hashes[assetMrn]
+	if !ok {
+		uploaded = make(map[string]string, len(hashes))
+		t.hashes[assetMrn] = uploaded
+	}
+	for id, hash := range hashes {
+		uploaded[id] = hash
+	}
+
+	stats := t.stats[assetMrn]
+	stats.Sent += sent
+	t.stats[assetMrn] = stats
+}
+
+// Stats returns the counters of an asset since its stats were last reset
+func (t *UploadTracker) Stats(assetMrn string) UploadStats {
+	if t == nil {
+		return UploadStats{}
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.stats[assetMrn]
+}
+
+// ResetStats sets the counters of an asset to zero, e.g. when a new scan of
+// the asset starts. The hashes of uploaded datapoints are kept.
+func (t *UploadTracker) ResetStats(assetMrn string) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.stats, assetMrn)
+}
+
+// Forget drops everything that is known about an asset, so that all of its
+// datapoints are sent again, e.g. after upstream lost or purged them
+func (t *UploadTracker) Forget(assetMrn string) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.hashes, assetMrn)
+	delete(t.stats, assetMrn)
+}
@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mondoo.com/cnquery/llx"
+)
+
+func TestUploadTracker(t *testing.T) {
+	tracker := NewUploadTracker()
+	asset := "//assets/1"
+	data := map[string]*llx.Result{
+		"a": {CodeId: "a", Data: llx.IntData(1)},
+		"b": {CodeId: "b", Data: llx.StringData("b")},
+	}
+
+	changed, hashes := tracker.changedData(asset, data)
+	assert.Len(t, changed, 2)
+
+	// nothing is skipped until the upload was committed
+	changed, _ = tracker.changedData(asset, data)
+	assert.Len(t, changed, 2)
+	tracker.ResetStats(asset)
+
+	tracker.commit(asset, hashes, len(changed))
+	assert.Equal(t, UploadStats{Sent: 2}, tracker.Stats(asset))
+
+	data["b"] = &llx.Result{CodeId: "b", Data: llx.StringData("changed")}
+	changed, hashes = tracker.changedData(asset, data)
+	assert.Len(t, changed, 1)
+	assert.Contains(t, changed, "b")
+	tracker.commit(asset, hashes, len(changed))
+	assert.Equal(t, UploadStats{Sent: 3, Skipped: 1}, tracker.Stats(asset))
+
+	// other assets are tracked separately
+	changed, _ = tracker.changedData("//assets/2", data)
+	assert.Len(t, changed, 2)
+
+	tracker.Forget(asset)
+	assert.Equal(t, UploadStats{}, tracker.Stats(asset))
+	changed, _ = tracker.changedData(asset, data)
+	assert.Len(t, changed, 2)
+
+	var disabled *UploadTracker
+	changed, _ = disabled.changedData(asset, data)
+	assert.Len(t, changed, 2)
+	assert.Equal(t, UploadStats{}, disabled.Stats(asset))
+}
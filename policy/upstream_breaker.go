@@ -0,0 +1,307 @@
+package policy
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DefaultUpstreamFailureThreshold = 3
+	DefaultUpstreamProbeInterval    = 30 * time.Second
+	upstreamProbeTimeout            = 10 * time.Second
+)
+
+// CircuitState is the state of the upstream circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed means upstream is healthy and all calls go through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means upstream failed repeatedly, calls are handled locally
+	// where possible while a background probe checks for recovery
+	CircuitOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// UpstreamHealth is a snapshot of the upstream circuit breaker
+type UpstreamHealth struct {
+	State               CircuitState
+	ConsecutiveFailures int
+	LastError           error
+	Since               time.Time
+}
+
+// UpstreamProbe checks if upstream is reachable again
+type UpstreamProbe func(ctx context.Context) error
+
+// UpstreamBreaker is a circuit breaker for calls to upstream. After a number
+// of consecutive transient failures it opens, which makes LocalServices
+// fall back to local (incognito-style) behavior where possible. While open,
+// upstream is probed in the background and the breaker closes once the probe
+// succeeds.
+type UpstreamBreaker struct {
+	threshold     int
+	probeInterval time.Duration
+	nowProvider   func() time.Time
+
+	lock      sync.Mutex
+	state     CircuitState
+	failures  int
+	lastError error
+	since     time.Time
+	probing   bool
+	stopped   bool
+	stop      chan struct{}
+}
+
+// NewUpstreamBreaker creates a new circuit breaker that opens after
+// threshold consecutive failures and probes upstream every probeInterval
+func NewUpstreamBreaker(threshold int, probeInterval time.Duration) *UpstreamBreaker {
+	if threshold <= 0 {
+		threshold = DefaultUpstreamFailureThreshold
+	}
+	if probeInterval <= 0 {
+		probeInterval = DefaultUpstreamProbeInterval
+	}
+
+	return &UpstreamBreaker{
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		nowProvider:   time.Now,
+		state:         CircuitClosed,
+		since:         time.Now(),
+		stop:          make(chan struct{}),
+	}
+}
+
+// Allow returns true if calls to upstream should be attempted
+func (b *UpstreamBreaker) Allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.state == CircuitClosed
+}
+
+// Success records a successful call to upstream
+func (b *UpstreamBreaker) Success() {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.close()
+}
+
+// Failure records a failed call to upstream. Only transient errors (network
+// errors, timeouts) count towards the threshold. Once the breaker opens, the
+// given probe is run in the background until upstream recovers. Failures
+// after Close are ignored, nothing would probe for recovery anymore.
+func (b *UpstreamBreaker) Failure(err error, probe UpstreamProbe) {
+	if b == nil || !IsTransientUpstreamError(err) {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.stopped {
+		return
+	}
+
+	b.failures++
+	b.lastError = err
+	if b.state == CircuitOpen || b.failures < b.threshold {
+		return
+	}
+
+	log.Warn().Err(err).Int("failures", b.failures).Msg("upstream> too many failures, continue without upstream")
+	b.state = CircuitOpen
+	b.since = b.nowProvider()
+
+	if !b.probing && probe != nil {
+		b.probing = true
+		go b.probeLoop(probe)
+	}
+}
+
+// Health returns the current state of the breaker
+func (b *UpstreamBreaker) Health() UpstreamHealth {
+	if b == nil {
+		return UpstreamHealth{State: CircuitClosed}
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return UpstreamHealth{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+		Since:               b.since,
+	}
+}
+
+// Close stops any background probing. Afterwards the breaker doesn't open
+// anymore and an open breaker is closed, so calls to upstream are attempted.
+func (b *UpstreamBreaker) Close() {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.stopped {
+		return
+	}
+	b.stopped = true
+	close(b.stop)
+	b.close()
+}
+
+// close resets the breaker, the caller must hold the lock
+func (b *UpstreamBreaker) close() {
+	if b.state == CircuitOpen {
+		log.Info().Msg("upstream> upstream is reachable again")
+		b.since = b.nowProvider()
+	}
+	b.state = CircuitClosed
+	b.failures = 0
+	b.lastError = nil
+}
+
+func (b *UpstreamBreaker) probeLoop(probe UpstreamProbe) {
+	ticker := time.NewTicker(b.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			b.lock.Lock()
+			b.probing = false
+			b.lock.Unlock()
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), upstreamProbeTimeout)
+		err := probe(ctx)
+		cancel()
+
+		b.lock.Lock()
+		if b.stopped {
+			b.probing = false
+			b.lock.Unlock()
+			return
+		}
+		if err == nil {
+			b.close()
+			b.probing = false
+			b.lock.Unlock()
+			return
+		}
+		// upstream may answer with an error while it is still unhealthy, only
+		// a successful probe closes the breaker
+		b.lastError = err
+		b.lock.Unlock()
+
+		log.Debug().Err(err).Msg("upstream> probe failed")
+	}
+}
+
+// IsTransientUpstreamError returns true for errors that indicate that
+// upstream is not reachable, as opposed to errors that upstream returned
+func IsTransientUpstreamError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// UpstreamBreakers keeps one circuit breaker per space and endpoint. Spaces
+// use different credentials, so failures of one space must not cut off the
+// scans of all others.
+type UpstreamBreakers struct {
+	threshold     int
+	probeInterval time.Duration
+
+	lock     sync.Mutex
+	breakers map[string]*UpstreamBreaker
+}
+
+// NewUpstreamBreakers creates circuit breakers that open after threshold
+// consecutive failures and probe upstream every probeInterval
+func NewUpstreamBreakers(threshold int, probeInterval time.Duration) *UpstreamBreakers {
+	return &UpstreamBreakers{
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		breakers:      map[string]*UpstreamBreaker{},
+	}
+}
+
+// Get returns the breaker of the space at the endpoint, it is created on
+// first use
+func (b *UpstreamBreakers) Get(endpoint string, spaceMrn string) *UpstreamBreaker {
+	if b == nil {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	key := endpoint + "\x00" + spaceMrn
+	res, ok := b.breakers[key]
+	if !ok {
+		res = NewUpstreamBreaker(b.threshold, b.probeInterval)
+		b.breakers[key] = res
+	}
+	return res
+}
+
+// Health returns the state of the breaker of the space at the endpoint.
+// Spaces without any upstream calls yet are healthy.
+func (b *UpstreamBreakers) Health(endpoint string, spaceMrn string) UpstreamHealth {
+	if b == nil {
+		return UpstreamHealth{State: CircuitClosed}
+	}
+
+	b.lock.Lock()
+	breaker := b.breakers[endpoint+"\x00"+spaceMrn]
+	b.lock.Unlock()
+	return breaker.Health()
+}
+
+// Close stops all breakers
+func (b *UpstreamBreakers) Close() {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, breaker := range b.breakers {
+		breaker.Close()
+	}
+}
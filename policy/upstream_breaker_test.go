@@ -0,0 +1,104 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamBreaker(t *testing.T) {
+	t.Run("opens after threshold", func(t *testing.T) {
+		b := NewUpstreamBreaker(2, time.Hour)
+		defer b.Close()
+
+		b.Failure(io.ErrUnexpectedEOF, nil)
+		assert.True(t, b.Allow())
+		b.Failure(io.ErrUnexpectedEOF, nil)
+		assert.False(t, b.Allow())
+		assert.Equal(t, CircuitOpen, b.Health().State)
+	})
+
+	t.Run("ignores non-transient errors", func(t *testing.T) {
+		b := NewUpstreamBreaker(1, time.Hour)
+		defer b.Close()
+
+		b.Failure(errors.New("policy not found"), nil)
+		assert.True(t, b.Allow())
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		b := NewUpstreamBreaker(2, time.Hour)
+		defer b.Close()
+
+		b.Failure(io.ErrUnexpectedEOF, nil)
+		b.Success()
+		b.Failure(io.ErrUnexpectedEOF, nil)
+		assert.True(t, b.Allow())
+	})
+
+	t.Run("probe closes the breaker", func(t *testing.T) {
+		b := NewUpstreamBreaker(1, time.Millisecond)
+		defer b.Close()
+
+		b.Failure(context.DeadlineExceeded, func(ctx context.Context) error {
+			return nil
+		})
+		assert.Eventually(t, b.Allow, time.Second, time.Millisecond)
+	})
+
+	t.Run("only a successful probe closes the breaker", func(t *testing.T) {
+		b := NewUpstreamBreaker(1, time.Millisecond)
+		defer b.Close()
+
+		probes := make(chan struct{}, 10)
+		b.Failure(io.ErrUnexpectedEOF, func(ctx context.Context) error {
+			probes <- struct{}{}
+			return errors.New("service unavailable")
+		})
+		for i := 0; i < 3; i++ {
+			<-probes
+		}
+		assert.False(t, b.Allow())
+		assert.EqualError(t, b.Health().LastError, "service unavailable")
+	})
+
+	t.Run("closed breakers don't open anymore", func(t *testing.T) {
+		b := NewUpstreamBreaker(1, time.Hour)
+		b.Failure(io.ErrUnexpectedEOF, func(ctx context.Context) error { return nil })
+		assert.False(t, b.Allow())
+
+		b.Close()
+		assert.True(t, b.Allow())
+		b.Failure(io.ErrUnexpectedEOF, func(ctx context.Context) error { return nil })
+		assert.True(t, b.Allow())
+		// closing twice is fine
+		b.Close()
+	})
+
+	t.Run("nil breaker allows everything", func(t *testing.T) {
+		var b *UpstreamBreaker
+		assert.True(t, b.Allow())
+		assert.Equal(t, CircuitClosed, b.Health().State)
+	})
+}
+
+func TestUpstreamBreakers(t *testing.T) {
+	breakers := NewUpstreamBreakers(1, time.Hour)
+	defer breakers.Close()
+
+	a := breakers.Get("https://api.example.com", "//spaces/a")
+	assert.Same(t, a, breakers.Get("https://api.example.com", "//spaces/a"))
+
+	a.Failure(io.ErrUnexpectedEOF, nil)
+	assert.False(t, a.Allow())
+	assert.Equal(t, CircuitOpen, breakers.Health("https://api.example.com", "//spaces/a").State)
+
+	// other spaces and endpoints are not affected
+	assert.True(t, breakers.Get("https://api.example.com", "//spaces/b").Allow())
+	assert.True(t, breakers.Get("https://eu.example.com", "//spaces/a").Allow())
+	assert.Equal(t, CircuitClosed, breakers.Health("https://api.example.com", "//spaces/c").State)
+}